@@ -0,0 +1,307 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"html/template"
+	"net/http"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminOption configures EnableAdmin
+type AdminOption func(*adminConfig)
+
+type adminConfig struct {
+	title    string
+	recent   int
+	settings any
+}
+
+// WithAdminTitle sets the title of the dashboard (default: the spec title, or "fluxo")
+func WithAdminTitle(title string) AdminOption {
+	return func(c *adminConfig) { c.title = title }
+}
+
+// WithAdminRecent sets how many recent requests the dashboard lists, 100 by default
+func WithAdminRecent(n int) AdminOption {
+	return func(c *adminConfig) { c.recent = max(n, 1) }
+}
+
+// WithAdminSettings shows settings on the dashboard besides the app's own, e.g. the Config given
+// to NewFromConfig. They are encoded as JSON, so leave secrets out.
+func WithAdminSettings(settings any) AdminOption {
+	return func(c *adminConfig) { c.settings = settings }
+}
+
+// AdminRequest is a request listed on the admin dashboard. The query string is left out, as it
+// may carry credentials.
+type AdminRequest struct {
+	Time      time.Time     `json:"time"`
+	Method    string        `json:"method"`
+	Path      string        `json:"path"`
+	Route     string        `json:"route,omitempty"` // empty when no route matched
+	Status    int           `json:"status"`
+	Duration  time.Duration `json:"duration"`
+	ClientIP  string        `json:"client_ip"`
+	RequestID string        `json:"request_id,omitempty"`
+}
+
+// recentRequests keeps the latest requests of an app in a ring
+type recentRequests struct {
+	mu     sync.Mutex
+	ring   []AdminRequest
+	next   int
+	full   bool
+	ignore string // path prefix of the dashboard, whose polling isn't listed
+}
+
+func (r *recentRequests) add(req AdminRequest) {
+	if req.Path == r.ignore || strings.HasPrefix(req.Path, r.ignore+"/") {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ring[r.next] = req
+	r.next = (r.next + 1) % len(r.ring)
+	r.full = r.full || r.next == 0
+}
+
+// list returns the requests, latest first
+func (r *recentRequests) list() []AdminRequest {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n := r.next
+	if r.full {
+		n = len(r.ring)
+	}
+	out := make([]AdminRequest, 0, n)
+	for i := 1; i <= n; i++ {
+		out = append(out, r.ring[(r.next-i+len(r.ring))%len(r.ring)])
+	}
+	return out
+}
+
+// AdminRoute is a row of the route table of the admin dashboard
+type AdminRoute struct {
+	Method      string   `json:"method"`
+	Path        string   `json:"path"`
+	Typed       bool     `json:"typed"`
+	Request     []string `json:"request,omitempty"`
+	Response    string   `json:"response,omitempty"`
+	ContentType string   `json:"content_type,omitempty"`
+	Middleware  []string `json:"middleware,omitempty"`
+}
+
+// adminRoutes returns the route table of the app and its mounted sub-apps, sorted by path and method
+func (a *App) adminRoutes() []AdminRoute {
+	out := []AdminRoute{}
+	for _, r := range a.routes {
+		row := AdminRoute{Method: r.Method, Path: r.Path, Typed: r.Typed, ContentType: r.ContentType, Middleware: r.Middleware}
+		for _, t := range r.Request {
+			row.Request = append(row.Request, t.String())
+		}
+		if r.Response != nil {
+			row.Response = r.Response.String()
+		}
+		out = append(out, row)
+	}
+	for _, m := range a.mounts {
+		for _, row := range m.app.adminRoutes() {
+			row.Path = m.prefix + row.Path
+			out = append(out, row)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Path != out[j].Path {
+			return out[i].Path < out[j].Path
+		}
+		return out[i].Method < out[j].Method
+	})
+	return out
+}
+
+// adminSettings returns the settings of the app shown on the dashboard
+func (a *App) adminSettings(extra any) gin.H {
+	maintenance := ""
+	if msg := a.life.maintenance.Load(); msg != nil {
+		maintenance = *msg
+	}
+	settings := gin.H{
+		"mode":        gin.Mode(),
+		"addr":        a.addr,
+		"docs":        a.docsPath,
+		"maintenance": maintenance,
+		"draining":    a.life.draining.Load(),
+		"drain_delay": a.life.drainDelay.String(),
+		"timeouts": gin.H{
+			"read":        a.timeouts.Read.String(),
+			"read_header": a.timeouts.ReadHeader.String(),
+			"write":       a.timeouts.Write.String(),
+			"idle":        a.timeouts.Idle.String(),
+		},
+		"build":      CurrentBuildInfo(),
+		"goroutines": runtime.NumGoroutine(),
+	}
+	if extra != nil {
+		settings["app"] = extra
+	}
+	return settings
+}
+
+// EnableAdmin serves an admin dashboard at path: the route table with the request and response
+// types of each route, its latency and error stats, the latest requests and the app's settings,
+// refreshed every few seconds. auth guards the page and its data, e.g. gin.BasicAuth; it is
+// required, as the dashboard exposes the app's internals. Like the Swagger UI, the page needs no
+// assets from the internet. Its data is served as JSON at path/state.
+//
+//	app.EnableAdmin("/admin", gin.BasicAuth(gin.Accounts{"ops": os.Getenv("ADMIN_PASSWORD")}))
+func (a *App) EnableAdmin(path string, auth gin.HandlerFunc, opts ...AdminOption) {
+	if auth == nil {
+		panic("fluxo: the admin dashboard needs auth middleware")
+	}
+	cfg := adminConfig{recent: 100}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.title == "" {
+		cfg.title = "fluxo"
+		if a.swagger != nil && a.swagger.spec.Info.Title != "" {
+			cfg.title = a.swagger.spec.Info.Title
+		}
+	}
+	path = "/" + strings.Trim(path, "/")
+	recent := &recentRequests{ring: make([]AdminRequest, cfg.recent), ignore: path}
+	a.stats.recent.Store(recent)
+
+	admin := a.TypedGroup(path, auth)
+	// The dashboard stays up in maintenance mode, which is when it is needed most
+	admin.GET("", Skip(Maintenance), func(ctx *gin.Context) {
+		ctx.Header("Content-Type", "text/html; charset=utf-8")
+		ctx.Header("Cache-Control", "no-store")
+		ctx.Status(http.StatusOK)
+		if err := adminTemplate.Execute(ctx.Writer, adminPage{Title: cfg.title, StateURL: path + "/state"}); err != nil {
+			ctx.Error(err)
+		}
+	})
+	admin.GET("/state", Skip(Maintenance), func(ctx *gin.Context) {
+		stats := a.Stats()
+		if stats == nil {
+			stats = []RouteStats{}
+		}
+		ctx.Header("Cache-Control", "no-store")
+		ctx.JSON(http.StatusOK, gin.H{
+			"routes":   a.adminRoutes(),
+			"stats":    stats,
+			"requests": recent.list(),
+			"settings": a.adminSettings(cfg.settings),
+		})
+	})
+}
+
+// adminPage holds the values rendered into adminTemplate
+type adminPage struct {
+	Title    string
+	StateURL string
+}
+
+var adminTemplate = template.Must(template.New("admin").Parse(`
+<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <title>{{.Title}} · admin</title>
+    <style>
+        body { margin: 0; font: 13px/1.4 system-ui, sans-serif; background: #fafafa; color: #222; }
+        header { padding: 12px 20px; background: #1b1f24; color: #fff; display: flex; justify-content: space-between; }
+        main { padding: 0 20px 20px; }
+        h2 { font-size: 15px; margin: 20px 0 8px; }
+        table { border-collapse: collapse; width: 100%; background: #fff; }
+        th, td { text-align: left; padding: 4px 8px; border-bottom: 1px solid #eee; white-space: nowrap; }
+        th { background: #f0f0f0; }
+        td.num { text-align: right; font-variant-numeric: tabular-nums; }
+        code, pre { font: 12px ui-monospace, monospace; }
+        pre { background: #fff; padding: 8px; border: 1px solid #eee; overflow: auto; }
+        .err { color: #b00020; }
+        .warn { color: #b36b00; }
+        .muted { color: #888; }
+    </style>
+</head>
+<body>
+    <header><strong>{{.Title}}</strong><span id="updated" class="muted"></span></header>
+    <main>
+        <h2>Routes</h2>
+        <table>
+            <thead><tr><th>Method</th><th>Path</th><th>Request</th><th>Response</th><th>Middleware</th>
+            <th>Requests</th><th>4xx</th><th>5xx</th><th>p50 ms</th><th>p95 ms</th><th>p99 ms</th><th>Max ms</th></tr></thead>
+            <tbody id="routes"></tbody>
+        </table>
+        <h2>Recent requests</h2>
+        <table>
+            <thead><tr><th>Time</th><th>Method</th><th>Path</th><th>Route</th><th>Status</th><th>ms</th><th>Client</th><th>Request ID</th></tr></thead>
+            <tbody id="requests"></tbody>
+        </table>
+        <h2>Settings</h2>
+        <pre id="settings"></pre>
+    </main>
+    <script>
+        const stateURL = {{.StateURL}};
+        const esc = s => String(s ?? "").replace(/[&<>"]/g, c => ({"&": "&amp;", "<": "&lt;", ">": "&gt;", '"': "&quot;"})[c]);
+        const ms = v => v.toFixed(v < 10 ? 2 : 0);
+        const statusClass = s => s >= 500 ? "err" : s >= 400 ? "warn" : "";
+        const row = cells => "<tr>" + cells.join("") + "</tr>";
+        const td = (v, cls) => '<td class="' + (cls || "") + '">' + v + "</td>";
+
+        async function refresh() {
+            let state;
+            try {
+                const res = await fetch(stateURL, {credentials: "same-origin"});
+                if (!res.ok) throw new Error(res.status);
+                state = await res.json();
+            } catch (e) {
+                document.getElementById("updated").textContent = "update failed: " + e.message;
+                return;
+            }
+            const stats = {};
+            for (const s of state.stats) stats[s.method + " " + s.path] = s;
+            document.getElementById("routes").innerHTML = state.routes.map(r => {
+                const s = stats[r.method + " " + r.path];
+                return row([
+                    td(esc(r.method)),
+                    td("<code>" + esc(r.path) + "</code>" + (r.typed ? "" : ' <span class="muted">untyped</span>')),
+                    td("<code>" + esc((r.request || []).join(", ")) + "</code>"),
+                    td("<code>" + esc(r.response) + "</code>"),
+                    td(esc((r.middleware || []).join(", "))),
+                    td(s ? s.count : 0, "num"),
+                    td(s ? s.client_errors : 0, "num" + (s && s.client_errors ? " warn" : "")),
+                    td(s ? s.server_errors : 0, "num" + (s && s.server_errors ? " err" : "")),
+                    td(s ? ms(s.p50_ms) : "", "num"),
+                    td(s ? ms(s.p95_ms) : "", "num"),
+                    td(s ? ms(s.p99_ms) : "", "num"),
+                    td(s ? ms(s.max_ms) : "", "num"),
+                ]);
+            }).join("");
+            document.getElementById("requests").innerHTML = state.requests.map(r => row([
+                td(esc(new Date(r.time).toLocaleTimeString())),
+                td(esc(r.method)),
+                td("<code>" + esc(r.path) + "</code>"),
+                td("<code>" + esc(r.route) + "</code>"),
+                td(r.status, statusClass(r.status)),
+                td(ms(r.duration / 1e6), "num"),
+                td(esc(r.client_ip)),
+                td("<code>" + esc(r.request_id) + "</code>"),
+            ])).join("");
+            document.getElementById("settings").textContent = JSON.stringify(state.settings, null, 2);
+            document.getElementById("updated").textContent = "updated " + new Date().toLocaleTimeString();
+        }
+        refresh();
+        setInterval(refresh, 3000);
+    </script>
+</body>
+</html>
+`))
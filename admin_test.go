@@ -0,0 +1,154 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type adminState struct {
+	Routes   []AdminRoute     `json:"routes"`
+	Stats    []map[string]any `json:"stats"`
+	Requests []AdminRequest   `json:"requests"`
+	Settings map[string]any   `json:"settings"`
+}
+
+func adminApp(opts ...AdminOption) *App {
+	gin.SetMode(gin.TestMode)
+	app := New(WithAddr(":9000"))
+	app.GET("/items/:id", Handle(statsGet))
+	app.GET("/health", func(ctx *gin.Context) { ctx.Status(http.StatusNoContent) })
+	app.EnableAdmin("/admin", gin.BasicAuth(gin.Accounts{"ops": "secret"}), opts...)
+	return app
+}
+
+func adminGet(app *App, path string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.SetBasicAuth("ops", "secret")
+	app.ServeHTTP(w, req)
+	return w
+}
+
+func adminStateOf(t *testing.T, app *App) adminState {
+	t.Helper()
+	w := adminGet(app, "/admin/state")
+	if w.Code != http.StatusOK {
+		t.Fatalf("state = %d: %s", w.Code, w.Body)
+	}
+	var state adminState
+	if err := json.Unmarshal(w.Body.Bytes(), &state); err != nil {
+		t.Fatal(err)
+	}
+	return state
+}
+
+func TestEnableAdmin_RequiresAuth(t *testing.T) {
+	app := adminApp()
+	for _, path := range []string{"/admin", "/admin/state"} {
+		if w := get(app, path); w.Code != http.StatusUnauthorized {
+			t.Errorf("%s without credentials = %d, want 401", path, w.Code)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("EnableAdmin without auth did not panic")
+		}
+	}()
+	New().EnableAdmin("/admin", nil)
+}
+
+func TestEnableAdmin_Page(t *testing.T) {
+	app := adminApp(WithAdminTitle("Orders API"))
+	w := adminGet(app, "/admin")
+	if w.Code != http.StatusOK || !strings.HasPrefix(w.Header().Get("Content-Type"), "text/html") {
+		t.Fatalf("page = %d %s", w.Code, w.Header().Get("Content-Type"))
+	}
+	body := w.Body.String()
+	if !strings.Contains(body, "<title>Orders API · admin</title>") || !strings.Contains(body, `"/admin/state"`) {
+		t.Errorf("unexpected page:\n%s", body)
+	}
+	if strings.Contains(body, "https://") {
+		t.Error("the page loads assets from the internet")
+	}
+}
+
+func TestEnableAdmin_State(t *testing.T) {
+	app := adminApp(WithAdminSettings(map[string]string{"region": "eu-west-1"}))
+	get(app, "/items/1")
+	get(app, "/items/broken?token=abc")
+	get(app, "/nowhere")
+	adminGet(app, "/admin/state")
+
+	state := adminStateOf(t, app)
+	var items *AdminRoute
+	for i, r := range state.Routes {
+		if r.Path == "/items/:id" {
+			items = &state.Routes[i]
+		}
+	}
+	if items == nil || !items.Typed || items.Response != "fluxo.statsRes" || len(items.Request) != 1 || items.Request[0] != "fluxo.statsReq" {
+		t.Fatalf("unexpected route table %+v", state.Routes)
+	}
+
+	if len(state.Requests) != 3 {
+		t.Fatalf("expected 3 recent requests without the dashboard's own, got %+v", state.Requests)
+	}
+	latest, broken, first := state.Requests[0], state.Requests[1], state.Requests[2]
+	if latest.Path != "/nowhere" || latest.Route != "" || latest.Status != http.StatusNotFound {
+		t.Errorf("unexpected latest request %+v", latest)
+	}
+	if broken.Path != "/items/broken" || broken.Route != "/items/:id" || broken.Status != http.StatusInternalServerError {
+		t.Errorf("unexpected request %+v", broken)
+	}
+	if first.Path != "/items/1" || first.ClientIP == "" {
+		t.Errorf("unexpected first request %+v", first)
+	}
+
+	found := false
+	for _, s := range state.Stats {
+		if s["path"] == "/items/:id" {
+			found = s["count"] == float64(2) && s["server_errors"] == float64(1)
+		}
+	}
+	if !found {
+		t.Errorf("unexpected stats %+v", state.Stats)
+	}
+
+	if state.Settings["addr"] != ":9000" || state.Settings["mode"] != gin.ReleaseMode {
+		t.Errorf("unexpected settings %+v", state.Settings)
+	}
+	if extra, _ := state.Settings["app"].(map[string]any); extra["region"] != "eu-west-1" {
+		t.Errorf("settings of the app missing: %+v", state.Settings)
+	}
+}
+
+func TestEnableAdmin_RecentRing(t *testing.T) {
+	app := adminApp(WithAdminRecent(2))
+	for _, path := range []string{"/items/1", "/items/2", "/items/3"} {
+		get(app, path)
+	}
+	state := adminStateOf(t, app)
+	if len(state.Requests) != 2 || state.Requests[0].Path != "/items/3" || state.Requests[1].Path != "/items/2" {
+		t.Errorf("expected the 2 latest requests, got %+v", state.Requests)
+	}
+}
+
+func TestEnableAdmin_InMaintenance(t *testing.T) {
+	app := adminApp()
+	app.SetMaintenance(true, "")
+	if w := get(app, "/health"); w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("health = %d in maintenance", w.Code)
+	}
+	state := adminStateOf(t, app)
+	if state.Settings["maintenance"] != DefaultMaintenanceMessage {
+		t.Errorf("maintenance not shown: %+v", state.Settings)
+	}
+}
@@ -3,9 +3,18 @@
 package fluxo
 
 import (
+	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
+	"log/slog"
+	"net"
 	"net/http"
+	"os"
 	"reflect"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -15,6 +24,30 @@ type App struct {
 	swagger       *SwaggerGenerator
 	enableSwagger bool
 	handlers      map[string]handlerInfo // Store handler type information
+	mounts        []mountedApp           // sub-apps whose routes are merged into the spec
+	parents       []*App                 // apps this app is mounted in
+	noRoute       []gin.HandlerFunc      // handlers of requests no route matches, see Mount
+	rootMounted   bool                   // whether a handler is mounted at "/"
+	stats         *routeStats            // per-route counters, see Stats
+	typed         []gin.HandlerFunc      // global middleware documented on every later route, see UseTyped
+	skips         map[string][]string    // "METHOD path" -> Skippable names the route opts out of
+	life          lifecycle
+	workers       *Workers
+	scheduler     *scheduler // scheduled tasks, see Schedule
+	routes        []Route    // every registered route, replayed to new OnRouteRegistered hooks
+	routeHooks    []func(Route) error
+	specHooks     []func(*OpenAPISpec)
+	errorHooks    []func(*Context, error) // see OnError
+	proxies       proxyTrust // hops whose forwarding headers RealIP believes, see SetTrustedProxies
+	validator     *Validator // rules and translations of the app's handlers, see WithValidator
+	logger        *slog.Logger
+	quietStart    bool   // see WithQuietStart
+	docsPath      string // where the Swagger UI is served, see EnableSwaggerUI
+	addr          string // see WithAddr
+	timeouts      Timeouts
+	noAutoHead    bool            // see WithoutAutoHead
+	heads         map[string]bool // paths whose GET route answers HEAD, see autoHead
+	tempDir       string          // see WithTempDir
 }
 
 type handlerInfo struct {
@@ -23,128 +56,369 @@ type handlerInfo struct {
 	reqTypes    []reflect.Type // Support multiple request types (e.g., from middleware)
 	resType     reflect.Type
 	contentType string
+	docs        []operationDoc // extra documentation applied to the generated operation
+	tag         string         // tag of the operation when the docs give it none, see Group.defaultTag
+	autoHead    bool           // the GET route answers HEAD too, see WithHeadOperations
 }
 
-func New() *App {
-	gin.SetMode(gin.ReleaseMode)
-	return &App{
-		router:        gin.New(),
+// AppOption configures the app and its gin engine, see New
+type AppOption func(*appConfig)
+
+type appConfig struct {
+	mode       string // gin mode, left alone when empty
+	engine     []func(*gin.Engine)
+	validator  *Validator
+	drainDelay time.Duration // see WithDrainDelay
+	fixPath    bool          // see WithRedirectFixedPath
+	foldCase   bool          // see WithCaseInsensitiveRouting
+	logger     *slog.Logger  // see WithLogger
+	quietStart bool          // see WithQuietStart
+	addr       string        // see WithAddr
+	timeouts   Timeouts      // see WithTimeouts
+	noAutoHead bool          // see WithoutAutoHead
+	tempDir    string        // see WithTempDir
+}
+
+// WithMode sets gin's mode (gin.DebugMode, gin.ReleaseMode or gin.TestMode). New uses
+// release mode by default. The mode is global to gin, not specific to the app.
+func WithMode(mode string) AppOption {
+	return func(c *appConfig) {
+		c.mode = mode
+	}
+}
+
+// WithValidator validates the app's requests with v instead of the default validator. Give each
+// app its own NewValidator to keep their rules and translations apart, or one to share them.
+func WithValidator(v *Validator) AppOption {
+	return func(c *appConfig) {
+		c.validator = v
+	}
+}
+
+// WithRedirectTrailingSlash sets whether /foo/ redirects to /foo when only the latter is
+// routed, and the other way around (gin's default is true)
+func WithRedirectTrailingSlash(enabled bool) AppOption {
+	return func(c *appConfig) {
+		c.engine = append(c.engine, func(e *gin.Engine) {
+			e.RedirectTrailingSlash = enabled
+		})
+	}
+}
+
+// WithHandleMethodNotAllowed sets whether a path routed for other methods answers 405 rather
+// than 404 (gin's default is false)
+func WithHandleMethodNotAllowed(enabled bool) AppOption {
+	return func(c *appConfig) {
+		c.engine = append(c.engine, func(e *gin.Engine) {
+			e.HandleMethodNotAllowed = enabled
+		})
+	}
+}
+
+// WithMaxMultipartMemory sets how many bytes of a multipart form are held in memory before
+// files spill to disk (gin's default is 32 MiB)
+func WithMaxMultipartMemory(bytes int64) AppOption {
+	return func(c *appConfig) {
+		c.engine = append(c.engine, func(e *gin.Engine) {
+			e.MaxMultipartMemory = bytes
+		})
+	}
+}
+
+// New returns an app on a new gin engine, in release mode unless WithMode says otherwise
+func New(opts ...AppOption) *App {
+	cfg := appConfig{mode: gin.ReleaseMode}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	gin.SetMode(cfg.mode)
+	return newApp(gin.New(), cfg)
+}
+
+// NewWithEngine returns an app on an existing gin engine, e.g. one from gin.Default() or with
+// custom settings. Middleware already on the engine runs before fluxo's; routes already on it
+// are served but not documented. The gin mode is only changed by WithMode.
+func NewWithEngine(engine *gin.Engine, opts ...AppOption) *App {
+	var cfg appConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.mode != "" {
+		gin.SetMode(cfg.mode)
+	}
+	return newApp(engine, cfg)
+}
+
+func newApp(engine *gin.Engine, cfg appConfig) *App {
+	for _, configure := range cfg.engine {
+		configure(engine)
+	}
+	a := &App{
+		router:        engine,
 		enableSwagger: false,
 		handlers:      make(map[string]handlerInfo),
+		stats:         &routeStats{},
+		skips:         make(map[string][]string),
+		validator:     cfg.validator,
+		logger:        cfg.logger,
+		quietStart:    cfg.quietStart,
+		addr:          cfg.addr,
+		timeouts:      cfg.timeouts,
+		noAutoHead:    cfg.noAutoHead,
+		heads:         make(map[string]bool),
+		tempDir:       cfg.tempDir,
+	}
+	a.life.drainDelay = cfg.drainDelay
+	if cfg.fixPath || cfg.foldCase {
+		a.noRoute = append(a.noRoute, a.fixedPath(cfg.fixPath))
+		engine.NoRoute(a.noRoute...)
+	}
+	if a.validator == nil {
+		a.validator = defaultValidator
 	}
+	if a.logger == nil {
+		a.logger = slog.Default()
+	}
+	a.router.Use(a.prepare, a.stats.record)
+	return a
 }
 
 func (a *App) GET(path string, handlers ...gin.HandlerFunc) {
-	// Check if this is a fluxo.Handle wrapper and extract type info if swagger is enabled
-	if a.enableSwagger && len(handlers) > 0 {
-		// We look at all handlers to find the one that was wrapped with fluxo.Handle
-		for _, h := range handlers {
-			a.captureHandlerInfo("GET", path, h)
-		}
-	}
+	// Record type info of fluxo.Handle wrappers for docs and introspection
+	a.captureRoute("GET", path, handlers)
 	a.router.GET(path, handlers...)
+	a.autoHead(path, handlers, func(handlers ...gin.HandlerFunc) {
+		a.router.HEAD(path, handlers...)
+	})
 }
 
 // POST registers a POST handler
 func (a *App) POST(path string, handlers ...gin.HandlerFunc) {
-	// Check if this is a fluxo.Handle wrapper and extract type info if swagger is enabled
-	if a.enableSwagger && len(handlers) > 0 {
-		for _, h := range handlers {
-			a.captureHandlerInfo("POST", path, h)
-		}
-	}
+	// Record type info of fluxo.Handle wrappers for docs and introspection
+	a.captureRoute("POST", path, handlers)
 	a.router.POST(path, handlers...)
 }
 
 // PUT registers a PUT handler
 func (a *App) PUT(path string, handlers ...gin.HandlerFunc) {
-	// Check if this is a fluxo.Handle wrapper and extract type info if swagger is enabled
-	if a.enableSwagger && len(handlers) > 0 {
-		for _, h := range handlers {
-			a.captureHandlerInfo("PUT", path, h)
-		}
-	}
+	// Record type info of fluxo.Handle wrappers for docs and introspection
+	a.captureRoute("PUT", path, handlers)
 	a.router.PUT(path, handlers...)
 }
 
 // DELETE registers a DELETE handler
 func (a *App) DELETE(path string, handlers ...gin.HandlerFunc) {
-	// Check if this is a fluxo.Handle wrapper and extract type info if swagger is enabled
-	if a.enableSwagger && len(handlers) > 0 {
-		for _, h := range handlers {
-			a.captureHandlerInfo("DELETE", path, h)
-		}
-	}
+	// Record type info of fluxo.Handle wrappers for docs and introspection
+	a.captureRoute("DELETE", path, handlers)
 	a.router.DELETE(path, handlers...)
 }
 
 // PATCH registers a PATCH handler
 func (a *App) PATCH(path string, handlers ...gin.HandlerFunc) {
-	// Check if this is a fluxo.Handle wrapper and extract type info if swagger is enabled
-	if a.enableSwagger && len(handlers) > 0 {
-		for _, h := range handlers {
-			a.captureHandlerInfo("PATCH", path, h)
-		}
-	}
+	// Record type info of fluxo.Handle wrappers for docs and introspection
+	a.captureRoute("PATCH", path, handlers)
 	a.router.PATCH(path, handlers...)
 }
 
+// HEAD registers a HEAD handler
+func (a *App) HEAD(path string, handlers ...gin.HandlerFunc) {
+	a.checkHead(path)
+	// Record type info of fluxo.Handle wrappers for docs and introspection
+	a.captureRoute("HEAD", path, handlers)
+	a.router.HEAD(path, handlers...)
+}
+
+// OPTIONS registers an OPTIONS handler
+func (a *App) OPTIONS(path string, handlers ...gin.HandlerFunc) {
+	// Record type info of fluxo.Handle wrappers for docs and introspection
+	a.captureRoute("OPTIONS", path, handlers)
+	a.router.OPTIONS(path, handlers...)
+}
+
+// Validator returns the validator of the app's requests, to register rules and translations on
+func (a *App) Validator() *Validator {
+	return a.validator
+}
+
 // Use adds middleware to the gin router
 func (a *App) Use(middleware ...gin.HandlerFunc) {
 	a.router.Use(middleware...)
 }
 
-// Group creates a route group with optional middleware
+// Group creates a plain gin route group with optional middleware. Its routes are served but
+// left out of the spec; use TypedGroup for groups of documented fluxo handlers.
 func (a *App) Group(path string, middleware ...gin.HandlerFunc) *gin.RouterGroup {
 	return a.router.Group(path, middleware...)
 }
 
+// TypedGroup creates a route group whose routes are documented like the app's own. Typed
+// middleware passed here is documented on every route of the group, and its operations are
+// tagged after the group's path ("/api/todos" gives "todos") unless WithTag or a controller's
+// Tag says otherwise.
+func (a *App) TypedGroup(path string, middleware ...gin.HandlerFunc) *Group {
+	return newGroup(a, a.router.Group(path, middleware...), a.typed, middleware)
+}
+
+// Start serves the app on addr until Shutdown is called, which makes it return nil. An empty
+// addr is the one set with WithAddr, else :$PORT, else :8080, as with gin's Run.
 func (a *App) Start(addr string) error {
-	return a.router.Run(addr)
+	server := &http.Server{Addr: addr, Handler: a.router.Handler()}
+	return a.serve(server, false)
+}
+
+// StartTLS is like Start but serves HTTPS with config, which must hold the server's certificate.
+// An empty addr defaults like Start's, not to :https. See MutualTLSConfig to require client
+// certificates.
+func (a *App) StartTLS(addr string, config *tls.Config) error {
+	server := &http.Server{Addr: addr, Handler: a.router.Handler(), TLSConfig: config}
+	return a.serve(server, true)
+}
+
+// serve runs the start hooks, then serves until the server is shut down
+func (a *App) serve(server *http.Server, useTLS bool) error {
+	if server.Addr == "" {
+		server.Addr = a.addr
+	}
+	server.ReadTimeout, server.ReadHeaderTimeout = a.timeouts.Read, a.timeouts.ReadHeader
+	server.WriteTimeout, server.IdleTimeout = a.timeouts.Write, a.timeouts.Idle
+	a.life.mu.Lock()
+	a.life.server = server
+	a.life.draining.Store(false)
+	hooks := append([]func(context.Context) error(nil), a.life.onStart...)
+	a.life.mu.Unlock()
+
+	if err := a.validateSpecOnStart(); err != nil {
+		return err
+	}
+	if err := a.createTempDir(); err != nil {
+		return err
+	}
+	for _, fn := range hooks {
+		if err := fn(context.Background()); err != nil {
+			return err
+		}
+	}
+
+	if server.Addr == "" {
+		server.Addr = defaultAddr()
+	}
+	ln, err := net.Listen("tcp", server.Addr)
+	if err != nil {
+		return err
+	}
+	a.reportStartup(ln.Addr(), useTLS)
+	if useTLS {
+		err = server.ServeTLS(ln, "", "")
+	} else {
+		err = server.Serve(ln)
+	}
+	if !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// defaultAddr is the address gin's Run listens on when given none: :$PORT, else :8080
+func defaultAddr() string {
+	if port := os.Getenv("PORT"); port != "" {
+		return ":" + port
+	}
+	return ":8080"
 }
 
 func (a *App) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	a.router.ServeHTTP(w, r)
 }
 
+const appKey = "fluxo.app"
+
+// prepare runs first on every route: it makes the app reachable from handlers, applies Skip
+// options and turns requests away in maintenance mode. Once the request is served, it drops its
+// OnDisconnect hooks.
+func (a *App) prepare(ctx *gin.Context) {
+	ctx.Set(appKey, a)
+	a.applySkips(ctx)
+	a.checkAvailable(ctx)
+	ctx.Next()
+	releaseDisconnect(ctx)
+}
+
+// appFrom returns the app serving the request
+func appFrom(ctx *gin.Context) (*App, bool) {
+	v, _ := ctx.Get(appKey)
+	a, ok := v.(*App)
+	return a, ok
+}
+
+// captureRoute passes a route to the OnRouteRegistered hooks, then records the global typed
+// middleware and the handlers of the route
+func (a *App) captureRoute(method, path string, handlers []gin.HandlerFunc) {
+	a.registerRoute(method, path, a.router.Handlers, handlers)
+	a.captureMiddlewareRoute(method, path, a.typed, handlers)
+	a.captureFormats(method, path, slices.Concat(a.router.Handlers, handlers))
+	a.captureExamples(method, path, handlers)
+}
+
 // captureHandlerInfo attempts to extract type information from fluxo.Handle wrappers
 func (a *App) captureHandlerInfo(method, path string, handler gin.HandlerFunc) {
-	reqType, resType, ct, ok := lookupHandlerTypes(handler)
-	if !ok {
+	if !recordHandlerInfo(a.handlers, method, path, handler) {
 		return
 	}
+
+	// New routes change the spec, so drop any cached copy
+	a.invalidateSpec()
+}
+
+// recordHandlerInfo stores the types and docs of a fluxo handler in handlers, reporting whether it was one
+func recordHandlerInfo(handlers map[string]handlerInfo, method, path string, handler gin.HandlerFunc) bool {
+	reqType, resType, ct, typed := lookupHandlerTypes(handler)
+	doc, documented := lookupOperationDoc(handler)
+	if !typed && !documented {
+		return false
+	}
 	handlerKey := fmt.Sprintf("%s:%s", method, path)
 
-	info := a.handlers[handlerKey]
+	info := handlers[handlerKey]
 	info.method = method
 	info.path = path
 
-	// Add request type if not already present
-	found := false
-	for _, rt := range info.reqTypes {
-		if rt == reqType {
-			found = true
-			break
+	if typed {
+		// Add request type if not already present
+		found := false
+		for _, rt := range info.reqTypes {
+			if rt == reqType {
+				found = true
+				break
+			}
+		}
+		if !found {
+			info.reqTypes = append(info.reqTypes, reqType)
 		}
-	}
-	if !found {
-		info.reqTypes = append(info.reqTypes, reqType)
-	}
 
-	if resType != nil {
-		info.resType = resType
+		if resType != nil {
+			info.resType = resType
+		}
+		if ct != "" {
+			info.contentType = ct
+		}
 	}
-	if ct != "" {
-		info.contentType = ct
+	if documented {
+		info.docs = append(info.docs, doc)
 	}
-	a.handlers[handlerKey] = info
+	handlers[handlerKey] = info
+	return true
 }
 
 // WithSwagger enables swagger documentation generation and serves it at /docs
 func (a *App) WithSwagger(title, version string, opts ...SwaggerOption) *App {
+	return a.withSwagger("/docs", title, version, opts...)
+}
+
+func (a *App) withSwagger(uiPath, title, version string, opts ...SwaggerOption) *App {
 	a.enableSwagger = true
 	a.swagger = NewSwaggerGenerator(title, version, opts...)
-	a.EnableSwaggerUI("/docs")
+	a.swagger.specHooks = append(a.swagger.specHooks, a.specHooks...)
+	a.EnableSwaggerUI(uiPath)
 	return a
 }
 
@@ -156,15 +430,14 @@ func (a *App) EnableSwaggerUI(path string) {
 
 	// Serve the OpenAPI JSON spec (only if not already registered)
 	if _, exists := a.handlers["GET:/openapi.json"]; !exists {
-		a.GET("/openapi.json", func(c *gin.Context) {
-			// Generate the OpenAPI spec dynamically when requested
-			spec := a.swagger.Generate(a.handlers)
-			c.JSON(http.StatusOK, spec)
-		})
+		a.GET("/openapi.json", a.swagger.specHandler(a.specHandlers))
 	}
 
-	// Serve the Swagger UI
+	// Serve the Swagger UI and its embedded assets
 	if path != "/openapi.json" {
+		a.docsPath = path
 		a.GET(path, a.swagger.UIHandler())
+		a.GET(strings.TrimSuffix(path, "/")+"/assets/*filepath", a.swagger.AssetsHandler())
+		a.swagger.warnMissingUI(a.Logger())
 	}
 }
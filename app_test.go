@@ -1,6 +1,8 @@
 package fluxo
 
 import (
+	"context"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -87,6 +89,38 @@ func TestApp_Start(t *testing.T) {
 	time.Sleep(100 * time.Millisecond)
 }
 
+func TestApp_StartDefaultAddr(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, port, _ := net.SplitHostPort(ln.Addr().String())
+	ln.Close()
+	t.Setenv("PORT", port)
+
+	app := New(WithMode(gin.TestMode), WithQuietStart())
+	app.GET("/ping", func(c *gin.Context) { c.String(http.StatusOK, "pong") })
+	done := make(chan error, 1)
+	go func() { done <- app.Start("") }()
+	time.Sleep(100 * time.Millisecond)
+	res, err := http.Get("http://127.0.0.1:" + port + "/ping")
+	if err != nil {
+		t.Fatalf("expected the app on $PORT: %v", err)
+	}
+	res.Body.Close()
+	if err := app.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("PORT", "")
+	if got := defaultAddr(); got != ":8080" {
+		t.Errorf("expected :8080 without $PORT, got %s", got)
+	}
+}
+
 func TestSwagger_UI_Disabled_Panic(t *testing.T) {
 	app := New()
 	defer func() {
@@ -116,3 +150,66 @@ func TestApp_DuplicateReqType(t *testing.T) {
 	// This should trigger the "found" logic in captureHandlerInfo
 	// We don't need to check anything specific, just ensure it doesn't panic and covers the lines
 }
+
+func TestNew_Options(t *testing.T) {
+	defer gin.SetMode(gin.TestMode)
+	app := New(
+		WithMode(gin.TestMode),
+		WithRedirectTrailingSlash(false),
+		WithHandleMethodNotAllowed(true),
+		WithMaxMultipartMemory(1<<10),
+	)
+	if gin.Mode() != gin.TestMode {
+		t.Fatalf("expected test mode, got %s", gin.Mode())
+	}
+	if app.router.MaxMultipartMemory != 1<<10 {
+		t.Fatalf("expected the multipart memory to be set, got %d", app.router.MaxMultipartMemory)
+	}
+	app.GET("/items", Handle(func(ctx *Context, req struct{}) (gin.H, error) { return gin.H{"ok": true}, nil }))
+
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/items", nil))
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("expected 405, got %d", w.Code)
+	}
+	w = httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/items/", nil))
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected no trailing slash redirect, got %d", w.Code)
+	}
+
+	New()
+	if gin.Mode() != gin.ReleaseMode {
+		t.Fatalf("expected release mode by default, got %s", gin.Mode())
+	}
+}
+
+func TestNewWithEngine(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(func(c *gin.Context) {
+		c.Header("X-Engine", "custom")
+	})
+	app := NewWithEngine(engine, WithHandleMethodNotAllowed(true)).WithSwagger("Test", "1.0.0")
+	if gin.Mode() != gin.TestMode {
+		t.Fatalf("expected the mode to be left alone, got %s", gin.Mode())
+	}
+	app.GET("/ping", Handle(func(ctx *Context, req struct{}) (gin.H, error) {
+		_, ok := appFrom(ctx.Context)
+		return gin.H{"app": ok}, nil
+	}))
+
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ping", nil))
+	if w.Code != http.StatusOK || w.Header().Get("X-Engine") != "custom" || w.Body.String() != `{"app":true}` {
+		t.Fatalf("unexpected response %d %v %s", w.Code, w.Header(), w.Body.String())
+	}
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/ping", nil))
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("expected the option to apply to the engine, got %d", w.Code)
+	}
+	if _, ok := app.handlers["GET:/ping"]; !ok {
+		t.Fatal("expected the route to be documented")
+	}
+}
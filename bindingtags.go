@@ -0,0 +1,135 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"reflect"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/gin-gonic/gin/codec/json"
+	"github.com/go-playground/validator/v10"
+)
+
+// multipartMemory is what gin keeps in memory of a multipart body before spilling to disk
+const multipartMemory = 32 << 20
+
+// WithBindingTags makes the validator check `binding` tags too, see UseBindingTags
+func WithBindingTags() ValidatorOption {
+	return func(v *Validator) {
+		v.UseBindingTags()
+	}
+}
+
+// UseBindingTags makes the default validator check `binding` tags alongside `validate` ones, so
+// types written for gin keep their rules without retagging:
+//
+//	type CreateTodoReq struct {
+//		Title string `json:"title" binding:"required,max=100"`
+//	}
+func UseBindingTags() {
+	defaultValidator.UseBindingTags()
+}
+
+// UseBindingTags makes the validator check `binding` tags alongside `validate` ones. Their failures
+// are reported like any other validation error, translated and with RegisterRule rules available,
+// instead of by gin while binding. Rules registered on Engine don't apply to them.
+func (v *Validator) UseBindingTags() {
+	v.bindingTags.Store(true)
+}
+
+// newBindingEngine returns the engine checking `binding` tags, configured like gin's
+func newBindingEngine() *validator.Validate {
+	engine := validator.New()
+	engine.SetTagName("binding")
+	return engine
+}
+
+// engines returns the engines of the validator, which share its rules and locales
+func (v *Validator) engines() []*validator.Validate {
+	return []*validator.Validate{v.validate, v.binding}
+}
+
+// checksBindingTags reports whether the request's validator checks `binding` tags, which gin's
+// validator then doesn't while binding
+func checksBindingTags(ctx *gin.Context) bool {
+	return requestValidator(ctx).bindingTags.Load()
+}
+
+// fieldRules returns the validation rules of field, those of a `binding` tag included, as the
+// docs describe them
+func fieldRules(field reflect.StructField) string {
+	rules, bound := field.Tag.Get("validate"), field.Tag.Get("binding")
+	if rules == "" || bound == "" {
+		return rules + bound
+	}
+	return rules + "," + bound
+}
+
+// decodeJSON decodes body into obj like gin's JSON binding, without checking `binding` tags
+func decodeJSON(body []byte, obj any) error {
+	dec := json.API.NewDecoder(bytes.NewReader(body))
+	if binding.EnableDecoderUseNumber {
+		dec.UseNumber()
+	}
+	if binding.EnableDecoderDisallowUnknownFields {
+		dec.DisallowUnknownFields()
+	}
+	return dec.Decode(obj)
+}
+
+// bindForm binds a form or multipart body into obj like ctx.ShouldBind, leaving `binding` tags
+// to the request's validator when it checks them
+func bindForm(ctx *gin.Context, obj any) error {
+	if !checksBindingTags(ctx) {
+		return ctx.ShouldBind(obj)
+	}
+	req := ctx.Request
+	if err := req.ParseMultipartForm(multipartMemory); err != nil && err != http.ErrNotMultipart {
+		return err
+	}
+	if err := binding.MapFormWithTag(obj, req.Form, "form"); err != nil {
+		return err
+	}
+	if req.MultipartForm != nil {
+		setFormFiles(reflect.ValueOf(obj), req.MultipartForm.File)
+	}
+	return nil
+}
+
+var (
+	fileHeaderPtrType   = reflect.TypeOf((*multipart.FileHeader)(nil))
+	fileHeaderSliceType = reflect.TypeOf([]*multipart.FileHeader(nil))
+)
+
+// setFormFiles sets the *multipart.FileHeader fields of the struct v points to, as gin does
+func setFormFiles(v reflect.Value, files map[string][]*multipart.FileHeader) {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return
+	}
+	for _, field := range reflect.VisibleFields(v.Type()) {
+		name := tagName(field, "form")
+		if name == "" || !field.IsExported() || len(files[name]) == 0 {
+			continue
+		}
+		dst, err := v.FieldByIndexErr(field.Index)
+		if err != nil {
+			continue
+		}
+		switch field.Type {
+		case fileHeaderPtrType:
+			dst.Set(reflect.ValueOf(files[name][0]))
+		case fileHeaderSliceType:
+			dst.Set(reflect.ValueOf(files[name]))
+		}
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ginTodoReq struct {
+	Title  string `json:"title" form:"title" binding:"required,max=10"`
+	Note   string `json:"note" form:"note" validate:"max=5"`
+	Owner  string `json:"owner" form:"owner" binding:"owner_exists"`
+	Status string `form:"status" binding:"required"`
+}
+
+func bindingTagsApp(v *Validator) *App {
+	gin.SetMode(gin.TestMode)
+	app := New(WithValidator(v)).WithSwagger("API", "1.0")
+	app.POST("/todos", Handle(func(ctx *Context, req ginTodoReq) (ginTodoReq, error) {
+		return req, nil
+	}))
+	return app
+}
+
+func post(app *App, path, contentType, body, lang string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Accept-Language", lang)
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, req)
+	return w
+}
+
+func TestBindingTags(t *testing.T) {
+	v := NewValidator(WithLocales("fr"), WithBindingTags())
+	v.RegisterRule("owner_exists", func(ctx context.Context, value any, _ string) (bool, error) {
+		return value != "ghost", nil
+	})
+	app := bindingTagsApp(v)
+
+	w := post(app, "/todos?status=open", "application/json", `{"title":"Ship","owner":"me"}`, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("valid request = %d: %s", w.Code, w.Body)
+	}
+
+	cases := []struct {
+		name, path, contentType, body, lang string
+		want                                []string
+	}{
+		{"json", "/todos", "application/json", `{"title":"A very long title","note":"too long","owner":"ghost"}`, "",
+			[]string{"Validation failed", "Title must be at most 10 characters", "Note must be at most 5 characters", "Owner failed validation for owner_exists", "Status is required"}},
+		{"form", "/todos", gin.MIMEPOSTForm, "note=ok", "",
+			[]string{"Validation failed", "Title is required", "Status is required"}},
+		{"translated", "/todos?status=open", "application/json", `{}`, "fr",
+			[]string{"Title est un champ obligatoire"}},
+	}
+	for _, tc := range cases {
+		w := post(app, tc.path, tc.contentType, tc.body, tc.lang)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d: %s", tc.name, w.Code, w.Body)
+		}
+		for _, want := range tc.want {
+			if !strings.Contains(w.Body.String(), want) {
+				t.Errorf("%s: want %q in %s", tc.name, want, w.Body)
+			}
+		}
+	}
+
+	spec, err := app.swagger.parsedSpec(app.specHandlers())
+	if err != nil {
+		t.Fatal(err)
+	}
+	op := spec.Paths["/todos"].POST
+	for _, p := range op.Parameters {
+		if p.Name == "status" && !p.Required {
+			t.Error("status not documented as required")
+		}
+	}
+}
+
+func TestBindingTags_Off(t *testing.T) {
+	// Without the option gin checks binding tags while binding, as it always has
+	app := New(WithValidator(NewValidator()))
+	app.POST("/todos", Handle(func(ctx *Context, req struct {
+		Title string `json:"title" binding:"required"`
+	}) (string, error) {
+		return req.Title, nil
+	}))
+	w := post(app, "/todos", "application/json", `{}`, "")
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "JSON binding failed") {
+		t.Errorf("status = %d: %s", w.Code, w.Body)
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// bodyTypes returns the content types a request body of type t may have, JSON first, or nil when
+// no field of t is read from the body. Form tags also take form bodies, uploads need multipart,
+// and fields without a source tag are JSON fields by name.
+func bodyTypes(t reflect.Type, patch bool) []string {
+	if patch {
+		return []string{gin.MIMEJSON, MIMEMergePatch, MIMEJSONPatch}
+	}
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return []string{gin.MIMEJSON}
+	}
+
+	var json, form, files bool
+	for _, field := range reflect.VisibleFields(t) {
+		if !field.IsExported() || (field.Anonymous && field.Tag == "") {
+			continue
+		}
+		if isUploadField(field.Type) {
+			files = true
+			continue
+		}
+		tagged := tagName(field, "form") != ""
+		form = form || tagged
+		for _, tag := range []string{"json", "uri", "header", "fluxo"} {
+			tagged = tagged || field.Tag.Get(tag) != ""
+		}
+		json = json || tagName(field, "json") != "" || !tagged
+	}
+	switch {
+	case files:
+		return []string{gin.MIMEMultipartPOSTForm}
+	case form && json:
+		return []string{gin.MIMEJSON, gin.MIMEPOSTForm, gin.MIMEMultipartPOSTForm}
+	case form:
+		return []string{gin.MIMEPOSTForm, gin.MIMEMultipartPOSTForm}
+	case json:
+		return []string{gin.MIMEJSON}
+	}
+	return nil
+}
+
+// acceptsBody reports whether the planned type takes a body of contentType. A missing content
+// type is taken for JSON, as are JSON-based types such as application/vnd.api+json.
+func (p *bindingPlan) acceptsBody(contentType string) bool {
+	if contentType == "" || (strings.HasSuffix(contentType, "+json") && contentType != MIMEMergePatch && contentType != MIMEJSONPatch) {
+		contentType = gin.MIMEJSON
+	}
+	for _, ct := range p.body {
+		if ct == contentType {
+			return true
+		}
+	}
+	return false
+}
+
+// unsupportedBody returns the message of the 415 response to a body of contentType
+func (p *bindingPlan) unsupportedBody(contentType string) string {
+	expected := p.body[0]
+	if len(p.body) > 1 {
+		expected = "one of " + strings.Join(p.body, ", ")
+	}
+	if contentType == "" {
+		return "Missing Content-Type, expected " + expected
+	}
+	return fmt.Sprintf("Unsupported Content-Type %q, expected %s", contentType, expected)
+}
+
+// bindingFailed returns the message of the 400 response to a body that failed to bind
+func bindingFailed(kind string, err error) string {
+	switch {
+	case errors.Is(err, io.EOF):
+		return kind + " binding failed: the body is empty"
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return kind + " binding failed: the body ends unexpectedly"
+	}
+	return fmt.Sprintf("%s binding failed: %v", kind, err)
+}
@@ -0,0 +1,108 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type bodyJSONReq struct {
+	ID    string `uri:"id"`
+	Title string `json:"title"`
+}
+
+type bodyFormReq struct {
+	Title string `form:"title"`
+}
+
+type bodyUploadReq struct {
+	Avatar UploadedFile `form:"avatar"`
+}
+
+type bodyNoneReq struct {
+	ID    string `uri:"id"`
+	Token string `header:"X-Token"`
+}
+
+func TestBodyTypes(t *testing.T) {
+	cases := []struct {
+		t    reflect.Type
+		want []string
+	}{
+		{reflect.TypeOf(bodyJSONReq{}), []string{gin.MIMEJSON}},
+		{reflect.TypeOf(bodyFormReq{}), []string{gin.MIMEPOSTForm, gin.MIMEMultipartPOSTForm}},
+		{reflect.TypeOf(queryListReq{}), []string{gin.MIMEPOSTForm, gin.MIMEMultipartPOSTForm}},
+		{reflect.TypeOf(ginTodoReq{}), []string{gin.MIMEJSON, gin.MIMEPOSTForm, gin.MIMEMultipartPOSTForm}},
+		{reflect.TypeOf(bodyUploadReq{}), []string{gin.MIMEMultipartPOSTForm}},
+		{reflect.TypeOf(struct{ Title string }{}), []string{gin.MIMEJSON}},
+		{reflect.TypeOf([]bodyJSONReq{}), []string{gin.MIMEJSON}},
+		{reflect.TypeOf(bodyNoneReq{}), nil},
+	}
+	for _, tc := range cases {
+		if got := bodyTypes(tc.t, false); !slices.Equal(got, tc.want) {
+			t.Errorf("%v: %v, want %v", tc.t, got, tc.want)
+		}
+	}
+}
+
+func TestUnsupportedBody(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := New()
+	app.POST("/todos/:id", Handle(func(ctx *Context, req bodyJSONReq) (string, error) { return req.Title, nil }))
+	app.POST("/forms", Handle(func(ctx *Context, req bodyFormReq) (string, error) { return req.Title, nil }))
+	app.POST("/pings/:id", Handle(func(ctx *Context, req bodyNoneReq) (string, error) { return req.ID, nil }))
+
+	cases := []struct {
+		path, contentType, body string
+		status                  int
+		want                    string
+	}{
+		{"/todos/1", "application/json", `{"title":"a"}`, http.StatusOK, "a"},
+		{"/todos/1", "application/vnd.api+json", `{"title":"a"}`, http.StatusOK, "a"},
+		{"/todos/1", "", `{"title":"a"}`, http.StatusOK, "a"},
+		{"/todos/1", "text/plain", "a", http.StatusUnsupportedMediaType, `Unsupported Content-Type \"text/plain\", expected application/json`},
+		{"/todos/1", gin.MIMEPOSTForm, "title=a", http.StatusUnsupportedMediaType, "expected application/json"},
+		{"/forms", "application/json", `{"title":"a"}`, http.StatusUnsupportedMediaType, "expected one of application/x-www-form-urlencoded, multipart/form-data"},
+		{"/forms", "", "title=a", http.StatusUnsupportedMediaType, "Missing Content-Type, expected one of"},
+		{"/forms", gin.MIMEPOSTForm, "title=a", http.StatusOK, "a"},
+		// Types reading nothing from the body ignore it
+		{"/pings/7", "text/plain", "ping", http.StatusOK, "7"},
+	}
+	for _, tc := range cases {
+		req := httptest.NewRequest(http.MethodPost, tc.path, strings.NewReader(tc.body))
+		if tc.contentType != "" {
+			req.Header.Set("Content-Type", tc.contentType)
+		}
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+		if w.Code != tc.status || !strings.Contains(w.Body.String(), tc.want) {
+			t.Errorf("%s %q: %d %s", tc.path, tc.contentType, w.Code, w.Body)
+		}
+	}
+}
+
+func TestBindingFailed(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := New()
+	app.POST("/todos/:id", Handle(func(ctx *Context, req bodyJSONReq) (string, error) { return req.Title, nil }))
+
+	for body, want := range map[string]string{
+		`{"title":`:   "JSON binding failed: the body ends unexpectedly",
+		`{"title":1}`: "JSON binding failed: ",
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/todos/1", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+		if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), want) {
+			t.Errorf("%s: %d %s", body, w.Code, w.Body)
+		}
+	}
+}
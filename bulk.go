@@ -0,0 +1,156 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultMaxBulkItems is the number of items HandleBulk accepts per request unless WithMaxBulkItems says otherwise
+const DefaultMaxBulkItems = 1000
+
+// BulkItem is the outcome of one item of a bulk request
+type BulkItem[T any] struct {
+	Index  int    `json:"index"` // position of the item in the request
+	Status int    `json:"status"`
+	Data   *T     `json:"data,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// BulkResult is the 207 Multi-Status response of a bulk endpoint, with the outcome of every item
+// in request order
+type BulkResult[T any] struct {
+	Succeeded int           `json:"succeeded"`
+	Failed    int           `json:"failed"`
+	Items     []BulkItem[T] `json:"items"`
+}
+
+// StatusCode is 207 Multi-Status: each item carries its own status
+func (r BulkResult[T]) StatusCode() int {
+	return http.StatusMultiStatus
+}
+
+// BulkOption configures HandleBulk
+type BulkOption func(*bulkConfig)
+
+type bulkConfig struct {
+	maxItems int
+}
+
+// WithMaxBulkItems sets how many items a request may carry; larger ones get 413
+func WithMaxBulkItems(n int) BulkOption {
+	return func(c *bulkConfig) {
+		c.maxItems = n
+	}
+}
+
+// HandleBulk creates a handler for bulk create and update endpoints. The body is a JSON array
+// of items; each one is decoded and validated on its own and passed to fn, and the response
+// reports every item's outcome, so that one bad item doesn't fail the others:
+//
+//	app.POST("/todos/bulk", fluxo.HandleBulk(func(ctx *fluxo.Context, req CreateTodoReq) (Todo, error) {
+//		return store.Create(ctx, req)
+//	}))
+//
+// Items failing to decode or validate get 400, those fn fails the status of its HTTPError or 500.
+// Succeeded items get 200, or the status of a StatusCoder result.
+func HandleBulk[Item any, Res any](fn HandlerFunc[Item, Res], opts ...BulkOption) gin.HandlerFunc {
+	cfg := bulkConfig{maxItems: DefaultMaxBulkItems}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	itemType := reflect.TypeOf((*Item)(nil)).Elem()
+	registerOptionalTypes(itemType)
+	plan := planFor(itemType)
+	mods := sanitizerFor(itemType)
+
+	handler := func(ctx *gin.Context) {
+		var raws []json.RawMessage
+		if err := bindJSON(ctx, &raws); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": bindingFailed("JSON", err)})
+			return
+		}
+		if len(raws) > cfg.maxItems {
+			ctx.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("A bulk request carries at most %d items", cfg.maxItems)})
+			return
+		}
+
+		result := BulkResult[Res]{Items: make([]BulkItem[Res], len(raws))}
+		for i, raw := range raws {
+			// The items left would only fail on the cancelled context
+			if clientGone(ctx) {
+				dropResponse(ctx)
+				return
+			}
+			item := bulkItem(ctx, fn, plan, mods, raw)
+			item.Index = i
+			if item.Error == "" {
+				result.Succeeded++
+			} else {
+				result.Failed++
+			}
+			result.Items[i] = item
+		}
+		renderJSON(ctx, result.StatusCode(), result)
+	}
+	registerHandlerTypes(handler, reflect.TypeOf([]Item(nil)), reflect.TypeOf(BulkResult[Res]{}), "application/json")
+	return handler
+}
+
+// bulkItem decodes, validates and handles one item of a bulk request
+func bulkItem[Item any, Res any](ctx *gin.Context, fn HandlerFunc[Item, Res], plan *bindingPlan, mods sanitizer, raw json.RawMessage) BulkItem[Res] {
+	var req Item
+	if err := decodeBulkItem(ctx, raw, &req); err != nil {
+		return BulkItem[Res]{Status: http.StatusBadRequest, Error: fmt.Sprintf("JSON binding failed: %v", err)}
+	}
+	if err := plan.check(ctx, &req); err != nil {
+		return BulkItem[Res]{Status: http.StatusBadRequest, Error: fmt.Sprintf("Validation failed: %v", err)}
+	}
+	if mods != nil {
+		mods(reflect.ValueOf(&req).Elem())
+	}
+	if plan.validates {
+		if err := validateStruct(ctx, &req); err != nil {
+			if cause, ok := ruleCause(err); ok {
+				return bulkError[Res](cause)
+			}
+			return BulkItem[Res]{Status: http.StatusBadRequest, Error: fmt.Sprintf("Validation failed: %v", err)}
+		}
+	}
+
+	res, err := fn(&Context{Context: ctx}, req)
+	if err != nil {
+		return bulkError[Res](err)
+	}
+	status := http.StatusOK
+	if sc, ok := any(res).(StatusCoder); ok {
+		status = sc.StatusCode()
+	}
+	return BulkItem[Res]{Status: status, Data: &res}
+}
+
+// decodeBulkItem decodes an item like bindJSON decodes a body, without gin's validation
+func decodeBulkItem(ctx *gin.Context, raw json.RawMessage, obj any) error {
+	if ctx.GetBool(strictJSONKey) {
+		return decodeStrict(raw, obj)
+	}
+	if codec, ok := requestCodec(ctx); ok {
+		return codec.Unmarshal(raw, obj)
+	}
+	return json.Unmarshal(raw, obj)
+}
+
+// bulkError reports a failed item with the status of an HTTPError, or 500 without exposing err
+func bulkError[T any](err error) BulkItem[T] {
+	var httpErr HTTPError
+	if errors.As(err, &httpErr) {
+		return BulkItem[T]{Status: httpErr.Status, Error: httpErr.Message}
+	}
+	return BulkItem[T]{Status: http.StatusInternalServerError, Error: http.StatusText(http.StatusInternalServerError)}
+}
@@ -0,0 +1,113 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type bulkTodoReq struct {
+	Title    string `json:"title" validate:"required"`
+	Priority int    `json:"priority" binding:"max=5"`
+}
+
+type bulkTodo struct {
+	ID    int    `json:"id"`
+	Title string `json:"title"`
+}
+
+func bulkApp(opts ...BulkOption) *App {
+	gin.SetMode(gin.TestMode)
+	app := New().WithSwagger("Bulk", "1.0")
+	next := 0
+	app.POST("/todos/bulk", HandleBulk(func(ctx *Context, req bulkTodoReq) (bulkTodo, error) {
+		switch req.Title {
+		case "taken":
+			return bulkTodo{}, Conflict("title taken")
+		case "boom":
+			return bulkTodo{}, errors.New("database password leaked")
+		}
+		next++
+		return bulkTodo{ID: next, Title: req.Title}, nil
+	}, opts...))
+	return app
+}
+
+func postBulk(app *App, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("POST", "/todos/bulk", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, req)
+	return w
+}
+
+func TestHandleBulk(t *testing.T) {
+	app := bulkApp()
+	w := postBulk(app, `[{"title":"a"},{"title":""},{"title":"taken"},{"title":1},{"title":"b","priority":9},{"title":"boom"},{"title":"c"}]`)
+	if w.Code != http.StatusMultiStatus {
+		t.Fatalf("status = %d: %s", w.Code, w.Body)
+	}
+	var res BulkResult[bulkTodo]
+	if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+		t.Fatal(err)
+	}
+	if res.Succeeded != 2 || res.Failed != 5 || len(res.Items) != 7 {
+		t.Fatalf("result = %+v", res)
+	}
+	wantStatus := []int{200, 400, 409, 400, 400, 500, 200}
+	for i, item := range res.Items {
+		if item.Index != i || item.Status != wantStatus[i] {
+			t.Errorf("item %d = %+v, want status %d", i, item, wantStatus[i])
+		}
+	}
+	if res.Items[0].Data == nil || res.Items[0].Data.ID != 1 || res.Items[6].Data.ID != 2 {
+		t.Errorf("created items = %+v, %+v", res.Items[0].Data, res.Items[6].Data)
+	}
+	if res.Items[2].Error != "title taken" || res.Items[1].Data != nil {
+		t.Errorf("failed items = %+v, %+v", res.Items[1], res.Items[2])
+	}
+	if strings.Contains(w.Body.String(), "password") {
+		t.Error("internal error text leaked into the response")
+	}
+}
+
+func TestHandleBulk_Limits(t *testing.T) {
+	app := bulkApp(WithMaxBulkItems(2))
+	if w := postBulk(app, `[{"title":"a"},{"title":"b"},{"title":"c"}]`); w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("too many items: status = %d, want 413", w.Code)
+	}
+	if w := postBulk(app, `{"title":"a"}`); w.Code != http.StatusBadRequest {
+		t.Errorf("object body: status = %d, want 400", w.Code)
+	}
+	if w := postBulk(app, `[]`); w.Code != http.StatusMultiStatus || !strings.Contains(w.Body.String(), `"items":[]`) {
+		t.Errorf("empty batch: status = %d, body = %s", w.Code, w.Body)
+	}
+}
+
+func TestHandleBulk_Swagger(t *testing.T) {
+	app := bulkApp()
+	spec, err := app.swagger.parsedSpec(app.specHandlers())
+	if err != nil {
+		t.Fatal(err)
+	}
+	op := spec.Paths["/todos/bulk"].POST
+	if op == nil {
+		t.Fatal("missing operation")
+	}
+	if _, ok := op.Responses["207"]; !ok {
+		t.Errorf("responses = %v, want 207", op.Responses)
+	}
+	if op.RequestBody == nil || op.RequestBody.Content["application/json"].Schema.Type != "array" {
+		t.Errorf("request body = %+v, want an array", op.RequestBody)
+	}
+	if err := app.Validate(); err != nil {
+		t.Errorf("Validate: %v", err)
+	}
+}
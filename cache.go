@@ -0,0 +1,316 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SurrogateKeyHeader lists the cache tags of a response, so that CDNs supporting surrogate keys
+// can purge it along with the ResponseCache
+const SurrogateKeyHeader = "Surrogate-Key"
+
+const cacheTagsKey = "fluxo.cache_tags"
+
+// CacheOption configures a ResponseCache
+type CacheOption func(*ResponseCache)
+
+// WithCacheSize sets how many responses are kept, 1000 by default; the oldest go first
+func WithCacheSize(n int) CacheOption {
+	return func(c *ResponseCache) {
+		c.size = n
+	}
+}
+
+// WithCacheBodyLimit sets the largest response body cached, 1MB by default
+func WithCacheBodyLimit(n int) CacheOption {
+	return func(c *ResponseCache) {
+		c.bodyLimit = n
+	}
+}
+
+// WithCacheKey adds to the key of cached responses, which is the URL and the Accept
+// and Accept-Language headers. Responses that depend on the client, like lists of a user's
+// items, must add it:
+//
+//	fluxo.WithCacheKey(func(ctx *gin.Context) string { return ctx.GetString("user_id") })
+func WithCacheKey(fn func(ctx *gin.Context) string) CacheOption {
+	return func(c *ResponseCache) {
+		c.key = fn
+	}
+}
+
+// ResponseCache keeps the successful GET responses of the routes after its middleware for a
+// while, serving them again without running the handlers. Responses are tagged with surrogate
+// keys, like the IDs of the resources they show, so that mutations purge exactly the responses
+// they make stale:
+//
+//	cache := fluxo.NewResponseCache(time.Minute)
+//	app.Use(cache.Middleware())
+//	app.GET("/todos/:id", fluxo.Handle(func(ctx *fluxo.Context, req GetTodo) (Todo, error) {
+//		fluxo.AddCacheTags(ctx, "todo:"+req.ID)
+//		return store.Get(ctx, req.ID)
+//	}))
+//	app.GET("/todos", fluxo.CacheTags("todos"), fluxo.Handle(listTodos))
+//	app.PUT("/todos/:id", fluxo.Handle(func(ctx *fluxo.Context, req UpdateTodo) (Todo, error) {
+//		defer cache.Purge("todo:"+req.ID, "todos")
+//		return store.Update(ctx, req)
+//	}))
+type ResponseCache struct {
+	ttl       time.Duration
+	size      int
+	bodyLimit int
+	key       func(ctx *gin.Context) string
+
+	mu      sync.Mutex
+	entries map[string]*cachedResponse
+	order   []string                       // keys, oldest first
+	tagged  map[string]map[string]struct{} // tag -> keys
+	purges  uint64                         // purges so far, so responses built before one aren't stored
+}
+
+type cachedResponse struct {
+	status  int
+	header  http.Header
+	body    []byte
+	tags    []string
+	stored  time.Time
+	expires time.Time
+}
+
+// NewResponseCache returns a cache keeping responses for ttl
+func NewResponseCache(ttl time.Duration, opts ...CacheOption) *ResponseCache {
+	c := &ResponseCache{
+		ttl:       ttl,
+		size:      1000,
+		bodyLimit: 1 << 20,
+		entries:   make(map[string]*cachedResponse),
+		tagged:    make(map[string]map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// CacheTags is a route option tagging the route's responses, see ResponseCache
+func CacheTags(tags ...string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		AddCacheTags(ctx, tags...)
+	}
+}
+
+// AddCacheTags tags the response of the request, for handlers whose tags depend on what they
+// return. The tags are also sent in the Surrogate-Key header.
+func AddCacheTags(ctx context.Context, tags ...string) {
+	c := ginContextOf(ctx)
+	if c == nil {
+		return
+	}
+	existing := c.GetStringSlice(cacheTagsKey)
+	for _, tag := range tags {
+		if tag != "" && !slices.Contains(existing, tag) {
+			existing = append(existing, tag)
+		}
+	}
+	c.Set(cacheTagsKey, existing)
+	c.Header(SurrogateKeyHeader, strings.Join(existing, " "))
+}
+
+// Middleware serves cached responses to GET and HEAD requests, and caches the 200 responses of
+// GET requests to the routes after it unless they say Cache-Control: no-store, no-cache or
+// private. Responses carry an X-Cache header, HIT or MISS, and cached ones their Age.
+func (c *ResponseCache) Middleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if ctx.Request.Method != http.MethodGet && ctx.Request.Method != http.MethodHead {
+			return
+		}
+		key := c.cacheKey(ctx)
+		if res, ok := c.lookup(key); ok {
+			c.serve(ctx, res)
+			return
+		}
+
+		purges := c.purgeCount()
+		ctx.Header("X-Cache", "MISS")
+		w := &copyWriter{ResponseWriter: ctx.Writer, limit: c.bodyLimit}
+		ctx.Writer = w
+		ctx.Next()
+		ctx.Writer = w.ResponseWriter
+
+		// HEAD requests are answered from GET responses, but have no body to cache
+		if ctx.Request.Method != http.MethodGet || w.Status() != http.StatusOK || w.truncated || ctx.FullPath() == "" || !storable(w.Header()) {
+			return
+		}
+		header := w.Header().Clone()
+		header.Del("X-Cache")
+		now := time.Now()
+		c.store(key, purges, &cachedResponse{
+			status:  w.Status(),
+			header:  header,
+			body:    append([]byte(nil), w.body.Bytes()...),
+			tags:    ctx.GetStringSlice(cacheTagsKey),
+			stored:  now,
+			expires: now.Add(c.ttl),
+		})
+	}
+}
+
+// storable reports whether the Cache-Control header of a response lets shared caches keep it
+func storable(h http.Header) bool {
+	for _, directive := range strings.Split(strings.ToLower(h.Get("Cache-Control")), ",") {
+		switch strings.TrimSpace(directive) {
+		case "no-store", "private", "no-cache":
+			return false
+		}
+	}
+	return true
+}
+
+func (c *ResponseCache) cacheKey(ctx *gin.Context) string {
+	key := ctx.Request.URL.RequestURI() + "\x00" + ctx.GetHeader("Accept") + "\x00" + ctx.GetHeader("Accept-Language")
+	if c.key != nil {
+		key += "\x00" + c.key(ctx)
+	}
+	return key
+}
+
+func (c *ResponseCache) serve(ctx *gin.Context, res *cachedResponse) {
+	h := ctx.Writer.Header()
+	for name, values := range res.header {
+		h[name] = slices.Clone(values)
+	}
+	h.Set("X-Cache", "HIT")
+	h.Set("Age", strconv.Itoa(int(time.Since(res.stored).Seconds())))
+	ctx.Status(res.status)
+	if ctx.Request.Method == http.MethodHead {
+		ctx.Writer.WriteHeaderNow()
+	} else {
+		_, _ = ctx.Writer.Write(res.body)
+	}
+	ctx.Abort()
+}
+
+func (c *ResponseCache) lookup(key string) (*cachedResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	res, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(res.expires) {
+		c.remove(key)
+		return nil, false
+	}
+	return res, true
+}
+
+func (c *ResponseCache) purgeCount() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.purges
+}
+
+// store caches res under key, unless the cache was purged since the response was built
+func (c *ResponseCache) store(key string, purges uint64, res *cachedResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.purges != purges {
+		return
+	}
+	c.remove(key)
+	for len(c.order) >= c.size && len(c.order) > 0 {
+		c.remove(c.order[0])
+	}
+	c.entries[key] = res
+	c.order = append(c.order, key)
+	for _, tag := range res.tags {
+		if c.tagged[tag] == nil {
+			c.tagged[tag] = make(map[string]struct{})
+		}
+		c.tagged[tag][key] = struct{}{}
+	}
+}
+
+// remove drops the response cached under key; c.mu must be held
+func (c *ResponseCache) remove(key string) {
+	res, ok := c.entries[key]
+	if !ok {
+		return
+	}
+	delete(c.entries, key)
+	if i := slices.Index(c.order, key); i >= 0 {
+		c.order = slices.Delete(c.order, i, i+1)
+	}
+	for _, tag := range res.tags {
+		delete(c.tagged[tag], key)
+		if len(c.tagged[tag]) == 0 {
+			delete(c.tagged, tag)
+		}
+	}
+}
+
+// Purge drops the cached responses tagged with any of tags, returning how many
+func (c *ResponseCache) Purge(tags ...string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.purges++
+	purged := 0
+	for _, tag := range tags {
+		for key := range c.tagged[tag] {
+			c.remove(key)
+			purged++
+		}
+	}
+	return purged
+}
+
+// PurgeAll drops every cached response
+func (c *ResponseCache) PurgeAll() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.purges++
+	c.entries = make(map[string]*cachedResponse)
+	c.tagged = make(map[string]map[string]struct{})
+	c.order = nil
+}
+
+// PurgeRequest is the body of the purge endpoint: the tags to purge, or all
+type PurgeRequest struct {
+	Tags []string `json:"tags"`
+	All  bool     `json:"all"`
+}
+
+// PurgeResponse says how many responses the purge endpoint dropped, -1 for all of them
+type PurgeResponse struct {
+	Purged int `json:"purged"`
+}
+
+// EnablePurge serves an endpoint purging the cache at POST path, for deploy scripts and other
+// services. auth guards it and must not be nil:
+//
+//	cache.EnablePurge(app, "/internal/cache/purge", requireAdmin)
+//	// POST {"tags": ["todo:42"]} -> {"purged": 2}
+func (c *ResponseCache) EnablePurge(a *App, path string, auth gin.HandlerFunc) {
+	if auth == nil {
+		panic("fluxo: EnablePurge needs an auth middleware")
+	}
+	a.POST(path, auth, Handle(func(ctx *Context, req PurgeRequest) (PurgeResponse, error) {
+		if req.All {
+			c.PurgeAll()
+			return PurgeResponse{Purged: -1}, nil
+		}
+		if len(req.Tags) == 0 {
+			return PurgeResponse{}, BadRequest("tags or all is required")
+		}
+		return PurgeResponse{Purged: c.Purge(req.Tags...)}, nil
+	}))
+}
@@ -0,0 +1,140 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type cacheTodoReq struct {
+	ID string `uri:"id"`
+}
+
+func cacheApp(opts ...CacheOption) (*App, *ResponseCache, map[string]int) {
+	gin.SetMode(gin.TestMode)
+	app := New()
+	cache := NewResponseCache(time.Minute, opts...)
+	calls := map[string]int{}
+	app.Use(cache.Middleware())
+	app.GET("/todos/:id", Handle(func(ctx *Context, req cacheTodoReq) (gin.H, error) {
+		calls[req.ID]++
+		AddCacheTags(ctx, "todo:"+req.ID)
+		return gin.H{"id": req.ID, "version": calls[req.ID]}, nil
+	}))
+	app.GET("/todos", CacheTags("todos"), func(ctx *gin.Context) {
+		calls["list"]++
+		ctx.JSON(http.StatusOK, gin.H{"version": calls["list"]})
+	})
+	app.GET("/private", func(ctx *gin.Context) {
+		calls["private"]++
+		ctx.Header("Cache-Control", "private")
+		ctx.JSON(http.StatusOK, gin.H{})
+	})
+	return app, cache, calls
+}
+
+func TestResponseCache(t *testing.T) {
+	app, cache, calls := cacheApp()
+
+	w := get(app, "/todos/1")
+	if w.Header().Get("X-Cache") != "MISS" || w.Header().Get(SurrogateKeyHeader) != "todo:1" {
+		t.Fatalf("expected a tagged miss, got %v", w.Header())
+	}
+	w = get(app, "/todos/1")
+	if w.Header().Get("X-Cache") != "HIT" || !strings.Contains(w.Body.String(), `"version":1`) || calls["1"] != 1 {
+		t.Fatalf("expected a hit, got %v %s after %d calls", w.Header(), w.Body, calls["1"])
+	}
+	get(app, "/todos/2")
+	get(app, "/todos")
+	get(app, "/private")
+	get(app, "/private")
+	if calls["private"] != 2 {
+		t.Errorf("private responses were cached")
+	}
+
+	if n := cache.Purge("todo:1"); n != 1 {
+		t.Errorf("expected one response purged, got %d", n)
+	}
+	if w = get(app, "/todos/1"); !strings.Contains(w.Body.String(), `"version":2`) {
+		t.Errorf("expected the purged response rebuilt, got %s", w.Body)
+	}
+	if get(app, "/todos/2"); calls["2"] != 1 {
+		t.Errorf("other responses were purged too")
+	}
+	if get(app, "/todos"); calls["list"] != 1 {
+		t.Errorf("the list was purged too")
+	}
+	cache.PurgeAll()
+	if get(app, "/todos"); calls["list"] != 2 {
+		t.Errorf("expected every response purged")
+	}
+}
+
+func TestResponseCache_Head(t *testing.T) {
+	app, _, calls := cacheApp()
+
+	// The GET route answers HEAD, without a body to cache
+	app.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodHead, "/todos/1", nil))
+	if w := get(app, "/todos/1"); w.Body.Len() == 0 || w.Header().Get("X-Cache") != "MISS" || calls["1"] != 2 {
+		t.Fatalf("a HEAD response was served to a GET: %v %q", w.Header(), w.Body)
+	}
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest(http.MethodHead, "/todos/1", nil))
+	if w.Header().Get("X-Cache") != "HIT" || w.Body.Len() != 0 || calls["1"] != 2 {
+		t.Errorf("expected HEAD answered from the cached GET, got %v %q", w.Header(), w.Body)
+	}
+}
+
+func TestResponseCache_Size(t *testing.T) {
+	app, _, calls := cacheApp(WithCacheSize(1))
+	get(app, "/todos/1")
+	get(app, "/todos/2")
+	get(app, "/todos/1")
+	if calls["1"] != 2 {
+		t.Errorf("expected the oldest response evicted, got %d calls", calls["1"])
+	}
+}
+
+func TestResponseCache_EnablePurge(t *testing.T) {
+	app, cache, calls := cacheApp()
+	cache.EnablePurge(app, "/internal/purge", func(ctx *gin.Context) {
+		if ctx.GetHeader("Authorization") != "Bearer admin" {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		}
+	})
+	get(app, "/todos/1")
+
+	purge := func(body, auth string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/internal/purge", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", auth)
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+		return w
+	}
+	if w := purge(`{"tags":["todo:1"]}`, ""); w.Code != http.StatusUnauthorized {
+		t.Errorf("expected the endpoint guarded, got %d", w.Code)
+	}
+	if w := purge(`{"tags":["todo:1"]}`, "Bearer admin"); w.Code != http.StatusOK || w.Body.String() != `{"purged":1}` {
+		t.Errorf("expected one response purged, got %d %s", w.Code, w.Body)
+	}
+	if w := purge(`{}`, "Bearer admin"); w.Code != http.StatusBadRequest {
+		t.Errorf("expected tags required, got %d", w.Code)
+	}
+	if get(app, "/todos/1"); calls["1"] != 2 {
+		t.Errorf("expected the response rebuilt after the purge")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic without auth")
+		}
+	}()
+	cache.EnablePurge(app, "/open", nil)
+}
@@ -0,0 +1,435 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"bytes"
+	"context"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxErrorBody bounds how much of an error response Call reads for its message
+const maxErrorBody = 64 << 10
+
+// Client calls the routes of another service with Call
+type Client struct {
+	baseURL string
+	http    *http.Client
+	retries int
+	backoff time.Duration
+	timeout time.Duration
+}
+
+// ClientOption configures a Client
+type ClientOption func(*Client)
+
+// NewClient returns a client for the service at baseURL, e.g. http://todos.internal
+func NewClient(baseURL string, opts ...ClientOption) *Client {
+	c := &Client{baseURL: strings.TrimSuffix(baseURL, "/"), http: &http.Client{}, backoff: 100 * time.Millisecond}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// WithHTTPClient sends calls with hc instead of a default http.Client
+func WithHTTPClient(hc *http.Client) ClientOption {
+	return func(c *Client) {
+		c.http = hc
+	}
+}
+
+// WithRetries retries idempotent calls up to n times on network errors and 429, 502, 503 and
+// 504 responses, waiting backoff, then twice as long each time, or what Retry-After asks for
+func WithRetries(n int, backoff time.Duration) ClientOption {
+	return func(c *Client) {
+		c.retries, c.backoff = n, backoff
+	}
+}
+
+// WithCallTimeout bounds each call, retries included, to d
+func WithCallTimeout(d time.Duration) ClientOption {
+	return func(c *Client) {
+		c.timeout = d
+	}
+}
+
+// Call sends req to the route method path of the service c points at and decodes its response,
+// mirroring the handler on the other side:
+//
+//	todo, err := fluxo.Call[CreateTodoReq, Todo](ctx, todos, "POST", "/todos", CreateTodoReq{Title: "Ship"})
+//
+// req is encoded the way Handle binds it: fields tagged `uri` fill the path parameters, `form`
+// the query and `header` the headers, and the rest is the JSON body of methods having one.
+// Zero query and header values are left out, so default= tags apply, and so are request metadata
+// fields tagged `fluxo`. Error responses are returned as an HTTPError with their status and
+// message. When ctx is a request's context, its ID, trace headers and deadline are propagated
+// like HTTPClient does.
+func Call[Req any, Res any](ctx context.Context, c *Client, method, path string, req Req) (Res, error) {
+	var res Res
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+	call, err := encodeCall(method, path, req)
+	if err != nil {
+		return res, err
+	}
+	hc := *c.http
+	hc.Transport = PropagatingTransport(ctx, c.http.Transport)
+
+	retries := 0
+	if idempotent(method) {
+		retries = c.retries
+	}
+	wait := c.backoff
+	for attempt := 0; ; attempt++ {
+		r, err := call.request(ctx, c.baseURL)
+		if err != nil {
+			return res, err
+		}
+		resp, err := hc.Do(r)
+		if attempt < retries && retryable(resp, err) {
+			delay := wait
+			if resp != nil {
+				if after, ok := retryAfter(resp); ok {
+					delay = after
+				}
+				_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxErrorBody))
+				_ = resp.Body.Close()
+			}
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				return res, fmt.Errorf("fluxo: %s %s: %w", method, path, ctx.Err())
+			}
+			wait *= 2
+			continue
+		}
+		if err != nil {
+			return res, fmt.Errorf("fluxo: %s %s: %w", method, path, err)
+		}
+		defer resp.Body.Close()
+		return decodeCall[Res](resp)
+	}
+}
+
+// encodedCall is a request encoded once and sent on every attempt
+type encodedCall struct {
+	method string
+	path   string
+	query  url.Values
+	header http.Header
+	body   []byte
+}
+
+func (e encodedCall) request(ctx context.Context, baseURL string) (*http.Request, error) {
+	target := baseURL + e.path
+	if len(e.query) > 0 {
+		target += "?" + e.query.Encode()
+	}
+	var body io.Reader
+	if e.body != nil {
+		body = bytes.NewReader(e.body)
+	}
+	r, err := http.NewRequestWithContext(ctx, e.method, target, body)
+	if err != nil {
+		return nil, fmt.Errorf("fluxo: %s %s: %w", e.method, e.path, err)
+	}
+	for name, values := range e.header {
+		r.Header[name] = values
+	}
+	if r.Header.Get("Accept") == "" {
+		r.Header.Set("Accept", "application/json")
+	}
+	if e.body != nil {
+		r.Header.Set("Content-Type", "application/json")
+	}
+	return r, nil
+}
+
+// encodeCall places the fields of req in the path, query, headers and body
+func encodeCall(method, path string, req any) (encodedCall, error) {
+	e := encodedCall{method: method, query: url.Values{}, header: http.Header{}}
+	params := map[string]string{}
+	v := reflect.ValueOf(req)
+	for v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+	var bound []string // JSON names of the fields bound from elsewhere than the body
+	hasBody := v.Kind() != reflect.Struct
+	if v.Kind() == reflect.Struct {
+		hasBody = encodeFields(v, params, e.query, e.header, &bound)
+	}
+
+	var missing []string
+	segments := strings.Split(path, "/")
+	for i, seg := range segments {
+		if seg == "" || (seg[0] != ':' && seg[0] != '*') {
+			continue
+		}
+		value, ok := params[seg[1:]]
+		if !ok {
+			missing = append(missing, seg[1:])
+			continue
+		}
+		segments[i] = escapePathParam(value, seg[0] == '*')
+	}
+	if len(missing) > 0 {
+		return e, fmt.Errorf("fluxo: %s %s: no uri field for path parameters %s", method, path, strings.Join(missing, ", "))
+	}
+	e.path = strings.Join(segments, "/")
+
+	if !hasBody || !methodHasBody(method) {
+		return e, nil
+	}
+	data, err := json.Marshal(req)
+	if err != nil {
+		return e, fmt.Errorf("fluxo: encoding %s %s: %w", method, path, err)
+	}
+	if len(bound) > 0 {
+		var fields map[string]json.RawMessage
+		if json.Unmarshal(data, &fields) == nil {
+			for _, name := range bound {
+				delete(fields, name)
+			}
+			data, _ = json.Marshal(fields)
+		}
+	}
+	e.body = data
+	return e, nil
+}
+
+// escapePathParam escapes the value of a path parameter. A catch-all one spans the rest of the
+// path: its slashes are kept, and the leading slash gin includes in its value is dropped.
+func escapePathParam(value string, wildcard bool) string {
+	if !wildcard {
+		return url.PathEscape(value)
+	}
+	parts := strings.Split(strings.TrimPrefix(value, "/"), "/")
+	for i, p := range parts {
+		parts[i] = url.PathEscape(p)
+	}
+	return strings.Join(parts, "/")
+}
+
+// encodeFields adds the uri, form and header fields of struct v to params, query and header,
+// recording their JSON names in bound, and reports whether some field belongs to the body
+func encodeFields(v reflect.Value, params map[string]string, query url.Values, header http.Header, bound *[]string) bool {
+	body := false
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if embedded, ok := embeddedStruct(field); ok && field.Tag.Get("uri") == "" && field.Tag.Get("header") == "" {
+			fv := v.Field(i)
+			if fv.Kind() == reflect.Ptr {
+				if fv.IsNil() {
+					continue
+				}
+				fv = fv.Elem()
+			}
+			if fv.Type() == embedded && encodeFields(fv, params, query, header, bound) {
+				body = true
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		uri, form, head := tagName(field, "uri"), tagName(field, "form"), tagName(field, "header")
+		switch {
+		case uri != "":
+			if values := paramValues(v.Field(i)); len(values) > 0 {
+				params[uri] = values[0]
+			}
+		case head != "":
+			for _, s := range paramValues(v.Field(i)) {
+				header.Add(head, s)
+			}
+		case form != "" && nestedQueryType(field.Type):
+			encodeNestedQuery(v.Field(i), form, query)
+		case form != "":
+			for _, s := range paramValues(v.Field(i)) {
+				query.Add(form, s)
+			}
+		case tagName(field, "fluxo") != "":
+			// Request metadata is filled in by the other side
+		default:
+			if field.Tag.Get("json") != "-" {
+				body = true
+			}
+			continue
+		}
+		if jsonTag := field.Tag.Get("json"); jsonTag == "" {
+			*bound = append(*bound, field.Name)
+		}
+	}
+	return body
+}
+
+// encodeNestedQuery adds the fields of a struct or the entries of a map to query as
+// name[field]=value, the way Handle binds them
+func encodeNestedQuery(v reflect.Value, name string, query url.Values) {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Map {
+		for _, key := range v.MapKeys() {
+			for _, s := range paramValues(v.MapIndex(key)) {
+				query.Add(name+"["+key.String()+"]", s)
+			}
+		}
+		return
+	}
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if _, ok := embeddedStruct(field); ok {
+			// Promoted fields are keyed like the struct's own
+			encodeNestedQuery(v.Field(i), name, query)
+			continue
+		}
+		if !field.IsExported() || field.Tag.Get("form") == "-" {
+			continue
+		}
+		sub := tagName(field, "form")
+		if sub != "" && nestedQueryType(field.Type) {
+			encodeNestedQuery(v.Field(i), name+"["+sub+"]", query)
+			continue
+		}
+		if sub == "" {
+			sub = field.Name
+		}
+		for _, s := range paramValues(v.Field(i)) {
+			query.Add(name+"["+sub+"]", s)
+		}
+	}
+}
+
+// paramValues formats a field for the path, query or headers, with none for zero values
+func paramValues(v reflect.Value) []string {
+	if !v.IsValid() || v.IsZero() {
+		return nil
+	}
+	if z, ok := v.Interface().(interface{ IsZero() bool }); ok && z.IsZero() {
+		return nil
+	}
+	switch x := v.Interface().(type) {
+	case time.Time:
+		return []string{x.Format(time.RFC3339Nano)}
+	case encoding.TextMarshaler:
+		if text, err := x.MarshalText(); err == nil {
+			return []string{string(text)}
+		}
+	case json.Marshaler:
+		if data, err := x.MarshalJSON(); err == nil {
+			var s string
+			if json.Unmarshal(data, &s) == nil {
+				return []string{s}
+			}
+			return []string{string(data)}
+		}
+	}
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return paramValues(v.Elem())
+	case reflect.Slice, reflect.Array:
+		var values []string
+		for i := 0; i < v.Len(); i++ {
+			values = append(values, paramValues(v.Index(i))...)
+		}
+		return values
+	}
+	return []string{fmt.Sprint(v.Interface())}
+}
+
+// decodeCall decodes a success response into Res, and an error response into an HTTPError
+func decodeCall[Res any](resp *http.Response) (Res, error) {
+	var res Res
+	if resp.StatusCode >= 300 {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		var body struct {
+			Message string `json:"message"`
+			Error   any    `json:"error"`
+		}
+		msg := http.StatusText(resp.StatusCode)
+		if json.Unmarshal(data, &body) == nil {
+			if s, ok := body.Error.(string); ok && s != "" {
+				msg = s
+			}
+			if body.Message != "" {
+				msg = body.Message
+			}
+		}
+		return res, NewHTTPError(resp.StatusCode, msg)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return res, fmt.Errorf("fluxo: reading the response: %w", err)
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return res, nil
+	}
+	if err := json.Unmarshal(data, &res); err != nil {
+		return res, fmt.Errorf("fluxo: decoding the response: %w", err)
+	}
+	return res, nil
+}
+
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+func methodHasBody(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodDelete, http.MethodOptions:
+		return false
+	}
+	return true
+}
+
+// retryable reports whether a call failed in a way worth another attempt
+func retryable(resp *http.Response, err error) bool {
+	if err != nil {
+		// A call past its deadline or canceled won't succeed on retry
+		return !errors.Is(err, context.DeadlineExceeded) && !errors.Is(err, context.Canceled)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// retryAfter reads a Retry-After header given in seconds or as a date
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	h := resp.Header.Get("Retry-After")
+	if h == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(h); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if at, err := http.ParseTime(h); err == nil {
+		return max(time.Until(at), 0), true
+	}
+	return 0, false
+}
@@ -0,0 +1,149 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type callTodoReq struct {
+	ListID int    `uri:"list"`
+	Notify bool   `form:"notify"`
+	Tenant string `header:"X-Tenant"`
+	Title  string `json:"title" validate:"required"`
+}
+
+type callTodo struct {
+	ID     int    `json:"id"`
+	ListID int    `json:"list_id"`
+	Title  string `json:"title"`
+	Notify bool   `json:"notify"`
+	Tenant string `json:"tenant"`
+}
+
+func callServer(t *testing.T) (*httptest.Server, *atomic.Int32) {
+	gin.SetMode(gin.TestMode)
+	var flaky atomic.Int32
+	app := New()
+	app.Use(StrictJSON(true))
+	app.POST("/lists/:list/todos", Handle(func(ctx *Context, req callTodoReq) (callTodo, error) {
+		return callTodo{ID: 7, ListID: req.ListID, Title: req.Title, Notify: req.Notify, Tenant: req.Tenant}, nil
+	}))
+	app.GET("/todos/:id", Handle(func(ctx *Context, req struct {
+		ID int `uri:"id"`
+	}) (callTodo, error) {
+		return callTodo{}, NotFound("no such todo")
+	}))
+	app.GET("/flaky", Handle(func(ctx *Context, _ struct{}) (string, error) {
+		if flaky.Add(1) < 3 {
+			return "", NewHTTPError(http.StatusServiceUnavailable, "try again")
+		}
+		return "ok", nil
+	}))
+	app.GET("/slow", Handle(func(ctx *Context, _ struct{}) (string, error) {
+		select {
+		case <-time.After(2 * time.Second):
+		case <-ctx.Request.Context().Done():
+		}
+		return "late", nil
+	}))
+	srv := httptest.NewServer(app)
+	t.Cleanup(srv.Close)
+	return srv, &flaky
+}
+
+func TestCall(t *testing.T) {
+	srv, _ := callServer(t)
+	c := NewClient(srv.URL)
+	todo, err := Call[callTodoReq, callTodo](context.Background(), c, "POST", "/lists/:list/todos", callTodoReq{ListID: 3, Notify: true, Tenant: "acme", Title: "Ship"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := callTodo{ID: 7, ListID: 3, Title: "Ship", Notify: true, Tenant: "acme"}
+	if todo != want {
+		t.Errorf("todo = %+v, want %+v", todo, want)
+	}
+
+	_, err = Call[callTodoReq, callTodo](context.Background(), c, "POST", "/lists/:list/todos", callTodoReq{ListID: 3})
+	var httpErr HTTPError
+	if !errors.As(err, &httpErr) || httpErr.Status != http.StatusBadRequest {
+		t.Errorf("invalid request: err = %v", err)
+	}
+
+	_, err = Call[struct {
+		ID int `uri:"id"`
+	}, callTodo](context.Background(), c, "GET", "/todos/:id", struct {
+		ID int `uri:"id"`
+	}{ID: 1})
+	if !errors.As(err, &httpErr) || httpErr.Status != http.StatusNotFound || httpErr.Message != "no such todo" {
+		t.Errorf("missing todo: err = %v", err)
+	}
+}
+
+func TestCall_Retries(t *testing.T) {
+	srv, flaky := callServer(t)
+	c := NewClient(srv.URL, WithRetries(3, time.Millisecond))
+	res, err := Call[struct{}, string](context.Background(), c, "GET", "/flaky", struct{}{})
+	if err != nil || res != "ok" || flaky.Load() != 3 {
+		t.Errorf("res = %q, err = %v after %d attempts", res, err, flaky.Load())
+	}
+
+	flaky.Store(0)
+	_, err = Call[struct{}, string](context.Background(), NewClient(srv.URL), "GET", "/flaky", struct{}{})
+	if err == nil || flaky.Load() != 1 {
+		t.Errorf("without retries: err = %v after %d attempts", err, flaky.Load())
+	}
+}
+
+func TestCall_Timeout(t *testing.T) {
+	srv, _ := callServer(t)
+	c := NewClient(srv.URL, WithCallTimeout(50*time.Millisecond), WithRetries(2, time.Millisecond))
+	start := time.Now()
+	_, err := Call[struct{}, string](context.Background(), c, "GET", "/slow", struct{}{})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want a deadline error", err)
+	}
+	if time.Since(start) > time.Second {
+		t.Errorf("call took %v", time.Since(start))
+	}
+}
+
+func TestEncodeCall(t *testing.T) {
+	call, err := encodeCall("GET", "/files/*filepath", struct {
+		Path string   `uri:"filepath"`
+		Tags []string `form:"tag"`
+		Page int      `form:"page"`
+	}{Path: "/docs/a b.txt", Tags: []string{"x", "y"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if call.path != "/files/docs/a%20b.txt" || call.query.Encode() != "tag=x&tag=y" || call.body != nil {
+		t.Errorf("call = %+v", call)
+	}
+	call, err = encodeCall("POST", "/todos", struct {
+		Title    string `json:"title"`
+		ClientIP string `fluxo:"client_ip"`
+	}{Title: "Ship", ClientIP: "10.0.0.1"})
+	if err != nil || string(call.body) != `{"title":"Ship"}` {
+		t.Errorf("body = %s, err = %v", call.body, err)
+	}
+	var nested planNestedReq
+	nested.Filter.Status, nested.Filter.Range.From, nested.Labels = "done", "x", map[string]string{"team": "core"}
+	if call, err = encodeCall("GET", "/todos", nested); err != nil {
+		t.Fatal(err)
+	}
+	if q := call.query.Encode(); q != "filter%5Brange%5D%5Bfrom%5D=x&filter%5Bstatus%5D=done&labels%5Bteam%5D=core" {
+		t.Errorf("nested query = %s", q)
+	}
+	if _, err := encodeCall("GET", "/todos/:id", struct{}{}); err == nil {
+		t.Error("missing path parameter accepted")
+	}
+}
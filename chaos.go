@@ -0,0 +1,135 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ChaosOption configures the faults injected by Chaos
+type ChaosOption func(*chaos)
+
+type chaos struct {
+	latencyRate float64
+	latencyMin  time.Duration
+	latencyMax  time.Duration
+	errorRate   float64
+	errorStatus int
+	resetRate   float64
+	mu          sync.Mutex
+	rng         *rand.Rand // nil uses the global source
+}
+
+// WithChaosLatency delays a share rate (0 to 1) of requests by a random duration between least and most
+func WithChaosLatency(rate float64, least, most time.Duration) ChaosOption {
+	return func(c *chaos) {
+		c.latencyRate, c.latencyMin, c.latencyMax = rate, least, max(least, most)
+	}
+}
+
+// WithChaosErrors answers a share rate of requests with status instead of running the handler.
+// 429 and 503 responses carry a Retry-After header of one second.
+func WithChaosErrors(rate float64, status int) ChaosOption {
+	return func(c *chaos) {
+		c.errorRate, c.errorStatus = rate, status
+	}
+}
+
+// WithChaosResets closes the connection of a share rate of requests without responding
+func WithChaosResets(rate float64) ChaosOption {
+	return func(c *chaos) {
+		c.resetRate = rate
+	}
+}
+
+// WithChaosSeed makes the requests Chaos picks reproducible
+func WithChaosSeed(seed uint64) ChaosOption {
+	return func(c *chaos) {
+		c.rng = rand.New(rand.NewPCG(seed, seed))
+	}
+}
+
+// Chaos returns middleware injecting faults into a share of the requests of the routes it is
+// used on, to exercise the retries and timeouts of clients in integration tests:
+//
+//	api := app.TypedGroup("/api", fluxo.Chaos(
+//		fluxo.WithChaosLatency(0.2, 100*time.Millisecond, 2*time.Second),
+//		fluxo.WithChaosErrors(0.05, http.StatusServiceUnavailable),
+//		fluxo.WithChaosResets(0.01),
+//	))
+//
+// Each fault is drawn independently; an injected response carries an X-Fluxo-Chaos header naming
+// its faults. It is meant for test environments, never enable it in production.
+func Chaos(opts ...ChaosOption) gin.HandlerFunc {
+	c := &chaos{errorStatus: http.StatusServiceUnavailable}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c.inject
+}
+
+func (c *chaos) inject(ctx *gin.Context) {
+	var faults []string
+	if c.hit(c.latencyRate) {
+		faults = append(faults, "latency")
+		ctx.Header("X-Fluxo-Chaos", strings.Join(faults, ", "))
+		delay := c.latencyMin + time.Duration(c.float()*float64(c.latencyMax-c.latencyMin))
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Request.Context().Done():
+			timer.Stop()
+			ctx.Abort()
+			return
+		}
+	}
+
+	if c.hit(c.resetRate) {
+		resetConnection(ctx)
+		return
+	}
+
+	if c.hit(c.errorRate) {
+		faults = append(faults, "error")
+		ctx.Header("X-Fluxo-Chaos", strings.Join(faults, ", "))
+		if c.errorStatus == http.StatusTooManyRequests || c.errorStatus == http.StatusServiceUnavailable {
+			ctx.Header("Retry-After", "1")
+		}
+		ctx.AbortWithStatusJSON(c.errorStatus, gin.H{"error": "Fault injected by fluxo.Chaos"})
+	}
+}
+
+// hit draws whether a fault with the given rate happens
+func (c *chaos) hit(rate float64) bool {
+	return rate > 0 && c.float() < rate
+}
+
+func (c *chaos) float() float64 {
+	if c.rng == nil {
+		return rand.Float64()
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.rng.Float64()
+}
+
+// resetConnection drops the client's connection, with a TCP reset where possible. Without a
+// connection to hijack, as under httptest.ResponseRecorder, net/http's abort panic is used.
+func resetConnection(ctx *gin.Context) {
+	ctx.Abort()
+	conn, _, err := ctx.Writer.Hijack()
+	if err != nil {
+		panic(http.ErrAbortHandler)
+	}
+	if tcp, ok := conn.(*net.TCPConn); ok {
+		_ = tcp.SetLinger(0)
+	}
+	_ = conn.Close()
+}
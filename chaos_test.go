@@ -0,0 +1,81 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func chaosApp(opts ...ChaosOption) *App {
+	gin.SetMode(gin.TestMode)
+	app := New()
+	app.GET("/ping", Chaos(opts...), Handle(func(ctx *Context, req struct{}) (gin.H, error) { return gin.H{"ok": true}, nil }))
+	return app
+}
+
+func TestChaos_Errors(t *testing.T) {
+	app := chaosApp(WithChaosErrors(1, http.StatusServiceUnavailable))
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ping", nil))
+	if w.Code != http.StatusServiceUnavailable || w.Header().Get("Retry-After") != "1" || w.Header().Get("X-Fluxo-Chaos") != "error" {
+		t.Fatalf("expected an injected 503, got %d %v", w.Code, w.Header())
+	}
+
+	app = chaosApp(WithChaosErrors(0, http.StatusInternalServerError))
+	w = httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ping", nil))
+	if w.Code != http.StatusOK || w.Header().Get("X-Fluxo-Chaos") != "" {
+		t.Fatalf("expected no fault at rate 0, got %d", w.Code)
+	}
+}
+
+func TestChaos_Latency(t *testing.T) {
+	app := chaosApp(WithChaosLatency(1, 20*time.Millisecond, 30*time.Millisecond))
+	start := time.Now()
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ping", nil))
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond || w.Code != http.StatusOK || w.Header().Get("X-Fluxo-Chaos") != "latency" {
+		t.Fatalf("expected a delayed success, got %d after %v", w.Code, elapsed)
+	}
+}
+
+func TestChaos_Resets(t *testing.T) {
+	srv := httptest.NewServer(chaosApp(WithChaosResets(1)))
+	defer srv.Close()
+	res, err := http.Get(srv.URL + "/ping")
+	if err == nil {
+		res.Body.Close()
+		t.Fatalf("expected the connection to be reset, got %d", res.StatusCode)
+	}
+}
+
+func TestChaos_Seed(t *testing.T) {
+	codes := func() []int {
+		app := chaosApp(WithChaosErrors(0.5, http.StatusTooManyRequests), WithChaosSeed(42))
+		var out []int
+		for i := 0; i < 20; i++ {
+			w := httptest.NewRecorder()
+			app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ping", nil))
+			out = append(out, w.Code)
+		}
+		return out
+	}
+	first, second := codes(), codes()
+	failures := 0
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("expected seeded runs to inject the same faults, got %v and %v", first, second)
+		}
+		if first[i] == http.StatusTooManyRequests {
+			failures++
+		}
+	}
+	if failures == 0 || failures == len(first) {
+		t.Fatalf("expected about half the requests to fail, got %v", first)
+	}
+}
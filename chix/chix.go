@@ -0,0 +1,53 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+
+// Package chix lets fluxo handlers run on a chi router.
+package chix
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/leviantech/fluxo"
+)
+
+type backend struct {
+	router chi.Router
+}
+
+// Backend adapts a chi router as a fluxo.Mux backend
+//
+//	mux := fluxo.NewMux(chix.Backend(chi.NewRouter()))
+func Backend(r chi.Router) fluxo.Backend {
+	return backend{router: r}
+}
+
+func (b backend) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	b.router.ServeHTTP(w, r)
+}
+
+func (b backend) Handle(method, path string, h http.Handler) {
+	b.router.Method(method, Pattern(path), h)
+}
+
+func (b backend) Param(r *http.Request, p fluxo.PathParam) string {
+	if p.Wildcard {
+		return chi.URLParam(r, "*")
+	}
+	return chi.URLParam(r, p.Name)
+}
+
+// Pattern converts a gin path (/files/:id/*rest) to a chi pattern (/files/{id}/*)
+func Pattern(path string) string {
+	parts := strings.Split(path, "/")
+	for i, part := range parts {
+		switch {
+		case strings.HasPrefix(part, ":"):
+			parts[i] = "{" + part[1:] + "}"
+		case strings.HasPrefix(part, "*"):
+			parts[i] = "*"
+		}
+	}
+	return strings.Join(parts, "/")
+}
@@ -0,0 +1,54 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package chix
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-chi/chi/v5"
+	"github.com/leviantech/fluxo"
+)
+
+type getUserReq struct {
+	ID   string `uri:"id" validate:"required"`
+	Rest string `uri:"rest"`
+}
+
+func TestBackend(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mux := fluxo.NewMux(Backend(chi.NewRouter()))
+	mux.GET("/users/:id/*rest", fluxo.Handle(func(ctx *fluxo.Context, req getUserReq) (string, error) {
+		return req.ID + req.Rest, nil
+	}))
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users/7/a/b", nil))
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "7/a/b") {
+		t.Fatalf("status=%d body=%s", w.Code, w.Body.String())
+	}
+}
+
+func TestBackend_Mounted(t *testing.T) {
+	mux := fluxo.NewMux(Backend(chi.NewRouter()), fluxo.WithMode(gin.TestMode))
+	mux.GET("/users/:id/*rest", fluxo.Handle(func(ctx *fluxo.Context, req getUserReq) (string, error) {
+		return req.ID + req.Rest, nil
+	}))
+	r := chi.NewRouter()
+	r.Mount("/api", mux)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/users/7/a/b", nil))
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "7/a/b") {
+		t.Fatalf("status=%d body=%s", w.Code, w.Body.String())
+	}
+}
+
+func TestPattern(t *testing.T) {
+	if got := Pattern("/users/:id/*rest"); got != "/users/{id}/*" {
+		t.Fatalf("got %s", got)
+	}
+}
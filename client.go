@@ -0,0 +1,111 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+)
+
+// TraceHeaders are the headers HTTPClient copies from the incoming request to downstream calls:
+// W3C Trace Context and Baggage, Zipkin B3 and Google Cloud's trace header
+var TraceHeaders = []string{
+	"traceparent", "tracestate", "baggage",
+	"b3", "X-B3-TraceId", "X-B3-SpanId", "X-B3-ParentSpanId", "X-B3-Sampled", "X-B3-Flags",
+	"X-Cloud-Trace-Context",
+}
+
+// HTTPClient returns a client for calls made while handling a request, sending on its ID (see
+// RequestID) and trace headers, and bounded by its deadline, so that a request can be followed
+// across services:
+//
+//	app.GET("/orders/:id", fluxo.HandleCtx(func(ctx context.Context, req GetOrderReq) (Order, error) {
+//		call, _ := http.NewRequestWithContext(ctx, "GET", inventoryURL, nil)
+//		res, err := fluxo.HTTPClient(ctx).Do(call)
+//		...
+//	}))
+//
+// ctx may be a *gin.Context, a *Context or the context of a HandleCtx handler.
+func HTTPClient(ctx context.Context) *http.Client {
+	return &http.Client{Transport: PropagatingTransport(ctx, nil)}
+}
+
+// PropagatingTransport returns a RoundTripper sending requests with base (http.DefaultTransport
+// when nil) after adding what HTTPClient propagates from ctx. Headers the request sets already
+// are kept.
+func PropagatingTransport(ctx context.Context, base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	t := propagatingTransport{base: base, header: http.Header{}}
+	t.deadline, t.hasDeadline = ctx.Deadline()
+	id, _ := RequestIDKey.Get(ctx)
+	if c := ginContextOf(ctx); c != nil && c.Request != nil {
+		// Contexts derived from a *gin.Context don't see the values stored on it
+		if id == "" {
+			id, _ = RequestIDKey.Get(c)
+		}
+		if h := c.GetHeader(RequestIDHeader); id == "" && validRequestID(h) {
+			id = h
+		}
+		for _, name := range TraceHeaders {
+			if v := c.Request.Header.Values(name); len(v) > 0 {
+				t.header[http.CanonicalHeaderKey(name)] = v
+			}
+		}
+		// A *gin.Context has no deadline of its own, the request's context does
+		if !t.hasDeadline {
+			t.deadline, t.hasDeadline = c.Request.Context().Deadline()
+		}
+	}
+	if id != "" {
+		t.header.Set(RequestIDHeader, id)
+	}
+	return t
+}
+
+type propagatingTransport struct {
+	base        http.RoundTripper
+	header      http.Header
+	deadline    time.Time
+	hasDeadline bool
+}
+
+func (t propagatingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, cancel := req.Context(), context.CancelFunc(nil)
+	if d, ok := ctx.Deadline(); t.hasDeadline && (!ok || t.deadline.Before(d)) {
+		ctx, cancel = context.WithDeadline(ctx, t.deadline)
+	}
+	// A RoundTripper must not modify the request it is given
+	req = req.Clone(ctx)
+	for name, values := range t.header {
+		if _, ok := req.Header[name]; !ok {
+			req.Header[name] = values
+		}
+	}
+	res, err := t.base.RoundTrip(req)
+	if cancel == nil {
+		return res, err
+	}
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	// The deadline must outlive RoundTrip until the body is read
+	res.Body = cancelOnClose{ReadCloser: res.Body, cancel: cancel}
+	return res, nil
+}
+
+// cancelOnClose releases the deadline of a response once its body is closed
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
@@ -0,0 +1,89 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestHTTPClient(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var got http.Header
+	downstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		_, _ = io.WriteString(w, "ok")
+	}))
+	defer downstream.Close()
+
+	app := New()
+	app.Use(RequestID())
+	app.GET("/call", HandleCtx(func(ctx context.Context, _ struct{}) (string, error) {
+		call, err := http.NewRequestWithContext(ctx, "GET", downstream.URL, nil)
+		if err != nil {
+			return "", err
+		}
+		call.Header.Set("tracestate", "mine=1")
+		res, err := HTTPClient(ctx).Do(call)
+		if err != nil {
+			return "", err
+		}
+		defer res.Body.Close()
+		body, err := io.ReadAll(res.Body)
+		return string(body), err
+	}))
+
+	req := httptest.NewRequest("GET", "/call", nil)
+	req.Header.Set(RequestIDHeader, "req-1")
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	req.Header.Set("tracestate", "theirs=1")
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body)
+	}
+	if got.Get(RequestIDHeader) != "req-1" {
+		t.Errorf("request ID = %q", got.Get(RequestIDHeader))
+	}
+	if got.Get("traceparent") != req.Header.Get("traceparent") {
+		t.Errorf("traceparent = %q", got.Get("traceparent"))
+	}
+	if got.Get("tracestate") != "mine=1" {
+		t.Errorf("tracestate set by the call was replaced: %q", got.Get("tracestate"))
+	}
+}
+
+func TestHTTPClient_Deadline(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	block := make(chan struct{})
+	downstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-block:
+		case <-r.Context().Done():
+		}
+	}))
+	defer downstream.Close()
+	defer close(block)
+
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	incoming, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	ctx.Request = httptest.NewRequest("GET", "/", nil).WithContext(incoming)
+
+	start := time.Now()
+	call, _ := http.NewRequest("GET", downstream.URL, nil)
+	res, err := HTTPClient(ctx).Do(call)
+	if err == nil {
+		res.Body.Close()
+		t.Fatal("call outlived the request's deadline")
+	}
+	if time.Since(start) > 2*time.Second {
+		t.Errorf("call took %v", time.Since(start))
+	}
+}
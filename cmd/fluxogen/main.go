@@ -0,0 +1,655 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+
+// Command fluxogen emits reflection-free binding, validation and schema code for the request
+// and response types of a package, for services that want the fastest binding and spec
+// generation fluxo can offer:
+//
+//	//go:generate go run github.com/leviantech/fluxo/cmd/fluxogen -type=CreateUserReq,CreateUserRes
+//
+// The code is written to fluxo_gen.go behind the fluxogen build tag. Build with -tags fluxogen
+// to use it and without to keep using reflection; both behave the same. Whatever the generator
+// cannot express (nested or named field types, embedded structs, collection formats, query
+// aliases, rules other than required, omitempty, min, max, len and oneof, ...) is left to
+// reflection and listed at the top of the file.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+func main() {
+	var (
+		types  = flag.String("type", "", "comma-separated list of struct types to generate code for")
+		output = flag.String("output", "fluxo_gen.go", "output file, relative to the package directory")
+		tag    = flag.String("tag", "fluxogen", "build tag guarding the generated file")
+	)
+	flag.Parse()
+	log.SetFlags(0)
+	log.SetPrefix("fluxogen: ")
+	if *types == "" {
+		log.Fatal("-type is required")
+	}
+	dir := "."
+	if flag.NArg() > 0 {
+		dir = flag.Arg(0)
+	}
+
+	src, err := generate(dir, strings.Split(*types, ","), *tag, *output)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, *output), src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// generate returns the formatted source of the generated file for types in the package in dir
+func generate(dir string, types []string, tag, output string) ([]byte, error) {
+	pkg, structs, err := parsePackage(dir, output)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &generator{}
+	for _, name := range types {
+		name = strings.TrimSpace(name)
+		st, ok := structs[name]
+		if !ok {
+			return nil, fmt.Errorf("struct type %s not found in %s", name, dir)
+		}
+		g.typ(name, st)
+	}
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "// Code generated by fluxogen; DO NOT EDIT.\n\n//go:build %s\n\n", tag)
+	for _, note := range g.notes {
+		fmt.Fprintf(&out, "// %s\n", note)
+	}
+	if len(g.notes) > 0 {
+		out.WriteString("\n")
+	}
+	fmt.Fprintf(&out, "package %s\n", pkg)
+	if g.init.Len() > 0 {
+		out.WriteString("\nimport (\n")
+		for _, imp := range g.imports() {
+			fmt.Fprintf(&out, "\t%q\n", imp)
+		}
+		out.WriteString(")\n\nfunc init() {\n")
+		out.WriteString(g.init.String())
+		out.WriteString("}\n")
+		out.WriteString(g.funcs.String())
+		if g.helpers {
+			out.WriteString(helpers)
+		}
+	}
+
+	src, err := format.Source([]byte(out.String()))
+	if err != nil {
+		return nil, fmt.Errorf("format generated code: %w\n%s", err, out.String())
+	}
+	return src, nil
+}
+
+// parsePackage returns the package name and struct types declared in the non-test files of dir
+func parsePackage(dir, output string) (string, map[string]*ast.StructType, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return "", nil, err
+	}
+	fset := token.NewFileSet()
+	var pkg string
+	structs := map[string]*ast.StructType{}
+	for _, path := range files {
+		if strings.HasSuffix(path, "_test.go") || filepath.Base(path) == output {
+			continue
+		}
+		f, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return "", nil, err
+		}
+		pkg = f.Name.Name
+		for _, decl := range f.Decls {
+			gd, ok := decl.(*ast.GenDecl)
+			if !ok || gd.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gd.Specs {
+				ts := spec.(*ast.TypeSpec)
+				if st, ok := ts.Type.(*ast.StructType); ok && ts.TypeParams == nil {
+					structs[ts.Name.Name] = st
+				}
+			}
+		}
+	}
+	if pkg == "" {
+		return "", nil, fmt.Errorf("no Go files in %s", dir)
+	}
+	return pkg, structs, nil
+}
+
+// fieldType is a field type the generator can express: a basic type, a pointer to one or a slice of them
+type fieldType struct {
+	basic string
+	ptr   bool
+	slice bool
+}
+
+var basicTypes = map[string]bool{
+	"string": true, "bool": true,
+	"int": true, "int8": true, "int16": true, "int32": true, "int64": true,
+	"uint": true, "uint8": true, "uint16": true, "uint32": true, "uint64": true,
+	"float32": true, "float64": true,
+}
+
+func parseFieldType(expr ast.Expr) (fieldType, bool) {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return fieldType{basic: e.Name}, basicTypes[e.Name]
+	case *ast.StarExpr:
+		if id, ok := e.X.(*ast.Ident); ok && basicTypes[id.Name] {
+			return fieldType{basic: id.Name, ptr: true}, true
+		}
+	case *ast.ArrayType:
+		if id, ok := e.Elt.(*ast.Ident); ok && e.Len == nil && basicTypes[id.Name] {
+			return fieldType{basic: id.Name, slice: true}, true
+		}
+	}
+	return fieldType{}, false
+}
+
+func (t fieldType) String() string {
+	switch {
+	case t.ptr:
+		return "*" + t.basic
+	case t.slice:
+		return "[]" + t.basic
+	}
+	return t.basic
+}
+
+type field struct {
+	name string
+	typ  fieldType
+	ok   bool   // typ is expressible
+	expr string // the declared type, for notes
+	tag  reflect.StructTag
+}
+
+// fields returns the exported fields of st, or the reason the struct cannot be generated
+func fields(st *ast.StructType) ([]field, string) {
+	var out []field
+	for _, f := range st.Fields.List {
+		if len(f.Names) == 0 {
+			return nil, "has an embedded field"
+		}
+		var tag reflect.StructTag
+		if f.Tag != nil {
+			s, _ := strconv.Unquote(f.Tag.Value)
+			tag = reflect.StructTag(s)
+		}
+		typ, ok := parseFieldType(f.Type)
+		for _, name := range f.Names {
+			if !name.IsExported() {
+				continue
+			}
+			out = append(out, field{name: name.Name, typ: typ, ok: ok, expr: typeString(f.Type), tag: tag})
+		}
+	}
+	return out, ""
+}
+
+// typeString renders a type expression for notes
+func typeString(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return e.Name
+	case *ast.StarExpr:
+		return "*" + typeString(e.X)
+	case *ast.ArrayType:
+		if e.Len == nil {
+			return "[]" + typeString(e.Elt)
+		}
+		return "[...]" + typeString(e.Elt)
+	case *ast.SelectorExpr:
+		return typeString(e.X) + "." + e.Sel.Name
+	case *ast.MapType:
+		return "map[" + typeString(e.Key) + "]" + typeString(e.Value)
+	case *ast.IndexExpr:
+		return typeString(e.X) + "[" + typeString(e.Index) + "]"
+	}
+	return "?"
+}
+
+type generator struct {
+	init    strings.Builder
+	funcs   strings.Builder
+	notes   []string
+	helpers bool
+	strconv bool
+	utf8    bool
+}
+
+func (g *generator) imports() []string {
+	imps := []string{"reflect", "github.com/leviantech/fluxo"}
+	if g.strconv {
+		imps = append(imps, "strconv")
+	}
+	if g.utf8 {
+		imps = append(imps, "unicode/utf8")
+	}
+	sort.Strings(imps)
+	return imps
+}
+
+func (g *generator) note(typ, format string, args ...any) {
+	g.notes = append(g.notes, typ+": "+fmt.Sprintf(format, args...))
+}
+
+// typ generates the binders, validator and schema of a struct type, leaving out what it cannot express
+func (g *generator) typ(name string, st *ast.StructType) {
+	fs, reason := fields(st)
+	if reason != "" {
+		g.note(name, "%s, left to reflection", reason)
+		return
+	}
+
+	var members []string
+	if binders, ok := g.binders(name, fs); ok {
+		members = append(members, binders...)
+	}
+	if g.validator(name, fs) {
+		members = append(members, fmt.Sprintf("Validate: fluxogenValidate%s,", name))
+	}
+	if schema, ok := g.schema(name, fs); ok {
+		members = append(members, "Schema: "+schema+",")
+	}
+	if len(members) == 0 {
+		return
+	}
+	fmt.Fprintf(&g.init, "fluxo.RegisterGenerated(reflect.TypeOf(%s{}), fluxo.Generated{\n%s\n})\n", name, strings.Join(members, "\n"))
+}
+
+var sources = []struct {
+	member, tag string
+	canonical   bool
+}{
+	{"Query", "form", false},
+	{"URI", "uri", false},
+	{"Header", "header", true},
+}
+
+// binders generates a binder for every source the type has tagged fields for. Like fluxo's
+// binding plan, untagged fields are bound by name once any field is tagged for the source.
+func (g *generator) binders(name string, fs []field) ([]string, bool) {
+	var members []string
+	var funcs strings.Builder
+	for _, src := range sources {
+		tagged := false
+		for _, f := range fs {
+			if _, ok := f.tag.Lookup(src.tag); ok {
+				tagged = true
+			}
+		}
+		if !tagged {
+			continue
+		}
+
+		fn := fmt.Sprintf("fluxogen%s%s", src.member, name)
+		fmt.Fprintf(&funcs, "\nfunc %s(obj any, src map[string][]string) error {\nreq := obj.(*%s)\n", fn, name)
+		for _, f := range fs {
+			key, opts, _ := strings.Cut(f.tag.Get(src.tag), ",")
+			if key == "-" {
+				continue
+			}
+			if !f.ok {
+				g.note(name, "field %s of type %s is bound from %s by reflection", f.name, f.expr, src.tag)
+				return nil, false
+			}
+			if format := f.tag.Get("collection_format"); format != "" && format != "multi" {
+				g.note(name, "field %s uses collection_format %s, bound by reflection", f.name, format)
+				return nil, false
+			}
+			if _, ok := f.tag.Lookup("alias"); ok && src.tag == "form" {
+				g.note(name, "field %s has query aliases, bound by reflection", f.name)
+				return nil, false
+			}
+			if key == "" {
+				key = f.name
+			}
+			if src.canonical {
+				key = textproto.CanonicalMIMEHeaderKey(key)
+			}
+			def, hasDef := "", false
+			for _, opt := range strings.Split(opts, ",") {
+				if v, ok := strings.CutPrefix(opt, "default="); ok {
+					def, hasDef = v, true
+				}
+			}
+			funcs.WriteString(bindField(f, key, def, hasDef))
+		}
+		funcs.WriteString("return nil\n}\n")
+		members = append(members, fmt.Sprintf("%s: %s,", src.member, fn))
+	}
+	if len(members) > 0 {
+		g.helpers = true
+		g.strconv = g.strconv || strings.Contains(funcs.String(), "strconv.")
+		g.funcs.WriteString(funcs.String())
+	}
+	return members, true
+}
+
+// bindField sets one field like fluxo's leaf binder: the first value, or the default when it is
+// missing or empty
+func bindField(f field, key, def string, hasDef bool) string {
+	if f.typ.slice {
+		defs := "nil"
+		if hasDef {
+			defs = fmt.Sprintf("%#v", strings.Split(strings.ReplaceAll(def, ";", ","), ","))
+		}
+		return fmt.Sprintf(`if vs, ok := fluxogenValues(src, %q, %s); ok {
+s := make([]%s, len(vs))
+for i, val := range vs {
+%s
+s[i] = x
+}
+req.%s = s
+}
+`, key, defs, f.typ.basic, convert(f.typ.basic), f.name)
+	}
+
+	set := fmt.Sprintf("req.%s = x", f.name)
+	if f.typ.ptr {
+		set = fmt.Sprintf("if req.%[1]s != nil {\n*req.%[1]s = x\n} else {\nreq.%[1]s = &x\n}", f.name)
+	}
+	return fmt.Sprintf(`if val, ok := fluxogenValue(src, %q, %q, %t); ok {
+%s
+%s
+}
+`, key, def, hasDef, convert(f.typ.basic), set)
+}
+
+// convert parses val into a new variable x of the basic type, returning on error
+func convert(basic string) string {
+	const check = "\nif err != nil {\nreturn err\n}"
+	bits := func(prefix string) string {
+		if n := strings.TrimPrefix(basic, prefix); n != "" {
+			return n
+		}
+		return "0"
+	}
+	switch {
+	case basic == "string":
+		return "x := val"
+	case basic == "bool":
+		return `x, err := strconv.ParseBool(fluxogenOr(val, "false"))` + check
+	case strings.HasPrefix(basic, "int"):
+		return fmt.Sprintf(`n, err := strconv.ParseInt(fluxogenOr(val, "0"), 10, %s)`, bits("int")) + check + fmt.Sprintf("\nx := %s(n)", basic)
+	case strings.HasPrefix(basic, "uint"):
+		return fmt.Sprintf(`n, err := strconv.ParseUint(fluxogenOr(val, "0"), 10, %s)`, bits("uint")) + check + fmt.Sprintf("\nx := %s(n)", basic)
+	default:
+		return fmt.Sprintf(`n, err := strconv.ParseFloat(fluxogenOr(val, "0.0"), %s)`, strings.TrimPrefix(basic, "float")) + check + fmt.Sprintf("\nx := %s(n)", basic)
+	}
+}
+
+// validator generates the Validate function of a type, reporting whether every rule could be expressed
+func (g *generator) validator(name string, fs []field) bool {
+	var body strings.Builder
+	usesUTF8 := false
+	for _, f := range fs {
+		rules := f.tag.Get("validate")
+		if rules == "" || rules == "-" {
+			continue
+		}
+		if !f.ok {
+			g.note(name, "field %s of type %s is validated by reflection", f.name, f.expr)
+			return false
+		}
+		code, utf, err := validateField(f, rules)
+		if err != nil {
+			g.note(name, "field %s %v, validated by reflection", f.name, err)
+			return false
+		}
+		usesUTF8 = usesUTF8 || utf
+		body.WriteString(code)
+	}
+	for _, f := range fs {
+		if !f.ok {
+			// The validator dives into nested structs even without rules
+			g.note(name, "field %s of type %s is validated by reflection", f.name, f.expr)
+			return false
+		}
+	}
+
+	g.utf8 = g.utf8 || usesUTF8
+	if body.Len() == 0 {
+		fmt.Fprintf(&g.funcs, "\nfunc fluxogenValidate%s(any) []fluxo.FieldError {\nreturn nil\n}\n", name)
+		return true
+	}
+	fmt.Fprintf(&g.funcs, "\nfunc fluxogenValidate%s(obj any) []fluxo.FieldError {\nreq := obj.(*%s)\nvar errs []fluxo.FieldError\n%sreturn errs\n}\n", name, name, body.String())
+	return true
+}
+
+// validateField checks the rules of a field in order, stopping at the first failure like the validator
+func validateField(f field, rules string) (string, bool, error) {
+	v := "req." + f.name
+	zero, nonZero := zeroCheck(f.typ, v)
+
+	var cases strings.Builder
+	omitEmpty, usesUTF8 := false, false
+	fail := func(cond, tag, param string) {
+		if param != "" {
+			param = fmt.Sprintf(", Param: %q", param)
+		}
+		fmt.Fprintf(&cases, "case %s:\nerrs = append(errs, fluxo.FieldError{Field: %q, Tag: %q%s})\n", cond, f.name, tag, param)
+	}
+	for _, rule := range strings.Split(rules, ",") {
+		tag, param, _ := strings.Cut(rule, "=")
+		switch tag {
+		case "omitempty":
+			omitEmpty = true
+		case "required":
+			fail(zero, tag, "")
+		case "min", "max", "len":
+			if f.typ.ptr || f.typ.basic == "bool" {
+				return "", false, fmt.Errorf("of type %s uses %s", f.typ, tag)
+			}
+			size, utf, err := sizeOf(f.typ, v, param)
+			if err != nil {
+				return "", false, err
+			}
+			usesUTF8 = usesUTF8 || utf
+			op := map[string]string{"min": "<", "max": ">", "len": "!="}[tag]
+			fail(size+" "+op+" "+literal(f.typ, param), tag, param)
+		case "oneof":
+			if f.typ.ptr || f.typ.slice || f.typ.basic == "bool" || strings.HasPrefix(f.typ.basic, "float") {
+				return "", false, fmt.Errorf("of type %s uses oneof", f.typ)
+			}
+			var conds []string
+			for _, opt := range strings.Fields(param) {
+				if f.typ.basic != "string" {
+					if _, err := strconv.ParseInt(opt, 10, 64); err != nil {
+						return "", false, fmt.Errorf("has a non-numeric oneof option %q", opt)
+					}
+					conds = append(conds, v+" != "+opt)
+				} else {
+					conds = append(conds, fmt.Sprintf("%s != %q", v, opt))
+				}
+			}
+			fail(strings.Join(conds, " && "), tag, param)
+		default:
+			return "", false, fmt.Errorf("uses the %q rule", tag)
+		}
+	}
+	if cases.Len() == 0 {
+		return "", false, nil
+	}
+
+	code := "switch {\n" + cases.String() + "}\n"
+	if omitEmpty {
+		code = fmt.Sprintf("if %s {\n%s}\n", nonZero, code)
+	}
+	return code, usesUTF8, nil
+}
+
+// zeroCheck returns the conditions for v having no value and having one, as required and
+// omitempty see it
+func zeroCheck(t fieldType, v string) (string, string) {
+	switch {
+	case t.ptr || t.slice:
+		return v + " == nil", v + " != nil"
+	case t.basic == "string":
+		return v + ` == ""`, v + ` != ""`
+	case t.basic == "bool":
+		return "!" + v, v
+	}
+	return v + " == 0", v + " != 0"
+}
+
+// sizeOf returns what min, max and len compare: the length of strings and slices, or the value of numbers
+func sizeOf(t fieldType, v, param string) (string, bool, error) {
+	if t.slice || t.basic == "string" {
+		if _, err := strconv.Atoi(param); err != nil {
+			return "", false, fmt.Errorf("has a non-numeric length %q", param)
+		}
+		if t.slice {
+			return "len(" + v + ")", false, nil
+		}
+		return "utf8.RuneCountInString(" + v + ")", true, nil
+	}
+	if _, err := strconv.ParseFloat(param, 64); err != nil {
+		return "", false, fmt.Errorf("has a non-numeric bound %q", param)
+	}
+	if !strings.HasPrefix(t.basic, "float") && strings.ContainsAny(param, ".eE") {
+		return "", false, fmt.Errorf("has a fractional bound %q for an integer", param)
+	}
+	return v, false, nil
+}
+
+func literal(t fieldType, param string) string {
+	if !t.slice && strings.HasPrefix(t.basic, "float") && !strings.ContainsAny(param, ".eE") {
+		return param + ".0"
+	}
+	return param
+}
+
+// schema renders the schema of a type the way the swagger generator builds it by reflection
+func (g *generator) schema(name string, fs []field) (string, bool) {
+	var props, required []string
+	for _, f := range fs {
+		prop := ""
+		if j := f.tag.Get("json"); j != "" && j != "-" {
+			prop, _, _ = strings.Cut(j, ",")
+		} else if form := f.tag.Get("form"); form != "" && form != "-" {
+			prop, _, _ = strings.Cut(form, ",")
+		}
+		if prop == "" {
+			continue
+		}
+		if !f.ok {
+			g.note(name, "field %s of type %s is documented by reflection", f.name, f.expr)
+			return "", false
+		}
+
+		schema := schemaOf(f.typ)
+		if rules := f.tag.Get("validate"); rules != "" {
+			schema = strings.TrimSuffix(schema, "}") + fmt.Sprintf(", Description: %q", "Validation: "+rules)
+			if strings.Contains(rules, "email") {
+				schema += `, Format: "email"`
+			}
+			schema += enumOf(f.typ, rules)
+			schema += "}"
+			if strings.Contains(rules, "required") {
+				required = append(required, strconv.Quote(prop))
+			}
+		}
+		props = append(props, fmt.Sprintf("%q: %s,", prop, schema))
+	}
+	return fmt.Sprintf("&fluxo.Schema{\nType: \"object\",\nProperties: map[string]fluxo.Schema{\n%s\n},\nRequired: []string{%s},\n}",
+		strings.Join(props, "\n"), strings.Join(required, ", ")), true
+}
+
+// enumOf renders the options of a oneof rule as the schema enum, like the swagger generator lists them
+func enumOf(t fieldType, rules string) string {
+	for _, rule := range strings.Split(rules, ",") {
+		tag, param, _ := strings.Cut(rule, "=")
+		if tag != "oneof" || t.slice {
+			continue
+		}
+		var values []string
+		for _, opt := range strings.Fields(param) {
+			switch {
+			case t.basic == "string":
+				values = append(values, strconv.Quote(opt))
+			case strings.HasPrefix(t.basic, "uint"):
+				values = append(values, "uint64("+opt+")")
+			default:
+				values = append(values, "int64("+opt+")")
+			}
+		}
+		return ", Enum: []interface{}{" + strings.Join(values, ", ") + "}"
+	}
+	return ""
+}
+
+func schemaOf(t fieldType) string {
+	var s string
+	switch {
+	case t.basic == "string":
+		s = `{Type: "string"}`
+	case t.basic == "bool":
+		s = `{Type: "boolean"}`
+	case strings.HasPrefix(t.basic, "int"), strings.HasPrefix(t.basic, "uint"):
+		s = `{Type: "integer", Format: "int64"}`
+	case strings.HasPrefix(t.basic, "float"):
+		s = `{Type: "number", Format: "double"}`
+	default:
+		s = `{Type: "object"}`
+	}
+	if t.slice {
+		return `{Type: "array", Items: &fluxo.Schema` + s + `}`
+	}
+	return s
+}
+
+const helpers = `
+// fluxogenValue returns the first value under key, or def when it is missing or empty
+func fluxogenValue(src map[string][]string, key, def string, hasDef bool) (string, bool) {
+	vs, ok := src[key]
+	if !ok && !hasDef {
+		return "", false
+	}
+	if len(vs) > 0 && vs[0] != "" {
+		return vs[0], true
+	}
+	return def, true
+}
+
+// fluxogenValues returns the values under key, or def when it is missing
+func fluxogenValues(src map[string][]string, key string, def []string) ([]string, bool) {
+	if vs, ok := src[key]; ok {
+		return vs, true
+	}
+	return def, def != nil
+}
+
+func fluxogenOr(val, zero string) string {
+	if val == "" {
+		return zero
+	}
+	return val
+}
+`
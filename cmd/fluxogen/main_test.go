@@ -0,0 +1,82 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// The example's generated file must be what the generator emits today
+func TestGenerate_ExampleUpToDate(t *testing.T) {
+	dir := filepath.Join("..", "..", "examples", "codegen")
+	want, err := os.ReadFile(filepath.Join(dir, "fluxo_gen.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := generate(dir, []string{"SearchProductsRequest", "CreateProductRequest", "Product", "ProductList"}, "fluxogen", "fluxo_gen.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(want) {
+		t.Fatal("examples/codegen/fluxo_gen.go is stale, run go generate ./examples/codegen")
+	}
+}
+
+func TestGenerate_LeavesUnsupportedToReflection(t *testing.T) {
+	dir := t.TempDir()
+	src := `package api
+
+import "time"
+
+type Base struct{ ID int }
+
+type Embeds struct {
+	Base
+	Name string ` + "`json:\"name\"`" + `
+}
+
+type Mixed struct {
+	Name    string    ` + "`form:\"name\" json:\"name\" validate:\"required,email\"`" + `
+	Created time.Time ` + "`json:\"created\"`" + `
+	IDs     []int     ` + "`form:\"ids\" collection_format:\"csv\"`" + `
+	secret  string
+}
+`
+	if err := os.WriteFile(filepath.Join(dir, "api.go"), []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	out, err := generate(dir, []string{"Embeds", "Mixed"}, "fluxogen", "fluxo_gen.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	code := string(out)
+	for _, note := range []string{
+		"// Embeds: has an embedded field, left to reflection",
+		"// Mixed: field Created of type time.Time is bound from form by reflection",
+		`// Mixed: field Name uses the "email" rule, validated by reflection`,
+		"// Mixed: field Created of type time.Time is documented by reflection",
+	} {
+		if !strings.Contains(code, note) {
+			t.Errorf("expected note %q in\n%s", note, code)
+		}
+	}
+	if strings.Contains(code, "RegisterGenerated") || strings.Contains(code, "import") {
+		t.Fatalf("nothing should be generated for these types:\n%s", code)
+	}
+	if !strings.HasPrefix(code, "// Code generated by fluxogen; DO NOT EDIT.\n\n//go:build fluxogen\n") {
+		t.Fatalf("unexpected header:\n%s", code)
+	}
+}
+
+func TestGenerate_UnknownType(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "api.go"), []byte("package api\n\ntype A struct{}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := generate(dir, []string{"B"}, "fluxogen", "fluxo_gen.go"); err == nil {
+		t.Fatal("expected an error for a missing type")
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/gin-gonic/gin/codec/json"
+)
+
+// Codec decodes JSON request bodies and encodes responses. sonic.ConfigStd and
+// jsoniter.ConfigCompatibleWithStandardLibrary satisfy it as they are:
+//
+//	app := fluxo.New().WithCodec(sonic.ConfigStd)
+type Codec interface {
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+}
+
+const codecKey = "fluxo.codec"
+
+// WithCodec uses c for JSON bodies of handlers registered after the call. Without a codec,
+// gin's JSON binding and rendering are used.
+func (a *App) WithCodec(c Codec) *App {
+	a.router.Use(func(ctx *gin.Context) {
+		ctx.Set(codecKey, c)
+	})
+	return a
+}
+
+// WithCodec uses c for JSON bodies of the mux's handlers registered after the call
+func (m *Mux) WithCodec(c Codec) *Mux {
+	m.app.WithCodec(c)
+	return m
+}
+
+// requestCodec returns the codec configured for the request, if any
+func requestCodec(ctx *gin.Context) (Codec, bool) {
+	c, ok := ctx.Value(codecKey).(Codec)
+	return c, ok
+}
+
+const strictJSONKey = "fluxo.strict_json"
+
+// StrictJSON returns middleware making the fluxo handlers after it reject JSON bodies with
+// fields the request type doesn't have; false turns off strictness set further out:
+//
+//	app.POST("/users", fluxo.StrictJSON(true), fluxo.Handle(createUser))
+func StrictJSON(enabled bool) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Set(strictJSONKey, enabled)
+	}
+}
+
+// WithStrictJSON rejects unknown JSON fields in routes registered afterwards
+func (a *App) WithStrictJSON() *App {
+	a.router.Use(StrictJSON(true))
+	return a
+}
+
+// WithStrictJSON rejects unknown JSON fields in the group's routes registered afterwards
+func (g *Group) WithStrictJSON() *Group {
+	g.RouterGroup.Use(StrictJSON(true))
+	return g
+}
+
+// decodeStrict decodes body into obj with gin's JSON decoder, failing on unknown fields
+func decodeStrict(body []byte, obj any) error {
+	dec := json.API.NewDecoder(bytes.NewReader(body))
+	if binding.EnableDecoderUseNumber {
+		dec.UseNumber()
+	}
+	dec.DisallowUnknownFields()
+	return dec.Decode(obj)
+}
+
+// bindJSON decodes the body into obj with the request's codec, keeping the body for later reads.
+// Strict requests are decoded by gin's decoder, which can reject unknown fields, whatever the codec.
+func bindJSON(ctx *gin.Context, obj any) error {
+	strict, unchecked := ctx.GetBool(strictJSONKey), checksBindingTags(ctx)
+	codec, ok := requestCodec(ctx)
+	if !ok && !strict && !unchecked {
+		return ctx.ShouldBindBodyWith(obj, binding.JSON)
+	}
+
+	var body []byte
+	if cached, exists := ctx.Get(gin.BodyBytesKey); exists {
+		body, _ = cached.([]byte)
+	} else {
+		raw, err := ctx.GetRawData()
+		if err != nil {
+			return err
+		}
+		body = raw
+		ctx.Set(gin.BodyBytesKey, body)
+	}
+	decode := decodeStrict
+	if ok && !strict {
+		decode = codec.Unmarshal
+	} else if !strict {
+		decode = decodeJSON
+	}
+	if err := decode(body, obj); err != nil {
+		return err
+	}
+	if binding.Validator == nil || unchecked {
+		return nil
+	}
+	return binding.Validator.ValidateStruct(obj)
+}
+
+// writeJSON renders v with the request's codec, falling back to gin's JSON renderer
+func writeJSON(ctx *gin.Context, status int, v any) {
+	codec, ok := requestCodec(ctx)
+	if !ok {
+		ctx.JSON(status, v)
+		return
+	}
+	data, err := codec.Marshal(v)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Encoding response failed: %v", err)})
+		return
+	}
+	ctx.Data(status, "application/json; charset=utf-8", data)
+}
@@ -0,0 +1,203 @@
+package fluxo
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bytedance/sonic"
+	"github.com/gin-gonic/gin"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// countingCodec records calls before delegating to encoding/json
+type countingCodec struct{ marshal, unmarshal int }
+
+func (c *countingCodec) Marshal(v any) ([]byte, error) {
+	c.marshal++
+	return json.Marshal(v)
+}
+
+func (c *countingCodec) Unmarshal(data []byte, v any) error {
+	c.unmarshal++
+	return json.Unmarshal(data, v)
+}
+
+type codecItem struct {
+	ID    int      `json:"id"`
+	Name  string   `json:"name" validate:"required"`
+	Tags  []string `json:"tags"`
+	Price float64  `json:"price"`
+}
+
+type codecOrder struct {
+	Customer string      `json:"customer" validate:"required"`
+	Items    []codecItem `json:"items"`
+}
+
+func codecEcho(ctx *Context, req codecOrder) (codecOrder, error) { return req, nil }
+
+func TestCodecUsedForRequestAndResponse(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	codec := &countingCodec{}
+	app := New().WithCodec(codec)
+	app.POST("/orders", Handle(codecEcho))
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(`{"customer":"ann","items":[{"id":1,"name":"pen"}]}`))
+	req.Header.Set("Content-Type", "application/json")
+	app.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", w.Code, w.Body.String())
+	}
+	if codec.unmarshal != 1 || codec.marshal != 1 {
+		t.Fatalf("expected one decode and one encode, got %+v", codec)
+	}
+	if !strings.Contains(w.Body.String(), `"customer":"ann"`) || !strings.HasPrefix(w.Header().Get("Content-Type"), "application/json") {
+		t.Fatalf("unexpected response %q %q", w.Header().Get("Content-Type"), w.Body.String())
+	}
+}
+
+func TestCodecValidatesAndPrunesFields(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	codec := &countingCodec{}
+	app := New().WithCodec(codec)
+	app.POST("/orders", Handle(codecEcho))
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(`{"items":[]}`))
+	req.Header.Set("Content-Type", "application/json")
+	app.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for missing customer, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodPost, "/orders?fields=customer", strings.NewReader(`{"customer":"ann","items":[{"id":1,"name":"pen"}]}`))
+	req.Header.Set("Content-Type", "application/json")
+	app.ServeHTTP(w, req)
+	if w.Code != http.StatusOK || strings.TrimSpace(w.Body.String()) != `{"customer":"ann"}` {
+		t.Fatalf("unexpected pruned response %d: %s", w.Code, w.Body.String())
+	}
+	// Once to build the generic tree, once for the pruned result
+	if codec.marshal != 2 {
+		t.Fatalf("expected the codec to encode the response and the pruned result, got %d", codec.marshal)
+	}
+}
+
+func TestCodecRejectsMalformedBody(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := New().WithCodec(jsoniter.ConfigCompatibleWithStandardLibrary)
+	app.POST("/orders", Handle(codecEcho))
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(`{"customer":`))
+	req.Header.Set("Content-Type", "application/json")
+	app.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "JSON binding failed") {
+		t.Fatalf("expected 400, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestMuxCodec(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	codec := &countingCodec{}
+	m := NewMux(ServeMux(http.NewServeMux())).WithCodec(codec)
+	m.POST("/orders", Handle(codecEcho))
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(`{"customer":"ann"}`))
+	req.Header.Set("Content-Type", "application/json")
+	m.ServeHTTP(w, req)
+	if w.Code != http.StatusOK || codec.unmarshal != 1 || codec.marshal != 1 {
+		t.Fatalf("status %d, codec %+v: %s", w.Code, codec, w.Body.String())
+	}
+}
+
+func benchmarkCodec(b *testing.B, codec Codec) {
+	gin.SetMode(gin.TestMode)
+	app := New()
+	if codec != nil {
+		app.WithCodec(codec)
+	}
+	app.POST("/orders", Handle(codecEcho))
+
+	order := codecOrder{Customer: "ann"}
+	for i := 0; i < 50; i++ {
+		order.Items = append(order.Items, codecItem{ID: i, Name: "item", Tags: []string{"a", "b", "c"}, Price: 9.99})
+	}
+	body, _ := json.Marshal(order)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(string(body)))
+		req.Header.Set("Content-Type", "application/json")
+		app.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			b.Fatalf("status %d: %s", w.Code, w.Body.String())
+		}
+	}
+}
+
+func BenchmarkCodecGin(b *testing.B) { benchmarkCodec(b, nil) }
+func BenchmarkCodecStd(b *testing.B) { benchmarkCodec(b, &countingCodec{}) }
+func BenchmarkCodecJsoniter(b *testing.B) {
+	benchmarkCodec(b, jsoniter.ConfigCompatibleWithStandardLibrary)
+}
+func BenchmarkCodecSonic(b *testing.B) { benchmarkCodec(b, sonic.ConfigStd) }
+
+func postOrder(app *App, path, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	app.ServeHTTP(w, req)
+	return w
+}
+
+func TestStrictJSON(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := New()
+	app.POST("/lenient", Handle(codecEcho))
+	app.POST("/route", StrictJSON(true), Handle(codecEcho))
+	strict := app.TypedGroup("/strict").WithStrictJSON()
+	strict.POST("/orders", Handle(codecEcho))
+	strict.POST("/relaxed", StrictJSON(false), Handle(codecEcho))
+
+	typo := `{"customer":"ann","items":[{"id":1,"name":"pen","prcie":2}]}`
+	for _, path := range []string{"/route", "/strict/orders"} {
+		w := postOrder(app, path, typo)
+		if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), `unknown field \"prcie\"`) {
+			t.Fatalf("%s: expected the unknown field to be reported, got %d %s", path, w.Code, w.Body.String())
+		}
+		if w := postOrder(app, path, `{"customer":"ann"}`); w.Code != http.StatusOK {
+			t.Fatalf("%s: expected a valid body to pass, got %d %s", path, w.Code, w.Body.String())
+		}
+		if w := postOrder(app, path, `{"items":[]}`); w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "Customer") {
+			t.Fatalf("%s: expected validation to run, got %d %s", path, w.Code, w.Body.String())
+		}
+	}
+	for _, path := range []string{"/lenient", "/strict/relaxed"} {
+		if w := postOrder(app, path, typo); w.Code != http.StatusOK {
+			t.Fatalf("%s: expected unknown fields to be ignored, got %d %s", path, w.Code, w.Body.String())
+		}
+	}
+}
+
+func TestStrictJSON_WithCodec(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	codec := &countingCodec{}
+	app := New().WithCodec(codec).WithStrictJSON()
+	app.POST("/orders", Handle(codecEcho))
+
+	if w := postOrder(app, "/orders", `{"customer":"ann","note":"x"}`); w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "note") {
+		t.Fatalf("expected the unknown field to be rejected, got %d %s", w.Code, w.Body.String())
+	}
+	if w := postOrder(app, "/orders", `{"customer":"ann"}`); w.Code != http.StatusOK || codec.marshal != 1 {
+		t.Fatalf("expected the codec to encode the response, got %d %+v", w.Code, codec)
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ErrNotModified answers a GET with 304 Not Modified and no body when a handler returns it, see
+// CheckNotModified. It is not passed to OnError hooks.
+var ErrNotModified = errors.New("fluxo: not modified")
+
+// Freshness is the current state of a resource or a list, compared with the copy a client
+// already has to answer conditional GETs
+type Freshness struct {
+	ETag         string    // e.g. from CollectionETag
+	LastModified time.Time // when the resource, or the most recently updated item of the list, changed
+}
+
+// CollectionETag returns a weak ETag for a list from the update time of its most recently
+// updated item and its number of items, so that the ETag changes when items are added,
+// updated or deleted. parts tell apart lists of the same items, such as the lists of two users.
+func CollectionETag(lastModified time.Time, count int64, parts ...string) string {
+	h := sha256.New()
+	h.Write([]byte(strconv.FormatInt(lastModified.UnixNano(), 10) + "|" + strconv.FormatInt(count, 10)))
+	for _, part := range parts {
+		h.Write([]byte("|" + part))
+	}
+	return `W/"` + hex.EncodeToString(h.Sum(nil)[:12]) + `"`
+}
+
+// CheckNotModified sets the ETag and Last-Modified headers of the response from f, and returns
+// ErrNotModified when the GET or HEAD request's If-None-Match or If-Modified-Since header shows
+// the client's copy is current. Query the latest update first and skip loading the list:
+//
+//	latest, count, err := store.Latest(ctx, req.Filter)
+//	...
+//	if err := fluxo.CheckNotModified(ctx, fluxo.Freshness{ETag: fluxo.CollectionETag(latest, count), LastModified: latest}); err != nil {
+//		return nil, err
+//	}
+func CheckNotModified(ctx context.Context, f Freshness) error {
+	c := ginContextOf(ctx)
+	if c == nil || c.Request == nil {
+		return InternalServerError("fluxo: CheckNotModified needs the request's context")
+	}
+	SetETag(c, f.ETag)
+	if !f.LastModified.IsZero() {
+		c.Header("Last-Modified", f.LastModified.UTC().Format(http.TimeFormat))
+	}
+	if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+		return nil
+	}
+	if notModified(c.Request, f) {
+		return ErrNotModified
+	}
+	return nil
+}
+
+// notModified reports whether the conditional headers of req match f. If-None-Match takes
+// precedence over If-Modified-Since, as RFC 9110 asks.
+func notModified(req *http.Request, f Freshness) bool {
+	if header := req.Header.Get("If-None-Match"); header != "" {
+		return f.ETag != "" && etagMatchesWeak(header, f.ETag)
+	}
+	since, err := http.ParseTime(req.Header.Get("If-Modified-Since"))
+	if err != nil || f.LastModified.IsZero() {
+		return false
+	}
+	// HTTP dates have a resolution of a second
+	return !f.LastModified.Truncate(time.Second).After(since)
+}
+
+// etagMatchesWeak reports whether the If-None-Match header value matches etag, using the weak
+// comparison: W/"x" matches "x"
+func etagMatchesWeak(header, etag string) bool {
+	want := strings.TrimPrefix(quoteETag(etag), "W/")
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || strings.TrimPrefix(tag, "W/") == want {
+			return true
+		}
+	}
+	return false
+}
+
+// NotModified returns middleware answering conditional GET and HEAD requests: current returns
+// the freshness of what the route serves, often from a cheap query like max(updated_at), and
+// requests whose client has it already get 304 without running the handler. Others get the
+// ETag and Last-Modified headers of f, and go on.
+//
+//	app.GET("/todos", fluxo.NotModified(func(ctx *gin.Context) (fluxo.Freshness, error) {
+//		latest, count, err := store.Latest(ctx)
+//		return fluxo.Freshness{ETag: fluxo.CollectionETag(latest, count), LastModified: latest}, err
+//	}), fluxo.Handle(listTodos))
+//
+// Errors from current are written like handler errors. The headers and 304 are documented on
+// the GET operation.
+func NotModified(current func(ctx *gin.Context) (Freshness, error)) gin.HandlerFunc {
+	handler := func(ctx *gin.Context) {
+		if ctx.Request.Method != http.MethodGet && ctx.Request.Method != http.MethodHead {
+			return
+		}
+		f, err := current(ctx)
+		if err == nil {
+			err = CheckNotModified(ctx, f)
+		}
+		if err != nil {
+			writeError(ctx, err)
+			ctx.Abort()
+		}
+	}
+	registerOperationDoc(handler, documentNotModified)
+	return handler
+}
+
+func documentNotModified(sg *SwaggerGenerator, op *Operation) {
+	if method := sg.operationMethod(op); method != "GET" && method != "HEAD" {
+		return
+	}
+	for _, p := range []Parameter{
+		{Name: "If-None-Match", In: "header", Description: "ETag of the copy the client has", Schema: Schema{Type: "string"}},
+		{Name: "If-Modified-Since", In: "header", Description: "Last-Modified of the copy the client has", Schema: Schema{Type: "string"}},
+	} {
+		if !hasParameter(op.Parameters, p.Name, p.In) {
+			op.Parameters = append(op.Parameters, p)
+		}
+	}
+	if op.Responses == nil {
+		op.Responses = map[string]Response{}
+	}
+	for code, res := range op.Responses {
+		if !strings.HasPrefix(code, "2") {
+			continue
+		}
+		if res.Headers == nil {
+			res.Headers = map[string]Header{}
+		}
+		res.Headers["ETag"] = Header{Description: "Version of the response, to send in If-None-Match", Schema: Schema{Type: "string"}}
+		res.Headers["Last-Modified"] = Header{Description: "When the response last changed, to send in If-Modified-Since", Schema: Schema{Type: "string"}}
+		op.Responses[code] = res
+	}
+	op.Responses["304"] = Response{Description: "The client's copy is current"}
+}
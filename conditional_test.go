@@ -0,0 +1,111 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type conditionalTodo struct {
+	ID        int       `json:"id"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func conditionalGet(app *App, path string, header ...string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	for i := 0; i+1 < len(header); i += 2 {
+		req.Header.Set(header[i], header[i+1])
+	}
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, req)
+	return w
+}
+
+func TestNotModified_Middleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	latest := time.Date(2025, 3, 1, 12, 0, 0, 500, time.UTC)
+	todos := []conditionalTodo{{1, latest}}
+	loads := 0
+	app := New()
+	app.GET("/todos", NotModified(func(ctx *gin.Context) (Freshness, error) {
+		return Freshness{ETag: CollectionETag(latest, int64(len(todos))), LastModified: latest}, nil
+	}), Handle(func(ctx *Context, _ struct{}) ([]conditionalTodo, error) {
+		loads++
+		return todos, nil
+	}))
+
+	w := conditionalGet(app, "/todos")
+	etag, modified := w.Header().Get("ETag"), w.Header().Get("Last-Modified")
+	if w.Code != http.StatusOK || etag == "" || etag[:2] != `W/` || modified != "Sat, 01 Mar 2025 12:00:00 GMT" {
+		t.Fatalf("expected the list with its ETag and Last-Modified, got %d %v", w.Code, w.Header())
+	}
+
+	for _, header := range [][]string{
+		{"If-None-Match", etag},
+		{"If-None-Match", `"other", ` + etag[2:]}, // weak comparison
+		{"If-Modified-Since", modified},
+	} {
+		w = conditionalGet(app, "/todos", header...)
+		if w.Code != http.StatusNotModified || w.Body.Len() != 0 || w.Header().Get("ETag") != etag {
+			t.Errorf("%v: expected 304 without a body, got %d %q", header, w.Code, w.Body)
+		}
+	}
+	if loads != 1 {
+		t.Errorf("expected the handler skipped for current copies, ran %d times", loads)
+	}
+
+	// A deleted item changes the ETag, though not the latest update
+	todos = nil
+	if w = conditionalGet(app, "/todos", "If-None-Match", etag); w.Code != http.StatusOK {
+		t.Errorf("expected the changed list, got %d", w.Code)
+	}
+	// If-None-Match wins over If-Modified-Since
+	if w = conditionalGet(app, "/todos", "If-None-Match", etag, "If-Modified-Since", modified); w.Code != http.StatusOK {
+		t.Errorf("expected If-None-Match to decide, got %d", w.Code)
+	}
+	if w = conditionalGet(app, "/todos", "If-Modified-Since", "Sat, 01 Mar 2025 11:59:59 GMT"); w.Code != http.StatusOK {
+		t.Errorf("expected an older copy refreshed, got %d", w.Code)
+	}
+}
+
+func TestCheckNotModified_Handler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	latest := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	app := New()
+	reported := 0
+	app.OnError(func(*Context, error) { reported++ })
+	app.GET("/todos", Handle(func(ctx *Context, _ struct{}) ([]conditionalTodo, error) {
+		if err := CheckNotModified(ctx, Freshness{LastModified: latest}); err != nil {
+			return nil, err
+		}
+		return []conditionalTodo{{1, latest}}, nil
+	}))
+
+	w := conditionalGet(app, "/todos", "If-Modified-Since", "Sat, 01 Mar 2025 12:00:00 GMT")
+	if w.Code != http.StatusNotModified || w.Body.Len() != 0 || reported != 0 {
+		t.Errorf("expected a quiet 304, got %d %q, reported %d", w.Code, w.Body, reported)
+	}
+}
+
+func TestNotModified_Documented(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := New().WithSwagger("t", "v")
+	app.GET("/todos", NotModified(func(ctx *gin.Context) (Freshness, error) { return Freshness{}, nil }),
+		Handle(func(ctx *Context, _ struct{}) ([]conditionalTodo, error) { return nil, nil }))
+
+	op := groupSpec(t, app).Paths["/todos"].GET
+	if !hasParameter(op.Parameters, "If-None-Match", "header") || !hasParameter(op.Parameters, "If-Modified-Since", "header") {
+		t.Errorf("expected the conditional headers documented, got %+v", op.Parameters)
+	}
+	if _, ok := op.Responses["304"]; !ok || op.Responses["200"].Headers["Last-Modified"].Schema.Type != "string" {
+		t.Errorf("expected 304 and the validators documented, got %+v", op.Responses)
+	}
+	if err := app.Validate(); err != nil {
+		t.Error(err)
+	}
+}
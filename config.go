@@ -0,0 +1,425 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/goccy/go-yaml"
+)
+
+// ConfigEnvPrefix prefixes the environment variables LoadConfig reads, e.g. FLUXO_ADDR or
+// FLUXO_CORS_ALLOW_ORIGINS
+var ConfigEnvPrefix = "FLUXO_"
+
+// Config holds the settings of a deployment, see LoadConfig and NewFromConfig. Files and
+// environment variables name the fields after their config tags: cors.allow_origins in a file is
+// FLUXO_CORS_ALLOW_ORIGINS in the environment, lists there being comma-separated.
+type Config struct {
+	Env        string          `config:"env"`  // e.g. dev, staging or prod, see LoadConfig
+	Addr       string          `config:"addr"` // e.g. :8080
+	Mode       string          `config:"mode"` // gin mode: debug, release or test
+	Timeouts   Timeouts        `config:"timeouts"`
+	DrainDelay time.Duration   `config:"drain_delay"` // see WithDrainDelay
+	CORS       CORSConfig      `config:"cors"`        // CORS is off without origins
+	Docs       DocsConfig      `config:"docs"`
+	RateLimit  RateLimitConfig `config:"rate_limit"`
+}
+
+// Timeouts bound the phases of serving a request, zero meaning none, see WithTimeouts
+type Timeouts struct {
+	Read       time.Duration `config:"read"`        // reading the whole request, body included
+	ReadHeader time.Duration `config:"read_header"` // reading the request headers
+	Write      time.Duration `config:"write"`       // from the end of the headers to the end of the response
+	Idle       time.Duration `config:"idle"`        // keeping an idle connection open
+}
+
+// DocsConfig sets whether and where the OpenAPI spec and the Swagger UI are served
+type DocsConfig struct {
+	Enabled bool     `config:"enabled"`
+	Title   string   `config:"title"`
+	Version string   `config:"version"`
+	Path    string   `config:"path"`    // of the Swagger UI
+	Servers []Server `config:"servers"` // listed in the spec, e.g. the public URL of this environment
+}
+
+// RateLimitConfig limits the requests of each client IP, see RateLimit
+type RateLimitConfig struct {
+	Rate  float64 `config:"rate"` // requests per second, 0 for no limit
+	Burst int     `config:"burst"`
+}
+
+// DefaultConfig returns the settings LoadConfig starts from
+func DefaultConfig() Config {
+	return Config{
+		Addr:     ":8080",
+		Mode:     gin.ReleaseMode,
+		Timeouts: Timeouts{ReadHeader: 10 * time.Second, Idle: 2 * time.Minute},
+		Docs:     DocsConfig{Title: "API", Version: "1.0.0", Path: "/docs"},
+	}
+}
+
+// LoadConfig returns DefaultConfig overridden by the YAML (.yaml, .yml) or JSON (.json) files
+// at paths in order, then by the environment, and validated:
+//
+//	# config.yaml
+//	addr: ":9000"
+//	timeouts:
+//	  read: 5s
+//	cors:
+//	  allow_origins: [https://app.example.com]
+//	docs:
+//	  enabled: true
+//
+// A file's environments section overrides its settings in the environment named by env, or by
+// FLUXO_ENV, so one file can serve every deployment:
+//
+//	docs:
+//	  enabled: true
+//	  servers:
+//	    - url: http://localhost:8080
+//	environments:
+//	  staging:
+//	    docs:
+//	      servers:
+//	        - url: https://staging-api.example.com
+//	          description: Staging
+//	  prod:
+//	    docs:
+//	      enabled: false
+//
+// Unknown keys are errors, so that typos don't go unnoticed.
+func LoadConfig(paths ...string) (Config, error) {
+	cfg := DefaultConfig()
+	v := reflect.ValueOf(&cfg).Elem()
+	type overlay struct {
+		path string
+		envs map[string]any
+	}
+	var overlays []overlay
+	for _, p := range paths {
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return cfg, fmt.Errorf("fluxo: config: %w", err)
+		}
+		var doc map[string]any
+		switch ext := strings.ToLower(filepath.Ext(p)); ext {
+		case ".yaml", ".yml":
+			err = yaml.Unmarshal(data, &doc)
+		case ".json":
+			err = json.Unmarshal(data, &doc)
+		default:
+			return cfg, fmt.Errorf("fluxo: config %s: unsupported file type %q", p, ext)
+		}
+		if err != nil {
+			return cfg, fmt.Errorf("fluxo: config %s: %w", p, err)
+		}
+		if raw, ok := doc["environments"]; ok {
+			envs, ok := raw.(map[string]any)
+			if !ok {
+				return cfg, fmt.Errorf("fluxo: config %s: environments: want a mapping, got %v", p, raw)
+			}
+			overlays = append(overlays, overlay{p, envs})
+			delete(doc, "environments")
+		}
+		if err := decodeConfig(v, doc, ""); err != nil {
+			return cfg, fmt.Errorf("fluxo: config %s: %w", p, err)
+		}
+	}
+	if env, ok := os.LookupEnv(ConfigEnvPrefix + "ENV"); ok {
+		cfg.Env = env
+	}
+	for _, o := range overlays {
+		raw, ok := o.envs[cfg.Env]
+		if !ok || cfg.Env == "" {
+			continue
+		}
+		section, ok := raw.(map[string]any)
+		if !ok {
+			return cfg, fmt.Errorf("fluxo: config %s: environments.%s: want a mapping, got %v", o.path, cfg.Env, raw)
+		}
+		if err := decodeConfig(v, section, "environments."+cfg.Env+"."); err != nil {
+			return cfg, fmt.Errorf("fluxo: config %s: %w", o.path, err)
+		}
+	}
+	if err := configFromEnv(v, ConfigEnvPrefix); err != nil {
+		return cfg, fmt.Errorf("fluxo: config: %w", err)
+	}
+	return cfg, cfg.Validate()
+}
+
+// decodeConfig sets the fields of struct v from the keys of a config file
+func decodeConfig(v reflect.Value, doc map[string]any, prefix string) error {
+	for key, raw := range doc {
+		field, ok := configField(v, key)
+		if !ok {
+			return fmt.Errorf("unknown key %s%s", prefix, key)
+		}
+		if field.Kind() == reflect.Struct {
+			sub, ok := raw.(map[string]any)
+			if !ok {
+				return fmt.Errorf("%s%s: want a mapping, got %v", prefix, key, raw)
+			}
+			if err := decodeConfig(field, sub, prefix+key+"."); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := setConfigValue(field, raw); err != nil {
+			return fmt.Errorf("%s%s: %w", prefix, key, err)
+		}
+	}
+	return nil
+}
+
+func configField(v reflect.Value, key string) (reflect.Value, bool) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if tagName(t.Field(i), "config") == key {
+			return v.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// configFromEnv sets the fields of struct v from the environment variables named after them
+func configFromEnv(v reflect.Value, prefix string) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		key := tagName(t.Field(i), "config")
+		if key == "" {
+			continue
+		}
+		name := prefix + strings.ToUpper(key)
+		field := v.Field(i)
+		if field.Kind() == reflect.Struct {
+			if err := configFromEnv(field, name+"_"); err != nil {
+				return err
+			}
+			continue
+		}
+		if s, ok := os.LookupEnv(name); ok {
+			if err := setConfigValue(field, s); err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// setConfigValue sets field to a value from a file or, as a string, from the environment. From
+// the environment, a list of mappings is comma-separated values of their first field.
+func setConfigValue(field reflect.Value, raw any) error {
+	if field.Kind() == reflect.Slice && field.Type().Elem().Kind() == reflect.Struct {
+		items := reflect.MakeSlice(field.Type(), 0, 0)
+		switch x := raw.(type) {
+		case []any:
+			for i, item := range x {
+				m, ok := item.(map[string]any)
+				if !ok {
+					return fmt.Errorf("[%d]: want a mapping, got %v", i, item)
+				}
+				elem := reflect.New(field.Type().Elem()).Elem()
+				if err := decodeConfig(elem, m, fmt.Sprintf("[%d].", i)); err != nil {
+					return err
+				}
+				items = reflect.Append(items, elem)
+			}
+		case string:
+			for _, item := range strings.Split(x, ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					elem := reflect.New(field.Type().Elem()).Elem()
+					if err := setConfigValue(elem.Field(0), item); err != nil {
+						return err
+					}
+					items = reflect.Append(items, elem)
+				}
+			}
+		case nil:
+		default:
+			return fmt.Errorf("want a list, got %v", raw)
+		}
+		field.Set(items)
+		return nil
+	}
+	if field.Kind() == reflect.Slice {
+		var items []string
+		switch x := raw.(type) {
+		case []any:
+			for _, item := range x {
+				items = append(items, fmt.Sprint(item))
+			}
+		case string:
+			for _, item := range strings.Split(x, ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					items = append(items, item)
+				}
+			}
+		case nil:
+		default:
+			return fmt.Errorf("want a list, got %v", raw)
+		}
+		field.Set(reflect.ValueOf(items))
+		return nil
+	}
+
+	s := fmt.Sprint(raw)
+	switch {
+	case field.Type() == reflect.TypeOf(time.Duration(0)):
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(d))
+	case field.Kind() == reflect.String:
+		field.SetString(s)
+	case field.Kind() == reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case field.CanInt():
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case field.CanFloat():
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported type %s", field.Type())
+	}
+	return nil
+}
+
+// Validate reports every invalid setting of c
+func (c Config) Validate() error {
+	var errs []error
+	fail := func(key, format string, args ...any) {
+		errs = append(errs, fmt.Errorf("fluxo: config %s: "+format, append([]any{key}, args...)...))
+	}
+	if _, _, err := net.SplitHostPort(c.Addr); c.Addr != "" && err != nil {
+		fail("addr", "%q is not host:port", c.Addr)
+	}
+	switch c.Mode {
+	case "", gin.DebugMode, gin.ReleaseMode, gin.TestMode:
+	default:
+		fail("mode", "%q is not debug, release or test", c.Mode)
+	}
+	durations := []struct {
+		key string
+		d   time.Duration
+	}{
+		{"timeouts.read", c.Timeouts.Read},
+		{"timeouts.read_header", c.Timeouts.ReadHeader},
+		{"timeouts.write", c.Timeouts.Write},
+		{"timeouts.idle", c.Timeouts.Idle},
+		{"drain_delay", c.DrainDelay},
+		{"cors.max_age", c.CORS.MaxAge},
+	}
+	for _, d := range durations {
+		if d.d < 0 {
+			fail(d.key, "negative duration %s", d.d)
+		}
+	}
+	for _, origin := range c.CORS.AllowOrigins {
+		if origin == "*" {
+			if c.CORS.AllowCredentials {
+				fail("cors.allow_credentials", "credentials can't be allowed for any origin")
+			}
+			continue
+		}
+		if u, err := url.Parse(origin); err != nil || u.Scheme == "" || u.Host == "" || (u.Path != "" && u.Path != "/") {
+			fail("cors.allow_origins", "%q is not an origin like https://app.example.com", origin)
+		}
+	}
+	if c.Docs.Enabled {
+		if c.Docs.Title == "" {
+			fail("docs.title", "required when the docs are enabled")
+		}
+		if !strings.HasPrefix(c.Docs.Path, "/") {
+			fail("docs.path", "%q doesn't start with /", c.Docs.Path)
+		}
+	}
+	for _, server := range c.Docs.Servers {
+		u, err := url.Parse(server.URL)
+		if err != nil || (u.Host == "" && !strings.HasPrefix(server.URL, "/")) {
+			fail("docs.servers", "%q is neither an absolute URL nor a path", server.URL)
+		}
+	}
+	if c.RateLimit.Rate < 0 {
+		fail("rate_limit.rate", "negative rate %v", c.RateLimit.Rate)
+	}
+	if c.RateLimit.Burst < 0 {
+		fail("rate_limit.burst", "negative burst %d", c.RateLimit.Burst)
+	}
+	return errors.Join(errs...)
+}
+
+// NewFromConfig validates cfg and returns an app set up from it: gin mode, server timeouts and
+// address, drain delay, CORS, a rate limit per client IP and the docs with their servers. opts
+// apply after cfg.
+// Start the app with an empty address to listen on cfg.Addr:
+//
+//	cfg, err := fluxo.LoadConfig("config.yaml")
+//	...
+//	app, err := fluxo.NewFromConfig(cfg)
+//	...
+//	log.Fatal(app.Start(""))
+func NewFromConfig(cfg Config, opts ...AppOption) (*App, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	base := []AppOption{WithAddr(cfg.Addr), WithTimeouts(cfg.Timeouts), WithDrainDelay(cfg.DrainDelay)}
+	if cfg.Mode != "" {
+		base = append(base, WithMode(cfg.Mode))
+	}
+	app := New(append(base, opts...)...)
+	if len(cfg.CORS.AllowOrigins) > 0 {
+		app.Use(CORS(cfg.CORS))
+	}
+	if cfg.Docs.Enabled {
+		var docs []SwaggerOption
+		for _, server := range cfg.Docs.Servers {
+			docs = append(docs, WithServer(server.URL, server.Description))
+		}
+		app.withSwagger(cfg.Docs.Path, cfg.Docs.Title, cfg.Docs.Version, docs...)
+	}
+	if cfg.RateLimit.Rate > 0 {
+		// Only routes registered from now on are limited, which leaves out the docs
+		app.UseTyped(RateLimit(cfg.RateLimit.Rate, cfg.RateLimit.Burst))
+	}
+	return app, nil
+}
+
+// WithAddr sets the address Start and StartTLS listen on when given an empty one, instead of
+// :$PORT or :8080
+func WithAddr(addr string) AppOption {
+	return func(c *appConfig) {
+		c.addr = addr
+	}
+}
+
+// WithTimeouts bounds the phases of serving requests, see Timeouts. Without it, only the
+// defaults of http.Server apply, which are none.
+func WithTimeouts(t Timeouts) AppOption {
+	return func(c *appConfig) {
+		c.timeouts = t
+	}
+}
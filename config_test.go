@@ -0,0 +1,203 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func writeConfig(t *testing.T, name, content string) string {
+	t.Helper()
+	p := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(p, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestLoadConfig(t *testing.T) {
+	yamlFile := writeConfig(t, "config.yaml", `
+addr: ":9000"
+mode: test
+timeouts:
+  read: 5s
+cors:
+  allow_origins: [https://app.example.com]
+  max_age: 1h
+docs:
+  enabled: true
+rate_limit:
+  rate: 2.5
+  burst: 5
+`)
+	jsonFile := writeConfig(t, "override.json", `{"timeouts": {"write": "30s"}, "docs": {"title": "Todos"}}`)
+	t.Setenv("FLUXO_ADDR", ":9100")
+	t.Setenv("FLUXO_CORS_ALLOW_ORIGINS", "https://a.example.com, https://b.example.com")
+
+	cfg, err := LoadConfig(yamlFile, jsonFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := DefaultConfig()
+	want.Addr, want.Mode = ":9100", gin.TestMode
+	want.Timeouts.Read, want.Timeouts.Write = 5*time.Second, 30*time.Second
+	want.CORS = CORSConfig{AllowOrigins: []string{"https://a.example.com", "https://b.example.com"}, MaxAge: time.Hour}
+	want.Docs.Enabled, want.Docs.Title = true, "Todos"
+	want.RateLimit = RateLimitConfig{Rate: 2.5, Burst: 5}
+	if !reflect.DeepEqual(cfg, want) {
+		t.Errorf("cfg = %+v\nwant %+v", cfg, want)
+	}
+}
+
+func TestLoadConfig_Errors(t *testing.T) {
+	cases := map[string]string{
+		"config.yaml": "cors:\n  allow_origin: [https://app.example.com]\n",
+		"bad.json":    `{"timeouts": {"read": "5"}}`,
+		"config.toml": "addr = ':80'",
+	}
+	for name, content := range cases {
+		if _, err := LoadConfig(writeConfig(t, name, content)); err == nil {
+			t.Errorf("%s: loaded %q", name, content)
+		}
+	}
+	t.Setenv("FLUXO_DOCS_ENABLED", "maybe")
+	if _, err := LoadConfig(); err == nil || !strings.Contains(err.Error(), "FLUXO_DOCS_ENABLED") {
+		t.Errorf("env err = %v", err)
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Addr = "8080"
+	cfg.Mode = "prod"
+	cfg.Timeouts.Read = -time.Second
+	cfg.CORS = CORSConfig{AllowOrigins: []string{"*", "app.example.com"}, AllowCredentials: true}
+	cfg.Docs = DocsConfig{Enabled: true, Path: "docs"}
+	cfg.RateLimit.Rate = -1
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("invalid config accepted")
+	}
+	for _, key := range []string{"addr", "mode", "timeouts.read", "cors.allow_credentials", "cors.allow_origins", "docs.title", "docs.path", "rate_limit.rate"} {
+		if !strings.Contains(err.Error(), "config "+key+":") {
+			t.Errorf("no error for %s in %v", key, err)
+		}
+	}
+	if err := DefaultConfig().Validate(); err != nil {
+		t.Errorf("default config: %v", err)
+	}
+}
+
+func TestNewFromConfig(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Mode = gin.TestMode
+	cfg.CORS.AllowOrigins = []string{"https://app.example.com"}
+	cfg.Docs = DocsConfig{Enabled: true, Title: "Todos", Version: "2.0.0", Path: "/api-docs"}
+	cfg.RateLimit = RateLimitConfig{Rate: 1, Burst: 1}
+	app, err := NewFromConfig(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	app.GET("/todos", Handle(func(ctx *Context, _ struct{}) ([]string, error) {
+		return []string{}, nil
+	}))
+	if app.addr != ":8080" || app.timeouts.ReadHeader != 10*time.Second {
+		t.Errorf("server settings = %q, %+v", app.addr, app.timeouts)
+	}
+
+	req := httptest.NewRequest("GET", "/todos", nil)
+	req.Header.Set("Origin", "https://app.example.com")
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, req)
+	if w.Code != http.StatusOK || w.Header().Get("Access-Control-Allow-Origin") != "https://app.example.com" {
+		t.Errorf("GET /todos = %d, headers %v", w.Code, w.Header())
+	}
+	if w := get(app, "/todos"); w.Code != http.StatusTooManyRequests {
+		t.Errorf("second GET /todos = %d, want 429", w.Code)
+	}
+
+	spec, err := app.swagger.parsedSpec(app.specHandlers())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if spec.Info.Title != "Todos" || spec.Paths["/todos"].GET.Responses["429"].Description == "" {
+		t.Errorf("spec info = %+v, operation %+v", spec.Info, spec.Paths["/todos"].GET)
+	}
+	if w := get(app, "/api-docs"); w.Code != http.StatusOK {
+		t.Errorf("GET /api-docs = %d", w.Code)
+	}
+
+	cfg.Mode = "prod"
+	if _, err := NewFromConfig(cfg); err == nil {
+		t.Error("invalid config accepted")
+	}
+}
+
+func TestLoadConfig_Environments(t *testing.T) {
+	file := writeConfig(t, "config.yaml", `
+env: dev
+docs:
+  enabled: true
+  servers:
+    - url: http://localhost:8080
+environments:
+  staging:
+    docs:
+      servers:
+        - url: https://staging-api.example.com
+          description: Staging
+  prod:
+    docs:
+      enabled: false
+`)
+	cfg, err := LoadConfig(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cfg.Docs.Enabled || !reflect.DeepEqual(cfg.Docs.Servers, []Server{{URL: "http://localhost:8080"}}) {
+		t.Errorf("dev docs = %+v", cfg.Docs)
+	}
+
+	t.Setenv("FLUXO_ENV", "staging")
+	if cfg, err = LoadConfig(file); err != nil {
+		t.Fatal(err)
+	}
+	want := []Server{{URL: "https://staging-api.example.com", Description: "Staging"}}
+	if cfg.Env != "staging" || !reflect.DeepEqual(cfg.Docs.Servers, want) {
+		t.Errorf("staging = %q, docs %+v", cfg.Env, cfg.Docs)
+	}
+	app, err := NewFromConfig(cfg, WithMode(gin.TestMode))
+	if err != nil {
+		t.Fatal(err)
+	}
+	spec, err := app.swagger.parsedSpec(app.specHandlers())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(spec.Servers, want) {
+		t.Errorf("spec servers = %+v", spec.Servers)
+	}
+
+	t.Setenv("FLUXO_ENV", "prod")
+	t.Setenv("FLUXO_DOCS_SERVERS", "https://api.example.com")
+	if cfg, err = LoadConfig(file); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Docs.Enabled || !reflect.DeepEqual(cfg.Docs.Servers, []Server{{URL: "https://api.example.com"}}) {
+		t.Errorf("prod docs = %+v", cfg.Docs)
+	}
+
+	cfg.Docs.Servers = []Server{{URL: "api.example.com"}}
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "docs.servers") {
+		t.Errorf("host without scheme: err = %v", err)
+	}
+}
@@ -0,0 +1,447 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ValidateRequests returns middleware checking requests against the app's OpenAPI spec before
+// the handlers after it run: required parameters are present, parameter values match the type,
+// format and enum of their schema, the body has a documented content type and JSON bodies match
+// their schema. Mismatches are rejected with 400, or 415 for an undocumented content type.
+//
+// Typed handlers validate their requests already, so it is mostly a safety net for raw gin
+// handlers documented with App.OnSpec. Routes missing from the spec are let through.
+func ValidateRequests() gin.HandlerFunc {
+	return validateRequest
+}
+
+func validateRequest(ctx *gin.Context) {
+	spec, op, ok := specOperation(ctx)
+	if !ok {
+		return
+	}
+
+	var problems []string
+	for _, p := range op.Parameters {
+		problems = append(problems, checkParameter(ctx, spec, p)...)
+	}
+
+	if op.RequestBody != nil {
+		status, bodyProblems := checkRequestBody(ctx, spec, op.RequestBody)
+		if status == http.StatusUnsupportedMediaType {
+			ctx.AbortWithStatusJSON(status, gin.H{"error": bodyProblems[0]})
+			return
+		}
+		problems = append(problems, bodyProblems...)
+	}
+
+	if len(problems) > 0 {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Request does not match the API spec: %s", strings.Join(problems, "; "))})
+	}
+}
+
+// SpecMismatch is a response found not to match the spec by ValidateResponses
+type SpecMismatch struct {
+	Method   string
+	Route    string // the route path, like /users/:id
+	Status   int
+	Problems []string
+}
+
+func (m SpecMismatch) Error() string {
+	return fmt.Sprintf("%s %s responded %d not matching the API spec: %s", m.Method, m.Route, m.Status, strings.Join(m.Problems, "; "))
+}
+
+// sampleBodyLimit is the largest response body ValidateResponses checks
+const sampleBodyLimit = 1 << 20
+
+// ValidateResponses returns middleware checking a share of responses against the app's OpenAPI
+// spec, rate being between 0 and 1 (0.01 checks 1% of them). A sampled response is still sent
+// as it is written; once it is done, its status, content type and JSON body are checked against
+// the documented operation and mismatches are passed to report (which may be nil) and counted
+// in the route's Stats, catching drift between the code and its docs in production:
+//
+//	app.Use(fluxo.ValidateResponses(0.01, func(ctx *gin.Context, m fluxo.SpecMismatch) {
+//		slog.Warn("spec drift", "error", m)
+//	}))
+func ValidateResponses(rate float64, report func(ctx *gin.Context, m SpecMismatch)) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if rate <= 0 || (rate < 1 && rand.Float64() >= rate) {
+			return
+		}
+		w := &copyWriter{ResponseWriter: ctx.Writer, limit: sampleBodyLimit}
+		ctx.Writer = w
+		ctx.Next()
+		ctx.Writer = w.ResponseWriter
+
+		spec, op, ok := specOperation(ctx)
+		if !ok || w.truncated {
+			return
+		}
+		problems := checkResponse(spec, op, w.Status(), w.Header().Get("Content-Type"), w.body.Bytes())
+		if len(problems) == 0 {
+			return
+		}
+		if a, ok := appFrom(ctx); ok {
+			a.stats.mismatch(ctx.Request.Method + " " + ctx.FullPath())
+		}
+		if report != nil {
+			report(ctx, SpecMismatch{Method: ctx.Request.Method, Route: ctx.FullPath(), Status: w.Status(), Problems: problems})
+		}
+	}
+}
+
+// copyWriter keeps a copy of the response written through it, up to limit bytes
+type copyWriter struct {
+	gin.ResponseWriter
+	limit     int
+	body      bytes.Buffer
+	truncated bool // the body is over limit and is not kept
+}
+
+func (w *copyWriter) Write(data []byte) (int, error) {
+	w.keep(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *copyWriter) WriteString(s string) (int, error) {
+	w.keep([]byte(s))
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *copyWriter) keep(data []byte) {
+	if w.truncated || w.body.Len()+len(data) > w.limit {
+		w.truncated = true
+		w.body.Reset()
+		return
+	}
+	w.body.Write(data)
+}
+
+// checkResponse returns how a response differs from what op documents
+func checkResponse(spec *OpenAPISpec, op *Operation, status int, contentType string, body []byte) []string {
+	code := strconv.Itoa(status)
+	res, ok := op.Responses[code]
+	if !ok {
+		res, ok = op.Responses[code[:1]+"XX"]
+	}
+	if !ok {
+		res, ok = op.Responses["default"]
+	}
+	if !ok {
+		return []string{fmt.Sprintf("status %d is not documented", status)}
+	}
+	if len(body) == 0 || len(res.Content) == 0 {
+		return nil
+	}
+
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	mediaType = strings.TrimSpace(mediaType)
+	media, ok := matchMediaType(res.Content, mediaType)
+	if !ok {
+		return []string{fmt.Sprintf("Content-Type %q is not documented for status %d", mediaType, status)}
+	}
+	if !isJSONMediaType(mediaType) {
+		return nil
+	}
+	var v any
+	if err := json.Unmarshal(body, &v); err != nil {
+		return []string{fmt.Sprintf("body is not valid JSON: %v", err)}
+	}
+	var problems []string
+	checkValue(spec, media.Schema, v, "body", &problems)
+	return problems
+}
+
+// specOperation returns the spec of the app serving the request and the operation documenting
+// its route
+func specOperation(ctx *gin.Context) (*OpenAPISpec, *Operation, bool) {
+	a, ok := appFrom(ctx)
+	if !ok || a.swagger == nil || ctx.FullPath() == "" {
+		return nil, nil, false
+	}
+	spec, err := a.swagger.parsedSpec(a.specHandlers())
+	if err != nil {
+		return nil, nil, false
+	}
+	op := spec.Paths[openAPIPath(ctx.FullPath())].operation(ctx.Request.Method)
+	return spec, op, op != nil
+}
+
+// checkParameter checks the value the request gives parameter p
+func checkParameter(ctx *gin.Context, spec *OpenAPISpec, p Parameter) []string {
+	where := p.In + " parameter " + p.Name
+	var values []string
+	switch p.In {
+	case "path":
+		if v := ctx.Param(p.Name); v != "" {
+			values = []string{v}
+		}
+	case "query":
+		values = ctx.QueryArray(p.Name)
+	case "header":
+		values = ctx.Request.Header.Values(p.Name)
+	case "cookie":
+		if v, err := ctx.Cookie(p.Name); err == nil {
+			values = []string{v}
+		}
+	}
+	if len(values) == 0 {
+		if p.Required {
+			return []string{where + " is required"}
+		}
+		return nil
+	}
+
+	schema := resolveSchema(spec, p.Schema)
+	var problems []string
+	if schema.Type == "array" && schema.Items != nil {
+		items := resolveSchema(spec, *schema.Items)
+		var decoded []any
+		for _, raw := range values {
+			v, err := parameterValue(items, raw)
+			if err != nil {
+				return []string{fmt.Sprintf("%s %v", where, err)}
+			}
+			decoded = append(decoded, v)
+		}
+		checkValue(spec, schema, decoded, where, &problems)
+		return problems
+	}
+
+	v, err := parameterValue(schema, values[0])
+	if err != nil {
+		return []string{fmt.Sprintf("%s %v", where, err)}
+	}
+	checkValue(spec, schema, v, where, &problems)
+	return problems
+}
+
+// parameterValue decodes raw as the type of schema
+func parameterValue(schema Schema, raw string) (any, error) {
+	switch schema.Type {
+	case "integer":
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("must be an integer, got %q", raw)
+		}
+		return float64(n), nil
+	case "number":
+		n, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("must be a number, got %q", raw)
+		}
+		return n, nil
+	case "boolean":
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("must be a boolean, got %q", raw)
+		}
+		return b, nil
+	}
+	return raw, nil
+}
+
+// checkRequestBody checks the request's content type is documented and that a JSON body
+// matches its schema. An undocumented content type is reported with 415.
+func checkRequestBody(ctx *gin.Context, spec *OpenAPISpec, body *RequestBody) (int, []string) {
+	if ctx.Request.Body == nil || ctx.Request.Body == http.NoBody || ctx.Request.ContentLength == 0 {
+		if body.Required {
+			return http.StatusBadRequest, []string{"request body is required"}
+		}
+		return http.StatusOK, nil
+	}
+
+	contentType := ctx.ContentType()
+	media, ok := matchMediaType(body.Content, contentType)
+	if !ok {
+		accepted := slices.Sorted(maps.Keys(body.Content))
+		return http.StatusUnsupportedMediaType, []string{fmt.Sprintf("Content-Type %q is not accepted, expected one of %s", contentType, strings.Join(accepted, ", "))}
+	}
+	if !isJSONMediaType(contentType) {
+		return http.StatusOK, nil
+	}
+
+	data, err := io.ReadAll(ctx.Request.Body)
+	if err != nil {
+		return http.StatusBadRequest, []string{fmt.Sprintf("reading body failed: %v", err)}
+	}
+	ctx.Request.Body = io.NopCloser(bytes.NewReader(data))
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return http.StatusBadRequest, []string{fmt.Sprintf("body is not valid JSON: %v", err)}
+	}
+	var problems []string
+	checkValue(spec, media.Schema, v, "body", &problems)
+	return http.StatusOK, problems
+}
+
+// matchMediaType returns the content documented for contentType, trying type/* and */* ranges
+// after an exact match
+func matchMediaType(content map[string]MediaType, contentType string) (MediaType, bool) {
+	contentType = strings.ToLower(contentType)
+	major, _, _ := strings.Cut(contentType, "/")
+	for _, key := range []string{contentType, major + "/*", "*/*"} {
+		if media, ok := content[key]; ok {
+			return media, true
+		}
+	}
+	return MediaType{}, false
+}
+
+// isJSONMediaType reports whether mediaType is JSON, including types like application/problem+json
+func isJSONMediaType(mediaType string) bool {
+	mediaType = strings.ToLower(mediaType)
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// resolveSchema follows s to the component schema it refers to, if any
+func resolveSchema(spec *OpenAPISpec, s Schema) Schema {
+	for i := 0; s.Ref != "" && i < 32; i++ {
+		name := strings.TrimPrefix(s.Ref, "#/components/schemas/")
+		resolved, ok := spec.Components.Schemas[name]
+		if !ok {
+			break
+		}
+		s = resolved
+	}
+	return s
+}
+
+var uuidRe = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// checkValue appends to problems how v, decoded from JSON, does not match schema s. Null is
+// accepted anywhere, as Go encodes nil pointers, slices and maps as null.
+func checkValue(spec *OpenAPISpec, s Schema, v any, where string, problems *[]string) {
+	s = resolveSchema(spec, s)
+	if v == nil {
+		return
+	}
+	fail := func(format string, args ...any) {
+		*problems = append(*problems, where+" "+fmt.Sprintf(format, args...))
+	}
+
+	for _, sub := range s.AllOf {
+		checkValue(spec, sub, v, where, problems)
+	}
+	if len(s.OneOf) > 0 {
+		matches := 0
+		for _, sub := range s.OneOf {
+			var subProblems []string
+			checkValue(spec, sub, v, where, &subProblems)
+			if len(subProblems) == 0 {
+				matches++
+			}
+		}
+		if matches != 1 {
+			fail("must match exactly one of its %d schemas, matches %d", len(s.OneOf), matches)
+		}
+	}
+	if len(s.Enum) > 0 && !slices.ContainsFunc(s.Enum, func(e any) bool { return reflect.DeepEqual(e, v) }) {
+		fail("must be one of %s", enumList(s.Enum))
+		return
+	}
+
+	switch s.Type {
+	case "string":
+		str, ok := v.(string)
+		if !ok {
+			fail("must be a string")
+			return
+		}
+		checkFormat(s.Format, str, fail)
+	case "integer":
+		if n, ok := v.(float64); !ok || n != math.Trunc(n) {
+			fail("must be an integer")
+		}
+	case "number":
+		if _, ok := v.(float64); !ok {
+			fail("must be a number")
+		}
+	case "boolean":
+		if _, ok := v.(bool); !ok {
+			fail("must be a boolean")
+		}
+	case "array":
+		items, ok := v.([]any)
+		if !ok {
+			fail("must be an array")
+			return
+		}
+		if s.MaxItems != nil && len(items) > *s.MaxItems {
+			fail("must have at most %d items", *s.MaxItems)
+		}
+		if s.Items != nil {
+			for i, item := range items {
+				checkValue(spec, *s.Items, item, fmt.Sprintf("%s[%d]", where, i), problems)
+			}
+		}
+	case "object":
+		obj, ok := v.(map[string]any)
+		if !ok {
+			fail("must be an object")
+			return
+		}
+		for _, name := range s.Required {
+			if _, ok := obj[name]; !ok {
+				fail("is missing required property %q", name)
+			}
+		}
+		for _, name := range slices.Sorted(maps.Keys(obj)) {
+			if prop, ok := s.Properties[name]; ok {
+				checkValue(spec, prop, obj[name], where+"."+name, problems)
+			} else if s.AdditionalProperties != nil {
+				checkValue(spec, *s.AdditionalProperties, obj[name], where+"."+name, problems)
+			}
+		}
+	}
+}
+
+// checkFormat checks the formats a string value can be validated by
+func checkFormat(format, s string, fail func(format string, args ...any)) {
+	switch format {
+	case "date-time":
+		if _, err := time.Parse(time.RFC3339, s); err != nil {
+			fail("must be an RFC 3339 date-time")
+		}
+	case "date":
+		if _, err := time.Parse(time.DateOnly, s); err != nil {
+			fail("must be a date like 2006-01-02")
+		}
+	case "uuid":
+		if !uuidRe.MatchString(s) {
+			fail("must be a UUID")
+		}
+	case "byte":
+		if _, err := base64.StdEncoding.DecodeString(s); err != nil {
+			fail("must be base64 encoded")
+		}
+	}
+}
+
+// enumList formats enum values for messages
+func enumList(values []any) string {
+	list := make([]string, len(values))
+	for i, v := range values {
+		list[i] = fmt.Sprint(v)
+	}
+	return strings.Join(list, ", ")
+}
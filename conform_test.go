@@ -0,0 +1,140 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type conformItem struct {
+	Name  string   `json:"name" validate:"required"`
+	Count int      `json:"count"`
+	Tags  []string `json:"tags"`
+}
+
+func conformApp() *App {
+	gin.SetMode(gin.TestMode)
+	app := New().WithSwagger("t", "v")
+	app.Use(ValidateRequests())
+	app.POST("/typed", Handle(func(ctx *Context, req conformItem) (conformItem, error) { return req, nil }))
+	app.PUT("/legacy/:id", func(ctx *gin.Context) { ctx.JSON(http.StatusOK, gin.H{"ok": true}) })
+	app.GET("/raw", func(ctx *gin.Context) { ctx.String(http.StatusOK, "raw") })
+
+	// The legacy route is a raw gin handler documented by hand
+	app.OnSpec(func(spec *OpenAPISpec) {
+		spec.Paths["/legacy/{id}"] = PathItem{PUT: &Operation{
+			Parameters: []Parameter{
+				{Name: "id", In: "path", Required: true, Schema: Schema{Type: "integer"}},
+				{Name: "mode", In: "query", Schema: Schema{Type: "string", Enum: []any{"fast", "safe"}}},
+				{Name: "X-Trace", In: "header", Schema: Schema{Type: "string", Format: "uuid"}},
+				{Name: "X-Tenant", In: "header", Required: true, Schema: Schema{Type: "string"}},
+			},
+			RequestBody: &RequestBody{Required: true, Content: map[string]MediaType{
+				"application/json": {Schema: Schema{Ref: "#/components/schemas/conformItem"}},
+			}},
+			Responses: map[string]Response{"200": {Description: "OK"}},
+		}}
+	})
+	return app
+}
+
+func TestValidateRequests(t *testing.T) {
+	app := conformApp()
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		header map[string]string
+		body   string
+		code   int
+		want   string
+	}{
+		{"valid", "PUT", "/legacy/7?mode=fast", map[string]string{"X-Tenant": "a"}, `{"name":"x","count":2,"tags":["a"]}`, http.StatusOK, `"ok":true`},
+		{"path type", "PUT", "/legacy/seven", map[string]string{"X-Tenant": "a"}, `{"name":"x"}`, http.StatusBadRequest, `path parameter id must be an integer, got \"seven\"`},
+		{"enum", "PUT", "/legacy/7?mode=slow", map[string]string{"X-Tenant": "a"}, `{"name":"x"}`, http.StatusBadRequest, "query parameter mode must be one of fast, safe"},
+		{"missing header", "PUT", "/legacy/7", nil, `{"name":"x"}`, http.StatusBadRequest, "header parameter X-Tenant is required"},
+		{"format", "PUT", "/legacy/7", map[string]string{"X-Tenant": "a", "X-Trace": "nope"}, `{"name":"x"}`, http.StatusBadRequest, "header parameter X-Trace must be a UUID"},
+		{"body schema", "PUT", "/legacy/7", map[string]string{"X-Tenant": "a"}, `{"count":1.5,"tags":[1]}`, http.StatusBadRequest, `body is missing required property \"name\"; body.count must be an integer; body.tags[0] must be a string`},
+		{"missing body", "PUT", "/legacy/7", map[string]string{"X-Tenant": "a"}, ``, http.StatusBadRequest, "request body is required"},
+		{"content type", "PUT", "/legacy/7", map[string]string{"X-Tenant": "a", "Content-Type": "text/plain"}, `x`, http.StatusUnsupportedMediaType, `Content-Type \"text/plain\" is not accepted, expected one of application/json`},
+		{"body left for the handler", "POST", "/typed", nil, `{"name":"a"}`, http.StatusOK, `"name":"a"`},
+		{"typed route", "POST", "/typed", nil, `{"name":1}`, http.StatusBadRequest, "body.name must be a string"},
+		{"undocumented route", "GET", "/raw", nil, ``, http.StatusOK, "raw"},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+		req.Header.Set("Content-Type", "application/json")
+		for k, v := range tt.header {
+			req.Header.Set(k, v)
+		}
+		app.ServeHTTP(w, req)
+		if w.Code != tt.code || !strings.Contains(w.Body.String(), tt.want) {
+			t.Errorf("%s: expected %d with %q, got %d %s", tt.name, tt.code, tt.want, w.Code, w.Body.String())
+		}
+	}
+}
+
+func TestValidateResponses(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var reports []SpecMismatch
+	app := New().WithSwagger("t", "v")
+	app.Use(ValidateResponses(1, func(ctx *gin.Context, m SpecMismatch) { reports = append(reports, m) }))
+	app.GET("/typed", Handle(func(ctx *Context, req struct{}) (conformItem, error) { return conformItem{Name: "a"}, nil }))
+	app.GET("/legacy/:id", func(ctx *gin.Context) {
+		if ctx.Param("id") == "teapot" {
+			ctx.Status(http.StatusTeapot)
+			return
+		}
+		ctx.JSON(http.StatusOK, gin.H{"name": 1, "count": "two"})
+	})
+	app.OnSpec(func(spec *OpenAPISpec) {
+		spec.Paths["/legacy/{id}"] = PathItem{GET: &Operation{Responses: map[string]Response{
+			"200": {Description: "OK", Content: map[string]MediaType{"application/json": {Schema: Schema{Ref: "#/components/schemas/conformItem"}}}},
+		}}}
+	})
+
+	for _, path := range []string{"/typed", "/legacy/1", "/legacy/teapot"} {
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		if path == "/legacy/1" && !strings.Contains(w.Body.String(), `"count":"two"`) {
+			t.Errorf("expected the response to be sent unchanged, got %s", w.Body.String())
+		}
+	}
+
+	if len(reports) != 2 {
+		t.Fatalf("expected two mismatches, got %+v", reports)
+	}
+	if got := reports[0].Error(); got != `GET /legacy/:id responded 200 not matching the API spec: body.count must be an integer; body.name must be a string` {
+		t.Errorf("unexpected report %q", got)
+	}
+	if got := reports[1].Problems; len(got) != 1 || got[0] != "status 418 is not documented" {
+		t.Errorf("unexpected problems %v", got)
+	}
+	for _, rs := range app.Stats() {
+		if want := map[string]uint64{"/legacy/:id": 2}[rs.Path]; rs.SpecMismatches != want {
+			t.Errorf("%s: expected %d mismatches in stats, got %d", rs.Path, want, rs.SpecMismatches)
+		}
+	}
+}
+
+func TestValidateResponses_Rate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	checked := 0
+	app := New().WithSwagger("t", "v")
+	app.Use(ValidateResponses(0, func(ctx *gin.Context, m SpecMismatch) { checked++ }))
+	app.GET("/x", func(ctx *gin.Context) { ctx.Status(http.StatusTeapot) })
+	app.OnSpec(func(spec *OpenAPISpec) {
+		spec.Paths["/x"] = PathItem{GET: &Operation{Responses: map[string]Response{"200": {Description: "OK"}}}}
+	})
+	app.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/x", nil))
+	if checked != 0 {
+		t.Fatal("expected no response to be sampled at rate 0")
+	}
+}
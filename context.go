@@ -0,0 +1,157 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"context"
+	"reflect"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Key is a typed key for a request-scoped value. Middleware sets it on the gin context and
+// handlers read it back from a *Context or, with HandleCtx, a plain context.Context:
+//
+//	var UserKey = fluxo.NewKey[User]("user")
+//
+//	UserKey.Set(ctx, user)           // in middleware
+//	user, ok := UserKey.Get(ctx)     // in a HandleCtx handler
+//	ctx = UserKey.With(ctx, user)    // in a unit test
+type Key[T any] struct {
+	name string
+}
+
+// NewKey returns a new key; every key is distinct, whatever its name
+func NewKey[T any](name string) *Key[T] {
+	return &Key[T]{name: name}
+}
+
+// String returns the key's name
+func (k *Key[T]) String() string {
+	return k.name
+}
+
+// Set stores v on the request
+func (k *Key[T]) Set(ctx *gin.Context, v T) {
+	ctx.Set(k, v)
+}
+
+// With returns a copy of ctx carrying v
+func (k *Key[T]) With(ctx context.Context, v T) context.Context {
+	return context.WithValue(ctx, k, v)
+}
+
+// Get returns the value stored under k in ctx, which may be a *gin.Context, a *Context or the
+// context passed to a HandleCtx handler
+func (k *Key[T]) Get(ctx context.Context) (T, bool) {
+	var v any
+	switch c := ctx.(type) {
+	case *gin.Context:
+		v, _ = c.Get(k)
+	case *Context:
+		v, _ = c.Get(k)
+	default:
+		v = ctx.Value(k)
+	}
+	t, ok := v.(T)
+	return t, ok
+}
+
+// MustGet is like Get but panics when the value is missing, e.g. when the middleware setting it
+// is not installed
+func (k *Key[T]) MustGet(ctx context.Context) T {
+	v, ok := k.Get(ctx)
+	if !ok {
+		panic("fluxo: no value for key " + k.name)
+	}
+	return v
+}
+
+// typeKeys holds the Key of each type given to Provide, by reflect.Type
+var typeKeys sync.Map
+
+// typeKey returns the Key that Provide and Use store values of type T under; each T has its own
+func typeKey[T any]() *Key[T] {
+	t := reflect.TypeFor[T]()
+	if k, ok := typeKeys.Load(t); ok {
+		return k.(*Key[T])
+	}
+	k, _ := typeKeys.LoadOrStore(t, NewKey[T](t.String()))
+	return k.(*Key[T])
+}
+
+// Provide stores v on the request under its type T, for the handlers after the middleware to
+// Use without string keys or type assertions:
+//
+//	type CurrentUser struct{ ID, Role string }
+//
+//	app.Use(func(ctx *gin.Context) {
+//		fluxo.Provide(ctx, CurrentUser{ID: "42", Role: "admin"})
+//	})
+//	app.GET("/me", fluxo.Handle(func(ctx *fluxo.Context, req MeReq) (CurrentUser, error) {
+//		return fluxo.MustUse[CurrentUser](ctx), nil
+//	}))
+//
+// Values of distinct types never clash, so provide named types rather than strings or ints,
+// and give T explicitly for interfaces: fluxo.Provide[Store](ctx, pg). Use a Key for several
+// values of one type. ctx is a *gin.Context, a *Context or the context of a HandleCtx handler.
+func Provide[T any](ctx context.Context, v T) {
+	c := ginContextOf(ctx)
+	if c == nil {
+		panic("fluxo: Provide needs the request's context")
+	}
+	typeKey[T]().Set(c, v)
+}
+
+// Use returns the value of type T provided for the request, see Provide
+func Use[T any](ctx context.Context) (T, bool) {
+	return typeKey[T]().Get(ctx)
+}
+
+// MustUse is like Use but panics when no value of type T was provided, e.g. when the middleware
+// providing it is not installed
+func MustUse[T any](ctx context.Context) T {
+	v, ok := Use[T](ctx)
+	if !ok {
+		panic("fluxo: no " + reflect.TypeFor[T]().String() + " provided")
+	}
+	return v
+}
+
+// WithProvided returns a copy of ctx carrying v for Use, to call HandleCtx handlers in tests
+func WithProvided[T any](ctx context.Context, v T) context.Context {
+	return typeKey[T]().With(ctx, v)
+}
+
+// HandleCtx is Handle for business logic that should not depend on gin. The handler gets the
+// request's context, which also carries the values middleware stored on the gin context (Key
+// values and string keys alike):
+//
+//	func CreateOrder(ctx context.Context, req CreateOrderReq) (Order, error) {
+//		user := UserKey.MustGet(ctx)
+//		...
+//	}
+//
+//	app.POST("/orders", fluxo.HandleCtx(CreateOrder))
+func HandleCtx[Req any, Res any](fn func(ctx context.Context, req Req) (Res, error)) gin.HandlerFunc {
+	return Handle(func(ctx *Context, req Req) (Res, error) {
+		return fn(requestContext{Context: ctx.Request.Context(), gin: ctx.Context}, req)
+	})
+}
+
+// requestContext is the request's context with the values of the gin context on top
+type requestContext struct {
+	context.Context
+	gin *gin.Context
+}
+
+func (c requestContext) Value(key any) any {
+	if v, ok := c.gin.Get(key); ok {
+		return v
+	}
+	if key == gin.ContextKey {
+		return c.gin
+	}
+	return c.Context.Value(key)
+}
@@ -0,0 +1,196 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ctxUser struct {
+	ID string
+}
+
+var ctxUserKey = NewKey[ctxUser]("user")
+
+type ctxOrderReq struct {
+	Item string `json:"item" validate:"required"`
+}
+
+type ctxOrderRes struct {
+	Item  string `json:"item"`
+	Owner string `json:"owner"`
+	Trace string `json:"trace"`
+}
+
+// createOrder is transport-agnostic business logic
+func createOrder(ctx context.Context, req ctxOrderReq) (ctxOrderRes, error) {
+	user, ok := ctxUserKey.Get(ctx)
+	if !ok {
+		return ctxOrderRes{}, Unauthorized("no user")
+	}
+	trace, _ := ctx.Value("trace_id").(string)
+	return ctxOrderRes{Item: req.Item, Owner: user.ID, Trace: trace}, nil
+}
+
+func TestHandleCtx(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := New()
+	app.POST("/orders", func(ctx *gin.Context) {
+		if ctx.GetHeader("X-User") != "" {
+			ctxUserKey.Set(ctx, ctxUser{ID: ctx.GetHeader("X-User")})
+		}
+		ctx.Set("trace_id", "t-1")
+		ctx.Next()
+	}, HandleCtx(createOrder))
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/orders", bytes.NewBufferString(`{"item":"book"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-User", "u1")
+	app.ServeHTTP(w, req)
+	if w.Code != http.StatusOK || w.Body.String() != `{"item":"book","owner":"u1","trace":"t-1"}` {
+		t.Fatalf("unexpected response %d %s", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodPost, "/orders", bytes.NewBufferString(`{"item":"book"}`))
+	req.Header.Set("Content-Type", "application/json")
+	app.ServeHTTP(w, req)
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 without a user, got %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodPost, "/orders", bytes.NewBufferString(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	app.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "Item is required") {
+		t.Fatalf("expected the request to be validated, got %d %s", w.Code, w.Body.String())
+	}
+}
+
+func TestHandleCtx_UnitTestWithoutGin(t *testing.T) {
+	ctx := ctxUserKey.With(context.Background(), ctxUser{ID: "u2"})
+	res, err := createOrder(ctx, ctxOrderReq{Item: "pen"})
+	if err != nil || res.Owner != "u2" {
+		t.Fatalf("unexpected result %+v %v", res, err)
+	}
+
+	var httpErr HTTPError
+	if _, err := createOrder(context.Background(), ctxOrderReq{}); !errors.As(err, &httpErr) || httpErr.Status != http.StatusUnauthorized {
+		t.Fatalf("expected a 401 error, got %v", err)
+	}
+}
+
+func TestKey_GetFromContexts(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	other := NewKey[ctxUser]("user")
+	ctxUserKey.Set(c, ctxUser{ID: "u3"})
+
+	if u, ok := ctxUserKey.Get(c); !ok || u.ID != "u3" {
+		t.Fatal("expected the value from the gin context")
+	}
+	if u, ok := ctxUserKey.Get(&Context{Context: c}); !ok || u.ID != "u3" {
+		t.Fatal("expected the value from the fluxo context")
+	}
+	if _, ok := other.Get(c); ok {
+		t.Fatal("keys with the same name must not collide")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected MustGet to panic on a missing value")
+		}
+	}()
+	other.MustGet(c)
+}
+
+type ctxTenantID string
+
+// ctxStore is provided as an interface
+type ctxStore interface {
+	Owner(item string) string
+}
+
+type ctxMemStore map[string]string
+
+func (s ctxMemStore) Owner(item string) string { return s[item] }
+
+// ownerOf is business logic reading provided values
+func ownerOf(ctx context.Context, req ctxOrderReq) (ctxOrderRes, error) {
+	user, ok := Use[ctxUser](ctx)
+	if !ok {
+		return ctxOrderRes{}, Unauthorized("no user")
+	}
+	return ctxOrderRes{Item: req.Item, Owner: MustUse[ctxStore](ctx).Owner(req.Item), Trace: user.ID + "@" + string(MustUse[ctxTenantID](ctx))}, nil
+}
+
+func TestProvide(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := New()
+	app.Use(func(ctx *gin.Context) {
+		Provide[ctxStore](ctx, ctxMemStore{"book": "alice"})
+		Provide(ctx, ctxTenantID("acme"))
+		Provide(ctx, "a string doesn't clash with ctxTenantID")
+	})
+	auth := Middleware(func(ctx *Context, req struct {
+		User string `header:"X-User"`
+	}) error {
+		if req.User != "" {
+			Provide(ctx, ctxUser{ID: req.User})
+		}
+		return nil
+	})
+	app.POST("/orders", auth, HandleCtx(ownerOf))
+	app.POST("/typed", auth, Handle(func(ctx *Context, req ctxOrderReq) (ctxOrderRes, error) {
+		return ownerOf(ctx, req)
+	}))
+
+	for _, path := range []string{"/orders", "/typed"} {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, path, bytes.NewBufferString(`{"item":"book"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-User", "u1")
+		app.ServeHTTP(w, req)
+		if w.Code != http.StatusOK || w.Body.String() != `{"item":"book","owner":"alice","trace":"u1@acme"}` {
+			t.Errorf("%s: unexpected response %d %s", path, w.Code, w.Body)
+		}
+
+		w = httptest.NewRecorder()
+		req = httptest.NewRequest(http.MethodPost, path, bytes.NewBufferString(`{"item":"book"}`))
+		req.Header.Set("Content-Type", "application/json")
+		app.ServeHTTP(w, req)
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("%s: expected 401 without a user, got %d", path, w.Code)
+		}
+	}
+}
+
+func TestProvide_UnitTestWithoutGin(t *testing.T) {
+	ctx := WithProvided(context.Background(), ctxUser{ID: "u2"})
+	ctx = WithProvided[ctxStore](ctx, ctxMemStore{"pen": "bob"})
+	ctx = WithProvided(ctx, ctxTenantID("acme"))
+	res, err := ownerOf(ctx, ctxOrderReq{Item: "pen"})
+	if err != nil || res.Owner != "bob" || res.Trace != "u2@acme" {
+		t.Fatalf("unexpected result %+v %v", res, err)
+	}
+
+	if _, ok := Use[ctxUser](context.Background()); ok {
+		t.Error("expected nothing provided")
+	}
+	defer func() {
+		if r := recover(); r != "fluxo: no fluxo.ctxTenantID provided" {
+			t.Errorf("expected MustUse to panic naming the type, got %v", r)
+		}
+	}()
+	MustUse[ctxTenantID](context.Background())
+}
@@ -0,0 +1,178 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RouteDef is a route declared by a Controller or a RouteTable. Its handlers run in order:
+// Middleware, Handlers, then Handler.
+type RouteDef struct {
+	Method     string
+	Path       string
+	Handlers   []gin.HandlerFunc
+	Middleware []gin.HandlerFunc // route options and middleware, e.g. fluxo.Skip or fluxo.View
+	Handler    gin.HandlerFunc   // typically fluxo.Handle(fn), whose types the compiler checks
+	Doc        Doc               // documentation of the route's operation
+}
+
+// Doc documents the operation of a RouteDef. Zero fields keep what the spec generates.
+type Doc struct {
+	Summary     string
+	Description string
+	Tags        []string
+	OperationID string
+	Deprecated  bool
+}
+
+// RouteTable is a Controller declaring its routes as a table, to keep a large API in one
+// reviewable place or to generate it:
+//
+//	var todoRoutes = fluxo.RouteTable{
+//		{Method: http.MethodGet, Path: "/todos", Handler: fluxo.Handle(listTodos),
+//			Doc: fluxo.Doc{Summary: "List todos", Tags: []string{"Todos"}}},
+//		{Method: http.MethodPost, Path: "/todos", Handler: fluxo.Handle(createTodo),
+//			Middleware: []gin.HandlerFunc{requireAuth}, Doc: fluxo.Doc{OperationID: "createTodo"}},
+//	}
+//
+//	app.Register(todoRoutes)
+//	app.TypedGroup("/v2").Register(todoRoutes) // the same table under another prefix
+type RouteTable []RouteDef
+
+// Routes returns the table
+func (t RouteTable) Routes() []RouteDef {
+	return t
+}
+
+// handlers returns the handlers of the route in the order they run
+func (d RouteDef) handlers() []gin.HandlerFunc {
+	handlers := slices.Concat(d.Middleware, d.Handlers)
+	if d.Handler != nil {
+		handlers = append(handlers, d.Handler)
+	}
+	return handlers
+}
+
+// Controller groups related handlers with their shared dependencies and declares their routes:
+//
+//	type UserController struct{ repo *UserRepo }
+//
+//	func (c *UserController) Prefix() string { return "/users" }
+//	func (c *UserController) Tag() string    { return "Users" }
+//
+//	func (c *UserController) Routes() []fluxo.RouteDef {
+//		return []fluxo.RouteDef{
+//			fluxo.Define(http.MethodGet, "/:id", fluxo.Handle(c.get)),
+//			fluxo.Define(http.MethodPost, "", fluxo.Handle(c.create)),
+//		}
+//	}
+//
+// A controller may also implement Prefix() string to mount its routes under a path, Tag() string
+// to tag their operations in the spec and Middleware() []gin.HandlerFunc to run middleware on
+// all of them.
+type Controller interface {
+	Routes() []RouteDef
+}
+
+type controllerPrefix interface{ Prefix() string }
+
+type controllerTag interface{ Tag() string }
+
+type controllerMiddleware interface {
+	Middleware() []gin.HandlerFunc
+}
+
+// Define declares a route of a controller
+func Define(method, path string, handlers ...gin.HandlerFunc) RouteDef {
+	return RouteDef{Method: method, Path: path, Handlers: handlers}
+}
+
+// Register registers the routes of controllers on the app
+func (a *App) Register(controllers ...Controller) {
+	for _, c := range controllers {
+		register(a.TypedGroup(""), c)
+	}
+}
+
+// Register registers the routes of controllers on the group
+func (g *Group) Register(controllers ...Controller) {
+	for _, c := range controllers {
+		register(g, c)
+	}
+}
+
+func register(g *Group, c Controller) {
+	var prefix, tag string
+	var middleware []gin.HandlerFunc
+	if p, ok := c.(controllerPrefix); ok {
+		prefix = p.Prefix()
+	}
+	if t, ok := c.(controllerTag); ok {
+		tag = t.Tag()
+	}
+	if m, ok := c.(controllerMiddleware); ok {
+		middleware = m.Middleware()
+	}
+
+	group := g.Group(prefix, middleware...)
+	for _, def := range c.Routes() {
+		handlers := def.handlers()
+		if def.Method == "" || len(handlers) == 0 {
+			panic(fmt.Sprintf("fluxo: route %q %q needs a method and a handler", def.Method, joinPaths(group.BasePath(), def.Path)))
+		}
+		group.Handle(def.Method, def.Path, handlers...)
+		path := joinPaths(group.BasePath(), def.Path)
+		if tag != "" {
+			g.app.tagOperation(def.Method, path, tag)
+		}
+		g.app.documentOperation(def.Method, path, def.Doc)
+	}
+}
+
+// tagOperation adds tag to the documented operation of a route
+func (a *App) tagOperation(method, path, tag string) {
+	key := method + ":" + path
+	info, ok := a.handlers[key]
+	if !ok {
+		return
+	}
+	info.docs = append(info.docs, func(_ *SwaggerGenerator, op *Operation) {
+		if !slices.Contains(op.Tags, tag) {
+			op.Tags = append(op.Tags, tag)
+		}
+	})
+	a.handlers[key] = info
+	a.invalidateSpec()
+}
+
+// documentOperation applies doc to the documented operation of a route
+func (a *App) documentOperation(method, path string, doc Doc) {
+	key := method + ":" + path
+	info, ok := a.handlers[key]
+	if !ok || doc.Summary == "" && doc.Description == "" && len(doc.Tags) == 0 && doc.OperationID == "" && !doc.Deprecated {
+		return
+	}
+	info.docs = append(info.docs, func(_ *SwaggerGenerator, op *Operation) {
+		if doc.Summary != "" {
+			op.Summary = doc.Summary
+		}
+		if doc.Description != "" {
+			op.Description = doc.Description
+		}
+		for _, tag := range doc.Tags {
+			if !slices.Contains(op.Tags, tag) {
+				op.Tags = append(op.Tags, tag)
+			}
+		}
+		if doc.OperationID != "" {
+			op.OperationID = doc.OperationID
+		}
+		op.Deprecated = op.Deprecated || doc.Deprecated
+	})
+	a.handlers[key] = info
+	a.invalidateSpec()
+}
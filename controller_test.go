@@ -0,0 +1,181 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ctrlItem struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type ctrlGetReq struct {
+	ID string `uri:"id" validate:"required"`
+}
+
+type ctrlCreateReq struct {
+	Name string `json:"name" validate:"required"`
+}
+
+// itemController shares its store between handlers
+type itemController struct {
+	items map[string]ctrlItem
+	calls int
+}
+
+func (c *itemController) Prefix() string { return "/items" }
+func (c *itemController) Tag() string    { return "Items" }
+
+func (c *itemController) Middleware() []gin.HandlerFunc {
+	return []gin.HandlerFunc{func(ctx *gin.Context) {
+		c.calls++
+		ctx.Next()
+	}}
+}
+
+func (c *itemController) Routes() []RouteDef {
+	return []RouteDef{
+		Define(http.MethodGet, "/:id", Handle(c.get)),
+		Define(http.MethodPost, "", Handle(c.create)),
+	}
+}
+
+func (c *itemController) get(ctx *Context, req ctrlGetReq) (ctrlItem, error) {
+	item, ok := c.items[req.ID]
+	if !ok {
+		return ctrlItem{}, NotFound("item not found")
+	}
+	return item, nil
+}
+
+func (c *itemController) create(ctx *Context, req ctrlCreateReq) (ctrlItem, error) {
+	item := ctrlItem{ID: "2", Name: req.Name}
+	c.items[item.ID] = item
+	return item, nil
+}
+
+// pingController only declares routes
+type pingController struct{}
+
+func (pingController) Routes() []RouteDef {
+	return []RouteDef{Define(http.MethodGet, "/ping", func(ctx *gin.Context) { ctx.String(http.StatusOK, "pong") })}
+}
+
+func TestRegister_Controller(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := New().WithSwagger("Test", "1.0")
+	ctrl := &itemController{items: map[string]ctrlItem{"1": {ID: "1", Name: "first"}}}
+	app.Register(ctrl, pingController{})
+
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/items/1", nil))
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"first"`) {
+		t.Fatalf("unexpected response %d %s", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(`{"name":"second"}`))
+	req.Header.Set("Content-Type", "application/json")
+	app.ServeHTTP(w, req)
+	if w.Code != http.StatusOK || ctrl.items["2"].Name != "second" {
+		t.Fatalf("unexpected response %d %s", w.Code, w.Body.String())
+	}
+	if ctrl.calls != 2 {
+		t.Fatalf("expected the controller middleware to run twice, ran %d times", ctrl.calls)
+	}
+
+	w = httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ping", nil))
+	if w.Body.String() != "pong" {
+		t.Fatalf("unexpected response %s", w.Body.String())
+	}
+
+	spec := groupSpec(t, app)
+	get := spec.Paths["/items/{id}"].GET
+	post := spec.Paths["/items"].POST
+	if get == nil || post == nil {
+		t.Fatalf("expected the controller routes to be documented, got %v", spec.Paths)
+	}
+	if !slices.Equal(get.Tags, []string{"Items"}) || !slices.Equal(post.Tags, []string{"Items"}) {
+		t.Fatalf("expected operations tagged Items, got %v and %v", get.Tags, post.Tags)
+	}
+}
+
+func TestRegister_OnGroup(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := New().WithSwagger("Test", "1.0")
+	app.TypedGroup("/v1").Register(&itemController{items: map[string]ctrlItem{"1": {ID: "1"}}})
+
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/items/1", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if op := groupSpec(t, app).Paths["/v1/items/{id}"].GET; op == nil || !slices.Equal(op.Tags, []string{"Items"}) {
+		t.Fatal("expected the route to be documented and tagged under the group")
+	}
+}
+
+func TestRegister_RouteTable(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	items := &itemController{items: map[string]ctrlItem{"1": {ID: "1", Name: "first"}}}
+	var order []string
+	mark := func(name string) gin.HandlerFunc {
+		return func(ctx *gin.Context) { order = append(order, name) }
+	}
+	table := RouteTable{
+		{Method: http.MethodGet, Path: "/items/:id", Handler: Handle(items.get),
+			Middleware: []gin.HandlerFunc{mark("auth")}, Handlers: []gin.HandlerFunc{mark("audit")},
+			Doc: Doc{Summary: "Get an item", Description: "By its ID", Tags: []string{"Items"}, OperationID: "getItem"}},
+		{Method: http.MethodPost, Path: "/items", Handler: Handle(items.create), Doc: Doc{Deprecated: true}},
+		{Method: http.MethodGet, Path: "/ping", Handler: func(ctx *gin.Context) { ctx.String(http.StatusOK, "pong") },
+			Doc: Doc{Summary: "Undocumented anyway"}},
+	}
+
+	app := New().WithSwagger("Test", "1.0")
+	app.Register(table)
+	app.TypedGroup("/v2").Register(table)
+
+	for _, path := range []string{"/items/1", "/v2/items/1"} {
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"first"`) {
+			t.Fatalf("%s: unexpected response %d %s", path, w.Code, w.Body)
+		}
+	}
+	if !slices.Equal(order, []string{"auth", "audit", "auth", "audit"}) {
+		t.Errorf("handlers ran in order %v", order)
+	}
+
+	spec := groupSpec(t, app)
+	get, post := spec.Paths["/items/{id}"].GET, spec.Paths["/items"].POST
+	if get == nil || post == nil || spec.Paths["/v2/items/{id}"].GET == nil {
+		t.Fatalf("expected the table documented, got %v", spec.Paths)
+	}
+	if get.Summary != "Get an item" || get.Description != "By its ID" || get.OperationID != "getItem" || !slices.Equal(get.Tags, []string{"Items"}) {
+		t.Errorf("unexpected operation %+v", get)
+	}
+	if !post.Deprecated || post.Summary == "" {
+		t.Errorf("expected a deprecated operation with its generated summary, got %+v", post)
+	}
+	if _, ok := spec.Paths["/ping"]; ok {
+		t.Error("a plain gin handler was documented")
+	}
+}
+
+func TestRegister_RouteTableWithoutHandler(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil || !strings.Contains(r.(string), "needs a method and a handler") {
+			t.Errorf("expected a panic, got %v", r)
+		}
+	}()
+	New().Register(RouteTable{{Method: http.MethodGet, Path: "/nothing"}})
+}
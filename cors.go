@@ -0,0 +1,81 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CORSConfig configures CORS, see CORS
+type CORSConfig struct {
+	AllowOrigins     []string      `config:"allow_origins"` // e.g. https://app.example.com, or * for any
+	AllowMethods     []string      `config:"allow_methods"` // defaults to GET, HEAD, POST, PUT, PATCH and DELETE
+	AllowHeaders     []string      `config:"allow_headers"` // defaults to the headers the preflight asks for
+	ExposeHeaders    []string      `config:"expose_headers"`
+	AllowCredentials bool          `config:"allow_credentials"` // not allowed with the * origin
+	MaxAge           time.Duration `config:"max_age"`           // how long browsers cache a preflight
+}
+
+var defaultCORSMethods = []string{"GET", "HEAD", "POST", "PUT", "PATCH", "DELETE"}
+
+// CORS returns middleware letting browsers call the app from the origins of cfg. Preflight
+// requests are answered with 204 and go no further; requests from other origins are served
+// without CORS headers, so browsers keep their responses from the calling page.
+func CORS(cfg CORSConfig) gin.HandlerFunc {
+	if cfg.AllowCredentials && slices.Contains(cfg.AllowOrigins, "*") {
+		panic("fluxo: CORS credentials can't be allowed for any origin")
+	}
+	methods := cfg.AllowMethods
+	if len(methods) == 0 {
+		methods = defaultCORSMethods
+	}
+	allowMethods := strings.Join(methods, ", ")
+	allowHeaders := strings.Join(cfg.AllowHeaders, ", ")
+	exposeHeaders := strings.Join(cfg.ExposeHeaders, ", ")
+	anyOrigin := slices.Contains(cfg.AllowOrigins, "*")
+
+	return func(ctx *gin.Context) {
+		origin := ctx.GetHeader("Origin")
+		if origin == "" {
+			return
+		}
+		h := ctx.Writer.Header()
+		h.Add("Vary", "Origin")
+		if !anyOrigin && !slices.ContainsFunc(cfg.AllowOrigins, func(o string) bool { return strings.EqualFold(o, origin) }) {
+			return
+		}
+		if anyOrigin {
+			h.Set("Access-Control-Allow-Origin", "*")
+		} else {
+			h.Set("Access-Control-Allow-Origin", origin)
+		}
+		if cfg.AllowCredentials {
+			h.Set("Access-Control-Allow-Credentials", "true")
+		}
+
+		if ctx.Request.Method != http.MethodOptions || ctx.GetHeader("Access-Control-Request-Method") == "" {
+			if exposeHeaders != "" {
+				h.Set("Access-Control-Expose-Headers", exposeHeaders)
+			}
+			return
+		}
+		h.Add("Vary", "Access-Control-Request-Method")
+		h.Add("Vary", "Access-Control-Request-Headers")
+		h.Set("Access-Control-Allow-Methods", allowMethods)
+		if allowHeaders != "" {
+			h.Set("Access-Control-Allow-Headers", allowHeaders)
+		} else if requested := ctx.GetHeader("Access-Control-Request-Headers"); requested != "" {
+			h.Set("Access-Control-Allow-Headers", requested)
+		}
+		if cfg.MaxAge > 0 {
+			h.Set("Access-Control-Max-Age", strconv.Itoa(int(cfg.MaxAge.Seconds())))
+		}
+		ctx.AbortWithStatus(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func corsApp(cfg CORSConfig) *App {
+	app := New(WithMode(gin.TestMode))
+	app.Use(CORS(cfg))
+	app.GET("/todos", Handle(func(ctx *Context, _ struct{}) ([]string, error) {
+		return []string{}, nil
+	}))
+	return app
+}
+
+func corsRequest(app *App, method, origin string, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/todos", nil)
+	if origin != "" {
+		req.Header.Set("Origin", origin)
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, req)
+	return w
+}
+
+func TestCORS(t *testing.T) {
+	app := corsApp(CORSConfig{
+		AllowOrigins:     []string{"https://app.example.com"},
+		ExposeHeaders:    []string{"ETag"},
+		AllowCredentials: true,
+		MaxAge:           time.Hour,
+	})
+
+	w := corsRequest(app, "GET", "https://app.example.com", nil)
+	h := w.Header()
+	if w.Code != http.StatusOK || h.Get("Access-Control-Allow-Origin") != "https://app.example.com" ||
+		h.Get("Access-Control-Allow-Credentials") != "true" || h.Get("Access-Control-Expose-Headers") != "ETag" {
+		t.Errorf("GET = %d, headers %v", w.Code, h)
+	}
+
+	w = corsRequest(app, "OPTIONS", "https://app.example.com", map[string]string{
+		"Access-Control-Request-Method":  "POST",
+		"Access-Control-Request-Headers": "Content-Type, Authorization",
+	})
+	h = w.Header()
+	if w.Code != http.StatusNoContent || h.Get("Access-Control-Allow-Methods") == "" ||
+		h.Get("Access-Control-Allow-Headers") != "Content-Type, Authorization" || h.Get("Access-Control-Max-Age") != "3600" {
+		t.Errorf("preflight = %d, headers %v", w.Code, h)
+	}
+
+	w = corsRequest(app, "GET", "https://evil.example.com", nil)
+	if w.Code != http.StatusOK || w.Header().Get("Access-Control-Allow-Origin") != "" {
+		t.Errorf("other origin = %d, headers %v", w.Code, w.Header())
+	}
+	if w := corsRequest(app, "GET", "", nil); w.Header().Get("Vary") != "" {
+		t.Errorf("same-origin request got headers %v", w.Header())
+	}
+}
+
+func TestCORS_AnyOrigin(t *testing.T) {
+	w := corsRequest(corsApp(CORSConfig{AllowOrigins: []string{"*"}}), "GET", "https://x.example.com", nil)
+	if w.Header().Get("Access-Control-Allow-Origin") != "*" {
+		t.Errorf("headers = %v", w.Header())
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("credentials for any origin accepted")
+		}
+	}()
+	CORS(CORSConfig{AllowOrigins: []string{"*"}, AllowCredentials: true})
+}
@@ -42,10 +42,20 @@ func (c *Context) GetAuthenticatedUser(target any) error {
 	return fmt.Errorf("authenticated user type mismatch")
 }
 
+// Lang returns the language negotiated from the Accept-Language header among those with
+// validation translations, "en" when none matches. Validation messages use it.
 func (c *Context) Lang() string {
-	lang := c.GetHeader("Accept-Language")
-	if lang == "" {
-		return "en"
+	return requestLang(c.Context)
+}
+
+// NegotiateLang returns the language of available the client prefers, following the
+// Accept-Language header with fallbacks ("fr-CH" accepts "fr"), or the first of available when
+// none matches:
+//
+//	lang := ctx.NegotiateLang("en", "fr", "de")
+func (c *Context) NegotiateLang(available ...string) string {
+	if len(available) == 0 {
+		return ""
 	}
-	return lang
+	return negotiateLang(c.GetHeader("Accept-Language"), available, available[0])
 }
@@ -66,7 +66,10 @@ func TestContext_Lang(t *testing.T) {
 		t.Fatalf("expected en, got %s", ctx.Lang())
 	}
 
-	// With header
+	// With header, for a language the app has translations for
+	v := NewValidator()
+	v.RegisterTranslation("fr", "required", "%s est obligatoire")
+	ginCtx.Set(appKey, New(WithValidator(v)))
 	req := httptest.NewRequest("GET", "/", nil)
 	req.Header.Set("Accept-Language", "fr")
 	ginCtx.Request = req
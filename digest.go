@@ -0,0 +1,297 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Headers carrying the expected digest of a body or multipart file
+const (
+	HeaderContentMD5    = "Content-MD5"
+	HeaderDigest        = "Digest"         // RFC 3230, e.g. sha-256=<base64>
+	HeaderContentDigest = "Content-Digest" // RFC 9530, e.g. sha-256=:<base64>:
+)
+
+// digestMemoryLimit is how much of a body is kept in memory while verifying it, larger bodies go to a temp file
+const digestMemoryLimit = 1 << 20
+
+var digestAlgorithms = map[string]func() hash.Hash{
+	"md5":     md5.New,
+	"sha-256": sha256.New,
+	"sha-512": sha512.New,
+}
+
+// expectedDigest is a digest sent by the client
+type expectedDigest struct {
+	header    string
+	algorithm string
+	sum       []byte
+}
+
+// parseDigests returns the supported digests in h; unknown algorithms are ignored
+func parseDigests(h http.Header) ([]expectedDigest, error) {
+	var digests []expectedDigest
+	add := func(header, algorithm, encoded string) error {
+		algorithm = strings.ToLower(strings.TrimSpace(algorithm))
+		if _, ok := digestAlgorithms[algorithm]; !ok {
+			return nil
+		}
+		sum, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+		if err != nil {
+			return fmt.Errorf("invalid %s header", header)
+		}
+		digests = append(digests, expectedDigest{header: header, algorithm: algorithm, sum: sum})
+		return nil
+	}
+
+	if v := h.Get(HeaderContentMD5); v != "" {
+		if err := add(HeaderContentMD5, "md5", v); err != nil {
+			return nil, err
+		}
+	}
+	for _, header := range []string{HeaderDigest, HeaderContentDigest} {
+		for _, v := range h.Values(header) {
+			for _, item := range strings.Split(v, ",") {
+				algorithm, encoded, _ := strings.Cut(item, "=")
+				if header == HeaderContentDigest {
+					encoded = strings.Trim(strings.TrimSpace(encoded), ":")
+				}
+				if err := add(header, algorithm, encoded); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	return digests, nil
+}
+
+// digestWriter hashes what is written with every algorithm of digests
+type digestWriter struct {
+	digests []expectedDigest
+	hashes  []hash.Hash
+}
+
+func newDigestWriter(digests []expectedDigest) *digestWriter {
+	w := &digestWriter{digests: digests}
+	for _, d := range digests {
+		w.hashes = append(w.hashes, digestAlgorithms[d.algorithm]())
+	}
+	return w
+}
+
+func (w *digestWriter) Write(p []byte) (int, error) {
+	for _, h := range w.hashes {
+		h.Write(p)
+	}
+	return len(p), nil
+}
+
+// mismatch returns the header whose digest does not match what was written, if any
+func (w *digestWriter) mismatch() (string, bool) {
+	for i, d := range w.digests {
+		if !bytes.Equal(w.hashes[i].Sum(nil), d.sum) {
+			return d.header, true
+		}
+	}
+	return "", false
+}
+
+// VerifyDigest returns middleware checking the request body against Content-MD5, Digest or
+// Content-Digest headers when the client sends them. In multipart bodies each file part may
+// carry its own digest headers. Mismatches are rejected with 422 before the handler runs.
+func VerifyDigest() gin.HandlerFunc {
+	return verifyDigest
+}
+
+// RequireDigest is like VerifyDigest but rejects requests without a digest with 400. A multipart
+// body may instead carry a digest on every file part.
+func RequireDigest() gin.HandlerFunc {
+	return requireDigest
+}
+
+func verifyDigest(ctx *gin.Context) {
+	checkDigest(ctx, false)
+}
+
+func requireDigest(ctx *gin.Context) {
+	checkDigest(ctx, true)
+}
+
+func init() {
+	registerOperationDoc(verifyDigest, func(sg *SwaggerGenerator, op *Operation) { documentDigest(op, false) })
+	registerOperationDoc(requireDigest, func(sg *SwaggerGenerator, op *Operation) { documentDigest(op, true) })
+}
+
+func checkDigest(ctx *gin.Context, required bool) {
+	digests, err := parseDigests(ctx.Request.Header)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// The body is spooled so it can be verified before anything is bound from it
+	check := newDigestWriter(digests)
+	body, err := spoolBody(requestTempDir(ctx), ctx.Request.Body, check)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Reading body failed: %v", err)})
+		return
+	}
+	defer body.Close()
+	ctx.Request.Body = body
+
+	if header, bad := check.mismatch(); bad {
+		ctx.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{"error": header + " does not match the request body"})
+		return
+	}
+
+	filesDigested := false
+	if ctx.ContentType() == gin.MIMEMultipartPOSTForm {
+		filesDigested, err = checkPartDigests(ctx, body)
+		if err != nil {
+			status := http.StatusBadRequest
+			if errors.Is(err, errDigestMismatch) {
+				status = http.StatusUnprocessableEntity
+			}
+			ctx.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	if required && len(digests) == 0 && !filesDigested {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "a Content-MD5, Digest or Content-Digest header is required"})
+		return
+	}
+	ctx.Next()
+}
+
+var errDigestMismatch = errors.New("digest mismatch")
+
+// checkPartDigests verifies file parts carrying digest headers, reporting whether every file part had one.
+// body is rewound for binding afterwards.
+func checkPartDigests(ctx *gin.Context, body *spooledBody) (bool, error) {
+	_, params, err := mime.ParseMediaType(ctx.GetHeader("Content-Type"))
+	if err != nil {
+		return false, err
+	}
+	defer body.rewind()
+
+	reader := multipart.NewReader(body, params["boundary"])
+	files, digested := 0, 0
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return false, err
+		}
+		if part.FileName() == "" {
+			continue
+		}
+		files++
+		digests, err := parseDigests(http.Header(part.Header))
+		if err != nil {
+			return false, err
+		}
+		if len(digests) == 0 {
+			continue
+		}
+		digested++
+		check := newDigestWriter(digests)
+		if _, err := io.Copy(check, part); err != nil {
+			return false, err
+		}
+		if header, bad := check.mismatch(); bad {
+			return false, fmt.Errorf("%w: %s does not match file %s (%s)", errDigestMismatch, header, part.FormName(), part.FileName())
+		}
+	}
+	return files > 0 && digested == files, nil
+}
+
+// spooledBody replays a request body from memory or, when large, a temp file
+type spooledBody struct {
+	io.ReadSeeker
+	file *os.File
+}
+
+// spoolBody copies r to a replayable body, writing it to w as it goes; large bodies go to a temp
+// file in dir
+func spoolBody(dir string, r io.Reader, w io.Writer) (*spooledBody, error) {
+	var buf bytes.Buffer
+	n, err := io.Copy(io.MultiWriter(&buf, w), io.LimitReader(r, digestMemoryLimit+1))
+	if err != nil {
+		return nil, err
+	}
+	if n <= digestMemoryLimit {
+		return &spooledBody{ReadSeeker: bytes.NewReader(buf.Bytes())}, nil
+	}
+
+	file, err := os.CreateTemp(dir, "fluxo-body-*")
+	if err != nil {
+		return nil, err
+	}
+	body := &spooledBody{ReadSeeker: file, file: file}
+	// The buffered start of the body was already written to w
+	if _, err := buf.WriteTo(file); err != nil {
+		body.Close()
+		return nil, err
+	}
+	if _, err := io.Copy(io.MultiWriter(file, w), r); err != nil {
+		body.Close()
+		return nil, err
+	}
+	body.rewind()
+	return body, nil
+}
+
+func (b *spooledBody) rewind() {
+	_, _ = b.Seek(0, io.SeekStart)
+}
+
+// Close removes the temp file backing the body, if any; calling it more than once is safe
+func (b *spooledBody) Close() error {
+	if b.file == nil {
+		return nil
+	}
+	err := b.file.Close()
+	_ = os.Remove(b.file.Name())
+	b.file = nil
+	return err
+}
+
+func documentDigest(op *Operation, required bool) {
+	description := "Digest of the request body, e.g. sha-256=<base64>; md5 and sha-512 are also accepted"
+	note := "The body is verified against Content-MD5, Digest or Content-Digest when sent, and multipart file parts against their own digest headers."
+	if required {
+		note = "A Content-MD5, Digest or Content-Digest header is required (for multipart bodies, or on every file part) and the body is verified against it."
+	}
+	op.Parameters = append(op.Parameters,
+		Parameter{Name: HeaderContentMD5, In: "header", Description: "Base64 MD5 of the request body", Schema: Schema{Type: "string"}},
+		Parameter{Name: HeaderDigest, In: "header", Description: description, Schema: Schema{Type: "string"}},
+		Parameter{Name: HeaderContentDigest, In: "header", Description: "Digest of the request body, e.g. sha-256=:<base64>:", Schema: Schema{Type: "string"}},
+	)
+	op.Description = strings.TrimSpace(op.Description + "\n\n" + note)
+	if op.Responses == nil {
+		op.Responses = map[string]Response{}
+	}
+	op.Responses["422"] = Response{Description: "Body or file does not match its digest"}
+	if required {
+		op.Responses["400"] = Response{Description: "Missing digest"}
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type digestReq struct {
+	Name string `json:"name" validate:"required"`
+}
+
+func newDigestApp(middleware gin.HandlerFunc) *App {
+	gin.SetMode(gin.TestMode)
+	app := New().WithSwagger("Digest", "1.0.0")
+	app.POST("/items", middleware, Handle(func(ctx *Context, req digestReq) (digestReq, error) {
+		return req, nil
+	}))
+	app.POST("/photos", middleware, Handle(func(ctx *Context, req uploadReq) (uploadRes, error) {
+		return uploadRes{Count: len(req.Photos)}, nil
+	}))
+	return app
+}
+
+func md5Header(data []byte) string {
+	sum := md5.Sum(data)
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+func sha256Header(data []byte) string {
+	sum := sha256.Sum256(data)
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+func TestVerifyDigest_Body(t *testing.T) {
+	app := newDigestApp(VerifyDigest())
+	body := []byte(`{"name":"widget"}`)
+
+	tests := []struct {
+		name    string
+		headers map[string]string
+		status  int
+	}{
+		{"no digest", nil, http.StatusOK},
+		{"content-md5", map[string]string{HeaderContentMD5: md5Header(body)}, http.StatusOK},
+		{"digest", map[string]string{HeaderDigest: "SHA-256=" + sha256Header(body)}, http.StatusOK},
+		{"content-digest", map[string]string{HeaderContentDigest: "sha-256=:" + sha256Header(body) + ":"}, http.StatusOK},
+		{"mismatch", map[string]string{HeaderContentMD5: md5Header([]byte("other"))}, http.StatusUnprocessableEntity},
+		{"one of several mismatches", map[string]string{HeaderDigest: "md5=" + md5Header(body) + ", sha-256=" + sha256Header([]byte("x"))}, http.StatusUnprocessableEntity},
+		{"malformed", map[string]string{HeaderContentMD5: "not base64!"}, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(string(body)))
+			req.Header.Set("Content-Type", "application/json")
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			w := httptest.NewRecorder()
+			app.ServeHTTP(w, req)
+			if w.Code != tt.status {
+				t.Fatalf("status=%d body=%s", w.Code, w.Body.String())
+			}
+			if tt.status == http.StatusOK && !strings.Contains(w.Body.String(), "widget") {
+				t.Fatalf("body not bound after verification: %s", w.Body.String())
+			}
+		})
+	}
+}
+
+func TestVerifyDigest_LargeBody(t *testing.T) {
+	app := newDigestApp(RequireDigest())
+	body := []byte(`{"name":"` + strings.Repeat("x", digestMemoryLimit+10) + `"}`)
+	req := httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(string(body)))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderDigest, "sha-256="+sha256Header(body))
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status=%d", w.Code)
+	}
+}
+
+func TestDigest_MultipartFiles(t *testing.T) {
+	filePart := func(data []byte, digest string) multipartPart {
+		return multipartPart{name: "photos", file: testFile{name: "a.png", contentType: "image/png", data: data, digest: digest}}
+	}
+	tests := []struct {
+		name       string
+		middleware gin.HandlerFunc
+		parts      []multipartPart
+		status     int
+	}{
+		{"matching part", VerifyDigest(), []multipartPart{filePart(pngHeader, md5Header(pngHeader))}, http.StatusOK},
+		{"mismatching part", VerifyDigest(), []multipartPart{filePart(pngHeader, md5Header([]byte("x")))}, http.StatusUnprocessableEntity},
+		{"required on every part", RequireDigest(), []multipartPart{filePart(pngHeader, md5Header(pngHeader))}, http.StatusOK},
+		{"required but missing", RequireDigest(), []multipartPart{filePart(pngHeader, "")}, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newDigestApp(tt.middleware)
+			body, ct := buildMultipart(t, tt.parts)
+			req := httptest.NewRequest(http.MethodPost, "/photos", body)
+			req.Header.Set("Content-Type", ct)
+			w := httptest.NewRecorder()
+			app.ServeHTTP(w, req)
+			if w.Code != tt.status {
+				t.Fatalf("status=%d body=%s", w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestDigest_Swagger(t *testing.T) {
+	app := newDigestApp(RequireDigest())
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	var spec OpenAPISpec
+	if err := json.Unmarshal(w.Body.Bytes(), &spec); err != nil {
+		t.Fatal(err)
+	}
+	op := spec.Paths["/items"].POST
+	if _, ok := op.Responses["422"]; !ok || !strings.Contains(op.Description, "required") {
+		t.Fatalf("operation: %+v", op)
+	}
+	found := false
+	for _, p := range op.Parameters {
+		found = found || (p.Name == HeaderContentMD5 && p.In == "header")
+	}
+	if !found {
+		t.Fatalf("parameters: %+v", op.Parameters)
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// StatusClientClosedRequest is the status recorded for requests whose client went away before
+// the response, as nginx does. It never reaches the client, but shows in logs and Stats.
+const StatusClientClosedRequest = 499
+
+const disconnectKey = "fluxo.disconnect"
+
+// The deadline and cancellation of a *Context are those of the request, whatever gin's
+// ContextWithFallback says, so that passing ctx to a database call stops the query when the
+// client goes away:
+//
+//	rows, err := db.QueryContext(ctx, "SELECT ...")
+
+// Deadline returns the deadline of the request's context
+func (c *Context) Deadline() (time.Time, bool) {
+	return c.Request.Context().Deadline()
+}
+
+// Done is closed when the client goes away, or when the request's context is cancelled otherwise
+func (c *Context) Done() <-chan struct{} {
+	return c.Request.Context().Done()
+}
+
+// Err returns why Done was closed
+func (c *Context) Err() error {
+	return c.Request.Context().Err()
+}
+
+// Disconnected reports whether the client went away before the response was complete
+func (c *Context) Disconnected() bool {
+	return clientGone(c.Context)
+}
+
+// disconnectHooks are the OnDisconnect hooks of a request
+type disconnectHooks struct {
+	mu    sync.Mutex
+	stops []func() bool
+}
+
+// OnDisconnect calls fn, in its own goroutine, if the client goes away before the request is
+// served, e.g. to cancel work started on its behalf or to remove a temporary file:
+//
+//	job := exports.Start(req)
+//	ctx.OnDisconnect(job.Cancel)
+//
+// Hooks are dropped once the request is served, so they don't run for requests that complete.
+// That needs the request to be served by an App or a Mux; elsewhere hooks also run when the
+// request ends normally.
+func (c *Context) OnDisconnect(fn func()) {
+	hooks, _ := c.Value(disconnectKey).(*disconnectHooks)
+	if hooks == nil {
+		hooks = &disconnectHooks{}
+		c.Set(disconnectKey, hooks)
+	}
+	ctx := c.Request.Context()
+	stop := context.AfterFunc(ctx, func() {
+		// The gin context may serve another request by now, so only ctx is looked at
+		if errors.Is(context.Cause(ctx), context.Canceled) {
+			fn()
+		}
+	})
+	hooks.mu.Lock()
+	hooks.stops = append(hooks.stops, stop)
+	hooks.mu.Unlock()
+}
+
+// releaseDisconnect drops the OnDisconnect hooks of a request that has been served
+func releaseDisconnect(ctx *gin.Context) {
+	hooks, _ := ctx.Value(disconnectKey).(*disconnectHooks)
+	if hooks == nil {
+		return
+	}
+	hooks.mu.Lock()
+	defer hooks.mu.Unlock()
+	for _, stop := range hooks.stops {
+		stop()
+	}
+	hooks.stops = nil
+}
+
+// clientGone reports whether the client of the request went away. net/http cancels the
+// request's context when the connection closes, while deadlines expire with another error.
+func clientGone(ctx *gin.Context) bool {
+	return errors.Is(context.Cause(ctx.Request.Context()), context.Canceled)
+}
+
+// dropResponse ends a request whose client went away without writing, reporting or logging the
+// error its handler returned, which is usually just the cancellation echoed back
+func dropResponse(ctx *gin.Context) {
+	ctx.AbortWithStatus(StatusClientClosedRequest)
+}
@@ -0,0 +1,131 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// disconnectingRequest returns a request whose client goes away when leave is called
+func disconnectingRequest(path string) (*http.Request, context.CancelFunc) {
+	ctx, leave := context.WithCancel(context.Background())
+	return httptest.NewRequest(http.MethodGet, path, nil).WithContext(ctx), leave
+}
+
+func TestContext_CancelledWithRequest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := New()
+	var cause error
+	app.GET("/slow", Handle(func(ctx *Context, _ struct{}) (gin.H, error) {
+		select {
+		case <-ctx.Done():
+			cause = ctx.Err()
+			return nil, ctx.Err()
+		case <-time.After(time.Second):
+			return gin.H{}, nil
+		}
+	}))
+
+	req, leave := disconnectingRequest("/slow")
+	leave()
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, req)
+	if !errors.Is(cause, context.Canceled) {
+		t.Fatalf("the handler's context wasn't cancelled: %v", cause)
+	}
+	if w.Code != StatusClientClosedRequest || w.Body.Len() != 0 {
+		t.Errorf("expected the response dropped with 499, got %d %s", w.Code, w.Body)
+	}
+	if stats := app.Stats(); len(stats) != 1 || stats[0].ClientErrors != 1 || stats[0].ServerErrors != 0 {
+		t.Errorf("expected a client error in the stats, got %+v", stats)
+	}
+}
+
+func TestContext_DisconnectedErrorsNotReported(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := New()
+	reported := 0
+	app.OnError(func(*Context, error) { reported++ })
+	app.GET("/query", Handle(func(ctx *Context, _ struct{}) (gin.H, error) {
+		return nil, errors.New("query failed: context canceled")
+	}))
+
+	req, leave := disconnectingRequest("/query")
+	leave()
+	app.ServeHTTP(httptest.NewRecorder(), req)
+	if reported != 0 {
+		t.Errorf("errors of a request whose client left were reported %d times", reported)
+	}
+
+	if w := get(app, "/query"); w.Code != http.StatusInternalServerError || reported != 1 {
+		t.Errorf("connected clients still get errors: %d, reported %d", w.Code, reported)
+	}
+}
+
+func TestContext_OnDisconnect(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := New()
+	called := make(chan string, 2)
+	app.GET("/work", Handle(func(ctx *Context, _ struct{}) (gin.H, error) {
+		id := ctx.Query("id")
+		ctx.OnDisconnect(func() { called <- id })
+		if ctx.Query("leave") == "true" {
+			<-ctx.Done()
+		}
+		return gin.H{}, nil
+	}))
+
+	// A request served normally drops its hooks
+	req, leave := disconnectingRequest("/work?id=served")
+	app.ServeHTTP(httptest.NewRecorder(), req)
+	leave()
+
+	req, leave = disconnectingRequest("/work?id=left&leave=true")
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		leave()
+	}()
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, req)
+
+	select {
+	case id := <-called:
+		if id != "left" {
+			t.Errorf("hook ran for %q", id)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("the hook didn't run when the client left")
+	}
+	select {
+	case id := <-called:
+		t.Errorf("hook ran for %q too", id)
+	case <-time.After(20 * time.Millisecond):
+	}
+}
+
+func TestContext_DisconnectedDeadline(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := New()
+	var gone bool
+	var deadline bool
+	app.GET("/deadline", func(c *gin.Context) {
+		ctx := &Context{Context: c}
+		<-ctx.Done()
+		_, deadline = ctx.Deadline()
+		gone = ctx.Disconnected()
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	app.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/deadline", nil).WithContext(ctx))
+	if !deadline || gone {
+		t.Errorf("a timed out request: deadline %v, disconnected %v", deadline, gone)
+	}
+}
@@ -0,0 +1,258 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Enumer is implemented by string and integer types with a fixed set of values. Request fields of
+// such types are rejected unless empty or one of the values, and the OpenAPI schema lists them:
+//
+//	type Status string
+//
+//	const (
+//	    StatusActive   Status = "active"
+//	    StatusArchived Status = "archived"
+//	)
+//
+//	func (Status) Enum() []any { return []any{StatusActive, StatusArchived} }
+//
+// Use `validate:"required"` to reject the empty value too. Fields tagged with the oneof rule
+// are listed in the schema the same way.
+type Enumer interface {
+	Enum() []any
+}
+
+var enumerType = reflect.TypeOf((*Enumer)(nil)).Elem()
+
+// enumValues returns the values of t when it is an Enumer of a basic kind, as plain strings and numbers
+func enumValues(t reflect.Type) ([]any, bool) {
+	if !t.Implements(enumerType) || !isEnumKind(t.Kind()) {
+		return nil, false
+	}
+	values := reflect.Zero(t).Interface().(Enumer).Enum()
+	plain := make([]any, len(values))
+	for i, value := range values {
+		plain[i] = plainEnumValue(reflect.ValueOf(value))
+	}
+	return plain, true
+}
+
+func isEnumKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// plainEnumValue strips the named type from v, so Status("active") and "active" compare equal
+func plainEnumValue(v reflect.Value) any {
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint()
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	case reflect.Invalid:
+		return nil
+	}
+	return v.Interface()
+}
+
+// enumParam renders values the way the oneof rule lists them
+func enumParam(values []any) string {
+	params := make([]string, len(values))
+	for i, value := range values {
+		params[i] = fmt.Sprint(value)
+	}
+	return strings.Join(params, " ")
+}
+
+// enumCheck reports the Enumer fields of a value holding a value outside their Enum
+type enumCheck func(v reflect.Value, failed func(field, param string))
+
+var enumChecks sync.Map // reflect.Type -> enumCheck, nil when the type holds no Enumer fields
+
+// enumCheckFor returns the cached enum check of t, or nil when t holds no Enumer fields
+func enumCheckFor(t reflect.Type) enumCheck {
+	if t == nil {
+		return nil
+	}
+	if c, ok := enumChecks.Load(t); ok {
+		return c.(enumCheck)
+	}
+	actual, _ := enumChecks.LoadOrStore(t, compileEnumCheck(t, "", map[reflect.Type]bool{}))
+	return actual.(enumCheck)
+}
+
+// compileEnumCheck walks t for Enumer values, reported as field; recursive types are only
+// checked down to their first repetition
+func compileEnumCheck(t reflect.Type, field string, seen map[reflect.Type]bool) enumCheck {
+	if seen[t] {
+		return nil
+	}
+	seen[t] = true
+	defer delete(seen, t)
+
+	if elem, ok := optionalElem(t); ok {
+		inner := compileEnumCheck(elem, field, seen)
+		if inner == nil {
+			return nil
+		}
+		return func(v reflect.Value, failed func(field, param string)) { inner(v.Field(0), failed) }
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array:
+		inner := compileEnumCheck(t.Elem(), field, seen)
+		if inner == nil {
+			return nil
+		}
+		if t.Kind() == reflect.Ptr {
+			return func(v reflect.Value, failed func(field, param string)) {
+				if !v.IsNil() {
+					inner(v.Elem(), failed)
+				}
+			}
+		}
+		return func(v reflect.Value, failed func(field, param string)) {
+			for i := 0; i < v.Len(); i++ {
+				inner(v.Index(i), failed)
+			}
+		}
+	}
+
+	if values, ok := enumValues(t); ok {
+		param := enumParam(values)
+		return func(v reflect.Value, failed func(field, param string)) {
+			if v.IsZero() {
+				return
+			}
+			value := plainEnumValue(v)
+			for _, allowed := range values {
+				if value == allowed {
+					return
+				}
+			}
+			failed(field, param)
+		}
+	}
+
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	var fields []enumCheck
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		if c := compileEnumCheck(f.Type, f.Name, seen); c != nil {
+			index := i
+			fields = append(fields, func(v reflect.Value, failed func(field, param string)) { c(v.Field(index), failed) })
+		}
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+	return func(v reflect.Value, failed func(field, param string)) {
+		for _, f := range fields {
+			f(v, failed)
+		}
+	}
+}
+
+// enumMessages returns the messages of the Enumer fields of s holding a value outside their Enum
+func (v *Validator) enumMessages(lang string, s any) []string {
+	value := reflect.ValueOf(s)
+	if !value.IsValid() {
+		return nil
+	}
+	check := enumCheckFor(value.Type())
+	if check == nil {
+		return nil
+	}
+	var messages []string
+	check(value, func(field, param string) {
+		messages = append(messages, v.message(lang, field, "enum", param))
+	})
+	return messages
+}
+
+// oneOfParam matches the options of a oneof rule, which may be quoted to hold spaces
+var oneOfParam = regexp.MustCompile(`'[^']*'|\S+`)
+
+// applyOneOf lists the options of a oneof rule in rules as the enum of schema, or of its items
+// when the rule follows dive. t is the type the schema was generated from.
+func applyOneOf(schema *Schema, t reflect.Type, rules string) {
+	target, dived := schema, false
+	for _, rule := range strings.Split(rules, ",") {
+		tag, param, _ := strings.Cut(strings.TrimSpace(rule), "=")
+		switch tag {
+		case "dive":
+			if dived || schema.Items == nil {
+				return
+			}
+			target, dived = schema.Items, true
+		case "oneof":
+			kind := enumKindOf(t, dived)
+			var values []any
+			for _, opt := range oneOfParam.FindAllString(param, -1) {
+				values = append(values, parseEnumOption(strings.Trim(opt, "'"), kind))
+			}
+			target.Enum = values
+		}
+	}
+}
+
+// enumKindOf returns the kind of the values of t, or of its elements when dived
+func enumKindOf(t reflect.Type, dived bool) reflect.Kind {
+	for {
+		if elem, ok := optionalElem(t); ok {
+			t = elem
+			continue
+		}
+		if t.Kind() == reflect.Ptr || (dived && (t.Kind() == reflect.Slice || t.Kind() == reflect.Array)) {
+			if t.Kind() != reflect.Ptr {
+				dived = false
+			}
+			t = t.Elem()
+			continue
+		}
+		return t.Kind()
+	}
+}
+
+// parseEnumOption converts a oneof option to the JSON type of kind, keeping it as is when it doesn't parse
+func parseEnumOption(opt string, kind reflect.Kind) any {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if n, err := strconv.ParseInt(opt, 10, 64); err == nil {
+			return n
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if n, err := strconv.ParseUint(opt, 10, 64); err == nil {
+			return n
+		}
+	case reflect.Float32, reflect.Float64:
+		if n, err := strconv.ParseFloat(opt, 64); err == nil {
+			return n
+		}
+	}
+	return opt
+}
@@ -0,0 +1,106 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type enumStatus string
+
+func (enumStatus) Enum() []any { return []any{enumStatus("active"), enumStatus("archived")} }
+
+type enumPriority int
+
+func (enumPriority) Enum() []any { return []any{1, 2, 3} }
+
+type enumItem struct {
+	Status enumStatus `json:"status"`
+}
+
+type enumReq struct {
+	Status   enumStatus             `form:"status"`
+	Priority enumPriority           `json:"priority"`
+	Tags     []enumStatus           `json:"tags"`
+	Next     Optional[enumPriority] `json:"next"`
+	Items    []enumItem             `json:"items"`
+	Sort     string                 `json:"sort" validate:"omitempty,oneof=name 'due date'"`
+	Sizes    []int                  `json:"sizes" validate:"dive,oneof=1 2"`
+}
+
+func TestEnum_Validation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := New(WithValidator(NewValidator()))
+	app.POST("/tasks", Handle(func(ctx *Context, req enumReq) (gin.H, error) { return gin.H{"ok": true}, nil }))
+
+	tests := []struct {
+		name  string
+		query string
+		body  string
+		code  int
+		want  string
+	}{
+		{"valid", "?status=active", `{"priority":2,"tags":["archived"],"next":3,"items":[{"status":"active"}]}`, http.StatusOK, ""},
+		{"empty values are allowed", "", `{}`, http.StatusOK, ""},
+		{"query", "?status=deleted", `{}`, http.StatusBadRequest, "Status must be one of active archived"},
+		{"number", "", `{"priority":7}`, http.StatusBadRequest, "Priority must be one of 1 2 3"},
+		{"slice", "", `{"tags":["active","x"]}`, http.StatusBadRequest, "Tags must be one of active archived"},
+		{"optional", "", `{"next":9}`, http.StatusBadRequest, "Next must be one of 1 2 3"},
+		{"nested", "", `{"items":[{"status":"x"}]}`, http.StatusBadRequest, "Status must be one of active archived"},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/tasks"+tt.query, strings.NewReader(tt.body))
+		req.Header.Set("Content-Type", "application/json")
+		app.ServeHTTP(w, req)
+		if w.Code != tt.code || !strings.Contains(w.Body.String(), tt.want) {
+			t.Errorf("%s: expected %d with %q, got %d %s", tt.name, tt.code, tt.want, w.Code, w.Body.String())
+		}
+	}
+}
+
+func TestEnum_Translation(t *testing.T) {
+	v := NewValidator()
+	v.RegisterTranslation("id", "enum", "%s harus salah satu dari %s")
+	err := v.check(t.Context(), "id", &enumReq{Status: "x"})
+	if err == nil || !strings.Contains(err.Error(), "Status harus salah satu dari active archived") {
+		t.Fatalf("expected a translated message, got %v", err)
+	}
+}
+
+func TestEnum_Schema(t *testing.T) {
+	sg := NewSwaggerGenerator("t", "v")
+	schema := sg.generateSchema(reflect.TypeOf(enumReq{}))
+	props := schema.Properties
+
+	tests := []struct {
+		name string
+		got  []any
+		want []any
+	}{
+		{"Enumer", props["priority"].Enum, []any{int64(1), int64(2), int64(3)}},
+		{"Enumer items", props["tags"].Items.Enum, []any{"active", "archived"}},
+		{"Optional Enumer", props["next"].Enum, []any{int64(1), int64(2), int64(3)}},
+		{"oneof", props["sort"].Enum, []any{"name", "due date"}},
+		{"oneof after dive", props["sizes"].Items.Enum, []any{int64(1), int64(2)}},
+	}
+	for _, tt := range tests {
+		if !reflect.DeepEqual(tt.got, tt.want) {
+			t.Errorf("%s: expected enum %v, got %v", tt.name, tt.want, tt.got)
+		}
+	}
+	if props["priority"].Type != "integer" || !props["next"].Nullable {
+		t.Errorf("expected enums to keep their type, got %+v and %+v", props["priority"], props["next"])
+	}
+
+	params := sg.generateParameters(reflect.TypeOf(enumReq{}), "/tasks")
+	if len(params) != 1 || !reflect.DeepEqual(params[0].Schema.Enum, []any{"active", "archived"}) {
+		t.Fatalf("expected the status parameter to list its values, got %+v", params)
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ErrorEncoder writes the response for an error returned by a handler or typed middleware
+type ErrorEncoder func(ctx *Context, err error)
+
+// Envelope wraps a successful response before it is encoded
+type Envelope func(ctx *Context, res any) any
+
+const (
+	errorEncoderKey = "fluxo.error_encoder"
+	envelopeKey     = "fluxo.envelope"
+)
+
+// DefaultErrorEncoder writes an HTTPError as it is and any other error as a 500 carrying its text
+func DefaultErrorEncoder(ctx *Context, err error) {
+	if httpErr, ok := err.(HTTPError); ok {
+		ctx.JSON(httpErr.Status, httpErr)
+		return
+	}
+	ctx.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Internal server error: %v", err)})
+}
+
+// TerseErrorEncoder writes client errors as they are but only the status text for server
+// errors, keeping internal details out of public APIs
+func TerseErrorEncoder(ctx *Context, err error) {
+	status := http.StatusInternalServerError
+	var httpErr HTTPError
+	if errors.As(err, &httpErr) {
+		if httpErr.Status < http.StatusInternalServerError {
+			ctx.JSON(httpErr.Status, httpErr)
+			return
+		}
+		status = httpErr.Status
+	}
+	ctx.JSON(status, gin.H{"error": http.StatusText(status)})
+}
+
+// DataEnvelope wraps responses as {"data": ...}
+func DataEnvelope(ctx *Context, res any) any {
+	return gin.H{"data": res}
+}
+
+// ErrorEncoding returns middleware making the fluxo handlers after it write errors with enc
+func ErrorEncoding(enc ErrorEncoder) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Set(errorEncoderKey, enc)
+	}
+}
+
+// Enveloping returns middleware making the fluxo handlers after it wrap responses with env;
+// a nil env turns off an envelope set further out
+func Enveloping(env Envelope) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Set(envelopeKey, env)
+	}
+}
+
+// WithErrorEncoder writes errors of routes registered afterwards with enc
+func (a *App) WithErrorEncoder(enc ErrorEncoder) *App {
+	a.router.Use(ErrorEncoding(enc))
+	return a
+}
+
+// WithEnvelope wraps responses of routes registered afterwards with env
+func (a *App) WithEnvelope(env Envelope) *App {
+	a.router.Use(Enveloping(env))
+	return a
+}
+
+// WithErrorEncoder writes errors of the group's routes registered afterwards with enc,
+// overriding the app's encoder:
+//
+//	admin := app.TypedGroup("/admin").WithErrorEncoder(fluxo.DefaultErrorEncoder)
+func (g *Group) WithErrorEncoder(enc ErrorEncoder) *Group {
+	g.RouterGroup.Use(ErrorEncoding(enc))
+	return g
+}
+
+// WithEnvelope wraps responses of the group's routes registered afterwards with env,
+// overriding the app's envelope
+func (g *Group) WithEnvelope(env Envelope) *Group {
+	g.RouterGroup.Use(Enveloping(env))
+	return g
+}
+
+// writeError passes err to the app's OnError hooks, then writes it. ErrNotModified is answered with 304.
+func writeError(ctx *gin.Context, err error) {
+	if errors.Is(err, ErrNotModified) {
+		ctx.AbortWithStatus(http.StatusNotModified)
+		return
+	}
+	if a, ok := appFrom(ctx); ok {
+		a.reportError(ctx, err)
+	}
+	encodeError(ctx, err)
+}
+
+// encodeError writes err with the encoder configured for the request
+func encodeError(ctx *gin.Context, err error) {
+	enc := DefaultErrorEncoder
+	if v, ok := ctx.Get(errorEncoderKey); ok && v.(ErrorEncoder) != nil {
+		enc = v.(ErrorEncoder)
+	}
+	enc(&Context{Context: ctx}, err)
+}
+
+// envelop wraps res with the envelope configured for the request, if any
+func envelop(ctx *gin.Context, res any) any {
+	if v, ok := ctx.Get(envelopeKey); ok && v.(Envelope) != nil {
+		return v.(Envelope)(&Context{Context: ctx}, res)
+	}
+	return res
+}
@@ -0,0 +1,81 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type envReq struct {
+	Name string `form:"name"`
+}
+
+type envRes struct {
+	Name string `json:"name"`
+	Role string `json:"role"`
+}
+
+func envGet(ctx *Context, req envReq) (envRes, error) {
+	switch req.Name {
+	case "":
+		return envRes{}, BadRequest("name is required")
+	case "crash":
+		return envRes{}, errors.New("connection refused by db-internal-7:5432")
+	}
+	return envRes{Name: req.Name, Role: "user"}, nil
+}
+
+func envServe(app *App, path string) (int, string) {
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+	return w.Code, strings.TrimSpace(w.Body.String())
+}
+
+func TestGroupErrorEncoderAndEnvelope(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := New().WithErrorEncoder(TerseErrorEncoder).WithEnvelope(DataEnvelope)
+	app.GET("/public/user", Handle(envGet))
+	admin := app.TypedGroup("/admin").WithErrorEncoder(DefaultErrorEncoder).WithEnvelope(nil)
+	admin.GET("/user", Handle(envGet))
+
+	cases := []struct {
+		path   string
+		status int
+		body   string
+	}{
+		{"/public/user?name=ann", http.StatusOK, `{"data":{"name":"ann","role":"user"}}`},
+		{"/public/user?name=ann&fields=name", http.StatusOK, `{"data":{"name":"ann"}}`},
+		{"/public/user", http.StatusBadRequest, `{"status":400,"message":"name is required"}`},
+		{"/public/user?name=crash", http.StatusInternalServerError, `{"error":"Internal Server Error"}`},
+		{"/admin/user?name=ann", http.StatusOK, `{"name":"ann","role":"user"}`},
+		{"/admin/user?name=crash", http.StatusInternalServerError, `{"error":"Internal server error: connection refused by db-internal-7:5432"}`},
+	}
+	for _, c := range cases {
+		status, body := envServe(app, c.path)
+		if status != c.status || body != c.body {
+			t.Errorf("%s: got %d %s, want %d %s", c.path, status, body, c.status, c.body)
+		}
+	}
+}
+
+func TestErrorEncoderForTypedMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := New().WithErrorEncoder(func(ctx *Context, err error) {
+		ctx.JSON(http.StatusTeapot, gin.H{"problem": err.Error()})
+	})
+	app.GET("/items/:id", Middleware(groupAuth), Handle(groupGetItem))
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/items/1", nil)
+	req.Header.Set("X-API-Key", "wrong")
+	app.ServeHTTP(w, req)
+	if w.Code != http.StatusTeapot || !strings.Contains(w.Body.String(), "bad key") {
+		t.Fatalf("expected the custom encoder, got %d: %s", w.Code, w.Body.String())
+	}
+}
@@ -2,11 +2,17 @@
 // Licensed under the MIT License. See LICENSE for details.
 package fluxo
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+)
 
 type HTTPError struct {
 	Status  int    `json:"status"`
 	Message string `json:"message"`
+	Stack   string `json:"stack,omitempty"` // of a panic, in debug mode, see Recovery
 }
 
 func (e HTTPError) Error() string {
@@ -36,6 +42,50 @@ func NotFound(message string) HTTPError {
 	return NewHTTPError(404, message)
 }
 
+func Conflict(message string) HTTPError {
+	return NewHTTPError(409, message)
+}
+
+func UnprocessableEntity(message string) HTTPError {
+	return NewHTTPError(422, message)
+}
+
 func InternalServerError(message string) HTTPError {
 	return NewHTTPError(500, message)
 }
+
+// Errorf returns an HTTPError whose message is the translation of key in the request's
+// negotiated language, formatted with args. Keys are registered like validation messages, with
+// fmt verbs through RegisterTranslation or {0}, {1}, ... placeholders in LoadTranslations files:
+//
+//	fluxo.RegisterTranslation("fr", "todo.not_found", "tâche %d introuvable")
+//	return Todo{}, fluxo.Errorf(ctx, 404, "todo.not_found", req.ID)
+//
+// Without a translation in that language the English one is used, then key itself.
+// ctx is the handler's *Context, *gin.Context or HandleCtx context.
+func Errorf(ctx context.Context, status int, key string, args ...any) HTTPError {
+	v, lang := defaultValidator, defaultLang
+	if c := ginContextOf(ctx); c != nil && c.Request != nil {
+		v, lang = requestValidator(c), requestLang(c)
+	}
+	for _, l := range []string{lang, defaultLang} {
+		if t, ok := v.lookup(l, key); ok {
+			return NewHTTPError(status, t.formatArgs(args...))
+		}
+	}
+	return NewHTTPError(status, key)
+}
+
+// ginContextOf returns the gin context behind ctx, if any
+func ginContextOf(ctx context.Context) *gin.Context {
+	switch c := ctx.(type) {
+	case *gin.Context:
+		return c
+	case *Context:
+		return c.Context
+	case nil:
+		return nil
+	}
+	c, _ := ctx.Value(gin.ContextKey).(*gin.Context)
+	return c
+}
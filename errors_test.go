@@ -1,14 +1,67 @@
 package fluxo
 
-import "testing"
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/gin-gonic/gin"
+)
 
 func TestHTTPErrorHelpers(t *testing.T) {
     if BadRequest("x").Status != 400 { t.Fatalf("bad request") }
     if Unauthorized("x").Status != 401 { t.Fatalf("unauthorized") }
     if Forbidden("x").Status != 403 { t.Fatalf("forbidden") }
     if NotFound("x").Status != 404 { t.Fatalf("notfound") }
+    if Conflict("x").Status != 409 { t.Fatalf("conflict") }
+    if UnprocessableEntity("x").Status != 422 { t.Fatalf("unprocessable") }
     if InternalServerError("x").Status != 500 { t.Fatalf("ise") }
 
     e := NewHTTPError(418, "teapot")
     if e.Error() == "" { t.Fatalf("error string empty") }
 }
+
+func TestErrorf(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	v := NewValidator()
+	v.RegisterTranslation("en", "todo.not_found", "todo %d not found")
+	v.RegisterTranslation("fr", "todo.not_found", "tâche %d introuvable")
+	fsys := fstest.MapFS{"l/id.yaml": {Data: []byte("todo:\n  not_found: \"todo {0} tidak ditemukan\"\n")}}
+	if err := v.LoadTranslations(fsys, "l/*.yaml"); err != nil {
+		t.Fatal(err)
+	}
+	app := New(WithValidator(v))
+	app.GET("/todos/:id", Handle(func(ctx *Context, req struct {
+		ID int `uri:"id"`
+	}) (gin.H, error) {
+		return nil, Errorf(ctx, http.StatusNotFound, "todo.not_found", req.ID)
+	}))
+	app.GET("/ctx/:id", HandleCtx(func(ctx context.Context, req struct {
+		ID int `uri:"id"`
+	}) (gin.H, error) {
+		return nil, Errorf(ctx, http.StatusNotFound, "todo.not_found", req.ID)
+	}))
+
+	tests := []struct{ path, lang, want string }{
+		{"/todos/7", "fr-CA, en;q=0.5", "tâche 7 introuvable"},
+		{"/todos/7", "id", "todo 7 tidak ditemukan"},
+		{"/todos/7", "de", "todo 7 not found"},
+		{"/ctx/8", "fr", "tâche 8 introuvable"},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+		req.Header.Set("Accept-Language", tt.lang)
+		app.ServeHTTP(w, req)
+		if w.Code != http.StatusNotFound || !strings.Contains(w.Body.String(), tt.want) {
+			t.Errorf("%s %s: expected %q, got %d %s", tt.path, tt.lang, tt.want, w.Code, w.Body.String())
+		}
+	}
+
+	if err := Errorf(context.Background(), http.StatusConflict, "todo.unknown_key", 1); err.Status != http.StatusConflict || err.Message != "todo.unknown_key" {
+		t.Fatalf("expected the key without a translation or request, got %+v", err)
+	}
+}
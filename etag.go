@@ -0,0 +1,145 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Versioned is implemented by resources carrying a version. Typed handlers returning one send
+// it as their ETag header, for clients to send back in If-Match when they update the resource:
+//
+//	func (t Todo) ETag() string { return strconv.Itoa(t.Version) }
+type Versioned interface {
+	ETag() string
+}
+
+var versionedType = reflect.TypeOf((*Versioned)(nil)).Elem()
+
+// versionOf returns the ETag of res if it is Versioned, and not a nil pointer
+func versionOf(res any) (string, bool) {
+	v, ok := res.(Versioned)
+	if !ok {
+		return "", false
+	}
+	if rv := reflect.ValueOf(res); rv.Kind() == reflect.Ptr && rv.IsNil() {
+		return "", false
+	}
+	return v.ETag(), true
+}
+
+// ETagOf returns an ETag derived from the JSON encoding of v, for resources without a version
+func ETagOf(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:16])
+}
+
+// SetETag sets the ETag header of the response, quoting etag unless it already is
+func SetETag(ctx *gin.Context, etag string) {
+	if etag != "" {
+		ctx.Header("ETag", quoteETag(etag))
+	}
+}
+
+func quoteETag(etag string) string {
+	if strings.HasSuffix(etag, `"`) && (strings.HasPrefix(etag, `"`) || strings.HasPrefix(etag, `W/"`)) {
+		return etag
+	}
+	return `"` + etag + `"`
+}
+
+// etagMatches reports whether the If-Match header value matches etag. Weak tags never match,
+// as If-Match uses the strong comparison.
+func etagMatches(header, etag string) bool {
+	want := quoteETag(etag)
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || tag == want && !strings.HasPrefix(tag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckIfMatch returns an error unless the request's If-Match header matches etag, the current
+// version of the resource it updates: 428 without the header and 412 on a mismatch. Handlers
+// that load the resource anyway can call it instead of using IfMatch:
+//
+//	todo, err := store.Get(ctx, req.ID)
+//	...
+//	if err := fluxo.CheckIfMatch(ctx, todo.ETag()); err != nil {
+//		return Todo{}, err
+//	}
+func CheckIfMatch(ctx context.Context, etag string) error {
+	c := ginContextOf(ctx)
+	if c == nil || c.Request == nil {
+		return InternalServerError("fluxo: CheckIfMatch needs the request's context")
+	}
+	header := c.GetHeader("If-Match")
+	if header == "" {
+		return NewHTTPError(http.StatusPreconditionRequired, "The If-Match header is required to update this resource")
+	}
+	if !etagMatches(header, etag) {
+		return NewHTTPError(http.StatusPreconditionFailed, "The resource was modified since it was fetched")
+	}
+	return nil
+}
+
+// IfMatch returns middleware enforcing optimistic concurrency on PUT and PATCH requests: current
+// returns the ETag of the resource the request targets, and requests get 428 without an If-Match
+// header and 412 when it doesn't match. Other methods pass through.
+//
+//	app.PUT("/todos/:id", fluxo.IfMatch(func(ctx *gin.Context) (string, error) {
+//		todo, err := store.Get(ctx, ctx.Param("id"))
+//		return todo.ETag(), err
+//	}), fluxo.Handle(updateTodo))
+//
+// Errors from current are written like handler errors. The precondition is documented on the
+// PUT and PATCH operations.
+func IfMatch(current func(ctx *gin.Context) (string, error)) gin.HandlerFunc {
+	handler := func(ctx *gin.Context) {
+		if ctx.Request.Method != http.MethodPut && ctx.Request.Method != http.MethodPatch {
+			return
+		}
+		etag, err := current(ctx)
+		if err == nil {
+			err = CheckIfMatch(ctx, etag)
+		}
+		if err != nil {
+			writeError(ctx, err)
+			ctx.Abort()
+		}
+	}
+	registerOperationDoc(handler, documentIfMatch)
+	return handler
+}
+
+func documentIfMatch(sg *SwaggerGenerator, op *Operation) {
+	if method := sg.operationMethod(op); method != "PUT" && method != "PATCH" {
+		return
+	}
+	if !hasParameter(op.Parameters, "If-Match", "header") {
+		op.Parameters = append(op.Parameters, Parameter{
+			Name: "If-Match", In: "header", Required: true,
+			Description: "ETag of the resource as last fetched",
+			Schema:      Schema{Type: "string"},
+		})
+	}
+	if op.Responses == nil {
+		op.Responses = map[string]Response{}
+	}
+	op.Responses["412"] = Response{Description: "The resource was modified since it was fetched"}
+	op.Responses["428"] = Response{Description: "If-Match header missing"}
+}
@@ -0,0 +1,144 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type versionedDoc struct {
+	ID      string `json:"id"`
+	Title   string `json:"title"`
+	Version int    `json:"version"`
+}
+
+func (d versionedDoc) ETag() string { return strconv.Itoa(d.Version) }
+
+type updateDocReq struct {
+	ID    string `uri:"id"`
+	Title string `json:"title"`
+}
+
+func etagApp() *App {
+	gin.SetMode(gin.TestMode)
+	var mu sync.Mutex
+	doc := versionedDoc{ID: "1", Title: "draft", Version: 1}
+
+	app := New().WithSwagger("Docs", "1.0")
+	current := IfMatch(func(ctx *gin.Context) (string, error) {
+		if ctx.Param("id") != doc.ID {
+			return "", NotFound("no such document")
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		return doc.ETag(), nil
+	})
+	app.GET("/docs/:id", current, Handle(func(ctx *Context, req updateDocReq) (versionedDoc, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		return doc, nil
+	}))
+	app.PUT("/docs/:id", current, Handle(func(ctx *Context, req updateDocReq) (versionedDoc, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		doc.Title = req.Title
+		doc.Version++
+		return doc, nil
+	}))
+	return app
+}
+
+func putDoc(app *App, id, ifMatch string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("PUT", "/docs/"+id, strings.NewReader(`{"title":"final"}`))
+	req.Header.Set("Content-Type", "application/json")
+	if ifMatch != "" {
+		req.Header.Set("If-Match", ifMatch)
+	}
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, req)
+	return w
+}
+
+func TestIfMatch(t *testing.T) {
+	app := etagApp()
+
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest("GET", "/docs/1", nil))
+	etag := w.Header().Get("ETag")
+	if w.Code != http.StatusOK || etag != `"1"` {
+		t.Fatalf("GET: status = %d, ETag = %q", w.Code, etag)
+	}
+
+	if w := putDoc(app, "1", ""); w.Code != http.StatusPreconditionRequired {
+		t.Errorf("without If-Match: status = %d, want 428", w.Code)
+	}
+	if w := putDoc(app, "1", `W/"1"`); w.Code != http.StatusPreconditionFailed {
+		t.Errorf("weak If-Match: status = %d, want 412", w.Code)
+	}
+	w = putDoc(app, "1", etag)
+	if w.Code != http.StatusOK || w.Header().Get("ETag") != `"2"` {
+		t.Fatalf("PUT: status = %d, ETag = %q: %s", w.Code, w.Header().Get("ETag"), w.Body)
+	}
+	// The first update changed the version, so a second one with the old ETag is lost
+	if w := putDoc(app, "1", etag); w.Code != http.StatusPreconditionFailed {
+		t.Errorf("stale If-Match: status = %d, want 412", w.Code)
+	}
+	if w := putDoc(app, "1", `"9", "2"`); w.Code != http.StatusOK {
+		t.Errorf("list If-Match: status = %d, want 200", w.Code)
+	}
+	if w := putDoc(app, "1", "*"); w.Code != http.StatusOK {
+		t.Errorf("If-Match *: status = %d, want 200", w.Code)
+	}
+	if w := putDoc(app, "2", "*"); w.Code != http.StatusNotFound {
+		t.Errorf("unknown document: status = %d, want 404", w.Code)
+	}
+}
+
+func TestIfMatch_Swagger(t *testing.T) {
+	app := etagApp()
+	spec, err := app.swagger.parsedSpec(app.specHandlers())
+	if err != nil {
+		t.Fatal(err)
+	}
+	item := spec.Paths["/docs/{id}"]
+	put, get := item.PUT, item.GET
+	if put == nil || get == nil {
+		t.Fatalf("missing operations: %+v", item)
+	}
+	if !hasParameter(put.Parameters, "If-Match", "header") || put.Responses["412"].Description == "" || put.Responses["428"].Description == "" {
+		t.Errorf("PUT lacks the precondition: %+v", put)
+	}
+	if hasParameter(get.Parameters, "If-Match", "header") {
+		t.Error("GET documents If-Match")
+	}
+	if _, ok := get.Responses["200"].Headers["ETag"]; !ok {
+		t.Error("GET response lacks the ETag header")
+	}
+}
+
+func TestCheckIfMatch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = httptest.NewRequest("PATCH", "/", nil)
+	ctx.Request.Header.Set("If-Match", `"abc"`)
+	if err := CheckIfMatch(ctx, "abc"); err != nil {
+		t.Errorf("matching ETag: %v", err)
+	}
+	if err := CheckIfMatch(ctx, `"def"`); err == nil || err.(HTTPError).Status != http.StatusPreconditionFailed {
+		t.Errorf("other ETag: %v", err)
+	}
+}
+
+func TestETagOf(t *testing.T) {
+	a, b := ETagOf(versionedDoc{Title: "a"}), ETagOf(versionedDoc{Title: "b"})
+	if a == "" || a == b || a != ETagOf(versionedDoc{Title: "a"}) {
+		t.Errorf("ETagOf = %q, %q", a, b)
+	}
+}
@@ -0,0 +1,331 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// WithGeneratedExamples fills in plausible examples for the fields and parameters that have
+// none, guessed from their names, formats and validate rules: an email field gets
+// "jane.doe@example.com", `validate:"min=18,max=65"` a number in range, `validate:"uuid"` a UUID.
+// Examples are the same on every build, so spec snapshots stay stable.
+func WithGeneratedExamples() SwaggerOption {
+	return func(sg *SwaggerGenerator) {
+		sg.generateExamples = true
+	}
+}
+
+// exampleDate is the instant generated date and time examples show
+var exampleDate = time.Date(2025, time.January, 15, 9, 30, 0, 0, time.UTC)
+
+// namedExamples are the string examples of fields by name, matched on the name's last words
+var namedExamples = map[string]string{
+	"firstname":   "Jane",
+	"lastname":    "Doe",
+	"fullname":    "Jane Doe",
+	"username":    "janedoe",
+	"nickname":    "jane",
+	"email":       "jane.doe@example.com",
+	"phone":       "+14155550123",
+	"mobile":      "+14155550123",
+	"password":    "correct-horse-battery",
+	"token":       "tok_4f9c2b7e1d",
+	"secret":      "s3cr3t",
+	"street":      "221B Baker Street",
+	"address":     "221B Baker Street",
+	"city":        "Amsterdam",
+	"postalcode":  "1012 AB",
+	"zipcode":     "94103",
+	"zip":         "94103",
+	"countrycode": "NL",
+	"country":     "NL",
+	"currency":    "EUR",
+	"locale":      "en-US",
+	"language":    "en",
+	"lang":        "en",
+	"timezone":    "Europe/Amsterdam",
+	"company":     "Acme Inc.",
+	"avatar":      "https://example.com/avatar.png",
+	"image":       "https://example.com/image.png",
+	"website":     "https://example.com",
+	"homepage":    "https://example.com",
+	"url":         "https://example.com",
+	"link":        "https://example.com",
+	"domain":      "example.com",
+	"host":        "api.example.com",
+	"ip":          "203.0.113.7",
+	"color":       "#3366ff",
+	"colour":      "#3366ff",
+	"slug":        "getting-started",
+	"sku":         "SKU-1001",
+	"title":       "Getting started",
+	"description": "A short description.",
+	"summary":     "A short summary.",
+	"comment":     "Looks good to me.",
+	"note":        "Remember the milk.",
+	"message":     "Hello, world!",
+	"tag":         "featured",
+	"status":      "active",
+	"code":        "ABC123",
+	"name":        "Jane Doe",
+	"id":          "8a1f3e2c",
+}
+
+// namedNumbers are the number examples of fields by name, see namedExamples
+var namedNumbers = map[string]float64{
+	"latitude":   52.3676,
+	"longitude":  4.9041,
+	"lat":        52.3676,
+	"lng":        4.9041,
+	"lon":        4.9041,
+	"age":        32,
+	"price":      19.99,
+	"amount":     19.99,
+	"cost":       19.99,
+	"total":      59.97,
+	"balance":    250.5,
+	"quantity":   3,
+	"qty":        3,
+	"count":      3,
+	"pagesize":   20,
+	"perpage":    20,
+	"limit":      20,
+	"offset":     0,
+	"page":       1,
+	"year":       2025,
+	"month":      1,
+	"day":        15,
+	"rating":     4.5,
+	"score":      87.5,
+	"percent":    50,
+	"percentage": 50,
+	"port":       8080,
+	"timeout":    30,
+	"duration":   30,
+	"weight":     1.25,
+	"id":         42,
+}
+
+// generateExample gives s an example when the generator makes them and s has none. name is the
+// field or parameter s documents and rules its validate rules.
+func (sg *SwaggerGenerator) generateExample(s *Schema, name, rules string) {
+	if !sg.generateExamples {
+		return
+	}
+	if s.PII {
+		// Personal data is masked in examples, as it is in recordings and logs
+		if s.Example == nil && s.Type == "string" {
+			s.Example = Redacted
+		}
+		return
+	}
+	own, elemRules := parseFuzzRules(rules)
+	if s.Type == "array" && s.Items != nil {
+		if s.Example == nil && len(s.Items.Enum) == 0 {
+			if v, ok := generatedValue(*s.Items, singular(name), elemRules); ok {
+				s.Items.Example = v
+			}
+		}
+		return
+	}
+	if s.Example != nil || len(s.Enum) > 0 {
+		return
+	}
+	if v, ok := generatedValue(*s, name, own); ok {
+		s.Example = v
+	}
+}
+
+// generatedValue returns a plausible value of s, a scalar schema; objects and references are
+// left to the examples of their own fields
+func generatedValue(s Schema, name string, rules []fuzzRule) (any, bool) {
+	keys := exampleKeys(name)
+	switch s.Type {
+	case "string":
+		return exampleString(s.Format, keys, rules)
+	case "integer":
+		n, ok := namedNumber(keys)
+		if !ok {
+			n = 1
+		}
+		return int64(fitNumber(float64(int64(n)), rules, true)), true
+	case "number":
+		n, ok := namedNumber(keys)
+		if !ok {
+			n = 1.5
+		}
+		return fitNumber(n, rules, false), true
+	case "boolean":
+		return true, true
+	}
+	return nil, false
+}
+
+func exampleString(format string, keys []string, rules []fuzzRule) (any, bool) {
+	switch format {
+	case "binary":
+		return nil, false
+	case "date-time":
+		return exampleDate.Format(time.RFC3339), true
+	case "date":
+		return exampleDate.Format(time.DateOnly), true
+	case "uuid":
+		return "3fa85f64-5717-4562-b3fc-2c963f66afa6", true
+	case "byte":
+		return "aGVsbG8=", true
+	case "email":
+		return "jane.doe@example.com", true
+	}
+	for _, r := range rules {
+		if r.either {
+			continue
+		}
+		switch r.tag {
+		case "email":
+			return "jane.doe@example.com", true
+		case "uuid", "uuid4", "uuid_rfc4122", "uuid4_rfc4122":
+			return "3fa85f64-5717-4562-b3fc-2c963f66afa6", true
+		case "url", "uri", "http_url":
+			return "https://example.com", true
+		case "hostname", "fqdn":
+			return "api.example.com", true
+		case "ip", "ipv4":
+			return "203.0.113.7", true
+		case "ipv6":
+			return "2001:db8::7", true
+		case "e164":
+			return "+14155550123", true
+		case "iso3166_1_alpha2":
+			return "NL", true
+		case "iso4217":
+			return "EUR", true
+		case "hexcolor":
+			return "#3366ff", true
+		case "datetime":
+			return exampleDate.Format(r.param), true
+		case "numeric", "number":
+			return fitString("12345", rules), true
+		}
+	}
+	for _, key := range keys {
+		if v, ok := namedExamples[key]; ok {
+			return fitString(v, rules), true
+		}
+	}
+	return fitString("example", rules), true
+}
+
+func namedNumber(keys []string) (float64, bool) {
+	for _, key := range keys {
+		if v, ok := namedNumbers[key]; ok {
+			return v, true
+		}
+	}
+	return 0, false
+}
+
+// fitString pads or cuts s to the length the len, min and max rules allow
+func fitString(s string, rules []fuzzRule) string {
+	lo, hi := 0, -1
+	if n, ok := ruleInt(rules, "len"); ok {
+		lo, hi = int(n), int(n)
+	}
+	if n, ok := ruleInt(rules, "min"); ok {
+		lo = int(n)
+	}
+	if n, ok := ruleInt(rules, "max"); ok {
+		hi = int(n)
+	}
+	if len(s) < lo {
+		s += strings.Repeat("x", lo-len(s))
+	}
+	if hi >= 0 && len(s) > hi {
+		s = s[:hi]
+	}
+	return s
+}
+
+// fitNumber moves n into the range of the min, max, gt, gte, lt and lte rules, to its middle
+// when both ends are bounded
+func fitNumber(n float64, rules []fuzzRule, integer bool) float64 {
+	step := 0.01
+	if integer {
+		step = 1
+	}
+	lo, hasLo := ruleFloat(rules, "min", "gte")
+	if v, ok := ruleFloat(rules, "gt"); ok {
+		lo, hasLo = v+step, true
+	}
+	hi, hasHi := ruleFloat(rules, "max", "lte")
+	if v, ok := ruleFloat(rules, "lt"); ok {
+		hi, hasHi = v-step, true
+	}
+	if hasLo && n < lo || hasHi && n > hi {
+		switch {
+		case hasLo && hasHi:
+			n = lo + (hi-lo)/2
+			if integer {
+				n = float64(int64(n))
+			}
+		case hasLo:
+			n = lo
+		default:
+			n = hi
+		}
+	}
+	return n
+}
+
+func ruleFloat(rules []fuzzRule, tags ...string) (float64, bool) {
+	for _, tag := range tags {
+		if param, ok := ruleParam(rules, tag); ok {
+			if v, err := strconv.ParseFloat(param, 64); err == nil {
+				return v, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// exampleKeys returns the last words of name lowercased and joined, longest first:
+// billingFirstName and billing_first_name -> billingfirstname, firstname, name
+func exampleKeys(name string) []string {
+	var words []string
+	start := 0
+	runes := []rune(name)
+	for i, r := range runes {
+		switch {
+		case r == '_' || r == '-' || r == '.' || unicode.IsSpace(r):
+			if i > start {
+				words = append(words, string(runes[start:i]))
+			}
+			start = i + 1
+		case unicode.IsUpper(r) && i > start && !unicode.IsUpper(runes[i-1]):
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+	if start < len(runes) {
+		words = append(words, string(runes[start:]))
+	}
+	keys := make([]string, len(words))
+	for i := range words {
+		keys[i] = strings.ToLower(strings.Join(words[i:], ""))
+	}
+	return keys
+}
+
+// singular guesses the singular of a plural field name, tags -> tag
+func singular(name string) string {
+	switch {
+	case strings.HasSuffix(name, "ies"):
+		return strings.TrimSuffix(name, "ies") + "y"
+	case strings.HasSuffix(name, "ses"):
+		return strings.TrimSuffix(name, "es")
+	}
+	return strings.TrimSuffix(name, "s")
+}
@@ -0,0 +1,87 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type exampleSignupReq struct {
+	Ref       string    `uri:"ref" validate:"uuid"`
+	Page      int       `form:"page"`
+	FirstName string    `json:"first_name" validate:"required"`
+	Email     string    `json:"contact" validate:"required,email"`
+	Age       int       `json:"age" validate:"min=40,max=60"`
+	Score     float64   `json:"score" validate:"gt=100"`
+	Handle    string    `json:"handle" validate:"min=10,max=12"`
+	Plan      string    `json:"plan" validate:"oneof=free pro"`
+	Tags      []string  `json:"tags" validate:"dive,max=3"`
+	Birthday  time.Time `json:"birthday"`
+	Admin     bool      `json:"admin"`
+}
+
+func TestWithGeneratedExamples(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := New().WithSwagger("t", "v", WithGeneratedExamples())
+	app.POST("/signups/:ref", Handle(func(ctx *Context, req exampleSignupReq) (gin.H, error) { return gin.H{}, nil }))
+
+	spec := groupSpec(t, app)
+	op := spec.Paths["/signups/{ref}"].POST
+	params := map[string]any{}
+	for _, p := range op.Parameters {
+		params[p.Name] = p.Schema.Example
+	}
+	if params["ref"] != "3fa85f64-5717-4562-b3fc-2c963f66afa6" || params["page"] != float64(1) {
+		t.Errorf("unexpected parameter examples %v", params)
+	}
+
+	props := spec.Components.Schemas["exampleSignupReq"].Properties
+	for name, want := range map[string]any{
+		"first_name": "Jane",
+		"contact":    "jane.doe@example.com",
+		"age":        float64(50),
+		"score":      100.01,
+		"handle":     "examplexxx",
+		"birthday":   "2025-01-15T09:30:00Z",
+		"admin":      true,
+		"plan":       nil, // the first enum value shows already
+	} {
+		if got := props[name].Example; got != want {
+			t.Errorf("%s: expected example %v, got %v", name, want, got)
+		}
+	}
+	if got := props["tags"].Items.Example; got != "fea" {
+		t.Errorf("tags: expected the item example cut to 3, got %v", got)
+	}
+
+	if err := app.Validate(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWithGeneratedExamples_OptIn(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := New().WithSwagger("t", "v")
+	app.POST("/signups/:ref", Handle(func(ctx *Context, req exampleSignupReq) (gin.H, error) { return gin.H{}, nil }))
+
+	if ex := groupSpec(t, app).Components.Schemas["exampleSignupReq"].Properties["age"].Example; ex != nil {
+		t.Errorf("examples are generated without the option: %v", ex)
+	}
+}
+
+func TestExampleKeys(t *testing.T) {
+	for name, want := range map[string]string{
+		"billing_first_name": "billingfirstname firstname name",
+		"userIP":             "userip ip",
+		"relationship":       "relationship",
+		"PostalCode":         "postalcode code",
+	} {
+		if got := strings.Join(exampleKeys(name), " "); got != want {
+			t.Errorf("%s: expected %q, got %q", name, want, got)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// routeExample is an example payload added by RequestExample or ResponseExample
+type routeExample struct {
+	status     int // 0 for the request body
+	name       string
+	value      any
+	mediaTypes []string
+}
+
+// RequestExample is a route option documenting value as an example request body, so that Swagger
+// UI's "Try it out" starts from it. Examples are listed under name, for every media type of the
+// body unless mediaTypes are given:
+//
+//	app.POST("/todos",
+//		fluxo.RequestExample("groceries", CreateTodo{Title: "Buy milk", Due: "2025-06-01"}),
+//		fluxo.ResponseExample(http.StatusCreated, "groceries", fluxo.ExampleFile("testdata/todo.json")),
+//		fluxo.Handle(createTodo))
+//
+// app.Validate reports JSON examples that don't match the schema they illustrate.
+func RequestExample(name string, value any, mediaTypes ...string) gin.HandlerFunc {
+	return exampleOption(routeExample{name: name, value: exampleValue(name, value), mediaTypes: mediaTypes})
+}
+
+// ResponseExample is a route option documenting value as an example of the response with the
+// given status, see RequestExample
+func ResponseExample(status int, name string, value any, mediaTypes ...string) gin.HandlerFunc {
+	return exampleOption(routeExample{status: status, name: name, value: exampleValue(name, value), mediaTypes: mediaTypes})
+}
+
+// ExampleFile reads an example payload from a JSON file, e.g. one kept in testdata next to the
+// tests sending it. It panics when the file can't be read or isn't JSON, as routes are
+// registered at startup.
+func ExampleFile(path string) json.RawMessage {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		panic(fmt.Sprintf("fluxo: reading example: %v", err))
+	}
+	if !json.Valid(data) {
+		panic(fmt.Sprintf("fluxo: example %s is not valid JSON", path))
+	}
+	return json.RawMessage(data)
+}
+
+// exampleValue returns value as it will appear in the spec, panicking when it can't be encoded
+func exampleValue(name string, value any) any {
+	data, err := json.Marshal(value)
+	if err != nil {
+		panic(fmt.Sprintf("fluxo: example %q: %v", name, err))
+	}
+	var v any
+	_ = json.Unmarshal(data, &v)
+	return v
+}
+
+// exampleOption only stands for its example at registration; requests go through untouched
+func exampleOption(ex routeExample) gin.HandlerFunc {
+	info := &wrapperInfo{examples: []routeExample{ex}}
+	return recordWrapper(info.option, info)
+}
+
+// routeExamples returns the examples added by RequestExample and ResponseExample among handlers
+func routeExamples(handlers []gin.HandlerFunc) []routeExample {
+	var examples []routeExample
+	for _, h := range handlers {
+		if p, ok := wrapperOf(h); ok {
+			examples = append(examples, p.examples...)
+		}
+	}
+	return examples
+}
+
+// captureExamples documents the examples of a documented route
+func (a *App) captureExamples(method, path string, handlers []gin.HandlerFunc) {
+	examples := routeExamples(handlers)
+	key := method + ":" + path
+	info, ok := a.handlers[key]
+	if len(examples) == 0 || !ok {
+		return
+	}
+	info.docs = append(info.docs, func(_ *SwaggerGenerator, op *Operation) {
+		for _, ex := range examples {
+			documentExample(op, ex)
+		}
+	})
+	a.handlers[key] = info
+	a.invalidateSpec()
+}
+
+// documentExample adds ex to the request body or response it illustrates, documenting a JSON
+// body when the operation has none
+func documentExample(op *Operation, ex routeExample) {
+	var content map[string]MediaType
+	if ex.status == 0 {
+		if op.RequestBody == nil {
+			op.RequestBody = &RequestBody{}
+		}
+		if op.RequestBody.Content == nil {
+			op.RequestBody.Content = map[string]MediaType{}
+		}
+		content = op.RequestBody.Content
+	} else {
+		code := strconv.Itoa(ex.status)
+		if op.Responses == nil {
+			op.Responses = map[string]Response{}
+		}
+		res, ok := op.Responses[code]
+		if !ok {
+			res.Description = http.StatusText(ex.status)
+		}
+		if res.Content == nil {
+			res.Content = map[string]MediaType{}
+		}
+		op.Responses[code] = res
+		content = res.Content
+	}
+
+	mediaTypes := ex.mediaTypes
+	if len(mediaTypes) == 0 {
+		mediaTypes = slices.Sorted(maps.Keys(content))
+	}
+	if len(mediaTypes) == 0 {
+		mediaTypes = []string{"application/json"}
+	}
+	for _, mediaType := range mediaTypes {
+		media := content[mediaType]
+		if media.Examples == nil {
+			media.Examples = map[string]Example{}
+		}
+		media.Examples[ex.name] = Example{Value: ex.value}
+		content[mediaType] = media
+	}
+}
+
+// checkExamples checks the JSON examples of a media type against its schema
+func checkExamples(spec *OpenAPISpec, where, mediaType string, media MediaType, fail func(where, format string, args ...any)) {
+	if !isJSONMediaType(mediaType) {
+		return
+	}
+	for _, name := range slices.Sorted(maps.Keys(media.Examples)) {
+		var problems []string
+		checkValue(spec, media.Schema, media.Examples[name].Value, "example "+strconv.Quote(name), &problems)
+		for _, problem := range problems {
+			fail(where, "%s", problem)
+		}
+	}
+}
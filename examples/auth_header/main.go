@@ -47,14 +47,11 @@ func main() {
 	// Protected route using header-based middleware
 	app.GET("/ping", fluxo.Middleware(AuthMiddleware), fluxo.Handle(PingHandler))
 
-	fmt.Println("🚀 Header Auth Demo starting on :8080")
-	fmt.Println("\n🌟 Test with valid token:")
+	fmt.Println("🌟 Test with valid token:")
 	fmt.Println(`  curl http://localhost:8080/ping \`)
 	fmt.Println(`       -H "Authorization: Bearer secret-token" \`)
 	fmt.Println(`       -H "X-Custom-ID: my-app-123"`)
 
-	fmt.Println("\n🌟 Check Swagger docs at http://localhost:8080/docs")
-
 	if err := app.Start(":8080"); err != nil {
 		log.Fatal(err)
 	}
@@ -0,0 +1,230 @@
+// Code generated by fluxogen; DO NOT EDIT.
+
+//go:build fluxogen
+
+// ProductList: field Items of type []Product is validated by reflection
+// ProductList: field Items of type []Product is documented by reflection
+
+package main
+
+import (
+	"github.com/leviantech/fluxo"
+	"reflect"
+	"strconv"
+	"unicode/utf8"
+)
+
+func init() {
+	fluxo.RegisterGenerated(reflect.TypeOf(SearchProductsRequest{}), fluxo.Generated{
+		Query:    fluxogenQuerySearchProductsRequest,
+		Header:   fluxogenHeaderSearchProductsRequest,
+		Validate: fluxogenValidateSearchProductsRequest,
+		Schema: &fluxo.Schema{
+			Type: "object",
+			Properties: map[string]fluxo.Schema{
+				"q":         {Type: "string", Description: "Validation: required,min=2"},
+				"limit":     {Type: "integer", Format: "int64", Description: "Validation: min=1,max=100"},
+				"sort":      {Type: "string", Description: "Validation: oneof=name price", Enum: []interface{}{"name", "price"}},
+				"tag":       {Type: "array", Items: &fluxo.Schema{Type: "string"}},
+				"max_price": {Type: "number", Format: "double"},
+			},
+			Required: []string{"q"},
+		},
+	})
+	fluxo.RegisterGenerated(reflect.TypeOf(CreateProductRequest{}), fluxo.Generated{
+		Validate: fluxogenValidateCreateProductRequest,
+		Schema: &fluxo.Schema{
+			Type: "object",
+			Properties: map[string]fluxo.Schema{
+				"name":  {Type: "string", Description: "Validation: required,min=3,max=80"},
+				"price": {Type: "number", Format: "double", Description: "Validation: min=0.01"},
+				"tags":  {Type: "array", Items: &fluxo.Schema{Type: "string"}, Description: "Validation: omitempty,max=5"},
+			},
+			Required: []string{"name"},
+		},
+	})
+	fluxo.RegisterGenerated(reflect.TypeOf(Product{}), fluxo.Generated{
+		Validate: fluxogenValidateProduct,
+		Schema: &fluxo.Schema{
+			Type: "object",
+			Properties: map[string]fluxo.Schema{
+				"id":    {Type: "integer", Format: "int64"},
+				"name":  {Type: "string"},
+				"price": {Type: "number", Format: "double"},
+				"tags":  {Type: "array", Items: &fluxo.Schema{Type: "string"}},
+			},
+			Required: []string{},
+		},
+	})
+}
+
+func fluxogenQuerySearchProductsRequest(obj any, src map[string][]string) error {
+	req := obj.(*SearchProductsRequest)
+	if val, ok := fluxogenValue(src, "q", "", false); ok {
+		x := val
+		req.Query = x
+	}
+	if val, ok := fluxogenValue(src, "limit", "20", true); ok {
+		n, err := strconv.ParseInt(fluxogenOr(val, "0"), 10, 0)
+		if err != nil {
+			return err
+		}
+		x := int(n)
+		req.Limit = x
+	}
+	if val, ok := fluxogenValue(src, "sort", "name", true); ok {
+		x := val
+		req.Sort = x
+	}
+	if vs, ok := fluxogenValues(src, "tag", nil); ok {
+		s := make([]string, len(vs))
+		for i, val := range vs {
+			x := val
+			s[i] = x
+		}
+		req.Tags = s
+	}
+	if val, ok := fluxogenValue(src, "max_price", "", false); ok {
+		n, err := strconv.ParseFloat(fluxogenOr(val, "0.0"), 64)
+		if err != nil {
+			return err
+		}
+		x := float64(n)
+		if req.MaxPrice != nil {
+			*req.MaxPrice = x
+		} else {
+			req.MaxPrice = &x
+		}
+	}
+	if val, ok := fluxogenValue(src, "Tenant", "", false); ok {
+		x := val
+		req.Tenant = x
+	}
+	return nil
+}
+
+func fluxogenHeaderSearchProductsRequest(obj any, src map[string][]string) error {
+	req := obj.(*SearchProductsRequest)
+	if val, ok := fluxogenValue(src, "Query", "", false); ok {
+		x := val
+		req.Query = x
+	}
+	if val, ok := fluxogenValue(src, "Limit", "", false); ok {
+		n, err := strconv.ParseInt(fluxogenOr(val, "0"), 10, 0)
+		if err != nil {
+			return err
+		}
+		x := int(n)
+		req.Limit = x
+	}
+	if val, ok := fluxogenValue(src, "Sort", "", false); ok {
+		x := val
+		req.Sort = x
+	}
+	if vs, ok := fluxogenValues(src, "Tags", nil); ok {
+		s := make([]string, len(vs))
+		for i, val := range vs {
+			x := val
+			s[i] = x
+		}
+		req.Tags = s
+	}
+	if val, ok := fluxogenValue(src, "Maxprice", "", false); ok {
+		n, err := strconv.ParseFloat(fluxogenOr(val, "0.0"), 64)
+		if err != nil {
+			return err
+		}
+		x := float64(n)
+		if req.MaxPrice != nil {
+			*req.MaxPrice = x
+		} else {
+			req.MaxPrice = &x
+		}
+	}
+	if val, ok := fluxogenValue(src, "X-Tenant", "", false); ok {
+		x := val
+		req.Tenant = x
+	}
+	return nil
+}
+
+func fluxogenValidateSearchProductsRequest(obj any) []fluxo.FieldError {
+	req := obj.(*SearchProductsRequest)
+	var errs []fluxo.FieldError
+	switch {
+	case req.Query == "":
+		errs = append(errs, fluxo.FieldError{Field: "Query", Tag: "required"})
+	case utf8.RuneCountInString(req.Query) < 2:
+		errs = append(errs, fluxo.FieldError{Field: "Query", Tag: "min", Param: "2"})
+	}
+	switch {
+	case req.Limit < 1:
+		errs = append(errs, fluxo.FieldError{Field: "Limit", Tag: "min", Param: "1"})
+	case req.Limit > 100:
+		errs = append(errs, fluxo.FieldError{Field: "Limit", Tag: "max", Param: "100"})
+	}
+	switch {
+	case req.Sort != "name" && req.Sort != "price":
+		errs = append(errs, fluxo.FieldError{Field: "Sort", Tag: "oneof", Param: "name price"})
+	}
+	switch {
+	case req.Tenant == "":
+		errs = append(errs, fluxo.FieldError{Field: "Tenant", Tag: "required"})
+	}
+	return errs
+}
+
+func fluxogenValidateCreateProductRequest(obj any) []fluxo.FieldError {
+	req := obj.(*CreateProductRequest)
+	var errs []fluxo.FieldError
+	switch {
+	case req.Name == "":
+		errs = append(errs, fluxo.FieldError{Field: "Name", Tag: "required"})
+	case utf8.RuneCountInString(req.Name) < 3:
+		errs = append(errs, fluxo.FieldError{Field: "Name", Tag: "min", Param: "3"})
+	case utf8.RuneCountInString(req.Name) > 80:
+		errs = append(errs, fluxo.FieldError{Field: "Name", Tag: "max", Param: "80"})
+	}
+	switch {
+	case req.Price < 0.01:
+		errs = append(errs, fluxo.FieldError{Field: "Price", Tag: "min", Param: "0.01"})
+	}
+	if req.Tags != nil {
+		switch {
+		case len(req.Tags) > 5:
+			errs = append(errs, fluxo.FieldError{Field: "Tags", Tag: "max", Param: "5"})
+		}
+	}
+	return errs
+}
+
+func fluxogenValidateProduct(any) []fluxo.FieldError {
+	return nil
+}
+
+// fluxogenValue returns the first value under key, or def when it is missing or empty
+func fluxogenValue(src map[string][]string, key, def string, hasDef bool) (string, bool) {
+	vs, ok := src[key]
+	if !ok && !hasDef {
+		return "", false
+	}
+	if len(vs) > 0 && vs[0] != "" {
+		return vs[0], true
+	}
+	return def, true
+}
+
+// fluxogenValues returns the values under key, or def when it is missing
+func fluxogenValues(src map[string][]string, key string, def []string) ([]string, bool) {
+	if vs, ok := src[key]; ok {
+		return vs, true
+	}
+	return def, def != nil
+}
+
+func fluxogenOr(val, zero string) string {
+	if val == "" {
+		return zero
+	}
+	return val
+}
@@ -0,0 +1,76 @@
+package main
+
+//go:generate go run ../../cmd/fluxogen -type=SearchProductsRequest,CreateProductRequest,Product,ProductList
+
+import (
+	"log"
+	"strings"
+
+	"github.com/leviantech/fluxo"
+)
+
+// SearchProductsRequest is bound from the query string and headers
+type SearchProductsRequest struct {
+	Query    string   `form:"q" validate:"required,min=2"`
+	Limit    int      `form:"limit,default=20" validate:"min=1,max=100"`
+	Sort     string   `form:"sort,default=name" validate:"oneof=name price"`
+	Tags     []string `form:"tag"`
+	MaxPrice *float64 `form:"max_price"`
+	Tenant   string   `header:"X-Tenant" validate:"required"`
+}
+
+type CreateProductRequest struct {
+	Name  string   `json:"name" validate:"required,min=3,max=80"`
+	Price float64  `json:"price" validate:"min=0.01"`
+	Tags  []string `json:"tags" validate:"omitempty,max=5"`
+}
+
+type Product struct {
+	ID    int      `json:"id"`
+	Name  string   `json:"name"`
+	Price float64  `json:"price"`
+	Tags  []string `json:"tags"`
+}
+
+type ProductList struct {
+	Tenant string    `json:"tenant"`
+	Items  []Product `json:"items"`
+}
+
+var catalog = []Product{
+	{ID: 1, Name: "keyboard", Price: 49.9, Tags: []string{"input"}},
+	{ID: 2, Name: "mouse", Price: 19.9, Tags: []string{"input"}},
+	{ID: 3, Name: "monitor", Price: 199, Tags: []string{"display"}},
+}
+
+func searchProducts(ctx *fluxo.Context, req SearchProductsRequest) (ProductList, error) {
+	list := ProductList{Tenant: req.Tenant, Items: []Product{}}
+	for _, p := range catalog {
+		if !strings.Contains(p.Name, req.Query) || (req.MaxPrice != nil && p.Price > *req.MaxPrice) {
+			continue
+		}
+		if len(list.Items) < req.Limit {
+			list.Items = append(list.Items, p)
+		}
+	}
+	return list, nil
+}
+
+func createProduct(ctx *fluxo.Context, req CreateProductRequest) (Product, error) {
+	p := Product{ID: len(catalog) + 1, Name: req.Name, Price: req.Price, Tags: req.Tags}
+	catalog = append(catalog, p)
+	return p, nil
+}
+
+func setupApp() *fluxo.App {
+	app := fluxo.New().WithSwagger("Catalog API", "1.0.0")
+	app.GET("/products", fluxo.Handle(searchProducts))
+	app.POST("/products", fluxo.Handle(createProduct))
+	return app
+}
+
+// Build with -tags fluxogen to bind, validate and document the types above with the code in
+// fluxo_gen.go instead of reflection. Run go generate after changing them.
+func main() {
+	log.Fatal(setupApp().Start(":8080"))
+}
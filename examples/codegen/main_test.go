@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/leviantech/fluxo"
+)
+
+// The tests pass with and without -tags fluxogen, which is the point of the generated code
+
+func TestSearchProducts(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := setupApp()
+
+	tests := []struct {
+		name   string
+		query  string
+		tenant string
+		code   int
+		body   string
+	}{
+		{"query too short", "?q=o", "acme", http.StatusBadRequest, "Query must be at least 2 characters"},
+		{"matches", "?q=mo&tag=input&tag=x", "acme", http.StatusOK, `"name":"mouse"`},
+		{"limit", "?q=mo&limit=1", "acme", http.StatusOK, `"tenant":"acme"`},
+		{"max price", "?q=mo&max_price=100", "acme", http.StatusOK, `"mouse"`},
+		{"bad number", "?q=mo&limit=many", "acme", http.StatusBadRequest, "Query binding failed"},
+		{"limit too high", "?q=mo&limit=500", "acme", http.StatusBadRequest, "Limit must be at most 100 characters"},
+		{"bad sort", "?q=mo&sort=rating", "acme", http.StatusBadRequest, "Sort failed validation for oneof"},
+		{"missing tenant", "?q=mo", "", http.StatusBadRequest, "Tenant is required"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/products"+tt.query, nil)
+			if tt.tenant != "" {
+				req.Header.Set("X-Tenant", tt.tenant)
+			}
+			app.ServeHTTP(w, req)
+			if w.Code != tt.code || !strings.Contains(w.Body.String(), tt.body) {
+				t.Fatalf("expected %d containing %q, got %d %s", tt.code, tt.body, w.Code, w.Body.String())
+			}
+		})
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/products?q=mo&max_price=100", nil)
+	req.Header.Set("X-Tenant", "acme")
+	app.ServeHTTP(w, req)
+	var list ProductList
+	if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Items) != 1 || list.Items[0].Name != "mouse" {
+		t.Fatalf("expected only the mouse under 100, got %+v", list.Items)
+	}
+}
+
+func TestCreateProduct(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := setupApp()
+
+	tests := []struct {
+		body string
+		code int
+		want string
+	}{
+		{`{"name":"webcam","price":59.9,"tags":["video"]}`, http.StatusOK, `"name":"webcam"`},
+		{`{"name":"tv","price":300}`, http.StatusBadRequest, "Name must be at least 3 characters"},
+		{`{"name":"cable","price":0}`, http.StatusBadRequest, "Price must be at least 0.01 characters"},
+		{`{"name":"cable","price":1,"tags":["a","b","c","d","e","f"]}`, http.StatusBadRequest, "Tags must be at most 5 characters"},
+		{`{"price":1}`, http.StatusBadRequest, "Name is required"},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/products", bytes.NewBufferString(tt.body))
+		req.Header.Set("Content-Type", "application/json")
+		app.ServeHTTP(w, req)
+		if w.Code != tt.code || !strings.Contains(w.Body.String(), tt.want) {
+			t.Fatalf("%s: expected %d containing %q, got %d %s", tt.body, tt.code, tt.want, w.Code, w.Body.String())
+		}
+	}
+}
+
+func TestSpec(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	setupApp().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	var spec fluxo.OpenAPISpec
+	if err := json.Unmarshal(w.Body.Bytes(), &spec); err != nil {
+		t.Fatal(err)
+	}
+	schema, ok := spec.Components.Schemas["CreateProductRequest"]
+	if !ok {
+		t.Fatalf("expected a CreateProductRequest schema, got %v", spec.Components.Schemas)
+	}
+	if schema.Properties["price"].Type != "number" || len(schema.Required) != 1 || schema.Required[0] != "name" {
+		t.Fatalf("unexpected schema %+v", schema)
+	}
+	if spec.Paths["/products"].GET == nil {
+		t.Fatal("expected GET /products to be documented")
+	}
+}
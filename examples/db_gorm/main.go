@@ -1,11 +1,11 @@
 package main
 
 import (
-	"fmt"
 	"log"
 
 	"github.com/gin-gonic/gin"
 	"github.com/leviantech/fluxo"
+	"github.com/leviantech/fluxo/gormx"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 )
@@ -69,8 +69,9 @@ func createProduct(ctx *fluxo.Context, req CreateProductRequest) (Product, error
 		Name:  req.Name,
 	}
 
+	// A duplicate code becomes 409 Conflict
 	if err := db.Create(&product).Error; err != nil {
-		return Product{}, fluxo.BadRequest(fmt.Sprintf("failed to create product: %v", err))
+		return Product{}, gormx.MapError(err)
 	}
 
 	return product, nil
@@ -78,16 +79,15 @@ func createProduct(ctx *fluxo.Context, req CreateProductRequest) (Product, error
 
 func getProduct(ctx *fluxo.Context, req GetProductRequest) (Product, error) {
 	var product Product
-	if err := db.First(&product, req.ID).Error; err != nil {
-		return Product{}, fluxo.NotFound(fmt.Sprintf("product %d not found", req.ID))
-	}
-	return product, nil
+	// A missing row becomes 404 Not Found
+	err := db.First(&product, req.ID).Error
+	return product, gormx.MapError(err)
 }
 
 func updateProduct(ctx *fluxo.Context, req UpdateProductRequest) (Product, error) {
 	var product Product
 	if err := db.First(&product, req.ID).Error; err != nil {
-		return Product{}, fluxo.NotFound(fmt.Sprintf("product %d not found", req.ID))
+		return Product{}, gormx.MapError(err)
 	}
 
 	if req.Price > 0 {
@@ -97,13 +97,12 @@ func updateProduct(ctx *fluxo.Context, req UpdateProductRequest) (Product, error
 		product.Name = req.Name
 	}
 
-	db.Save(&product)
-	return product, nil
+	return product, gormx.MapError(db.Save(&product).Error)
 }
 
 func deleteProduct(ctx *fluxo.Context, req GetProductRequest) (gin.H, error) {
 	if err := db.Delete(&Product{}, req.ID).Error; err != nil {
-		return nil, fluxo.BadRequest(fmt.Sprintf("failed to delete: %v", err))
+		return nil, gormx.MapError(err)
 	}
 	return gin.H{"status": "deleted"}, nil
 }
@@ -124,6 +123,7 @@ func setupApp() *fluxo.App {
 
 	app := fluxo.New().WithSwagger("GORM SQLite API", "1.0.0")
 
+	app.GET("/healthz", gormx.Health(db))
 	app.GET("/products", fluxo.Handle(listProducts))
 	app.POST("/products", fluxo.Handle(createProduct))
 	app.GET("/products/:id", fluxo.Handle(getProduct))
@@ -135,6 +135,7 @@ func setupApp() *fluxo.App {
 
 func main() {
 	app := setupApp()
-	fmt.Println("🚀 GORM API starting on :8080")
-	app.Start(":8080")
+	if err := app.Start(":8080"); err != nil {
+		log.Fatal(err)
+	}
 }
@@ -54,6 +54,34 @@ func TestProductAPI(t *testing.T) {
 		}
 	})
 
+	t.Run("Create Product - Duplicate Code", func(t *testing.T) {
+		body, _ := json.Marshal(CreateProductRequest{
+			Code:  "P001",
+			Price: 120,
+			Name:  "Another Laptop",
+		})
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/products", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		app.ServeHTTP(w, req)
+
+		if w.Code != http.StatusConflict {
+			t.Errorf("Expected status 409, got %d. Body: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("Health", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+
+		app.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("Expected status 200, got %d", w.Code)
+		}
+	})
+
 	t.Run("List Products", func(t *testing.T) {
 		w := httptest.NewRecorder()
 		req := httptest.NewRequest(http.MethodGet, "/products", nil)
@@ -65,8 +65,7 @@ func main() {
 	// Swagger will automatically merge fields from AuthHeader and CreatePostRequest
 	app.POST("/posts", fluxo.Middleware(AuthMiddleware), fluxo.Handle(CreatePostHandler))
 
-	fmt.Println("🚀 Middleware Demo starting on :8080")
-	fmt.Println("\n🌟 Test with valid token:")
+	fmt.Println("🌟 Test with valid token:")
 	fmt.Println(`  curl -X POST "http://localhost:8080/posts?token=valid-token" \`)
 	fmt.Println(`       -H "Content-Type: application/json" \`)
 	fmt.Println(`       -d '{"title":"Fluxo is Great","content":"Type-safe middleware is awesome"}'`)
@@ -75,8 +74,6 @@ func main() {
 	fmt.Println(`  curl -X POST "http://localhost:8080/posts?token=wrong" \`)
 	fmt.Println(`       -d '{"title":"Fail","content":"fail"}'`)
 
-	fmt.Println("\n🌟 Check Swagger docs at http://localhost:8080/docs")
-
 	if err := app.Start(":8080"); err != nil {
 		log.Fatal(err)
 	}
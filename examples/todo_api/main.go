@@ -132,8 +132,8 @@ func setupApp() *fluxo.App {
 	app := fluxo.New().WithSwagger("Todo Advanced API", "1.0.0")
 
 	// Global middleware
+	app.WithRecovery()
 	app.Use(gin.Logger())
-	app.Use(gin.Recovery())
 
 	// Public routes
 	app.GET("/todos", fluxo.Handle(listTodosHandler))
@@ -152,7 +152,6 @@ func setupApp() *fluxo.App {
 
 func main() {
 	app := setupApp()
-	fmt.Println("🚀 Todo API starting on :8080")
 	if err := app.Start(":8080"); err != nil {
 		log.Fatal(err)
 	}
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/gin-gonic/gin"
+	"github.com/leviantech/fluxo"
 )
 
 func TestTodoAPI(t *testing.T) {
@@ -137,3 +138,8 @@ func TestTodoAPI(t *testing.T) {
 		}
 	})
 }
+
+func TestTodoAPI_Fuzz(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	fluxo.Fuzz(t, setupApp())
+}
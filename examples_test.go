@@ -0,0 +1,118 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type exampleTodoReq struct {
+	Title string `json:"title" validate:"required"`
+	Done  bool   `json:"done"`
+}
+
+type exampleTodo struct {
+	ID    int    `json:"id"`
+	Title string `json:"title"`
+}
+
+func TestRequestExample_Documented(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	file := filepath.Join(t.TempDir(), "todo.json")
+	if err := os.WriteFile(file, []byte(`{"id": 7, "title": "Buy milk"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	app := New().WithSwagger("t", "v")
+	app.POST("/todos",
+		RequestExample("groceries", exampleTodoReq{Title: "Buy milk"}),
+		ResponseExample(http.StatusOK, "groceries", ExampleFile(file)),
+		ResponseExample(http.StatusConflict, "duplicate", gin.H{"error": "todo exists"}),
+		Handle(func(ctx *Context, req exampleTodoReq) (exampleTodo, error) { return exampleTodo{}, nil }))
+
+	op := groupSpec(t, app).Paths["/todos"].POST
+	if op == nil {
+		t.Fatal("the route isn't documented")
+	}
+	req := op.RequestBody.Content["application/json"].Examples["groceries"].Value
+	if want := map[string]any{"title": "Buy milk", "done": false}; !reflect.DeepEqual(req, want) {
+		t.Errorf("request example: got %v", req)
+	}
+	res := op.Responses["200"].Content["application/json"].Examples["groceries"].Value
+	if want := map[string]any{"id": float64(7), "title": "Buy milk"}; !reflect.DeepEqual(res, want) {
+		t.Errorf("response example: got %v", res)
+	}
+	conflict, ok := op.Responses["409"]
+	if !ok || conflict.Description != "Conflict" || conflict.Content["application/json"].Examples["duplicate"].Value == nil {
+		t.Errorf("expected an undocumented response to be added, got %+v", op.Responses)
+	}
+	if err := app.Validate(); err != nil {
+		t.Errorf("expected valid examples, got %v", err)
+	}
+
+	// Requests go through the options untouched
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/todos", strings.NewReader(`{"title":"x"}`))
+	r.Header.Set("Content-Type", "application/json")
+	app.ServeHTTP(w, r)
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d %s", w.Code, w.Body)
+	}
+}
+
+func TestRequestExample_MediaTypes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := New().WithSwagger("t", "v")
+	app.GET("/todos", Produces(CSVFormat),
+		ResponseExample(http.StatusOK, "csv", "id,title\n7,Buy milk\n", "text/csv"),
+		Handle(func(ctx *Context, _ struct{}) ([]exampleTodo, error) { return nil, nil }))
+
+	content := groupSpec(t, app).Paths["/todos"].GET.Responses["200"].Content
+	if content["text/csv"].Examples["csv"].Value != "id,title\n7,Buy milk\n" {
+		t.Errorf("expected the CSV example, got %+v", content["text/csv"])
+	}
+	if len(content["application/json"].Examples) != 0 {
+		t.Errorf("expected no JSON example, got %+v", content["application/json"].Examples)
+	}
+	if err := app.Validate(); err != nil {
+		t.Errorf("non-JSON examples aren't checked against schemas, got %v", err)
+	}
+}
+
+func TestRequestExample_Validated(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := New().WithSwagger("t", "v")
+	g := app.TypedGroup("/v1")
+	g.POST("/todos",
+		RequestExample("stale", gin.H{"title": 42}),
+		Handle(func(ctx *Context, req exampleTodoReq) (exampleTodo, error) { return exampleTodo{}, nil }))
+
+	err := app.Validate()
+	if err == nil {
+		t.Fatal("expected the example to be reported")
+	}
+	if want := `POST /v1/todos: request body application/json: example "stale"`; !strings.Contains(err.Error(), want) || !strings.Contains(err.Error(), "must be a string") {
+		t.Errorf("expected %q about the title in:\n%v", want, err)
+	}
+}
+
+func TestExampleFile_Invalid(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "broken.json")
+	if err := os.WriteFile(file, []byte(`{"id":`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if r := recover(); r == nil || !strings.Contains(r.(string), "not valid JSON") {
+			t.Errorf("expected a panic about the file, got %v", r)
+		}
+	}()
+	ExampleFile(file)
+}
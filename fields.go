@@ -0,0 +1,120 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FieldsQueryParam is the query parameter used to request a sparse fieldset, e.g. ?fields=id,owner.name.
+// Set it to "" to disable response filtering.
+var FieldsQueryParam = "fields"
+
+// fieldTree is a parsed sparse fieldset; a nil subtree selects the whole value
+type fieldTree map[string]fieldTree
+
+// parseFields parses "id,title,owner.name" into a tree of selected paths
+func parseFields(s string) fieldTree {
+	tree := fieldTree{}
+	for _, path := range strings.Split(s, ",") {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+		node := tree
+		parts := strings.Split(path, ".")
+		for i, part := range parts {
+			child, exists := node[part]
+			if exists && child == nil {
+				// A shorter path already selects the whole value
+				break
+			}
+			if i == len(parts)-1 {
+				node[part] = nil
+				break
+			}
+			if child == nil {
+				child = fieldTree{}
+				node[part] = child
+			}
+			node = child
+		}
+	}
+	return tree
+}
+
+// prune keeps only the selected fields of v; arrays are filtered element by element
+func (ft fieldTree) prune(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(ft))
+		for key, sub := range ft {
+			field, ok := val[key]
+			if !ok {
+				continue
+			}
+			if sub == nil {
+				out[key] = field
+			} else {
+				out[key] = sub.prune(field)
+			}
+		}
+		return out
+	case []interface{}:
+		for i := range val {
+			val[i] = ft.prune(val[i])
+		}
+		return val
+	default:
+		return v
+	}
+}
+
+// renderJSON writes res as JSON, applying the request's scopes and view and the sparse fieldset
+// requested by the client, unless the client prefers a format the route Produces
+func renderJSON(ctx *gin.Context, status int, res interface{}) {
+	f, formatted := negotiateFormat(ctx)
+	// Fields outside the request's scopes and view are removed first, so that no format shows them
+	res, err := filterResponse(ctx, res, formatted)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Encoding response failed: %v", err)})
+		return
+	}
+	if formatted {
+		writeFormat(ctx, status, f, res)
+		return
+	}
+	fields := ""
+	if FieldsQueryParam != "" {
+		fields = ctx.Query(FieldsQueryParam)
+	}
+	tree := parseFields(fields)
+	if len(tree) == 0 {
+		writeJSON(ctx, status, envelop(ctx, res))
+		return
+	}
+
+	marshal := json.Marshal
+	if codec, ok := requestCodec(ctx); ok {
+		marshal = codec.Marshal
+	}
+	data, err := marshal(res)
+	if err != nil {
+		writeJSON(ctx, status, envelop(ctx, res))
+		return
+	}
+	var generic interface{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&generic); err != nil {
+		writeJSON(ctx, status, envelop(ctx, res))
+		return
+	}
+	writeJSON(ctx, status, envelop(ctx, tree.prune(generic)))
+}
@@ -0,0 +1,72 @@
+package fluxo
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type fsOwner struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type fsTodo struct {
+	ID    int     `json:"id"`
+	Title string  `json:"title"`
+	Done  bool    `json:"done"`
+	Owner fsOwner `json:"owner"`
+}
+
+func TestParseFields(t *testing.T) {
+	tree := parseFields("id, owner.name,owner,title,")
+	want := fieldTree{"id": nil, "owner": nil, "title": nil}
+	if !reflect.DeepEqual(tree, want) {
+		t.Fatalf("unexpected tree %#v", tree)
+	}
+}
+
+func TestSparseFieldsets(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := New().WithSwagger("Test", "1.0.0")
+	todo := fsTodo{ID: 1, Title: "t", Done: true, Owner: fsOwner{ID: 9, Name: "ann"}}
+	app.GET("/todo", Handle(func(ctx *Context, req struct{}) (fsTodo, error) { return todo, nil }))
+	app.GET("/todos", Handle(func(ctx *Context, req struct{}) ([]fsTodo, error) { return []fsTodo{todo, todo}, nil }))
+
+	get := func(url string) interface{} {
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, url, nil))
+		var v interface{}
+		if err := json.Unmarshal(w.Body.Bytes(), &v); err != nil {
+			t.Fatalf("invalid json: %v", err)
+		}
+		return v
+	}
+
+	got := get("/todo?fields=id,owner.name")
+	want := map[string]interface{}{"id": float64(1), "owner": map[string]interface{}{"name": "ann"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected body %v", got)
+	}
+
+	list := get("/todos?fields=title").([]interface{})
+	if len(list) != 2 || !reflect.DeepEqual(list[1], map[string]interface{}{"title": "t"}) {
+		t.Fatalf("unexpected list %v", list)
+	}
+
+	full := get("/todo").(map[string]interface{})
+	if len(full) != 4 {
+		t.Fatalf("expected full body without fields, got %v", full)
+	}
+
+	spec := app.swagger.Generate(app.handlers)
+	op := spec["paths"].(map[string]interface{})["/todo"].(map[string]interface{})["get"].(map[string]interface{})
+	params := op["parameters"].([]interface{})
+	if len(params) != 1 || params[0].(map[string]interface{})["name"] != "fields" {
+		t.Fatalf("expected fields query parameter, got %v", params)
+	}
+}
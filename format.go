@@ -0,0 +1,311 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Format is a media type typed handlers can respond with besides JSON, see Produces
+type Format struct {
+	MediaType string
+	Encode    func(w io.Writer, v any) error
+	// Schema documents the encoded response given the schema of its JSON form; nil keeps it
+	Schema func(json Schema) Schema
+}
+
+var (
+	// XMLFormat encodes responses with encoding/xml
+	XMLFormat = Format{
+		MediaType: "application/xml",
+		Encode:    func(w io.Writer, v any) error { return xml.NewEncoder(w).Encode(v) },
+	}
+
+	// NDJSONFormat writes each element of a slice response as a line of JSON
+	NDJSONFormat = Format{MediaType: "application/x-ndjson", Encode: encodeNDJSON, Schema: ndjsonSchema}
+
+	// CSVFormat writes a slice of structs as CSV, with a header row of their JSON field names
+	CSVFormat = Format{
+		MediaType: "text/csv",
+		Encode:    encodeCSV,
+		Schema:    func(Schema) Schema { return Schema{Type: "string"} },
+	}
+)
+
+const formatsKey = "fluxo.formats"
+
+// Produces is a route option (or group and app middleware) letting the typed handlers after it
+// respond in formats besides JSON. The format is picked from the Accept header, JSON winning
+// ties, and each one is documented under the operation's success responses:
+//
+//	app.GET("/todos", fluxo.Produces(fluxo.CSVFormat, fluxo.NDJSONFormat), fluxo.Handle(listTodos))
+//
+// Errors are still written as JSON.
+func Produces(formats ...Format) gin.HandlerFunc {
+	return recordWrapper(func(ctx *gin.Context) {
+		existing, _ := ctx.Value(formatsKey).([]Format)
+		ctx.Set(formatsKey, slices.Concat(existing, formats))
+	}, &wrapperInfo{formats: formats})
+}
+
+// routeFormats returns the formats added by Produces among handlers
+func routeFormats(handlers []gin.HandlerFunc) []Format {
+	var formats []Format
+	for _, h := range handlers {
+		if p, ok := wrapperOf(h); ok {
+			formats = append(formats, p.formats...)
+		}
+	}
+	return formats
+}
+
+// captureFormats documents the formats a documented route produces, and its default view
+func (a *App) captureFormats(method, path string, handlers []gin.HandlerFunc) {
+	formats, view := routeFormats(handlers), routeView(handlers)
+	key := method + ":" + path
+	info, ok := a.handlers[key]
+	if len(formats) == 0 && view == "" || !ok {
+		return
+	}
+	info.docs = append(info.docs, func(_ *SwaggerGenerator, op *Operation) {
+		documentFormats(op, formats)
+		if view != "" {
+			documentDefaultView(op, view)
+		}
+	})
+	a.handlers[key] = info
+	a.invalidateSpec()
+}
+
+// documentFormats adds formats next to the JSON content of the operation's success responses
+func documentFormats(op *Operation, formats []Format) {
+	for code, res := range op.Responses {
+		media, ok := res.Content["application/json"]
+		if !strings.HasPrefix(code, "2") || !ok {
+			continue
+		}
+		for _, f := range formats {
+			schema := media.Schema
+			if f.Schema != nil {
+				schema = f.Schema(schema)
+			}
+			res.Content[f.MediaType] = MediaType{Schema: schema}
+		}
+	}
+}
+
+// negotiateFormat returns the format of the request the client prefers over JSON, if any
+func negotiateFormat(ctx *gin.Context) (Format, bool) {
+	formats, _ := ctx.Value(formatsKey).([]Format)
+	if len(formats) == 0 {
+		return Format{}, false
+	}
+	ctx.Header("Vary", "Accept")
+	ranges := parseAccept(ctx.GetHeader("Accept"))
+	if len(ranges) == 0 {
+		return Format{}, false
+	}
+
+	best, bestQ := -1, acceptQuality(ranges, "application/json")
+	for i, f := range formats {
+		if q := acceptQuality(ranges, f.MediaType); q > bestQ {
+			best, bestQ = i, q
+		}
+	}
+	if best < 0 {
+		return Format{}, false
+	}
+	return formats[best], true
+}
+
+// writeFormat encodes res in format f, reporting encoding failures as JSON errors
+func writeFormat(ctx *gin.Context, status int, f Format, res any) {
+	var buf bytes.Buffer
+	if err := f.Encode(&buf, res); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Encoding response failed: %v", err)})
+		return
+	}
+	ctx.Data(status, f.MediaType, buf.Bytes())
+}
+
+// mediaRange is an entry of an Accept header
+type mediaRange struct {
+	typ string
+	q   float64
+}
+
+// parseAccept returns the media ranges of an Accept header, most preferred first
+func parseAccept(header string) []mediaRange {
+	var ranges []mediaRange
+	for _, part := range strings.Split(header, ",") {
+		typ, params, _ := strings.Cut(part, ";")
+		typ = strings.ToLower(strings.TrimSpace(typ))
+		if typ == "" {
+			continue
+		}
+		q := 1.0
+		for _, param := range strings.Split(params, ";") {
+			name, value, ok := strings.Cut(param, "=")
+			if !ok || strings.TrimSpace(name) != "q" {
+				continue
+			}
+			if parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil && parsed >= 0 && parsed <= 1 {
+				q = parsed
+			}
+		}
+		ranges = append(ranges, mediaRange{typ: typ, q: q})
+	}
+	sort.SliceStable(ranges, func(i, j int) bool { return ranges[i].q > ranges[j].q })
+	return ranges
+}
+
+// acceptQuality returns the quality the client gives mediaType, taken from the most specific
+// range matching it
+func acceptQuality(ranges []mediaRange, mediaType string) float64 {
+	mediaType = strings.ToLower(mediaType)
+	major, _, _ := strings.Cut(mediaType, "/")
+	q, specificity := 0.0, 0
+	for _, r := range ranges {
+		s := 0
+		switch r.typ {
+		case mediaType:
+			s = 3
+		case major + "/*":
+			s = 2
+		case "*/*":
+			s = 1
+		}
+		if s > specificity {
+			q, specificity = r.q, s
+		}
+	}
+	return q
+}
+
+// encodeNDJSON writes each element of a slice or array as a line of JSON, and anything else as one line
+func encodeNDJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return enc.Encode(v)
+	}
+	for i := 0; i < rv.Len(); i++ {
+		if err := enc.Encode(rv.Index(i).Interface()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ndjsonSchema documents a line of an NDJSON response
+func ndjsonSchema(s Schema) Schema {
+	if s.Type == "array" && s.Items != nil {
+		return *s.Items
+	}
+	return s
+}
+
+// csvColumn is a column of a CSV response
+type csvColumn struct {
+	name  string
+	index []int
+}
+
+// encodeCSV writes a slice of structs, or a single struct, as CSV with a header row
+func encodeCSV(w io.Writer, v any) error {
+	rows := reflect.Indirect(reflect.ValueOf(v))
+	if rows.Kind() == reflect.Struct {
+		single := reflect.MakeSlice(reflect.SliceOf(rows.Type()), 1, 1)
+		single.Index(0).Set(rows)
+		rows = single
+	}
+	if rows.Kind() != reflect.Slice && rows.Kind() != reflect.Array {
+		return fmt.Errorf("CSV needs a slice of structs, got %T", v)
+	}
+	elem := rows.Type().Elem()
+	if elem.Kind() == reflect.Ptr {
+		elem = elem.Elem()
+	}
+	if elem.Kind() != reflect.Struct {
+		return fmt.Errorf("CSV needs a slice of structs, got %T", v)
+	}
+
+	var columns []csvColumn
+	for _, f := range reflect.VisibleFields(elem) {
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if !f.IsExported() || name == "-" || (f.Anonymous && name == "") {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		columns = append(columns, csvColumn{name: name, index: f.Index})
+	}
+
+	cw := csv.NewWriter(w)
+	header := make([]string, len(columns))
+	for i, c := range columns {
+		header[i] = c.name
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for i := 0; i < rows.Len(); i++ {
+		row := reflect.Indirect(rows.Index(i))
+		if !row.IsValid() {
+			continue
+		}
+		record := make([]string, len(columns))
+		for j, c := range columns {
+			field, err := row.FieldByIndexErr(c.index)
+			if err != nil {
+				continue // behind a nil embedded pointer
+			}
+			if record[j], err = csvValue(field); err != nil {
+				return err
+			}
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// csvValue formats a field: text and basic values as they are, anything else as JSON
+func csvValue(v reflect.Value) (string, error) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return "", nil
+		}
+		v = v.Elem()
+	}
+	if m, ok := v.Interface().(encoding.TextMarshaler); ok {
+		text, err := m.MarshalText()
+		return string(text), err
+	}
+	switch v.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return fmt.Sprint(v.Interface()), nil
+	}
+	data, err := json.Marshal(v.Interface())
+	return string(data), err
+}
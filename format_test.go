@@ -0,0 +1,114 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type formatTodo struct {
+	ID     int       `json:"id" xml:"id"`
+	Title  string    `json:"title" xml:"title"`
+	Tags   []string  `json:"tags" xml:"tag"`
+	Due    time.Time `json:"due" xml:"due"`
+	Secret string    `json:"-" xml:"-"`
+}
+
+func formatTodos(ctx *Context, req struct{}) ([]formatTodo, error) {
+	due := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	return []formatTodo{{ID: 1, Title: "milk, eggs", Tags: []string{"home"}, Due: due}, {ID: 2, Title: "call"}}, nil
+}
+
+func formatApp() *App {
+	gin.SetMode(gin.TestMode)
+	app := New().WithSwagger("t", "v")
+	app.GET("/todos", Produces(CSVFormat, NDJSONFormat), Handle(formatTodos))
+	xml := app.TypedGroup("/xml", Produces(XMLFormat))
+	xml.GET("/todo", Handle(func(ctx *Context, req struct{}) (formatTodo, error) { return formatTodo{ID: 3, Title: "x"}, nil }))
+	return app
+}
+
+func TestProduces_Negotiation(t *testing.T) {
+	app := formatApp()
+
+	tests := []struct {
+		path   string
+		accept string
+		ctype  string
+		body   string
+	}{
+		{"/todos", "", "application/json", `[{"id":1`},
+		{"/todos", "*/*", "application/json", `[{"id":1`},
+		{"/todos", "text/csv", "text/csv", "id,title,tags,due\n1,\"milk, eggs\",\"[\"\"home\"\"]\",2025-01-02T00:00:00Z\n2,call,null,0001-01-01T00:00:00Z\n"},
+		{"/todos", "application/json;q=0.5, application/x-ndjson", "application/x-ndjson", "{\"id\":1,\"title\":\"milk, eggs\",\"tags\":[\"home\"],\"due\":\"2025-01-02T00:00:00Z\"}\n{\"id\":2,"},
+		{"/todos", "text/*, application/json", "application/json", `[{"id":1`},
+		{"/todos", "application/xml", "application/json", `[{"id":1`},
+		{"/xml/todo", "application/xml", "application/xml", "<formatTodo><id>3</id><title>x</title>"},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+		if tt.accept != "" {
+			req.Header.Set("Accept", tt.accept)
+		}
+		app.ServeHTTP(w, req)
+		if w.Code != http.StatusOK || !strings.HasPrefix(w.Header().Get("Content-Type"), tt.ctype) || !strings.HasPrefix(w.Body.String(), tt.body) {
+			t.Errorf("%s with %q: expected %s starting with %q, got %d %s %q", tt.path, tt.accept, tt.ctype, tt.body, w.Code, w.Header().Get("Content-Type"), w.Body.String())
+		}
+		if w.Header().Get("Vary") != "Accept" {
+			t.Errorf("%s: expected Vary: Accept", tt.path)
+		}
+	}
+}
+
+func TestProduces_EncodingError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := New()
+	app.GET("/h", Produces(CSVFormat), Handle(func(ctx *Context, req struct{}) (gin.H, error) { return gin.H{"a": 1}, nil }))
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/h", nil)
+	req.Header.Set("Accept", "text/csv")
+	app.ServeHTTP(w, req)
+	if w.Code != http.StatusInternalServerError || !strings.Contains(w.Body.String(), "CSV needs a slice of structs") {
+		t.Fatalf("expected a 500 explaining the failure, got %d %s", w.Code, w.Body.String())
+	}
+}
+
+func TestProduces_Spec(t *testing.T) {
+	app := formatApp()
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	var spec OpenAPISpec
+	if err := json.Unmarshal(w.Body.Bytes(), &spec); err != nil {
+		t.Fatal(err)
+	}
+
+	content := spec.Paths["/todos"].GET.Responses["200"].Content
+	if content["application/json"].Schema.Type != "array" {
+		t.Fatalf("expected the JSON response to stay, got %+v", content)
+	}
+	if content["text/csv"].Schema.Type != "string" {
+		t.Errorf("expected CSV documented as text, got %+v", content["text/csv"])
+	}
+	if line := content["application/x-ndjson"].Schema; line.Type != "object" || line.Properties["title"].Type != "string" {
+		t.Errorf("expected NDJSON documented by its lines, got %+v", line)
+	}
+	if _, ok := content["application/xml"]; ok {
+		t.Error("expected XML only on the group's routes")
+	}
+	todo := spec.Paths["/xml/todo"].GET.Responses["200"].Content
+	if xml, ok := todo["application/xml"]; !ok || !reflect.DeepEqual(xml.Schema, todo["application/json"].Schema) {
+		t.Errorf("expected XML documented like JSON, got %+v", todo)
+	}
+	if _, ok := spec.Paths["/todos"].GET.Responses["400"].Content["text/csv"]; ok {
+		t.Error("expected errors to stay JSON")
+	}
+}
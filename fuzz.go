@@ -0,0 +1,745 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math/rand/v2"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// FuzzOption configures Fuzz
+type FuzzOption func(*fuzzConfig)
+
+type fuzzConfig struct {
+	runs int
+	seed uint64
+}
+
+// WithFuzzRuns sets how many valid requests are generated per route, 10 by default. Each one
+// is also sent once per way of making it invalid.
+func WithFuzzRuns(n int) FuzzOption {
+	return func(c *fuzzConfig) {
+		c.runs = n
+	}
+}
+
+// WithFuzzSeed seeds the generated requests, 1 by default. Failures report the seed so they can
+// be replayed.
+func WithFuzzSeed(seed uint64) FuzzOption {
+	return func(c *fuzzConfig) {
+		c.seed = seed
+	}
+}
+
+// Fuzz drives random requests through every documented route of the app, built from the request
+// types of their handlers and middleware: valid ones, following the validate tags, and invalid ones
+// missing a required field, breaking a rule, sending a value of the wrong type or malformed JSON.
+// It fails t when a handler panics, or when an invalid request isn't answered with a 4xx status:
+//
+//	func TestFuzz(t *testing.T) {
+//		fluxo.Fuzz(t, newApp())
+//	}
+//
+// Routes are served through app.ServeHTTP, so their middleware runs as usual.
+func Fuzz(t testing.TB, a *App, opts ...FuzzOption) {
+	t.Helper()
+	cfg := fuzzConfig{runs: 10, seed: 1}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	g := &fuzzGen{rng: rand.New(rand.NewPCG(cfg.seed, cfg.seed))}
+
+	handlers := a.specHandlers()
+	for _, key := range slices.Sorted(maps.Keys(handlers)) {
+		route := newFuzzRoute(handlers[key])
+		mutations := route.mutations()
+		for i := 0; i < cfg.runs; i++ {
+			if !fuzzServe(t, a, route.valid(g), "", cfg.seed) {
+				break
+			}
+			failed := false
+			for _, m := range mutations {
+				r := route.valid(g)
+				m.apply(r, g)
+				if !fuzzServe(t, a, r, m.desc, cfg.seed) {
+					failed = true
+					break
+				}
+			}
+			if failed {
+				break
+			}
+		}
+	}
+}
+
+// fuzzServe sends r, reporting a panic, or a status other than 4xx when r is invalid because of
+// invalid. It returns false when it failed t.
+func fuzzServe(t testing.TB, a *App, r *fuzzRequest, invalid string, seed uint64) (ok bool) {
+	t.Helper()
+	req := r.build()
+	w := httptest.NewRecorder()
+	defer func() {
+		if p := recover(); p != nil {
+			t.Errorf("fluxo: %s panicked: %v (seed %d)\n%s", r, p, seed, r.dump())
+			ok = false
+		}
+	}()
+	a.ServeHTTP(w, req)
+	if invalid != "" && (w.Code < 400 || w.Code > 499) {
+		t.Errorf("fluxo: %s answered %d to a request %s, expected a 4xx status (seed %d)\n%s\nresponse: %s",
+			r, w.Code, invalid, seed, r.dump(), truncate(w.Body.String(), 200))
+		return false
+	}
+	return true
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "..."
+}
+
+// fuzzRequest is a request to a route, kept as values so it can be made invalid before it is built
+type fuzzRequest struct {
+	method string
+	path   string // route path, like /users/:id
+	params map[string]string
+	query  url.Values
+	header http.Header
+	body   map[string]any // JSON body, nil for none
+	raw    string         // replaces the JSON body when set
+}
+
+func (r *fuzzRequest) String() string {
+	return r.method + " " + r.path
+}
+
+// target returns the URL of the request, with the route's parameters filled in
+func (r *fuzzRequest) target() string {
+	parts := strings.Split(r.path, "/")
+	for i, part := range parts {
+		if strings.HasPrefix(part, ":") || strings.HasPrefix(part, "*") {
+			value, ok := r.params[part[1:]]
+			if !ok || value == "" {
+				value = "1"
+			}
+			parts[i] = escapePathParam(value, part[0] == '*')
+		}
+	}
+	target := strings.Join(parts, "/")
+	if len(r.query) > 0 {
+		target += "?" + r.query.Encode()
+	}
+	return target
+}
+
+func (r *fuzzRequest) payload() string {
+	if r.raw != "" {
+		return r.raw
+	}
+	if r.body == nil {
+		return ""
+	}
+	data, _ := json.Marshal(r.body)
+	return string(data)
+}
+
+func (r *fuzzRequest) build() *http.Request {
+	var req *http.Request
+	if payload := r.payload(); payload != "" {
+		req = httptest.NewRequest(r.method, r.target(), strings.NewReader(payload))
+		req.Header.Set("Content-Type", "application/json")
+	} else {
+		req = httptest.NewRequest(r.method, r.target(), nil)
+	}
+	for name, values := range r.header {
+		req.Header[name] = values
+	}
+	return req
+}
+
+// dump shows the request in failure messages
+func (r *fuzzRequest) dump() string {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "%s %s\n", r.method, r.target())
+	for _, name := range slices.Sorted(maps.Keys(r.header)) {
+		fmt.Fprintf(&b, "%s: %s\n", name, strings.Join(r.header[name], ", "))
+	}
+	if payload := r.payload(); payload != "" {
+		b.WriteString("\n" + truncate(payload, 500))
+	}
+	return b.String()
+}
+
+// fuzzField is a field of a request type and where it is bound from
+type fuzzField struct {
+	name      string
+	source    string // path, query, header or body
+	typ       reflect.Type
+	rules     []fuzzRule // validate rules of the field itself
+	elemRules []fuzzRule // validate rules after dive
+	required  bool
+	defaulted bool // the form tag gives a default
+}
+
+type fuzzRule struct {
+	tag, param string
+	either     bool // an alternative of a rule like email|url
+}
+
+// fuzzRoute is a documented route and the fields of its request types
+type fuzzRoute struct {
+	method string
+	path   string
+	body   bool // requests carry a JSON body
+	fields []fuzzField
+}
+
+func newFuzzRoute(info handlerInfo) *fuzzRoute {
+	route := &fuzzRoute{method: info.method, path: info.path}
+	route.body = info.method != http.MethodGet && info.method != http.MethodHead &&
+		(info.contentType == "" || info.contentType == "application/json")
+	seen := make(map[string]bool)
+	for _, t := range info.reqTypes {
+		for t != nil && t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if t == nil || t.Kind() != reflect.Struct {
+			continue
+		}
+		for _, f := range fuzzFields(t, route.body) {
+			if key := f.source + ":" + f.name; !seen[key] {
+				seen[key] = true
+				route.fields = append(route.fields, f)
+			}
+		}
+	}
+	return route
+}
+
+// fuzzFields returns the fields of struct type t bound from the request
+func fuzzFields(t reflect.Type, body bool) []fuzzField {
+	var fields []fuzzField
+	for _, sf := range reflect.VisibleFields(t) {
+		if !sf.IsExported() || sf.Anonymous || isUploadField(sf.Type) {
+			continue
+		}
+		f := fuzzField{typ: sf.Type}
+		f.rules, f.elemRules = parseFuzzRules(sf.Tag.Get("validate"))
+		_, f.required = ruleParam(f.rules, "required")
+
+		jsonName, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+		switch {
+		case tagName(sf, "uri") != "":
+			f.source, f.name = "path", tagName(sf, "uri")
+		case tagName(sf, "header") != "":
+			f.source, f.name = "header", tagName(sf, "header")
+		case tagName(sf, "form") != "":
+			f.source, f.name = "query", tagName(sf, "form")
+			_, opts, _ := strings.Cut(sf.Tag.Get("form"), ",")
+			f.defaulted = strings.Contains(opts, "default=")
+		case body && jsonName != "-":
+			f.source, f.name = "body", jsonName
+			if f.name == "" {
+				f.name = sf.Name
+			}
+		default:
+			continue
+		}
+		fields = append(fields, f)
+	}
+	return fields
+}
+
+func tagName(sf reflect.StructField, key string) string {
+	name, _, _ := strings.Cut(sf.Tag.Get(key), ",")
+	if name == "-" {
+		return ""
+	}
+	return name
+}
+
+// parseFuzzRules splits a validate tag into the rules of the field and those of its elements
+func parseFuzzRules(tag string) (rules, elemRules []fuzzRule) {
+	target := &rules
+	for _, rule := range strings.Split(tag, ",") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+		if rule == "dive" {
+			if target == &elemRules {
+				break
+			}
+			target = &elemRules
+			continue
+		}
+		for _, alt := range strings.Split(rule, "|") {
+			name, param, _ := strings.Cut(alt, "=")
+			*target = append(*target, fuzzRule{tag: name, param: param, either: strings.Contains(rule, "|")})
+		}
+	}
+	return rules, elemRules
+}
+
+// ruleParam returns the parameter of the rule called tag, unless it is one of alternatives
+func ruleParam(rules []fuzzRule, tag string) (string, bool) {
+	for _, r := range rules {
+		if r.tag == tag && !r.either {
+			return r.param, true
+		}
+	}
+	return "", false
+}
+
+func ruleInt(rules []fuzzRule, tag string) (int64, bool) {
+	param, ok := ruleParam(rules, tag)
+	if !ok {
+		return 0, false
+	}
+	n, err := strconv.ParseInt(param, 10, 64)
+	return n, err == nil
+}
+
+// valid returns a request following the validate rules of the route's fields, as far as they
+// are understood
+func (route *fuzzRoute) valid(g *fuzzGen) *fuzzRequest {
+	r := &fuzzRequest{
+		method: route.method,
+		path:   route.path,
+		params: make(map[string]string),
+		query:  make(url.Values),
+		header: make(http.Header),
+	}
+	if route.body {
+		r.body = make(map[string]any)
+	}
+	for _, f := range route.fields {
+		if f.omittable() && g.rng.IntN(4) == 0 {
+			continue // leave optional fields out now and then
+		}
+		r.set(f, g.value(f.typ, f.rules, f.elemRules, 0))
+	}
+	return r
+}
+
+// omittable reports whether the request is still valid without f
+func (f fuzzField) omittable() bool {
+	if f.source == "path" || (len(f.rules) > 0 && f.rules[0].tag != "omitempty") {
+		return false
+	}
+	// The fields of a struct are validated even when it's left out
+	return f.typ.Kind() != reflect.Struct || f.typ == timeType || isOptionalType(f.typ)
+}
+
+// set puts v in the request as field f
+func (r *fuzzRequest) set(f fuzzField, v any) {
+	switch f.source {
+	case "body":
+		r.body[f.name] = v
+	case "path":
+		r.params[f.name] = paramString(v)
+	case "query":
+		r.query.Del(f.name)
+		for _, s := range paramStrings(v) {
+			r.query.Add(f.name, s)
+		}
+	case "header":
+		r.header.Del(f.name)
+		for _, s := range paramStrings(v) {
+			r.header.Add(f.name, s)
+		}
+	}
+}
+
+// unset removes field f from the request
+func (r *fuzzRequest) unset(f fuzzField) {
+	switch f.source {
+	case "body":
+		delete(r.body, f.name)
+	case "query":
+		r.query.Del(f.name)
+	case "header":
+		r.header.Del(f.name)
+	}
+}
+
+func paramStrings(v any) []string {
+	if items, ok := v.([]any); ok {
+		out := make([]string, len(items))
+		for i, item := range items {
+			out[i] = paramString(item)
+		}
+		return out
+	}
+	return []string{paramString(v)}
+}
+
+func paramString(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return fmt.Sprint(v)
+}
+
+// fuzzMutation makes a valid request invalid
+type fuzzMutation struct {
+	desc  string
+	apply func(r *fuzzRequest, g *fuzzGen)
+}
+
+// mutations returns the ways requests to the route can be made invalid
+func (route *fuzzRoute) mutations() []fuzzMutation {
+	var mutations []fuzzMutation
+	if route.body {
+		mutations = append(mutations, fuzzMutation{
+			desc:  "with malformed JSON",
+			apply: func(r *fuzzRequest, g *fuzzGen) { r.raw = `{"` },
+		})
+	}
+	for _, f := range route.fields {
+		if f.required && !f.defaulted && f.source != "path" && !isOptionalType(f.typ) {
+			mutations = append(mutations, fuzzMutation{
+				desc:  fmt.Sprintf("without the required %s field %s", f.source, f.name),
+				apply: func(r *fuzzRequest, g *fuzzGen) { r.unset(f) },
+			})
+		}
+		if wrong, ok := wrongTypeValue(f); ok {
+			mutations = append(mutations, fuzzMutation{
+				desc:  fmt.Sprintf("with a %s field %s of the wrong type", f.source, f.name),
+				apply: func(r *fuzzRequest, g *fuzzGen) { r.set(f, wrong) },
+			})
+		}
+		if bad, rule, ok := ruleBreakingValue(f); ok {
+			mutations = append(mutations, fuzzMutation{
+				desc:  fmt.Sprintf("with a %s field %s breaking its %s rule", f.source, f.name, rule),
+				apply: func(r *fuzzRequest, g *fuzzGen) { r.set(f, bad) },
+			})
+		}
+	}
+	return mutations
+}
+
+func isOptionalType(t reflect.Type) bool {
+	_, ok := optionalElem(t)
+	return ok
+}
+
+var (
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// decodesItself reports whether t decodes its own values, so any input may be valid
+func decodesItself(t reflect.Type) bool {
+	p := reflect.PointerTo(t)
+	return p.Implements(jsonUnmarshalerType) || p.Implements(textUnmarshalerType) || p.Implements(bindUnmarshalerType)
+}
+
+// wrongTypeValue returns a value field f can't be decoded from
+func wrongTypeValue(f fuzzField) (any, bool) {
+	t := f.typ
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if decodesItself(t) || t == durationType {
+		return nil, false
+	}
+	if f.source != "body" {
+		if t.Kind() == reflect.Slice {
+			t = t.Elem()
+		}
+		if decodesItself(t) {
+			return nil, false
+		}
+		switch {
+		case isIntKind(t.Kind()), isFloatKind(t.Kind()), t.Kind() == reflect.Bool:
+			return "fuzz", true
+		}
+		return nil, false
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return 12345, true
+	case reflect.Bool, reflect.Slice, reflect.Array, reflect.Map, reflect.Struct:
+		return "fuzz", true
+	}
+	if isIntKind(t.Kind()) || isFloatKind(t.Kind()) {
+		return "fuzz", true
+	}
+	return nil, false
+}
+
+// ruleBreakingValue returns a value of field f breaking one of its rules, and the rule
+func ruleBreakingValue(f fuzzField) (any, string, bool) {
+	t := f.typ
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if values, ok := enumValues(t); ok && len(values) > 0 {
+		if t.Kind() == reflect.String {
+			return "fuzz-invalid", "enum", true
+		}
+		if isIntKind(t.Kind()) {
+			return outsideInts(values), "enum", true
+		}
+	}
+	if decodesItself(t) {
+		return nil, "", false
+	}
+
+	switch {
+	case t.Kind() == reflect.String:
+		if _, ok := ruleParam(f.rules, "oneof"); ok {
+			return "fuzz-invalid", "oneof", true
+		}
+		if n, ok := ruleInt(f.rules, "max"); ok {
+			return strings.Repeat("x", int(n)+1), "max", true
+		}
+		if n, ok := ruleInt(f.rules, "len"); ok {
+			return strings.Repeat("x", int(n)+1), "len", true
+		}
+		if n, ok := ruleInt(f.rules, "min"); ok && n > 1 {
+			return strings.Repeat("x", int(n)-1), "min", true
+		}
+		for _, format := range []string{"email", "uuid", "uuid4", "url", "uri", "numeric", "alpha"} {
+			if _, ok := ruleParam(f.rules, format); ok {
+				return "fuzz invalid 1 !", format, true
+			}
+		}
+	case isIntKind(t.Kind()):
+		unsigned := t.Kind() >= reflect.Uint && t.Kind() <= reflect.Uint64
+		if param, ok := ruleParam(f.rules, "oneof"); ok {
+			var values []any
+			for _, opt := range strings.Fields(param) {
+				if n, err := strconv.ParseInt(opt, 10, 64); err == nil {
+					values = append(values, n)
+				}
+			}
+			return outsideInts(values), "oneof", true
+		}
+		for _, r := range []struct {
+			tag   string
+			delta int64
+		}{{"max", 1}, {"lte", 1}, {"lt", 0}, {"min", -1}, {"gte", -1}, {"gt", 0}} {
+			n, ok := ruleInt(f.rules, r.tag)
+			if !ok {
+				continue
+			}
+			bad := n + r.delta
+			if bad == 0 {
+				bad = n + 2*r.delta - 1 // zero is skipped by omitempty
+			}
+			if bad < 0 && unsigned {
+				continue
+			}
+			return bad, r.tag, true
+		}
+	}
+	return nil, "", false
+}
+
+// outsideInts returns a non-zero integer that is not one of values
+func outsideInts(values []any) int64 {
+	bad := int64(1)
+	for _, v := range values {
+		if n, ok := v.(int64); ok && n >= bad {
+			bad = n + 1
+		}
+	}
+	return bad
+}
+
+func isIntKind(k reflect.Kind) bool {
+	return (k >= reflect.Int && k <= reflect.Int64) || (k >= reflect.Uint && k <= reflect.Uint64)
+}
+
+func isFloatKind(k reflect.Kind) bool {
+	return k == reflect.Float32 || k == reflect.Float64
+}
+
+// fuzzGen generates values of request fields
+type fuzzGen struct {
+	rng *rand.Rand
+}
+
+const fuzzLetters = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// value returns a JSON value of type t following rules, and elemRules for its elements
+func (g *fuzzGen) value(t reflect.Type, rules, elemRules []fuzzRule, depth int) any {
+	if elem, ok := optionalElem(t); ok {
+		t = elem
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if values, ok := enumValues(t); ok && len(values) > 0 {
+		return values[g.rng.IntN(len(values))]
+	}
+	if param, ok := ruleParam(rules, "oneof"); ok {
+		if opts := oneOfParam.FindAllString(param, -1); len(opts) > 0 {
+			return parseEnumOption(strings.Trim(opts[g.rng.IntN(len(opts))], "'"), t.Kind())
+		}
+	}
+	switch t {
+	case timeType:
+		return time.Now().UTC().Add(time.Duration(g.rng.IntN(1000)) * time.Hour).Format(time.RFC3339)
+	case durationType:
+		return (time.Duration(1+g.rng.IntN(60)) * time.Second).String()
+	}
+
+	_, required := ruleParam(rules, "required")
+	switch {
+	case t.Kind() == reflect.String:
+		return g.string(rules)
+	case isIntKind(t.Kind()):
+		return g.int(rules, required, t.Kind() >= reflect.Uint)
+	case isFloatKind(t.Kind()):
+		return float64(g.int(rules, required, false)) + 0.5
+	case t.Kind() == reflect.Bool:
+		return required || g.rng.IntN(2) == 0
+	case t.Kind() == reflect.Slice || t.Kind() == reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return base64.StdEncoding.EncodeToString([]byte(g.letters(8)))
+		}
+		lo, hi := g.bounds(rules, required, 0, 3)
+		n := lo + g.rng.IntN(hi-lo+1)
+		items := make([]any, n)
+		for i := range items {
+			items[i] = g.value(t.Elem(), elemRules, nil, depth+1)
+		}
+		return items
+	case t.Kind() == reflect.Map:
+		if t.Key().Kind() != reflect.String || depth > 3 {
+			return map[string]any{}
+		}
+		return map[string]any{g.letters(4): g.value(t.Elem(), elemRules, nil, depth+1)}
+	case t.Kind() == reflect.Struct:
+		obj := make(map[string]any)
+		if depth > 3 {
+			return obj
+		}
+		for _, f := range fuzzFields(t, true) {
+			if f.source == "body" {
+				obj[f.name] = g.value(f.typ, f.rules, f.elemRules, depth+1)
+			}
+		}
+		return obj
+	case t.Kind() == reflect.Interface:
+		return g.letters(6)
+	}
+	return nil
+}
+
+// bounds returns the length range allowed by the min, max and len rules
+func (g *fuzzGen) bounds(rules []fuzzRule, required bool, lo, hi int) (int, int) {
+	if required && lo == 0 {
+		lo = 1
+	}
+	if n, ok := ruleInt(rules, "len"); ok {
+		return int(n), int(n)
+	}
+	if n, ok := ruleInt(rules, "min"); ok {
+		lo = int(n)
+		hi = max(hi, lo)
+	}
+	if n, ok := ruleInt(rules, "max"); ok {
+		hi = int(n)
+		lo = min(lo, hi)
+	}
+	return lo, hi
+}
+
+func (g *fuzzGen) letters(n int) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = fuzzLetters[g.rng.IntN(len(fuzzLetters))]
+	}
+	return string(b)
+}
+
+func (g *fuzzGen) string(rules []fuzzRule) string {
+	for _, r := range rules {
+		if r.either {
+			continue
+		}
+		switch r.tag {
+		case "email":
+			return strings.ToLower(g.letters(6)) + "@example.com"
+		case "uuid", "uuid4", "uuid_rfc4122", "uuid4_rfc4122":
+			b := make([]byte, 16)
+			for i := range b {
+				b[i] = byte(g.rng.IntN(256))
+			}
+			b[6], b[8] = b[6]&0x0f|0x40, b[8]&0x3f|0x80
+			return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+		case "url", "uri", "http_url":
+			return "https://example.com/" + strings.ToLower(g.letters(6))
+		case "hostname", "fqdn":
+			return strings.ToLower(g.letters(6)) + ".example.com"
+		case "ip", "ipv4":
+			return fmt.Sprintf("192.0.2.%d", 1+g.rng.IntN(254))
+		case "datetime":
+			return time.Now().Format(r.param)
+		case "numeric", "number":
+			return strconv.Itoa(g.rng.IntN(100000))
+		}
+	}
+	_, required := ruleParam(rules, "required")
+	lo, hi := g.bounds(rules, required, 1, 12)
+	return g.letters(lo + g.rng.IntN(hi-lo+1))
+}
+
+func (g *fuzzGen) int(rules []fuzzRule, required, unsigned bool) int64 {
+	lo, hi, bounded := int64(0), int64(100), false
+	if unsigned || required {
+		lo = 1
+	}
+	if n, ok := ruleInt(rules, "min"); ok {
+		lo = n
+	}
+	if n, ok := ruleInt(rules, "gte"); ok {
+		lo = n
+	}
+	if n, ok := ruleInt(rules, "gt"); ok {
+		lo = n + 1
+	}
+	if n, ok := ruleInt(rules, "max"); ok {
+		hi, bounded = n, true
+	}
+	if n, ok := ruleInt(rules, "lte"); ok {
+		hi, bounded = n, true
+	}
+	if n, ok := ruleInt(rules, "lt"); ok {
+		hi, bounded = n-1, true
+	}
+	if !bounded || hi < lo {
+		hi = lo + 100
+	}
+	v := lo + g.rng.Int64N(hi-lo+1)
+	if v == 0 && required {
+		v = hi
+	}
+	return v
+}
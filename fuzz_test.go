@@ -0,0 +1,96 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type fuzzAddress struct {
+	City string `json:"city" validate:"required,max=20"`
+}
+
+type fuzzReq struct {
+	ID       int              `uri:"id" validate:"min=1"`
+	Page     int              `form:"page" validate:"omitempty,gte=1,lte=50"`
+	Tenant   string           `header:"X-Tenant" validate:"required,uuid"`
+	Name     string           `json:"name" validate:"required,min=2,max=10"`
+	Email    string           `json:"email" validate:"omitempty,email"`
+	Status   enumStatus       `json:"status"`
+	Sort     string           `json:"sort" validate:"omitempty,oneof=name date"`
+	Tags     []string         `json:"tags" validate:"max=3,dive,max=5"`
+	Due      time.Time        `json:"due"`
+	Note     Optional[string] `json:"note"`
+	Address  fuzzAddress      `json:"address"`
+	Priority int              `json:"priority" validate:"oneof=1 2 3"`
+}
+
+func TestFuzz(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := New().WithSwagger("t", "v")
+	var seen []fuzzReq
+	app.PUT("/items/:id", Handle(func(ctx *Context, req fuzzReq) (fuzzReq, error) {
+		seen = append(seen, req)
+		return req, nil
+	}))
+	app.GET("/items", Handle(func(ctx *Context, req struct {
+		Q string `form:"q" validate:"required"`
+	}) ([]string, error) {
+		return nil, nil
+	}))
+
+	Fuzz(t, app, WithFuzzRuns(20))
+	if len(seen) < 20 {
+		t.Fatalf("expected the valid requests to reach the handler, got %d", len(seen))
+	}
+	for _, req := range seen {
+		if req.ID < 1 || len(req.Name) < 2 || len(req.Name) > 10 || req.Address.City == "" || req.Priority < 1 || req.Priority > 3 {
+			t.Fatalf("expected requests following the rules, got %+v", req)
+		}
+	}
+}
+
+func TestFuzz_Failures(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := New().WithSwagger("t", "v")
+	app.POST("/panics", Handle(func(ctx *Context, req struct {
+		Count int `json:"count" validate:"max=100"`
+	}) (gin.H, error) {
+		if req.Count > 50 {
+			panic("count too high")
+		}
+		return gin.H{}, nil
+	}))
+	// Middleware answering malformed bodies with 200 hides invalid requests
+	app.POST("/lenient", func(ctx *gin.Context) {
+		data, _ := io.ReadAll(ctx.Request.Body)
+		if !json.Valid(data) {
+			ctx.AbortWithStatus(http.StatusOK)
+		}
+		ctx.Request.Body = io.NopCloser(bytes.NewReader(data))
+	}, Handle(func(ctx *Context, req struct {
+		Name string `json:"name"`
+	}) (gin.H, error) {
+		return gin.H{}, nil
+	}))
+
+	rt := &recordingT{}
+	Fuzz(rt, app, WithFuzzSeed(7))
+	if len(rt.errors) != 2 {
+		t.Fatalf("expected a failure per route, got %v", rt.errors)
+	}
+	if !strings.Contains(rt.errors[0], "POST /lenient answered 200 to a request with malformed JSON") || !strings.Contains(rt.errors[0], "seed 7") {
+		t.Errorf("unexpected failure %q", rt.errors[0])
+	}
+	if !strings.Contains(rt.errors[1], "POST /panics panicked: count too high") {
+		t.Errorf("unexpected failure %q", rt.errors[1])
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Generated is the reflection-free code cmd/fluxogen emits for a request or response type.
+// Nil members are left to the reflection-based implementation.
+type Generated struct {
+	// Query, URI and Header set the fields of obj, a pointer to the type, from their source
+	Query  func(obj any, src map[string][]string) error
+	URI    func(obj any, src map[string][]string) error
+	Header func(obj any, src map[string][]string) error
+	// Validate checks obj like the validate tags would, returning the failed rules in field order
+	Validate func(obj any) []FieldError
+	// Schema is the documented schema of the type
+	Schema *Schema
+}
+
+// FieldError is a validation rule a field failed
+type FieldError struct {
+	Field string
+	Tag   string
+	Param string
+}
+
+var generatedTypes sync.Map // reflect.Type -> Generated
+
+// RegisterGenerated registers the generated code of t. Files emitted by cmd/fluxogen call it
+// from init, so building with their build tag is all it takes to use them.
+func RegisterGenerated(t reflect.Type, g Generated) {
+	generatedTypes.Store(t, g)
+	// Plans compiled before registration would keep using reflection
+	bindingPlans.Delete(t)
+}
+
+// lookupGenerated returns the generated code of t, or of the type t points to
+func lookupGenerated(t reflect.Type) (Generated, bool) {
+	if t == nil {
+		return Generated{}, false
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	g, ok := generatedTypes.Load(t)
+	if !ok {
+		return Generated{}, false
+	}
+	return g.(Generated), true
+}
+
+// generatedPass wraps a generated binder; a nil binder means the type has no field for the source
+func generatedPass(bind func(obj any, src map[string][]string) error) bindPass {
+	return bindPass{bind: bind, tagged: bind != nil, defaults: true}
+}
+
+// generatedValidation formats failed rules like check formats validator errors
+func (v *Validator) generatedValidation(lang string, errs []FieldError) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	messages := make([]string, len(errs))
+	for i, e := range errs {
+		messages[i] = v.message(lang, e.Field, e.Tag, e.Param)
+	}
+	return fmt.Errorf("validation failed: %s", strings.Join(messages, "; "))
+}
+
+// generatedSchema returns a copy of the generated schema of t that callers may modify
+func generatedSchema(t reflect.Type) (Schema, bool) {
+	g, ok := lookupGenerated(t)
+	if !ok || g.Schema == nil {
+		return Schema{}, false
+	}
+	schema := *g.Schema
+	schema.Properties = maps.Clone(schema.Properties)
+	schema.Required = slices.Clone(schema.Required)
+	return schema, true
+}
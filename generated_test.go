@@ -0,0 +1,104 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type genReq struct {
+	Name string `form:"name" json:"name" validate:"required"`
+}
+
+type genRes struct {
+	Greeting string `json:"greeting"`
+}
+
+func TestRegisterGenerated_ReplacesReflection(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var bound, validated int
+	RegisterGenerated(reflect.TypeOf(genReq{}), Generated{
+		Query: func(obj any, src map[string][]string) error {
+			bound++
+			if vs := src["name"]; len(vs) > 0 {
+				if vs[0] == "bad" {
+					return errors.New("bad name")
+				}
+				obj.(*genReq).Name = vs[0]
+			}
+			return nil
+		},
+		Validate: func(obj any) []FieldError {
+			validated++
+			if obj.(*genReq).Name == "" {
+				return []FieldError{{Field: "Name", Tag: "required"}}
+			}
+			return nil
+		},
+	})
+	RegisterGenerated(reflect.TypeOf(genRes{}), Generated{
+		Schema: &Schema{Type: "object", Properties: map[string]Schema{"greeting": {Type: "string", Description: "generated"}}, Required: []string{}},
+	})
+	t.Cleanup(func() {
+		for _, typ := range []reflect.Type{reflect.TypeOf(genReq{}), reflect.TypeOf(genRes{})} {
+			generatedTypes.Delete(typ)
+			bindingPlans.Delete(typ)
+		}
+	})
+
+	app := New().WithSwagger("Test", "1.0")
+	app.GET("/hello", Handle(func(ctx *Context, req genReq) (genRes, error) {
+		return genRes{Greeting: "hello " + req.Name}, nil
+	}))
+
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/hello?name=ada", nil))
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "hello ada") {
+		t.Fatalf("unexpected response %d %s", w.Code, w.Body.String())
+	}
+	if bound != 1 || validated != 1 {
+		t.Fatalf("expected the generated binder and validator to run once, got %d and %d", bound, validated)
+	}
+
+	w = httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/hello", nil))
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "Name is required") {
+		t.Fatalf("expected the generated validation error, got %d %s", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/hello?name=bad", nil))
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "bad name") {
+		t.Fatalf("expected the generated binding error, got %d %s", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	var spec OpenAPISpec
+	if err := json.Unmarshal(w.Body.Bytes(), &spec); err != nil {
+		t.Fatal(err)
+	}
+	if spec.Components.Schemas["genRes"].Properties["greeting"].Description != "generated" {
+		t.Fatalf("expected the generated schema, got %+v", spec.Components.Schemas["genRes"])
+	}
+}
+
+func TestGeneratedValidation_Translated(t *testing.T) {
+	v := NewValidator()
+	v.RegisterTranslation("id", "required", "%s wajib diisi")
+	err := v.generatedValidation("id", []FieldError{{Field: "Name", Tag: "required"}, {Field: "Age", Tag: "min", Param: "18"}})
+	if err == nil || err.Error() != "validation failed: Name wajib diisi; Age must be at least 18 characters" {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if v.generatedValidation("en", nil) != nil {
+		t.Fatal("expected no error without failed rules")
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package gormx
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/leviantech/fluxo"
+	"gorm.io/gorm"
+)
+
+// MapError turns GORM errors into fluxo HTTP errors: ErrRecordNotFound becomes 404, unique and
+// foreign key violations 409. Other errors, including nil, are returned unchanged.
+// Violations are recognized with or without gorm.Config.TranslateError.
+func MapError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return fluxo.NotFound("record not found")
+	case isViolation(err, gorm.ErrDuplicatedKey, "23505", uniqueMessages):
+		return fluxo.Conflict("record already exists")
+	case isViolation(err, gorm.ErrForeignKeyViolated, "23503", foreignKeyMessages):
+		return fluxo.Conflict("record references a missing record or is still referenced")
+	}
+	return err
+}
+
+// MapErrors returns middleware applying MapError to the errors of the fluxo handlers after it
+//
+//	api := app.TypedGroup("/api", gormx.MapErrors())
+func MapErrors() gin.HandlerFunc {
+	return fluxo.Intercept(func(ctx *fluxo.Context, res any, err error) (any, error) {
+		return res, MapError(err)
+	})
+}
+
+// Driver messages for untranslated errors: SQLite, PostgreSQL, MySQL
+var (
+	uniqueMessages     = []string{"UNIQUE constraint failed", "duplicate key value violates unique constraint", "Duplicate entry"}
+	foreignKeyMessages = []string{"FOREIGN KEY constraint failed", "violates foreign key constraint", "a foreign key constraint fails"}
+)
+
+func isViolation(err, translated error, sqlState string, messages []string) bool {
+	if errors.Is(err, translated) {
+		return true
+	}
+	var stateErr interface{ SQLState() string }
+	if errors.As(err, &stateErr) && stateErr.SQLState() == sqlState {
+		return true
+	}
+	msg := err.Error()
+	for _, m := range messages {
+		if strings.Contains(msg, m) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,90 @@
+package gormx
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/leviantech/fluxo"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type sku struct {
+	ID   uint   `gorm:"primaryKey"`
+	Code string `gorm:"uniqueIndex"`
+}
+
+type pgError struct{ code string }
+
+func (e pgError) Error() string    { return "ERROR: constraint violated" }
+func (e pgError) SQLState() string { return e.code }
+
+func statusOf(err error) int {
+	var httpErr fluxo.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.Status
+	}
+	return 0
+}
+
+func TestMapError(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.AutoMigrate(&sku{}); err != nil {
+		t.Fatal(err)
+	}
+	db.Create(&sku{Code: "A1"})
+
+	duplicate := db.Create(&sku{Code: "A1"}).Error
+	missing := db.First(&sku{}, 99).Error
+	cases := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"not found", missing, http.StatusNotFound},
+		{"sqlite unique", duplicate, http.StatusConflict},
+		{"translated unique", gorm.ErrDuplicatedKey, http.StatusConflict},
+		{"postgres unique", pgError{"23505"}, http.StatusConflict},
+		{"postgres foreign key", pgError{"23503"}, http.StatusConflict},
+		{"mysql unique", errors.New("Error 1062 (23000): Duplicate entry 'A1' for key 'code'"), http.StatusConflict},
+		{"other", errors.New("connection reset"), 0},
+	}
+	for _, c := range cases {
+		if got := statusOf(MapError(c.err)); got != c.want {
+			t.Errorf("%s: got %d, want %d (%v)", c.name, got, c.want, c.err)
+		}
+	}
+	if MapError(nil) != nil {
+		t.Fatal("nil should stay nil")
+	}
+}
+
+type skuReq struct {
+	Code string `json:"code"`
+}
+
+func TestMapErrors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db, _ := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	db.AutoMigrate(&sku{})
+
+	app := fluxo.New()
+	app.GET("/skus/:code", MapErrors(), fluxo.Handle(func(ctx *fluxo.Context, req struct {
+		Code string `uri:"code"`
+	}) (sku, error) {
+		var s sku
+		return s, db.Where("code = ?", req.Code).First(&s).Error
+	}))
+
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/skus/nope", nil))
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d: %s", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package gormx
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/leviantech/fluxo"
+	"gorm.io/gorm"
+)
+
+// HealthTimeout bounds the database ping of Health
+var HealthTimeout = 2 * time.Second
+
+// HealthRequest is the empty request of Health
+type HealthRequest struct{}
+
+// HealthStatus is the body served by Health
+type HealthStatus struct {
+	Status          string  `json:"status"`
+	Database        string  `json:"database"`
+	LatencyMs       float64 `json:"latency_ms"`
+	OpenConnections int     `json:"open_connections"`
+	InUse           int     `json:"in_use"`
+	Idle            int     `json:"idle"`
+}
+
+// Health returns a handler pinging the database, answering 503 when it is unreachable
+//
+//	app.GET("/healthz", gormx.Health(db))
+func Health(db *gorm.DB) gin.HandlerFunc {
+	return fluxo.Handle(func(ctx *fluxo.Context, req HealthRequest) (HealthStatus, error) {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return HealthStatus{}, fluxo.NewHTTPError(http.StatusServiceUnavailable, fmt.Sprintf("database unavailable: %v", err))
+		}
+		pingCtx, cancel := context.WithTimeout(ctx.Request.Context(), HealthTimeout)
+		defer cancel()
+		start := time.Now()
+		if err := sqlDB.PingContext(pingCtx); err != nil {
+			return HealthStatus{}, fluxo.NewHTTPError(http.StatusServiceUnavailable, fmt.Sprintf("database unavailable: %v", err))
+		}
+		stats := sqlDB.Stats()
+		return HealthStatus{
+			Status:          "ok",
+			Database:        db.Dialector.Name(),
+			LatencyMs:       float64(time.Since(start)) / float64(time.Millisecond),
+			OpenConnections: stats.OpenConnections,
+			InUse:           stats.InUse,
+			Idle:            stats.Idle,
+		}, nil
+	})
+}
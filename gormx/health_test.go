@@ -0,0 +1,36 @@
+package gormx
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/leviantech/fluxo"
+)
+
+func TestHealth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := openDB(t)
+	app := fluxo.New()
+	app.GET("/healthz", Health(db))
+
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	var status HealthStatus
+	if err := json.Unmarshal(w.Body.Bytes(), &status); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", w.Code, w.Body.String())
+	}
+	if status.Status != "ok" || status.Database != "sqlite" {
+		t.Fatalf("unexpected status %+v", status)
+	}
+
+	sqlDB, _ := db.DB()
+	sqlDB.Close()
+	w = httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 for a closed database, got %d: %s", w.Code, w.Body.String())
+	}
+}
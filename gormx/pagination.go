@@ -0,0 +1,40 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+
+// Package gormx provides helpers for using GORM from fluxo handlers.
+package gormx
+
+import (
+	"github.com/leviantech/fluxo"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Paginate returns a GORM scope applying the offset, limit and sort order of req.
+// Only columns listed in allowedSorts are used for ordering, to keep client input out of raw SQL.
+func Paginate(req fluxo.PageRequest, allowedSorts ...string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		db = db.Offset(req.Offset()).Limit(req.PageLimit())
+		if len(allowedSorts) == 0 {
+			return db
+		}
+		for _, f := range req.SortFields(allowedSorts...) {
+			db = db.Order(clause.OrderByColumn{Column: clause.Column{Name: f.Field}, Desc: f.Desc})
+		}
+		return db
+	}
+}
+
+// FindPage counts the rows matched by query and loads the page selected by req
+func FindPage[T any](query *gorm.DB, req fluxo.PageRequest, allowedSorts ...string) (fluxo.Page[T], error) {
+	var total int64
+	if err := query.Session(&gorm.Session{}).Model(new(T)).Count(&total).Error; err != nil {
+		return fluxo.Page[T]{}, err
+	}
+
+	var items []T
+	if err := query.Session(&gorm.Session{}).Scopes(Paginate(req, allowedSorts...)).Find(&items).Error; err != nil {
+		return fluxo.Page[T]{}, err
+	}
+	return fluxo.NewPage(items, total, req), nil
+}
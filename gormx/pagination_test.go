@@ -0,0 +1,68 @@
+package gormx
+
+import (
+	"testing"
+
+	"github.com/leviantech/fluxo"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type product struct {
+	ID   uint   `gorm:"primaryKey" json:"id"`
+	Name string `json:"name"`
+}
+
+func openDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	if err := db.AutoMigrate(&product{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		db.Create(&product{Name: name})
+	}
+	return db
+}
+
+func TestFindPage(t *testing.T) {
+	db := openDB(t)
+
+	page, err := FindPage[product](db, fluxo.PageRequest{Page: 2, Limit: 2, Sort: "-name"}, "name")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if page.Total != 5 || len(page.Items) != 2 {
+		t.Fatalf("total=%d items=%d", page.Total, len(page.Items))
+	}
+	if page.Items[0].Name != "c" || page.Items[1].Name != "b" {
+		t.Fatalf("unexpected order %+v", page.Items)
+	}
+	if page.NextCursor == "" {
+		t.Fatalf("expected next cursor")
+	}
+
+	last, err := FindPage[product](db, fluxo.PageRequest{Limit: 2, Cursor: page.NextCursor, Sort: "-name"}, "name")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(last.Items) != 1 || last.Items[0].Name != "a" || last.NextCursor != "" {
+		t.Fatalf("unexpected last page %+v", last)
+	}
+}
+
+func TestPaginate_IgnoresUnknownSort(t *testing.T) {
+	db := openDB(t)
+
+	var items []product
+	err := db.Scopes(Paginate(fluxo.PageRequest{Limit: 10, Sort: "name; DROP TABLE products"}, "name")).Find(&items).Error
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(items) != 5 {
+		t.Fatalf("expected 5 items, got %d", len(items))
+	}
+}
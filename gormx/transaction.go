@@ -0,0 +1,60 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package gormx
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/gin-gonic/gin"
+	"github.com/leviantech/fluxo"
+	"gorm.io/gorm"
+)
+
+// Transactional returns middleware running each request in a GORM transaction, committed on
+// 2xx and rolled back otherwise (see fluxo.Transaction). Handlers get it with Tx or DB:
+//
+//	api := app.TypedGroup("/api", gormx.Transactional(db))
+//	gormx.DB(ctx, db).Create(&todo)
+func Transactional(db *gorm.DB, opts ...fluxo.TxOption) gin.HandlerFunc {
+	var o *sql.TxOptions
+	if len(opts) > 0 {
+		o = &sql.TxOptions{}
+		for _, opt := range opts {
+			opt(o)
+		}
+	}
+	return fluxo.Transaction[*gorm.DB](transactor{db: db, opts: o})
+}
+
+// Tx returns the transaction opened by Transactional, or nil outside of one
+func Tx(ctx *fluxo.Context) *gorm.DB {
+	tx, _ := fluxo.TxFrom[*gorm.DB](ctx)
+	return tx
+}
+
+// DB returns the request's transaction when there is one and db otherwise, bound to the request context
+func DB(ctx *fluxo.Context, db *gorm.DB) *gorm.DB {
+	if tx := Tx(ctx); tx != nil {
+		return tx
+	}
+	return db.WithContext(ctx.Request.Context())
+}
+
+type transactor struct {
+	db   *gorm.DB
+	opts *sql.TxOptions
+}
+
+func (t transactor) Begin(ctx context.Context) (*gorm.DB, error) {
+	var tx *gorm.DB
+	if t.opts != nil {
+		tx = t.db.WithContext(ctx).Begin(t.opts)
+	} else {
+		tx = t.db.WithContext(ctx).Begin()
+	}
+	return tx, tx.Error
+}
+
+func (t transactor) Commit(tx *gorm.DB) error   { return tx.Commit().Error }
+func (t transactor) Rollback(tx *gorm.DB) error { return tx.Rollback().Error }
@@ -0,0 +1,54 @@
+package gormx
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/leviantech/fluxo"
+)
+
+type createProductReq struct {
+	Name string `json:"name" validate:"required"`
+}
+
+func TestTransactional(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := openDB(t)
+	app := fluxo.New()
+	app.POST("/products", Transactional(db), fluxo.Handle(func(ctx *fluxo.Context, req createProductReq) (product, error) {
+		if Tx(ctx) == nil {
+			t.Fatal("expected a transaction")
+		}
+		p := product{Name: req.Name}
+		if err := DB(ctx, db).Create(&p).Error; err != nil {
+			return product{}, err
+		}
+		if req.Name == "dup" {
+			return product{}, fluxo.Conflict("duplicate")
+		}
+		return p, nil
+	}))
+
+	post := func(name string) int {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, "/products", bytes.NewBufferString(`{"name":"`+name+`"}`))
+		r.Header.Set("Content-Type", "application/json")
+		app.ServeHTTP(w, r)
+		return w.Code
+	}
+	if code := post("f"); code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", code)
+	}
+	if code := post("dup"); code != http.StatusConflict {
+		t.Fatalf("expected 409, got %d", code)
+	}
+
+	var count int64
+	db.Model(&product{}).Where("name IN ?", []string{"f", "dup"}).Count(&count)
+	if count != 1 {
+		t.Fatalf("expected only the committed product, got %d", count)
+	}
+}
@@ -0,0 +1,185 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Group is a gin route group whose fluxo handlers are documented like the app's own routes
+type Group struct {
+	*gin.RouterGroup
+	app   *App
+	typed []gin.HandlerFunc // middleware documented on every route of the group
+	tag   string            // set with WithTag, inherited by sub-groups
+}
+
+func newGroup(app *App, rg *gin.RouterGroup, inherited, middleware []gin.HandlerFunc) *Group {
+	typed := append(append([]gin.HandlerFunc(nil), inherited...), middleware...)
+	return &Group{RouterGroup: rg, app: app, typed: typed}
+}
+
+// UseTyped adds middleware to the app like Use and documents typed middleware (fluxo.Middleware)
+// on every route registered afterwards, merging its parameters into each operation:
+//
+//	app.UseTyped(fluxo.Middleware(requireAPIKey))
+func (a *App) UseTyped(middleware ...gin.HandlerFunc) {
+	a.router.Use(middleware...)
+	a.typed = append(a.typed, middleware...)
+}
+
+// UseTyped adds middleware to the group and documents it on routes registered afterwards
+func (g *Group) UseTyped(middleware ...gin.HandlerFunc) gin.IRoutes {
+	g.typed = append(g.typed, middleware...)
+	return g.RouterGroup.Use(middleware...)
+}
+
+// Group creates a sub-group that inherits the group's typed middleware and tag
+func (g *Group) Group(path string, middleware ...gin.HandlerFunc) *Group {
+	sub := newGroup(g.app, g.RouterGroup.Group(path, middleware...), g.typed, middleware)
+	sub.tag = g.tag
+	return sub
+}
+
+// WithTag tags the operations of the group and its sub-groups with tag instead of the one
+// derived from the group's path, see App.TypedGroup
+func (g *Group) WithTag(tag string) *Group {
+	g.tag = tag
+	return g
+}
+
+// defaultTag returns the tag of operations registered on the group without one: the WithTag
+// tag, or else the last segment of the group's path that isn't a parameter or a version
+// ("/api/v1/todos/:id/comments" gives "comments", "/api/v1" gives "api")
+func (g *Group) defaultTag() string {
+	if g.tag != "" {
+		return g.tag
+	}
+	segments := strings.Split(g.BasePath(), "/")
+	for i := len(segments) - 1; i >= 0; i-- {
+		segment := segments[i]
+		if segment == "" || segment[0] == ':' || segment[0] == '*' || isVersionSegment(segment) {
+			continue
+		}
+		return segment
+	}
+	return ""
+}
+
+// isVersionSegment reports whether a path segment is an API version such as "v1"
+func isVersionSegment(segment string) bool {
+	if len(segment) < 2 || (segment[0] != 'v' && segment[0] != 'V') {
+		return false
+	}
+	return strings.Trim(segment[1:], "0123456789.") == ""
+}
+
+// Handle registers a route on the group, recording its fluxo handlers for the spec
+func (g *Group) Handle(method, relativePath string, handlers ...gin.HandlerFunc) gin.IRoutes {
+	fullPath := joinPaths(g.BasePath(), relativePath)
+	if method == http.MethodHead {
+		g.app.checkHead(fullPath)
+	}
+	g.app.registerRoute(method, fullPath, g.RouterGroup.Handlers, handlers)
+	g.app.captureMiddlewareRoute(method, fullPath, g.typed, handlers)
+	g.app.captureFormats(method, fullPath, slices.Concat(g.RouterGroup.Handlers, handlers))
+	g.app.captureExamples(method, fullPath, handlers)
+	if info, ok := g.app.handlers[method+":"+fullPath]; ok {
+		info.tag = g.defaultTag()
+		g.app.handlers[method+":"+fullPath] = info
+	}
+	routes := g.RouterGroup.Handle(method, relativePath, handlers...)
+	if method == http.MethodGet {
+		g.app.autoHead(fullPath, handlers, func(handlers ...gin.HandlerFunc) {
+			g.RouterGroup.Handle(http.MethodHead, relativePath, handlers...)
+		})
+	}
+	return routes
+}
+
+// GET registers a GET handler on the group
+func (g *Group) GET(path string, handlers ...gin.HandlerFunc) gin.IRoutes {
+	return g.Handle(http.MethodGet, path, handlers...)
+}
+
+// POST registers a POST handler on the group
+func (g *Group) POST(path string, handlers ...gin.HandlerFunc) gin.IRoutes {
+	return g.Handle(http.MethodPost, path, handlers...)
+}
+
+// PUT registers a PUT handler on the group
+func (g *Group) PUT(path string, handlers ...gin.HandlerFunc) gin.IRoutes {
+	return g.Handle(http.MethodPut, path, handlers...)
+}
+
+// DELETE registers a DELETE handler on the group
+func (g *Group) DELETE(path string, handlers ...gin.HandlerFunc) gin.IRoutes {
+	return g.Handle(http.MethodDelete, path, handlers...)
+}
+
+// PATCH registers a PATCH handler on the group
+func (g *Group) PATCH(path string, handlers ...gin.HandlerFunc) gin.IRoutes {
+	return g.Handle(http.MethodPatch, path, handlers...)
+}
+
+// HEAD registers a HEAD handler on the group
+func (g *Group) HEAD(path string, handlers ...gin.HandlerFunc) gin.IRoutes {
+	return g.Handle(http.MethodHead, path, handlers...)
+}
+
+// OPTIONS registers an OPTIONS handler on the group
+func (g *Group) OPTIONS(path string, handlers ...gin.HandlerFunc) gin.IRoutes {
+	return g.Handle(http.MethodOptions, path, handlers...)
+}
+
+// captureMiddlewareRoute records typed middleware and handlers of a route along with its Skip
+// options. The middleware is only documented on routes that are documented themselves, so
+// plain gin routes stay out of the spec, and not on routes skipping it.
+func (a *App) captureMiddlewareRoute(method, path string, middleware, handlers []gin.HandlerFunc) {
+	skips := routeSkips(handlers)
+	if len(skips) > 0 {
+		a.skips[method+" "+path] = skips
+	}
+
+	capture := func(hs []gin.HandlerFunc) {
+		for _, h := range hs {
+			inner, names := unwrapMiddleware(h)
+			if !skipsAny(skips, names) {
+				a.captureHandlerInfo(method, path, inner)
+			}
+		}
+	}
+	for _, h := range handlers {
+		if inner, _ := unwrapMiddleware(h); isDocumentedHandler(inner) {
+			capture(middleware)
+			break
+		}
+	}
+	capture(handlers)
+}
+
+// isDocumentedHandler reports whether h carries types or docs for the spec
+func isDocumentedHandler(h gin.HandlerFunc) bool {
+	if _, _, _, ok := lookupHandlerTypes(h); ok {
+		return true
+	}
+	_, ok := lookupOperationDoc(h)
+	return ok
+}
+
+// joinPaths joins a group's base path with a relative route path, keeping a trailing slash like gin
+func joinPaths(base, relative string) string {
+	if relative == "" {
+		return base
+	}
+	joined := path.Join(base, relative)
+	if strings.HasSuffix(relative, "/") && !strings.HasSuffix(joined, "/") {
+		return joined + "/"
+	}
+	return joined
+}
@@ -0,0 +1,215 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type groupAuthReq struct {
+	APIKey string `header:"X-API-Key" validate:"required"`
+}
+
+type groupTenantReq struct {
+	Tenant string `header:"X-Tenant" validate:"required"`
+}
+
+type groupItemReq struct {
+	ID string `uri:"id"`
+}
+
+type groupItemRes struct {
+	ID   string `json:"id"`
+	User string `json:"user"`
+}
+
+func groupAuth(ctx *Context, req groupAuthReq) error {
+	if req.APIKey != "secret" {
+		return Unauthorized("bad key")
+	}
+	ctx.Set("user", "alice")
+	return nil
+}
+
+func groupTenant(ctx *Context, req groupTenantReq) error { return nil }
+
+func groupGetItem(ctx *Context, req groupItemReq) (groupItemRes, error) {
+	return groupItemRes{ID: req.ID, User: ctx.GetString("user")}, nil
+}
+
+func groupSpec(t *testing.T, app *App) OpenAPISpec {
+	t.Helper()
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	var spec OpenAPISpec
+	if err := json.Unmarshal(w.Body.Bytes(), &spec); err != nil {
+		t.Fatal(err)
+	}
+	return spec
+}
+
+func groupHeaderParams(op *Operation) map[string]bool {
+	params := map[string]bool{}
+	if op == nil {
+		return params
+	}
+	for _, p := range op.Parameters {
+		if p.In == "header" {
+			params[p.Name] = true
+		}
+	}
+	return params
+}
+
+func TestUseTypedAppliesAndDocuments(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := New().WithSwagger("Groups", "1.0")
+	app.UseTyped(Middleware(groupAuth))
+	app.GET("/items/:id", Handle(groupGetItem))
+	app.GET("/plain", func(ctx *gin.Context) { ctx.String(http.StatusOK, "ok") })
+
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/items/1", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected the missing key to be rejected, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/items/1", nil)
+	req.Header.Set("X-API-Key", "secret")
+	app.ServeHTTP(w, req)
+	if w.Code != http.StatusOK || !json.Valid(w.Body.Bytes()) {
+		t.Fatalf("status %d: %s", w.Code, w.Body.String())
+	}
+
+	spec := groupSpec(t, app)
+	if !groupHeaderParams(spec.Paths["/items/{id}"].GET)["X-API-Key"] {
+		t.Fatalf("expected the middleware header on the operation, got %+v", spec.Paths["/items/{id}"].GET)
+	}
+	if _, ok := spec.Paths["/plain"]; ok {
+		t.Fatal("plain gin routes should stay out of the spec")
+	}
+	if _, ok := spec.Paths["/openapi.json"]; ok {
+		t.Fatal("the spec route should stay out of the spec")
+	}
+}
+
+func TestGroupTypedMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := New().WithSwagger("Groups", "1.0")
+	api := app.TypedGroup("/api", Middleware(groupAuth))
+	api.GET("/items/:id", Handle(groupGetItem))
+	tenants := api.Group("/tenants")
+	tenants.UseTyped(Middleware(groupTenant))
+	tenants.GET("/items/:id", Handle(groupGetItem))
+	app.GET("/public/:id", Handle(groupGetItem))
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/api/items/7", nil)
+	req.Header.Set("X-API-Key", "secret")
+	app.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", w.Code, w.Body.String())
+	}
+	var res groupItemRes
+	if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil || res.User != "alice" || res.ID != "7" {
+		t.Fatalf("unexpected response %+v (%v)", res, err)
+	}
+
+	spec := groupSpec(t, app)
+	api1 := groupHeaderParams(spec.Paths["/api/items/{id}"].GET)
+	nested := groupHeaderParams(spec.Paths["/api/tenants/items/{id}"].GET)
+	public := groupHeaderParams(spec.Paths["/public/{id}"].GET)
+	if !api1["X-API-Key"] || api1["X-Tenant"] {
+		t.Fatalf("unexpected group params %v", api1)
+	}
+	if !nested["X-API-Key"] || !nested["X-Tenant"] {
+		t.Fatalf("nested groups should inherit typed middleware, got %v", nested)
+	}
+	if len(public) != 0 {
+		t.Fatalf("routes outside the group should not get its params, got %v", public)
+	}
+}
+
+func TestJoinPaths(t *testing.T) {
+	cases := map[[2]string]string{
+		{"/api", ""}:        "/api",
+		{"/api", "/items"}:  "/api/items",
+		{"/api/", "items/"}: "/api/items/",
+		{"/", "/items/:id"}: "/items/:id",
+	}
+	for in, want := range cases {
+		if got := joinPaths(in[0], in[1]); got != want {
+			t.Errorf("joinPaths(%q, %q) = %q, want %q", in[0], in[1], got, want)
+		}
+	}
+}
+
+type tagsController struct{}
+
+func (tagsController) Prefix() string { return "/billing" }
+func (tagsController) Tag() string    { return "Invoices" }
+func (tagsController) Routes() []RouteDef {
+	return []RouteDef{Define(http.MethodGet, "", Handle(func(ctx *Context, req struct{}) (gin.H, error) { return gin.H{}, nil }))}
+}
+
+func TestGroup_Tags(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := New().WithSwagger("t", "v", WithSwaggerTag("todos", "Todo items"), WithSwaggerTag("Admin", ""))
+	ok := Handle(func(ctx *Context, req groupItemReq) (gin.H, error) { return gin.H{}, nil })
+
+	api := app.TypedGroup("/api/v1")
+	api.GET("/health", ok)
+	todos := api.Group("/todos")
+	todos.GET("/:id", ok)
+	todos.Group("/:id/comments").GET("", ok)
+	admin := api.Group("/internal").WithTag("Admin")
+	admin.Group("/jobs").GET("", ok)
+	api.Register(tagsController{})
+	app.GET("/root", ok)
+
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	var spec OpenAPISpec
+	if err := json.Unmarshal(w.Body.Bytes(), &spec); err != nil {
+		t.Fatal(err)
+	}
+
+	for path, want := range map[string]string{
+		"/api/v1/health":              "api",
+		"/api/v1/todos/{id}":          "todos",
+		"/api/v1/todos/{id}/comments": "comments",
+		"/api/v1/internal/jobs":       "Admin",
+		"/api/v1/billing":             "Invoices",
+	} {
+		op := spec.Paths[path].GET
+		if op == nil || len(op.Tags) != 1 || op.Tags[0] != want {
+			t.Errorf("%s: expected tag %q, got %+v", path, want, op)
+		}
+	}
+	if tags := spec.Paths["/root"].GET.Tags; len(tags) != 0 {
+		t.Errorf("expected routes outside groups to stay untagged, got %v", tags)
+	}
+
+	var names []string
+	for _, tag := range spec.Tags {
+		names = append(names, tag.Name)
+	}
+	want := []string{"todos", "Admin", "Invoices", "api", "comments"}
+	if len(names) != len(want) {
+		t.Fatalf("expected tags %v, got %v", want, names)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Fatalf("expected tags %v, got %v", want, names)
+		}
+	}
+	if spec.Tags[0].Description != "Todo items" {
+		t.Errorf("expected the declared description, got %+v", spec.Tags[0])
+	}
+}
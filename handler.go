@@ -3,13 +3,14 @@
 package fluxo
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"reflect"
+	"strings"
 	"sync"
 
 	"github.com/gin-gonic/gin"
-	"github.com/gin-gonic/gin/binding"
 )
 
 type typesPair struct {
@@ -28,6 +29,22 @@ func registerHandlerTypes(h gin.HandlerFunc, req, res reflect.Type, ct string) {
 	handlerTypeRegistry.Store(reflect.ValueOf(h).Pointer(), typesPair{req: req, res: res, ct: ct})
 }
 
+// operationDoc customizes the documented operation of a handler after it is generated
+type operationDoc func(sg *SwaggerGenerator, op *Operation)
+
+var handlerDocRegistry sync.Map
+
+func registerOperationDoc(h gin.HandlerFunc, doc operationDoc) {
+	handlerDocRegistry.Store(reflect.ValueOf(h).Pointer(), doc)
+}
+
+func lookupOperationDoc(h gin.HandlerFunc) (operationDoc, bool) {
+	if v, ok := handlerDocRegistry.Load(reflect.ValueOf(h).Pointer()); ok {
+		return v.(operationDoc), true
+	}
+	return nil, false
+}
+
 func lookupHandlerTypes(h gin.HandlerFunc) (reflect.Type, reflect.Type, string, bool) {
 	if v, ok := handlerTypeRegistry.Load(reflect.ValueOf(h).Pointer()); ok {
 		p := v.(typesPair)
@@ -42,55 +59,114 @@ func Handle[Req any, Res any](fn HandlerFunc[Req, Res]) gin.HandlerFunc {
 	var resZero Res
 	reqType := reflect.TypeOf(reqZero)
 	resType := reflect.TypeOf(resZero)
+	registerOptionalTypes(reqType)
+	plan := planFor(reqType)
+	mods := sanitizerFor(reqType)
 
 	handler := func(ctx *gin.Context) {
 		var req Req
 
 		// Use gin's native binding based on content type
-		if ctx.Request.Method != http.MethodGet && ctx.Request.Method != http.MethodHead && ctx.Request.ContentLength != 0 {
+		if ctx.Request.Method != http.MethodGet && ctx.Request.Method != http.MethodHead && ctx.Request.ContentLength != 0 && plan.body != nil {
 			contentType := ctx.ContentType()
+			if !plan.acceptsBody(contentType) {
+				ctx.JSON(http.StatusUnsupportedMediaType, gin.H{"error": plan.unsupportedBody(contentType)})
+				return
+			}
 
 			switch contentType {
 			case gin.MIMEPOSTForm:
-				if err := ctx.ShouldBind(&req); err != nil {
-					ctx.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Form binding failed: %v", err)})
+				if err := bindForm(ctx, &req); err != nil {
+					ctx.JSON(http.StatusBadRequest, gin.H{"error": bindingFailed("Form", err)})
 					return
 				}
 			case gin.MIMEMultipartPOSTForm:
-				if err := ctx.ShouldBind(&req); err != nil {
-					ctx.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Multipart binding failed: %v", err)})
+				// UploadedFile fields are streamed to storage instead of being buffered
+				if len(plan.uploads) > 0 {
+					if err := bindUploads(ctx, &req, plan.uploads); err != nil {
+						// A scanned file was rejected (see WithUploadScanner) or a field was too large
+						var httpErr HTTPError
+						if errors.As(err, &httpErr) {
+							writeError(ctx, httpErr)
+							return
+						}
+						status := http.StatusBadRequest
+						if errors.Is(err, ErrNoUploadStorage) {
+							status = http.StatusInternalServerError
+						}
+						ctx.JSON(status, gin.H{"error": bindingFailed("Multipart", err)})
+						return
+					}
+				} else if err := bindForm(ctx, &req); err != nil {
+					ctx.JSON(http.StatusBadRequest, gin.H{"error": bindingFailed("Multipart", err)})
+					return
+				}
+			case MIMEJSONPatch, MIMEMergePatch:
+				if err := bindPatch(ctx, &req, contentType); err != nil {
+					ctx.JSON(http.StatusBadRequest, gin.H{"error": bindingFailed("Patch", err)})
 					return
 				}
 			default:
-				// JSON binding as default (use ShouldBindBodyWith to allow multiple reads)
-				if err := ctx.ShouldBindBodyWith(&req, binding.JSON); err != nil {
-					ctx.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("JSON binding failed: %v", err)})
+				// JSON binding as default, keeping the body to allow multiple reads
+				if err := bindJSON(ctx, &req); err != nil {
+					ctx.JSON(http.StatusBadRequest, gin.H{"error": bindingFailed("JSON", err)})
 					return
 				}
+				// Plain JSON sent to a patch route is a merge patch
+				if plan.patch {
+					if err := bindPatch(ctx, &req, MIMEMergePatch); err != nil {
+						ctx.JSON(http.StatusBadRequest, gin.H{"error": bindingFailed("Patch", err)})
+						return
+					}
+				}
 			}
 		}
 
-		// Bind query parameters using gin's native binding
-		if err := ctx.ShouldBindQuery(&req); err != nil {
+		// Files streamed to storage above are deleted when binding fails from here on.
+		// Reject query parameters nothing reads when the route is strict about them
+		if unknown := unknownQuery(ctx, plan); len(unknown) > 0 {
+			discardRequestUploads(ctx)
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Unknown query parameters: " + strings.Join(unknown, ", ")})
+			return
+		}
+
+		// Bind query parameters with the type's precompiled plan
+		if err := plan.bindQuery(ctx, &req); err != nil {
+			discardRequestUploads(ctx)
 			ctx.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Query binding failed: %v", err)})
 			return
 		}
 
-		// Bind path parameters using gin's native binding
-		if err := ctx.ShouldBindUri(&req); err != nil {
+		// Bind path parameters with the type's precompiled plan
+		if err := plan.bindURI(ctx, &req); err != nil {
+			discardRequestUploads(ctx)
 			ctx.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Path binding failed: %v", err)})
 			return
 		}
 
-		// Bind header parameters using gin's native binding
-		if err := ctx.ShouldBindHeader(&req); err != nil {
+		// Bind header parameters with the type's precompiled plan
+		if err := plan.bindHeader(ctx, &req); err != nil {
+			discardRequestUploads(ctx)
 			ctx.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Header binding failed: %v", err)})
 			return
 		}
 
+		// Fill request metadata fields last, so clients can't set them
+		plan.bindMeta(ctx, &req)
+
+		// Normalize fields tagged with mod before validating them
+		if mods != nil {
+			mods(reflect.ValueOf(&req).Elem())
+		}
+
 		// Validate the request if it's a struct
-		if reqType != nil && (reqType.Kind() == reflect.Struct || (reqType.Kind() == reflect.Ptr && reqType.Elem().Kind() == reflect.Struct)) {
+		if plan.validates {
 			if err := validateStruct(ctx, &req); err != nil {
+				discardRequestUploads(ctx)
+				if cause, ok := ruleCause(err); ok {
+					writeError(ctx, cause)
+					return
+				}
 				ctx.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Validation failed: %v", err)})
 				return
 			}
@@ -98,17 +174,43 @@ func Handle[Req any, Res any](fn HandlerFunc[Req, Res]) gin.HandlerFunc {
 
 		// Call the handler function
 		res, err := fn(&Context{Context: ctx}, req)
-		if err != nil {
-			if httpErr, ok := err.(HTTPError); ok {
-				ctx.JSON(httpErr.Status, httpErr)
-			} else {
-				ctx.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Internal server error: %v", err)})
+		if clientGone(ctx) {
+			dropResponse(ctx)
+			return
+		}
+
+		// Let response interceptors inspect or replace the result before it is written
+		if interceptors := requestInterceptors(ctx); len(interceptors) > 0 {
+			var out any
+			out, err = intercept(ctx, interceptors, res, err)
+			if r, ok := out.(Res); ok {
+				res = r
+			} else if err == nil {
+				respondAny(ctx, out)
+				return
 			}
+		}
+		if err != nil {
+			writeError(ctx, err)
 			return
 		}
 
-		// Return success response
-		ctx.JSON(http.StatusOK, res)
+		// Return success response, letting the value pick its status and headers
+		status := http.StatusOK
+		if sc, ok := any(res).(StatusCoder); ok {
+			status = sc.StatusCode()
+		}
+		if hs, ok := any(&res).(HeaderSetter); ok {
+			hs.SetHeaders(&Context{Context: ctx})
+		}
+		if etag, ok := versionOf(res); ok {
+			SetETag(ctx, etag)
+		}
+		if r, ok := any(res).(renderer); ok {
+			r.render(ctx)
+			return
+		}
+		renderJSON(ctx, status, res)
 	}
 
 	// Determine content types based on struct tags
@@ -125,61 +227,95 @@ func Handle[Req any, Res any](fn HandlerFunc[Req, Res]) gin.HandlerFunc {
 func Middleware[Req any](fn MiddlewareFunc[Req]) gin.HandlerFunc {
 	var reqZero Req
 	reqType := reflect.TypeOf(reqZero)
+	registerOptionalTypes(reqType)
+	plan := planFor(reqType)
+	mods := sanitizerFor(reqType)
 
 	handler := func(ctx *gin.Context) {
 		var req Req
 
-		// Use gin's native binding based on content type
-		if ctx.Request.Method != http.MethodGet && ctx.Request.Method != http.MethodHead && ctx.Request.ContentLength != 0 {
+		// Use gin's native binding based on content type, leaving bodies the type doesn't take to
+		// the handler of the route
+		if ctx.Request.Method != http.MethodGet && ctx.Request.Method != http.MethodHead && ctx.Request.ContentLength != 0 && plan.acceptsBody(ctx.ContentType()) {
 			contentType := ctx.ContentType()
 
 			switch contentType {
 			case gin.MIMEPOSTForm:
-				if err := ctx.ShouldBind(&req); err != nil {
-					ctx.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Form binding failed: %v", err)})
+				if err := bindForm(ctx, &req); err != nil {
+					ctx.JSON(http.StatusBadRequest, gin.H{"error": bindingFailed("Form", err)})
 					ctx.Abort()
 					return
 				}
 			case gin.MIMEMultipartPOSTForm:
-				if err := ctx.ShouldBind(&req); err != nil {
-					ctx.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Multipart binding failed: %v", err)})
+				if err := bindForm(ctx, &req); err != nil {
+					ctx.JSON(http.StatusBadRequest, gin.H{"error": bindingFailed("Multipart", err)})
+					ctx.Abort()
+					return
+				}
+			case MIMEJSONPatch, MIMEMergePatch:
+				if err := bindPatch(ctx, &req, contentType); err != nil {
+					ctx.JSON(http.StatusBadRequest, gin.H{"error": bindingFailed("Patch", err)})
 					ctx.Abort()
 					return
 				}
 			default:
-				// JSON binding as default (use ShouldBindBodyWith to allow multiple reads)
-				if err := ctx.ShouldBindBodyWith(&req, binding.JSON); err != nil {
-					ctx.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("JSON binding failed: %v", err)})
+				// JSON binding as default, keeping the body to allow multiple reads
+				if err := bindJSON(ctx, &req); err != nil {
+					ctx.JSON(http.StatusBadRequest, gin.H{"error": bindingFailed("JSON", err)})
 					ctx.Abort()
 					return
 				}
+				// Plain JSON sent to a patch route is a merge patch
+				if plan.patch {
+					if err := bindPatch(ctx, &req, MIMEMergePatch); err != nil {
+						ctx.JSON(http.StatusBadRequest, gin.H{"error": bindingFailed("Patch", err)})
+						ctx.Abort()
+						return
+					}
+				}
 			}
 		}
 
-		// Bind query parameters using gin's native binding
-		if err := ctx.ShouldBindQuery(&req); err != nil {
+		// The handler accepts the query parameters read here under StrictQuery
+		noteQueryPlan(ctx, plan)
+
+		// Bind query parameters with the type's precompiled plan
+		if err := plan.bindQuery(ctx, &req); err != nil {
 			ctx.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Query binding failed: %v", err)})
 			ctx.Abort()
 			return
 		}
 
-		// Bind path parameters using gin's native binding
-		if err := ctx.ShouldBindUri(&req); err != nil {
+		// Bind path parameters with the type's precompiled plan
+		if err := plan.bindURI(ctx, &req); err != nil {
 			ctx.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Path binding failed: %v", err)})
 			ctx.Abort()
 			return
 		}
 
-		// Bind header parameters using gin's native binding
-		if err := ctx.ShouldBindHeader(&req); err != nil {
+		// Bind header parameters with the type's precompiled plan
+		if err := plan.bindHeader(ctx, &req); err != nil {
 			ctx.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Header binding failed: %v", err)})
 			ctx.Abort()
 			return
 		}
 
+		// Fill request metadata fields last, so clients can't set them
+		plan.bindMeta(ctx, &req)
+
+		// Normalize fields tagged with mod before validating them
+		if mods != nil {
+			mods(reflect.ValueOf(&req).Elem())
+		}
+
 		// Validate the request if it's a struct
-		if reqType != nil && (reqType.Kind() == reflect.Struct || (reqType.Kind() == reflect.Ptr && reqType.Elem().Kind() == reflect.Struct)) {
+		if plan.validates {
 			if err := validateStruct(ctx, &req); err != nil {
+				if cause, ok := ruleCause(err); ok {
+					writeError(ctx, cause)
+					ctx.Abort()
+					return
+				}
 				ctx.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Validation failed: %v", err)})
 				ctx.Abort()
 				return
@@ -188,12 +324,12 @@ func Middleware[Req any](fn MiddlewareFunc[Req]) gin.HandlerFunc {
 
 		// Call the middleware function
 		err := fn(&Context{Context: ctx}, req)
+		if err != nil && clientGone(ctx) {
+			dropResponse(ctx)
+			return
+		}
 		if err != nil {
-			if httpErr, ok := err.(HTTPError); ok {
-				ctx.JSON(httpErr.Status, httpErr)
-			} else {
-				ctx.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Internal server error: %v", err)})
-			}
+			writeError(ctx, err)
 			ctx.Abort()
 			return
 		}
@@ -234,8 +370,7 @@ func detectContentTypes(reqType reflect.Type) []string {
 		}
 
 		// Check for file upload fields
-		if field.Type.String() == "*multipart.FileHeader" ||
-			field.Type.String() == "[]*multipart.FileHeader" {
+		if isUploadField(field.Type) {
 			hasFile = true
 		}
 	}
@@ -0,0 +1,143 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Typed GET routes answer HEAD requests too, as load balancers and link checkers expect: the
+// handlers of the GET route run, with the same middleware, and the body is counted for the
+// Content-Length header instead of being sent. A HEAD route registered before the GET route
+// takes precedence; WithoutAutoHead turns this off, and WithHeadOperations documents them.
+
+// WithoutAutoHead keeps typed GET routes from answering HEAD requests
+func WithoutAutoHead() AppOption {
+	return func(c *appConfig) {
+		c.noAutoHead = true
+	}
+}
+
+// WithHeadOperations documents the HEAD operation of every typed GET route, like the GET
+// operation without response bodies
+func WithHeadOperations() SwaggerOption {
+	return func(sg *SwaggerGenerator) {
+		sg.headOperations = true
+	}
+}
+
+// autoHead registers HEAD for the GET route at path when its handlers are typed, with
+// register adding the route to the router or group
+func (a *App) autoHead(path string, handlers []gin.HandlerFunc, register func(handlers ...gin.HandlerFunc)) {
+	if a.noAutoHead || a.heads[path] || !slices.ContainsFunc(handlers, func(h gin.HandlerFunc) bool {
+		inner, _ := unwrapMiddleware(h)
+		return isDocumentedHandler(inner)
+	}) {
+		return
+	}
+	if slices.ContainsFunc(a.routes, func(r Route) bool { return r.Method == http.MethodHead && r.Path == path }) {
+		return
+	}
+	if skips, ok := a.skips["GET "+path]; ok {
+		a.skips["HEAD "+path] = skips
+	}
+	if info, ok := a.handlers["GET:"+path]; ok {
+		info.autoHead = true
+		a.handlers["GET:"+path] = info
+		a.invalidateSpec()
+	}
+	a.heads[path] = true
+	register(slices.Concat([]gin.HandlerFunc{headOnly}, handlers)...)
+}
+
+// checkHead panics when a HEAD route is registered at a path its GET route answers already
+func (a *App) checkHead(path string) {
+	if a.heads[path] {
+		panic(fmt.Sprintf("fluxo: HEAD %s is answered by its GET route; register it before the GET route, or use WithoutAutoHead", path))
+	}
+}
+
+// headOnly runs the handlers of a GET route for a HEAD request, sending the headers they set
+// with the length of the body they wrote
+func headOnly(ctx *gin.Context) {
+	w := &headWriter{ResponseWriter: ctx.Writer, size: -1}
+	ctx.Writer = w
+	defer func() {
+		ctx.Writer = w.ResponseWriter
+		w.finish()
+	}()
+	ctx.Next()
+}
+
+// headWriter counts the body of a response instead of writing it
+type headWriter struct {
+	gin.ResponseWriter
+	size int
+}
+
+func (w *headWriter) Write(data []byte) (int, error) {
+	w.size = max(w.size, 0) + len(data)
+	return len(data), nil
+}
+
+func (w *headWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *headWriter) Size() int {
+	return w.size
+}
+
+func (w *headWriter) Written() bool {
+	return w.size >= 0 || w.ResponseWriter.Written()
+}
+
+// Flush is left to finish, as the length is only known once the handlers return
+func (w *headWriter) Flush() {}
+
+// finish sends the headers, with the Content-Length of what the handlers wrote unless they set one
+func (w *headWriter) finish() {
+	if w.ResponseWriter.Written() {
+		return
+	}
+	status := w.Status()
+	bodyless := status < http.StatusOK || status == http.StatusNoContent || status == http.StatusNotModified
+	if w.Header().Get("Content-Length") == "" && w.Header().Get("Transfer-Encoding") == "" && !bodyless {
+		w.Header().Set("Content-Length", strconv.Itoa(max(w.size, 0)))
+	}
+	w.WriteHeaderNow()
+}
+
+// documentHead adds the HEAD operation of the GET operation at path, unless one is documented
+func (sg *SwaggerGenerator) documentHead(path string) {
+	item, ok := sg.spec.Paths[openAPIPath(path)]
+	if !ok || item.GET == nil || item.HEAD != nil {
+		return
+	}
+	op := *item.GET
+	op.Summary = "HEAD " + path
+	if op.OperationID != "" {
+		op.OperationID += "Head"
+	}
+	op.Parameters = slices.Clone(op.Parameters)
+	op.Responses = make(map[string]Response, len(item.GET.Responses))
+	for code, res := range item.GET.Responses {
+		res.Content = nil
+		res.Headers = maps.Clone(res.Headers)
+		if len(code) == 3 && code[0] == '2' && code != "204" {
+			if res.Headers == nil {
+				res.Headers = map[string]Header{}
+			}
+			res.Headers["Content-Length"] = Header{Description: "Length of the body a GET request gets", Schema: Schema{Type: "integer"}}
+		}
+		op.Responses[code] = res
+	}
+	item.HEAD = &op
+	sg.spec.Paths[openAPIPath(path)] = item
+}
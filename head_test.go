@@ -0,0 +1,106 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type headTodoReq struct {
+	ID int `uri:"id"`
+}
+
+type headTodo struct {
+	ID    int    `json:"id"`
+	Title string `json:"title"`
+}
+
+func getTodoForHead(ctx *Context, req headTodoReq) (headTodo, error) {
+	if req.ID != 1 {
+		return headTodo{}, NotFound("todo not found")
+	}
+	return headTodo{ID: 1, Title: "Write docs"}, nil
+}
+
+func head(app *App, path string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest(http.MethodHead, path, nil))
+	return w
+}
+
+func TestAutoHead(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := New()
+	app.GET("/todos/:id", Handle(getTodoForHead))
+	app.TypedGroup("/v2").GET("/todos/:id", Handle(getTodoForHead))
+	app.GET("/plain", func(ctx *gin.Context) { ctx.String(http.StatusOK, "plain") })
+
+	for _, path := range []string{"/todos/1", "/todos/2", "/v2/todos/1"} {
+		full := get(app, path)
+		w := head(app, path)
+		if w.Code != full.Code || w.Body.Len() != 0 || w.Header().Get("Content-Type") != full.Header().Get("Content-Type") {
+			t.Errorf("%s: expected the GET response without its body, got %d %v %q", path, w.Code, w.Header(), w.Body)
+		}
+		if got := w.Header().Get("Content-Length"); got != strconv.Itoa(full.Body.Len()) {
+			t.Errorf("%s: expected Content-Length %d, got %q", path, full.Body.Len(), got)
+		}
+	}
+	if w := head(app, "/plain"); w.Code != http.StatusNotFound {
+		t.Errorf("untyped GET routes must not answer HEAD, got %d", w.Code)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic for a HEAD route after its GET route")
+		}
+	}()
+	app.HEAD("/todos/:id", func(ctx *gin.Context) {})
+}
+
+func TestAutoHead_Explicit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := New()
+	app.HEAD("/todos/:id", func(ctx *gin.Context) { ctx.Header("X-Explicit", "yes") })
+	app.GET("/todos/:id", Handle(getTodoForHead))
+	if w := head(app, "/todos/1"); w.Header().Get("X-Explicit") != "yes" {
+		t.Errorf("expected the HEAD route registered first to answer, got %v", w.Header())
+	}
+
+	off := New(WithoutAutoHead())
+	off.GET("/todos/:id", Handle(getTodoForHead))
+	if w := head(off, "/todos/1"); w.Code != http.StatusNotFound {
+		t.Errorf("expected no HEAD route with WithoutAutoHead, got %d", w.Code)
+	}
+}
+
+func TestWithHeadOperations(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := New().WithSwagger("t", "v", WithHeadOperations())
+	app.GET("/todos/:id", Handle(getTodoForHead))
+
+	item := groupSpec(t, app).Paths["/todos/{id}"]
+	if item.HEAD == nil {
+		t.Fatal("expected the HEAD operation documented")
+	}
+	ok := item.HEAD.Responses["200"]
+	if len(ok.Content) != 0 || ok.Headers["Content-Length"].Schema.Type != "integer" || len(item.HEAD.Parameters) != len(item.GET.Parameters) {
+		t.Errorf("unexpected HEAD operation %+v", item.HEAD)
+	}
+	if len(item.GET.Responses["200"].Content) == 0 {
+		t.Errorf("the GET operation lost its content")
+	}
+	if err := app.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	plain := New().WithSwagger("t", "v")
+	plain.GET("/todos/:id", Handle(getTodoForHead))
+	if groupSpec(t, plain).Paths["/todos/{id}"].HEAD != nil {
+		t.Errorf("HEAD operations are documented without the option")
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ResponseInterceptor runs after a fluxo handler with its response and error, before anything
+// is written, and returns the response and error to use instead
+type ResponseInterceptor func(ctx *Context, res any, err error) (any, error)
+
+const interceptorsKey = "fluxo.interceptors"
+
+// Intercept returns middleware registering fn for the fluxo handlers that run after it, on a
+// route, a group or the whole app. Interceptors run innermost first, like the code after
+// ctx.Next() in gin middleware:
+//
+//	app.Use(fluxo.Intercept(func(ctx *fluxo.Context, res any, err error) (any, error) {
+//		metrics.Observe(ctx.FullPath(), reflect.TypeOf(res), err)
+//		return res, err
+//	}))
+func Intercept(fn ResponseInterceptor) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var interceptors []ResponseInterceptor
+		if v, ok := ctx.Get(interceptorsKey); ok {
+			interceptors = v.([]ResponseInterceptor)
+		}
+		// Copy so requests never share a backing array
+		ctx.Set(interceptorsKey, append(interceptors[:len(interceptors):len(interceptors)], fn))
+		ctx.Next()
+	}
+}
+
+// InterceptType is like Intercept but only runs for handlers whose response type is Res
+//
+//	app.Use(fluxo.InterceptType(func(ctx *fluxo.Context, u User, err error) (User, error) {
+//		if !isAdmin(ctx) {
+//			u.Email = ""
+//		}
+//		return u, err
+//	}))
+func InterceptType[Res any](fn func(ctx *Context, res Res, err error) (Res, error)) gin.HandlerFunc {
+	return Intercept(func(ctx *Context, res any, err error) (any, error) {
+		typed, ok := res.(Res)
+		if !ok {
+			return res, err
+		}
+		return fn(ctx, typed, err)
+	})
+}
+
+// requestInterceptors returns the interceptors registered for the request
+func requestInterceptors(ctx *gin.Context) []ResponseInterceptor {
+	v, _ := ctx.Get(interceptorsKey)
+	interceptors, _ := v.([]ResponseInterceptor)
+	return interceptors
+}
+
+// intercept passes res and err through interceptors, innermost first
+func intercept(ctx *gin.Context, interceptors []ResponseInterceptor, res any, err error) (any, error) {
+	fctx := &Context{Context: ctx}
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		res, err = interceptors[i](fctx, res, err)
+	}
+	return res, err
+}
+
+// respondAny writes a response an interceptor replaced with a value of another type
+func respondAny(ctx *gin.Context, res any) {
+	status := http.StatusOK
+	if sc, ok := res.(StatusCoder); ok {
+		status = sc.StatusCode()
+	}
+	if hs, ok := res.(HeaderSetter); ok {
+		hs.SetHeaders(&Context{Context: ctx})
+	}
+	if r, ok := res.(renderer); ok {
+		r.render(ctx)
+		return
+	}
+	renderJSON(ctx, status, res)
+}
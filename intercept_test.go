@@ -0,0 +1,115 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type icUser struct {
+	Name      string `json:"name"`
+	Email     string `json:"email,omitempty"`
+	AuditedBy string `json:"audited_by,omitempty"`
+}
+
+type icReq struct {
+	Name string `form:"name"`
+}
+
+func icGetUser(ctx *Context, req icReq) (icUser, error) {
+	if req.Name == "" {
+		return icUser{}, NotFound("no such user")
+	}
+	if req.Name == "crash" {
+		return icUser{}, errors.New("db down")
+	}
+	return icUser{Name: req.Name, Email: req.Name + "@example.com"}, nil
+}
+
+func icServe(app *App, path string, header ...string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	for i := 0; i+1 < len(header); i += 2 {
+		req.Header.Set(header[i], header[i+1])
+	}
+	app.ServeHTTP(w, req)
+	return w
+}
+
+func TestInterceptTypeRewritesResponse(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := New()
+	app.Use(InterceptType(func(ctx *Context, u icUser, err error) (icUser, error) {
+		if ctx.GetHeader("X-Role") != "admin" {
+			u.Email = ""
+		}
+		u.AuditedBy = "interceptor"
+		return u, err
+	}))
+	app.GET("/user", Handle(icGetUser))
+	app.GET("/count", Handle(func(ctx *Context, req icReq) (int, error) { return 3, nil }))
+
+	body := icServe(app, "/user?name=ann").Body.String()
+	if strings.Contains(body, "email") || !strings.Contains(body, `"audited_by":"interceptor"`) {
+		t.Fatalf("unexpected response %s", body)
+	}
+	if body := icServe(app, "/user?name=ann", "X-Role", "admin").Body.String(); !strings.Contains(body, "ann@example.com") {
+		t.Fatalf("admins should see the email, got %s", body)
+	}
+	if body := icServe(app, "/count").Body.String(); strings.TrimSpace(body) != "3" {
+		t.Fatalf("other response types should pass through, got %s", body)
+	}
+}
+
+func TestInterceptSeesErrorsAndTypes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var seen []string
+	app := New()
+	app.GET("/user", Intercept(func(ctx *Context, res any, err error) (any, error) {
+		seen = append(seen, reflect.TypeOf(res).Name())
+		if err != nil && !errors.As(err, new(HTTPError)) {
+			return res, NewHTTPError(http.StatusServiceUnavailable, "try again later")
+		}
+		return res, err
+	}), Handle(icGetUser))
+
+	if w := icServe(app, "/user?name=crash"); w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected the interceptor's error, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := icServe(app, "/user"); w.Code != http.StatusNotFound {
+		t.Fatalf("expected the handler's error, got %d", w.Code)
+	}
+	if len(seen) != 2 || seen[0] != "icUser" {
+		t.Fatalf("unexpected types %v", seen)
+	}
+}
+
+func TestInterceptReplacesResponseAndOrder(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var order []string
+	app := New()
+	app.Use(Intercept(func(ctx *Context, res any, err error) (any, error) {
+		order = append(order, "outer")
+		return gin.H{"data": res}, err
+	}))
+	app.Use(Intercept(func(ctx *Context, res any, err error) (any, error) {
+		order = append(order, "inner")
+		return res, err
+	}))
+	app.GET("/user", Handle(icGetUser))
+
+	w := icServe(app, "/user?name=ann")
+	if w.Code != http.StatusOK || !strings.HasPrefix(w.Body.String(), `{"data":{"name":"ann"`) {
+		t.Fatalf("unexpected response %d: %s", w.Code, w.Body.String())
+	}
+	if !reflect.DeepEqual(order, []string{"inner", "outer"}) {
+		t.Fatalf("interceptors should run innermost first, got %v", order)
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// defaultLang is used when the client accepts none of the available languages
+const defaultLang = "en"
+
+// languageRange is an entry of an Accept-Language header
+type languageRange struct {
+	tag string
+	q   float64
+}
+
+// parseAcceptLanguage returns the language ranges of an Accept-Language header, most preferred
+// first. Ranges with q=0 are dropped and malformed entries are skipped.
+func parseAcceptLanguage(header string) []languageRange {
+	var ranges []languageRange
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			continue
+		}
+		q := 1.0
+		if params = strings.TrimSpace(params); params != "" {
+			name, value, ok := strings.Cut(params, "=")
+			if !ok || strings.TrimSpace(name) != "q" {
+				continue
+			}
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil || parsed < 0 || parsed > 1 {
+				continue
+			}
+			q = parsed
+		}
+		if q > 0 {
+			ranges = append(ranges, languageRange{tag: tag, q: q})
+		}
+	}
+	sort.SliceStable(ranges, func(i, j int) bool { return ranges[i].q > ranges[j].q })
+	return ranges
+}
+
+// negotiateLang picks the language of available the client prefers according to header, using
+// the lookup scheme of RFC 4647: each range, in order of preference, is tried as is and then
+// with subtags removed from the end ("fr-CH" falls back to "fr"). "*" and no match give fallback.
+func negotiateLang(header string, available []string, fallback string) string {
+	for _, r := range parseAcceptLanguage(header) {
+		if r.tag == "*" {
+			return fallback
+		}
+		for tag := r.tag; tag != ""; tag = truncateLangTag(tag) {
+			if i := slices.IndexFunc(available, func(a string) bool { return strings.EqualFold(a, tag) }); i >= 0 {
+				return available[i]
+			}
+		}
+	}
+	return fallback
+}
+
+// truncateLangTag removes the last subtag of tag, along with a singleton such as "x" left before it
+func truncateLangTag(tag string) string {
+	i := strings.LastIndexByte(tag, '-')
+	if i < 0 {
+		return ""
+	}
+	tag = tag[:i]
+	if j := strings.LastIndexByte(tag, '-'); j >= 0 && len(tag)-j == 2 {
+		tag = tag[:j]
+	}
+	return tag
+}
+
+// languages returns the languages the validator has messages for, including the built-in English
+func (v *Validator) languages() []string {
+	v.reloadTranslations()
+
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	langs := []string{defaultLang}
+	for lang, messages := range v.translations {
+		if len(messages) > 0 && lang != defaultLang {
+			langs = append(langs, lang)
+		}
+	}
+	for lang := range v.locales {
+		if !slices.Contains(langs, lang) {
+			langs = append(langs, lang)
+		}
+	}
+	sort.Strings(langs[1:])
+	return langs
+}
+
+// requestLang returns the language used for messages in the request: the one the client
+// prefers among those the app's validator has translations for, defaulting to English.
+func requestLang(ctx *gin.Context) string {
+	return negotiateLang(ctx.GetHeader("Accept-Language"), requestValidator(ctx).languages(), defaultLang)
+}
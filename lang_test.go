@@ -0,0 +1,69 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestNegotiateLang(t *testing.T) {
+	available := []string{"en", "fr", "de-CH", "zh-Hant"}
+	tests := []struct{ header, want string }{
+		{"", "en"},
+		{"fr", "fr"},
+		{"FR", "fr"},
+		{"fr-CH, fr;q=0.9, en;q=0.8", "fr"},
+		{"de-CH-1996", "de-CH"},
+		{"de", "en"},
+		{"zh-Hant-x-private", "zh-Hant"},
+		{"es, de-CH;q=0.5", "de-CH"},
+		{"en;q=0.5, fr;q=0.8", "fr"},
+		{"fr;q=0, en", "en"},
+		{"*", "en"},
+		{"es, *;q=0.5, fr;q=0.1", "en"},
+		{"fr;q=abc, de-CH", "de-CH"},
+		{"ja", "en"},
+	}
+	for _, tt := range tests {
+		if got := negotiateLang(tt.header, available, "en"); got != tt.want {
+			t.Errorf("%q: expected %q, got %q", tt.header, tt.want, got)
+		}
+	}
+}
+
+func TestContext_NegotiatedLang(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	v := NewValidator()
+	v.RegisterTranslation("fr", "required", "%s est obligatoire")
+	app := New(WithValidator(v))
+	app.POST("/items", Handle(func(ctx *Context, req struct {
+		Name string `json:"name" validate:"required"`
+	}) (gin.H, error) {
+		return gin.H{}, nil
+	}))
+	app.GET("/lang", Handle(func(ctx *Context, req struct{}) (gin.H, error) {
+		return gin.H{"lang": ctx.Lang(), "page": ctx.NegotiateLang("en", "de", "fr-CH")}, nil
+	}))
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept-Language", "fr-CH, fr;q=0.9, en;q=0.8")
+	app.ServeHTTP(w, req)
+	if !strings.Contains(w.Body.String(), "Name est obligatoire") {
+		t.Fatalf("expected the fr bundle to be used, got %s", w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodGet, "/lang", nil)
+	req.Header.Set("Accept-Language", "fr-CH, de;q=0.9")
+	app.ServeHTTP(w, req)
+	if w.Body.String() != `{"lang":"fr","page":"fr-CH"}` {
+		t.Fatalf("unexpected negotiation %s", w.Body.String())
+	}
+}
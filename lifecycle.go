@@ -0,0 +1,61 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// lifecycle tracks the server started by App.Start and what must stop with it
+type lifecycle struct {
+	mu         sync.Mutex
+	server     *http.Server
+	onStart    []func(ctx context.Context) error
+	onShutdown []func(ctx context.Context) error
+
+	maintenance atomic.Pointer[string] // the maintenance message, nil when serving normally
+	draining    atomic.Bool            // set by Shutdown, see WithDrainDelay
+	drainDelay  time.Duration
+}
+
+// OnShutdown registers fn to run when the app shuts down, after the HTTP server has stopped
+// accepting requests. Hooks run in reverse registration order.
+func (a *App) OnShutdown(fn func(ctx context.Context) error) {
+	a.life.mu.Lock()
+	defer a.life.mu.Unlock()
+	a.life.onShutdown = append(a.life.onShutdown, fn)
+}
+
+// Shutdown gracefully stops the server started by Start, waiting for in-flight requests, then
+// runs the OnShutdown hooks (draining workers, stopping schedules, ...) within ctx. New requests
+// get 503 from the moment it is called, see WithDrainDelay.
+func (a *App) Shutdown(ctx context.Context) error {
+	a.life.mu.Lock()
+	server := a.life.server
+	hooks := append([]func(context.Context) error(nil), a.life.onShutdown...)
+	a.life.mu.Unlock()
+
+	a.life.draining.Store(true)
+	if server != nil && a.life.drainDelay > 0 {
+		timer := time.NewTimer(a.life.drainDelay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+		}
+	}
+
+	var errs []error
+	if server != nil {
+		errs = append(errs, server.Shutdown(ctx))
+	}
+	for i := len(hooks) - 1; i >= 0; i-- {
+		errs = append(errs, hooks[i](ctx))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,121 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/go-playground/locales"
+	"github.com/go-playground/locales/de"
+	"github.com/go-playground/locales/es"
+	"github.com/go-playground/locales/fr"
+	"github.com/go-playground/locales/id"
+	"github.com/go-playground/locales/it"
+	"github.com/go-playground/locales/ja"
+	"github.com/go-playground/locales/ko"
+	"github.com/go-playground/locales/pt"
+	"github.com/go-playground/locales/pt_BR"
+	"github.com/go-playground/locales/ru"
+	"github.com/go-playground/locales/tr"
+	"github.com/go-playground/locales/zh"
+	"github.com/go-playground/locales/zh_Hant_TW"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	de_translations "github.com/go-playground/validator/v10/translations/de"
+	es_translations "github.com/go-playground/validator/v10/translations/es"
+	fr_translations "github.com/go-playground/validator/v10/translations/fr"
+	id_translations "github.com/go-playground/validator/v10/translations/id"
+	it_translations "github.com/go-playground/validator/v10/translations/it"
+	ja_translations "github.com/go-playground/validator/v10/translations/ja"
+	ko_translations "github.com/go-playground/validator/v10/translations/ko"
+	pt_translations "github.com/go-playground/validator/v10/translations/pt"
+	pt_BR_translations "github.com/go-playground/validator/v10/translations/pt_BR"
+	ru_translations "github.com/go-playground/validator/v10/translations/ru"
+	tr_translations "github.com/go-playground/validator/v10/translations/tr"
+	zh_translations "github.com/go-playground/validator/v10/translations/zh"
+	zh_tw_translations "github.com/go-playground/validator/v10/translations/zh_tw"
+)
+
+// localeBundle is a built-in set of validation messages from go-playground's translations
+type localeBundle struct {
+	locale   func() locales.Translator
+	register func(*validator.Validate, ut.Translator) error
+}
+
+var localeBundles = map[string]localeBundle{
+	"de":    {de.New, de_translations.RegisterDefaultTranslations},
+	"es":    {es.New, es_translations.RegisterDefaultTranslations},
+	"fr":    {fr.New, fr_translations.RegisterDefaultTranslations},
+	"id":    {id.New, id_translations.RegisterDefaultTranslations},
+	"it":    {it.New, it_translations.RegisterDefaultTranslations},
+	"ja":    {ja.New, ja_translations.RegisterDefaultTranslations},
+	"ko":    {ko.New, ko_translations.RegisterDefaultTranslations},
+	"pt":    {pt.New, pt_translations.RegisterDefaultTranslations},
+	"pt-BR": {pt_BR.New, pt_BR_translations.RegisterDefaultTranslations},
+	"ru":    {ru.New, ru_translations.RegisterDefaultTranslations},
+	"tr":    {tr.New, tr_translations.RegisterDefaultTranslations},
+	"zh":    {zh.New, zh_translations.RegisterDefaultTranslations},
+	"zh-TW": {zh_Hant_TW.New, zh_tw_translations.RegisterDefaultTranslations},
+}
+
+// BuiltinLocales returns the languages UseLocales has messages for
+func BuiltinLocales() []string {
+	return slices.Sorted(maps.Keys(localeBundles))
+}
+
+// ValidatorOption configures a validator created with NewValidator
+type ValidatorOption func(*Validator)
+
+// WithLocales adds the built-in messages of langs, see UseLocales. It panics on a language
+// without built-in messages.
+func WithLocales(langs ...string) ValidatorOption {
+	return func(v *Validator) {
+		if err := v.UseLocales(langs...); err != nil {
+			panic(fmt.Sprintf("fluxo: %v", err))
+		}
+	}
+}
+
+// UseLocales adds the built-in messages of langs (see BuiltinLocales) to the default validator
+func UseLocales(langs ...string) error {
+	return defaultValidator.UseLocales(langs...)
+}
+
+// UseLocales adds the built-in messages of langs for the common validator tags. Messages
+// registered with RegisterTranslation or LoadTranslations take precedence over them, and tags
+// without a built-in message keep the English one.
+//
+//	v := fluxo.NewValidator(fluxo.WithLocales("es", "fr", "de"))
+func (v *Validator) UseLocales(langs ...string) error {
+	for _, lang := range langs {
+		bundle, ok := localeBundles[lang]
+		if !ok {
+			return fmt.Errorf("no built-in validation messages for locale %q", lang)
+		}
+		// A translator holds the messages of one engine
+		var translators []ut.Translator
+		for _, engine := range v.engines() {
+			locale := bundle.locale()
+			trans, _ := ut.New(locale, locale).GetTranslator(locale.Locale())
+			if err := bundle.register(engine, trans); err != nil {
+				return fmt.Errorf("locale %q: %w", lang, err)
+			}
+			translators = append(translators, trans)
+		}
+
+		v.mu.Lock()
+		v.locales[lang] = translators
+		v.mu.Unlock()
+	}
+	return nil
+}
+
+// locale returns the translators of a language added with UseLocales, one per engine
+func (v *Validator) locale(lang string) ([]ut.Translator, bool) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	translators, ok := v.locales[lang]
+	return translators, ok
+}
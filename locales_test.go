@@ -0,0 +1,84 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type localeReq struct {
+	Name string `json:"name" validate:"required"`
+	Nick string `json:"nick" validate:"min=3"`
+	Code string `json:"code" validate:"test_locale_rule"`
+}
+
+func TestWithLocales(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	v := NewValidator(WithLocales("fr", "de", "pt-BR"))
+	v.Engine().RegisterValidation("test_locale_rule", func(fl validator.FieldLevel) bool { return fl.Field().String() != "x" })
+	v.RegisterTranslation("de", "required", "%s fehlt")
+	app := New(WithValidator(v))
+	app.POST("/users", Handle(func(ctx *Context, req localeReq) (gin.H, error) { return gin.H{}, nil }))
+
+	tests := []struct {
+		lang string
+		want []string
+	}{
+		{"fr-CH, fr;q=0.9", []string{"Name est un champ obligatoire", "Nick doit faire une taille minimum de 3 caractères"}},
+		{"pt-BR", []string{"Name é um campo obrigatório", "Nick deve ter pelo menos 3 caracteres"}},
+		// Registered messages win over the built-in ones
+		{"de", []string{"Name fehlt", "Nick muss mindestens 3 Zeichen lang sein"}},
+		// Not added, so English
+		{"es", []string{"Name is required", "Nick must be at least 3 characters"}},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(`{"nick":"a","code":"x"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept-Language", tt.lang)
+		app.ServeHTTP(w, req)
+		for _, want := range append(tt.want, "Code failed validation for test_locale_rule") {
+			if !strings.Contains(w.Body.String(), want) {
+				t.Errorf("%s: expected %q in %s", tt.lang, want, w.Body.String())
+			}
+		}
+	}
+}
+
+func TestUseLocales(t *testing.T) {
+	v := NewValidator()
+	if err := v.UseLocales("xx"); err == nil {
+		t.Fatal("expected an unknown locale to fail")
+	}
+	if err := v.UseLocales("ja"); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Contains(v.languages(), "ja") {
+		t.Fatalf("expected ja to be negotiable, got %v", v.languages())
+	}
+	if got := v.message("ja", "Name", "required", ""); got == "Name is required" {
+		t.Fatal("expected generated errors to use the built-in message when it fits")
+	}
+	if got := v.message("ja", "Name", "min", "3"); got != "Name must be at least 3 characters" {
+		t.Fatalf("expected kind-dependent messages to fall back to English, got %q", got)
+	}
+	for _, lang := range []string{"es", "fr", "de", "pt", "ja", "id", "zh"} {
+		if !slices.Contains(BuiltinLocales(), lang) {
+			t.Errorf("expected %s to be built in", lang)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected WithLocales to panic on an unknown locale")
+		}
+	}()
+	NewValidator(WithLocales("xx"))
+}
@@ -0,0 +1,203 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Localized is a value in several languages, keyed by language tag, such as the name of a
+// product. JSON responses of typed handlers hold the translation the client prefers instead of
+// all of them, negotiated from Accept-Language among the languages of the response's values,
+// and say which with a Content-Language header:
+//
+//	type Product struct {
+//		ID   int                      `json:"id"`
+//		Name fluxo.Localized[string] `json:"name"`
+//	}
+//
+//	return Product{ID: 1, Name: fluxo.Localized[string]{"en": "Chair", "fr": "Chaise"}}, nil
+//	// Accept-Language: fr -> {"id":1,"name":"Chaise"}, Content-Language: fr
+//
+// Values missing the negotiated language fall back to English, or else their first language
+// in alphabetical order. Elsewhere, like in request bodies, Localized is a plain JSON object.
+type Localized[T any] map[string]T
+
+// In returns the translation in lang, with the fallbacks of responses
+func (l Localized[T]) In(lang string) T {
+	if v, ok := l[lang]; ok {
+		return v
+	}
+	return l[fallbackLang(slices.Collect(maps.Keys(l)))]
+}
+
+func (Localized[T]) localizedElem() reflect.Type {
+	return reflect.TypeFor[T]()
+}
+
+type localizedValue interface {
+	localizedElem() reflect.Type
+}
+
+var localizedValueType = reflect.TypeFor[localizedValue]()
+
+// localizedElem returns T when t is a Localized[T]
+func localizedElem(t reflect.Type) (reflect.Type, bool) {
+	if t == nil || !t.Implements(localizedValueType) {
+		return nil, false
+	}
+	return reflect.Zero(t).Interface().(localizedValue).localizedElem(), true
+}
+
+// fallbackLang returns the language used when langs has none the client accepts: English, or
+// else the first one
+func fallbackLang(langs []string) string {
+	if slices.Contains(langs, defaultLang) || len(langs) == 0 {
+		return defaultLang
+	}
+	return slices.Min(langs)
+}
+
+// localizedNode describes where the Localized values are in the JSON form of a type
+type localizedNode struct {
+	localized bool                      // the value itself is Localized
+	fields    map[string]*localizedNode // of a struct, by JSON name
+	elem      *localizedNode            // of the elements of a slice or the values of a map
+}
+
+var localizedNodes sync.Map // reflect.Type -> *localizedNode, nil for types without Localized values
+
+// localizedNodeFor returns where the Localized values of t are, or nil when it has none
+func localizedNodeFor(t reflect.Type) *localizedNode {
+	if t == nil {
+		return nil
+	}
+	if n, ok := localizedNodes.Load(t); ok {
+		return n.(*localizedNode)
+	}
+	n := buildLocalizedNode(t, map[reflect.Type]*localizedNode{})
+	localizedNodes.Store(t, n)
+	return n
+}
+
+func buildLocalizedNode(t reflect.Type, building map[reflect.Type]*localizedNode) *localizedNode {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if _, ok := localizedElem(t); ok {
+		return &localizedNode{localized: true}
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		if n, ok := building[t]; ok {
+			// A recursive type: the node is completed by the outer call
+			return n
+		}
+		n := &localizedNode{fields: map[string]*localizedNode{}}
+		building[t] = n
+		addLocalizedFields(n, t, building)
+		if len(n.fields) == 0 {
+			return nil
+		}
+		return n
+	case reflect.Slice, reflect.Array, reflect.Map:
+		if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+			return nil
+		}
+		if elem := buildLocalizedNode(t.Elem(), building); elem != nil {
+			return &localizedNode{elem: elem}
+		}
+	}
+	return nil
+}
+
+// addLocalizedFields adds the fields of struct t holding Localized values to n, naming them like encoding/json
+func addLocalizedFields(n *localizedNode, t reflect.Type, building map[reflect.Type]*localizedNode) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		jsonTag := field.Tag.Get("json")
+		if jsonTag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(jsonTag, ",")
+		if field.Anonymous && name == "" {
+			if embedded, ok := embeddedStruct(field); ok {
+				addLocalizedFields(n, embedded, building)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if child := buildLocalizedNode(field.Type, building); child != nil {
+			n.fields[name] = child
+		}
+	}
+}
+
+// each replaces the Localized values under v, a decoded JSON value, with what fn returns for them
+func (n *localizedNode) each(v any, fn func(translations map[string]any) any) any {
+	if n.localized {
+		if translations, ok := v.(map[string]any); ok {
+			return fn(translations)
+		}
+		return v
+	}
+	switch val := v.(type) {
+	case map[string]any:
+		if n.elem != nil {
+			for key, item := range val {
+				val[key] = n.elem.each(item, fn)
+			}
+			return val
+		}
+		for name, f := range n.fields {
+			if item, ok := val[name]; ok {
+				val[name] = f.each(item, fn)
+			}
+		}
+	case []any:
+		if n.elem != nil {
+			for i, item := range val {
+				val[i] = n.elem.each(item, fn)
+			}
+		}
+	}
+	return v
+}
+
+// localize replaces the Localized values of v, a decoded JSON response, with their translation
+// in the language the client prefers, and tells the client which it is
+func localize(ctx *gin.Context, n *localizedNode, v any) any {
+	var langs []string
+	n.each(v, func(translations map[string]any) any {
+		for lang := range translations {
+			if !slices.Contains(langs, lang) {
+				langs = append(langs, lang)
+			}
+		}
+		return translations
+	})
+	ctx.Writer.Header().Add("Vary", "Accept-Language")
+	if len(langs) == 0 {
+		return n.each(v, func(map[string]any) any { return nil })
+	}
+	slices.Sort(langs)
+	lang := negotiateLang(ctx.GetHeader("Accept-Language"), langs, fallbackLang(langs))
+	ctx.Header("Content-Language", lang)
+	return n.each(v, func(translations map[string]any) any {
+		if value, ok := translations[lang]; ok {
+			return value
+		}
+		return translations[fallbackLang(slices.Collect(maps.Keys(translations)))]
+	})
+}
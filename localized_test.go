@@ -0,0 +1,101 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type localizedProduct struct {
+	ID       int                  `json:"id"`
+	Name     Localized[string]    `json:"name"`
+	Variants []localizedVariant   `json:"variants,omitempty"`
+	Notes    *Localized[[]string] `json:"notes,omitempty"`
+}
+
+type localizedVariant struct {
+	Label Localized[string] `json:"label"`
+}
+
+func localizedApp() *App {
+	gin.SetMode(gin.TestMode)
+	app := New().WithSwagger("t", "v")
+	app.GET("/products/1", Handle(func(ctx *Context, _ struct{}) (localizedProduct, error) {
+		return localizedProduct{
+			ID:       1,
+			Name:     Localized[string]{"en": "Chair", "fr": "Chaise", "de": "Stuhl"},
+			Variants: []localizedVariant{{Label: Localized[string]{"en": "Oak", "fr": "Chêne"}}},
+			Notes:    &Localized[[]string]{"de": {"Massivholz"}},
+		}, nil
+	}))
+	return app
+}
+
+func getLocalized(t *testing.T, app *App, acceptLanguage string) (*httptest.ResponseRecorder, map[string]any) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/products/1", nil)
+	if acceptLanguage != "" {
+		req.Header.Set("Accept-Language", acceptLanguage)
+	}
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, req)
+	var body map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("%v: %s", err, w.Body)
+	}
+	return w, body
+}
+
+func TestLocalized_Negotiated(t *testing.T) {
+	app := localizedApp()
+
+	w, body := getLocalized(t, app, "fr-CH, de;q=0.5")
+	if body["name"] != "Chaise" || body["variants"].([]any)[0].(map[string]any)["label"] != "Chêne" {
+		t.Errorf("expected French, got %v", body)
+	}
+	// Values without the language fall back to English, or else their first language
+	if notes := body["notes"].([]any); notes[0] != "Massivholz" {
+		t.Errorf("expected the only translation of the notes, got %v", notes)
+	}
+	if w.Header().Get("Content-Language") != "fr" || !strings.Contains(w.Header().Get("Vary"), "Accept-Language") {
+		t.Errorf("expected Content-Language fr and Vary, got %v", w.Header())
+	}
+
+	w, body = getLocalized(t, app, "de")
+	if body["name"] != "Stuhl" || body["variants"].([]any)[0].(map[string]any)["label"] != "Oak" || w.Header().Get("Content-Language") != "de" {
+		t.Errorf("expected German with an English fallback, got %v %v", body, w.Header())
+	}
+
+	w, body = getLocalized(t, app, "")
+	if body["name"] != "Chair" || w.Header().Get("Content-Language") != "en" {
+		t.Errorf("expected English by default, got %v %v", body, w.Header())
+	}
+}
+
+func TestLocalized_In(t *testing.T) {
+	name := Localized[string]{"fr": "Chaise", "de": "Stuhl"}
+	if name.In("fr") != "Chaise" || name.In("ja") != "Stuhl" {
+		t.Errorf("unexpected translations %q %q", name.In("fr"), name.In("ja"))
+	}
+	if got := (Localized[string]{}).In("fr"); got != "" {
+		t.Errorf("expected the zero value, got %q", got)
+	}
+}
+
+func TestLocalized_Documented(t *testing.T) {
+	spec := groupSpec(t, localizedApp())
+	name := spec.Components.Schemas["localizedProduct"].Properties["name"]
+	if name.Type != "string" || !strings.Contains(name.Description, "Accept-Language") {
+		t.Errorf("expected the name documented as a string, got %+v", name)
+	}
+	res := spec.Paths["/products/1"].GET.Responses["200"]
+	if _, ok := res.Headers["Content-Language"]; !ok {
+		t.Errorf("expected Content-Language documented, got %+v", res.Headers)
+	}
+}
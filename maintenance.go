@@ -0,0 +1,88 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"net/http"
+	"reflect"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Maintenance is the Skip name exempting a route from maintenance mode and drain responses:
+//
+//	app.GET("/admin/status", fluxo.Skip(fluxo.Maintenance), fluxo.Handle(status))
+const Maintenance = "maintenance"
+
+// DefaultMaintenanceMessage is the error of maintenance responses when SetMaintenance is given none
+const DefaultMaintenanceMessage = "The service is down for maintenance"
+
+// Retry-After values of maintenance and drain responses, in seconds
+const (
+	maintenanceRetryAfter = "60"
+	drainRetryAfter       = "1"
+)
+
+// WithDrainDelay makes Shutdown keep the server up for delay before closing it, answering 503
+// from Readiness and to new requests meanwhile, so that load balancers stop routing to the app
+func WithDrainDelay(delay time.Duration) AppOption {
+	return func(c *appConfig) {
+		c.drainDelay = delay
+	}
+}
+
+// SetMaintenance turns maintenance mode on or off. In maintenance, routes not exempted with
+// Skip(fluxo.Maintenance) answer 503 with message and a Retry-After header, and so does Readiness.
+func (a *App) SetMaintenance(on bool, message string) {
+	if !on {
+		a.life.maintenance.Store(nil)
+		return
+	}
+	if message == "" {
+		message = DefaultMaintenanceMessage
+	}
+	a.life.maintenance.Store(&message)
+}
+
+// InMaintenance reports whether maintenance mode is on
+func (a *App) InMaintenance() bool {
+	return a.life.maintenance.Load() != nil
+}
+
+// Readiness returns a handler for readiness probes: 200 while the app serves traffic, 503 in
+// maintenance mode or while Shutdown drains it
+//
+//	app.GET("/readyz", app.Readiness())
+func (a *App) Readiness() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		switch {
+		case a.life.draining.Load():
+			ctx.JSON(http.StatusServiceUnavailable, gin.H{"status": "draining"})
+		case a.InMaintenance():
+			ctx.JSON(http.StatusServiceUnavailable, gin.H{"status": Maintenance})
+		default:
+			ctx.JSON(http.StatusOK, gin.H{"status": "ready"})
+		}
+	}
+}
+
+// readinessCode identifies Readiness handlers, which answer for themselves
+var readinessCode = reflect.ValueOf((&App{}).Readiness()).Pointer()
+
+// checkAvailable answers 503 to requests arriving in maintenance mode or while draining, unless
+// their route skips Maintenance. It runs after applySkips.
+func (a *App) checkAvailable(ctx *gin.Context) {
+	draining, message := a.life.draining.Load(), a.life.maintenance.Load()
+	if !draining && message == nil || skipped(ctx, Maintenance) || reflect.ValueOf(ctx.Handler()).Pointer() == readinessCode {
+		return
+	}
+	if draining {
+		ctx.Header("Connection", "close")
+		ctx.Header("Retry-After", drainRetryAfter)
+		ctx.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{"error": "The server is shutting down"})
+		return
+	}
+	ctx.Header("Retry-After", maintenanceRetryAfter)
+	ctx.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{"error": *message})
+}
@@ -0,0 +1,113 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func maintenanceApp(opts ...AppOption) *App {
+	gin.SetMode(gin.TestMode)
+	app := New(opts...)
+	app.GET("/readyz", app.Readiness())
+	app.GET("/orders", func(ctx *gin.Context) { ctx.JSON(http.StatusOK, gin.H{"orders": []string{}}) })
+	app.GET("/admin/status", Skip(Maintenance), func(ctx *gin.Context) { ctx.String(http.StatusOK, "up") })
+	return app
+}
+
+func get(app http.Handler, path string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+	return w
+}
+
+func TestApp_SetMaintenance(t *testing.T) {
+	app := maintenanceApp()
+	if w := get(app, "/readyz"); w.Code != http.StatusOK {
+		t.Fatalf("readyz = %d before maintenance", w.Code)
+	}
+
+	app.SetMaintenance(true, "Back at 10:00 UTC")
+	if !app.InMaintenance() {
+		t.Error("InMaintenance = false")
+	}
+	w := get(app, "/orders")
+	if w.Code != http.StatusServiceUnavailable || w.Header().Get("Retry-After") == "" || !strings.Contains(w.Body.String(), "Back at 10:00 UTC") {
+		t.Errorf("orders: status = %d, Retry-After = %q, body = %s", w.Code, w.Header().Get("Retry-After"), w.Body)
+	}
+	if w := get(app, "/readyz"); w.Code != http.StatusServiceUnavailable || !strings.Contains(w.Body.String(), `"status":"maintenance"`) {
+		t.Errorf("readyz: status = %d, body = %s", w.Code, w.Body)
+	}
+	if w := get(app, "/admin/status"); w.Code != http.StatusOK {
+		t.Errorf("exempt route: status = %d, want 200", w.Code)
+	}
+
+	app.SetMaintenance(false, "")
+	if w := get(app, "/orders"); w.Code != http.StatusOK {
+		t.Errorf("orders after maintenance: status = %d", w.Code)
+	}
+}
+
+func TestApp_SetMaintenance_DefaultMessage(t *testing.T) {
+	app := maintenanceApp()
+	app.SetMaintenance(true, "")
+	if w := get(app, "/orders"); !strings.Contains(w.Body.String(), DefaultMaintenanceMessage) {
+		t.Errorf("body = %s", w.Body)
+	}
+}
+
+func TestApp_Shutdown_Drain(t *testing.T) {
+	app := maintenanceApp(WithMode(gin.TestMode), WithDrainDelay(300*time.Millisecond))
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := listener.Addr().String()
+	_ = listener.Close()
+
+	done := make(chan error, 1)
+	go func() { done <- app.Start(addr) }()
+	var res *http.Response
+	for i := 0; i < 50; i++ {
+		if res, err = http.Get("http://" + addr + "/readyz"); err == nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = res.Body.Close()
+
+	stopped := make(chan error, 1)
+	go func() { stopped <- app.Shutdown(context.Background()) }()
+	time.Sleep(50 * time.Millisecond)
+
+	// The server is still up during the drain delay, but turns requests away
+	client := &http.Client{Transport: &http.Transport{DisableKeepAlives: true}}
+	for path, want := range map[string]int{"/readyz": http.StatusServiceUnavailable, "/orders": http.StatusServiceUnavailable, "/admin/status": http.StatusOK} {
+		res, err := client.Get("http://" + addr + path)
+		if err != nil {
+			t.Fatalf("%s: %v", path, err)
+		}
+		_ = res.Body.Close()
+		if res.StatusCode != want {
+			t.Errorf("%s while draining: status = %d, want %d", path, res.StatusCode, want)
+		}
+	}
+
+	if err := <-stopped; err != nil {
+		t.Errorf("Shutdown: %v", err)
+	}
+	if err := <-done; err != nil {
+		t.Errorf("Start: %v", err)
+	}
+}
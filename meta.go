@@ -0,0 +1,118 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// requestMeta reads the request metadata that string fields tagged `fluxo:"name"` are filled with:
+//
+//	type CreateTodoReq struct {
+//		Title     string `json:"title"`
+//		ClientIP  string `fluxo:"client_ip"`
+//		UserAgent string `fluxo:"user_agent"`
+//	}
+var requestMeta = map[string]func(ctx *gin.Context) string{
+	"client_ip":  func(ctx *gin.Context) string { return (&Context{ctx}).RealIP() },
+	"user_agent": func(ctx *gin.Context) string { return ctx.Request.UserAgent() },
+	"method":     func(ctx *gin.Context) string { return ctx.Request.Method },
+	"path":       func(ctx *gin.Context) string { return ctx.Request.URL.Path },
+	"route":      func(ctx *gin.Context) string { return ctx.FullPath() },
+	"host":       func(ctx *gin.Context) string { return ctx.Request.Host },
+	"request_id": requestIDOf,
+	"trace_id":   func(ctx *gin.Context) string { return traceID(ctx.Request.Header) },
+}
+
+// metaField is a request field filled with metadata
+type metaField struct {
+	index []int
+	read  func(ctx *gin.Context) string
+}
+
+// metaFields returns the fields of t tagged with fluxo, panicking on unknown names and on fields
+// that aren't strings, so that mistakes show when the route is registered
+func metaFields(t reflect.Type) []metaField {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+	var fields []metaField
+	for _, field := range reflect.VisibleFields(t) {
+		name := tagName(field, "fluxo")
+		if name == "" {
+			continue
+		}
+		read, ok := requestMeta[name]
+		if !ok {
+			names := slices.Sorted(maps.Keys(requestMeta))
+			panic(fmt.Sprintf("fluxo: %s.%s: unknown request metadata %q, want one of %s", t.Name(), field.Name, name, strings.Join(names, ", ")))
+		}
+		if field.Type.Kind() != reflect.String || !field.IsExported() {
+			panic(fmt.Sprintf("fluxo: %s.%s: request metadata goes into exported string fields", t.Name(), field.Name))
+		}
+		fields = append(fields, metaField{index: field.Index, read: read})
+	}
+	return fields
+}
+
+// bindMeta fills the metadata fields of obj, a pointer to the planned type. It runs after the
+// other sources, so clients can't set these fields through the body or the query.
+func (p *bindingPlan) bindMeta(ctx *gin.Context, obj any) {
+	if len(p.meta) == 0 {
+		return
+	}
+	v := reflect.ValueOf(obj).Elem()
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		v = v.Elem()
+	}
+	for _, f := range p.meta {
+		// Fields promoted through nil embedded pointers are left alone
+		if field, err := v.FieldByIndexErr(f.index); err == nil {
+			field.SetString(f.read(ctx))
+		}
+	}
+}
+
+// requestIDOf returns the ID given by RequestID, or else a valid one sent by the client
+func requestIDOf(ctx *gin.Context) string {
+	if id, ok := CurrentRequestID(ctx); ok {
+		return id
+	}
+	if id := ctx.GetHeader(RequestIDHeader); validRequestID(id) {
+		return id
+	}
+	return ""
+}
+
+// traceID returns the trace ID of the W3C, B3 or Google Cloud trace headers
+func traceID(h http.Header) string {
+	if parts := strings.Split(h.Get("traceparent"), "-"); len(parts) >= 4 && len(parts[1]) == 32 {
+		return parts[1]
+	}
+	if id := h.Get("X-B3-TraceId"); id != "" {
+		return id
+	}
+	if b3 := h.Get("b3"); b3 != "" {
+		if id, _, ok := strings.Cut(b3, "-"); ok {
+			return id
+		}
+	}
+	if cloud := h.Get("X-Cloud-Trace-Context"); cloud != "" {
+		id, _, _ := strings.Cut(cloud, "/")
+		return id
+	}
+	return ""
+}
@@ -0,0 +1,114 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type metaAudit struct {
+	RequestID string `fluxo:"request_id"`
+	TraceID   string `fluxo:"trace_id"`
+}
+
+type metaReq struct {
+	ID        string `uri:"id"`
+	Title     string `json:"title"`
+	ClientIP  string `fluxo:"client_ip"`
+	UserAgent string `fluxo:"user_agent"`
+	Method    string `fluxo:"method"`
+	Route     string `fluxo:"route"`
+	Path      string `fluxo:"path"`
+	metaAudit
+}
+
+func TestRequestMeta(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := New().WithSwagger("API", "1.0")
+	var got metaReq
+	app.PUT("/todos/:id", Handle(func(ctx *Context, req metaReq) (string, error) {
+		got = req
+		return "ok", nil
+	}))
+
+	body := `{"title":"Ship","ClientIP":"6.6.6.6","Method":"GET"}`
+	req := httptest.NewRequest("PUT", "/todos/7?ClientIP=6.6.6.6", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "todo-cli/1.0")
+	req.Header.Set("X-Request-ID", "req-1")
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	req.RemoteAddr = "10.0.0.1:1234"
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body)
+	}
+
+	want := metaReq{
+		ID: "7", Title: "Ship", ClientIP: "10.0.0.1", UserAgent: "todo-cli/1.0", Method: "PUT",
+		Route: "/todos/:id", Path: "/todos/7",
+		metaAudit: metaAudit{RequestID: "req-1", TraceID: "4bf92f3577b34da6a3ce929d0e0e4736"},
+	}
+	if got != want {
+		t.Errorf("req = %+v\nwant %+v", got, want)
+	}
+
+	spec, err := app.swagger.SpecJSON(app.specHandlers())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(spec), "ClientIP") || strings.Contains(string(spec), "client_ip") {
+		t.Errorf("metadata field documented: %s", spec)
+	}
+}
+
+func TestRequestMeta_Invalid(t *testing.T) {
+	for name, fn := range map[string]func(){
+		"unknown name": func() {
+			Handle(func(ctx *Context, req struct {
+				IP string `fluxo:"ip"`
+			}) (string, error) {
+				return "", nil
+			})
+		},
+		"not a string": func() {
+			Handle(func(ctx *Context, req struct {
+				Method int `fluxo:"method"`
+			}) (string, error) {
+				return "", nil
+			})
+		},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: no panic", name)
+				}
+			}()
+			fn()
+		}()
+	}
+}
+
+func TestTraceID(t *testing.T) {
+	cases := map[string]string{
+		"X-B3-TraceId":          "80f198ee56343ba864fe8b2a57d3eff7",
+		"b3":                    "80f198ee56343ba864fe8b2a57d3eff7-e457b5a2e4d86bd1-1",
+		"X-Cloud-Trace-Context": "105445aa7843bc8bf206b12000100000/1;o=1",
+	}
+	for header, value := range cases {
+		h := http.Header{}
+		h.Set(header, value)
+		if id := traceID(h); id == "" || strings.ContainsAny(id, "-/") {
+			t.Errorf("%s: trace ID = %q", header, id)
+		}
+	}
+	if id := traceID(http.Header{}); id != "" {
+		t.Errorf("no headers: trace ID = %q", id)
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type mountedApp struct {
+	prefix string
+	app    *App
+}
+
+// Mount serves every request under prefix with h, stripping the prefix from the URL path.
+// Use it to keep legacy net/http handlers running next to typed routes. Mounted at "/", h
+// serves the requests no route of the app matches.
+func (a *App) Mount(prefix string, h http.Handler) {
+	prefix = "/" + strings.Trim(prefix, "/")
+	if prefix == "/" {
+		a.mountRoot(h)
+		return
+	}
+	handler := gin.WrapH(stripPrefix(prefix, h))
+	a.router.Any(prefix, handler)
+	a.router.Any(prefix+"/*fluxo_mount_path", handler)
+}
+
+// mountRoot serves the requests no route matches with h. A catch-all route at the root would
+// conflict with every other route of the app.
+func (a *App) mountRoot(h http.Handler) {
+	if a.rootMounted {
+		panic("fluxo: a handler is already mounted at /")
+	}
+	a.rootMounted = true
+	a.noRoute = append(a.noRoute, func(ctx *gin.Context) {
+		// gin has set the 404 of an unmatched request, which h answers instead
+		ctx.Status(http.StatusOK)
+		h.ServeHTTP(ctx.Writer, ctx.Request)
+		ctx.Abort()
+	})
+	a.router.NoRoute(a.noRoute...)
+}
+
+// MountApp serves sub under prefix and merges its documented routes into this app's spec
+func (a *App) MountApp(prefix string, sub *App) {
+	a.Mount(prefix, sub)
+	// The routes of a sub-app mounted at "/" keep their paths
+	prefix = strings.TrimSuffix("/"+strings.Trim(prefix, "/"), "/")
+	a.mounts = append(a.mounts, mountedApp{prefix: prefix, app: sub})
+	sub.parents = append(sub.parents, a)
+	a.invalidateSpec()
+}
+
+// specHandlers returns the documented routes of the app and every mounted sub-app
+func (a *App) specHandlers() map[string]handlerInfo {
+	if len(a.mounts) == 0 {
+		return a.handlers
+	}
+	merged := make(map[string]handlerInfo, len(a.handlers))
+	for key, info := range a.handlers {
+		merged[key] = info
+	}
+	for _, m := range a.mounts {
+		for _, info := range m.app.specHandlers() {
+			info.path = m.prefix + info.path
+			merged[info.method+":"+info.path] = info
+		}
+	}
+	return merged
+}
+
+// invalidateSpec drops the cached spec of the app and of every app it is mounted in
+func (a *App) invalidateSpec() {
+	if a.swagger != nil {
+		a.swagger.Invalidate()
+	}
+	for _, parent := range a.parents {
+		parent.invalidateSpec()
+	}
+}
+
+// stripPrefix is like http.StripPrefix but always leaves a rooted path
+func stripPrefix(prefix string, h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p := strings.TrimPrefix(r.URL.Path, prefix)
+		rp := strings.TrimPrefix(r.URL.RawPath, prefix)
+		if !strings.HasPrefix(p, "/") {
+			p = "/" + p
+		}
+		if rp != "" && !strings.HasPrefix(rp, "/") {
+			rp = "/" + rp
+		}
+
+		r2 := new(http.Request)
+		*r2 = *r
+		r2.URL = new(url.URL)
+		*r2.URL = *r.URL
+		r2.URL.Path = p
+		r2.URL.RawPath = rp
+		h.ServeHTTP(w, r2)
+	})
+}
@@ -0,0 +1,130 @@
+package fluxo
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type mtInvoiceReq struct {
+	ID string `uri:"id"`
+}
+
+type mtInvoice struct {
+	ID string `json:"id"`
+}
+
+func TestMount_HTTPHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := New()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("legacy:" + r.URL.Path))
+	})
+	app.Mount("/legacy/", mux)
+
+	for path, want := range map[string]string{
+		"/legacy":         "legacy:/",
+		"/legacy/":        "legacy:/",
+		"/legacy/a/b?x=1": "legacy:/a/b",
+	} {
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, httptest.NewRequest(http.MethodPost, path, nil))
+		if w.Code != http.StatusOK || w.Body.String() != want {
+			t.Errorf("%s: status=%d body=%q", path, w.Code, w.Body.String())
+		}
+	}
+}
+
+func TestMountApp_RoutesAndSpec(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	billing := New()
+	billing.GET("/invoices/:id", Handle(func(ctx *Context, req mtInvoiceReq) (mtInvoice, error) {
+		return mtInvoice{ID: req.ID}, nil
+	}))
+
+	app := New().WithSwagger("Test", "1.0.0")
+	app.MountApp("/billing", billing)
+
+	fetchPaths := func() map[string]interface{} {
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+		var spec map[string]interface{}
+		_ = json.Unmarshal(w.Body.Bytes(), &spec)
+		return spec["paths"].(map[string]interface{})
+	}
+
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/billing/invoices/42", nil))
+	if w.Code != http.StatusOK || w.Body.String() != `{"id":"42"}` {
+		t.Fatalf("status=%d body=%s", w.Code, w.Body.String())
+	}
+
+	if _, ok := fetchPaths()["/billing/invoices/{id}"]; !ok {
+		t.Fatalf("expected mounted route in spec")
+	}
+
+	// Routes added to the sub-app later invalidate the parent's cached spec
+	billing.POST("/invoices", Handle(func(ctx *Context, req mtInvoice) (mtInvoice, error) { return req, nil }))
+	if _, ok := fetchPaths()["/billing/invoices"]; !ok {
+		t.Fatalf("expected late route in spec")
+	}
+}
+
+func TestMount_Root(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := New(WithRedirectFixedPath(true))
+	app.GET("/invoices/:id", Handle(func(ctx *Context, req mtInvoiceReq) (mtInvoice, error) {
+		return mtInvoice{ID: req.ID}, nil
+	}))
+	legacy := http.NewServeMux()
+	legacy.HandleFunc("/old/", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("legacy:" + r.URL.Path))
+	})
+	app.Mount("/", legacy)
+
+	for path, want := range map[string]struct {
+		status int
+		body   string
+	}{
+		"/invoices/7":  {http.StatusOK, `{"id":"7"}`},
+		"/old/a/b":     {http.StatusOK, "legacy:/old/a/b"},
+		"/nope":        {http.StatusNotFound, "404 page not found\n"},
+		"/invoices//7": {http.StatusMovedPermanently, ""},
+	} {
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		if w.Code != want.status || (want.body != "" && w.Body.String() != want.body) {
+			t.Errorf("%s: status=%d body=%q", path, w.Code, w.Body.String())
+		}
+	}
+}
+
+func TestMountApp_Root(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	billing := New()
+	billing.GET("/invoices/:id", Handle(func(ctx *Context, req mtInvoiceReq) (mtInvoice, error) {
+		return mtInvoice{ID: req.ID}, nil
+	}))
+	app := New().WithSwagger("Test", "1.0.0")
+	app.MountApp("/", billing)
+
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/invoices/42", nil))
+	if w.Code != http.StatusOK || w.Body.String() != `{"id":"42"}` {
+		t.Fatalf("status=%d body=%s", w.Code, w.Body.String())
+	}
+	if _, ok := app.specHandlers()["GET:/invoices/:id"]; !ok {
+		t.Fatalf("expected the sub-app's route under its own path, got %v", app.specHandlers())
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a second mount at / to panic")
+		}
+	}()
+	app.Mount("/", http.NotFoundHandler())
+}
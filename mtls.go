@@ -0,0 +1,128 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MutualTLSConfig returns a TLS config serving the certificate in certFile and keyFile, and
+// requiring clients to present a certificate signed by a CA in clientCAFile (PEM encoded):
+//
+//	config, err := fluxo.MutualTLSConfig("server.crt", "server.key", "clients-ca.crt")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	log.Fatal(app.StartTLS(":8443", config))
+func MutualTLSConfig(certFile, keyFile, clientCAFile string) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("fluxo: loading the server certificate: %w", err)
+	}
+	pem, err := os.ReadFile(clientCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("fluxo: reading the client CAs: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, errors.New("fluxo: no certificate found in " + clientCAFile)
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+// ClientIdentity describes the verified certificate a client presented
+type ClientIdentity struct {
+	CommonName   string
+	Organization []string
+	DNSNames     []string
+	URIs         []string // URI SANs, such as SPIFFE IDs
+	Emails       []string
+	SerialNumber string
+	Certificate  *x509.Certificate `json:"-"`
+}
+
+// Names returns the common name followed by the DNS and URI SANs of the certificate
+func (id ClientIdentity) Names() []string {
+	names := []string{id.CommonName}
+	names = append(names, id.DNSNames...)
+	return append(names, id.URIs...)
+}
+
+// ClientIdentityKey holds the identity RequireClientCert verified, for HandleCtx handlers
+var ClientIdentityKey = NewKey[ClientIdentity]("fluxo.client_identity")
+
+// ClientCert returns the identity of the client's verified certificate. ctx is a *gin.Context,
+// a *Context, or the context of a HandleCtx handler behind RequireClientCert.
+// Certificates the server didn't verify are ignored.
+func ClientCert(ctx context.Context) (ClientIdentity, bool) {
+	if id, ok := ClientIdentityKey.Get(ctx); ok {
+		return id, true
+	}
+	var req *http.Request
+	switch c := ctx.(type) {
+	case *gin.Context:
+		req = c.Request
+	case *Context:
+		req = c.Request
+	}
+	if req == nil {
+		return ClientIdentity{}, false
+	}
+	return clientIdentity(req)
+}
+
+// clientIdentity returns the identity of the leaf of the request's first verified chain
+func clientIdentity(req *http.Request) (ClientIdentity, bool) {
+	if req.TLS == nil || len(req.TLS.VerifiedChains) == 0 || len(req.TLS.VerifiedChains[0]) == 0 {
+		return ClientIdentity{}, false
+	}
+	cert := req.TLS.VerifiedChains[0][0]
+	id := ClientIdentity{
+		CommonName:   cert.Subject.CommonName,
+		Organization: cert.Subject.Organization,
+		DNSNames:     cert.DNSNames,
+		Emails:       cert.EmailAddresses,
+		SerialNumber: cert.SerialNumber.String(),
+		Certificate:  cert,
+	}
+	for _, uri := range cert.URIs {
+		id.URIs = append(id.URIs, uri.String())
+	}
+	return id, true
+}
+
+// RequireClientCert returns middleware rejecting requests without a verified client certificate
+// with 401, and, when allowed names are given, those whose common name, DNS or URI SANs match none
+// of them with 403. The identity is then available from ClientCert:
+//
+//	internal := app.TypedGroup("/internal", fluxo.RequireClientCert("billing", "spiffe://prod/orders"))
+//
+// The server must ask for client certificates, see MutualTLSConfig.
+func RequireClientCert(allowed ...string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id, ok := clientIdentity(ctx.Request)
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "A verified client certificate is required"})
+			return
+		}
+		if len(allowed) > 0 && !slices.ContainsFunc(id.Names(), func(name string) bool { return name != "" && slices.Contains(allowed, name) }) {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": fmt.Sprintf("Client certificate %q is not allowed", id.CommonName)})
+			return
+		}
+		ClientIdentityKey.Set(ctx, id)
+	}
+}
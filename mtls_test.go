@@ -0,0 +1,243 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newTestCA(t *testing.T) testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	return testCA{cert: cert, key: key}
+}
+
+// issue signs a certificate for cn, used for servers and clients alike
+func (ca testCA) issue(t *testing.T, cn string, uris ...string) tls.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: cn, Organization: []string{"fluxo"}},
+		DNSNames:     []string{cn + ".internal"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	for _, raw := range uris {
+		u, _ := url.Parse(raw)
+		tmpl.URIs = append(tmpl.URIs, u)
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf, _ := x509.ParseCertificate(der)
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: leaf}
+}
+
+// verifiedRequest returns a request as if it came with the verified certificate cert
+func verifiedRequest(cert tls.Certificate) *http.Request {
+	req := httptest.NewRequest("GET", "/internal", nil)
+	req.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert.Leaf}}}
+	return req
+}
+
+func TestRequireClientCert(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ca := newTestCA(t)
+	app := New()
+	app.GET("/internal", RequireClientCert("billing", "spiffe://prod/orders"), func(ctx *gin.Context) {
+		id, _ := ClientCert(ctx)
+		ctx.JSON(http.StatusOK, gin.H{"cn": id.CommonName})
+	})
+
+	tests := []struct {
+		name string
+		req  *http.Request
+		want int
+	}{
+		{"no TLS", httptest.NewRequest("GET", "/internal", nil), http.StatusUnauthorized},
+		{"unverified", func() *http.Request {
+			req := httptest.NewRequest("GET", "/internal", nil)
+			req.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{ca.issue(t, "billing").Leaf}}
+			return req
+		}(), http.StatusUnauthorized},
+		{"allowed CN", verifiedRequest(ca.issue(t, "billing")), http.StatusOK},
+		{"allowed URI SAN", verifiedRequest(ca.issue(t, "orders", "spiffe://prod/orders")), http.StatusOK},
+		{"not allowed", verifiedRequest(ca.issue(t, "reports")), http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			app.ServeHTTP(w, tt.req)
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d: %s", w.Code, tt.want, w.Body)
+			}
+		})
+	}
+}
+
+func TestClientCert_HandleCtx(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ca := newTestCA(t)
+	app := New()
+	app.GET("/internal", RequireClientCert(), HandleCtx(func(ctx context.Context, _ struct{}) (ClientIdentity, error) {
+		id, ok := ClientCert(ctx)
+		if !ok {
+			t.Error("ClientCert found no identity")
+		}
+		return id, nil
+	}))
+
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, verifiedRequest(ca.issue(t, "orders", "spiffe://prod/orders")))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body)
+	}
+	for _, want := range []string{`"CommonName":"orders"`, `"orders.internal"`, `"spiffe://prod/orders"`, `"fluxo"`} {
+		if !strings.Contains(w.Body.String(), want) {
+			t.Errorf("body %s lacks %s", w.Body, want)
+		}
+	}
+}
+
+func TestClientCert_NoCertificate(t *testing.T) {
+	if _, ok := ClientCert(context.Background()); ok {
+		t.Error("ClientCert found an identity in a plain context")
+	}
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = httptest.NewRequest("GET", "/", nil)
+	if _, ok := ClientCert(ctx); ok {
+		t.Error("ClientCert found an identity without TLS")
+	}
+}
+
+func TestApp_StartTLS_MutualTLS(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ca := newTestCA(t)
+	dir := t.TempDir()
+	writePEM := func(name, kind string, der []byte) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: kind, Bytes: der}), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	server := ca.issue(t, "localhost")
+	keyDER, _ := x509.MarshalECPrivateKey(server.PrivateKey.(*ecdsa.PrivateKey))
+	config, err := MutualTLSConfig(
+		writePEM("server.crt", "CERTIFICATE", server.Certificate[0]),
+		writePEM("server.key", "EC PRIVATE KEY", keyDER),
+		writePEM("ca.crt", "CERTIFICATE", ca.cert.Raw),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := listener.Addr().String()
+	_ = listener.Close()
+
+	app := New()
+	app.GET("/whoami", RequireClientCert("billing"), func(ctx *gin.Context) {
+		id, _ := ClientCert(ctx)
+		ctx.String(http.StatusOK, id.CommonName)
+	})
+	done := make(chan error, 1)
+	go func() { done <- app.StartTLS(addr, config) }()
+	defer func() {
+		_ = app.Shutdown(context.Background())
+		if err := <-done; err != nil {
+			t.Errorf("StartTLS: %v", err)
+		}
+	}()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	client := func(certs ...tls.Certificate) *http.Client {
+		return &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: roots, Certificates: certs}}}
+	}
+	var res *http.Response
+	for i := 0; i < 50; i++ {
+		if res, err = client(ca.issue(t, "billing")).Get("https://" + addr + "/whoami"); err == nil {
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		t.Errorf("status = %d, want 200", res.StatusCode)
+	}
+
+	if res, err := client().Get("https://" + addr + "/whoami"); err == nil {
+		_ = res.Body.Close()
+		t.Error("the server accepted a client without a certificate")
+	}
+	res, err = client(ca.issue(t, "reports")).Get("https://" + addr + "/whoami")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = res.Body.Close()
+	if res.StatusCode != http.StatusForbidden {
+		t.Errorf("status = %d, want 403", res.StatusCode)
+	}
+}
+
+func TestMutualTLSConfig_Errors(t *testing.T) {
+	if _, err := MutualTLSConfig("missing.crt", "missing.key", "missing-ca.crt"); err == nil {
+		t.Error("MutualTLSConfig accepted missing files")
+	}
+}
@@ -0,0 +1,195 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PathParam is a parameter in a route path; paths use gin syntax (:id, *filepath)
+type PathParam struct {
+	Name     string
+	Wildcard bool
+}
+
+// Backend is a router that a Mux registers its routes on
+type Backend interface {
+	http.Handler
+	// Handle registers h for method and path, given in gin syntax
+	Handle(method, path string, h http.Handler)
+	// Param returns the value of a path parameter for a request routed by this backend
+	Param(r *http.Request, p PathParam) string
+}
+
+// Mux registers fluxo handlers on a non-gin router, keeping typed binding, validation and
+// OpenAPI generation. Its routes are registered on an App, and the backend's routes hand the
+// path parameters they matched to it, so the App's middleware, stats, validator and error
+// handling apply as usual and the mux can be mounted under any prefix.
+//
+//	mux := fluxo.NewMux(fluxo.ServeMux(http.NewServeMux())).WithSwagger("API", "1.0.0")
+//	mux.GET("/todos/:id", fluxo.Handle(getTodo))
+//	http.ListenAndServe(":8080", mux)
+type Mux struct {
+	backend    Backend
+	app        *App
+	middleware []func(http.Handler) http.Handler
+}
+
+// NewMux creates a Mux on top of backend, running its routes on an App created with New(opts...)
+func NewMux(backend Backend, opts ...AppOption) *Mux {
+	m := &Mux{
+		backend: backend,
+		app:     New(opts...),
+	}
+	// Route paths are rebuilt from escaped parameter values, see routeHandler
+	m.app.router.UseRawPath = true
+	m.app.OnRouteRegistered(func(r Route) error {
+		var h http.Handler = m.routeHandler(r.Path)
+		for i := len(m.middleware) - 1; i >= 0; i-- {
+			h = m.middleware[i](h)
+		}
+		m.backend.Handle(r.Method, r.Path, h)
+		return nil
+	})
+	return m
+}
+
+// routeHandler serves the requests the backend routed to path on the app. The app is given path
+// filled in with the parameters the backend matched, rather than the request's own path, which
+// may carry the prefix the mux is mounted under.
+func (m *Mux) routeHandler(path string) http.Handler {
+	segments := strings.Split(path, "/")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		filled := slices.Clone(segments)
+		for i, seg := range filled {
+			if seg == "" || (seg[0] != ':' && seg[0] != '*') {
+				continue
+			}
+			p := PathParam{Name: seg[1:], Wildcard: seg[0] == '*'}
+			filled[i] = escapePathParam(m.backend.Param(r, p), p.Wildcard)
+		}
+		rawPath := strings.Join(filled, "/")
+		unescaped, err := url.PathUnescape(rawPath)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		r2 := new(http.Request)
+		*r2 = *r
+		r2.URL = new(url.URL)
+		*r2.URL = *r.URL
+		r2.URL.Path, r2.URL.RawPath = unescaped, rawPath
+		m.app.ServeHTTP(w, r2)
+	})
+}
+
+// App returns the app the mux's routes run on, to add middleware, hooks or validation rules
+func (m *Mux) App() *App {
+	return m.app
+}
+
+// Use adds net/http middleware to routes registered after the call
+func (m *Mux) Use(middleware ...func(http.Handler) http.Handler) {
+	m.middleware = append(m.middleware, middleware...)
+}
+
+func (m *Mux) GET(path string, handlers ...gin.HandlerFunc) {
+	m.app.GET(path, handlers...)
+}
+
+func (m *Mux) POST(path string, handlers ...gin.HandlerFunc) {
+	m.app.POST(path, handlers...)
+}
+
+func (m *Mux) PUT(path string, handlers ...gin.HandlerFunc) {
+	m.app.PUT(path, handlers...)
+}
+
+func (m *Mux) DELETE(path string, handlers ...gin.HandlerFunc) {
+	m.app.DELETE(path, handlers...)
+}
+
+func (m *Mux) PATCH(path string, handlers ...gin.HandlerFunc) {
+	m.app.PATCH(path, handlers...)
+}
+
+func (m *Mux) HEAD(path string, handlers ...gin.HandlerFunc) {
+	m.app.HEAD(path, handlers...)
+}
+
+func (m *Mux) OPTIONS(path string, handlers ...gin.HandlerFunc) {
+	m.app.OPTIONS(path, handlers...)
+}
+
+// Handle registers a chain of fluxo handlers (typed middleware followed by the handler)
+func (m *Mux) Handle(method, path string, handlers ...gin.HandlerFunc) {
+	switch method {
+	case http.MethodGet:
+		m.GET(path, handlers...)
+	case http.MethodPost:
+		m.POST(path, handlers...)
+	case http.MethodPut:
+		m.PUT(path, handlers...)
+	case http.MethodDelete:
+		m.DELETE(path, handlers...)
+	case http.MethodPatch:
+		m.PATCH(path, handlers...)
+	case http.MethodHead:
+		m.HEAD(path, handlers...)
+	case http.MethodOptions:
+		m.OPTIONS(path, handlers...)
+	default:
+		panic("fluxo: unsupported mux method " + method)
+	}
+}
+
+// WithSwagger serves the spec at /openapi.json and the UI at /docs
+func (m *Mux) WithSwagger(title, version string, opts ...SwaggerOption) *Mux {
+	m.app.WithSwagger(title, version, opts...)
+	return m
+}
+
+func (m *Mux) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.backend.ServeHTTP(w, r)
+}
+
+type serveMuxBackend struct {
+	mux *http.ServeMux
+}
+
+// ServeMux adapts a net/http ServeMux (Go 1.22 patterns) as a Mux backend
+func ServeMux(mux *http.ServeMux) Backend {
+	return serveMuxBackend{mux: mux}
+}
+
+func (b serveMuxBackend) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	b.mux.ServeHTTP(w, r)
+}
+
+func (b serveMuxBackend) Handle(method, path string, h http.Handler) {
+	b.mux.Handle(method+" "+ServeMuxPattern(path), h)
+}
+
+func (b serveMuxBackend) Param(r *http.Request, p PathParam) string {
+	return r.PathValue(p.Name)
+}
+
+// ServeMuxPattern converts a gin path (/files/:id/*rest) to a ServeMux pattern (/files/{id}/{rest...})
+func ServeMuxPattern(path string) string {
+	parts := strings.Split(path, "/")
+	for i, part := range parts {
+		switch {
+		case strings.HasPrefix(part, ":"):
+			parts[i] = "{" + part[1:] + "}"
+		case strings.HasPrefix(part, "*"):
+			parts[i] = "{" + part[1:] + "...}"
+		}
+	}
+	return strings.Join(parts, "/")
+}
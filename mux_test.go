@@ -0,0 +1,174 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type muxTodoReq struct {
+	ID    string `uri:"id" validate:"required"`
+	Title string `json:"title" validate:"required"`
+}
+
+type muxTodo struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+type muxFileReq struct {
+	Path string `uri:"path"`
+}
+
+func newTestMux() *Mux {
+	gin.SetMode(gin.TestMode)
+	mux := NewMux(ServeMux(http.NewServeMux())).WithSwagger("Mux", "1.0.0")
+	mux.PUT("/todos/:id", Handle(func(ctx *Context, req muxTodoReq) (muxTodo, error) {
+		return muxTodo{ID: req.ID, Title: req.Title}, nil
+	}))
+	mux.GET("/files/*path", Handle(func(ctx *Context, req muxFileReq) (string, error) {
+		return req.Path, nil
+	}))
+	return mux
+}
+
+func TestMux_ServeMuxBindsAndValidates(t *testing.T) {
+	mux := newTestMux()
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPut, "/todos/42", strings.NewReader(`{"title":"milk"}`))
+	req.Header.Set("Content-Type", "application/json")
+	mux.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status=%d body=%s", w.Code, w.Body.String())
+	}
+	var got muxTodo
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil || got.ID != "42" || got.Title != "milk" {
+		t.Fatalf("got %+v err=%v", got, err)
+	}
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodPut, "/todos/42", strings.NewReader(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	mux.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for invalid body, got %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/files/a/b.txt", nil))
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "/a/b.txt") {
+		t.Fatalf("wildcard: status=%d body=%s", w.Code, w.Body.String())
+	}
+}
+
+func TestMux_Swagger(t *testing.T) {
+	mux := newTestMux()
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status=%d", w.Code)
+	}
+	var spec OpenAPISpec
+	if err := json.Unmarshal(w.Body.Bytes(), &spec); err != nil {
+		t.Fatal(err)
+	}
+	if spec.Paths["/todos/{id}"].PUT == nil {
+		t.Fatalf("missing PUT /todos/:id in %v", spec.Paths)
+	}
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs", nil))
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "/openapi.json") {
+		t.Fatalf("docs: status=%d", w.Code)
+	}
+}
+
+func TestMux_Middleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mux := NewMux(ServeMux(http.NewServeMux()))
+	mux.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Mw", "1")
+			next.ServeHTTP(w, r)
+		})
+	})
+	mux.GET("/ping", func(c *gin.Context) { c.String(http.StatusOK, "pong") })
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ping", nil))
+	if w.Body.String() != "pong" || w.Header().Get("X-Mw") != "1" {
+		t.Fatalf("body=%q header=%q", w.Body.String(), w.Header().Get("X-Mw"))
+	}
+}
+
+func TestMux_RunsOnApp(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mux := NewMux(ServeMux(http.NewServeMux()))
+	var reported []error
+	mux.App().OnError(func(ctx *Context, err error) {
+		reported = append(reported, err)
+	})
+	mux.App().Use(func(c *gin.Context) {
+		c.Header("X-App", "1")
+	})
+	mux.GET("/todos/:id", Handle(func(ctx *Context, req muxFileReq) (string, error) {
+		if ctx.Param("id") == "0" {
+			return "", NotFound("no such todo")
+		}
+		return ctx.Param("id"), nil
+	}))
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/todos/0", nil))
+	if w.Code != http.StatusNotFound || w.Header().Get("X-App") != "1" {
+		t.Fatalf("status=%d header=%q", w.Code, w.Header().Get("X-App"))
+	}
+	if len(reported) != 1 {
+		t.Fatalf("expected the error to reach OnError, got %v", reported)
+	}
+	stats := mux.App().Stats()
+	if len(stats) != 1 || stats[0].Path != "/todos/:id" || stats[0].Count != 1 || !stats[0].Typed {
+		t.Fatalf("stats=%+v", stats)
+	}
+}
+
+func TestMux_EscapedParams(t *testing.T) {
+	mux := NewMux(ServeMux(http.NewServeMux()), WithMode(gin.TestMode))
+	mux.GET("/files/:name", func(c *gin.Context) { c.String(http.StatusOK, c.Param("name")) })
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/files/a%2Fb%20c", nil))
+	if w.Code != http.StatusOK || w.Body.String() != "a/b c" {
+		t.Fatalf("status=%d body=%q", w.Code, w.Body.String())
+	}
+}
+
+func TestMux_ReleaseModeByDefault(t *testing.T) {
+	defer gin.SetMode(gin.TestMode)
+	defer func(w io.Writer) { gin.DefaultWriter = w }(gin.DefaultWriter)
+	var out bytes.Buffer
+	gin.DefaultWriter = &out
+	gin.SetMode(gin.DebugMode)
+
+	mux := NewMux(ServeMux(http.NewServeMux()))
+	mux.GET("/ping", func(c *gin.Context) { c.String(http.StatusOK, "pong") })
+	if gin.Mode() != gin.ReleaseMode || out.Len() != 0 {
+		t.Fatalf("mode=%s output=%q", gin.Mode(), out.String())
+	}
+}
+
+func TestServeMuxPattern(t *testing.T) {
+	if got := ServeMuxPattern("/files/:id/*rest"); got != "/files/{id}/{rest...}" {
+		t.Fatalf("got %s", got)
+	}
+}
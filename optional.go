@@ -0,0 +1,204 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Optional distinguishes a field that was absent from the request, explicitly null, or set to a value.
+// Validation rules on an Optional field only run when a value is present, and the field is
+// documented as nullable. Use the omitzero json option to leave unset fields out of responses.
+//
+//	type UpdateTodoReq struct {
+//	    Title fluxo.Optional[string] `json:"title" validate:"min=3"`
+//	    Due   fluxo.Optional[time.Time] `json:"due"`
+//	}
+type Optional[T any] struct {
+	Value T
+	Set   bool // the field was present in the request
+	Null  bool // the field was present and null
+}
+
+// Some returns an Optional holding v
+func Some[T any](v T) Optional[T] {
+	return Optional[T]{Value: v, Set: true}
+}
+
+// Null returns an Optional that is explicitly null
+func Null[T any]() Optional[T] {
+	return Optional[T]{Set: true, Null: true}
+}
+
+// Get returns the value and whether one is present (set and not null)
+func (o Optional[T]) Get() (T, bool) {
+	return o.Value, o.Set && !o.Null
+}
+
+// OrElse returns the value when present, fallback otherwise
+func (o Optional[T]) OrElse(fallback T) T {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return fallback
+}
+
+// IsZero reports whether the field was absent, so `json:",omitzero"` skips it
+func (o Optional[T]) IsZero() bool {
+	return !o.Set
+}
+
+func (o Optional[T]) MarshalJSON() ([]byte, error) {
+	if !o.Set || o.Null {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.Value)
+}
+
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	var zero T
+	o.Value = zero
+	o.Set = true
+	o.Null = bytes.Equal(bytes.TrimSpace(data), []byte("null"))
+	if o.Null {
+		return nil
+	}
+	return json.Unmarshal(data, &o.Value)
+}
+
+// UnmarshalParam binds query, path, header and form values
+func (o *Optional[T]) UnmarshalParam(param string) error {
+	o.Set = true
+	o.Null = false
+	if s, ok := any(&o.Value).(*string); ok {
+		*s = param
+		return nil
+	}
+	if err := json.Unmarshal([]byte(param), &o.Value); err != nil {
+		// Allow unquoted values for string-like types such as time.Time
+		return json.Unmarshal([]byte(strconv.Quote(param)), &o.Value)
+	}
+	return nil
+}
+
+// optionalValue is implemented by every Optional instantiation
+type optionalValue interface {
+	optionalElem() reflect.Type
+	validationValue() interface{}
+}
+
+func (o Optional[T]) optionalElem() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+func (o Optional[T]) validationValue() interface{} {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return nil
+}
+
+var optionalValueType = reflect.TypeOf((*optionalValue)(nil)).Elem()
+
+// optionalElem returns T when t is an Optional[T]
+func optionalElem(t reflect.Type) (reflect.Type, bool) {
+	if t == nil || !t.Implements(optionalValueType) {
+		return nil, false
+	}
+	return reflect.Zero(t).Interface().(optionalValue).optionalElem(), true
+}
+
+var registeredOptionals sync.Map
+
+// registerOptionalTypes teaches the validator to look inside every Optional reachable from t.
+// It runs when handlers are built, since the validator must not be configured concurrently with validation.
+func registerOptionalTypes(t reflect.Type) {
+	registerOptionalTypesSeen(t, map[reflect.Type]bool{})
+}
+
+func registerOptionalTypesSeen(t reflect.Type, seen map[reflect.Type]bool) {
+	if t == nil || seen[t] {
+		return
+	}
+	seen[t] = true
+
+	if elem, ok := optionalElem(t); ok {
+		if _, loaded := registeredOptionals.LoadOrStore(t, true); !loaded {
+			registerOptionalType(t)
+		}
+		registerOptionalTypesSeen(elem, seen)
+		return
+	}
+
+	// Patch[T] is validated as a T once applied
+	if target, ok := patchTargetOf(t); ok && t.Kind() == reflect.Struct {
+		registerOptionalTypesSeen(target, seen)
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+		registerOptionalTypesSeen(t.Elem(), seen)
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			registerOptionalTypesSeen(t.Field(i).Type, seen)
+		}
+	}
+}
+
+// isUnsetOptional resolves a validator struct namespace such as "Req.Items[0].Title" against root
+// and reports whether it points at an Optional without a value
+func isUnsetOptional(root reflect.Value, structNamespace string) bool {
+	parts := strings.Split(structNamespace, ".")
+	if len(parts) < 2 {
+		return false
+	}
+
+	v := root
+	for _, part := range parts[1:] {
+		name, rest, _ := strings.Cut(part, "[")
+		v = reflect.Indirect(v)
+		if v.Kind() != reflect.Struct {
+			return false
+		}
+		v = v.FieldByName(name)
+		if !v.IsValid() {
+			return false
+		}
+		for rest != "" {
+			idx, after, ok := strings.Cut(rest, "]")
+			if !ok {
+				return false
+			}
+			rest = strings.TrimPrefix(after, "[")
+			v = reflect.Indirect(v)
+			switch v.Kind() {
+			case reflect.Slice, reflect.Array:
+				i, err := strconv.Atoi(idx)
+				if err != nil || i < 0 || i >= v.Len() {
+					return false
+				}
+				v = v.Index(i)
+			case reflect.Map:
+				if v.Type().Key().Kind() != reflect.String {
+					return false
+				}
+				v = v.MapIndex(reflect.ValueOf(idx).Convert(v.Type().Key()))
+				if !v.IsValid() {
+					return false
+				}
+			default:
+				return false
+			}
+		}
+	}
+
+	if ov, ok := v.Interface().(optionalValue); ok {
+		return ov.validationValue() == nil
+	}
+	return false
+}
@@ -0,0 +1,130 @@
+package fluxo
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type opItem struct {
+	Name Optional[string] `json:"name" validate:"min=2"`
+}
+
+type opUpdateReq struct {
+	Title Optional[string] `json:"title" validate:"required,min=3"`
+	Count Optional[int]    `json:"count" validate:"max=10"`
+	Items []opItem         `json:"items" validate:"dive"`
+	Limit Optional[int]    `form:"limit"`
+}
+
+type opUpdateRes struct {
+	Title      string `json:"title"`
+	TitleSet   bool   `json:"title_set"`
+	TitleNull  bool   `json:"title_null"`
+	CountValue int    `json:"count_value"`
+	LimitSet   bool   `json:"limit_set"`
+	Limit      int    `json:"limit"`
+}
+
+func TestOptional_JSON(t *testing.T) {
+	var v struct {
+		A Optional[string] `json:"a"`
+		B Optional[string] `json:"b"`
+		C Optional[string] `json:"c"`
+	}
+	if err := json.Unmarshal([]byte(`{"a":"x","b":null}`), &v); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if a, ok := v.A.Get(); !ok || a != "x" {
+		t.Fatalf("expected a to be set")
+	}
+	if !v.B.Set || !v.B.Null {
+		t.Fatalf("expected b to be null")
+	}
+	if v.C.Set || v.C.OrElse("d") != "d" {
+		t.Fatalf("expected c to be absent")
+	}
+
+	out, _ := json.Marshal(struct {
+		A Optional[int] `json:"a,omitzero"`
+		B Optional[int] `json:"b"`
+		C Optional[int] `json:"c"`
+	}{B: Null[int](), C: Some(3)})
+	if string(out) != `{"b":null,"c":3}` {
+		t.Fatalf("unexpected json %s", out)
+	}
+}
+
+func TestOptional_HandleValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := New()
+	app.PATCH("/todos", Handle(func(ctx *Context, req opUpdateReq) (opUpdateRes, error) {
+		limit, limitSet := req.Limit.Get()
+		return opUpdateRes{
+			Title:      req.Title.Value,
+			TitleSet:   req.Title.Set,
+			TitleNull:  req.Title.Null,
+			CountValue: req.Count.OrElse(-1),
+			LimitSet:   limitSet,
+			Limit:      limit,
+		}, nil
+	}))
+
+	do := func(url, body string) (int, opUpdateRes) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPatch, url, strings.NewReader(body))
+		r.Header.Set("Content-Type", "application/json")
+		app.ServeHTTP(w, r)
+		var res opUpdateRes
+		_ = json.Unmarshal(w.Body.Bytes(), &res)
+		return w.Code, res
+	}
+
+	// Absent fields skip all rules, including required
+	if code, res := do("/todos", `{"count":3}`); code != http.StatusOK || res.TitleSet || res.CountValue != 3 {
+		t.Fatalf("status=%d res=%+v", code, res)
+	}
+	if code, res := do("/todos", `{"title":null}`); code != http.StatusOK || !res.TitleNull {
+		t.Fatalf("status=%d res=%+v", code, res)
+	}
+	if code, _ := do("/todos", `{"title":"ab"}`); code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for short title, got %d", code)
+	}
+	if code, _ := do("/todos", `{"count":11}`); code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for count, got %d", code)
+	}
+	if code, _ := do("/todos", `{"items":[{},{"name":"x"}]}`); code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for nested optional, got %d", code)
+	}
+	if code, _ := do("/todos", `{"items":[{},{"name":null}]}`); code != http.StatusOK {
+		t.Fatalf("expected 200 for unset nested optionals, got %d", code)
+	}
+	if code, res := do("/todos?limit=5", `{}`); code != http.StatusOK || !res.LimitSet || res.Limit != 5 {
+		t.Fatalf("status=%d res=%+v", code, res)
+	}
+}
+
+func TestOptional_Schema(t *testing.T) {
+	sg := NewSwaggerGenerator("Test", "1.0")
+	schema := sg.generateSchema(reflect.TypeOf(opUpdateReq{}))
+	title := schema.Properties["title"]
+	if title.Type != "string" || !title.Nullable {
+		t.Fatalf("expected nullable string, got %+v", title)
+	}
+	if schema.Properties["count"].Type != "integer" {
+		t.Fatalf("expected integer count")
+	}
+}
+
+func TestOptional_SchemaNotRequired(t *testing.T) {
+	sg := NewSwaggerGenerator("Test", "1.0")
+	schema := sg.generateSchema(reflect.TypeOf(opUpdateReq{}))
+	if len(schema.Required) != 0 {
+		t.Fatalf("expected optional fields not to be required, got %v", schema.Required)
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"encoding/base64"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var (
+	// DefaultPageLimit is used when a PageRequest does not specify a limit
+	DefaultPageLimit = 20
+	// MaxPageLimit caps the limit a client can request
+	MaxPageLimit = 100
+)
+
+// PageRequest binds the standard pagination query parameters. Embed it in a request struct:
+//
+//	type ListProductsReq struct {
+//	    fluxo.PageRequest
+//	    Category string `form:"category"`
+//	}
+//
+// Pages past 1000000 are rejected, use Cursor to go further.
+type PageRequest struct {
+	Page   int    `form:"page" validate:"omitempty,min=1,max=1000000"`
+	Limit  int    `form:"limit" validate:"omitempty,min=1"`
+	Sort   string `form:"sort"`   // comma separated fields, prefix with "-" for descending
+	Cursor string `form:"cursor"` // opaque cursor from a previous Page.NextCursor
+}
+
+// SortField is a single parsed entry of PageRequest.Sort
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+// PageLimit returns the requested limit with the default and cap applied
+func (p PageRequest) PageLimit() int {
+	if p.Limit <= 0 {
+		return DefaultPageLimit
+	}
+	if MaxPageLimit > 0 && p.Limit > MaxPageLimit {
+		return MaxPageLimit
+	}
+	return p.Limit
+}
+
+// Offset returns the number of items to skip, taken from the cursor when present. It saturates at
+// math.MaxInt rather than overflow for huge pages.
+func (p PageRequest) Offset() int {
+	if offset, ok := decodeCursor(p.Cursor); ok {
+		return offset
+	}
+	page := p.Page
+	if page < 1 {
+		page = 1
+	}
+	limit := p.PageLimit()
+	if page-1 > math.MaxInt/limit {
+		return math.MaxInt
+	}
+	return (page - 1) * limit
+}
+
+// SortFields parses Sort, keeping only fields listed in allowed (all fields when allowed is empty)
+func (p PageRequest) SortFields(allowed ...string) []SortField {
+	var fields []SortField
+	for _, part := range strings.Split(p.Sort, ",") {
+		part = strings.TrimSpace(part)
+		desc := strings.HasPrefix(part, "-")
+		part = strings.TrimLeft(part, "+-")
+		if part == "" {
+			continue
+		}
+		if len(allowed) > 0 && !contains(allowed, part) {
+			continue
+		}
+		fields = append(fields, SortField{Field: part, Desc: desc})
+	}
+	return fields
+}
+
+// Page is a generic paginated response, documented as PageOf<T> in the OpenAPI spec
+type Page[T any] struct {
+	Items      []T    `json:"items"`
+	Total      int64  `json:"total"`
+	Page       int    `json:"page"`
+	Limit      int    `json:"limit"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// NewPage wraps one page of items that were fetched using req
+func NewPage[T any](items []T, total int64, req PageRequest) Page[T] {
+	if items == nil {
+		items = []T{}
+	}
+	limit := req.PageLimit()
+	offset := req.Offset()
+
+	page := Page[T]{
+		Items: items,
+		Total: total,
+		Page:  offset/limit + 1,
+		Limit: limit,
+	}
+	if next := offset + len(items); next >= offset && int64(next) < total {
+		page.NextCursor = encodeCursor(next)
+	}
+	return page
+}
+
+// PaginateSlice applies req to an in-memory slice
+func PaginateSlice[T any](items []T, req PageRequest) Page[T] {
+	offset := min(max(req.Offset(), 0), len(items))
+	end := offset + min(req.PageLimit(), len(items)-offset)
+	return NewPage(items[offset:end], int64(len(items)), req)
+}
+
+func encodeCursor(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(offset)))
+}
+
+func decodeCursor(cursor string) (int, bool) {
+	if cursor == "" {
+		return 0, false
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, false
+	}
+	offset, err := strconv.Atoi(string(raw))
+	if err != nil || offset < 0 {
+		return 0, false
+	}
+	return offset, true
+}
@@ -0,0 +1,130 @@
+package fluxo
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type pgProduct struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type pgListReq struct {
+	PageRequest
+	Category string `form:"category"`
+}
+
+func TestPageRequest_Defaults_Caps(t *testing.T) {
+	if (PageRequest{}).PageLimit() != DefaultPageLimit {
+		t.Fatalf("expected default limit")
+	}
+	if (PageRequest{Limit: MaxPageLimit + 50}).PageLimit() != MaxPageLimit {
+		t.Fatalf("expected capped limit")
+	}
+	if (PageRequest{Page: 3, Limit: 10}).Offset() != 20 {
+		t.Fatalf("expected offset 20")
+	}
+
+	sorts := PageRequest{Sort: "-created_at, name,secret"}.SortFields("created_at", "name")
+	if len(sorts) != 2 || !sorts[0].Desc || sorts[0].Field != "created_at" || sorts[1].Desc {
+		t.Fatalf("unexpected sort fields %+v", sorts)
+	}
+}
+
+func TestPaginateSlice_Cursor(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5}
+	page := PaginateSlice(items, PageRequest{Limit: 2})
+	if !reflect.DeepEqual(page.Items, []int{1, 2}) || page.Total != 5 || page.NextCursor == "" {
+		t.Fatalf("unexpected first page %+v", page)
+	}
+
+	page = PaginateSlice(items, PageRequest{Limit: 2, Cursor: page.NextCursor})
+	if !reflect.DeepEqual(page.Items, []int{3, 4}) || page.Page != 2 {
+		t.Fatalf("unexpected second page %+v", page)
+	}
+
+	page = PaginateSlice(items, PageRequest{Page: 10, Limit: 2})
+	if len(page.Items) != 0 || page.Items == nil || page.NextCursor != "" {
+		t.Fatalf("unexpected page past the end %+v", page)
+	}
+}
+
+func TestPaginateSlice_HugePage(t *testing.T) {
+	req := PageRequest{Page: 461168601842738792}
+	if req.Offset() != math.MaxInt {
+		t.Fatalf("expected the offset to saturate, got %d", req.Offset())
+	}
+	page := PaginateSlice([]int{1, 2, 3}, req)
+	if len(page.Items) != 0 || page.NextCursor != "" {
+		t.Fatalf("unexpected page %+v", page)
+	}
+}
+
+func TestPagination_HandlerAndSpec(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := New().WithSwagger("Test", "1.0.0")
+	app.GET("/products", Handle(func(ctx *Context, req pgListReq) (Page[pgProduct], error) {
+		all := []pgProduct{{1, "a"}, {2, "b"}, {3, "c"}}
+		return PaginateSlice(all, req.PageRequest), nil
+	}))
+
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/products?limit=2&page=2", nil))
+	var page Page[pgProduct]
+	if err := json.Unmarshal(w.Body.Bytes(), &page); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	if len(page.Items) != 1 || page.Items[0].ID != 3 {
+		t.Fatalf("unexpected page %+v", page)
+	}
+
+	w = httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/products?page=-1", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for page=-1, got %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/products?page=461168601842738792", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for a huge page, got %d", w.Code)
+	}
+
+	spec := app.swagger.Generate(app.handlers)
+	schemas := spec["components"].(map[string]interface{})["schemas"].(map[string]interface{})
+	if _, ok := schemas["PageOfPgProduct"]; !ok {
+		t.Fatalf("expected PageOfPgProduct schema, got %v", reflect.ValueOf(schemas).MapKeys())
+	}
+	get := spec["paths"].(map[string]interface{})["/products"].(map[string]interface{})["get"].(map[string]interface{})
+	names := map[string]bool{}
+	for _, p := range get["parameters"].([]interface{}) {
+		names[p.(map[string]interface{})["name"].(string)] = true
+	}
+	for _, n := range []string{"page", "limit", "sort", "cursor", "category"} {
+		if !names[n] {
+			t.Errorf("expected %s query parameter", n)
+		}
+	}
+}
+
+func TestSchemaName_Generics(t *testing.T) {
+	cases := map[string]string{
+		"Product":                                 "Product",
+		"Page[github.com/acme/shop.Product]":      "PageOfProduct",
+		"Pair[string,github.com/acme/shop.Item]":  "PairOfStringAndItem",
+		"Page[[]github.com/acme/shop.Product]":    "PageOfListOfProduct",
+		"Page[github.com/acme.Box[main.Product]]": "PageOfBoxOfProduct",
+	}
+	for in, want := range cases {
+		if got := typeNameFromString(in, false); got != want {
+			t.Errorf("%s: expected %s, got %s", in, want, got)
+		}
+	}
+}
@@ -0,0 +1,227 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	MIMEJSONPatch  = "application/json-patch+json"
+	MIMEMergePatch = "application/merge-patch+json"
+)
+
+// Patch holds a JSON Patch (RFC 6902) or JSON Merge Patch (RFC 7396) document for a T.
+// Add it to a PATCH request struct and apply it with ApplyPatch:
+//
+//	type PatchTodoReq struct {
+//	    ID    int               `uri:"id"`
+//	    Patch fluxo.Patch[Todo] `json:"-"`
+//	}
+//
+// Plain application/json bodies are treated as merge patches.
+type Patch[T any] struct {
+	ContentType string `json:"-"`
+	Body        []byte `json:"-"`
+	lang        string
+	validator   *Validator
+}
+
+// patchBody is implemented by *Patch[T] so the binder can fill it without knowing T
+type patchBody interface {
+	setPatch(contentType string, body []byte, lang string, v *Validator)
+	patchTarget() reflect.Type
+}
+
+func (p *Patch[T]) setPatch(contentType string, body []byte, lang string, v *Validator) {
+	p.ContentType = contentType
+	p.Body = body
+	p.lang = lang
+	p.validator = v
+}
+
+func (p *Patch[T]) patchTarget() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// ApplyPatch applies patch to target and validates the patched value.
+// target is only modified when the patch applies cleanly and the result is valid.
+func ApplyPatch[T any](target *T, patch Patch[T]) error {
+	doc, err := json.Marshal(target)
+	if err != nil {
+		return InternalServerError(fmt.Sprintf("failed to encode patch target: %v", err))
+	}
+
+	var patched []byte
+	switch patch.ContentType {
+	case MIMEJSONPatch:
+		ops, err := jsonpatch.DecodePatch(patch.Body)
+		if err != nil {
+			return BadRequest(fmt.Sprintf("invalid JSON patch: %v", err))
+		}
+		patched, err = ops.Apply(doc)
+		if errors.Is(err, jsonpatch.ErrTestFailed) {
+			return Conflict(fmt.Sprintf("JSON patch test failed: %v", err))
+		}
+		if err != nil {
+			return UnprocessableEntity(fmt.Sprintf("failed to apply JSON patch: %v", err))
+		}
+	default:
+		patched, err = jsonpatch.MergePatch(doc, patch.Body)
+		if err != nil {
+			return BadRequest(fmt.Sprintf("invalid merge patch: %v", err))
+		}
+	}
+
+	// Start from a copy so fields hidden from JSON survive, but clear the
+	// JSON-visible ones so removed members become zero values
+	result := *target
+	resetJSONFields(reflect.ValueOf(&result).Elem())
+	if err := json.Unmarshal(patched, &result); err != nil {
+		return UnprocessableEntity(fmt.Sprintf("patched document is invalid: %v", err))
+	}
+
+	if isStructType(reflect.TypeOf(result)) {
+		lang := patch.lang
+		if lang == "" {
+			lang = "en"
+		}
+		v := patch.validator
+		if v == nil {
+			v = defaultValidator
+		}
+		if err := v.check(context.Background(), lang, &result); err != nil {
+			if cause, ok := ruleCause(err); ok {
+				return cause
+			}
+			return UnprocessableEntity(err.Error())
+		}
+	}
+
+	*target = result
+	return nil
+}
+
+// resetJSONFields zeroes the exported fields of a struct that are encoded to JSON
+func resetJSONFields(v reflect.Value) {
+	if v.Kind() != reflect.Struct {
+		v.Set(reflect.Zero(v.Type()))
+		return
+	}
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		if field.Anonymous && tag == "" && field.Type.Kind() == reflect.Struct {
+			resetJSONFields(v.Field(i))
+			continue
+		}
+		v.Field(i).Set(reflect.Zero(field.Type))
+	}
+}
+
+func isStructType(t reflect.Type) bool {
+	return t != nil && (t.Kind() == reflect.Struct || (t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Struct))
+}
+
+// findPatchBody returns the Patch value inside req (or req itself), if any
+func findPatchBody(req interface{}) (patchBody, bool) {
+	if pb, ok := req.(patchBody); ok {
+		return pb, true
+	}
+	v := reflect.ValueOf(req)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return nil, false
+	}
+	v = v.Elem()
+	for i := 0; i < v.NumField(); i++ {
+		if !v.Type().Field(i).IsExported() {
+			continue
+		}
+		if pb, ok := v.Field(i).Addr().Interface().(patchBody); ok {
+			return pb, true
+		}
+	}
+	return nil, false
+}
+
+// patchTargetOf returns the patched resource type when t carries a Patch
+func patchTargetOf(t reflect.Type) (reflect.Type, bool) {
+	if t == nil {
+		return nil, false
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, false
+	}
+	if pb, ok := reflect.New(t).Interface().(patchBody); ok {
+		return pb.patchTarget(), true
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if pb, ok := reflect.New(t.Field(i).Type).Interface().(patchBody); ok {
+			return pb.patchTarget(), true
+		}
+	}
+	return nil, false
+}
+
+// bindPatch stores the raw request body in the Patch carried by req
+func bindPatch(ctx *gin.Context, req interface{}, contentType string) error {
+	pb, ok := findPatchBody(req)
+	if !ok {
+		return fmt.Errorf("request does not accept %s bodies", contentType)
+	}
+
+	var body []byte
+	if cached, exists := ctx.Get(gin.BodyBytesKey); exists {
+		body, _ = cached.([]byte)
+	} else {
+		raw, err := ctx.GetRawData()
+		if err != nil {
+			return err
+		}
+		body = raw
+		ctx.Set(gin.BodyBytesKey, body)
+	}
+
+	if contentType != MIMEJSONPatch {
+		contentType = MIMEMergePatch
+	}
+	pb.setPatch(contentType, body, requestLang(ctx), requestValidator(ctx))
+	return nil
+}
+
+// hasPatchBody reports whether req carries a Patch, used to capture plain JSON bodies as merge patches
+func hasPatchBody(req interface{}) bool {
+	_, ok := findPatchBody(req)
+	return ok
+}
+
+// jsonPatchSchema documents an RFC 6902 operation list
+var jsonPatchSchema = Schema{
+	Type: "array",
+	Items: &Schema{
+		Type:     "object",
+		Required: []string{"op", "path"},
+		Properties: map[string]Schema{
+			"op":    {Type: "string", Description: "One of add, remove, replace, move, copy, test"},
+			"path":  {Type: "string", Description: "JSON pointer to the target member"},
+			"from":  {Type: "string", Description: "JSON pointer used by move and copy"},
+			"value": {Description: "Value used by add, replace and test"},
+		},
+	},
+}
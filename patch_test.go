@@ -0,0 +1,112 @@
+package fluxo
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ptTodo struct {
+	ID     int      `json:"id"`
+	Title  string   `json:"title" validate:"required,min=3"`
+	Tags   []string `json:"tags"`
+	Secret string   `json:"-"`
+}
+
+type ptPatchReq struct {
+	ID    int           `uri:"id"`
+	Patch Patch[ptTodo] `json:"-"`
+}
+
+func TestApplyPatch_Merge(t *testing.T) {
+	todo := ptTodo{ID: 1, Title: "write", Tags: []string{"a"}, Secret: "s"}
+	err := ApplyPatch(&todo, Patch[ptTodo]{ContentType: MIMEMergePatch, Body: []byte(`{"title":"write docs","tags":null}`)})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if todo.Title != "write docs" || todo.Tags != nil || todo.ID != 1 || todo.Secret != "s" {
+		t.Fatalf("unexpected result %+v", todo)
+	}
+
+	err = ApplyPatch(&todo, Patch[ptTodo]{ContentType: MIMEMergePatch, Body: []byte(`{"title":"x"}`)})
+	if httpErr, ok := err.(HTTPError); !ok || httpErr.Status != http.StatusUnprocessableEntity {
+		t.Fatalf("expected 422, got %v", err)
+	}
+	if todo.Title != "write docs" {
+		t.Fatalf("target must not change on invalid patch")
+	}
+}
+
+func TestApplyPatch_JSONPatch(t *testing.T) {
+	todo := ptTodo{ID: 1, Title: "write", Tags: []string{"a"}}
+	body := `[{"op":"test","path":"/title","value":"write"},{"op":"add","path":"/tags/-","value":"b"},{"op":"replace","path":"/title","value":"read"}]`
+	if err := ApplyPatch(&todo, Patch[ptTodo]{ContentType: MIMEJSONPatch, Body: []byte(body)}); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if todo.Title != "read" || len(todo.Tags) != 2 {
+		t.Fatalf("unexpected result %+v", todo)
+	}
+
+	err := ApplyPatch(&todo, Patch[ptTodo]{ContentType: MIMEJSONPatch, Body: []byte(`[{"op":"test","path":"/title","value":"nope"}]`)})
+	if httpErr, ok := err.(HTTPError); !ok || httpErr.Status != http.StatusConflict {
+		t.Fatalf("expected 409, got %v", err)
+	}
+
+	err = ApplyPatch(&todo, Patch[ptTodo]{ContentType: MIMEJSONPatch, Body: []byte(`{"op":"add"}`)})
+	if httpErr, ok := err.(HTTPError); !ok || httpErr.Status != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %v", err)
+	}
+}
+
+func TestPatch_Binding(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := New().WithSwagger("Test", "1.0.0")
+	app.PATCH("/todos/:id", Handle(func(ctx *Context, req ptPatchReq) (ptTodo, error) {
+		todo := ptTodo{ID: req.ID, Title: "write"}
+		if err := ApplyPatch(&todo, req.Patch); err != nil {
+			return ptTodo{}, err
+		}
+		return todo, nil
+	}))
+
+	cases := []struct {
+		ct, body string
+		status   int
+		title    string
+	}{
+		{MIMEMergePatch, `{"title":"merged"}`, http.StatusOK, "merged"},
+		{MIMEJSONPatch, `[{"op":"replace","path":"/title","value":"patched"}]`, http.StatusOK, "patched"},
+		{"application/json", `{"title":"plain"}`, http.StatusOK, "plain"},
+		{MIMEMergePatch, `{"title":""}`, http.StatusUnprocessableEntity, ""},
+	}
+	for _, tc := range cases {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPatch, "/todos/7", strings.NewReader(tc.body))
+		r.Header.Set("Content-Type", tc.ct)
+		app.ServeHTTP(w, r)
+		if w.Code != tc.status {
+			t.Fatalf("%s: status=%d body=%s", tc.ct, w.Code, w.Body.String())
+		}
+		if tc.status != http.StatusOK {
+			continue
+		}
+		var todo ptTodo
+		_ = json.Unmarshal(w.Body.Bytes(), &todo)
+		if todo.Title != tc.title || todo.ID != 7 {
+			t.Fatalf("%s: unexpected todo %+v", tc.ct, todo)
+		}
+	}
+
+	spec := app.swagger.Generate(app.handlers)
+	op := spec["paths"].(map[string]interface{})["/todos/{id}"].(map[string]interface{})["patch"].(map[string]interface{})
+	content := op["requestBody"].(map[string]interface{})["content"].(map[string]interface{})
+	for _, ct := range []string{MIMEMergePatch, MIMEJSONPatch, "application/json"} {
+		if _, ok := content[ct]; !ok {
+			t.Errorf("expected %s request body", ct)
+		}
+	}
+}
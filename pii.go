@@ -0,0 +1,138 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Fields tagged `pii:"true"` hold personal data, such as emails, phone numbers or card numbers:
+//
+//	type Signup struct {
+//		Email string `json:"email" validate:"required,email" pii:"true"`
+//		Plan  string `json:"plan"`
+//	}
+//
+// Their values are replaced with Redacted wherever fluxo shows data: in the requests and
+// responses of a Recorder, in generated examples and in values logged through Masked. The spec
+// marks them with x-pii.
+
+// isPII reports whether field is tagged as personal data
+func isPII(field reflect.StructField) bool {
+	pii, _ := strconv.ParseBool(field.Tag.Get("pii"))
+	return pii
+}
+
+// piiNames are the names personal data goes by in the requests and responses of a type
+type piiNames struct {
+	fields  map[string]bool // JSON, form and path names, lowercase
+	headers []string        // canonical header names
+}
+
+var piiCache sync.Map // reflect.Type -> *piiNames
+
+// piiOf returns the names of the fields of t tagged as personal data, nested types included
+func piiOf(t reflect.Type) *piiNames {
+	if v, ok := piiCache.Load(t); ok {
+		return v.(*piiNames)
+	}
+	names := &piiNames{fields: map[string]bool{}}
+	names.collect(t, map[reflect.Type]bool{})
+	v, _ := piiCache.LoadOrStore(t, names)
+	return v.(*piiNames)
+}
+
+func (p *piiNames) collect(t reflect.Type, seen map[reflect.Type]bool) {
+	for t != nil && (t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map) {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct || seen[t] {
+		return
+	}
+	seen[t] = true
+	for _, field := range reflect.VisibleFields(t) {
+		if !field.IsExported() {
+			continue
+		}
+		if isPII(field) {
+			if field.Tag.Get("json") == "" {
+				p.fields[strings.ToLower(field.Name)] = true
+			}
+			for _, key := range []string{"json", "form", "uri"} {
+				if name := tagName(field, key); name != "" {
+					p.fields[strings.ToLower(name)] = true
+				}
+			}
+			if name := tagName(field, "header"); name != "" {
+				p.headers = append(p.headers, http.CanonicalHeaderKey(name))
+			}
+			continue
+		}
+		p.collect(field.Type, seen)
+	}
+}
+
+// routePII returns the names of the personal data of the request and response types of the
+// route serving ctx
+func routePII(ctx *gin.Context) *piiNames {
+	names := &piiNames{fields: map[string]bool{}}
+	a, ok := appFrom(ctx)
+	if !ok {
+		return names
+	}
+	info, ok := a.handlers[ctx.Request.Method+":"+ctx.FullPath()]
+	if !ok {
+		return names
+	}
+	for _, t := range append(append([]reflect.Type(nil), info.reqTypes...), info.resType) {
+		if t == nil {
+			continue
+		}
+		p := piiOf(t)
+		for name := range p.fields {
+			names.fields[name] = true
+		}
+		names.headers = append(names.headers, p.headers...)
+	}
+	return names
+}
+
+// Masked wraps v, a struct or a pointer to one, for logging with slog, replacing the values of
+// its personal data with Redacted:
+//
+//	logger.Info("signup", "request", fluxo.Masked(req))
+func Masked(v any) slog.LogValuer {
+	return maskedValue{v: v}
+}
+
+type maskedValue struct {
+	v any
+}
+
+func (m maskedValue) LogValue() slog.Value {
+	if m.v == nil {
+		return slog.AnyValue(nil)
+	}
+	data, err := json.Marshal(m.v)
+	if err != nil {
+		return slog.StringValue(Redacted)
+	}
+	var decoded any
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&decoded); err != nil {
+		return slog.StringValue(Redacted)
+	}
+	pii := piiOf(reflect.TypeOf(m.v)).fields
+	redactFields(decoded, func(name string) bool { return pii[strings.ToLower(name)] })
+	return slog.AnyValue(decoded)
+}
@@ -0,0 +1,95 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type piiAddress struct {
+	Street string `json:"street" pii:"true"`
+	City   string `json:"city"`
+}
+
+type piiSignupReq struct {
+	Contact string     `json:"contact" validate:"required,email" pii:"true"`
+	Phone   string     `header:"X-Phone" pii:"true"`
+	Ref     string     `form:"ref" pii:"true"`
+	Plan    string     `json:"plan"`
+	Address piiAddress `json:"address"`
+}
+
+type piiAccount struct {
+	ID   int    `json:"id"`
+	Card string `json:"card" pii:"true"`
+}
+
+func TestPII_Recorder(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := New()
+	rec := NewRecorder()
+	app.Use(rec.Middleware())
+	app.POST("/signups", Handle(func(ctx *Context, req piiSignupReq) (piiAccount, error) {
+		if req.Contact != "ann@example.com" || req.Phone != "+31612345678" {
+			t.Errorf("the handler got a masked request: %+v", req)
+		}
+		return piiAccount{ID: 1, Card: "4111111111111111"}, nil
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/signups?ref=friend-ann&utm=mail", strings.NewReader(`{"contact":"ann@example.com","plan":"pro","address":{"street":"Main St 1","city":"Utrecht"}}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Phone", "+31612345678")
+	app.ServeHTTP(httptest.NewRecorder(), req)
+
+	got := rec.Recordings(http.MethodPost, "/signups")[0]
+	if got.Body != `{"address":{"city":"Utrecht","street":"[REDACTED]"},"contact":"[REDACTED]","plan":"pro"}` {
+		t.Errorf("expected the personal data of the request masked, got %s", got.Body)
+	}
+	if got.Header.Get("X-Phone") != Redacted || !strings.Contains(got.URL, "ref=%5BREDACTED%5D") || !strings.Contains(got.URL, "utm=mail") {
+		t.Errorf("expected the phone header and ref parameter masked, got %v %s", got.Header, got.URL)
+	}
+	if got.Response != `{"card":"[REDACTED]","id":1}` {
+		t.Errorf("expected the card masked in the response, got %s", got.Response)
+	}
+}
+
+func TestPII_Masked(t *testing.T) {
+	var out bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&out, nil))
+	logger.Info("signup", "request", Masked(&piiSignupReq{Contact: "ann@example.com", Plan: "pro", Address: piiAddress{Street: "Main St 1", City: "Utrecht"}}))
+
+	line := out.String()
+	if strings.Contains(line, "ann@example.com") || strings.Contains(line, "Main St") {
+		t.Fatalf("personal data was logged: %s", line)
+	}
+	if !strings.Contains(line, `"contact":"[REDACTED]"`) || !strings.Contains(line, `"plan":"pro"`) || !strings.Contains(line, `"city":"Utrecht"`) {
+		t.Errorf("expected the other fields logged, got %s", line)
+	}
+}
+
+func TestPII_Documented(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := New().WithSwagger("t", "v", WithGeneratedExamples())
+	app.POST("/signups", Handle(func(ctx *Context, req piiSignupReq) (piiAccount, error) { return piiAccount{}, nil }))
+
+	spec := groupSpec(t, app)
+	contact := spec.Components.Schemas["piiSignupReq"].Properties["contact"]
+	if !contact.PII || contact.Example != Redacted {
+		t.Errorf("expected the contact documented as masked personal data, got %+v", contact)
+	}
+	if plan := spec.Components.Schemas["piiSignupReq"].Properties["plan"]; plan.PII || plan.Example == Redacted {
+		t.Errorf("the plan isn't personal data, got %+v", plan)
+	}
+	for _, p := range spec.Paths["/signups"].POST.Parameters {
+		if p.Name == "X-Phone" && (!p.Schema.PII || p.Schema.Example != Redacted) {
+			t.Errorf("expected the phone header masked, got %+v", p.Schema)
+		}
+	}
+}
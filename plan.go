@@ -0,0 +1,747 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/textproto"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/gin-gonic/gin/codec/json"
+)
+
+// bindingPlan is what binding and validating a request type needs, computed once per type.
+// The query, uri and header binders follow gin's form mapping rules (field name fallback,
+// default= and collection_format options, UnmarshalParam, time_format, ...) with tags parsed,
+// header keys canonicalized and converters chosen up front. Types using something the plan
+// does not compile (an unknown collection_format, recursive types, ...) are bound by gin instead.
+// Unlike gin, a source is skipped when no field of the type is tagged for it, so a plain JSON
+// type is not filled from the query string by field name, and the fields of a struct tagged
+// for the query are bound from keys nested under its name, ?filter[status]=done or
+// ?filter.status=done, rather than from the top level.
+type bindingPlan struct {
+	query  bindPass
+	uri    bindPass
+	header bindPass
+
+	compiled    bool // the binders above are usable
+	bindingTags bool // gin's validator has `binding` rules to check after each pass
+	validates   bool // the type is a struct (or pointer to one) checked by validateStruct
+	patch       bool // the type carries a Patch
+	uploads     map[string]reflect.StructField
+	meta        []metaField // fields tagged with fluxo, see bindMeta
+	body        []string    // content types of the body, nil when nothing is read from it
+}
+
+// bindPass is the compiled binder of one source
+type bindPass struct {
+	node     bindNode
+	bind     func(obj any, src map[string][]string) error // generated binder, used instead of node
+	tagged   bool // some field is tagged for the source
+	defaults bool // some field has a default= value, which applies even to an empty source
+	nested   bool // some keys are nested, see nestedKeys
+	keys     map[string]bool // keys the pass reads, aliases included
+	prefixes []string        // of the keys of nested maps, which may be anything below them
+}
+
+// reads reports whether the pass reads key
+func (b bindPass) reads(key string) bool {
+	if b.keys[key] {
+		return true
+	}
+	for _, prefix := range b.prefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// skip reports whether the pass cannot set anything
+func (b bindPass) skip(empty bool) bool {
+	return !b.tagged || (empty && !b.defaults)
+}
+
+var bindingPlans sync.Map // reflect.Type -> *bindingPlan
+
+// planFor returns the cached binding plan of t
+func planFor(t reflect.Type) *bindingPlan {
+	if t == nil {
+		return &bindingPlan{}
+	}
+	if p, ok := bindingPlans.Load(t); ok {
+		return p.(*bindingPlan)
+	}
+
+	p := &bindingPlan{
+		validates:   t.Kind() == reflect.Struct || (t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Struct),
+		patch:       hasPatchBody(reflect.New(t).Interface()),
+		uploads:     uploadFields(t),
+		bindingTags: hasBindingTags(t, map[reflect.Type]bool{}),
+		meta:        metaFields(t),
+	}
+	p.body = bodyTypes(t, p.patch)
+	if g, ok := lookupGenerated(t); ok && (g.Query != nil || g.URI != nil || g.Header != nil) {
+		p.query, p.uri, p.header = generatedPass(g.Query), generatedPass(g.URI), generatedPass(g.Header)
+		p.compiled = true
+		// The keys the generated binder reads, for StrictQuery
+		if q, err := compileBinder(t, "form", false, true); err == nil {
+			p.query.keys, p.query.prefixes = q.keys, q.prefixes
+		}
+		actual, _ := bindingPlans.LoadOrStore(t, p)
+		return actual.(*bindingPlan)
+	}
+	var errQ, errU, errH error
+	p.query, errQ = compileBinder(t, "form", false, true)
+	p.uri, errU = compileBinder(t, "uri", false, false)
+	p.header, errH = compileBinder(t, "header", true, false)
+	// gin binds maps directly from the source rather than field by field
+	p.compiled = errQ == nil && errU == nil && errH == nil && t.Kind() != reflect.Map
+
+	actual, _ := bindingPlans.LoadOrStore(t, p)
+	return actual.(*bindingPlan)
+}
+
+// bindQuery binds the query string into obj, a pointer to the planned type
+func (p *bindingPlan) bindQuery(ctx *gin.Context, obj any) error {
+	if !p.compiled {
+		return ctx.ShouldBindQuery(obj)
+	}
+	if p.query.skip(ctx.Request.URL.RawQuery == "") {
+		return p.check(ctx, obj)
+	}
+	src := ctx.Request.URL.Query()
+	if p.query.nested {
+		src = nestedKeys(src)
+	}
+	return p.bind(ctx, p.query, obj, src)
+}
+
+// nestedKeys spells the bracketed keys of a query with dots, see nestedKey
+func nestedKeys(src map[string][]string) map[string][]string {
+	var out map[string][]string
+	for key, values := range src {
+		if !strings.Contains(key, "[") {
+			continue
+		}
+		if out == nil {
+			out = make(map[string][]string, len(src))
+			for k, v := range src {
+				if !strings.Contains(k, "[") {
+					out[k] = v
+				}
+			}
+		}
+		key = nestedKey(key)
+		out[key] = append(out[key], values...)
+	}
+	if out == nil {
+		return src
+	}
+	return out
+}
+
+var bracketReplacer = strings.NewReplacer("][", ".", "[", ".", "]", "")
+
+// nestedKey spells a bracketed query key with dots, filter[status] as filter.status, and drops
+// the empty brackets of lists, tags[] being tags
+func nestedKey(key string) string {
+	if !strings.Contains(key, "[") {
+		return key
+	}
+	return bracketReplacer.Replace(strings.TrimSuffix(key, "[]"))
+}
+
+// bindURI binds path parameters into obj
+func (p *bindingPlan) bindURI(ctx *gin.Context, obj any) error {
+	if !p.compiled {
+		return ctx.ShouldBindUri(obj)
+	}
+	if p.uri.skip(len(ctx.Params) == 0) {
+		return p.check(ctx, obj)
+	}
+	params := make(map[string][]string, len(ctx.Params))
+	for _, param := range ctx.Params {
+		params[param.Key] = []string{param.Value}
+	}
+	return p.bind(ctx, p.uri, obj, params)
+}
+
+// bindHeader binds request headers into obj
+func (p *bindingPlan) bindHeader(ctx *gin.Context, obj any) error {
+	if !p.compiled {
+		return ctx.ShouldBindHeader(obj)
+	}
+	if p.header.skip(len(ctx.Request.Header) == 0) {
+		return p.check(ctx, obj)
+	}
+	return p.bind(ctx, p.header, obj, ctx.Request.Header)
+}
+
+func (p *bindingPlan) bind(ctx *gin.Context, pass bindPass, obj any, src map[string][]string) error {
+	if pass.bind != nil {
+		if err := pass.bind(obj, src); err != nil {
+			return err
+		}
+	} else if pass.node != nil {
+		if _, err := pass.node.set(reflect.ValueOf(obj), src); err != nil {
+			return err
+		}
+	}
+	return p.check(ctx, obj)
+}
+
+// check runs gin's validator, which like gin's bindings checks `binding` rules after every pass,
+// unless the request's validator checks them. Types bound by gin are checked by it regardless.
+func (p *bindingPlan) check(ctx *gin.Context, obj any) error {
+	if p.bindingTags && binding.Validator != nil && !checksBindingTags(ctx) {
+		return binding.Validator.ValidateStruct(obj)
+	}
+	return nil
+}
+
+// hasBindingTags reports whether t or a type it contains has `binding` rules
+func hasBindingTags(t reflect.Type, seen map[reflect.Type]bool) bool {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || seen[t] {
+		return false
+	}
+	seen[t] = true
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Tag.Get("binding") != "" || hasBindingTags(field.Type, seen) {
+			return true
+		}
+	}
+	return false
+}
+
+// bindNode sets a value from a form-like source, reporting whether anything was set
+type bindNode interface {
+	set(v reflect.Value, src map[string][]string) (bool, error)
+}
+
+var errNotCompiled = errors.New("type is bound by gin")
+
+// compileBinder builds the binder of t for tag, mirroring gin's mapping of a pointer to t. For
+// the query, fields may have other names listed in an alias tag, and the fields of structs and
+// the entries of maps in a field tagged for the query are bound from keys prefixed with its
+// name, filter.status for a Status field of Filter.
+func compileBinder(t reflect.Type, tag string, canonicalKeys, query bool) (bindPass, error) {
+	c := binderCompiler{tag: tag, canonicalKeys: canonicalKeys, query: query, stack: map[reflect.Type]bool{}, keys: map[string]bool{}}
+	node, err := c.compile(reflect.PointerTo(t), reflect.StructField{})
+	return bindPass{node: node, tagged: c.tagged, defaults: c.defaults, nested: c.nestedKeys, keys: c.keys, prefixes: c.prefixes}, err
+}
+
+type binderCompiler struct {
+	tag           string
+	canonicalKeys bool
+	query         bool
+	prefix        string                // of the keys of the fields being compiled, see compileBinder
+	stack         map[reflect.Type]bool // struct types being compiled, to refuse recursive types
+	tagged        bool
+	defaults      bool
+	nestedKeys    bool
+	keys          map[string]bool
+	prefixes      []string
+}
+
+// nestedName returns the name a struct or map field's contents are prefixed with, if nested
+func (c *binderCompiler) nestedName(field reflect.StructField) (string, bool) {
+	if !c.query || field.Anonymous || field.Name == "" {
+		return "", false
+	}
+	name := tagName(field, c.tag)
+	return name, name != ""
+}
+
+func (c *binderCompiler) compile(t reflect.Type, field reflect.StructField) (bindNode, error) {
+	if field.Tag.Get(c.tag) == "-" {
+		return nil, nil
+	}
+
+	if t.Kind() == reflect.Ptr {
+		elem, err := c.compile(t.Elem(), field)
+		if err != nil || elem == nil {
+			return nil, err
+		}
+		return &ptrNode{elem: elem, typ: t.Elem()}, nil
+	}
+
+	var leaf *leafNode
+	if t.Kind() != reflect.Struct || !field.Anonymous {
+		var err error
+		if leaf, err = c.leaf(t, field); err != nil {
+			return nil, err
+		}
+	}
+	if t.Kind() == reflect.Map && leaf != nil && !leaf.custom {
+		if name, ok := c.nestedName(field); ok && t.Key().Kind() == reflect.String {
+			if conv, err := converterFor(t.Elem(), field); err == nil && scalarKind(t.Elem().Kind()) {
+				c.nestedKeys = true
+				c.prefixes = append(c.prefixes, c.prefix+name+".")
+				return &mapNode{leaf: leaf, prefix: c.prefix + name + ".", conv: conv}, nil
+			}
+		}
+	}
+	if t.Kind() != reflect.Struct {
+		if leaf == nil {
+			return nil, nil
+		}
+		return leaf, nil
+	}
+
+	if c.stack[t] {
+		return nil, errNotCompiled
+	}
+	c.stack[t] = true
+	defer delete(c.stack, t)
+
+	if name, ok := c.nestedName(field); ok && t != timeType && !leaf.custom {
+		prefix := c.prefix
+		c.prefix, c.nestedKeys = prefix+name+".", true
+		defer func() { c.prefix = prefix }()
+	}
+
+	node := &structNode{leaf: leaf}
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.PkgPath != "" && !sf.Anonymous { // unexported
+			continue
+		}
+		child, err := c.compile(sf.Type, sf)
+		if err != nil {
+			return nil, err
+		}
+		if child != nil {
+			node.fields = append(node.fields, structField{index: i, node: child})
+		}
+	}
+	if node.leaf == nil && len(node.fields) == 0 {
+		return nil, nil
+	}
+	return node, nil
+}
+
+// leaf compiles gin's tryToSetValue for a field of type t
+func (c *binderCompiler) leaf(t reflect.Type, field reflect.StructField) (*leafNode, error) {
+	tag, tagged := field.Tag.Lookup(c.tag)
+	c.tagged = c.tagged || tagged
+	name, opts, _ := strings.Cut(tag, ",")
+	if name == "" {
+		name = field.Name
+	}
+	if name == "" {
+		return nil, nil
+	}
+	name = c.prefix + name
+	if c.canonicalKeys {
+		name = textproto.CanonicalMIMEHeaderKey(name)
+	}
+
+	leaf := &leafNode{key: name, kind: t.Kind(), custom: reflect.PointerTo(t).Implements(bindUnmarshalerType)}
+	c.keys[name] = true
+	if c.query {
+		for _, alias := range tagList(field, "alias") {
+			leaf.aliases = append(leaf.aliases, c.prefix+alias)
+			c.keys[c.prefix+alias] = true
+		}
+	}
+	format := field.Tag.Get("collection_format")
+	for opts != "" {
+		var opt string
+		opt, opts, _ = strings.Cut(opts, ",")
+		if k, v, _ := strings.Cut(opt, "="); k == "default" {
+			leaf.hasDefault = true
+			c.defaults = true
+			leaf.defaultValue = v
+			if (field.Type.Kind() == reflect.Slice || field.Type.Kind() == reflect.Array) && (format == "" || format == "multi" || format == "csv") {
+				leaf.defaultValue = strings.ReplaceAll(v, ";", ",")
+			}
+		}
+	}
+
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		leaf.splitDefault = format == "" || format == "multi"
+		switch format {
+		case "", "multi":
+		case "csv":
+			leaf.sep = ","
+		case "ssv":
+			leaf.sep = " "
+		case "tsv":
+			leaf.sep = "\t"
+		case "pipes":
+			leaf.sep = "|"
+		default:
+			return nil, errNotCompiled
+		}
+		conv, err := converterFor(t.Elem(), field)
+		if err != nil {
+			return nil, err
+		}
+		leaf.conv = conv
+	default:
+		conv, err := converterFor(t, field)
+		if err != nil {
+			return nil, err
+		}
+		leaf.conv = conv
+	}
+	return leaf, nil
+}
+
+var (
+	bindUnmarshalerType = reflect.TypeOf((*binding.BindUnmarshaler)(nil)).Elem()
+	timeType            = reflect.TypeOf(time.Time{})
+	durationType        = reflect.TypeOf(time.Duration(0))
+	fileHeaderType      = reflect.TypeOf(multipart.FileHeader{})
+)
+
+// converter parses one value into v, like gin's setWithProperType
+type converter func(val string, v reflect.Value) error
+
+func converterFor(t reflect.Type, field reflect.StructField) (converter, error) {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if t == durationType {
+			return func(val string, v reflect.Value) error {
+				d, err := time.ParseDuration(val)
+				if err != nil {
+					return err
+				}
+				v.SetInt(int64(d))
+				return nil
+			}, nil
+		}
+		bits := t.Bits()
+		if t.Kind() == reflect.Int {
+			bits = 0
+		}
+		return func(val string, v reflect.Value) error {
+			if val == "" {
+				val = "0"
+			}
+			n, err := strconv.ParseInt(val, 10, bits)
+			if err == nil {
+				v.SetInt(n)
+			}
+			return err
+		}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		bits := t.Bits()
+		if t.Kind() == reflect.Uint {
+			bits = 0
+		}
+		return func(val string, v reflect.Value) error {
+			if val == "" {
+				val = "0"
+			}
+			n, err := strconv.ParseUint(val, 10, bits)
+			if err == nil {
+				v.SetUint(n)
+			}
+			return err
+		}, nil
+	case reflect.Bool:
+		return func(val string, v reflect.Value) error {
+			if val == "" {
+				val = "false"
+			}
+			b, err := strconv.ParseBool(val)
+			if err == nil {
+				v.SetBool(b)
+			}
+			return err
+		}, nil
+	case reflect.Float32, reflect.Float64:
+		bits := t.Bits()
+		return func(val string, v reflect.Value) error {
+			if val == "" {
+				val = "0.0"
+			}
+			f, err := strconv.ParseFloat(val, bits)
+			if err == nil {
+				v.SetFloat(f)
+			}
+			return err
+		}, nil
+	case reflect.String:
+		return func(val string, v reflect.Value) error {
+			v.SetString(val)
+			return nil
+		}, nil
+	case reflect.Struct:
+		switch t {
+		case timeType:
+			return timeConverter(field)
+		case fileHeaderType:
+			return func(string, reflect.Value) error { return nil }, nil
+		}
+		return jsonConverter, nil
+	case reflect.Map:
+		return jsonConverter, nil
+	case reflect.Ptr:
+		elem, err := converterFor(t.Elem(), field)
+		if err != nil {
+			return nil, err
+		}
+		return func(val string, v reflect.Value) error {
+			if !v.Elem().IsValid() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			return elem(val, v.Elem())
+		}, nil
+	default:
+		return func(string, reflect.Value) error { return errors.New("unknown type") }, nil
+	}
+}
+
+func jsonConverter(val string, v reflect.Value) error {
+	return json.API.Unmarshal([]byte(val), v.Addr().Interface())
+}
+
+// timeConverter parses times using the time_format, time_utc and time_location tags of field
+func timeConverter(field reflect.StructField) (converter, error) {
+	format := field.Tag.Get("time_format")
+	if format == "" {
+		format = time.RFC3339
+	}
+
+	switch tf := strings.ToLower(format); tf {
+	case "unix", "unixmilli", "unixmicro", "unixnano":
+		return func(val string, v reflect.Value) error {
+			n, err := strconv.ParseInt(val, 10, 64)
+			if err != nil {
+				return err
+			}
+			var t time.Time
+			switch tf {
+			case "unix":
+				t = time.Unix(n, 0)
+			case "unixmilli":
+				t = time.UnixMilli(n)
+			case "unixmicro":
+				t = time.UnixMicro(n)
+			default:
+				t = time.Unix(0, n)
+			}
+			v.Set(reflect.ValueOf(t))
+			return nil
+		}, nil
+	}
+
+	loc := time.Local
+	if utc, _ := strconv.ParseBool(field.Tag.Get("time_utc")); utc {
+		loc = time.UTC
+	}
+	if name := field.Tag.Get("time_location"); name != "" {
+		l, err := time.LoadLocation(name)
+		if err != nil {
+			// gin reports this per request, so leave the type to it
+			return nil, errNotCompiled
+		}
+		loc = l
+	}
+	return func(val string, v reflect.Value) error {
+		if val == "" {
+			v.Set(reflect.ValueOf(time.Time{}))
+			return nil
+		}
+		t, err := time.ParseInLocation(format, val, loc)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(t))
+		return nil
+	}, nil
+}
+
+// ptrNode allocates a pointer only when something is set through it
+type ptrNode struct {
+	elem bindNode
+	typ  reflect.Type
+}
+
+func (n *ptrNode) set(v reflect.Value, src map[string][]string) (bool, error) {
+	if !v.IsNil() {
+		return n.elem.set(v.Elem(), src)
+	}
+	ptr := reflect.New(n.typ)
+	ok, err := n.elem.set(ptr.Elem(), src)
+	if err != nil {
+		return false, err
+	}
+	if ok {
+		v.Set(ptr)
+	}
+	return ok, nil
+}
+
+type structField struct {
+	index int
+	node  bindNode
+}
+
+// structNode tries the struct as a single value first, then its fields
+type structNode struct {
+	leaf   *leafNode
+	fields []structField
+}
+
+func (n *structNode) set(v reflect.Value, src map[string][]string) (bool, error) {
+	if n.leaf != nil {
+		ok, err := n.leaf.set(v, src)
+		if err != nil {
+			return false, err
+		}
+		if ok {
+			return true, nil
+		}
+	}
+	var isSet bool
+	for _, f := range n.fields {
+		ok, err := f.node.set(v.Field(f.index), src)
+		if err != nil {
+			return false, err
+		}
+		isSet = isSet || ok
+	}
+	return isSet, nil
+}
+
+// mapNode sets the entries of a map from the keys under prefix, once its leaf found no value
+// for the map as a whole
+type mapNode struct {
+	leaf   *leafNode
+	prefix string
+	conv   converter
+}
+
+func (n *mapNode) set(v reflect.Value, src map[string][]string) (bool, error) {
+	if ok, err := n.leaf.set(v, src); ok || err != nil {
+		return ok, err
+	}
+	var isSet bool
+	for key, vs := range src {
+		name, ok := strings.CutPrefix(key, n.prefix)
+		if !ok || name == "" || len(vs) == 0 {
+			continue
+		}
+		if v.IsNil() {
+			v.Set(reflect.MakeMap(v.Type()))
+		}
+		elem := reflect.New(v.Type().Elem()).Elem()
+		if err := n.conv(vs[0], elem); err != nil {
+			return false, err
+		}
+		v.SetMapIndex(reflect.ValueOf(name).Convert(v.Type().Key()), elem)
+		isSet = true
+	}
+	return isSet, nil
+}
+
+// scalarKind reports whether values of kind are parsed from a single string
+func scalarKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.String, reflect.Bool, reflect.Float32, reflect.Float64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
+// leafNode sets one field from the values under key, like gin's setByForm
+type leafNode struct {
+	key          string
+	aliases      []string // other keys tried in order when key has no value
+	kind         reflect.Kind
+	custom       bool // implements binding.BindUnmarshaler
+	conv         converter
+	hasDefault   bool
+	defaultValue string
+	sep          string // collection_format separator, empty for multi
+	splitDefault bool
+}
+
+func (n *leafNode) set(v reflect.Value, src map[string][]string) (bool, error) {
+	vs, ok := src[n.key]
+	for _, alias := range n.aliases {
+		if ok {
+			break
+		}
+		vs, ok = src[alias]
+	}
+	if !ok && !n.hasDefault {
+		return false, nil
+	}
+
+	switch n.kind {
+	case reflect.Slice, reflect.Array:
+		if !ok {
+			vs = []string{n.defaultValue}
+			if n.splitDefault {
+				vs = strings.Split(n.defaultValue, ",")
+			}
+		}
+		if n.custom {
+			return true, v.Addr().Interface().(binding.BindUnmarshaler).UnmarshalParam(vs[0])
+		}
+		if n.sep != "" {
+			split := make([]string, 0, len(vs))
+			for _, s := range vs {
+				split = append(split, strings.Split(s, n.sep)...)
+			}
+			vs = split
+		}
+
+		if n.kind == reflect.Array {
+			if len(vs) != v.Len() {
+				return false, fmt.Errorf("%q is not valid value for %s", vs, v.Type().String())
+			}
+			return true, n.setElems(vs, v)
+		}
+		slice := reflect.MakeSlice(v.Type(), len(vs), len(vs))
+		if err := n.setElems(vs, slice); err != nil {
+			return true, err
+		}
+		v.Set(slice)
+		return true, nil
+	default:
+		var val string
+		if !ok {
+			val = n.defaultValue
+		}
+		if len(vs) > 0 {
+			val = vs[0]
+			if val == "" {
+				val = n.defaultValue
+			}
+		}
+		if n.custom {
+			return true, v.Addr().Interface().(binding.BindUnmarshaler).UnmarshalParam(val)
+		}
+		return true, n.conv(val, v)
+	}
+}
+
+func (n *leafNode) setElems(vs []string, v reflect.Value) error {
+	for i, s := range vs {
+		if err := n.conv(s, v.Index(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
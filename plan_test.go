@@ -0,0 +1,293 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type planInner struct {
+	Level int `form:"level"`
+}
+
+type planEmbedded struct {
+	Region string `form:"region"`
+}
+
+type planReq struct {
+	planEmbedded
+	ID       string            `uri:"id"`
+	Name     string            `form:"name,default=anon"`
+	Untagged string            // bound by field name, like gin
+	Age      *int              `form:"age"`
+	Score    float32           `form:"score"`
+	Active   bool              `form:"active"`
+	Count    uint16            `form:"count"`
+	Tags     []string          `form:"tags"`
+	IDs      []int             `form:"ids" collection_format:"csv"`
+	Pair     [2]string         `form:"pair"`
+	Defaults []string          `form:"defaults,default=a;b"`
+	Since    time.Time         `form:"since" time_format:"2006-01-02" time_utc:"1"`
+	Epoch    time.Time         `form:"epoch" time_format:"unix"`
+	Timeout  time.Duration     `form:"timeout"`
+	Meta     map[string]string `form:"meta"`
+	Inner    planInner         `form:"inner"`
+	Nested   *planInner
+	Title    Optional[string] `form:"title"`
+	Skipped  string           `form:"-"`
+	Token    string           `header:"x-api-token"`
+}
+
+func TestBindingPlan_MatchesGin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	queries := []string{
+		"",
+		"name=bob&Untagged=u&age=30&score=1.5&active=true&count=7&region=eu",
+		"tags=a&tags=b&ids=1,2,3&pair=x&pair=y&since=2024-05-01&epoch=1700000000&timeout=1m30s",
+		`meta={"k":"v"}&inner={"level":3}&level=9&title=hello&Skipped=no`,
+		"name=&title=",
+		"age=notanumber",
+		"pair=only-one",
+		"timeout=soon",
+	}
+
+	plan := planFor(reflect.TypeOf(planReq{}))
+	if !plan.compiled {
+		t.Fatal("expected planReq to compile")
+	}
+	for _, q := range queries {
+		req := httptest.NewRequest(http.MethodGet, "/items/42?"+q, nil)
+		req.Header.Set("X-Api-Token", "secret")
+
+		bindWith := func(useGin bool) (planReq, []error) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = req
+			c.Params = gin.Params{{Key: "id", Value: "42"}}
+			var out planReq
+			var errs []error
+			if useGin {
+				errs = []error{c.ShouldBindQuery(&out), c.ShouldBindUri(&out), c.ShouldBindHeader(&out)}
+			} else {
+				errs = []error{plan.bindQuery(c, &out), plan.bindURI(c, &out), plan.bindHeader(c, &out)}
+			}
+			return out, errs
+		}
+
+		want, wantErrs := bindWith(true)
+		got, gotErrs := bindWith(false)
+		for i := range wantErrs {
+			if (wantErrs[i] == nil) != (gotErrs[i] == nil) {
+				t.Errorf("%q: pass %d error gin=%v plan=%v", q, i, wantErrs[i], gotErrs[i])
+			}
+		}
+		if !reflect.DeepEqual(want, got) {
+			t.Errorf("%q:\n gin  %+v\n plan %+v", q, want, got)
+		}
+	}
+}
+
+type planRecursive struct {
+	Name string         `form:"name"`
+	Next *planRecursive `form:"-"`
+	Bad  []string       `form:"bad" collection_format:"nope"`
+}
+
+func TestBindingPlan_FallsBackToGin(t *testing.T) {
+	if planFor(reflect.TypeOf(planRecursive{})).compiled {
+		t.Fatal("unknown collection_format should leave the type to gin")
+	}
+	if planFor(reflect.TypeOf(map[string]string{})).compiled {
+		t.Fatal("maps should be bound by gin")
+	}
+	if planFor(reflect.TypeOf(planReq{})) != planFor(reflect.TypeOf(planReq{})) {
+		t.Fatal("plans should be cached per type")
+	}
+}
+
+type planJSONReq struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+type planDefaultReq struct {
+	Limit int `form:"limit,default=20"`
+}
+
+func TestBindingPlan_SkipsUntaggedSources(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	plan := planFor(reflect.TypeOf(planJSONReq{}))
+	if !plan.query.skip(false) || !plan.uri.skip(false) || !plan.header.skip(false) {
+		t.Fatal("a JSON-only type should skip query, path and header binding")
+	}
+
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, "/users/1?Name=query", nil)
+	c.Request.Header.Set("Email", "header@example.com")
+	c.Params = gin.Params{{Key: "Name", Value: "path"}}
+	out := planJSONReq{Name: "body"}
+	errs := []error{plan.bindQuery(c, &out), plan.bindURI(c, &out), plan.bindHeader(c, &out)}
+	for _, err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if out.Name != "body" || out.Email != "" {
+		t.Fatalf("untagged fields should not be bound by name, got %+v", out)
+	}
+}
+
+func TestBindingPlan_DefaultsApplyToEmptyQuery(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	plan := planFor(reflect.TypeOf(planDefaultReq{}))
+	if plan.query.skip(true) || !plan.uri.skip(false) {
+		t.Fatalf("unexpected passes %+v %+v", plan.query, plan.uri)
+	}
+
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, "/items", nil)
+	var out planDefaultReq
+	if err := plan.bindQuery(c, &out); err != nil || out.Limit != 20 {
+		t.Fatalf("expected the default limit, got %+v (%v)", out, err)
+	}
+}
+
+type benchReq struct {
+	ID     string `uri:"id"`
+	Page   int    `form:"page"`
+	Limit  int    `form:"limit"`
+	Sort   string `form:"sort"`
+	Search string `form:"q"`
+	Token  string `header:"Authorization"`
+}
+
+func benchmarkBinding(b *testing.B, bind func(c *gin.Context, req *benchReq) error) {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, "/items/42?page=2&limit=50&sort=name&q=shoes", nil)
+	c.Request.Header.Set("Authorization", "Bearer x")
+	c.Params = gin.Params{{Key: "id", Value: "42"}}
+	b.ReportAllocs()
+	for b.Loop() {
+		var req benchReq
+		if err := bind(c, &req); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkBinding_Gin(b *testing.B) {
+	benchmarkBinding(b, func(c *gin.Context, req *benchReq) error {
+		if err := c.ShouldBindQuery(req); err != nil {
+			return err
+		}
+		if err := c.ShouldBindUri(req); err != nil {
+			return err
+		}
+		return c.ShouldBindHeader(req)
+	})
+}
+
+func BenchmarkBinding_Plan(b *testing.B) {
+	plan := planFor(reflect.TypeOf(benchReq{}))
+	benchmarkBinding(b, func(c *gin.Context, req *benchReq) error {
+		if err := plan.bindQuery(c, req); err != nil {
+			return err
+		}
+		if err := plan.bindURI(c, req); err != nil {
+			return err
+		}
+		return plan.bindHeader(c, req)
+	})
+}
+
+func BenchmarkBinding_PlanUntagged(b *testing.B) {
+	gin.SetMode(gin.TestMode)
+	plan := planFor(reflect.TypeOf(planJSONReq{}))
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodPost, "/users?trace=1", nil)
+	c.Request.Header.Set("Authorization", "Bearer x")
+	b.ReportAllocs()
+	for b.Loop() {
+		var req planJSONReq
+		if err := plan.bindQuery(c, &req); err != nil {
+			b.Fatal(err)
+		}
+		if err := plan.bindURI(c, &req); err != nil {
+			b.Fatal(err)
+		}
+		if err := plan.bindHeader(c, &req); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+type planFilter struct {
+	Status string   `form:"status"`
+	Owner  int      `form:"owner"`
+	Tags   []string `form:"tags"`
+	Range  struct {
+		From string `form:"from"`
+		To   string `form:"to"`
+	} `form:"range"`
+}
+
+type planNestedReq struct {
+	Filter planFilter        `form:"filter"`
+	Range  *planInner        `form:"range"`
+	Labels map[string]string `form:"labels"`
+	Page   int               `form:"page"`
+}
+
+func TestBindingPlan_NestedQuery(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	plan := planFor(reflect.TypeOf(planNestedReq{}))
+	if !plan.compiled || !plan.query.nested {
+		t.Fatal("expected planNestedReq to compile with nested keys")
+	}
+	bind := func(q string) (planNestedReq, error) {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodGet, "/todos?"+q, nil)
+		var out planNestedReq
+		return out, plan.bindQuery(c, &out)
+	}
+
+	got, err := bind("filter[status]=done&filter[owner]=42&filter[tags][]=a&filter[tags][]=b&filter[range][from]=x&range.level=3&labels[team]=core&page=2&status=ignored")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := planNestedReq{Range: &planInner{Level: 3}, Labels: map[string]string{"team": "core"}, Page: 2}
+	want.Filter = planFilter{Status: "done", Owner: 42, Tags: []string{"a", "b"}}
+	want.Filter.Range.From = "x"
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got  %+v\nwant %+v", got, want)
+	}
+
+	if got, err = bind("filter.status=open&filter.range.to=y"); err != nil || got.Filter.Status != "open" || got.Filter.Range.To != "y" || got.Range != nil {
+		t.Errorf("dot notation: %+v, %v", got, err)
+	}
+	if _, err = bind("filter[owner]=me"); err == nil {
+		t.Error("invalid nested value accepted")
+	}
+}
+
+func TestBindingPlan_NestedQuerySwagger(t *testing.T) {
+	sg := NewSwaggerGenerator("API", "1.0")
+	params := sg.generateParameters(reflect.TypeOf(planNestedReq{}), "/todos")
+	styles := map[string]string{}
+	for _, p := range params {
+		styles[p.Name] = p.Style
+		if p.Style != "" && !p.Explode {
+			t.Errorf("%s: deepObject without explode", p.Name)
+		}
+	}
+	want := map[string]string{"filter": "deepObject", "range": "deepObject", "labels": "deepObject", "page": ""}
+	if !reflect.DeepEqual(styles, want) {
+		t.Errorf("styles = %v", styles)
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"context"
+	"fmt"
+)
+
+// Plugin packages an extension of the app, such as metrics, auth or a docs theme. Install
+// typically adds middleware and routes and registers hooks with OnRouteRegistered, OnSpec,
+// OnStart and OnShutdown.
+type Plugin interface {
+	Install(app *App) error
+}
+
+// PluginFunc adapts a function to Plugin
+type PluginFunc func(app *App) error
+
+func (f PluginFunc) Install(app *App) error {
+	return f(app)
+}
+
+// Install installs plugins in order, stopping at the first one that fails
+//
+//	if err := app.Install(metrics.New(), auth.New(issuer)); err != nil {
+//		log.Fatal(err)
+//	}
+func (a *App) Install(plugins ...Plugin) error {
+	for _, p := range plugins {
+		if err := p.Install(a); err != nil {
+			return fmt.Errorf("install plugin %T: %w", p, err)
+		}
+	}
+	return nil
+}
+
+// OnSpec registers fn to modify the generated OpenAPI spec before it is served
+func (a *App) OnSpec(fn func(spec *OpenAPISpec)) {
+	a.specHooks = append(a.specHooks, fn)
+	if a.swagger != nil {
+		a.swagger.specHooks = append(a.swagger.specHooks, fn)
+	}
+	a.invalidateSpec()
+}
+
+// OnStart registers fn to run when Start is called, before the server listens. An error
+// aborts Start.
+func (a *App) OnStart(fn func(ctx context.Context) error) {
+	a.life.mu.Lock()
+	defer a.life.mu.Unlock()
+	a.life.onStart = append(a.life.onStart, fn)
+}
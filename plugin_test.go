@@ -0,0 +1,120 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// poweredBy is a small plugin touching every hook: middleware, routes, spec and lifecycle
+type poweredBy struct {
+	routes  []Route
+	started bool
+	stopped bool
+}
+
+func (p *poweredBy) Install(app *App) error {
+	app.Use(func(ctx *gin.Context) {
+		ctx.Header("X-Powered-By", "fluxo")
+		ctx.Next()
+	})
+	app.OnRouteRegistered(func(r Route) error {
+		p.routes = append(p.routes, r)
+		return nil
+	})
+	app.OnSpec(func(spec *OpenAPISpec) { spec.Info.Description = "Powered by fluxo" })
+	app.OnStart(func(context.Context) error {
+		p.started = true
+		return nil
+	})
+	app.OnShutdown(func(context.Context) error {
+		p.stopped = true
+		return nil
+	})
+	return nil
+}
+
+func TestInstall_PluginHooks(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := New()
+	app.GET("/before", func(ctx *gin.Context) { ctx.Status(http.StatusNoContent) })
+
+	p := &poweredBy{}
+	if err := app.Install(p); err != nil {
+		t.Fatal(err)
+	}
+	app.WithSwagger("Test", "1.0")
+	app.GET("/after", func(ctx *gin.Context) { ctx.Status(http.StatusNoContent) })
+	app.TypedGroup("/v1").POST("/todos", Handle(func(ctx *Context, req struct{}) (struct{}, error) {
+		return struct{}{}, nil
+	}))
+
+	want := []string{"GET /before", "GET /after", "POST /v1/todos"}
+	var got []string
+	for _, r := range p.routes {
+		if !strings.HasPrefix(r.Path, "/docs") && r.Path != "/openapi.json" {
+			got = append(got, r.Method+" "+r.Path)
+		}
+	}
+	if !slices.Equal(got, want) {
+		t.Fatalf("expected routes %v, got %v", want, got)
+	}
+
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/after", nil))
+	if w.Header().Get("X-Powered-By") != "fluxo" {
+		t.Fatal("expected the plugin middleware to run on later routes")
+	}
+
+	if spec := groupSpec(t, app); spec.Info.Description != "Powered by fluxo" {
+		t.Fatalf("expected the spec hook to run, got %q", spec.Info.Description)
+	}
+
+	if err := app.Shutdown(context.Background()); err != nil || !p.stopped {
+		t.Fatalf("expected the shutdown hook to run, err %v", err)
+	}
+}
+
+func TestInstall_SpecHookAfterSwagger(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := New().WithSwagger("Test", "1.0")
+	groupSpec(t, app) // cache the spec before the hook is added
+	app.OnSpec(func(spec *OpenAPISpec) { spec.Info.Title = "Renamed" })
+	if spec := groupSpec(t, app); spec.Info.Title != "Renamed" {
+		t.Fatalf("expected the cached spec to be rebuilt, got %q", spec.Info.Title)
+	}
+}
+
+func TestInstall_StopsAtFirstError(t *testing.T) {
+	app := New()
+	var installed []string
+	ok := PluginFunc(func(*App) error {
+		installed = append(installed, "ok")
+		return nil
+	})
+	failing := PluginFunc(func(*App) error { return errors.New("missing config") })
+
+	err := app.Install(ok, failing, ok)
+	if err == nil || !strings.Contains(err.Error(), "missing config") {
+		t.Fatalf("expected the plugin error, got %v", err)
+	}
+	if len(installed) != 1 {
+		t.Fatalf("expected installation to stop, installed %v", installed)
+	}
+}
+
+func TestStart_OnStartErrorAborts(t *testing.T) {
+	app := New()
+	app.OnStart(func(context.Context) error { return errors.New("migrations failed") })
+	if err := app.Start("127.0.0.1:0"); err == nil || err.Error() != "migrations failed" {
+		t.Fatalf("expected the start hook error, got %v", err)
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultPresignExpiry is how long pre-signed URLs stay valid unless told otherwise
+const DefaultPresignExpiry = 15 * time.Minute
+
+// Presigner signs URLs letting clients send files straight to storage and fetch them from it,
+// so large transfers bypass the API server. s3x.Storage is one, for S3 and compatible services.
+type Presigner interface {
+	PresignPut(ctx context.Context, key string, opts PresignOptions) (PresignedURL, error)
+	PresignGet(ctx context.Context, key string, opts PresignOptions) (PresignedURL, error)
+}
+
+// PresignOptions constrain what a pre-signed URL allows
+type PresignOptions struct {
+	Expires       time.Duration // DefaultPresignExpiry when zero
+	ContentType   string        // PUT: the type the upload must declare; GET: the type it is served as
+	ContentLength int64         // PUT: the exact size the upload must have, when positive
+	Filename      string        // GET: the name it downloads as, with Content-Disposition: attachment
+}
+
+// PresignedURL is a request the client may make to storage without credentials
+type PresignedURL struct {
+	Method    string            `json:"method"`
+	URL       string            `json:"url"`
+	Headers   map[string]string `json:"headers,omitempty"` // to send as they are, as they were signed
+	Key       string            `json:"key"`
+	ExpiresAt time.Time         `json:"expires_at"`
+}
+
+// PresignUploadRequest asks for a URL to upload a file to
+type PresignUploadRequest struct {
+	Filename    string `json:"filename" validate:"required,max=255"`
+	ContentType string `json:"content_type" validate:"required"`
+	Size        int64  `json:"size" validate:"required,min=1"`
+}
+
+// PresignOption configures PresignedUploads
+type PresignOption func(*presignConfig)
+
+type presignConfig struct {
+	expires time.Duration
+	types   []string
+	maxSize int64
+	key     func(ctx *Context, req PresignUploadRequest) string
+}
+
+// WithPresignExpiry sets how long the URLs stay valid, DefaultPresignExpiry by default
+func WithPresignExpiry(d time.Duration) PresignOption {
+	return func(c *presignConfig) {
+		c.expires = d
+	}
+}
+
+// WithPresignTypes sets the content types that may be uploaded, like image/png or image/*
+func WithPresignTypes(types ...string) PresignOption {
+	return func(c *presignConfig) {
+		c.types = types
+	}
+}
+
+// WithPresignMaxSize sets the largest file that may be uploaded, in bytes
+func WithPresignMaxSize(n int64) PresignOption {
+	return func(c *presignConfig) {
+		c.maxSize = n
+	}
+}
+
+// WithPresignKey sets the storage key of uploads; the default is a random name keeping the
+// extension, like Uploads. Prefix it with the user to keep their files apart.
+func WithPresignKey(fn func(ctx *Context, req PresignUploadRequest) string) PresignOption {
+	return func(c *presignConfig) {
+		c.key = fn
+	}
+}
+
+// PresignedUploads returns a typed handler answering a PresignUploadRequest with a URL the
+// client PUTs the file to, signed for its content type and exact size:
+//
+//	app.POST("/uploads/presign", requireUser, fluxo.PresignedUploads(storage,
+//		fluxo.WithPresignTypes("image/*", "application/pdf"),
+//		fluxo.WithPresignMaxSize(100<<20),
+//	))
+//	// POST {"filename": "scan.pdf", "content_type": "application/pdf", "size": 5242880}
+//	// -> {"method": "PUT", "url": "https://...", "headers": {"Content-Type": "application/pdf", ...}, "key": "...", "expires_at": "..."}
+//
+// Disallowed types and sizes are answered with 422. Once uploaded, the client sends the key
+// back to a handler that records the file.
+func PresignedUploads(p Presigner, opts ...PresignOption) gin.HandlerFunc {
+	cfg := presignConfig{
+		expires: DefaultPresignExpiry,
+		key: func(_ *Context, req PresignUploadRequest) string {
+			return strings.ToLower(rand.Text() + filepath.Ext(req.Filename))
+		},
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return Handle(func(ctx *Context, req PresignUploadRequest) (PresignedURL, error) {
+		mediaType, _, err := mime.ParseMediaType(req.ContentType)
+		if err != nil {
+			return PresignedURL{}, UnprocessableEntity("content_type is not a media type")
+		}
+		if len(cfg.types) > 0 && !mimeAllowed(mediaType, cfg.types) {
+			return PresignedURL{}, UnprocessableEntity(fmt.Sprintf("content_type must be one of %s", strings.Join(cfg.types, " ")))
+		}
+		if cfg.maxSize > 0 && req.Size > cfg.maxSize {
+			return PresignedURL{}, UnprocessableEntity(fmt.Sprintf("size must be at most %d bytes", cfg.maxSize))
+		}
+		return p.PresignPut(ctx, cfg.key(ctx, req), PresignOptions{
+			Expires:       cfg.expires,
+			ContentType:   req.ContentType,
+			ContentLength: req.Size,
+		})
+	})
+}
+
+// PresignDownload returns a redirect to a pre-signed URL fetching key, for typed handlers that
+// check access to a file and let storage serve it:
+//
+//	app.GET("/documents/:id/file", fluxo.Handle(func(ctx *fluxo.Context, req DocumentReq) (fluxo.Redirection, error) {
+//		doc, err := docs.Get(ctx, req.ID) // only the user's own
+//		if err != nil {
+//			return fluxo.Redirection{}, err
+//		}
+//		return fluxo.PresignDownload(ctx, storage, doc.Key, fluxo.PresignOptions{Filename: doc.Name})
+//	}))
+func PresignDownload(ctx context.Context, p Presigner, key string, opts PresignOptions) (Redirection, error) {
+	u, err := p.PresignGet(ctx, key, opts)
+	if err != nil {
+		return Redirection{}, err
+	}
+	return Redirect(http.StatusFound, u.URL), nil
+}
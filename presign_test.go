@@ -0,0 +1,106 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// fakePresigner signs URLs of example.com, recording the options of the last one
+type fakePresigner struct {
+	opts PresignOptions
+}
+
+func (p *fakePresigner) PresignPut(_ context.Context, key string, opts PresignOptions) (PresignedURL, error) {
+	p.opts = opts
+	return PresignedURL{
+		Method:    http.MethodPut,
+		URL:       "https://storage.example.com/" + key + "?signature=x",
+		Headers:   map[string]string{"Content-Type": opts.ContentType, "Content-Length": strconv.FormatInt(opts.ContentLength, 10)},
+		Key:       key,
+		ExpiresAt: time.Now().Add(opts.Expires),
+	}, nil
+}
+
+func (p *fakePresigner) PresignGet(_ context.Context, key string, opts PresignOptions) (PresignedURL, error) {
+	p.opts = opts
+	return PresignedURL{Method: http.MethodGet, URL: "https://storage.example.com/" + key + "?signature=x", Key: key}, nil
+}
+
+func TestPresignedUploads(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	presigner := &fakePresigner{}
+	app := New().WithSwagger("t", "v")
+	app.POST("/uploads/presign", PresignedUploads(presigner,
+		WithPresignTypes("image/*", "application/pdf"),
+		WithPresignMaxSize(1<<20),
+		WithPresignExpiry(time.Minute),
+	))
+
+	post := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/uploads/presign", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+		return w
+	}
+
+	w := post(`{"filename":"Scan.PDF","content_type":"application/pdf","size":1024}`)
+	var got PresignedURL
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("status=%d body=%s", w.Code, w.Body)
+	}
+	if got.Method != http.MethodPut || !strings.HasSuffix(got.Key, ".pdf") || got.Headers["Content-Length"] != "1024" {
+		t.Errorf("unexpected URL %+v", got)
+	}
+	if presigner.opts != (PresignOptions{Expires: time.Minute, ContentType: "application/pdf", ContentLength: 1024}) {
+		t.Errorf("unexpected options %+v", presigner.opts)
+	}
+
+	for body, want := range map[string]int{
+		`{"filename":"a.png","content_type":"image/png","size":2048}`:             http.StatusOK,
+		`{"filename":"a.exe","content_type":"application/x-msdownload","size":1}`: http.StatusUnprocessableEntity,
+		`{"filename":"a.png","content_type":"image/png","size":2097152}`:          http.StatusUnprocessableEntity,
+		`{"filename":"a.png","content_type":"image/png"}`:                         http.StatusBadRequest,
+	} {
+		if w := post(body); w.Code != want {
+			t.Errorf("%s: expected %d, got %d %s", body, want, w.Code, w.Body)
+		}
+	}
+
+	if err := app.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	op := groupSpec(t, app).Paths["/uploads/presign"].POST
+	if op == nil || op.Responses["200"].Content["application/json"].Schema.Properties["url"].Type != "string" {
+		t.Errorf("expected the operation documented, got %+v", op)
+	}
+}
+
+func TestPresignDownload(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	presigner := &fakePresigner{}
+	app := New()
+	app.GET("/documents/:id/file", Handle(func(ctx *Context, req struct {
+		ID string `uri:"id"`
+	}) (Redirection, error) {
+		return PresignDownload(ctx, presigner, "docs/"+req.ID, PresignOptions{Filename: "Report.pdf"})
+	}))
+
+	w := get(app, "/documents/42/file")
+	if w.Code != http.StatusFound || w.Header().Get("Location") != "https://storage.example.com/docs/42?signature=x" {
+		t.Errorf("unexpected redirect %d %v", w.Code, w.Header())
+	}
+	if presigner.opts.Filename != "Report.pdf" {
+		t.Errorf("unexpected options %+v", presigner.opts)
+	}
+}
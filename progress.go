@@ -0,0 +1,231 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HeaderUploadID names an upload whose progress is tracked, see ProgressTracker. It may be sent
+// as the upload_id query parameter instead, for plain HTML forms.
+const HeaderUploadID = "X-Upload-ID"
+
+// uploadIDPattern keeps upload IDs to what clients generate, like UUIDs
+var uploadIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{8,64}$`)
+
+// UploadProgress is how much of an upload's body has been received
+type UploadProgress struct {
+	ID       string  `json:"id"`
+	Received int64   `json:"received"`          // bytes of the body read so far
+	Total    int64   `json:"total"`             // Content-Length, -1 when the client didn't send it
+	Percent  float64 `json:"percent,omitempty"` // of Total, when known
+	Done     bool    `json:"done"`              // the handler has returned
+	Status   int     `json:"status,omitempty"`  // of the response, once done
+}
+
+// ProgressOption configures a ProgressTracker
+type ProgressOption func(*ProgressTracker)
+
+// WithProgressInterval sets how often progress streams send an event while the upload
+// advances, every 250ms by default
+func WithProgressInterval(d time.Duration) ProgressOption {
+	return func(t *ProgressTracker) {
+		t.interval = d
+	}
+}
+
+// WithProgressRetention sets how long the progress of a finished upload can still be queried,
+// a minute by default
+func WithProgressRetention(d time.Duration) ProgressOption {
+	return func(t *ProgressTracker) {
+		t.retain = d
+	}
+}
+
+// ProgressTracker counts the bytes received of uploads that carry an ID, so that UIs can show
+// real progress bars while the request is still being sent. The client picks the ID, sends it
+// in the X-Upload-ID header and meanwhile polls, or listens to, the tracker's endpoints:
+//
+//	progress := fluxo.NewProgressTracker()
+//	progress.Enable(app, "/uploads/progress")
+//	app.POST("/videos", progress.Middleware(), fluxo.Handle(uploadVideo))
+//
+//	// GET /uploads/progress/:id        -> {"id": "...", "received": 1048576, "total": 8388608, "percent": 12.5, "done": false}
+//	// GET /uploads/progress/:id/events -> the same as Server-Sent Events until done
+//
+// Anyone knowing an ID can see its progress, so clients should use random ones, like UUIDs.
+// Progress is kept in memory, so polls must reach the instance receiving the upload.
+type ProgressTracker struct {
+	interval time.Duration
+	retain   time.Duration
+
+	mu      sync.Mutex
+	uploads map[string]*trackedUpload
+}
+
+type trackedUpload struct {
+	received atomic.Int64
+	total    int64
+	done     bool
+	status   int
+	finished time.Time
+}
+
+// NewProgressTracker returns an empty tracker
+func NewProgressTracker(opts ...ProgressOption) *ProgressTracker {
+	t := &ProgressTracker{
+		interval: 250 * time.Millisecond,
+		retain:   time.Minute,
+		uploads:  make(map[string]*trackedUpload),
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+// Middleware tracks the bodies of requests carrying an upload ID as the handlers after it read
+// them. Requests without one go on untracked; malformed IDs and IDs of uploads in progress are
+// rejected with 400 and 409.
+func (t *ProgressTracker) Middleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id := ctx.GetHeader(HeaderUploadID)
+		if id == "" {
+			id = ctx.Query("upload_id")
+		}
+		if id == "" {
+			return
+		}
+		if !uploadIDPattern.MatchString(id) {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "upload IDs have 8 to 64 letters, digits, - or _"})
+			return
+		}
+		upload, ok := t.start(id, ctx.Request.ContentLength)
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": fmt.Sprintf("upload %s is in progress", id)})
+			return
+		}
+		ctx.Request.Body = &progressReader{ReadCloser: ctx.Request.Body, upload: upload}
+		defer func() {
+			t.finish(upload, ctx.Writer.Status())
+		}()
+		ctx.Next()
+	}
+}
+
+// start begins tracking the upload id, unless one by that ID is in progress
+func (t *ProgressTracker) start(id string, total int64) (*trackedUpload, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := time.Now()
+	for key, u := range t.uploads {
+		if u.done && now.Sub(u.finished) > t.retain {
+			delete(t.uploads, key)
+		}
+	}
+	if u, ok := t.uploads[id]; ok && !u.done {
+		return nil, false
+	}
+	u := &trackedUpload{total: total}
+	t.uploads[id] = u
+	return u, true
+}
+
+func (t *ProgressTracker) finish(u *trackedUpload, status int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	u.done, u.status, u.finished = true, status, time.Now()
+}
+
+// Progress returns the progress of the upload id, reporting whether it is known
+func (t *ProgressTracker) Progress(id string) (UploadProgress, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	u, ok := t.uploads[id]
+	if !ok || (u.done && time.Since(u.finished) > t.retain) {
+		return UploadProgress{}, false
+	}
+	p := UploadProgress{ID: id, Received: u.received.Load(), Total: u.total, Done: u.done, Status: u.status}
+	if p.Total > 0 {
+		p.Percent = float64(min(p.Received, p.Total)) * 100 / float64(p.Total)
+	}
+	return p, true
+}
+
+type progressReader struct {
+	io.ReadCloser
+	upload *trackedUpload
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.upload.received.Add(int64(n))
+	return n, err
+}
+
+// ProgressRequest names the upload whose progress is asked for
+type ProgressRequest struct {
+	ID string `uri:"id" validate:"required"`
+}
+
+// Enable serves the progress of uploads as JSON at GET path/:id, and as a stream of
+// Server-Sent Events at GET path/:id/events. Each event is a "progress" event holding an
+// UploadProgress; the stream ends after the event of the finished upload.
+func (t *ProgressTracker) Enable(a *App, path string) {
+	a.GET(path+"/:id", Handle(func(ctx *Context, req ProgressRequest) (UploadProgress, error) {
+		p, ok := t.Progress(req.ID)
+		if !ok {
+			return UploadProgress{}, NotFound("upload not found")
+		}
+		return p, nil
+	}))
+	a.GET(path+"/:id/events", t.events)
+}
+
+// events streams the progress of an upload until it is done or the client goes away
+func (t *ProgressTracker) events(ctx *gin.Context) {
+	id := ctx.Param("id")
+	p, ok := t.Progress(id)
+	if !ok {
+		ctx.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "upload not found"})
+		return
+	}
+	ctx.Header("Content-Type", "text/event-stream")
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("X-Accel-Buffering", "no") // keep proxies like nginx from holding the events back
+	ctx.Status(http.StatusOK)
+
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+	var last UploadProgress
+	for {
+		if p != last {
+			data, _ := json.Marshal(p)
+			if _, err := fmt.Fprintf(ctx.Writer, "event: progress\ndata: %s\n\n", data); err != nil {
+				return
+			}
+			ctx.Writer.Flush()
+			last = p
+		}
+		if p.Done {
+			return
+		}
+		select {
+		case <-ctx.Request.Context().Done():
+			return
+		case <-ticker.C:
+		}
+		if p, ok = t.Progress(id); !ok {
+			return
+		}
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const testUploadID = "3fa85f64-5717-4562"
+
+func progressApp(opts ...ProgressOption) (*App, *ProgressTracker) {
+	gin.SetMode(gin.TestMode)
+	app := New()
+	tracker := NewProgressTracker(opts...)
+	tracker.Enable(app, "/uploads/progress")
+	app.POST("/videos", tracker.Middleware(), func(ctx *gin.Context) {
+		n, _ := io.Copy(io.Discard, ctx.Request.Body)
+		ctx.JSON(http.StatusCreated, gin.H{"size": n})
+	})
+	return app, tracker
+}
+
+// startUpload sends a body of total bytes through a pipe, returning the writer and a channel
+// receiving the response once the body is closed
+func startUpload(app *App, total int64) (*io.PipeWriter, <-chan *httptest.ResponseRecorder) {
+	body, pw := io.Pipe()
+	req := httptest.NewRequest(http.MethodPost, "/videos", body)
+	req.ContentLength = total
+	req.Header.Set(HeaderUploadID, testUploadID)
+	done := make(chan *httptest.ResponseRecorder, 1)
+	go func() {
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+		done <- w
+	}()
+	return pw, done
+}
+
+func waitProgress(t *testing.T, tracker *ProgressTracker, received int64) UploadProgress {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if p, ok := tracker.Progress(testUploadID); ok && p.Received == received {
+			return p
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("the upload never reached %d bytes", received)
+	return UploadProgress{}
+}
+
+func TestProgressTracker(t *testing.T) {
+	app, tracker := progressApp()
+	pw, done := startUpload(app, 8)
+
+	pw.Write([]byte("abcd"))
+	if p := waitProgress(t, tracker, 4); p.Total != 8 || p.Percent != 50 || p.Done {
+		t.Errorf("unexpected progress %+v", p)
+	}
+	w := get(app, "/uploads/progress/"+testUploadID)
+	var p UploadProgress
+	if err := json.Unmarshal(w.Body.Bytes(), &p); err != nil || p.Received != 4 {
+		t.Errorf("unexpected progress response %d %s", w.Code, w.Body)
+	}
+	if w := get(app, "/uploads/progress/unknown-upload"); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for unknown uploads, got %d", w.Code)
+	}
+
+	// Another upload by the same ID is rejected while this one runs
+	req := httptest.NewRequest(http.MethodPost, "/videos", strings.NewReader("x"))
+	req.Header.Set(HeaderUploadID, testUploadID)
+	conflict := httptest.NewRecorder()
+	app.ServeHTTP(conflict, req)
+	if conflict.Code != http.StatusConflict {
+		t.Errorf("expected 409 for an upload in progress, got %d", conflict.Code)
+	}
+
+	pw.Write([]byte("efgh"))
+	pw.Close()
+	if w := <-done; w.Code != http.StatusCreated {
+		t.Fatalf("unexpected upload response %d %s", w.Code, w.Body)
+	}
+	if p, _ := tracker.Progress(testUploadID); !p.Done || p.Status != http.StatusCreated || p.Percent != 100 {
+		t.Errorf("unexpected final progress %+v", p)
+	}
+}
+
+func TestProgressTracker_Events(t *testing.T) {
+	app, tracker := progressApp(WithProgressInterval(time.Millisecond))
+	pw, done := startUpload(app, 4)
+	pw.Write([]byte("ab"))
+	waitProgress(t, tracker, 2)
+
+	events := make(chan *httptest.ResponseRecorder, 1)
+	go func() {
+		events <- get(app, "/uploads/progress/"+testUploadID+"/events")
+	}()
+	time.Sleep(10 * time.Millisecond)
+	pw.Write([]byte("cd"))
+	pw.Close()
+	<-done
+
+	w := <-events
+	if w.Header().Get("Content-Type") != "text/event-stream" {
+		t.Errorf("unexpected content type %q", w.Header().Get("Content-Type"))
+	}
+	body := w.Body.String()
+	if !strings.HasPrefix(body, "event: progress\ndata: {") || !strings.Contains(body, `"received":2`) ||
+		!strings.HasSuffix(body, `"done":true,"status":201}`+"\n\n") {
+		t.Errorf("unexpected events %q", body)
+	}
+}
+
+func TestProgressTracker_Untracked(t *testing.T) {
+	app, tracker := progressApp(WithProgressRetention(0))
+	req := httptest.NewRequest(http.MethodPost, "/videos", strings.NewReader("abc"))
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, req)
+	if w.Code != http.StatusCreated {
+		t.Errorf("untracked uploads must go on, got %d", w.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/videos?upload_id=bad!", strings.NewReader("abc"))
+	w = httptest.NewRecorder()
+	app.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected malformed IDs rejected, got %d", w.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/videos?upload_id="+testUploadID, strings.NewReader("abc"))
+	app.ServeHTTP(httptest.NewRecorder(), req)
+	time.Sleep(time.Millisecond)
+	if _, ok := tracker.Progress(testUploadID); ok {
+		t.Errorf("expected finished uploads forgotten after the retention")
+	}
+}
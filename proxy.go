@@ -0,0 +1,153 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// cloudflareRanges are the addresses Cloudflare connects from, see https://www.cloudflare.com/ips/
+var cloudflareRanges = []string{
+	"173.245.48.0/20", "103.21.244.0/22", "103.22.200.0/22", "103.31.4.0/22",
+	"141.101.64.0/18", "108.162.192.0/18", "190.93.240.0/20", "188.114.96.0/20",
+	"197.234.240.0/22", "198.41.128.0/17", "162.158.0.0/15", "104.16.0.0/13",
+	"104.24.0.0/14", "172.64.0.0/13", "131.0.72.0/22",
+	"2400:cb00::/32", "2606:4700::/32", "2803:f800::/32", "2405:b500::/32",
+	"2405:8100::/32", "2a06:98c0::/29", "2c0f:f248::/32",
+}
+
+// privateRanges are loopback and private networks, where load balancers and sidecars usually live
+var privateRanges = []string{
+	"127.0.0.0/8", "10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16",
+	"::1/128", "fc00::/7",
+}
+
+// proxyTrust decides which hops may report the client address. Nothing is trusted by default.
+type proxyTrust struct {
+	proxies []string
+	nets    []*net.IPNet
+	header  string // header a trusted platform puts the client address in, e.g. CF-Connecting-IP
+}
+
+// SetTrustedProxies replaces the proxies, given as IPs or CIDRs, whose X-Forwarded-For and
+// X-Real-IP headers Context.RealIP believes. gin's ClientIP is configured the same way.
+func (a *App) SetTrustedProxies(proxies ...string) error {
+	nets, err := parseProxies(proxies)
+	if err != nil {
+		return err
+	}
+	if err := a.router.SetTrustedProxies(proxies); err != nil {
+		return err
+	}
+	a.proxies.proxies = append([]string(nil), proxies...)
+	a.proxies.nets = nets
+	return nil
+}
+
+// TrustCloudflare trusts Cloudflare's edge and its CF-Connecting-IP header, in addition to the
+// proxies trusted already
+func (a *App) TrustCloudflare() *App {
+	a.trustPreset(cloudflareRanges)
+	a.proxies.header = "CF-Connecting-IP"
+	return a
+}
+
+// TrustPrivateNetworks trusts proxies on loopback and private networks, in addition to the
+// proxies trusted already
+func (a *App) TrustPrivateNetworks() *App {
+	a.trustPreset(privateRanges)
+	return a
+}
+
+func (a *App) trustPreset(ranges []string) {
+	if err := a.SetTrustedProxies(append(a.proxies.proxies, ranges...)...); err != nil {
+		panic(fmt.Sprintf("fluxo: invalid proxy preset: %v", err))
+	}
+}
+
+func parseProxies(proxies []string) ([]*net.IPNet, error) {
+	nets := make([]*net.IPNet, 0, len(proxies))
+	for _, p := range proxies {
+		if !strings.Contains(p, "/") {
+			ip := net.ParseIP(p)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid trusted proxy %q", p)
+			}
+			bits := 8 * net.IPv6len
+			if ip4 := ip.To4(); ip4 != nil {
+				ip, bits = ip4, 8*net.IPv4len
+			}
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, n, err := net.ParseCIDR(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q", p)
+		}
+		nets = append(nets, n)
+	}
+	return nets, nil
+}
+
+func (t *proxyTrust) trusts(ip net.IP) bool {
+	for _, n := range t.nets {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// clientIP returns the address of the client that sent r. Forwarding headers are only read when
+// the connection comes from a trusted proxy, and X-Forwarded-For is walked from the right, so a
+// client can't forge the hops in front of the trusted ones.
+func (t *proxyTrust) clientIP(r *http.Request) string {
+	remote, _, err := net.SplitHostPort(strings.TrimSpace(r.RemoteAddr))
+	if err != nil {
+		remote = strings.TrimSpace(r.RemoteAddr)
+	}
+	ip := net.ParseIP(remote)
+	if ip == nil || !t.trusts(ip) {
+		return remote
+	}
+
+	if t.header != "" {
+		if v := net.ParseIP(strings.TrimSpace(r.Header.Get(t.header))); v != nil {
+			return v.String()
+		}
+	}
+
+	if values := r.Header.Values("X-Forwarded-For"); len(values) > 0 {
+		hops := strings.Split(strings.Join(values, ","), ",")
+		for i := len(hops) - 1; i >= 0; i-- {
+			hop := net.ParseIP(strings.TrimSpace(hops[i]))
+			if hop == nil {
+				break
+			}
+			ip = hop
+			if !t.trusts(hop) {
+				break
+			}
+		}
+		return ip.String()
+	}
+
+	if v := net.ParseIP(strings.TrimSpace(r.Header.Get("X-Real-IP"))); v != nil {
+		return v.String()
+	}
+	return remote
+}
+
+// RealIP returns the client's address, believing X-Forwarded-For and X-Real-IP only from the
+// proxies trusted with SetTrustedProxies or a preset such as TrustCloudflare
+func (c *Context) RealIP() string {
+	a, ok := appFrom(c.Context)
+	if !ok {
+		var none proxyTrust
+		return none.clientIP(c.Request)
+	}
+	return a.proxies.clientIP(c.Request)
+}
@@ -0,0 +1,94 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func realIPApp(t *testing.T) *App {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	app := New()
+	app.GET("/ip", func(c *gin.Context) {
+		ctx := &Context{Context: c}
+		c.String(http.StatusOK, ctx.RealIP())
+	})
+	return app
+}
+
+func realIP(app *App, remote string, headers map[string]string) string {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/ip", nil)
+	req.RemoteAddr = remote
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	app.ServeHTTP(w, req)
+	return w.Body.String()
+}
+
+func TestRealIP_UntrustedByDefault(t *testing.T) {
+	app := realIPApp(t)
+	got := realIP(app, "10.0.0.1:1234", map[string]string{"X-Forwarded-For": "1.2.3.4", "X-Real-IP": "5.6.7.8"})
+	if got != "10.0.0.1" {
+		t.Fatalf("expected forwarding headers to be ignored, got %q", got)
+	}
+}
+
+func TestRealIP_TrustedProxies(t *testing.T) {
+	app := realIPApp(t)
+	if err := app.SetTrustedProxies("10.0.0.0/8", "192.168.1.5"); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		remote  string
+		headers map[string]string
+		want    string
+	}{
+		{"forwarded", "10.0.0.1:1234", map[string]string{"X-Forwarded-For": "1.2.3.4"}, "1.2.3.4"},
+		{"spoofed hop", "10.0.0.1:1234", map[string]string{"X-Forwarded-For": "6.6.6.6, 1.2.3.4, 10.0.0.2"}, "1.2.3.4"},
+		{"all trusted", "192.168.1.5:80", map[string]string{"X-Forwarded-For": "10.0.0.3, 10.0.0.2"}, "10.0.0.3"},
+		{"invalid hop", "10.0.0.1:1234", map[string]string{"X-Forwarded-For": "junk, 10.0.0.2"}, "10.0.0.2"},
+		{"real ip", "10.0.0.1:1234", map[string]string{"X-Real-IP": "5.6.7.8"}, "5.6.7.8"},
+		{"untrusted remote", "8.8.8.8:1234", map[string]string{"X-Forwarded-For": "1.2.3.4"}, "8.8.8.8"},
+		{"no headers", "10.0.0.1:1234", nil, "10.0.0.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := realIP(app, tt.remote, tt.headers); got != tt.want {
+				t.Fatalf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRealIP_Presets(t *testing.T) {
+	app := realIPApp(t).TrustCloudflare().TrustPrivateNetworks()
+
+	if got := realIP(app, "173.245.48.10:443", map[string]string{"CF-Connecting-IP": "1.2.3.4", "X-Forwarded-For": "6.6.6.6"}); got != "1.2.3.4" {
+		t.Fatalf("expected the Cloudflare header, got %q", got)
+	}
+	if got := realIP(app, "8.8.8.8:443", map[string]string{"CF-Connecting-IP": "1.2.3.4"}); got != "8.8.8.8" {
+		t.Fatalf("expected the header to be ignored from outside Cloudflare, got %q", got)
+	}
+	if got := realIP(app, "127.0.0.1:8080", map[string]string{"X-Forwarded-For": "1.2.3.4"}); got != "1.2.3.4" {
+		t.Fatalf("expected a private proxy to be trusted, got %q", got)
+	}
+}
+
+func TestSetTrustedProxies_Invalid(t *testing.T) {
+	app := New()
+	if err := app.SetTrustedProxies("not-an-ip"); err == nil {
+		t.Fatal("expected an error for an invalid proxy")
+	}
+	if err := app.SetTrustedProxies("10.0.0.0/33"); err == nil {
+		t.Fatal("expected an error for an invalid CIDR")
+	}
+}
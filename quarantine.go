@@ -0,0 +1,181 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ErrFileRejected is wrapped by FileScanners rejecting a file, which is then answered with 422
+// and never reaches the handler:
+//
+//	return fmt.Errorf("%w: %s", fluxo.ErrFileRejected, signature)
+var ErrFileRejected = errors.New("file rejected")
+
+// ScannedFile is an uploaded file waiting for a FileScanner's verdict
+type ScannedFile struct {
+	Field       string // form name of the part
+	Filename    string // as sent by the client
+	ContentType string // as declared by the client, or sniffed for UploadedFile fields
+	Size        int64
+	open        func() (io.ReadCloser, error)
+}
+
+// Open returns the content of the file; it may be called more than once
+func (f ScannedFile) Open() (io.ReadCloser, error) {
+	return f.open()
+}
+
+// FileScanner inspects uploaded files before handlers see them, e.g. with an antivirus. Scan
+// returns an error wrapping ErrFileRejected to reject a file, or an HTTPError to answer with it.
+// Other errors mean the file couldn't be scanned, and the request fails with 503.
+type FileScanner interface {
+	Scan(ctx context.Context, file ScannedFile) error
+}
+
+// FileScannerFunc adapts a function to FileScanner
+type FileScannerFunc func(ctx context.Context, file ScannedFile) error
+
+func (f FileScannerFunc) Scan(ctx context.Context, file ScannedFile) error {
+	return f(ctx, file)
+}
+
+// scanFile passes file to scanner, returning the HTTPError answering the request when the
+// file may not go on
+func scanFile(ctx *gin.Context, scanner FileScanner, file ScannedFile) error {
+	err := scanner.Scan(ctx.Request.Context(), file)
+	var httpErr HTTPError
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, ErrFileRejected):
+		return UnprocessableEntity(fmt.Sprintf("%s: %v", file.Filename, err))
+	case errors.As(err, &httpErr):
+		return httpErr
+	}
+	_ = ctx.Error(err)
+	return NewHTTPError(http.StatusServiceUnavailable, "uploaded files can't be scanned right now")
+}
+
+// WithTempDir makes the app write the temp files it creates for requests to dir instead of the
+// system's temp directory, such as the bodies spooled by RequireDigest and RequireSignature.
+// Start creates dir, failing when it can't. Files of *multipart.FileHeader fields are spooled
+// by the standard library's parser, which only knows os.TempDir: set TMPDIR for those.
+func WithTempDir(dir string) AppOption {
+	return func(c *appConfig) {
+		c.tempDir = dir
+	}
+}
+
+// createTempDir creates the directory set with WithTempDir, if any
+func (a *App) createTempDir() error {
+	if a.tempDir == "" {
+		return nil
+	}
+	if err := os.MkdirAll(a.tempDir, 0o700); err != nil {
+		return fmt.Errorf("fluxo: creating the temp dir: %w", err)
+	}
+	return nil
+}
+
+// requestTempDir returns the directory for the temp files of a request, see WithTempDir;
+// empty means the system's
+func requestTempDir(ctx *gin.Context) string {
+	if a, ok := appFrom(ctx); ok {
+		return a.tempDir
+	}
+	return ""
+}
+
+// TempUploadOption configures TempUploads
+type TempUploadOption func(*tempUploadConfig)
+
+type tempUploadConfig struct {
+	scanner FileScanner
+}
+
+// WithFileScanner passes every uploaded file through scanner before the handler runs
+func WithFileScanner(scanner FileScanner) TempUploadOption {
+	return func(c *tempUploadConfig) {
+		c.scanner = scanner
+	}
+}
+
+// TempUploads returns middleware parsing the multipart bodies of the routes after it, with their
+// *multipart.FileHeader files, up front. Files held in temp files are removed once the handlers
+// return, whatever they did with them, and with WithFileScanner every file is scanned first:
+//
+//	api.POST("/documents", fluxo.TempUploads(fluxo.WithFileScanner(clamav)), fluxo.Handle(uploadDocument))
+//
+// Routes with UploadedFile fields stream their files instead; scan those with WithUploadScanner.
+func TempUploads(opts ...TempUploadOption) gin.HandlerFunc {
+	cfg := tempUploadConfig{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return func(ctx *gin.Context) {
+		if ctx.ContentType() != gin.MIMEMultipartPOSTForm {
+			return
+		}
+		form, err := ctx.MultipartForm()
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": bindingFailed("Multipart", err)})
+			return
+		}
+		defer func() {
+			_ = form.RemoveAll()
+		}()
+		if cfg.scanner != nil {
+			for field, files := range form.File {
+				for _, fh := range files {
+					file := ScannedFile{
+						Field:       field,
+						Filename:    fh.Filename,
+						ContentType: fh.Header.Get("Content-Type"),
+						Size:        fh.Size,
+						open: func() (io.ReadCloser, error) {
+							return fh.Open()
+						},
+					}
+					if err := scanFile(ctx, cfg.scanner, file); err != nil {
+						writeError(ctx, err)
+						ctx.Abort()
+						return
+					}
+				}
+			}
+		}
+		ctx.Next()
+	}
+}
+
+// WithUploadScanner passes every file streamed to storage through scanner before the handler
+// runs; the files of a request with a rejected one are deleted
+func WithUploadScanner(scanner FileScanner) UploadOption {
+	return func(c *uploadConfig) {
+		c.scanner = scanner
+	}
+}
+
+// scanStored passes a file streamed to storage through the scanner of the uploads, if any
+func scanStored(ctx *gin.Context, cfg *uploadConfig, f UploadedFile) error {
+	if cfg.scanner == nil {
+		return nil
+	}
+	return scanFile(ctx, cfg.scanner, ScannedFile{
+		Field:       f.Field,
+		Filename:    f.Filename,
+		ContentType: f.ContentType,
+		Size:        f.Size,
+		open: func() (io.ReadCloser, error) {
+			return cfg.storage.Open(ctx.Request.Context(), f.Key)
+		},
+	})
+}
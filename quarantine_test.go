@@ -0,0 +1,152 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// eicarScanner rejects files holding the EICAR test signature, and fails on files named down.txt
+var eicarScanner = FileScannerFunc(func(ctx context.Context, file ScannedFile) error {
+	if file.Filename == "down.txt" {
+		return errors.New("clamd: connection refused")
+	}
+	r, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	data, _ := io.ReadAll(r)
+	if bytes.Contains(data, []byte("EICAR")) {
+		return fmt.Errorf("%w: Eicar-Test-Signature", ErrFileRejected)
+	}
+	return nil
+})
+
+func TestTempUploads(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := New(WithMaxMultipartMemory(1))
+
+	var spooled string
+	handled := 0
+	app.POST("/upload", TempUploads(WithFileScanner(eicarScanner)), Handle(func(ctx *Context, req uploadReq) (uploadRes, error) {
+		handled++
+		f, err := req.Photos[0].Open()
+		if err != nil {
+			return uploadRes{}, err
+		}
+		defer f.Close()
+		if file, ok := f.(*os.File); ok {
+			spooled = file.Name()
+		}
+		return uploadRes{Count: len(req.Photos)}, nil
+	}))
+
+	png := testFile{name: "a.png", contentType: "image/png", data: pngHeader}
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, multipartRequest(t, "photos", png))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status=%d body=%s", w.Code, w.Body)
+	}
+	if spooled == "" {
+		t.Fatal("expected the file spooled to disk")
+	}
+	if _, err := os.Stat(spooled); !os.IsNotExist(err) {
+		t.Errorf("expected the temp file removed after the handler, got %v", err)
+	}
+
+	infected := testFile{name: "b.png", contentType: "image/png", data: append(append([]byte{}, pngHeader...), "EICAR"...)}
+	w = httptest.NewRecorder()
+	app.ServeHTTP(w, multipartRequest(t, "photos", png, infected))
+	if w.Code != http.StatusUnprocessableEntity || !strings.Contains(w.Body.String(), "b.png: file rejected: Eicar-Test-Signature") {
+		t.Errorf("expected the infected file rejected, got %d %s", w.Code, w.Body)
+	}
+	w = httptest.NewRecorder()
+	app.ServeHTTP(w, multipartRequest(t, "photos", testFile{name: "down.txt", contentType: "image/png", data: pngHeader}))
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected 503 when scanning fails, got %d %s", w.Code, w.Body)
+	}
+	if handled != 1 {
+		t.Errorf("rejected files reached the handler")
+	}
+}
+
+func TestWithTempDir(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	dir := filepath.Join(t.TempDir(), "spool")
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	app := New(WithTempDir(dir))
+	var spooled string
+	app.POST("/blobs", RequireDigest(), func(ctx *gin.Context) {
+		if body, ok := ctx.Request.Body.(*spooledBody); ok && body.file != nil {
+			spooled = body.file.Name()
+		}
+		ctx.Status(http.StatusNoContent)
+	})
+
+	body := bytes.Repeat([]byte("x"), digestMemoryLimit+1)
+	req := httptest.NewRequest(http.MethodPost, "/blobs", bytes.NewReader(body))
+	req.Header.Set(HeaderContentMD5, md5Header(body))
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, req)
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("status=%d body=%s", w.Code, w.Body)
+	}
+	if filepath.Dir(spooled) != dir {
+		t.Errorf("expected the body spooled to %s, got %q", dir, spooled)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("temp files were left behind: %v", entries)
+	}
+}
+
+func TestWithTempDir_StartFails(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(file, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	app := New(WithMode(gin.TestMode), WithQuietStart(), WithTempDir(filepath.Join(file, "spool")))
+	if err := app.Start("127.0.0.1:0"); err == nil || !strings.Contains(err.Error(), "temp dir") {
+		t.Fatalf("expected Start to fail creating the temp dir, got %v", err)
+	}
+}
+
+func TestUploads_Scanner(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	dir := t.TempDir()
+	app := New()
+	app.POST("/profile", Uploads(NewLocalStorage(dir), WithUploadScanner(eicarScanner)), Handle(func(ctx *Context, req streamReq) (streamRes, error) {
+		return streamRes(req), nil
+	}))
+
+	png := testFile{name: "me.png", contentType: "image/png", data: pngHeader}
+	infected := testFile{name: "b.png", contentType: "image/png", data: append(append([]byte{}, pngHeader...), "EICAR"...)}
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, streamRequest(t, "hello", png, infected))
+	if w.Code != http.StatusUnprocessableEntity || !strings.Contains(w.Body.String(), "b.png: file rejected") {
+		t.Fatalf("expected the infected file rejected, got %d %s", w.Code, w.Body)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("the files of the rejected request were kept: %v", entries)
+	}
+
+	w = httptest.NewRecorder()
+	app.ServeHTTP(w, streamRequest(t, "hello", png))
+	if w.Code != http.StatusOK {
+		t.Errorf("expected clean files stored, got %d %s", w.Code, w.Body)
+	}
+}
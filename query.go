@@ -0,0 +1,87 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"slices"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	strictQueryKey = "fluxo.strict_query"
+	queryPlansKey  = "fluxo.query_plans" // plans of the typed middleware a request went through
+)
+
+// StrictQuery returns middleware making the fluxo handlers after it reject requests with query
+// parameters that neither the request type nor the typed middleware of the route read, with a
+// 400 listing them; false turns off strictness set further out:
+//
+//	app.GET("/todos", fluxo.StrictQuery(true), fluxo.Handle(listTodos))
+//
+// The parameters fluxo reads itself (fields, view) and the global ones of the spec are accepted.
+// Request types bound by gin rather than a compiled plan accept anything.
+func StrictQuery(enabled bool) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Set(strictQueryKey, enabled)
+	}
+}
+
+// WithStrictQuery rejects unknown query parameters in routes registered afterwards
+func (a *App) WithStrictQuery() *App {
+	a.router.Use(StrictQuery(true))
+	return a
+}
+
+// WithStrictQuery rejects unknown query parameters in the group's routes registered afterwards
+func (g *Group) WithStrictQuery() *Group {
+	g.RouterGroup.Use(StrictQuery(true))
+	return g
+}
+
+// noteQueryPlan records that typed middleware of the request reads the query keys of plan
+func noteQueryPlan(ctx *gin.Context, plan *bindingPlan) {
+	if !ctx.GetBool(strictQueryKey) {
+		return
+	}
+	plans, _ := ctx.Value(queryPlansKey).([]*bindingPlan)
+	ctx.Set(queryPlansKey, append(plans, plan))
+}
+
+// unknownQuery returns the query parameters of a strict request that no plan of it reads, sorted
+func unknownQuery(ctx *gin.Context, plan *bindingPlan) []string {
+	if !ctx.GetBool(strictQueryKey) || ctx.Request.URL.RawQuery == "" {
+		return nil
+	}
+	plans, _ := ctx.Value(queryPlansKey).([]*bindingPlan)
+	plans = append(plans[:len(plans):len(plans)], plan)
+	for _, p := range plans {
+		if !p.compiled || p.query.keys == nil {
+			return nil
+		}
+	}
+
+	var unknown []string
+	for key := range ctx.Request.URL.Query() {
+		if !queryKnown(ctx, plans, nestedKey(key)) {
+			unknown = append(unknown, key)
+		}
+	}
+	slices.Sort(unknown)
+	return unknown
+}
+
+func queryKnown(ctx *gin.Context, plans []*bindingPlan, key string) bool {
+	if key == FieldsQueryParam || key == ViewQueryParam {
+		return key != ""
+	}
+	for _, p := range plans {
+		if p.query.reads(key) {
+			return true
+		}
+	}
+	if a, ok := appFrom(ctx); ok && a.swagger != nil {
+		return hasParameter(a.swagger.globals, key, "query")
+	}
+	return false
+}
@@ -0,0 +1,90 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type queryListReq struct {
+	Limit  int    `form:"limit,default=20" alias:"per_page,pageSize"`
+	Status string `form:"status"`
+	Filter struct {
+		Owner string `form:"owner" alias:"user"`
+	} `form:"filter"`
+	Labels map[string]string `form:"labels"`
+}
+
+type queryTenantReq struct {
+	Tenant string `form:"tenant"`
+}
+
+func queryApp(strict bool) (*App, *queryListReq) {
+	gin.SetMode(gin.TestMode)
+	app := New().WithSwagger("API", "1.0", WithGlobalQuery("debug", false, Schema{Type: "boolean"}))
+	if strict {
+		app.WithStrictQuery()
+	}
+	var got queryListReq
+	app.GET("/todos", Middleware(func(ctx *Context, req queryTenantReq) error { return nil }), Handle(func(ctx *Context, req queryListReq) ([]string, error) {
+		got = req
+		return []string{}, nil
+	}))
+	return app, &got
+}
+
+func TestQueryAliases(t *testing.T) {
+	app, got := queryApp(false)
+	cases := map[string]int{
+		"/todos?limit=5":            5,
+		"/todos?per_page=6":         6,
+		"/todos?pageSize=7":         7,
+		"/todos?limit=5&per_page=6": 5,
+		"/todos":                    20,
+	}
+	for path, want := range cases {
+		if w := get(app, path); w.Code != http.StatusOK || got.Limit != want {
+			t.Errorf("GET %s = %d, limit %d, want %d", path, w.Code, got.Limit, want)
+		}
+	}
+	if get(app, "/todos?filter[user]=alice"); got.Filter.Owner != "alice" {
+		t.Errorf("nested alias: owner = %q", got.Filter.Owner)
+	}
+
+	spec, err := app.swagger.parsedSpec(app.specHandlers())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range spec.Paths["/todos"].GET.Parameters {
+		if p.Name == "limit" && p.Description != "Also accepted as per_page, pageSize" {
+			t.Errorf("limit description = %q", p.Description)
+		}
+	}
+}
+
+func TestStrictQuery(t *testing.T) {
+	app, _ := queryApp(true)
+	for _, path := range []string{
+		"/todos?limit=5&status=done&tenant=acme",
+		"/todos?per_page=5&filter[owner]=me&labels[team]=core&labels.env=prod",
+		"/todos?fields=id&view=summary&debug=true",
+	} {
+		if w := get(app, path); w.Code != http.StatusOK {
+			t.Errorf("GET %s = %d: %s", path, w.Code, w.Body)
+		}
+	}
+
+	w := get(app, "/todos?limt=5&status=done&filter[ownr]=me")
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "filter[ownr], limt") {
+		t.Errorf("unknown parameters = %d: %s", w.Code, w.Body)
+	}
+
+	lax, _ := queryApp(false)
+	if w := get(lax, "/todos?limt=5"); w.Code != http.StatusOK {
+		t.Errorf("lax route = %d", w.Code)
+	}
+}
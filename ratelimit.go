@@ -0,0 +1,101 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimit returns middleware limiting each client IP, as RealIP sees it, to rate requests per
+// second with bursts of burst. Requests over the limit get 429 with a Retry-After header. Used
+// with UseTyped or on a Group, the 429 response is documented on every operation.
+func RateLimit(rate float64, burst int) gin.HandlerFunc {
+	if rate <= 0 {
+		panic("fluxo: RateLimit needs a positive rate")
+	}
+	var buckets rateBuckets
+	handler := func(ctx *gin.Context) {
+		ip := (&Context{ctx}).RealIP()
+		if wait, ok := buckets.allow(ip, rate, burst); !ok {
+			ctx.Header("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+			ctx.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "Too many requests"})
+		}
+	}
+	registerOperationDoc(handler, func(_ *SwaggerGenerator, op *Operation) {
+		if op.Responses == nil {
+			op.Responses = map[string]Response{}
+		}
+		op.Responses["429"] = Response{Description: "The client's rate limit is exceeded"}
+	})
+	return handler
+}
+
+// maxRateBuckets bounds a rate limiter's memory; beyond it, buckets that refilled are dropped
+const maxRateBuckets = 10000
+
+// rateBuckets holds a token bucket per key, e.g. a tenant or a client IP
+type rateBuckets struct {
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+}
+
+// allow takes a token from the bucket of key, or returns how long until one is available
+func (r *rateBuckets) allow(key string, rate float64, burst int) (time.Duration, bool) {
+	if rate <= 0 {
+		return 0, true
+	}
+	burst = max(burst, 1)
+
+	now := time.Now()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	b, ok := r.buckets[key]
+	if !ok {
+		if r.buckets == nil {
+			r.buckets = make(map[string]*tokenBucket)
+		}
+		if len(r.buckets) >= maxRateBuckets {
+			r.prune(now)
+		}
+		b = &tokenBucket{tokens: float64(burst), last: now}
+		r.buckets[key] = b
+	}
+	return b.take(now, rate, float64(burst))
+}
+
+func (r *rateBuckets) prune(now time.Time) {
+	for key, b := range r.buckets {
+		if b.full(now) {
+			delete(r.buckets, key)
+		}
+	}
+}
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+	rate   float64
+	burst  float64
+}
+
+func (b *tokenBucket) take(now time.Time, rate, burst float64) (time.Duration, bool) {
+	b.rate, b.burst = rate, burst
+	b.tokens = min(burst, b.tokens+now.Sub(b.last).Seconds()*rate)
+	b.last = now
+	if b.tokens < 1 {
+		return time.Duration((1 - b.tokens) / rate * float64(time.Second)), false
+	}
+	b.tokens--
+	return 0, true
+}
+
+// full reports whether the bucket refilled, so that dropping it changes nothing
+func (b *tokenBucket) full(now time.Time) bool {
+	return b.tokens+now.Sub(b.last).Seconds()*b.rate >= b.burst
+}
@@ -0,0 +1,46 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRateLimit(t *testing.T) {
+	app := New(WithMode(gin.TestMode)).WithSwagger("API", "1.0")
+	app.UseTyped(RateLimit(1, 2))
+	app.GET("/todos", Handle(func(ctx *Context, _ struct{}) ([]string, error) {
+		return []string{}, nil
+	}))
+
+	from := func(remote string) int {
+		req := httptest.NewRequest("GET", "/todos", nil)
+		req.RemoteAddr = remote
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+		if w.Code == http.StatusTooManyRequests && w.Header().Get("Retry-After") != "1" {
+			t.Errorf("Retry-After = %q", w.Header().Get("Retry-After"))
+		}
+		return w.Code
+	}
+	for i, want := range []int{200, 200, 429} {
+		if got := from("10.0.0.1:1234"); got != want {
+			t.Errorf("request %d = %d, want %d", i, got, want)
+		}
+	}
+	if got := from("10.0.0.2:1234"); got != http.StatusOK {
+		t.Errorf("other client = %d", got)
+	}
+
+	spec, err := app.swagger.parsedSpec(app.specHandlers())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := spec.Paths["/todos"].GET.Responses["429"]; !ok {
+		t.Error("429 not documented")
+	}
+}
@@ -0,0 +1,344 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Recording is a request served by a route and the response it got, see Recorder
+type Recording struct {
+	Time           time.Time     `json:"time"`
+	Method         string        `json:"method"`
+	Route          string        `json:"route"` // the route path, like /users/:id
+	URL            string        `json:"url"`
+	Header         http.Header   `json:"header,omitempty"`
+	Body           string        `json:"body,omitempty"`
+	BodyTruncated  bool          `json:"body_truncated,omitempty"` // the body was over the limit and is left out
+	Status         int           `json:"status"`
+	ResponseHeader http.Header   `json:"response_header,omitempty"`
+	Response       string        `json:"response,omitempty"`
+	Duration       time.Duration `json:"duration"`
+}
+
+// clone copies rec, so that its headers can be changed before replaying it
+func (rec Recording) clone() Recording {
+	rec.Header = rec.Header.Clone()
+	rec.ResponseHeader = rec.ResponseHeader.Clone()
+	return rec
+}
+
+// Redacted replaces the values of sanitized headers, query parameters and JSON fields
+const Redacted = "[REDACTED]"
+
+// RecorderOption configures a Recorder
+type RecorderOption func(*Recorder)
+
+// WithRecordSize sets how many recordings are kept per route, 50 by default
+func WithRecordSize(n int) RecorderOption {
+	return func(r *Recorder) {
+		r.size = n
+	}
+}
+
+// WithRecordBodyLimit sets the largest request and response bodies recorded, 64KB by default
+func WithRecordBodyLimit(n int) RecorderOption {
+	return func(r *Recorder) {
+		r.bodyLimit = n
+	}
+}
+
+// WithRecordFile also writes every recording to w as a line of JSON, see ReadRecordings
+func WithRecordFile(w io.Writer) RecorderOption {
+	return func(r *Recorder) {
+		r.file = w
+	}
+}
+
+// WithRedactedHeaders adds headers to redact, besides Authorization, Cookie, Set-Cookie,
+// Proxy-Authorization and X-Api-Key
+func WithRedactedHeaders(names ...string) RecorderOption {
+	return func(r *Recorder) {
+		for _, name := range names {
+			r.headers = append(r.headers, http.CanonicalHeaderKey(name))
+		}
+	}
+}
+
+// WithRedactedFields adds to the words marking JSON fields and query parameters to redact.
+// Names containing password, secret, token or api_key are redacted by default.
+func WithRedactedFields(words ...string) RecorderOption {
+	return func(r *Recorder) {
+		for _, word := range words {
+			r.fields = append(r.fields, strings.ToLower(word))
+		}
+	}
+}
+
+// Recorder keeps the latest requests of each route with their responses, sanitized, so that a
+// bug report can be reproduced with Replay. Record them with its middleware:
+//
+//	rec := fluxo.NewRecorder()
+//	app.Use(rec.Middleware())
+//	app.GET("/debug/recordings", rec.Handler())
+type Recorder struct {
+	size      int
+	bodyLimit int
+	headers   []string // canonical names of the headers to redact
+	fields    []string // lowercase words marking the fields to redact
+	file      io.Writer
+
+	mu     sync.Mutex
+	routes map[string][]Recording // "METHOD route" -> recordings, oldest first
+}
+
+// NewRecorder returns a recorder keeping the latest requests of every route
+func NewRecorder(opts ...RecorderOption) *Recorder {
+	r := &Recorder{
+		size:      50,
+		bodyLimit: 64 << 10,
+		headers:   []string{"Authorization", "Cookie", "Set-Cookie", "Proxy-Authorization", "X-Api-Key"},
+		fields:    []string{"password", "secret", "token", "api_key", "apikey"},
+		routes:    make(map[string][]Recording),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Middleware records the requests of the routes after it. Requests matching no route are not recorded.
+func (r *Recorder) Middleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		start := time.Now()
+		body, truncated := r.peekBody(ctx.Request)
+		w := &copyWriter{ResponseWriter: ctx.Writer, limit: r.bodyLimit}
+		ctx.Writer = w
+		ctx.Next()
+		ctx.Writer = w.ResponseWriter
+
+		if ctx.FullPath() == "" {
+			return
+		}
+		pii := routePII(ctx)
+		rec := Recording{
+			Time:           start,
+			Method:         ctx.Request.Method,
+			Route:          ctx.FullPath(),
+			URL:            r.redactURL(ctx.Request.URL, pii),
+			Header:         r.redactHeader(ctx.Request.Header, pii),
+			Body:           r.redactBody(ctx.ContentType(), body, pii),
+			BodyTruncated:  truncated,
+			Status:         w.Status(),
+			ResponseHeader: r.redactHeader(w.Header(), pii),
+			Response:       r.redactBody(w.Header().Get("Content-Type"), w.body.Bytes(), pii),
+			Duration:       time.Since(start),
+		}
+		r.add(rec)
+	}
+}
+
+// peekBody reads up to the body limit of the request, leaving the body readable by handlers
+func (r *Recorder) peekBody(req *http.Request) ([]byte, bool) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, false
+	}
+	data, err := io.ReadAll(io.LimitReader(req.Body, int64(r.bodyLimit)+1))
+	req.Body = readCloser{Reader: io.MultiReader(bytes.NewReader(data), req.Body), Closer: req.Body}
+	if err != nil || len(data) > r.bodyLimit {
+		return nil, true
+	}
+	return data, false
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+func (r *Recorder) add(rec Recording) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := rec.Method + " " + rec.Route
+	recs := append(r.routes[key], rec)
+	if len(recs) > r.size {
+		recs = recs[len(recs)-r.size:]
+	}
+	r.routes[key] = recs
+	if r.file != nil {
+		_ = json.NewEncoder(r.file).Encode(rec)
+	}
+}
+
+// Recordings returns the recordings of a route, like ("GET", "/users/:id"), oldest first
+func (r *Recorder) Recordings(method, route string) []Recording {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	recs := make([]Recording, len(r.routes[method+" "+route]))
+	for i, rec := range r.routes[method+" "+route] {
+		recs[i] = rec.clone()
+	}
+	return recs
+}
+
+// All returns the recordings of every route, oldest first
+func (r *Recorder) All() []Recording {
+	r.mu.Lock()
+	var all []Recording
+	for _, recs := range r.routes {
+		for _, rec := range recs {
+			all = append(all, rec.clone())
+		}
+	}
+	r.mu.Unlock()
+	sort.SliceStable(all, func(i, j int) bool { return all[i].Time.Before(all[j].Time) })
+	return all
+}
+
+// Handler serves the recordings as JSON; the method and route query parameters narrow them to a route
+func (r *Recorder) Handler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		recs := r.All()
+		if method, route := ctx.Query("method"), ctx.Query("route"); method != "" || route != "" {
+			filtered := recs[:0]
+			for _, rec := range recs {
+				if (method == "" || strings.EqualFold(rec.Method, method)) && (route == "" || rec.Route == route) {
+					filtered = append(filtered, rec)
+				}
+			}
+			recs = filtered
+		}
+		ctx.JSON(http.StatusOK, recs)
+	}
+}
+
+// Replay serves the request of rec again with h, usually an App, and returns the response.
+// Redacted values are sent as they are, so set the credentials the request needs first:
+//
+//	rec.Header.Set("Authorization", "Bearer "+testToken)
+//	res := fluxo.Replay(app, rec)
+func Replay(h http.Handler, rec Recording) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(rec.Method, rec.URL, strings.NewReader(rec.Body))
+	for name, values := range rec.Header {
+		req.Header[name] = append([]string(nil), values...)
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	return w
+}
+
+// ReadRecordings reads the recordings written by WithRecordFile
+func ReadRecordings(r io.Reader) ([]Recording, error) {
+	var recs []Recording
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 16<<20)
+	for scanner.Scan() {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var rec Recording
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			return recs, err
+		}
+		recs = append(recs, rec)
+	}
+	return recs, scanner.Err()
+}
+
+// sensitive reports whether a field or parameter called name is redacted, as it is personal
+// data of the route or contains one of the redacted words
+func (r *Recorder) sensitive(name string, pii *piiNames) bool {
+	name = strings.ToLower(name)
+	if pii.fields[name] {
+		return true
+	}
+	for _, word := range r.fields {
+		if strings.Contains(name, word) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Recorder) redactHeader(h http.Header, pii *piiNames) http.Header {
+	out := h.Clone()
+	for _, name := range slices.Concat(r.headers, pii.headers) {
+		if _, ok := out[name]; ok {
+			out[name] = []string{Redacted}
+		}
+	}
+	return out
+}
+
+func (r *Recorder) redactURL(u *url.URL, pii *piiNames) string {
+	query := u.Query()
+	redacted := false
+	for name := range query {
+		if r.sensitive(name, pii) {
+			query[name] = []string{Redacted}
+			redacted = true
+		}
+	}
+	if !redacted {
+		return u.RequestURI()
+	}
+	out := *u
+	out.RawQuery = query.Encode()
+	return out.RequestURI()
+}
+
+// redactBody redacts the sensitive fields of a JSON body; other bodies are kept as they are
+func (r *Recorder) redactBody(contentType string, body []byte, pii *piiNames) string {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	if len(body) == 0 || !isJSONMediaType(strings.TrimSpace(mediaType)) {
+		return string(body)
+	}
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil || !redactFields(v, func(name string) bool { return r.sensitive(name, pii) }) {
+		return string(body)
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return string(body)
+	}
+	return string(data)
+}
+
+// redactFields redacts the sensitive fields of a decoded JSON value in place, reporting whether
+// it found any
+func redactFields(v any, sensitive func(name string) bool) bool {
+	redacted := false
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if sensitive(key) {
+				v[key] = Redacted
+				redacted = true
+			} else if redactFields(value, sensitive) {
+				redacted = true
+			}
+		}
+	case []any:
+		for _, item := range v {
+			if redactFields(item, sensitive) {
+				redacted = true
+			}
+		}
+	}
+	return redacted
+}
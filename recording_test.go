@@ -0,0 +1,97 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type recordLogin struct {
+	User     string `json:"user"`
+	Password string `json:"password"`
+	Count    int64  `json:"count"`
+}
+
+func recordApp(opts ...RecorderOption) (*App, *Recorder) {
+	gin.SetMode(gin.TestMode)
+	app := New()
+	rec := NewRecorder(opts...)
+	app.Use(rec.Middleware())
+	app.POST("/login/:team", Handle(func(ctx *Context, req recordLogin) (gin.H, error) {
+		if req.Password != "hunter2" {
+			return nil, NewHTTPError(http.StatusUnauthorized, "bad password")
+		}
+		return gin.H{"user": req.User, "count": req.Count, "access_token": "t0k3n"}, nil
+	}))
+	app.GET("/debug/recordings", rec.Handler())
+	return app, rec
+}
+
+func TestRecorder(t *testing.T) {
+	var file bytes.Buffer
+	app, rec := recordApp(WithRecordSize(2), WithRecordFile(&file), WithRedactedHeaders("X-Session"))
+
+	for _, password := range []string{"nope", "hunter2", "hunter2"} {
+		req := httptest.NewRequest(http.MethodPost, "/login/blue?api_key=k&page=2", strings.NewReader(`{"user":"ann","password":"`+password+`","count":9007199254740993}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer secret")
+		req.Header.Set("X-Session", "s")
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+		if password == "hunter2" && w.Code != http.StatusOK {
+			t.Fatalf("expected the handler to read the body, got %d %s", w.Code, w.Body.String())
+		}
+	}
+	app.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/missing", nil))
+
+	recs := rec.Recordings(http.MethodPost, "/login/:team")
+	if len(recs) != 2 {
+		t.Fatalf("expected the latest two recordings, got %d", len(recs))
+	}
+	got := recs[1]
+	if got.URL != "/login/blue?api_key=%5BREDACTED%5D&page=2" || got.Status != http.StatusOK {
+		t.Errorf("unexpected recording %+v", got)
+	}
+	if got.Header.Get("Authorization") != Redacted || got.Header.Get("X-Session") != Redacted || got.Header.Get("Content-Type") != "application/json" {
+		t.Errorf("expected credentials to be redacted, got %v", got.Header)
+	}
+	if got.Body != `{"count":9007199254740993,"password":"[REDACTED]","user":"ann"}` {
+		t.Errorf("expected the password to be redacted, got %s", got.Body)
+	}
+	if !strings.Contains(got.Response, `"access_token":"[REDACTED]"`) {
+		t.Errorf("expected the token to be redacted, got %s", got.Response)
+	}
+
+	fromFile, err := ReadRecordings(&file)
+	if err != nil || len(fromFile) != 3 || fromFile[0].Status != http.StatusUnauthorized {
+		t.Fatalf("expected every recording in the file, got %d %v", len(fromFile), err)
+	}
+
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/debug/recordings?route=/login/:team", nil))
+	if w.Code != http.StatusOK || strings.Count(w.Body.String(), `"route":"/login/:team"`) != 2 {
+		t.Errorf("expected the recordings to be served, got %s", w.Body.String())
+	}
+}
+
+func TestReplay(t *testing.T) {
+	app, rec := recordApp()
+	req := httptest.NewRequest(http.MethodPost, "/login/blue", strings.NewReader(`{"user":"ann","password":"hunter2"}`))
+	req.Header.Set("Content-Type", "application/json")
+	app.ServeHTTP(httptest.NewRecorder(), req)
+
+	recorded := rec.Recordings(http.MethodPost, "/login/:team")[0]
+	if w := Replay(app, recorded); w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected the redacted password to be replayed, got %d", w.Code)
+	}
+	recorded.Body = `{"user":"ann","password":"hunter2"}`
+	if w := Replay(app, recorded); w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"user":"ann"`) {
+		t.Fatalf("expected the replay to succeed, got %d %s", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"fmt"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PanicError is a panic caught by Recovery, as the OnError hooks see it
+type PanicError struct {
+	Value any    // what was passed to panic
+	Stack []byte // the stack of the panicking goroutine
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// Unwrap returns the value of the panic when it is an error
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// Recovery returns middleware turning panics of the handlers after it into a 500 HTTPError,
+// written with the request's error encoder. In gin's debug mode the error carries the panic
+// and its stack; otherwise it only says "Internal Server Error". Panics are logged with the
+// app's logger and passed to its OnError hooks as a *PanicError.
+//
+// A response already partly written can't be replaced, so the connection is dropped instead,
+// letting the client see the failure rather than a truncated body it could take for a whole one.
+func Recovery() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		defer func() {
+			p := recover()
+			if p == nil {
+				return
+			}
+			if p == http.ErrAbortHandler {
+				panic(p)
+			}
+			perr := &PanicError{Value: p, Stack: debug.Stack()}
+
+			app, ok := appFrom(ctx)
+			if ok {
+				app.Logger().Error("fluxo: panic recovered", "panic", fmt.Sprint(p), "method", ctx.Request.Method, "path", ctx.Request.URL.Path, "stack", string(perr.Stack))
+				app.reportError(ctx, perr)
+			}
+
+			ctx.Abort()
+			if ctx.Writer.Written() {
+				panic(http.ErrAbortHandler)
+			}
+			httpErr := InternalServerError(http.StatusText(http.StatusInternalServerError))
+			if gin.IsDebugging() {
+				httpErr.Message, httpErr.Stack = perr.Error(), string(perr.Stack)
+			}
+			encodeError(ctx, httpErr)
+		}()
+		ctx.Next()
+	}
+}
+
+// WithRecovery recovers from panics of routes registered afterwards, see Recovery. Call it
+// before adding other middleware, so that theirs are caught too.
+func (a *App) WithRecovery() *App {
+	a.router.Use(Recovery())
+	return a
+}
+
+// OnError registers fn to be told of the errors returned by handlers and typed middleware and of
+// the panics caught by Recovery, e.g. to report them to an error tracker. It runs before the
+// error is written.
+func (a *App) OnError(fn func(ctx *Context, err error)) {
+	a.errorHooks = append(a.errorHooks, fn)
+}
+
+// reportError passes err to the OnError hooks
+func (a *App) reportError(ctx *gin.Context, err error) {
+	for _, hook := range a.errorHooks {
+		hook(&Context{Context: ctx}, err)
+	}
+}
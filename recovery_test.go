@@ -0,0 +1,109 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// errorLog collects the errors reported to OnError hooks, which may run on server goroutines
+type errorLog struct {
+	mu     sync.Mutex
+	errors []error
+}
+
+func (l *errorLog) add(err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.errors = append(l.errors, err)
+}
+
+func (l *errorLog) all() []error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return slices.Clone(l.errors)
+}
+
+func recoveryApp(t *testing.T, mode string, reported *errorLog) *App {
+	app := New(WithMode(mode), WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil)))).WithRecovery()
+	app.OnError(func(ctx *Context, err error) {
+		reported.add(err)
+	})
+	app.GET("/boom", Handle(func(ctx *Context, _ struct{}) (string, error) {
+		panic("boom")
+	}))
+	app.GET("/missing", Handle(func(ctx *Context, _ struct{}) (string, error) {
+		return "", NotFound("no todo")
+	}))
+	app.GET("/partial", func(ctx *gin.Context) {
+		ctx.Writer.WriteHeader(http.StatusOK)
+		ctx.Writer.WriteString(`{"items":[`)
+		ctx.Writer.Flush()
+		panic(errors.New("stream broke"))
+	})
+	t.Cleanup(func() { gin.SetMode(gin.TestMode) })
+	return app
+}
+
+func TestRecovery(t *testing.T) {
+	var log errorLog
+	app := recoveryApp(t, gin.ReleaseMode, &log)
+
+	w := get(app, "/boom")
+	if w.Code != http.StatusInternalServerError || strings.TrimSpace(w.Body.String()) != `{"status":500,"message":"Internal Server Error"}` {
+		t.Errorf("panic = %d: %s", w.Code, w.Body)
+	}
+	get(app, "/missing")
+
+	reported := log.all()
+	if len(reported) != 2 {
+		t.Fatalf("reported %v", reported)
+	}
+	var perr *PanicError
+	if !errors.As(reported[0], &perr) || perr.Value != "boom" || len(perr.Stack) == 0 {
+		t.Errorf("panic reported as %#v", reported[0])
+	}
+	if httpErr, ok := reported[1].(HTTPError); !ok || httpErr.Status != http.StatusNotFound {
+		t.Errorf("error reported as %#v", reported[1])
+	}
+}
+
+func TestRecovery_DebugStack(t *testing.T) {
+	app := recoveryApp(t, gin.DebugMode, &errorLog{})
+
+	w := get(app, "/boom")
+	body := w.Body.String()
+	if w.Code != http.StatusInternalServerError || !strings.Contains(body, `"message":"panic: boom"`) || !strings.Contains(body, `"stack":"goroutine`) {
+		t.Errorf("panic = %d: %s", w.Code, body)
+	}
+}
+
+func TestRecovery_PartialWrite(t *testing.T) {
+	var log errorLog
+	app := recoveryApp(t, gin.ReleaseMode, &log)
+	srv := httptest.NewServer(app)
+	defer srv.Close()
+
+	res, err := http.Get(srv.URL + "/partial")
+	if err == nil {
+		_, err = io.ReadAll(res.Body)
+		res.Body.Close()
+	}
+	if err == nil {
+		t.Error("truncated response read as a whole one")
+	}
+	srv.Close() // waits for the handler to report
+	if reported := log.all(); len(reported) != 1 || !strings.Contains(reported[0].Error(), "stream broke") {
+		t.Errorf("reported %v", reported)
+	}
+}
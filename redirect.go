@@ -0,0 +1,58 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Redirection is a typed handler response sending the client to another URL. It has no body
+// of its own; see Redirect.
+type Redirection struct {
+	Status   int
+	Location string
+}
+
+var redirectionType = reflect.TypeOf(Redirection{})
+
+// Redirect returns a response redirecting to location with status, a 3xx code:
+//
+//	app.GET("/go/:code", fluxo.Handle(func(ctx *fluxo.Context, req ShortLinkReq) (fluxo.Redirection, error) {
+//		link, err := links.Find(ctx, req.Code)
+//		if err != nil {
+//			return fluxo.Redirection{}, err
+//		}
+//		return fluxo.Redirect(http.StatusFound, link.URL), nil
+//	}))
+//
+// Relative locations are resolved against the request path like http.Redirect does. The spec
+// documents the operation's 3XX response with its Location header.
+func Redirect(status int, location string) Redirection {
+	if status < http.StatusMultipleChoices || status > http.StatusPermanentRedirect {
+		panic(fmt.Sprintf("fluxo: cannot redirect with status %d", status))
+	}
+	return Redirection{Status: status, Location: location}
+}
+
+// StatusCode is the redirect's status, 302 Found unless Redirect set another one
+func (r Redirection) StatusCode() int {
+	if r.Status == 0 {
+		return http.StatusFound
+	}
+	return r.Status
+}
+
+// render writes the redirect, with the short HTML body http.Redirect gives GET requests
+func (r Redirection) render(ctx *gin.Context) {
+	ctx.Redirect(r.StatusCode(), r.Location)
+}
+
+func (r Redirection) responseHeaders() map[string]Header {
+	return map[string]Header{
+		"Location": {Description: "URL the client is redirected to", Schema: Schema{Type: "string"}},
+	}
+}
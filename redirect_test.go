@@ -0,0 +1,92 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type shortLinkReq struct {
+	Code string `uri:"code"`
+}
+
+func redirectApp() *App {
+	gin.SetMode(gin.TestMode)
+	app := New().WithSwagger("Links", "1.0")
+	app.GET("/go/:code", Handle(func(ctx *Context, req shortLinkReq) (Redirection, error) {
+		switch req.Code {
+		case "docs":
+			return Redirect(http.StatusMovedPermanently, "https://example.com/docs"), nil
+		case "home":
+			return Redirection{Location: "/"}, nil
+		}
+		return Redirection{}, NotFound("no such link")
+	}))
+	app.POST("/orders", Handle(func(ctx *Context, _ struct{}) (Redirection, error) {
+		return Redirect(http.StatusSeeOther, "/orders/42"), nil
+	}))
+	return app
+}
+
+func TestRedirect(t *testing.T) {
+	app := redirectApp()
+
+	w := get(app, "/go/docs")
+	if w.Code != http.StatusMovedPermanently || w.Header().Get("Location") != "https://example.com/docs" {
+		t.Errorf("GET /go/docs = %d to %q", w.Code, w.Header().Get("Location"))
+	}
+	if strings.Contains(w.Body.String(), `"Location"`) {
+		t.Errorf("redirect rendered as JSON: %s", w.Body)
+	}
+	if w := get(app, "/go/home"); w.Code != http.StatusFound || w.Header().Get("Location") != "/" {
+		t.Errorf("GET /go/home = %d to %q", w.Code, w.Header().Get("Location"))
+	}
+	if w := get(app, "/go/nope"); w.Code != http.StatusNotFound {
+		t.Errorf("GET /go/nope = %d, want 404", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest("POST", "/orders", nil))
+	if w.Code != http.StatusSeeOther || w.Header().Get("Location") != "/orders/42" {
+		t.Errorf("POST /orders = %d to %q", w.Code, w.Header().Get("Location"))
+	}
+}
+
+func TestRedirect_InvalidStatus(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Redirect accepted status 200")
+		}
+	}()
+	Redirect(http.StatusOK, "/")
+}
+
+func TestRedirect_Swagger(t *testing.T) {
+	app := redirectApp()
+	spec, err := app.swagger.parsedSpec(app.specHandlers())
+	if err != nil {
+		t.Fatal(err)
+	}
+	op := spec.Paths["/go/{code}"].GET
+	if op == nil {
+		t.Fatal("missing operation")
+	}
+	res, ok := op.Responses["3XX"]
+	if !ok || len(res.Content) != 0 {
+		t.Fatalf("responses = %+v, want a bodiless 3XX", op.Responses)
+	}
+	if _, ok := res.Headers["Location"]; !ok {
+		t.Error("3XX response lacks the Location header")
+	}
+	if _, ok := op.Responses["200"]; ok || hasParameter(op.Parameters, "fields", "query") {
+		t.Errorf("redirect documented like a JSON response: %+v", op)
+	}
+	if err := app.Validate(); err != nil {
+		t.Errorf("Validate: %v", err)
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+
+// Package redisx backs fluxo's background jobs with Redis.
+package redisx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/leviantech/fluxo"
+	"github.com/redis/go-redis/v9"
+)
+
+// DefaultQueueKey is the list holding jobs when no key is given
+const DefaultQueueKey = "fluxo:jobs"
+
+// Queue is a fluxo.JobQueue on a Redis list, shared by every instance using the same key.
+// A job is removed when a worker takes it, so jobs running when a process dies are lost.
+type Queue struct {
+	client redis.UniversalClient
+	key    string
+	poll   time.Duration
+	closed atomic.Bool
+}
+
+// QueueOption configures a Queue
+type QueueOption func(*Queue)
+
+// WithKey sets the Redis list holding the jobs
+func WithKey(key string) QueueOption {
+	return func(q *Queue) { q.key = key }
+}
+
+// WithPollTimeout sets how long a worker blocks waiting for a job before checking for shutdown (default 1s)
+func WithPollTimeout(d time.Duration) QueueOption {
+	return func(q *Queue) { q.poll = d }
+}
+
+// NewQueue creates a job queue on client
+//
+//	app.WithWorkers(fluxo.NewWorkers(redisx.NewQueue(rdb)))
+func NewQueue(client redis.UniversalClient, opts ...QueueOption) *Queue {
+	q := &Queue{client: client, key: DefaultQueueKey, poll: time.Second}
+	for _, opt := range opts {
+		opt(q)
+	}
+	return q
+}
+
+func (q *Queue) Push(ctx context.Context, job fluxo.Job) error {
+	if q.closed.Load() {
+		return fluxo.ErrQueueClosed
+	}
+	data, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+	return q.client.LPush(ctx, q.key, data).Err()
+}
+
+func (q *Queue) Pop(ctx context.Context) (fluxo.Job, error) {
+	for {
+		// Jobs left in Redis are picked up by other instances or after a restart
+		if q.closed.Load() {
+			return fluxo.Job{}, fluxo.ErrQueueClosed
+		}
+		res, err := q.client.BRPop(ctx, q.poll, q.key).Result()
+		if errors.Is(err, redis.Nil) {
+			continue
+		}
+		if err != nil {
+			return fluxo.Job{}, err
+		}
+		var job fluxo.Job
+		if err := json.Unmarshal([]byte(res[1]), &job); err != nil {
+			return fluxo.Job{}, err
+		}
+		return job, nil
+	}
+}
+
+// Close stops taking jobs; the client stays open
+func (q *Queue) Close() error {
+	q.closed.Store(true)
+	return nil
+}
+
+// Len returns the number of queued jobs
+func (q *Queue) Len(ctx context.Context) (int64, error) {
+	return q.client.LLen(ctx, q.key).Result()
+}
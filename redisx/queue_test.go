@@ -0,0 +1,75 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package redisx
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/leviantech/fluxo"
+	"github.com/redis/go-redis/v9"
+)
+
+func newQueue(t *testing.T, opts ...QueueOption) (*Queue, *redis.Client) {
+	t.Helper()
+	srv := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: srv.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return NewQueue(client, append([]QueueOption{WithPollTimeout(50 * time.Millisecond)}, opts...)...), client
+}
+
+func TestQueueRunsJobs(t *testing.T) {
+	q, _ := newQueue(t)
+	w := fluxo.NewWorkers(q, fluxo.WithConcurrency(2))
+	done := make(chan string, 3)
+	fluxo.RegisterJob(w, "greet", func(ctx context.Context, name string) error {
+		done <- name
+		return nil
+	})
+	w.Start()
+
+	for _, name := range []string{"a", "b", "c"} {
+		if _, err := w.Enqueue(context.Background(), "greet", name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	seen := map[string]bool{}
+	for len(seen) < 3 {
+		select {
+		case name := <-done:
+			seen[name] = true
+		case <-time.After(2 * time.Second):
+			t.Fatalf("timed out, got %v", seen)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := w.Shutdown(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Enqueue(context.Background(), "greet", "late"); !errors.Is(err, fluxo.ErrQueueClosed) {
+		t.Fatalf("expected ErrQueueClosed, got %v", err)
+	}
+}
+
+func TestQueueKeepsJobsAfterClose(t *testing.T) {
+	q, client := newQueue(t, WithKey("test:jobs"))
+	if err := q.Push(context.Background(), fluxo.Job{ID: "1", Type: "greet"}); err != nil {
+		t.Fatal(err)
+	}
+	q.Close()
+	if _, err := q.Pop(context.Background()); !errors.Is(err, fluxo.ErrQueueClosed) {
+		t.Fatalf("expected ErrQueueClosed, got %v", err)
+	}
+
+	// Another instance picks the job up
+	other := NewQueue(client, WithKey("test:jobs"))
+	job, err := other.Pop(context.Background())
+	if err != nil || job.ID != "1" || job.Type != "greet" {
+		t.Fatalf("unexpected job %+v (%v)", job, err)
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"runtime/debug"
+)
+
+// ErrorReporter sends the errors of failed requests to an error tracker, such as Sentry (see the
+// sentryx package) or Rollbar. Report is called before the error is written, so it should hand
+// the report off rather than wait on the network.
+type ErrorReporter interface {
+	Report(ctx context.Context, report ErrorReport)
+}
+
+// ErrorReporterFunc adapts a function to ErrorReporter
+type ErrorReporterFunc func(ctx context.Context, report ErrorReport)
+
+func (f ErrorReporterFunc) Report(ctx context.Context, report ErrorReport) {
+	f(ctx, report)
+}
+
+// ErrorReport is a failed request as an ErrorReporter sees it
+type ErrorReport struct {
+	Err       error         // returned by a handler or typed middleware, or a *PanicError
+	Status    int           // of the response, 500 unless Err is an HTTPError
+	Request   *http.Request // its body has been read
+	Route     string        // the route template, e.g. /todos/:id
+	RequestID string
+	TraceID   string
+	User      string // see ReportUser
+	ClientIP  string
+	Stack     []byte // of the panic, or of the goroutine writing the error
+}
+
+// Panicked reports whether the request failed with a panic
+func (r ErrorReport) Panicked() bool {
+	var perr *PanicError
+	return errors.As(r.Err, &perr)
+}
+
+// ReportOption configures WithErrorReporter
+type ReportOption func(*reportConfig)
+
+type reportConfig struct {
+	user         func(ctx *Context) string
+	clientErrors bool
+}
+
+// ReportUser names the user of reported requests with fn. By default, requests with a verified
+// client certificate are reported with its common name.
+func ReportUser(fn func(ctx *Context) string) ReportOption {
+	return func(c *reportConfig) {
+		c.user = fn
+	}
+}
+
+// ReportClientErrors reports 4xx errors too, which are left out by default
+func ReportClientErrors() ReportOption {
+	return func(c *reportConfig) {
+		c.clientErrors = true
+	}
+}
+
+// WithErrorReporter reports the server errors and panics of the app to r through an OnError
+// hook. Panics are only caught under WithRecovery.
+//
+//	app := fluxo.New().WithRecovery().WithErrorReporter(reporter,
+//		fluxo.ReportUser(func(ctx *fluxo.Context) string { return ctx.GetString("user_id") }))
+func (a *App) WithErrorReporter(r ErrorReporter, opts ...ReportOption) *App {
+	cfg := reportConfig{user: certUser}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	a.OnError(func(ctx *Context, err error) {
+		report := ErrorReport{Err: err, Status: http.StatusInternalServerError}
+		var httpErr HTTPError
+		if errors.As(err, &httpErr) {
+			report.Status = httpErr.Status
+		}
+		if report.Status < http.StatusInternalServerError && !cfg.clientErrors {
+			return
+		}
+
+		report.Request, report.Route = ctx.Request, ctx.FullPath()
+		report.RequestID, report.TraceID = requestIDOf(ctx.Context), traceID(ctx.Request.Header)
+		report.User, report.ClientIP = cfg.user(ctx), ctx.RealIP()
+		var perr *PanicError
+		if errors.As(err, &perr) {
+			report.Stack = perr.Stack
+		} else {
+			report.Stack = debug.Stack()
+		}
+		r.Report(ctx.Request.Context(), report)
+	})
+	return a
+}
+
+// certUser returns the common name of the client's verified certificate, if any
+func certUser(ctx *Context) string {
+	if id, ok := ClientCert(ctx); ok {
+		return id.CommonName
+	}
+	return ""
+}
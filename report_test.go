@@ -0,0 +1,66 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestErrorReporter(t *testing.T) {
+	var reports []ErrorReport
+	reporter := ErrorReporterFunc(func(ctx context.Context, report ErrorReport) {
+		reports = append(reports, report)
+	})
+	app := New(WithMode(gin.TestMode), WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil)))).
+		WithRecovery().
+		WithErrorReporter(reporter, ReportUser(func(ctx *Context) string { return ctx.GetHeader("X-User") }))
+	app.GET("/todos/:id", Handle(func(ctx *Context, _ struct{}) (string, error) {
+		panic("boom")
+	}))
+	app.GET("/missing", Handle(func(ctx *Context, _ struct{}) (string, error) {
+		return "", NotFound("no todo")
+	}))
+	app.GET("/down", Handle(func(ctx *Context, _ struct{}) (string, error) {
+		return "", NewHTTPError(http.StatusServiceUnavailable, "db down")
+	}))
+
+	req := httptest.NewRequest("GET", "/todos/7", nil)
+	req.Header.Set("X-User", "alice")
+	req.Header.Set(RequestIDHeader, "req-1")
+	req.RemoteAddr = "10.0.0.1:1234"
+	app.ServeHTTP(httptest.NewRecorder(), req)
+	get(app, "/missing")
+	get(app, "/down")
+
+	if len(reports) != 2 {
+		t.Fatalf("reports = %+v", reports)
+	}
+	r := reports[0]
+	if !r.Panicked() || r.Status != 500 || r.Route != "/todos/:id" || r.User != "alice" || r.RequestID != "req-1" || r.ClientIP != "10.0.0.1" || len(r.Stack) == 0 || r.Request == nil {
+		t.Errorf("panic report = %+v", r)
+	}
+	if r := reports[1]; r.Panicked() || r.Status != http.StatusServiceUnavailable || len(r.Stack) == 0 {
+		t.Errorf("error report = %+v", r)
+	}
+}
+
+func TestErrorReporter_ClientErrors(t *testing.T) {
+	var statuses []int
+	app := New(WithMode(gin.TestMode)).WithErrorReporter(ErrorReporterFunc(func(ctx context.Context, report ErrorReport) {
+		statuses = append(statuses, report.Status)
+	}), ReportClientErrors())
+	app.GET("/missing", Handle(func(ctx *Context, _ struct{}) (string, error) {
+		return "", NotFound("no todo")
+	}))
+	get(app, "/missing")
+	if len(statuses) != 1 || statuses[0] != http.StatusNotFound {
+		t.Errorf("statuses = %v", statuses)
+	}
+}
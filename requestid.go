@@ -0,0 +1,74 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequestIDHeader carries the ID correlating a request across services
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength bounds the IDs accepted from clients, so they can't flood logs
+const maxRequestIDLength = 128
+
+// RequestIDKey holds the request's ID, see RequestID
+var RequestIDKey = NewKey[string]("requestID")
+
+// RequestID returns middleware giving every request an ID: the one in its X-Request-ID header,
+// or a new random one. The ID is stored under RequestIDKey, echoed in the response header and
+// sent on by HTTPClient.
+func RequestID() gin.HandlerFunc {
+	handler := func(ctx *gin.Context) {
+		id := ctx.GetHeader(RequestIDHeader)
+		if !validRequestID(id) {
+			id = newRequestID()
+		}
+		RequestIDKey.Set(ctx, id)
+		ctx.Header(RequestIDHeader, id)
+		ctx.Next()
+	}
+	registerOperationDoc(handler, documentRequestID)
+	return handler
+}
+
+// CurrentRequestID returns the ID RequestID gave the request
+func CurrentRequestID(ctx context.Context) (string, bool) {
+	return RequestIDKey.Get(ctx)
+}
+
+// validRequestID accepts non-empty printable ASCII IDs of a sane length
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func documentRequestID(sg *SwaggerGenerator, op *Operation) {
+	if !hasParameter(op.Parameters, RequestIDHeader, "header") {
+		op.Parameters = append(op.Parameters, Parameter{Name: RequestIDHeader, In: "header", Description: "ID correlating the request across services; generated when missing", Schema: Schema{Type: "string"}})
+	}
+	for code, res := range op.Responses {
+		if res.Headers == nil {
+			res.Headers = map[string]Header{}
+		}
+		res.Headers[RequestIDHeader] = Header{Description: "ID of the request", Schema: Schema{Type: "string"}}
+		op.Responses[code] = res
+	}
+}
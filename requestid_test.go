@@ -0,0 +1,64 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func requestIDApp() *App {
+	gin.SetMode(gin.TestMode)
+	app := New().WithSwagger("IDs", "1.0")
+	app.UseTyped(RequestID())
+	app.GET("/id", Handle(func(ctx *Context, _ struct{}) (map[string]string, error) {
+		id, _ := CurrentRequestID(ctx)
+		return map[string]string{"id": id}, nil
+	}))
+	return app
+}
+
+func TestRequestID(t *testing.T) {
+	app := requestIDApp()
+
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest("GET", "/id", nil))
+	id := w.Header().Get(RequestIDHeader)
+	if len(id) != 32 || !strings.Contains(w.Body.String(), id) {
+		t.Errorf("generated ID = %q, body = %s", id, w.Body)
+	}
+
+	req := httptest.NewRequest("GET", "/id", nil)
+	req.Header.Set(RequestIDHeader, "abc-123")
+	w = httptest.NewRecorder()
+	app.ServeHTTP(w, req)
+	if got := w.Header().Get(RequestIDHeader); got != "abc-123" {
+		t.Errorf("client ID echoed as %q", got)
+	}
+
+	req = httptest.NewRequest("GET", "/id", nil)
+	req.Header.Set(RequestIDHeader, strings.Repeat("x", 200))
+	w = httptest.NewRecorder()
+	app.ServeHTTP(w, req)
+	if got := w.Header().Get(RequestIDHeader); len(got) != 32 {
+		t.Errorf("oversized client ID kept: %q", got)
+	}
+}
+
+func TestRequestID_Swagger(t *testing.T) {
+	app := requestIDApp()
+	spec, err := app.swagger.parsedSpec(app.specHandlers())
+	if err != nil {
+		t.Fatal(err)
+	}
+	op := spec.Paths["/id"].GET
+	if op == nil || !hasParameter(op.Parameters, RequestIDHeader, "header") {
+		t.Fatalf("operation lacks the request ID: %+v", op)
+	}
+	if _, ok := op.Responses["200"].Headers[RequestIDHeader]; !ok {
+		t.Error("response lacks the request ID header")
+	}
+}
@@ -5,6 +5,34 @@ package fluxo
 import (
 	"encoding/json"
 	"net/http"
+	"reflect"
+
+	"github.com/gin-gonic/gin"
+)
+
+// StatusCoder is implemented by typed handler responses that choose their own status code, such as Accepted
+type StatusCoder interface {
+	StatusCode() int
+}
+
+// HeaderSetter is implemented by typed handler responses that add headers before the body is written
+type HeaderSetter interface {
+	SetHeaders(ctx *Context)
+}
+
+// renderer is implemented by typed handler responses written otherwise than as a JSON body, such as Redirection
+type renderer interface {
+	render(ctx *gin.Context)
+}
+
+// headerDoc lets a response type document the headers it sets
+type headerDoc interface {
+	responseHeaders() map[string]Header
+}
+
+var (
+	statusCoderType = reflect.TypeOf((*StatusCoder)(nil)).Elem()
+	headerDocType   = reflect.TypeOf((*headerDoc)(nil)).Elem()
 )
 
 func JSON(w http.ResponseWriter, status int, data interface{}) error {
@@ -0,0 +1,520 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultResumablePath is where resumable uploads are served when no path is given to App.Resumable
+const DefaultResumablePath = "/uploads"
+
+// DefaultResumableExpiry is how long an unfinished upload is kept after its last chunk
+const DefaultResumableExpiry = 24 * time.Hour
+
+// Headers of the tus 1.0 resumable upload protocol
+const (
+	TusVersion         = "1.0.0"
+	HeaderTusResumable = "Tus-Resumable"
+	HeaderUploadLength = "Upload-Length"
+	HeaderUploadOffset = "Upload-Offset"
+	HeaderUploadMeta   = "Upload-Metadata"
+	HeaderUploadExpiry = "Upload-Expires"
+	MIMEOffsetOctets   = "application/offset+octet-stream"
+)
+
+// ResumableUpload is the state of an upload sent in chunks
+type ResumableUpload struct {
+	ID        string            `json:"id"`
+	Key       string            `json:"key"` // storage key of the content
+	Length    int64             `json:"length"`
+	Offset    int64             `json:"offset"`
+	Metadata  map[string]string `json:"metadata,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+	ExpiresAt time.Time         `json:"expires_at"`
+}
+
+// Done reports whether every byte of the upload has been received
+func (u ResumableUpload) Done() bool {
+	return u.Offset >= u.Length
+}
+
+// ErrUploadNotFound is returned by a ResumableStore when no upload has the requested ID
+var ErrUploadNotFound = errors.New("upload not found")
+
+// ResumableStore persists upload state; implement it to resume uploads across instances
+type ResumableStore interface {
+	Save(ctx context.Context, upload ResumableUpload) error
+	Get(ctx context.Context, id string) (ResumableUpload, error)
+	Delete(ctx context.Context, id string) error
+	// Expired lists unfinished uploads that expired before t
+	Expired(ctx context.Context, t time.Time) ([]ResumableUpload, error)
+}
+
+// MemoryResumableStore keeps upload state in process memory
+type MemoryResumableStore struct {
+	mu      sync.RWMutex
+	uploads map[string]ResumableUpload
+}
+
+func NewMemoryResumableStore() *MemoryResumableStore {
+	return &MemoryResumableStore{uploads: make(map[string]ResumableUpload)}
+}
+
+func (s *MemoryResumableStore) Save(_ context.Context, upload ResumableUpload) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.uploads[upload.ID] = upload
+	return nil
+}
+
+func (s *MemoryResumableStore) Get(_ context.Context, id string) (ResumableUpload, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	upload, ok := s.uploads[id]
+	if !ok {
+		return ResumableUpload{}, ErrUploadNotFound
+	}
+	return upload, nil
+}
+
+func (s *MemoryResumableStore) Delete(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.uploads, id)
+	return nil
+}
+
+func (s *MemoryResumableStore) Expired(_ context.Context, t time.Time) ([]ResumableUpload, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var expired []ResumableUpload
+	for _, u := range s.uploads {
+		if !u.Done() && u.ExpiresAt.Before(t) {
+			expired = append(expired, u)
+		}
+	}
+	return expired, nil
+}
+
+// Resumable serves tus-style chunked uploads: POST creates an upload, PATCH appends a chunk
+// at the current offset, HEAD reports the offset to resume from and DELETE cancels it.
+type Resumable struct {
+	storage    AppendStorage
+	store      ResumableStore
+	path       string
+	expiry     time.Duration
+	maxSize    int64
+	onComplete func(ctx context.Context, upload ResumableUpload) error
+	locks      sync.Map // upload ID -> *sync.Mutex, serializing chunks of one upload
+}
+
+// ResumableOption configures a Resumable
+type ResumableOption func(*Resumable)
+
+// WithResumableStore keeps upload state in store instead of process memory
+func WithResumableStore(store ResumableStore) ResumableOption {
+	return func(r *Resumable) {
+		r.store = store
+	}
+}
+
+// WithResumableExpiry sets how long unfinished uploads are kept after their last chunk
+func WithResumableExpiry(d time.Duration) ResumableOption {
+	return func(r *Resumable) {
+		r.expiry = d
+	}
+}
+
+// WithResumableMaxSize rejects uploads larger than n bytes
+func WithResumableMaxSize(n int64) ResumableOption {
+	return func(r *Resumable) {
+		r.maxSize = n
+	}
+}
+
+// WithResumableComplete calls fn once the last chunk of an upload is stored
+func WithResumableComplete(fn func(ctx context.Context, upload ResumableUpload) error) ResumableOption {
+	return func(r *Resumable) {
+		r.onComplete = fn
+	}
+}
+
+// NewResumable creates a resumable upload module writing to storage
+func NewResumable(storage AppendStorage, opts ...ResumableOption) *Resumable {
+	r := &Resumable{
+		storage: storage,
+		store:   NewMemoryResumableStore(),
+		path:    DefaultResumablePath,
+		expiry:  DefaultResumableExpiry,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Store returns the underlying ResumableStore
+func (r *Resumable) Store() ResumableStore {
+	return r.store
+}
+
+// Cleanup deletes unfinished uploads that have expired; run it periodically
+func (r *Resumable) Cleanup(ctx context.Context) error {
+	expired, err := r.store.Expired(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+	for _, u := range expired {
+		if err := r.remove(ctx, u); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Resumable registers the upload endpoints under path (defaults to DefaultResumablePath)
+func (a *App) Resumable(r *Resumable, path ...string) {
+	if len(path) > 0 && path[0] != "" {
+		r.path = "/" + strings.Trim(path[0], "/")
+	}
+	a.OPTIONS(r.path, documented(r.options, r.documentOptions))
+	a.POST(r.path, documented(r.create, r.documentCreate))
+	a.HEAD(r.path+"/:id", documented(r.head, r.documentHead))
+	a.PATCH(r.path+"/:id", documented(r.patch, r.documentPatch))
+	a.DELETE(r.path+"/:id", documented(r.terminate, r.documentTerminate))
+}
+
+// documented registers doc as the documentation of a handler that has no request or response types
+func documented(h gin.HandlerFunc, doc operationDoc) gin.HandlerFunc {
+	registerOperationDoc(h, doc)
+	return h
+}
+
+func (r *Resumable) options(ctx *gin.Context) {
+	ctx.Header(HeaderTusResumable, TusVersion)
+	ctx.Header("Tus-Version", TusVersion)
+	ctx.Header("Tus-Extension", "creation,expiration,termination")
+	if r.maxSize > 0 {
+		ctx.Header("Tus-Max-Size", strconv.FormatInt(r.maxSize, 10))
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// checkVersion rejects requests from clients speaking another protocol version
+func checkVersion(ctx *gin.Context) bool {
+	ctx.Header(HeaderTusResumable, TusVersion)
+	if ctx.GetHeader(HeaderTusResumable) != TusVersion {
+		ctx.Header("Tus-Version", TusVersion)
+		ctx.AbortWithStatusJSON(http.StatusPreconditionFailed, gin.H{"error": "unsupported Tus-Resumable version"})
+		return false
+	}
+	return true
+}
+
+func (r *Resumable) create(ctx *gin.Context) {
+	if !checkVersion(ctx) {
+		return
+	}
+	length, err := strconv.ParseInt(ctx.GetHeader(HeaderUploadLength), 10, 64)
+	if err != nil || length < 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Upload-Length must be a non-negative integer"})
+		return
+	}
+	if r.maxSize > 0 && length > r.maxSize {
+		ctx.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("upload exceeds the maximum size of %d bytes", r.maxSize)})
+		return
+	}
+	metadata, err := parseUploadMetadata(ctx.GetHeader(HeaderUploadMeta))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	id, err := newTaskID()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	now := time.Now().UTC()
+	upload := ResumableUpload{ID: id, Key: id, Length: length, Metadata: metadata, CreatedAt: now, ExpiresAt: now.Add(r.expiry)}
+	// Create the object up front so empty uploads are complete and HEAD works before the first chunk
+	if _, err := r.storage.Append(ctx.Request.Context(), upload.Key, strings.NewReader("")); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if err := r.store.Save(ctx.Request.Context(), upload); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if upload.Done() && !r.complete(ctx, upload) {
+		return
+	}
+
+	ctx.Header("Location", r.path+"/"+id)
+	ctx.Header(HeaderUploadExpiry, upload.ExpiresAt.Format(http.TimeFormat))
+	ctx.Status(http.StatusCreated)
+}
+
+// lookup loads the upload named in the path, answering 404 or 410 itself when it is unusable
+func (r *Resumable) lookup(ctx *gin.Context) (ResumableUpload, bool) {
+	upload, err := r.store.Get(ctx.Request.Context(), ctx.Param("id"))
+	if errors.Is(err, ErrUploadNotFound) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "upload not found"})
+		return upload, false
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return upload, false
+	}
+	if !upload.Done() && time.Now().After(upload.ExpiresAt) {
+		_ = r.remove(ctx.Request.Context(), upload)
+		ctx.JSON(http.StatusGone, gin.H{"error": "upload expired"})
+		return upload, false
+	}
+	return upload, true
+}
+
+func (r *Resumable) head(ctx *gin.Context) {
+	if !checkVersion(ctx) {
+		return
+	}
+	upload, ok := r.lookup(ctx)
+	if !ok {
+		return
+	}
+	ctx.Header("Cache-Control", "no-store")
+	ctx.Header(HeaderUploadOffset, strconv.FormatInt(upload.Offset, 10))
+	ctx.Header(HeaderUploadLength, strconv.FormatInt(upload.Length, 10))
+	if !upload.Done() {
+		ctx.Header(HeaderUploadExpiry, upload.ExpiresAt.Format(http.TimeFormat))
+	}
+	if meta := formatUploadMetadata(upload.Metadata); meta != "" {
+		ctx.Header(HeaderUploadMeta, meta)
+	}
+	ctx.Status(http.StatusOK)
+}
+
+func (r *Resumable) patch(ctx *gin.Context) {
+	if !checkVersion(ctx) {
+		return
+	}
+	if ctx.ContentType() != MIMEOffsetOctets {
+		ctx.JSON(http.StatusUnsupportedMediaType, gin.H{"error": "Content-Type must be " + MIMEOffsetOctets})
+		return
+	}
+	offset, err := strconv.ParseInt(ctx.GetHeader(HeaderUploadOffset), 10, 64)
+	if err != nil || offset < 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Upload-Offset must be a non-negative integer"})
+		return
+	}
+
+	// Unknown and expired uploads are turned away before a lock is made for them, which would
+	// never be dropped
+	if _, ok := r.lookup(ctx); !ok {
+		return
+	}
+	id := ctx.Param("id")
+	lock, _ := r.locks.LoadOrStore(id, &sync.Mutex{})
+	lock.(*sync.Mutex).Lock()
+	defer lock.(*sync.Mutex).Unlock()
+
+	// Loaded again under the lock, since a concurrent chunk may have moved the offset
+	upload, ok := r.lookup(ctx)
+	if !ok {
+		r.locks.Delete(id)
+		return
+	}
+	if offset != upload.Offset {
+		ctx.JSON(http.StatusConflict, gin.H{"error": fmt.Sprintf("Upload-Offset %d does not match the current offset %d", offset, upload.Offset)})
+		return
+	}
+
+	// Bytes stored before a dropped connection still count, so the client can resume after them
+	n, err := r.storage.Append(ctx.Request.Context(), upload.Key, io.LimitReader(ctx.Request.Body, upload.Length-upload.Offset))
+	upload.Offset += n
+	upload.ExpiresAt = time.Now().UTC().Add(r.expiry)
+	if saveErr := r.store.Save(ctx.Request.Context(), upload); err == nil {
+		err = saveErr
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if upload.Done() {
+		r.locks.Delete(upload.ID)
+		if !r.complete(ctx, upload) {
+			return
+		}
+	}
+
+	ctx.Header(HeaderUploadOffset, strconv.FormatInt(upload.Offset, 10))
+	if !upload.Done() {
+		ctx.Header(HeaderUploadExpiry, upload.ExpiresAt.Format(http.TimeFormat))
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+func (r *Resumable) terminate(ctx *gin.Context) {
+	if !checkVersion(ctx) {
+		return
+	}
+	upload, ok := r.lookup(ctx)
+	if !ok {
+		return
+	}
+	if err := r.remove(ctx.Request.Context(), upload); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// complete runs the completion callback, answering 500 itself when it fails
+func (r *Resumable) complete(ctx *gin.Context, upload ResumableUpload) bool {
+	if r.onComplete == nil {
+		return true
+	}
+	if err := r.onComplete(ctx.Request.Context(), upload); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return false
+	}
+	return true
+}
+
+func (r *Resumable) remove(ctx context.Context, upload ResumableUpload) error {
+	r.locks.Delete(upload.ID)
+	if err := r.storage.Delete(ctx, upload.Key); err != nil {
+		return err
+	}
+	return r.store.Delete(ctx, upload.ID)
+}
+
+// parseUploadMetadata decodes "key base64value,key2 base64value2"
+func parseUploadMetadata(header string) (map[string]string, error) {
+	if strings.TrimSpace(header) == "" {
+		return nil, nil
+	}
+	metadata := map[string]string{}
+	for _, pair := range strings.Split(header, ",") {
+		key, encoded, _ := strings.Cut(strings.TrimSpace(pair), " ")
+		if key == "" {
+			return nil, errors.New("invalid Upload-Metadata")
+		}
+		value, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Upload-Metadata value for %s", key)
+		}
+		metadata[key] = string(value)
+	}
+	return metadata, nil
+}
+
+func formatUploadMetadata(metadata map[string]string) string {
+	pairs := make([]string, 0, len(metadata))
+	for key, value := range metadata {
+		pairs = append(pairs, key+" "+base64.StdEncoding.EncodeToString([]byte(value)))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+var (
+	tusResumableParam = Parameter{Name: HeaderTusResumable, In: "header", Required: true, Description: "Protocol version", Schema: Schema{Type: "string", Enum: []interface{}{TusVersion}}}
+	uploadIDParam     = Parameter{Name: "id", In: "path", Required: true, Schema: Schema{Type: "string"}}
+	uploadOffsetDoc   = Header{Description: "Bytes received so far", Schema: Schema{Type: "integer", Format: "int64"}}
+	uploadExpiresDoc  = Header{Description: "When the unfinished upload will be discarded", Schema: Schema{Type: "string"}}
+)
+
+func (r *Resumable) documentOptions(_ *SwaggerGenerator, op *Operation) {
+	op.Summary = "Discover resumable upload support"
+	op.RequestBody = nil
+	op.Responses = map[string]Response{"204": {
+		Description: "Supported protocol versions and extensions",
+		Headers: map[string]Header{
+			"Tus-Version":   {Schema: Schema{Type: "string"}},
+			"Tus-Extension": {Schema: Schema{Type: "string"}},
+			"Tus-Max-Size":  {Description: "Maximum upload size in bytes, when limited", Schema: Schema{Type: "integer", Format: "int64"}},
+		},
+	}}
+}
+
+func (r *Resumable) documentCreate(_ *SwaggerGenerator, op *Operation) {
+	op.Summary = "Create a resumable upload"
+	op.Description = "Send the content afterwards with PATCH requests to the returned Location."
+	op.RequestBody = nil
+	op.Parameters = []Parameter{
+		tusResumableParam,
+		{Name: HeaderUploadLength, In: "header", Required: true, Description: "Total size in bytes", Schema: Schema{Type: "integer", Format: "int64"}},
+		{Name: HeaderUploadMeta, In: "header", Description: "Comma separated key and base64 value pairs", Schema: Schema{Type: "string"}},
+	}
+	op.Responses = map[string]Response{
+		"201": {Description: "Upload created", Headers: map[string]Header{
+			"Location":         {Description: "URL of the upload", Schema: Schema{Type: "string"}},
+			HeaderUploadExpiry: uploadExpiresDoc,
+		}},
+		"400": {Description: "Invalid Upload-Length or Upload-Metadata"},
+		"412": {Description: "Unsupported protocol version"},
+		"413": {Description: "Upload exceeds the maximum size"},
+	}
+}
+
+func (r *Resumable) documentHead(_ *SwaggerGenerator, op *Operation) {
+	op.Summary = "Get the offset to resume an upload from"
+	op.Parameters = []Parameter{uploadIDParam, tusResumableParam}
+	op.Responses = map[string]Response{
+		"200": {Description: "Upload state", Headers: map[string]Header{
+			HeaderUploadOffset: uploadOffsetDoc,
+			HeaderUploadLength: {Description: "Total size in bytes", Schema: Schema{Type: "integer", Format: "int64"}},
+			HeaderUploadExpiry: uploadExpiresDoc,
+		}},
+		"404": {Description: "Upload not found"},
+		"410": {Description: "Upload expired"},
+	}
+}
+
+func (r *Resumable) documentPatch(_ *SwaggerGenerator, op *Operation) {
+	op.Summary = "Upload a chunk"
+	op.Description = "Appends the body at Upload-Offset, which must equal the current offset of the upload."
+	op.Parameters = []Parameter{
+		uploadIDParam,
+		tusResumableParam,
+		{Name: HeaderUploadOffset, In: "header", Required: true, Description: "Offset the chunk starts at", Schema: Schema{Type: "integer", Format: "int64"}},
+	}
+	op.RequestBody = &RequestBody{
+		Required: true,
+		Content:  map[string]MediaType{MIMEOffsetOctets: {Schema: Schema{Type: "string", Format: "binary"}}},
+	}
+	op.Responses = map[string]Response{
+		"204": {Description: "Chunk stored", Headers: map[string]Header{
+			HeaderUploadOffset: uploadOffsetDoc,
+			HeaderUploadExpiry: uploadExpiresDoc,
+		}},
+		"404": {Description: "Upload not found"},
+		"409": {Description: "Upload-Offset does not match the current offset"},
+		"410": {Description: "Upload expired"},
+		"415": {Description: "Content-Type is not " + MIMEOffsetOctets},
+	}
+}
+
+func (r *Resumable) documentTerminate(_ *SwaggerGenerator, op *Operation) {
+	op.Summary = "Cancel an upload"
+	op.RequestBody = nil
+	op.Parameters = []Parameter{uploadIDParam, tusResumableParam}
+	op.Responses = map[string]Response{
+		"204": {Description: "Upload deleted"},
+		"404": {Description: "Upload not found"},
+	}
+}
@@ -0,0 +1,185 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newResumableApp(t *testing.T, opts ...ResumableOption) (*App, *Resumable, *LocalStorage) {
+	gin.SetMode(gin.TestMode)
+	storage := NewLocalStorage(t.TempDir())
+	r := NewResumable(storage, opts...)
+	app := New().WithSwagger("Uploads", "1.0.0")
+	app.Resumable(r, "/files")
+	return app, r, storage
+}
+
+func tusRequest(method, path string, body string, headers map[string]string) *http.Request {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set(HeaderTusResumable, TusVersion)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	return req
+}
+
+func serveTus(app *App, req *http.Request) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, req)
+	return w
+}
+
+func TestResumable_ChunkedUpload(t *testing.T) {
+	var completed ResumableUpload
+	app, _, storage := newResumableApp(t, WithResumableComplete(func(ctx context.Context, u ResumableUpload) error {
+		completed = u
+		return nil
+	}))
+
+	w := serveTus(app, tusRequest(http.MethodPost, "/files", "", map[string]string{
+		HeaderUploadLength: "11",
+		HeaderUploadMeta:   "filename aGVsbG8udHh0",
+	}))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create: status=%d body=%s", w.Code, w.Body.String())
+	}
+	location := w.Header().Get("Location")
+	if !strings.HasPrefix(location, "/files/") || w.Header().Get(HeaderUploadExpiry) == "" {
+		t.Fatalf("headers: %v", w.Header())
+	}
+
+	chunk := map[string]string{"Content-Type": MIMEOffsetOctets, HeaderUploadOffset: "0"}
+	w = serveTus(app, tusRequest(http.MethodPatch, location, "hello ", chunk))
+	if w.Code != http.StatusNoContent || w.Header().Get(HeaderUploadOffset) != "6" {
+		t.Fatalf("patch: status=%d offset=%s", w.Code, w.Header().Get(HeaderUploadOffset))
+	}
+
+	w = serveTus(app, tusRequest(http.MethodHead, location, "", nil))
+	if w.Code != http.StatusOK || w.Header().Get(HeaderUploadOffset) != "6" || w.Header().Get(HeaderUploadLength) != "11" {
+		t.Fatalf("head: status=%d headers=%v", w.Code, w.Header())
+	}
+
+	// Resuming from a stale offset is rejected
+	w = serveTus(app, tusRequest(http.MethodPatch, location, "hello ", chunk))
+	if w.Code != http.StatusConflict {
+		t.Fatalf("stale offset: status=%d", w.Code)
+	}
+
+	chunk[HeaderUploadOffset] = "6"
+	w = serveTus(app, tusRequest(http.MethodPatch, location, "world", chunk))
+	if w.Code != http.StatusNoContent || w.Header().Get(HeaderUploadOffset) != "11" {
+		t.Fatalf("final patch: status=%d body=%s", w.Code, w.Body.String())
+	}
+	if !completed.Done() || completed.Metadata["filename"] != "hello.txt" {
+		t.Fatalf("completed: %+v", completed)
+	}
+
+	rc, err := storage.Open(context.Background(), completed.Key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rc.Close()
+	data, _ := io.ReadAll(rc)
+	if string(data) != "hello world" {
+		t.Fatalf("content %q", data)
+	}
+}
+
+func TestResumable_Errors(t *testing.T) {
+	app, _, _ := newResumableApp(t, WithResumableMaxSize(10))
+
+	req := tusRequest(http.MethodPost, "/files", "", map[string]string{HeaderUploadLength: "5"})
+	req.Header.Del(HeaderTusResumable)
+	if w := serveTus(app, req); w.Code != http.StatusPreconditionFailed {
+		t.Fatalf("missing version: status=%d", w.Code)
+	}
+	if w := serveTus(app, tusRequest(http.MethodPost, "/files", "", map[string]string{HeaderUploadLength: "11"})); w.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("too large: status=%d", w.Code)
+	}
+
+	w := serveTus(app, tusRequest(http.MethodPost, "/files", "", map[string]string{HeaderUploadLength: "5"}))
+	location := w.Header().Get("Location")
+	if w := serveTus(app, tusRequest(http.MethodPatch, location, "abc", map[string]string{"Content-Type": "text/plain", HeaderUploadOffset: "0"})); w.Code != http.StatusUnsupportedMediaType {
+		t.Fatalf("content type: status=%d", w.Code)
+	}
+	if w := serveTus(app, tusRequest(http.MethodDelete, location, "", nil)); w.Code != http.StatusNoContent {
+		t.Fatalf("delete: status=%d", w.Code)
+	}
+	if w := serveTus(app, tusRequest(http.MethodHead, location, "", nil)); w.Code != http.StatusNotFound {
+		t.Fatalf("deleted upload: status=%d", w.Code)
+	}
+
+	w = serveTus(app, httptest.NewRequest(http.MethodOptions, "/files", nil))
+	if w.Code != http.StatusNoContent || w.Header().Get("Tus-Max-Size") != "10" || w.Header().Get("Tus-Version") != TusVersion {
+		t.Fatalf("options: status=%d headers=%v", w.Code, w.Header())
+	}
+}
+
+func TestResumable_NoLocksForUnknownUploads(t *testing.T) {
+	app, r, _ := newResumableApp(t, WithResumableExpiry(-time.Second))
+
+	w := serveTus(app, tusRequest(http.MethodPost, "/files", "", map[string]string{HeaderUploadLength: "5"}))
+	expired := w.Header().Get("Location")
+	patch := map[string]string{"Content-Type": MIMEOffsetOctets, HeaderUploadOffset: "0"}
+	if w := serveTus(app, tusRequest(http.MethodPatch, expired, "abc", patch)); w.Code != http.StatusGone {
+		t.Fatalf("expired: status=%d", w.Code)
+	}
+	for i := 0; i < 3; i++ {
+		if w := serveTus(app, tusRequest(http.MethodPatch, "/files/nope"+strconv.Itoa(i), "abc", patch)); w.Code != http.StatusNotFound {
+			t.Fatalf("unknown: status=%d", w.Code)
+		}
+	}
+	r.locks.Range(func(id, _ any) bool {
+		t.Errorf("lock left for %v", id)
+		return true
+	})
+}
+
+func TestResumable_Expiry(t *testing.T) {
+	app, r, _ := newResumableApp(t, WithResumableExpiry(-time.Second))
+
+	w := serveTus(app, tusRequest(http.MethodPost, "/files", "", map[string]string{HeaderUploadLength: "5"}))
+	location := w.Header().Get("Location")
+	if w := serveTus(app, tusRequest(http.MethodHead, location, "", nil)); w.Code != http.StatusGone {
+		t.Fatalf("expired: status=%d", w.Code)
+	}
+
+	w = serveTus(app, tusRequest(http.MethodPost, "/files", "", map[string]string{HeaderUploadLength: "5"}))
+	id := strings.TrimPrefix(w.Header().Get("Location"), "/files/")
+	if err := r.Cleanup(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Store().Get(context.Background(), id); err != ErrUploadNotFound {
+		t.Fatalf("expected cleanup to remove upload, got %v", err)
+	}
+}
+
+func TestResumable_Swagger(t *testing.T) {
+	app, _, _ := newResumableApp(t)
+	w := serveTus(app, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	var spec OpenAPISpec
+	if err := json.Unmarshal(w.Body.Bytes(), &spec); err != nil {
+		t.Fatal(err)
+	}
+	files, item := spec.Paths["/files"], spec.Paths["/files/{id}"]
+	if files.POST == nil || files.OPTIONS == nil || item.HEAD == nil || item.PATCH == nil || item.DELETE == nil {
+		t.Fatalf("missing operations: %+v %+v", files, item)
+	}
+	if _, ok := item.PATCH.RequestBody.Content[MIMEOffsetOctets]; !ok {
+		t.Fatalf("patch body: %+v", item.PATCH.RequestBody)
+	}
+	if _, ok := item.PATCH.Responses["409"]; !ok {
+		t.Fatalf("patch responses: %+v", item.PATCH.Responses)
+	}
+}
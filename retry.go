@@ -0,0 +1,295 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// RetryBudget caps the retries and hedges of a Retrier at a share of the requests it sends, so
+// that retrying can't multiply the load of an upstream that is already failing. Share one
+// budget between the retriers of an upstream to cap them together.
+type RetryBudget struct {
+	mu     sync.Mutex
+	ratio  float64
+	min    float64 // tokens granted per second, whatever the traffic
+	cap    float64
+	tokens float64
+	last   time.Time
+}
+
+// NewRetryBudget allows ratio extra attempts per request sent, e.g. 0.1 for one in ten, plus
+// minPerSecond so that quiet upstreams can be retried too. Unused budget accumulates up to ten
+// seconds of minPerSecond or a hundred requests' worth, whichever is more.
+func NewRetryBudget(ratio, minPerSecond float64) *RetryBudget {
+	capacity := max(100*ratio, 10*minPerSecond, 1)
+	return &RetryBudget{ratio: ratio, min: minPerSecond, cap: capacity, tokens: capacity, last: time.Now()}
+}
+
+// deposit credits the budget for a request sent
+func (b *RetryBudget) deposit() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refill()
+	b.tokens = min(b.tokens+b.ratio, b.cap)
+}
+
+// withdraw spends the budget of an extra attempt, reporting whether there was any
+func (b *RetryBudget) withdraw() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refill()
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+func (b *RetryBudget) refill() {
+	now := time.Now()
+	b.tokens = min(b.tokens+now.Sub(b.last).Seconds()*b.min, b.cap)
+	b.last = now
+}
+
+// RetryStats counts what a Retrier did, see Retrier.Stats
+type RetryStats struct {
+	Requests  uint64 `json:"requests"`   // sent by callers
+	Retries   uint64 `json:"retries"`    // attempts after a failed one
+	Hedges    uint64 `json:"hedges"`     // attempts racing a slow one
+	HedgeWins uint64 `json:"hedge_wins"` // hedges answering first
+	Exhausted uint64 `json:"exhausted"`  // retries and hedges the budget turned down
+}
+
+// RetryRate returns the extra attempts per request
+func (s RetryStats) RetryRate() float64 {
+	if s.Requests == 0 {
+		return 0
+	}
+	return float64(s.Retries+s.Hedges) / float64(s.Requests)
+}
+
+// RetryOption configures a Retrier
+type RetryOption func(*Retrier)
+
+// WithRetryAttempts retries failed requests up to n times, on network errors and 429, 502, 503
+// and 504 responses, waiting backoff, then twice as long each time, or what Retry-After asks for
+func WithRetryAttempts(n int, backoff time.Duration) RetryOption {
+	return func(t *Retrier) {
+		t.attempts, t.backoff = n, backoff
+	}
+}
+
+// WithHedging sends a second attempt when the first hasn't answered after delay, e.g. the p95
+// latency of the upstream, and takes whichever answers first, cancelling the other
+func WithHedging(delay time.Duration) RetryOption {
+	return func(t *Retrier) {
+		t.hedge = delay
+	}
+}
+
+// WithRetryBudget caps retries and hedges with b (default: NewRetryBudget(0.1, 10)); nil removes the cap
+func WithRetryBudget(b *RetryBudget) RetryOption {
+	return func(t *Retrier) {
+		t.budget = b
+	}
+}
+
+// Retrier is an http.RoundTripper retrying and hedging requests that are safe to send twice:
+// those with an idempotent method or an Idempotency-Key header, and a body that can be sent
+// again (http.NewRequest makes bodies of byte slices and strings replayable). Others are sent
+// once. Use it as the transport of a reverse proxy mounted on the app, or of a Client:
+//
+//	retrier := fluxo.NewRetrier(http.DefaultTransport,
+//		fluxo.WithRetryAttempts(2, 50*time.Millisecond),
+//		fluxo.WithHedging(300*time.Millisecond),
+//	)
+//	proxy := httputil.NewSingleHostReverseProxy(ordersURL)
+//	proxy.Transport = retrier
+//	app.Mount("/orders", proxy)
+type Retrier struct {
+	base     http.RoundTripper
+	attempts int
+	backoff  time.Duration
+	hedge    time.Duration
+	budget   *RetryBudget
+
+	requests, retries, hedges, hedgeWins, exhausted atomic.Uint64
+}
+
+// NewRetrier returns a Retrier sending requests with base (http.DefaultTransport when nil)
+func NewRetrier(base http.RoundTripper, opts ...RetryOption) *Retrier {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	t := &Retrier{base: base, backoff: 100 * time.Millisecond, budget: NewRetryBudget(0.1, 10)}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+// Stats returns what the retrier did so far, e.g. to export as metrics
+func (t *Retrier) Stats() RetryStats {
+	return RetryStats{
+		Requests:  t.requests.Load(),
+		Retries:   t.retries.Load(),
+		Hedges:    t.hedges.Load(),
+		HedgeWins: t.hedgeWins.Load(),
+		Exhausted: t.exhausted.Load(),
+	}
+}
+
+func (t *Retrier) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.requests.Add(1)
+	if t.budget != nil {
+		t.budget.deposit()
+	}
+	if !replayable(req) {
+		return t.base.RoundTrip(req)
+	}
+	if req.Body != nil {
+		// Every attempt sends a copy from GetBody
+		defer req.Body.Close()
+	}
+
+	wait := t.backoff
+	for attempt := 0; ; attempt++ {
+		resp, err := t.try(req)
+		if attempt >= t.attempts || !retryable(resp, err) || req.Context().Err() != nil {
+			return resp, err
+		}
+		if !t.spend() {
+			return resp, err
+		}
+		delay := wait
+		if resp != nil {
+			if after, ok := retryAfter(resp); ok {
+				delay = after
+			}
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxErrorBody))
+			_ = resp.Body.Close()
+		}
+		select {
+		case <-time.After(delay):
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+		t.retries.Add(1)
+		wait *= 2
+	}
+}
+
+// spend withdraws an extra attempt from the budget, counting refusals
+func (t *Retrier) spend() bool {
+	if t.budget == nil || t.budget.withdraw() {
+		return true
+	}
+	t.exhausted.Add(1)
+	return false
+}
+
+// attemptResult is the outcome of one attempt of a hedged request, the first (0) or the hedge (1)
+type attemptResult struct {
+	attempt int
+	resp    *http.Response
+	err     error
+}
+
+// try sends an attempt, hedged when the retrier hedges
+func (t *Retrier) try(req *http.Request) (*http.Response, error) {
+	if t.hedge <= 0 {
+		r, err := copyRequest(req.Context(), req)
+		if err != nil {
+			return nil, err
+		}
+		return t.base.RoundTrip(r)
+	}
+
+	results := make(chan attemptResult, 2)
+	var cancels [2]context.CancelFunc
+	send := func(attempt int) {
+		ctx, cancel := context.WithCancel(req.Context())
+		cancels[attempt] = cancel
+		r, err := copyRequest(ctx, req)
+		if err != nil {
+			results <- attemptResult{attempt: attempt, err: err}
+			return
+		}
+		go func() {
+			resp, err := t.base.RoundTrip(r)
+			results <- attemptResult{attempt: attempt, resp: resp, err: err}
+		}()
+	}
+	send(0)
+	timer := time.NewTimer(t.hedge)
+	defer timer.Stop()
+
+	inFlight := 1
+	for {
+		select {
+		case <-timer.C:
+			if t.spend() {
+				t.hedges.Add(1)
+				inFlight++
+				send(1)
+			}
+		case res := <-results:
+			inFlight--
+			// A failed attempt leaves the answer to the other one, if it is still running
+			if inFlight > 0 && retryable(res.resp, res.err) {
+				discard(res, cancels[res.attempt])
+				continue
+			}
+			if inFlight > 0 {
+				other := 1 - res.attempt
+				cancels[other]()
+				go func() { discard(<-results, cancels[other]) }()
+			}
+			if res.err != nil {
+				cancels[res.attempt]()
+				return nil, res.err
+			}
+			if res.attempt == 1 {
+				t.hedgeWins.Add(1)
+			}
+			// The attempt's context must outlive RoundTrip until the body is read
+			res.resp.Body = cancelOnClose{ReadCloser: res.resp.Body, cancel: cancels[res.attempt]}
+			return res.resp, nil
+		}
+	}
+}
+
+// discard cancels an attempt whose answer isn't used
+func discard(res attemptResult, cancel context.CancelFunc) {
+	cancel()
+	if res.resp != nil {
+		_ = res.resp.Body.Close()
+	}
+}
+
+// replayable reports whether req may be sent more than once
+func replayable(req *http.Request) bool {
+	if !idempotent(req.Method) && req.Header.Get("Idempotency-Key") == "" {
+		return false
+	}
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// copyRequest returns a copy of req bound to ctx with a fresh copy of its body
+func copyRequest(ctx context.Context, req *http.Request) (*http.Request, error) {
+	r := req.Clone(ctx)
+	if req.Body != nil && req.Body != http.NoBody {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		r.Body = body
+	}
+	return r, nil
+}
@@ -0,0 +1,159 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// flakyUpstream fails the first failures requests with 503, echoing the body of the others
+func flakyUpstream(t *testing.T, failures int32) (*httptest.Server, *atomic.Int32) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= failures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		w.Write(append([]byte(r.Method+" "), body...))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &calls
+}
+
+func TestRetrier_RetriesIdempotentRequests(t *testing.T) {
+	srv, calls := flakyUpstream(t, 2)
+	retrier := NewRetrier(nil, WithRetryAttempts(3, time.Millisecond))
+	client := &http.Client{Transport: retrier}
+
+	resp, err := client.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || string(body) != "GET " || calls.Load() != 3 {
+		t.Fatalf("got %d %q after %d calls", resp.StatusCode, body, calls.Load())
+	}
+	if s := retrier.Stats(); s.Requests != 1 || s.Retries != 2 || s.RetryRate() != 2 {
+		t.Errorf("unexpected stats %+v", s)
+	}
+}
+
+func TestRetrier_OnlyReplayableRequests(t *testing.T) {
+	srv, calls := flakyUpstream(t, 1)
+	client := &http.Client{Transport: NewRetrier(nil, WithRetryAttempts(3, time.Millisecond))}
+
+	resp, err := client.Post(srv.URL, "text/plain", strings.NewReader("order"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusServiceUnavailable || calls.Load() != 1 {
+		t.Fatalf("a POST was retried: %d after %d calls", resp.StatusCode, calls.Load())
+	}
+
+	calls.Store(0)
+	req, _ := http.NewRequest(http.MethodPost, srv.URL, strings.NewReader("order"))
+	req.Header.Set("Idempotency-Key", "k1")
+	resp, err = client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(body) != "POST order" || calls.Load() != 2 {
+		t.Errorf("expected the keyed POST retried with its body, got %q after %d calls", body, calls.Load())
+	}
+}
+
+func TestRetrier_Budget(t *testing.T) {
+	srv, calls := flakyUpstream(t, 100)
+	// Without traffic or a minimum, the budget only holds its single initial token
+	retrier := NewRetrier(nil, WithRetryAttempts(3, time.Millisecond), WithRetryBudget(NewRetryBudget(0, 0)))
+	client := &http.Client{Transport: retrier}
+
+	for range 2 {
+		resp, err := client.Get(srv.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+	if calls.Load() != 3 {
+		t.Errorf("expected one retry in the budget, got %d calls", calls.Load())
+	}
+	if s := retrier.Stats(); s.Retries != 1 || s.Exhausted != 2 {
+		t.Errorf("unexpected stats %+v", s)
+	}
+}
+
+func TestRetrier_Hedging(t *testing.T) {
+	var calls atomic.Int32
+	cancelled := make(chan struct{}, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			select {
+			case <-r.Context().Done():
+				cancelled <- struct{}{}
+			case <-time.After(2 * time.Second):
+			}
+			return
+		}
+		w.Write([]byte("fast"))
+	}))
+	t.Cleanup(srv.Close)
+
+	retrier := NewRetrier(nil, WithHedging(20*time.Millisecond))
+	start := time.Now()
+	resp, err := (&http.Client{Transport: retrier}).Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(body) != "fast" || time.Since(start) > time.Second {
+		t.Fatalf("expected the hedge's answer, got %q after %v", body, time.Since(start))
+	}
+	if s := retrier.Stats(); s.Hedges != 1 || s.HedgeWins != 1 {
+		t.Errorf("unexpected stats %+v", s)
+	}
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Error("the slow attempt wasn't cancelled")
+	}
+}
+
+func TestRetrier_ProxiedRoute(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	srv, calls := flakyUpstream(t, 1)
+	upstream, _ := url.Parse(srv.URL)
+	proxy := httputil.NewSingleHostReverseProxy(upstream)
+	proxy.Transport = NewRetrier(nil, WithRetryAttempts(1, time.Millisecond))
+
+	app := New()
+	app.Mount("/orders", proxy)
+	// ReverseProxy needs a real connection under gin
+	front := httptest.NewServer(app)
+	defer front.Close()
+	resp, err := http.Get(front.URL + "/orders/42")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || string(body) != "GET " || calls.Load() != 2 {
+		t.Errorf("expected the proxied GET retried, got %d %q after %d calls", resp.StatusCode, body, calls.Load())
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Route describes a route registered on the app or one of its groups
+type Route struct {
+	Method      string
+	Path        string
+	Typed       bool           // the route has a documented fluxo handler
+	Request     []reflect.Type // request types of the handler and of the typed middleware it runs
+	Response    reflect.Type   // nil for handlers without a typed response
+	ContentType string
+	Middleware  []string // names of the Skippable middleware the route runs
+	Skips       []string // names the route opts out of with Skip
+}
+
+// Runs reports whether the route runs the Skippable middleware called name
+func (r Route) Runs(name string) bool {
+	return slices.Contains(r.Middleware, name)
+}
+
+// OnRouteRegistered calls fn for every route registered on the app or its groups, with the
+// middleware and handlers in effect. Routes registered before the hook are replayed first, so
+// plugins can be installed at any point. A non-nil error rejects the route, making its
+// registration panic like a conflicting gin route does:
+//
+//	app.OnRouteRegistered(func(r fluxo.Route) error {
+//		if r.Typed && !r.Runs("auth") {
+//			return errors.New("every API route must require auth")
+//		}
+//		return nil
+//	})
+func (a *App) OnRouteRegistered(fn func(route Route) error) {
+	for _, r := range a.routes {
+		mustAccept(r, fn)
+	}
+	a.routeHooks = append(a.routeHooks, fn)
+}
+
+// registerRoute describes a route from its group's handlers and its own, records it and passes
+// it to the OnRouteRegistered hooks
+func (a *App) registerRoute(method, path string, group, handlers []gin.HandlerFunc) {
+	r := Route{Method: method, Path: path, Skips: routeSkips(handlers)}
+	for _, h := range handlers {
+		if inner, _ := unwrapMiddleware(h); isDocumentedHandler(inner) {
+			r.Typed = true
+		}
+	}
+	for _, h := range slices.Concat(group, handlers) {
+		inner, names := unwrapMiddleware(h)
+		if skipsAny(r.Skips, names) {
+			continue
+		}
+		r.Middleware = append(r.Middleware, names...)
+		req, res, ct, ok := lookupHandlerTypes(inner)
+		if !ok {
+			continue
+		}
+		if req != nil && !slices.Contains(r.Request, req) {
+			r.Request = append(r.Request, req)
+		}
+		if res != nil {
+			r.Response = res
+		}
+		if ct != "" {
+			r.ContentType = ct
+		}
+	}
+
+	for _, fn := range a.routeHooks {
+		mustAccept(r, fn)
+	}
+	a.routes = append(a.routes, r)
+}
+
+func mustAccept(r Route, fn func(Route) error) {
+	if err := fn(r); err != nil {
+		panic(fmt.Sprintf("fluxo: route %s %s rejected: %v", r.Method, r.Path, err))
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestOnRouteRegistered_DescribesRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := New().WithSwagger("Test", "1.0")
+	routes := map[string]Route{}
+	app.OnRouteRegistered(func(r Route) error {
+		routes[r.Method+" "+r.Path] = r
+		return nil
+	})
+
+	app.Use(Skippable("auth", Middleware(groupAuth)))
+	api := app.TypedGroup("/api", Middleware(groupTenant))
+	api.GET("/items/:id", Handle(groupGetItem))
+	api.GET("/healthz", Skip("auth"), Handle(skipHealth))
+	app.GET("/plain", func(ctx *gin.Context) { ctx.Status(http.StatusNoContent) })
+
+	item := routes["GET /api/items/:id"]
+	wantReq := []reflect.Type{reflect.TypeOf(groupAuthReq{}), reflect.TypeOf(groupTenantReq{}), reflect.TypeOf(groupItemReq{})}
+	if !item.Typed || !slices.Equal(item.Request, wantReq) {
+		t.Fatalf("unexpected request types: %v", item.Request)
+	}
+	if item.Response != reflect.TypeOf(groupItemRes{}) || item.ContentType != "application/json" {
+		t.Fatalf("unexpected response: %v %q", item.Response, item.ContentType)
+	}
+	if !item.Runs("auth") {
+		t.Fatal("expected the route to run auth")
+	}
+
+	health := routes["GET /api/healthz"]
+	if health.Runs("auth") || !slices.Equal(health.Skips, []string{"auth"}) {
+		t.Fatalf("expected healthz to skip auth, got %+v", health)
+	}
+	if slices.Contains(health.Request, reflect.TypeOf(groupAuthReq{})) {
+		t.Fatal("skipped middleware should not contribute request types")
+	}
+
+	plain := routes["GET /plain"]
+	if plain.Typed || plain.Response != nil || !plain.Runs("auth") {
+		t.Fatalf("unexpected plain route: %+v", plain)
+	}
+}
+
+func TestOnRouteRegistered_RejectsRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := New().WithSwagger("Test", "1.0")
+	app.GET("/legacy", Handle(groupGetItem))
+
+	requireAuth := func(r Route) error {
+		if r.Typed && !r.Runs("auth") {
+			return errors.New("typed routes must require auth")
+		}
+		return nil
+	}
+
+	// Routes registered before the hook are checked too
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("expected the earlier route to be rejected")
+			}
+		}()
+		app.OnRouteRegistered(requireAuth)
+	}()
+
+	app = New().WithSwagger("Test", "1.0")
+	app.OnRouteRegistered(requireAuth)
+	app.TypedGroup("/secure", Skippable("auth", Middleware(groupAuth))).GET("/items/:id", Handle(groupGetItem))
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("expected the route without auth to be rejected")
+			}
+		}()
+		app.GET("/open/:id", Handle(groupGetItem))
+	}()
+
+	spec := groupSpec(t, app)
+	if _, ok := spec.Paths["/open/{id}"]; ok {
+		t.Fatal("a rejected route should not be documented")
+	}
+	if _, ok := spec.Paths["/secure/items/{id}"]; !ok {
+		t.Fatal("expected the accepted route to be documented")
+	}
+}
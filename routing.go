@@ -0,0 +1,126 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WithRedirectFixedPath sets whether a path only routed once cleaned up (/../ and double
+// slashes removed) and compared case-insensitively redirects to the routed one, with 301 for GET
+// and 307 for other methods. It replaces gin's RedirectFixedPath, which panics on routes mixing
+// static and parameter segments like /users/me and /users/:id.
+func WithRedirectFixedPath(enabled bool) AppOption {
+	return func(c *appConfig) {
+		c.fixPath = enabled
+	}
+}
+
+// WithCaseInsensitiveRouting makes the static parts of route paths match in any case, so that
+// /Users/42 is served by /users/:id without a redirect. Parameter values keep their case, and a
+// route matching in its own case always wins. WithRedirectFixedPath redirects instead when set.
+// The app serves otherwise unrouted requests with these options, so don't set gin's NoRoute
+// handlers on its engine.
+func WithCaseInsensitiveRouting() AppOption {
+	return func(c *appConfig) {
+		c.foldCase = true
+	}
+}
+
+// fixedPath returns the handler of requests no route matched, serving or, with redirect,
+// redirecting to the route matching them once their path is fixed. Routed requests pay nothing.
+func (a *App) fixedPath(redirect bool) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		target := ctx.Request.URL.Path
+		if redirect {
+			target = cleanPath(target)
+		}
+		fixed, ok := a.foldPath(ctx.Request.Method, target)
+		if !ok || fixed == ctx.Request.URL.Path {
+			// Left to gin's 404
+			return
+		}
+		if redirect {
+			status := http.StatusMovedPermanently
+			if ctx.Request.Method != http.MethodGet {
+				status = http.StatusTemporaryRedirect
+			}
+			u := *ctx.Request.URL
+			u.Path, u.RawPath = fixed, ""
+			ctx.Redirect(status, u.RequestURI())
+			ctx.Abort()
+			return
+		}
+		req := ctx.Request.Clone(ctx.Request.Context())
+		req.URL.Path, req.URL.RawPath = fixed, ""
+		a.router.ServeHTTP(ctx.Writer, req)
+		ctx.Abort()
+	}
+}
+
+// cleanPath resolves . and .. segments and double slashes, keeping a trailing slash
+func cleanPath(p string) string {
+	cleaned := path.Clean("/" + p)
+	if strings.HasSuffix(p, "/") && cleaned != "/" {
+		cleaned += "/"
+	}
+	return cleaned
+}
+
+// foldPath returns path spelled like the route of method matching it case-insensitively with the
+// most static segments
+func (a *App) foldPath(method, path string) (string, bool) {
+	best, bestStatic := "", -1
+	segments := strings.Split(path, "/")
+	for _, r := range a.router.Routes() {
+		if r.Method != method {
+			continue
+		}
+		if fixed, static, ok := foldRoute(r.Path, segments); ok && static > bestStatic {
+			best, bestStatic = fixed, static
+		}
+	}
+	return best, bestStatic >= 0
+}
+
+// foldRoute matches the segments of a path against a gin route case-insensitively, returning
+// the path with the route's spelling of its static segments and how many there are
+func foldRoute(route string, segments []string) (string, int, bool) {
+	parts := strings.Split(route, "/")
+	fixed := make([]string, 0, len(segments))
+	static := 0
+	for i, part := range parts {
+		if strings.HasPrefix(part, "*") {
+			// A catch-all parameter takes the rest of the path as it is
+			if i >= len(segments) {
+				return "", 0, false
+			}
+			return strings.Join(append(fixed, segments[i:]...), "/"), static, true
+		}
+		if i >= len(segments) {
+			return "", 0, false
+		}
+		switch {
+		case strings.HasPrefix(part, ":"):
+			if segments[i] == "" {
+				return "", 0, false
+			}
+			fixed = append(fixed, segments[i])
+		case strings.EqualFold(part, segments[i]):
+			fixed = append(fixed, part)
+			if part != "" {
+				static++
+			}
+		default:
+			return "", 0, false
+		}
+	}
+	if len(parts) != len(segments) {
+		return "", 0, false
+	}
+	return strings.Join(fixed, "/"), static, true
+}
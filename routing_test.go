@@ -0,0 +1,92 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func routingApp(calls *int, opts ...AppOption) *App {
+	app := New(append([]AppOption{WithMode(gin.TestMode)}, opts...)...)
+	app.GET("/users/me", Handle(func(ctx *Context, _ struct{}) (string, error) {
+		return "me", nil
+	}))
+	app.GET("/users/:id", Handle(func(ctx *Context, req struct {
+		ID string `uri:"id"`
+	}) (string, error) {
+		*calls++
+		return "user " + req.ID, nil
+	}))
+	return app
+}
+
+func TestCaseInsensitiveRouting(t *testing.T) {
+	calls := 0
+	app := routingApp(&calls, WithCaseInsensitiveRouting())
+
+	cases := map[string]string{
+		"/users/AbC": "user AbC",
+		"/USERS/AbC": "user AbC",
+		"/Users/Me":  "me",
+	}
+	for path, want := range cases {
+		w := get(app, path)
+		if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), want) {
+			t.Errorf("GET %s = %d %s, want %q", path, w.Code, w.Body, want)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("handler ran %d times, want 2", calls)
+	}
+	if w := get(app, "/USERS"); w.Code != http.StatusNotFound {
+		t.Errorf("GET /USERS = %d, want 404", w.Code)
+	}
+	if w := get(app, "/people/1"); w.Code != http.StatusNotFound {
+		t.Errorf("GET /people/1 = %d, want 404", w.Code)
+	}
+}
+
+func TestRoutingOptions(t *testing.T) {
+	calls := 0
+	if w := get(routingApp(&calls), "/USERS/1"); w.Code != http.StatusNotFound {
+		t.Errorf("case-sensitive by default: status = %d", w.Code)
+	}
+
+	if w := get(routingApp(&calls, WithRedirectTrailingSlash(false)), "/users/1/"); w.Code != http.StatusNotFound {
+		t.Errorf("trailing slash without redirects: status = %d", w.Code)
+	}
+	if w := get(routingApp(&calls), "/users/1/"); w.Code != http.StatusMovedPermanently {
+		t.Errorf("trailing slash redirect: status = %d", w.Code)
+	}
+}
+
+func TestFoldRoute(t *testing.T) {
+	fixed, static, ok := foldRoute("/files/*path", strings.Split("/FILES/A/b.txt", "/"))
+	if !ok || fixed != "/files/A/b.txt" || static != 1 {
+		t.Errorf("foldRoute = %q, %d, %v", fixed, static, ok)
+	}
+	if _, _, ok := foldRoute("/users/:id", strings.Split("/users/", "/")); ok {
+		t.Error("empty parameter matched")
+	}
+}
+
+func TestRedirectFixedPath(t *testing.T) {
+	calls := 0
+	app := routingApp(&calls, WithRedirectFixedPath(true))
+	w := get(app, "/api/../USERS//7?x=1")
+	if w.Code != http.StatusMovedPermanently || w.Header().Get("Location") != "/users/7?x=1" {
+		t.Errorf("redirect = %d to %q", w.Code, w.Header().Get("Location"))
+	}
+	req := httptest.NewRequest("POST", "/Users/7", nil)
+	rec := httptest.NewRecorder()
+	app.POST("/users/:id", func(ctx *gin.Context) {})
+	app.ServeHTTP(rec, req)
+	if rec.Code != http.StatusTemporaryRedirect {
+		t.Errorf("POST redirect = %d, want 307", rec.Code)
+	}
+}
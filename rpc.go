@@ -0,0 +1,293 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// JSON-RPC 2.0 error codes
+const (
+	RPCParseError     = -32700
+	RPCInvalidRequest = -32600
+	RPCMethodNotFound = -32601
+	RPCInvalidParams  = -32602
+	RPCInternalError  = -32603
+	// RPCServerError is the code of an HTTPError whose status has no JSON-RPC counterpart, from
+	// the range the spec leaves to implementations
+	RPCServerError = -32000
+)
+
+// RPCError is the error object of a JSON-RPC response
+type RPCError struct {
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("JSON-RPC %d: %s", e.Code, e.Message)
+}
+
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	ID      json.RawMessage `json:"id,omitempty"`
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *RPCError       `json:"error,omitempty"`
+	ID      json.RawMessage `json:"id"`
+}
+
+type rpcMethod struct {
+	params reflect.Type
+	result reflect.Type
+	call   func(ctx *Context, params json.RawMessage) (interface{}, *RPCError)
+}
+
+// RPC serves typed JSON-RPC 2.0 methods, including batches and notifications, on a single endpoint:
+//
+//	rpc := fluxo.NewRPC()
+//	fluxo.RPCMethod(rpc, "todos.create", createTodo)
+//	app.POST("/rpc", rpc.Handler())
+type RPC struct {
+	mu      sync.RWMutex
+	methods map[string]rpcMethod
+}
+
+func NewRPC() *RPC {
+	return &RPC{methods: make(map[string]rpcMethod)}
+}
+
+// RPCMethod registers fn under name. Params are decoded from the named (object) form and
+// validated like Handle request structs; HTTPError codes are returned as JSON-RPC error codes.
+func RPCMethod[Params any, Result any](rpc *RPC, name string, fn HandlerFunc[Params, Result]) {
+	paramsType := reflect.TypeOf((*Params)(nil)).Elem()
+	registerOptionalTypes(paramsType)
+	mods := sanitizerFor(paramsType)
+
+	call := func(ctx *Context, raw json.RawMessage) (interface{}, *RPCError) {
+		var params Params
+		if len(raw) > 0 && !bytes.Equal(bytes.TrimSpace(raw), []byte("null")) {
+			if err := json.Unmarshal(raw, &params); err != nil {
+				return nil, &RPCError{Code: RPCInvalidParams, Message: fmt.Sprintf("Invalid params: %v", err)}
+			}
+		}
+		if mods != nil {
+			mods(reflect.ValueOf(&params).Elem())
+		}
+		if isStructType(paramsType) {
+			if err := validateStruct(ctx.Context, &params); err != nil {
+				if cause, ok := ruleCause(err); ok {
+					return nil, rpcErrorFrom(cause)
+				}
+				return nil, &RPCError{Code: RPCInvalidParams, Message: fmt.Sprintf("Validation failed: %v", err)}
+			}
+		}
+
+		res, err := fn(ctx, params)
+		if err != nil {
+			return nil, rpcErrorFrom(err)
+		}
+		return res, nil
+	}
+
+	rpc.mu.Lock()
+	defer rpc.mu.Unlock()
+	rpc.methods[name] = rpcMethod{params: paramsType, result: reflect.TypeOf((*Result)(nil)).Elem(), call: call}
+}
+
+// rpcErrorFrom maps handler errors onto JSON-RPC errors. An HTTPError, wrapped or not, gets the
+// JSON-RPC code of its status, and the status itself as {"status": ...} in data.
+func rpcErrorFrom(err error) *RPCError {
+	var rpcErr *RPCError
+	if errors.As(err, &rpcErr) {
+		return rpcErr
+	}
+	var httpErr HTTPError
+	if errors.As(err, &httpErr) {
+		return &RPCError{Code: rpcCodeFor(httpErr.Status), Message: httpErr.Message, Data: gin.H{"status": httpErr.Status}}
+	}
+	return &RPCError{Code: RPCInternalError, Message: fmt.Sprintf("Internal error: %v", err)}
+}
+
+// rpcCodeFor returns the JSON-RPC code of an HTTP status. RPCMethodNotFound is kept for unknown
+// methods: a handler's 404 is about what it looked up, so it gets RPCServerError like other
+// statuses without a JSON-RPC counterpart.
+func rpcCodeFor(status int) int {
+	switch {
+	case status == http.StatusBadRequest || status == http.StatusUnprocessableEntity:
+		return RPCInvalidParams
+	case status >= 500:
+		return RPCInternalError
+	default:
+		return RPCServerError
+	}
+}
+
+// Handler returns the gin handler serving the registered methods; register it with a POST route
+func (rpc *RPC) Handler() gin.HandlerFunc {
+	handler := func(ctx *gin.Context) {
+		body, err := ctx.GetRawData()
+		if err != nil {
+			ctx.JSON(http.StatusOK, rpcResponse{JSONRPC: "2.0", Error: &RPCError{Code: RPCParseError, Message: "Parse error"}, ID: json.RawMessage("null")})
+			return
+		}
+		body = bytes.TrimSpace(body)
+
+		// Batch call
+		if len(body) > 0 && body[0] == '[' {
+			var batch []json.RawMessage
+			if err := json.Unmarshal(body, &batch); err != nil {
+				ctx.JSON(http.StatusOK, rpcResponse{JSONRPC: "2.0", Error: &RPCError{Code: RPCParseError, Message: "Parse error"}, ID: json.RawMessage("null")})
+				return
+			}
+			if len(batch) == 0 {
+				ctx.JSON(http.StatusOK, rpcResponse{JSONRPC: "2.0", Error: &RPCError{Code: RPCInvalidRequest, Message: "Invalid Request"}, ID: json.RawMessage("null")})
+				return
+			}
+			responses := make([]rpcResponse, 0, len(batch))
+			for _, raw := range batch {
+				if res, ok := rpc.dispatch(ctx, raw); ok {
+					responses = append(responses, res)
+				}
+			}
+			if len(responses) == 0 {
+				ctx.Status(http.StatusNoContent)
+				return
+			}
+			ctx.JSON(http.StatusOK, responses)
+			return
+		}
+
+		res, ok := rpc.dispatch(ctx, body)
+		if !ok {
+			ctx.Status(http.StatusNoContent)
+			return
+		}
+		ctx.JSON(http.StatusOK, res)
+	}
+
+	// The envelope is documented by rpc.document, so no Go types are registered for it
+	registerHandlerTypes(handler, nil, nil, "application/json")
+	registerOperationDoc(handler, rpc.document)
+	return handler
+}
+
+// dispatch runs one call; ok is false for notifications, which get no response
+func (rpc *RPC) dispatch(ctx *gin.Context, raw json.RawMessage) (rpcResponse, bool) {
+	var req rpcRequest
+	if err := json.Unmarshal(raw, &req); err != nil {
+		code, msg := RPCInvalidRequest, "Invalid Request"
+		if !json.Valid(raw) {
+			code, msg = RPCParseError, "Parse error"
+		}
+		return rpcResponse{JSONRPC: "2.0", Error: &RPCError{Code: code, Message: msg}, ID: json.RawMessage("null")}, true
+	}
+
+	id := req.ID
+	notification := len(id) == 0
+	if notification {
+		id = json.RawMessage("null")
+	}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		return rpcResponse{JSONRPC: "2.0", Error: &RPCError{Code: RPCInvalidRequest, Message: "Invalid Request"}, ID: id}, true
+	}
+
+	rpc.mu.RLock()
+	method, exists := rpc.methods[req.Method]
+	rpc.mu.RUnlock()
+
+	var res rpcResponse
+	if !exists {
+		res = rpcResponse{JSONRPC: "2.0", Error: &RPCError{Code: RPCMethodNotFound, Message: "Method not found"}, ID: id}
+	} else {
+		result, rpcErr := method.call(&Context{Context: ctx}, req.Params)
+		res = rpcResponse{JSONRPC: "2.0", Result: result, Error: rpcErr, ID: id}
+		if rpcErr == nil && result == nil {
+			res.Result = json.RawMessage("null")
+		}
+	}
+	return res, !notification
+}
+
+// document lists the registered methods and their params/result schemas on the RPC operation
+func (rpc *RPC) document(sg *SwaggerGenerator, op *Operation) {
+	rpc.mu.RLock()
+	names := make([]string, 0, len(rpc.methods))
+	for name := range rpc.methods {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var lines []string
+	enum := make([]interface{}, 0, len(names))
+	for _, name := range names {
+		m := rpc.methods[name]
+		// Struct params and results are added to components so the description can refer to them
+		sg.generateSchema(m.params)
+		sg.generateSchema(m.result)
+		lines = append(lines, fmt.Sprintf("- `%s`: params %s, result %s", name, typeLabel(sg, m.params), typeLabel(sg, m.result)))
+		enum = append(enum, name)
+	}
+	rpc.mu.RUnlock()
+
+	idSchema := Schema{Description: "String, number or null; omitted for notifications"}
+	op.Summary = "JSON-RPC 2.0 endpoint"
+	op.Description = "Accepts a single call or a batch (array) of calls. Methods:\n" + strings.Join(lines, "\n")
+	op.RequestBody = &RequestBody{
+		Required: true,
+		Content: map[string]MediaType{"application/json": {Schema: Schema{
+			Type:     "object",
+			Required: []string{"jsonrpc", "method"},
+			Properties: map[string]Schema{
+				"jsonrpc": {Type: "string", Enum: []interface{}{"2.0"}},
+				"method":  {Type: "string", Enum: enum},
+				"params":  {Type: "object", Description: "Params of the selected method"},
+				"id":      idSchema,
+			},
+		}}},
+	}
+	op.Responses["200"] = Response{
+		Description: "Result or error of the call (an array for batches)",
+		Content: map[string]MediaType{"application/json": {Schema: Schema{
+			Type: "object",
+			Properties: map[string]Schema{
+				"jsonrpc": {Type: "string"},
+				"result":  {Description: "Result of the method"},
+				"error": {Type: "object", Properties: map[string]Schema{
+					"code":    {Type: "integer", Description: "JSON-RPC code; an HTTPError's status is in data.status"},
+					"message": {Type: "string"},
+					"data":    {},
+				}},
+				"id": idSchema,
+			},
+		}}},
+	}
+	op.Responses["204"] = Response{Description: "Only notifications were sent"}
+}
+
+// typeLabel names a type the way it appears in the spec
+func typeLabel(sg *SwaggerGenerator, t reflect.Type) string {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Struct && t.Name() != "" {
+		return sg.componentName(t)
+	}
+	return sg.generateSchema(t).Type
+}
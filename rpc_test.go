@@ -0,0 +1,136 @@
+package fluxo
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type rpcAddParams struct {
+	A int `json:"a" validate:"min=0"`
+	B int `json:"b"`
+}
+
+type rpcAddResult struct {
+	Sum int `json:"sum"`
+}
+
+func newRPCApp() *App {
+	gin.SetMode(gin.TestMode)
+	app := New().WithSwagger("Test", "1.0.0")
+	rpc := NewRPC()
+	RPCMethod(rpc, "math.add", func(ctx *Context, p rpcAddParams) (rpcAddResult, error) {
+		return rpcAddResult{Sum: p.A + p.B}, nil
+	})
+	RPCMethod(rpc, "todos.get", func(ctx *Context, p struct {
+		ID int `json:"id"`
+	}) (gin.H, error) {
+		return nil, NotFound("todo not found")
+	})
+	app.POST("/rpc", rpc.Handler())
+	return app
+}
+
+func rpcCall(t *testing.T, app *App, body string) (int, string) {
+	t.Helper()
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/rpc", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	app.ServeHTTP(w, r)
+	return w.Code, w.Body.String()
+}
+
+func TestRPC_SingleCalls(t *testing.T) {
+	app := newRPCApp()
+
+	cases := []struct {
+		body string
+		want string
+	}{
+		{`{"jsonrpc":"2.0","method":"math.add","params":{"a":1,"b":2},"id":1}`, `{"jsonrpc":"2.0","result":{"sum":3},"id":1}`},
+		{`{"jsonrpc":"2.0","method":"math.add","params":{"a":-1},"id":"x"}`, `"code":-32602`},
+		{`{"jsonrpc":"2.0","method":"math.add","params":[1,2],"id":2}`, `"code":-32602`},
+		{`{"jsonrpc":"2.0","method":"todos.get","params":{"id":1},"id":3}`, `{"code":-32000,"message":"todo not found","data":{"status":404}}`},
+		{`{"jsonrpc":"2.0","method":"nope","id":4}`, `"code":-32601`},
+		{`{"jsonrpc":"1.0","method":"math.add","id":5}`, `"code":-32600`},
+		{`{"jsonrpc":"2.0","method"`, `"code":-32700`},
+	}
+	for _, tc := range cases {
+		code, body := rpcCall(t, app, tc.body)
+		if code != http.StatusOK || !strings.Contains(body, tc.want) {
+			t.Errorf("%s: status=%d body=%s", tc.body, code, body)
+		}
+	}
+
+	if code, _ := rpcCall(t, app, `{"jsonrpc":"2.0","method":"math.add","params":{"a":1}}`); code != http.StatusNoContent {
+		t.Errorf("expected 204 for notification, got %d", code)
+	}
+}
+
+func TestRPC_HTTPErrorCodes(t *testing.T) {
+	cases := []struct {
+		status int
+		code   int
+	}{
+		{http.StatusBadRequest, RPCInvalidParams},
+		{http.StatusUnprocessableEntity, RPCInvalidParams},
+		{http.StatusNotFound, RPCServerError},
+		{http.StatusInternalServerError, RPCInternalError},
+		{http.StatusServiceUnavailable, RPCInternalError},
+		{http.StatusForbidden, RPCServerError},
+	}
+	for _, tc := range cases {
+		rpcErr := rpcErrorFrom(NewHTTPError(tc.status, "nope"))
+		if rpcErr.Code != tc.code || rpcErr.Data.(gin.H)["status"] != tc.status {
+			t.Errorf("%d: got %+v", tc.status, rpcErr)
+		}
+	}
+
+	wrapped := rpcErrorFrom(fmt.Errorf("loading todo: %w", NotFound("todo not found")))
+	if wrapped.Code != RPCServerError || wrapped.Message != "todo not found" || wrapped.Data.(gin.H)["status"] != http.StatusNotFound {
+		t.Errorf("wrapped: got %+v", wrapped)
+	}
+}
+
+func TestRPC_Batch(t *testing.T) {
+	app := newRPCApp()
+
+	_, body := rpcCall(t, app, `[
+		{"jsonrpc":"2.0","method":"math.add","params":{"a":1,"b":1},"id":1},
+		{"jsonrpc":"2.0","method":"math.add","params":{"a":5}},
+		{"jsonrpc":"2.0","method":"nope","id":2},
+		1
+	]`)
+	var responses []rpcResponse
+	if err := json.Unmarshal([]byte(body), &responses); err != nil {
+		t.Fatalf("invalid batch response %s", body)
+	}
+	if len(responses) != 3 {
+		t.Fatalf("expected 3 responses, got %s", body)
+	}
+	if responses[1].Error.Code != RPCMethodNotFound || responses[2].Error.Code != RPCInvalidRequest {
+		t.Fatalf("unexpected batch errors %s", body)
+	}
+
+	if _, body := rpcCall(t, app, `[]`); !strings.Contains(body, `"code":-32600`) {
+		t.Fatalf("expected invalid request for empty batch, got %s", body)
+	}
+}
+
+func TestRPC_Spec(t *testing.T) {
+	app := newRPCApp()
+	spec := app.swagger.Generate(app.handlers)
+	post := spec["paths"].(map[string]interface{})["/rpc"].(map[string]interface{})["post"].(map[string]interface{})
+	if !strings.Contains(post["description"].(string), "`math.add`: params rpcAddParams, result rpcAddResult") {
+		t.Fatalf("unexpected description %v", post["description"])
+	}
+	schemas := spec["components"].(map[string]interface{})["schemas"].(map[string]interface{})
+	if _, ok := schemas["rpcAddParams"]; !ok {
+		t.Fatalf("expected params schema in components, got %v", schemas)
+	}
+}
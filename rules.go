@@ -0,0 +1,99 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// RuleFunc is a validation rule that needs more than the value, e.g. a uniqueness check against
+// the database. ctx is the request's context, carrying what middleware stored on the gin context
+// like the context of a HandleCtx handler; param is the tag's parameter. Returning false fails
+// the field like a static rule would, while an error aborts the request and is written like a
+// handler error.
+type RuleFunc func(ctx context.Context, value any, param string) (bool, error)
+
+// RegisterRule makes fn available to validate tags as tag on the default validator. Like
+// RegisterModifier, call it before serving requests:
+//
+//	fluxo.RegisterRule("unique_email", func(ctx context.Context, v any, _ string) (bool, error) {
+//		taken, err := DBKey.MustGet(ctx).EmailTaken(ctx, v.(string))
+//		return !taken, err
+//	})
+//	fluxo.RegisterTranslation("en", "unique_email", "%s is already taken")
+func RegisterRule(tag string, fn RuleFunc) {
+	defaultValidator.RegisterRule(tag, fn)
+}
+
+// RegisterRule makes fn available to validate tags of this validator as tag
+func (v *Validator) RegisterRule(tag string, fn RuleFunc) {
+	check := func(ctx context.Context, fl validator.FieldLevel) bool {
+		state, _ := ctx.Value(ruleStateKey{}).(*ruleState)
+		// After a failure the request is lost anyway, so spare the remaining lookups
+		if state != nil && state.failed() {
+			return true
+		}
+		field := fl.Field()
+		if !field.IsValid() {
+			return true
+		}
+		ok, err := fn(ctx, field.Interface(), fl.Param())
+		if err != nil {
+			if state != nil {
+				state.fail(err)
+			}
+			return true
+		}
+		return ok
+	}
+	for _, engine := range v.engines() {
+		if err := engine.RegisterValidationCtx(tag, check); err != nil {
+			panic(fmt.Sprintf("fluxo: register rule %q: %v", tag, err))
+		}
+	}
+}
+
+type ruleStateKey struct{}
+
+// ruleState collects the first error of the rules run by one validation
+type ruleState struct {
+	mu  sync.Mutex
+	err error
+}
+
+func (s *ruleState) fail(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err == nil {
+		s.err = err
+	}
+}
+
+func (s *ruleState) failed() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err != nil
+}
+
+// ruleError is returned by validation when a rule failed to run rather than rejected a value
+type ruleError struct {
+	err error
+}
+
+func (e *ruleError) Error() string { return e.err.Error() }
+
+func (e *ruleError) Unwrap() error { return e.err }
+
+// ruleCause returns the error of the rule that could not run, if err comes from one
+func ruleCause(err error) (error, bool) {
+	var re *ruleError
+	if errors.As(err, &re) {
+		return re.err, true
+	}
+	return nil, false
+}
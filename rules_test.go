@@ -0,0 +1,122 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ruleUsers stands in for a user table
+type ruleUsers struct {
+	emails  map[string]bool
+	down    bool
+	lookups int
+}
+
+var ruleUsersKey = NewKey[*ruleUsers]("users")
+
+func init() {
+	RegisterRule("test_unique_email", func(ctx context.Context, v any, _ string) (bool, error) {
+		users := ruleUsersKey.MustGet(ctx)
+		users.lookups++
+		if users.down {
+			return false, NewHTTPError(http.StatusServiceUnavailable, "user store unavailable")
+		}
+		return !users.emails[v.(string)], nil
+	})
+	RegisterRule("test_prefix", func(ctx context.Context, v any, param string) (bool, error) {
+		return strings.HasPrefix(v.(string), param), nil
+	})
+}
+
+type ruleSignupReq struct {
+	Email string `json:"email" validate:"required,email,test_unique_email"`
+	Code  string `json:"code" validate:"test_prefix=INV-"`
+}
+
+func ruleApp(users *ruleUsers) *App {
+	gin.SetMode(gin.TestMode)
+	app := New()
+	app.Use(func(ctx *gin.Context) {
+		ruleUsersKey.Set(ctx, users)
+	})
+	app.POST("/signup", Handle(func(ctx *Context, req ruleSignupReq) (gin.H, error) {
+		return gin.H{"ok": true}, nil
+	}))
+	return app
+}
+
+func postSignup(app *App, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/signup", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	app.ServeHTTP(w, req)
+	return w
+}
+
+func TestRegisterRule(t *testing.T) {
+	users := &ruleUsers{emails: map[string]bool{"taken@example.com": true}}
+	app := ruleApp(users)
+
+	if w := postSignup(app, `{"email":"new@example.com","code":"INV-1"}`); w.Code != http.StatusOK {
+		t.Fatalf("expected a free email to pass, got %d %s", w.Code, w.Body.String())
+	}
+
+	w := postSignup(app, `{"email":"taken@example.com","code":"X"}`)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d %s", w.Code, w.Body.String())
+	}
+	for _, want := range []string{"Email failed validation for test_unique_email", "Code failed validation for test_prefix"} {
+		if !strings.Contains(w.Body.String(), want) {
+			t.Fatalf("expected %q alongside the static rules, got %s", want, w.Body.String())
+		}
+	}
+
+	// Static rules run first, so an invalid email never reaches the store
+	users.lookups = 0
+	if w := postSignup(app, `{"email":"nope","code":"INV-1"}`); w.Code != http.StatusBadRequest || users.lookups != 0 {
+		t.Fatalf("expected the store to be skipped, got %d after %d lookups", w.Code, users.lookups)
+	}
+}
+
+func TestRegisterRule_Translation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	v := NewValidator()
+	v.RegisterRule("prefix", func(ctx context.Context, value any, param string) (bool, error) {
+		return strings.HasPrefix(value.(string), param), nil
+	})
+	v.RegisterTranslation("en", "prefix", "%s must start with %s")
+	app := New(WithValidator(v))
+	app.POST("/codes", Handle(func(ctx *Context, req struct {
+		Code string `json:"code" validate:"prefix=INV-"`
+	}) (gin.H, error) {
+		return gin.H{"ok": true}, nil
+	}))
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/codes", strings.NewReader(`{"code":"X"}`))
+	req.Header.Set("Content-Type", "application/json")
+	app.ServeHTTP(w, req)
+	if !strings.Contains(w.Body.String(), "Code must start with INV-") {
+		t.Fatalf("expected the translated message, got %s", w.Body.String())
+	}
+}
+
+func TestRegisterRule_Error(t *testing.T) {
+	app := ruleApp(&ruleUsers{down: true})
+	w := postSignup(app, `{"email":"a@example.com","code":"INV-1"}`)
+	if w.Code != http.StatusServiceUnavailable || !strings.Contains(w.Body.String(), "user store unavailable") {
+		t.Fatalf("expected the rule's error to be written, got %d %s", w.Code, w.Body.String())
+	}
+
+	if _, ok := ruleCause(errors.New("validation failed")); ok {
+		t.Fatal("expected plain errors not to be rule errors")
+	}
+}
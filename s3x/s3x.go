@@ -0,0 +1,178 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+
+// Package s3x stores fluxo uploads in S3 or any S3-compatible service (MinIO, R2, Google Cloud
+// Storage through its XML API with HMAC keys, ...).
+package s3x
+
+import (
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/leviantech/fluxo"
+)
+
+// Client is the part of *s3.Client used by Storage
+type Client interface {
+	manager.UploadAPIClient
+	GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error)
+	DeleteObject(ctx context.Context, params *s3.DeleteObjectInput, optFns ...func(*s3.Options)) (*s3.DeleteObjectOutput, error)
+}
+
+// Storage is a fluxo.Storage writing objects to a bucket.
+// Uploads are streamed in parts, so files of unknown size are never held in memory whole.
+type Storage struct {
+	client   Client
+	uploader *manager.Uploader
+	presign  *s3.PresignClient // when client is an *s3.Client
+	bucket   string
+	prefix   string
+}
+
+var (
+	_ fluxo.Storage   = (*Storage)(nil)
+	_ fluxo.Presigner = (*Storage)(nil)
+)
+
+// ErrNoPresign is returned when URLs are pre-signed by a Storage whose client isn't an *s3.Client
+var ErrNoPresign = errors.New("s3x: pre-signing URLs needs an *s3.Client")
+
+// Option configures a Storage
+type Option func(*Storage)
+
+// WithPrefix stores objects under prefix, e.g. "uploads/"
+func WithPrefix(prefix string) Option {
+	return func(s *Storage) {
+		s.prefix = prefix
+	}
+}
+
+// WithUploader configures the multipart uploader, e.g. its part size and concurrency
+func WithUploader(opts ...func(*manager.Uploader)) Option {
+	return func(s *Storage) {
+		s.uploader = manager.NewUploader(s.client, opts...)
+	}
+}
+
+// New creates a Storage for bucket
+func New(client Client, bucket string, opts ...Option) *Storage {
+	s := &Storage{client: client, bucket: bucket}
+	s.uploader = manager.NewUploader(client)
+	if c, ok := client.(*s3.Client); ok {
+		s.presign = s3.NewPresignClient(c)
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func (s *Storage) key(key string) *string {
+	return aws.String(path.Join(s.prefix, key))
+}
+
+// Put uploads r; failed multipart uploads are aborted by the uploader
+func (s *Storage) Put(ctx context.Context, key string, r io.Reader) error {
+	_, err := s.uploader.Upload(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    s.key(key),
+		Body:   r,
+	})
+	return err
+}
+
+func (s *Storage) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	out, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    s.key(key),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out.Body, nil
+}
+
+func (s *Storage) Delete(ctx context.Context, key string) error {
+	_, err := s.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    s.key(key),
+	})
+	return err
+}
+
+// PresignPut signs a PutObject request for key, bound to the content type and length of opts
+func (s *Storage) PresignPut(ctx context.Context, key string, opts fluxo.PresignOptions) (fluxo.PresignedURL, error) {
+	if s.presign == nil {
+		return fluxo.PresignedURL{}, ErrNoPresign
+	}
+	input := &s3.PutObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    s.key(key),
+	}
+	if opts.ContentType != "" {
+		input.ContentType = aws.String(opts.ContentType)
+	}
+	if opts.ContentLength > 0 {
+		input.ContentLength = aws.Int64(opts.ContentLength)
+	}
+	expires := presignExpiry(opts)
+	req, err := s.presign.PresignPutObject(ctx, input, s3.WithPresignExpires(expires))
+	if err != nil {
+		return fluxo.PresignedURL{}, err
+	}
+	return presigned(req, key, expires), nil
+}
+
+// PresignGet signs a GetObject request for key, served with the content type and file name of opts
+func (s *Storage) PresignGet(ctx context.Context, key string, opts fluxo.PresignOptions) (fluxo.PresignedURL, error) {
+	if s.presign == nil {
+		return fluxo.PresignedURL{}, ErrNoPresign
+	}
+	input := &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    s.key(key),
+	}
+	if opts.ContentType != "" {
+		input.ResponseContentType = aws.String(opts.ContentType)
+	}
+	if opts.Filename != "" {
+		input.ResponseContentDisposition = aws.String(mime.FormatMediaType("attachment", map[string]string{"filename": opts.Filename}))
+	}
+	expires := presignExpiry(opts)
+	req, err := s.presign.PresignGetObject(ctx, input, s3.WithPresignExpires(expires))
+	if err != nil {
+		return fluxo.PresignedURL{}, err
+	}
+	return presigned(req, key, expires), nil
+}
+
+func presignExpiry(opts fluxo.PresignOptions) time.Duration {
+	if opts.Expires > 0 {
+		return opts.Expires
+	}
+	return fluxo.DefaultPresignExpiry
+}
+
+// presigned returns the URL of req with the headers the client must send, Host aside
+func presigned(req *v4.PresignedHTTPRequest, key string, expires time.Duration) fluxo.PresignedURL {
+	u := fluxo.PresignedURL{Method: req.Method, URL: req.URL, Key: key, ExpiresAt: time.Now().Add(expires).UTC()}
+	for name := range req.SignedHeader {
+		if name = http.CanonicalHeaderKey(name); name == "Host" {
+			continue
+		}
+		if u.Headers == nil {
+			u.Headers = map[string]string{}
+		}
+		u.Headers[name] = req.SignedHeader.Get(name)
+	}
+	return u
+}
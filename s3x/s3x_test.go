@@ -0,0 +1,138 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package s3x
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/leviantech/fluxo"
+)
+
+// fakeS3 serves single-part PutObject, GetObject and DeleteObject with path-style URLs
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string]string
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch r.Method {
+	case http.MethodPut:
+		body, _ := io.ReadAll(r.Body)
+		f.objects[r.URL.Path] = string(body)
+	case http.MethodGet:
+		body, ok := f.objects[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = io.WriteString(w, body)
+	case http.MethodDelete:
+		delete(f.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func newTestClient(url string) *s3.Client {
+	return s3.New(s3.Options{
+		Region:                     "us-east-1",
+		BaseEndpoint:               aws.String(url),
+		UsePathStyle:               true,
+		Credentials:                aws.AnonymousCredentials{},
+		RequestChecksumCalculation: aws.RequestChecksumCalculationWhenRequired,
+		ResponseChecksumValidation: aws.ResponseChecksumValidationWhenRequired,
+	})
+}
+
+func TestStorage(t *testing.T) {
+	fake := &fakeS3{objects: map[string]string{}}
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	ctx := context.Background()
+	storage := New(newTestClient(srv.URL), "media", WithPrefix("uploads"))
+	if err := storage.Put(ctx, "a.txt", strings.NewReader("hello")); err != nil {
+		t.Fatal(err)
+	}
+	if fake.objects["/media/uploads/a.txt"] != "hello" {
+		t.Fatalf("objects: %v", fake.objects)
+	}
+
+	rc, err := storage.Open(ctx, "a.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := io.ReadAll(rc)
+	_ = rc.Close()
+	if string(data) != "hello" {
+		t.Fatalf("read %q", data)
+	}
+
+	if err := storage.Delete(ctx, "a.txt"); err != nil {
+		t.Fatal(err)
+	}
+	if len(fake.objects) != 0 {
+		t.Fatalf("objects: %v", fake.objects)
+	}
+}
+
+func newSigningClient(url string) *s3.Client {
+	return s3.New(s3.Options{
+		Region:       "us-east-1",
+		BaseEndpoint: aws.String(url),
+		UsePathStyle: true,
+		Credentials: aws.CredentialsProviderFunc(func(context.Context) (aws.Credentials, error) {
+			return aws.Credentials{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "secret"}, nil
+		}),
+	})
+}
+
+func TestStorage_Presign(t *testing.T) {
+	ctx := context.Background()
+	storage := New(newSigningClient("https://s3.example.com"), "media", WithPrefix("uploads"))
+
+	put, err := storage.PresignPut(ctx, "scan.pdf", fluxo.PresignOptions{ContentType: "application/pdf", ContentLength: 1024, Expires: time.Minute})
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, _ := url.Parse(put.URL)
+	if put.Method != http.MethodPut || u.Path != "/media/uploads/scan.pdf" || u.Query().Get("X-Amz-Expires") != "60" || put.Key != "scan.pdf" {
+		t.Errorf("unexpected upload URL %+v", put)
+	}
+	if put.Headers["Content-Type"] != "application/pdf" || put.Headers["Content-Length"] != "1024" || put.Headers["Host"] != "" {
+		t.Errorf("expected the type and size signed, got %v", put.Headers)
+	}
+	if !strings.Contains(u.Query().Get("X-Amz-SignedHeaders"), "content-type") || time.Until(put.ExpiresAt) > time.Minute {
+		t.Errorf("unexpected signature %v expiring at %v", u.Query(), put.ExpiresAt)
+	}
+
+	get, err := storage.PresignGet(ctx, "scan.pdf", fluxo.PresignOptions{Filename: "Scan 1.pdf"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, _ = url.Parse(get.URL)
+	if get.Method != http.MethodGet || u.Query().Get("response-content-disposition") != `attachment; filename="Scan 1.pdf"` ||
+		u.Query().Get("X-Amz-Expires") != "900" {
+		t.Errorf("unexpected download URL %+v", get)
+	}
+
+	custom := New(fakeClient{newSigningClient("https://s3.example.com")}, "media")
+	if _, err := custom.PresignGet(ctx, "scan.pdf", fluxo.PresignOptions{}); !errors.Is(err, ErrNoPresign) {
+		t.Errorf("expected ErrNoPresign for other clients, got %v", err)
+	}
+}
+
+// fakeClient wraps a client, hiding that it is an *s3.Client
+type fakeClient struct{ *s3.Client }
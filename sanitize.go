@@ -0,0 +1,193 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Modifier rewrites the value of a string field listing it in a `mod` tag. Modifiers run in tag
+// order after binding and before validation, so handlers and validation rules see clean input:
+//
+//	type SignupReq struct {
+//	    Email string `json:"email" mod:"trim,lcase" validate:"required,email"`
+//	    Name  string `json:"name" mod:"strip_ctrl,trim"`
+//	}
+//
+// They apply to strings, pointers to strings, string slices and Optional strings, and nested
+// structs are sanitized too.
+type Modifier func(string) string
+
+var modifiers sync.Map // name -> Modifier
+
+func init() {
+	RegisterModifier("trim", strings.TrimSpace)
+	RegisterModifier("ltrim", func(s string) string { return strings.TrimLeftFunc(s, unicode.IsSpace) })
+	RegisterModifier("rtrim", func(s string) string { return strings.TrimRightFunc(s, unicode.IsSpace) })
+	RegisterModifier("lcase", strings.ToLower)
+	RegisterModifier("ucase", strings.ToUpper)
+	RegisterModifier("strip_ctrl", func(s string) string {
+		return strings.Map(func(r rune) rune {
+			if unicode.IsControl(r) {
+				return -1
+			}
+			return r
+		}, s)
+	})
+}
+
+// RegisterModifier makes fn available to `mod` tags as name, replacing any modifier of that name.
+// Register modifiers before building the handlers that use them.
+func RegisterModifier(name string, fn Modifier) {
+	modifiers.Store(name, fn)
+}
+
+// sanitizer applies the modifiers of a type to a value of it
+type sanitizer func(v reflect.Value)
+
+var sanitizers sync.Map // reflect.Type -> sanitizer, nil when the type has no mod tags
+
+// sanitizerFor returns the cached sanitizer of t, or nil when nothing in t is tagged. It panics
+// when a tag names an unknown modifier or a field that isn't a string, like a bad route would.
+func sanitizerFor(t reflect.Type) sanitizer {
+	if t == nil {
+		return nil
+	}
+	if s, ok := sanitizers.Load(t); ok {
+		return s.(sanitizer)
+	}
+	s, err := compileSanitizer(t, map[reflect.Type]bool{})
+	if err != nil {
+		panic(fmt.Sprintf("fluxo: %v", err))
+	}
+	actual, _ := sanitizers.LoadOrStore(t, s)
+	return actual.(sanitizer)
+}
+
+// compileSanitizer walks t for mod tags; recursive types are only sanitized down to their first repetition
+func compileSanitizer(t reflect.Type, seen map[reflect.Type]bool) (sanitizer, error) {
+	if seen[t] {
+		return nil, nil
+	}
+	seen[t] = true
+	defer delete(seen, t)
+
+	if elem, ok := optionalElem(t); ok {
+		inner, err := compileSanitizer(elem, seen)
+		if inner == nil || err != nil {
+			return nil, err
+		}
+		return func(v reflect.Value) { inner(v.Field(0)) }, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array:
+		inner, err := compileSanitizer(t.Elem(), seen)
+		if inner == nil || err != nil {
+			return nil, err
+		}
+		return eachElem(t, inner), nil
+	case reflect.Struct:
+		var fields []sanitizer
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			var (
+				s   sanitizer
+				err error
+			)
+			if tag := field.Tag.Get("mod"); tag != "" {
+				s, err = compileModifiers(field, tag)
+			} else {
+				s, err = compileSanitizer(field.Type, seen)
+			}
+			if err != nil {
+				return nil, err
+			}
+			if s != nil {
+				index := i
+				fields = append(fields, func(v reflect.Value) { s(v.Field(index)) })
+			}
+		}
+		if len(fields) == 0 {
+			return nil, nil
+		}
+		return func(v reflect.Value) {
+			for _, f := range fields {
+				f(v)
+			}
+		}, nil
+	}
+	return nil, nil
+}
+
+// compileModifiers returns the sanitizer applying the modifiers listed in tag to field
+func compileModifiers(field reflect.StructField, tag string) (sanitizer, error) {
+	var chain []Modifier
+	for _, name := range strings.Split(tag, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		fn, ok := modifiers.Load(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown modifier %q on field %s", name, field.Name)
+		}
+		chain = append(chain, fn.(Modifier))
+	}
+	apply := func(s string) string {
+		for _, fn := range chain {
+			s = fn(s)
+		}
+		return s
+	}
+	s := stringSanitizer(field.Type, apply)
+	if s == nil {
+		return nil, fmt.Errorf("mod tag on field %s of type %s, which holds no string", field.Name, field.Type)
+	}
+	return s, nil
+}
+
+// stringSanitizer returns the sanitizer applying fn to the strings of t, or nil when t holds none
+func stringSanitizer(t reflect.Type, fn func(string) string) sanitizer {
+	if elem, ok := optionalElem(t); ok {
+		inner := stringSanitizer(elem, fn)
+		if inner == nil {
+			return nil
+		}
+		return func(v reflect.Value) { inner(v.Field(0)) }
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return func(v reflect.Value) { v.SetString(fn(v.String())) }
+	case reflect.Ptr, reflect.Slice, reflect.Array:
+		inner := stringSanitizer(t.Elem(), fn)
+		if inner == nil {
+			return nil
+		}
+		return eachElem(t, inner)
+	}
+	return nil
+}
+
+// eachElem applies inner to what a pointer, slice or array of type t holds
+func eachElem(t reflect.Type, inner sanitizer) sanitizer {
+	if t.Kind() == reflect.Ptr {
+		return func(v reflect.Value) {
+			if !v.IsNil() {
+				inner(v.Elem())
+			}
+		}
+	}
+	return func(v reflect.Value) {
+		for i := 0; i < v.Len(); i++ {
+			inner(v.Index(i))
+		}
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type sanitizeAddress struct {
+	City string `json:"city" mod:"trim,ucase"`
+}
+
+type sanitizeReq struct {
+	Email    string            `json:"email" mod:"trim,lcase" validate:"required,email"`
+	Name     string            `json:"name" mod:"strip_ctrl,trim"`
+	Nick     *string           `json:"nick" mod:"trim"`
+	Tags     []string          `json:"tags" mod:"lcase"`
+	Title    Optional[string]  `json:"title" mod:"rtrim"`
+	Home     sanitizeAddress   `json:"home"`
+	Others   []sanitizeAddress `json:"others"`
+	Untagged string            `json:"untagged"`
+	Search   string            `form:"q" mod:"trim"`
+}
+
+func TestHandle_Sanitize(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := New()
+	var got sanitizeReq
+	app.POST("/signup", Handle(func(ctx *Context, req sanitizeReq) (gin.H, error) {
+		got = req
+		return gin.H{"ok": true}, nil
+	}))
+
+	body := `{"email":"  Ann@Example.COM ","name":" Ann\u0000\u0007 ","nick":" a ","tags":["Go","API"],` +
+		`"title":"Hi  ","home":{"city":" oslo "},"others":[{"city":"rome "}],"untagged":" x "}`
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/signup?q=+find+", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	app.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected the trimmed email to validate, got %d %s", w.Code, w.Body.String())
+	}
+
+	want := sanitizeReq{
+		Email:    "ann@example.com",
+		Name:     "Ann",
+		Tags:     []string{"go", "api"},
+		Title:    Some("Hi"),
+		Home:     sanitizeAddress{City: "OSLO"},
+		Others:   []sanitizeAddress{{City: "ROME"}},
+		Untagged: " x ",
+		Search:   "find",
+	}
+	if got.Nick == nil || *got.Nick != "a" {
+		t.Fatalf("expected the pointer to be trimmed, got %v", got.Nick)
+	}
+	got.Nick = nil
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %+v, got %+v", want, got)
+	}
+}
+
+func TestRPCMethod_Sanitize(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	rpc := NewRPC()
+	RPCMethod(rpc, "echo", func(ctx *Context, req sanitizeAddress) (sanitizeAddress, error) {
+		return req, nil
+	})
+	app := New()
+	app.POST("/rpc", rpc.Handler())
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/rpc", strings.NewReader(`{"jsonrpc":"2.0","method":"echo","params":{"city":" bern "},"id":1}`))
+	req.Header.Set("Content-Type", "application/json")
+	app.ServeHTTP(w, req)
+	if !strings.Contains(w.Body.String(), `"city":"BERN"`) {
+		t.Fatalf("expected sanitized params, got %s", w.Body.String())
+	}
+}
+
+func TestRegisterModifier(t *testing.T) {
+	RegisterModifier("test_dashes", func(s string) string { return strings.ReplaceAll(s, " ", "-") })
+	type slugReq struct {
+		Slug string `json:"slug" mod:"trim,lcase,test_dashes"`
+	}
+	v := slugReq{Slug: " Hello World "}
+	sanitizerFor(reflect.TypeOf(v))(reflect.ValueOf(&v).Elem())
+	if v.Slug != "hello-world" {
+		t.Fatalf("expected a slug, got %q", v.Slug)
+	}
+}
+
+func TestSanitizerFor_InvalidTags(t *testing.T) {
+	type unknownMod struct {
+		Name string `mod:"nope"`
+	}
+	type notString struct {
+		Age int `mod:"trim"`
+	}
+	for _, typ := range []reflect.Type{reflect.TypeOf(unknownMod{}), reflect.TypeOf(notString{})} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("expected %s to panic", typ)
+				}
+			}()
+			sanitizerFor(typ)
+		}()
+	}
+	if s := sanitizerFor(reflect.TypeOf(codecItem{})); s != nil {
+		t.Fatal("expected no sanitizer for a type without mod tags")
+	}
+}
@@ -0,0 +1,233 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// ScheduledFunc is the work of a scheduled task
+type ScheduledFunc func(ctx context.Context) error
+
+// ScheduleStats describes a scheduled task and its runs so far
+type ScheduleStats struct {
+	Name         string        `json:"name"`
+	Spec         string        `json:"spec"`
+	Runs         uint64        `json:"runs"`
+	Failures     uint64        `json:"failures"`
+	Skipped      uint64        `json:"skipped"` // runs skipped because the previous one was still going
+	Running      bool          `json:"running"`
+	LastRun      time.Time     `json:"last_run,omitempty"`
+	LastDuration time.Duration `json:"last_duration"`
+	LastError    string        `json:"last_error,omitempty"`
+	NextRun      time.Time     `json:"next_run"`
+}
+
+// ScheduleOption configures a scheduled task
+type ScheduleOption func(*scheduledTask)
+
+// WithScheduleName names the task in Schedules (default its spec)
+func WithScheduleName(name string) ScheduleOption {
+	return func(t *scheduledTask) { t.stats.Name = name }
+}
+
+// WithJitter delays each run by a random duration up to max, so instances sharing a schedule
+// do not all start at once
+func WithJitter(max time.Duration) ScheduleOption {
+	return func(t *scheduledTask) { t.jitter = max }
+}
+
+// WithOverlap lets a run start while the previous one is still going; by default it is skipped
+func WithOverlap() ScheduleOption {
+	return func(t *scheduledTask) { t.overlap = true }
+}
+
+// WithScheduleTimeout cancels the context of a run after d
+func WithScheduleTimeout(d time.Duration) ScheduleOption {
+	return func(t *scheduledTask) { t.timeout = d }
+}
+
+// WithScheduleObserver is called after every run, e.g. to record metrics or log failures
+func WithScheduleObserver(fn func(name string, took time.Duration, err error)) ScheduleOption {
+	return func(t *scheduledTask) { t.observe = fn }
+}
+
+type scheduledTask struct {
+	schedule cron.Schedule
+	fn       ScheduledFunc
+	jitter   time.Duration
+	overlap  bool
+	timeout  time.Duration
+	observe  func(name string, took time.Duration, err error)
+
+	mu      sync.Mutex
+	stats   ScheduleStats
+	running int
+}
+
+// scheduler runs the scheduled tasks of an app until it shuts down
+type scheduler struct {
+	mu     sync.Mutex
+	tasks  []*scheduledTask
+	stop   chan struct{}   // closed on shutdown to stop scheduling runs
+	once   sync.Once       // closes stop, as Shutdown may be called more than once
+	ctx    context.Context // passed to runs, cancelled when shutdown runs out of time
+	cancel context.CancelFunc
+	loops  sync.WaitGroup // one per task, waiting for the next run
+	runs   sync.WaitGroup // runs in progress
+}
+
+// Schedule runs fn on a cron schedule: five fields ("*/5 * * * *"), a descriptor like "@hourly"
+// or "@every 90s". Runs start in the background right away and stop on Shutdown, which waits
+// for runs in progress.
+//
+//	app.Schedule("0 3 * * *", cleanupTokens, fluxo.WithScheduleName("token-cleanup"), fluxo.WithJitter(time.Minute))
+func (a *App) Schedule(spec string, fn ScheduledFunc, opts ...ScheduleOption) error {
+	schedule, err := cron.ParseStandard(spec)
+	if err != nil {
+		return fmt.Errorf("invalid schedule %q: %w", spec, err)
+	}
+	a.addSchedule(spec, schedule, fn, opts...)
+	return nil
+}
+
+func (a *App) addSchedule(spec string, schedule cron.Schedule, fn ScheduledFunc, opts ...ScheduleOption) {
+	t := &scheduledTask{schedule: schedule, fn: fn, stats: ScheduleStats{Name: spec, Spec: spec}}
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	if a.scheduler == nil {
+		ctx, cancel := context.WithCancel(context.Background())
+		a.scheduler = &scheduler{stop: make(chan struct{}), ctx: ctx, cancel: cancel}
+		a.OnShutdown(a.scheduler.shutdown)
+	}
+	s := a.scheduler
+	s.mu.Lock()
+	s.tasks = append(s.tasks, t)
+	s.mu.Unlock()
+	s.loops.Add(1)
+	go s.loop(t)
+}
+
+// Schedules returns the scheduled tasks and their runs so far, sorted by name
+func (a *App) Schedules() []ScheduleStats {
+	if a.scheduler == nil {
+		return nil
+	}
+	a.scheduler.mu.Lock()
+	defer a.scheduler.mu.Unlock()
+	out := make([]ScheduleStats, 0, len(a.scheduler.tasks))
+	for _, t := range a.scheduler.tasks {
+		t.mu.Lock()
+		stats := t.stats
+		stats.Running = t.running > 0
+		t.mu.Unlock()
+		out = append(out, stats)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+func (s *scheduler) loop(t *scheduledTask) {
+	defer s.loops.Done()
+	for {
+		next := t.schedule.Next(time.Now())
+		if t.jitter > 0 {
+			next = next.Add(rand.N(t.jitter))
+		}
+		t.mu.Lock()
+		t.stats.NextRun = next
+		t.mu.Unlock()
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-s.stop:
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		t.mu.Lock()
+		if t.running > 0 && !t.overlap {
+			t.stats.Skipped++
+			t.mu.Unlock()
+			continue
+		}
+		t.running++
+		t.mu.Unlock()
+
+		s.runs.Add(1)
+		go func() {
+			defer s.runs.Done()
+			s.run(t)
+		}()
+	}
+}
+
+func (s *scheduler) run(t *scheduledTask) {
+	ctx := s.ctx
+	if t.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, t.timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	err := func() (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("scheduled task panicked: %v", r)
+			}
+		}()
+		return t.fn(ctx)
+	}()
+	took := time.Since(start)
+
+	t.mu.Lock()
+	t.running--
+	t.stats.Runs++
+	t.stats.LastRun = start
+	t.stats.LastDuration = took
+	t.stats.LastError = ""
+	if err != nil {
+		t.stats.Failures++
+		t.stats.LastError = err.Error()
+	}
+	name := t.stats.Name
+	t.mu.Unlock()
+
+	if t.observe != nil {
+		t.observe(name, took, err)
+	}
+}
+
+// shutdown stops scheduling runs and waits for runs in progress; when ctx ends first they are
+// cancelled and ctx's error is returned
+func (s *scheduler) shutdown(ctx context.Context) error {
+	s.once.Do(func() {
+		close(s.stop)
+	})
+	done := make(chan struct{})
+	go func() {
+		s.loops.Wait()
+		s.runs.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		s.cancel()
+		return nil
+	case <-ctx.Done():
+		s.cancel()
+		<-done
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,232 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// every is a cron.Schedule firing at a sub-second interval, which robfig's "@every" rounds up
+type every time.Duration
+
+func (e every) Next(t time.Time) time.Time {
+	return t.Add(time.Duration(e))
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met in time")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestSchedule_InvalidSpec(t *testing.T) {
+	app := New()
+	if err := app.Schedule("every five minutes", func(context.Context) error { return nil }); err == nil {
+		t.Fatal("expected an error for an invalid spec")
+	}
+	if err := app.Schedule("*/5 * * * *", func(context.Context) error { return nil }, WithScheduleName("sync")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	stats := app.Schedules()
+	if len(stats) != 1 || stats[0].Name != "sync" || stats[0].Spec != "*/5 * * * *" {
+		t.Fatalf("unexpected schedules: %+v", stats)
+	}
+	if err := app.Shutdown(context.Background()); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+}
+
+func TestSchedule_RunsAndRecordsStats(t *testing.T) {
+	app := New()
+	var runs atomic.Int32
+	var observed atomic.Int32
+	app.addSchedule("fast", every(10*time.Millisecond), func(context.Context) error {
+		if runs.Add(1) == 2 {
+			return errors.New("boom")
+		}
+		return nil
+	}, WithScheduleObserver(func(name string, took time.Duration, err error) {
+		if name != "fast" {
+			t.Errorf("unexpected name %q", name)
+		}
+		observed.Add(1)
+	}))
+
+	waitFor(t, func() bool { return runs.Load() >= 3 })
+	if err := app.Shutdown(context.Background()); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+
+	stats := app.Schedules()[0]
+	if stats.Runs < 3 || stats.Failures != 1 {
+		t.Fatalf("expected at least 3 runs and 1 failure, got %+v", stats)
+	}
+	if stats.LastRun.IsZero() || stats.Running {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+	if int(observed.Load()) != int(stats.Runs) {
+		t.Fatalf("observer saw %d runs, stats %d", observed.Load(), stats.Runs)
+	}
+
+	// No runs after shutdown
+	after := runs.Load()
+	time.Sleep(30 * time.Millisecond)
+	if runs.Load() != after {
+		t.Fatal("task ran after shutdown")
+	}
+}
+
+func TestSchedule_SkipsOverlappingRuns(t *testing.T) {
+	app := New()
+	release := make(chan struct{})
+	var running, maxRunning atomic.Int32
+	app.addSchedule("slow", every(5*time.Millisecond), func(context.Context) error {
+		n := running.Add(1)
+		defer running.Add(-1)
+		if n > maxRunning.Load() {
+			maxRunning.Store(n)
+		}
+		<-release
+		return nil
+	})
+
+	waitFor(t, func() bool { return app.Schedules()[0].Skipped >= 2 })
+	if !app.Schedules()[0].Running {
+		t.Fatal("expected the task to be running")
+	}
+	close(release)
+	if err := app.Shutdown(context.Background()); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+	if maxRunning.Load() != 1 {
+		t.Fatalf("expected runs not to overlap, saw %d at once", maxRunning.Load())
+	}
+}
+
+func TestSchedule_WithOverlap(t *testing.T) {
+	app := New()
+	release := make(chan struct{})
+	var running atomic.Int32
+	app.addSchedule("slow", every(5*time.Millisecond), func(context.Context) error {
+		running.Add(1)
+		<-release
+		return nil
+	}, WithOverlap())
+
+	waitFor(t, func() bool { return running.Load() >= 2 })
+	close(release)
+	if err := app.Shutdown(context.Background()); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+	if app.Schedules()[0].Skipped != 0 {
+		t.Fatal("expected no skipped runs")
+	}
+}
+
+func TestSchedule_RecoversPanicsAndTimesOut(t *testing.T) {
+	app := New()
+	var mu sync.Mutex
+	var errs []error
+	app.addSchedule("panics", every(5*time.Millisecond), func(context.Context) error {
+		panic("oops")
+	}, WithScheduleObserver(func(_ string, _ time.Duration, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		errs = append(errs, err)
+	}))
+	app.addSchedule("timeout", every(5*time.Millisecond), func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}, WithScheduleTimeout(10*time.Millisecond))
+
+	waitFor(t, func() bool {
+		stats := app.Schedules()
+		return stats[0].Failures > 0 && stats[1].Failures > 0
+	})
+	if err := app.Shutdown(context.Background()); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+	stats := app.Schedules()
+	if stats[0].LastError != "scheduled task panicked: oops" {
+		t.Fatalf("unexpected panic error: %q", stats[0].LastError)
+	}
+	if stats[1].LastError != context.DeadlineExceeded.Error() {
+		t.Fatalf("unexpected timeout error: %q", stats[1].LastError)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(errs) == 0 || errs[0] == nil {
+		t.Fatal("expected the observer to see the panic")
+	}
+}
+
+func TestSchedule_ShutdownCancelsRunsAtDeadline(t *testing.T) {
+	app := New()
+	started := make(chan struct{}, 1)
+	var cancelled atomic.Bool
+	app.addSchedule("stuck", every(5*time.Millisecond), func(ctx context.Context) error {
+		select {
+		case started <- struct{}{}:
+		default:
+		}
+		<-ctx.Done()
+		cancelled.Store(true)
+		return ctx.Err()
+	})
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := app.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+	if !cancelled.Load() {
+		t.Fatal("expected the run to be cancelled")
+	}
+}
+
+func TestSchedule_ShutdownTwice(t *testing.T) {
+	app := New()
+	var runs atomic.Int32
+	app.addSchedule("fast", every(5*time.Millisecond), func(context.Context) error {
+		runs.Add(1)
+		return nil
+	})
+	waitFor(t, func() bool { return runs.Load() > 0 })
+
+	// e.g. a signal handler and a deferred call
+	for range 2 {
+		if err := app.Shutdown(context.Background()); err != nil {
+			t.Fatalf("shutdown: %v", err)
+		}
+	}
+	after := runs.Load()
+	time.Sleep(20 * time.Millisecond)
+	if runs.Load() != after {
+		t.Fatal("expected no runs after shutdown")
+	}
+}
+
+func TestSchedule_Jitter(t *testing.T) {
+	app := New()
+	before := time.Now()
+	app.addSchedule("jittered", every(time.Hour), func(context.Context) error { return nil }, WithJitter(time.Minute))
+	waitFor(t, func() bool { return !app.Schedules()[0].NextRun.IsZero() })
+	next := app.Schedules()[0].NextRun
+	if next.Before(before.Add(time.Hour)) || next.After(time.Now().Add(time.Hour+time.Minute)) {
+		t.Fatalf("next run %v outside the jitter window", next)
+	}
+	if err := app.Shutdown(context.Background()); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+}
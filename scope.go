@@ -0,0 +1,281 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ScopeResolver returns the scopes granted to a request, such as its user's roles
+type ScopeResolver func(ctx *gin.Context) []string
+
+const scopesKey = "fluxo.scopes"
+
+// ResolveScopes returns middleware making the typed handlers after it leave out the response
+// fields whose `scope` tag names none of the request's scopes:
+//
+//	type User struct {
+//		ID     int    `json:"id"`
+//		Email  string `json:"email" scope:"admin,support"`
+//		Salary int    `json:"salary" scope:"admin"`
+//	}
+//
+//	app.Use(fluxo.ResolveScopes(func(ctx *gin.Context) []string { return ctx.GetStringSlice("roles") }))
+//
+// The resolver runs when the response is written, so it sees what later middleware stored.
+// Without a resolver, scoped fields are always left out.
+func ResolveScopes(resolve ScopeResolver) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Set(scopesKey, resolve)
+	}
+}
+
+// WithScopes resolves the scopes of requests to routes registered afterwards with resolve, see ResolveScopes
+func (a *App) WithScopes(resolve ScopeResolver) *App {
+	a.router.Use(ResolveScopes(resolve))
+	return a
+}
+
+// WithScopes resolves the scopes of requests to the group's routes registered afterwards with resolve
+func (g *Group) WithScopes(resolve ScopeResolver) *Group {
+	g.RouterGroup.Use(ResolveScopes(resolve))
+	return g
+}
+
+// requestScopes returns the scopes the request's resolver grants
+func requestScopes(ctx *gin.Context) []string {
+	if v, ok := ctx.Get(scopesKey); ok {
+		if resolve, ok := v.(ScopeResolver); ok && resolve != nil {
+			return resolve(ctx)
+		}
+	}
+	return nil
+}
+
+// tagList returns the values of a comma separated tag, like `scope:"admin,support"`
+func tagList(field reflect.StructField, name string) []string {
+	var values []string
+	for _, v := range strings.Split(field.Tag.Get(name), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// taggedNode describes where the fields carrying a tag, like scope or view, are in the JSON form
+// of a type
+type taggedNode struct {
+	fields map[string]taggedField // of a struct, by JSON name
+	elem   *taggedNode            // of the elements of a slice or the values of a map
+}
+
+type taggedField struct {
+	values []string // empty when only fields inside the value are tagged
+	node   *taggedNode
+}
+
+type taggedKey struct {
+	t   reflect.Type
+	tag string
+}
+
+var taggedNodes sync.Map // taggedKey -> *taggedNode, nil for types without tagged fields
+
+// taggedNodeFor returns where the fields of t tagged with tag are, or nil when it has none
+func taggedNodeFor(t reflect.Type, tag string) *taggedNode {
+	if t == nil {
+		return nil
+	}
+	key := taggedKey{t, tag}
+	if n, ok := taggedNodes.Load(key); ok {
+		return n.(*taggedNode)
+	}
+	n := buildTaggedNode(t, tag, map[reflect.Type]*taggedNode{})
+	taggedNodes.Store(key, n)
+	return n
+}
+
+func buildTaggedNode(t reflect.Type, tag string, building map[reflect.Type]*taggedNode) *taggedNode {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		if n, ok := building[t]; ok {
+			// A recursive type: the node is completed by the outer call
+			return n
+		}
+		n := &taggedNode{fields: map[string]taggedField{}}
+		building[t] = n
+		addTaggedFields(n, t, tag, building)
+		if len(n.fields) == 0 {
+			return nil
+		}
+		return n
+	case reflect.Slice, reflect.Array, reflect.Map:
+		if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+			return nil
+		}
+		if elem := buildTaggedNode(t.Elem(), tag, building); elem != nil {
+			return &taggedNode{elem: elem}
+		}
+	}
+	return nil
+}
+
+// addTaggedFields adds the tagged fields of struct t to n, naming them like encoding/json
+func addTaggedFields(n *taggedNode, t reflect.Type, tag string, building map[reflect.Type]*taggedNode) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		jsonTag := field.Tag.Get("json")
+		if jsonTag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(jsonTag, ",")
+		if field.Anonymous && name == "" {
+			ft := field.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				addTaggedFields(n, ft, tag, building)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		values := tagList(field, tag)
+		child := buildTaggedNode(field.Type, tag, building)
+		if len(values) > 0 || child != nil {
+			n.fields[name] = taggedField{values: values, node: child}
+		}
+	}
+}
+
+// values returns the tag values used anywhere under n, sorted
+func (n *taggedNode) values() []string {
+	seen := map[*taggedNode]bool{}
+	var values []string
+	var walk func(n *taggedNode)
+	walk = func(n *taggedNode) {
+		if n == nil || seen[n] {
+			return
+		}
+		seen[n] = true
+		walk(n.elem)
+		for _, f := range n.fields {
+			values = append(values, f.values...)
+			walk(f.node)
+		}
+	}
+	walk(n)
+	slices.Sort(values)
+	return slices.Compact(values)
+}
+
+// filter removes the fields of v, a decoded JSON value, tagged with none of the granted values
+func (n *taggedNode) filter(v any, granted []string) {
+	switch val := v.(type) {
+	case map[string]any:
+		if n.elem != nil {
+			for _, item := range val {
+				n.elem.filter(item, granted)
+			}
+			return
+		}
+		for name, f := range n.fields {
+			item, ok := val[name]
+			if !ok {
+				continue
+			}
+			if len(f.values) > 0 && !slices.ContainsFunc(f.values, func(s string) bool { return slices.Contains(granted, s) }) {
+				delete(val, name)
+			} else if f.node != nil {
+				f.node.filter(item, granted)
+			}
+		}
+	case []any:
+		if n.elem != nil {
+			for _, item := range val {
+				n.elem.filter(item, granted)
+			}
+		}
+	}
+}
+
+// filterResponse returns res without the fields the request's scopes don't grant, nor those
+// outside its view: decoded JSON, or when typed a copy of res for formats encoding Go values.
+// res is returned as it is when its type has no scoped or view fields.
+func filterResponse(ctx *gin.Context, res any, typed bool) (any, error) {
+	t := reflect.TypeOf(res)
+	scopes := taggedNodeFor(t, "scope")
+	view, viewed := requestView(ctx)
+	views := taggedNodeFor(t, "view")
+	// Localized values change shape, so only JSON responses hold a single translation
+	localized := localizedNodeFor(t)
+	if typed {
+		localized = nil
+	}
+	if scopes == nil && (views == nil || !viewed) && localized == nil {
+		return res, nil
+	}
+	marshal := json.Marshal
+	if codec, ok := requestCodec(ctx); ok {
+		marshal = codec.Marshal
+	}
+	data, err := marshal(res)
+	if err != nil {
+		return nil, err
+	}
+	var generic any
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&generic); err != nil {
+		return nil, err
+	}
+	if scopes != nil {
+		scopes.filter(generic, requestScopes(ctx))
+	}
+	if views != nil && viewed {
+		views.filter(generic, []string{view})
+	}
+	if localized != nil {
+		generic = localize(ctx, localized, generic)
+	}
+	if !typed {
+		return generic, nil
+	}
+
+	if data, err = json.Marshal(generic); err != nil {
+		return nil, err
+	}
+	out := reflect.New(t)
+	if err := json.Unmarshal(data, out.Interface()); err != nil {
+		return nil, err
+	}
+	return out.Elem().Interface(), nil
+}
+
+// documentScopes marks a scoped property in the spec
+func documentScopes(schema Schema, scopes []string) Schema {
+	if schema.Ref != "" {
+		// Siblings of $ref are ignored, so the reference is wrapped
+		schema = Schema{AllOf: []Schema{schema}}
+	}
+	schema.Scopes = scopes
+	note := "Only returned to requests with the scope " + strings.Join(scopes, " or ") + "."
+	schema.Description = strings.TrimSpace(note + " " + schema.Description)
+	return schema
+}
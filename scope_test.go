@@ -0,0 +1,139 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type scopedAudit struct {
+	IP      string `json:"ip" scope:"admin"`
+	Created string `json:"created"`
+}
+
+type scopedUser struct {
+	ID     int          `json:"id"`
+	Name   string       `json:"name"`
+	Email  string       `json:"email" scope:"admin,support"`
+	Salary int          `json:"salary" scope:"admin"`
+	Audit  *scopedAudit `json:"audit,omitempty"`
+	Notes  []scopedNote `json:"notes"`
+}
+
+type scopedNote struct {
+	Text     string `json:"text"`
+	Internal bool   `json:"internal" scope:"admin"`
+}
+
+func scopedApp() *App {
+	gin.SetMode(gin.TestMode)
+	app := New().WithSwagger("Users", "1.0")
+	app.WithScopes(func(ctx *gin.Context) []string {
+		if role := ctx.GetHeader("X-Role"); role != "" {
+			return []string{role}
+		}
+		return nil
+	})
+	app.GET("/users/:id", Produces(CSVFormat), Handle(func(ctx *Context, _ struct {
+		ID int `uri:"id"`
+	}) (scopedUser, error) {
+		return scopedUser{
+			ID: 1, Name: "Ann", Email: "ann@example.com", Salary: 5000,
+			Audit: &scopedAudit{IP: "10.0.0.1", Created: "2025-01-01"},
+			Notes: []scopedNote{{Text: "vip", Internal: true}},
+		}, nil
+	}))
+	app.GET("/users", Handle(func(ctx *Context, _ struct{}) ([]scopedUser, error) {
+		return []scopedUser{{ID: 1, Email: "ann@example.com"}, {ID: 2, Email: "bob@example.com"}}, nil
+	}))
+	return app
+}
+
+func getAs(app *App, path, role string) map[string]any {
+	req := httptest.NewRequest("GET", path, nil)
+	if role != "" {
+		req.Header.Set("X-Role", role)
+	}
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, req)
+	var body map[string]any
+	_ = json.Unmarshal(w.Body.Bytes(), &body)
+	return body
+}
+
+func TestResolveScopes(t *testing.T) {
+	app := scopedApp()
+
+	user := getAs(app, "/users/1", "")
+	for _, hidden := range []string{"email", "salary"} {
+		if _, ok := user[hidden]; ok {
+			t.Errorf("end user sees %s: %v", hidden, user)
+		}
+	}
+	if _, ok := user["audit"].(map[string]any)["ip"]; ok {
+		t.Errorf("end user sees audit.ip: %v", user)
+	}
+	if _, ok := user["notes"].([]any)[0].(map[string]any)["internal"]; ok {
+		t.Errorf("end user sees notes.internal: %v", user)
+	}
+	if user["name"] != "Ann" || user["audit"].(map[string]any)["created"] != "2025-01-01" {
+		t.Errorf("end user misses public fields: %v", user)
+	}
+
+	support := getAs(app, "/users/1", "support")
+	if support["email"] != "ann@example.com" || support["salary"] != nil {
+		t.Errorf("support view = %v", support)
+	}
+
+	admin := getAs(app, "/users/1", "admin")
+	if admin["salary"] != float64(5000) || admin["audit"].(map[string]any)["ip"] != "10.0.0.1" {
+		t.Errorf("admin view = %v", admin)
+	}
+}
+
+func TestResolveScopes_Slices(t *testing.T) {
+	app := scopedApp()
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest("GET", "/users", nil))
+	if strings.Contains(w.Body.String(), "example.com") {
+		t.Errorf("list leaks emails: %s", w.Body)
+	}
+}
+
+func TestResolveScopes_Formats(t *testing.T) {
+	app := scopedApp()
+	req := httptest.NewRequest("GET", "/users/1", nil)
+	req.Header.Set("Accept", "text/csv")
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, req)
+	if w.Code != http.StatusOK || strings.Contains(w.Body.String(), "ann@example.com") || strings.Contains(w.Body.String(), "5000") {
+		t.Errorf("CSV leaks scoped fields: %d %s", w.Code, w.Body)
+	}
+}
+
+func TestResolveScopes_Swagger(t *testing.T) {
+	app := scopedApp()
+	spec, err := app.swagger.parsedSpec(app.specHandlers())
+	if err != nil {
+		t.Fatal(err)
+	}
+	props := spec.Components.Schemas["scopedUser"].Properties
+	if got := props["email"].Scopes; len(got) != 2 || got[0] != "admin" {
+		t.Errorf("email scopes = %v", got)
+	}
+	if !strings.Contains(props["salary"].Description, "admin") {
+		t.Errorf("salary description = %q", props["salary"].Description)
+	}
+	if len(props["name"].Scopes) != 0 {
+		t.Errorf("name scopes = %v", props["name"].Scopes)
+	}
+	if err := app.Validate(); err != nil {
+		t.Errorf("Validate: %v", err)
+	}
+}
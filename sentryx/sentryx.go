@@ -0,0 +1,279 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+
+// Package sentryx reports fluxo errors to Sentry, or any service taking Sentry envelopes
+// (GlitchTip, self-hosted Sentry, ...), using only the standard library.
+package sentryx
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/leviantech/fluxo"
+)
+
+// ErrDropped is passed to the failure handler for reports dropped because too many were in flight
+var ErrDropped = errors.New("sentryx: too many reports in flight, report dropped")
+
+// Reporter is a fluxo.ErrorReporter sending each report to Sentry as an event, in the background
+type Reporter struct {
+	dsn         string
+	endpoint    string
+	auth        string
+	client      *http.Client
+	environment string
+	release     string
+	serverName  string
+	onFailure   func(err error)
+
+	inFlight chan struct{}
+	wg       sync.WaitGroup
+}
+
+var _ fluxo.ErrorReporter = (*Reporter)(nil)
+
+// Option configures a Reporter
+type Option func(*Reporter)
+
+// WithClient sets the HTTP client events are sent with (default: 10s timeout)
+func WithClient(client *http.Client) Option {
+	return func(r *Reporter) { r.client = client }
+}
+
+// WithEnvironment tags events with environment, e.g. "production"
+func WithEnvironment(environment string) Option {
+	return func(r *Reporter) { r.environment = environment }
+}
+
+// WithRelease tags events with the release of the app, e.g. a version or commit
+func WithRelease(release string) Option {
+	return func(r *Reporter) { r.release = release }
+}
+
+// WithMaxInFlight sets how many reports may be sent at once; more are dropped (default 32)
+func WithMaxInFlight(n int) Option {
+	return func(r *Reporter) { r.inFlight = make(chan struct{}, max(n, 1)) }
+}
+
+// WithFailureHandler is called when a report can't be sent
+func WithFailureHandler(fn func(err error)) Option {
+	return func(r *Reporter) { r.onFailure = fn }
+}
+
+// New returns a reporter for the project of dsn, e.g. https://key@o1.ingest.sentry.io/42
+//
+//	reporter, err := sentryx.New(os.Getenv("SENTRY_DSN"), sentryx.WithEnvironment("production"))
+//	app.WithRecovery().WithErrorReporter(reporter)
+//	app.OnShutdown(func(ctx context.Context) error { reporter.Wait(); return nil })
+func New(dsn string, opts ...Option) (*Reporter, error) {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("sentryx: invalid DSN: %w", err)
+	}
+	key := u.User.Username()
+	dir, project := "", strings.TrimPrefix(u.Path, "/")
+	if i := strings.LastIndex(project, "/"); i >= 0 {
+		dir, project = "/"+project[:i], project[i+1:]
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || key == "" || project == "" {
+		return nil, fmt.Errorf("sentryx: invalid DSN %q, want scheme://key@host/project", dsn)
+	}
+
+	hostname, _ := os.Hostname()
+	r := &Reporter{
+		dsn:        dsn,
+		endpoint:   fmt.Sprintf("%s://%s%s/api/%s/envelope/", u.Scheme, u.Host, dir, project),
+		auth:       "Sentry sentry_version=7, sentry_client=fluxo-sentryx/1.0, sentry_key=" + key,
+		client:     &http.Client{Timeout: 10 * time.Second},
+		serverName: hostname,
+		inFlight:   make(chan struct{}, 32),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r, nil
+}
+
+// Report sends report to Sentry in the background
+func (r *Reporter) Report(ctx context.Context, report fluxo.ErrorReport) {
+	select {
+	case r.inFlight <- struct{}{}:
+	default:
+		r.fail(ErrDropped)
+		return
+	}
+	ev := r.event(report)
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		defer func() { <-r.inFlight }()
+		if err := r.send(context.WithoutCancel(ctx), ev); err != nil {
+			r.fail(err)
+		}
+	}()
+}
+
+// Wait blocks until the reports in flight have been sent
+func (r *Reporter) Wait() {
+	r.wg.Wait()
+}
+
+func (r *Reporter) fail(err error) {
+	if r.onFailure != nil {
+		r.onFailure(err)
+	}
+}
+
+// send posts ev to the envelope endpoint
+func (r *Reporter) send(ctx context.Context, ev *event) error {
+	payload, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	header, _ := json.Marshal(map[string]string{"event_id": ev.EventID, "dsn": r.dsn, "sent_at": time.Now().UTC().Format(time.RFC3339)})
+	item, _ := json.Marshal(map[string]any{"type": "event", "length": len(payload), "content_type": "application/json"})
+
+	var body bytes.Buffer
+	for _, line := range [][]byte{header, item, payload} {
+		body.Write(line)
+		body.WriteByte('\n')
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.endpoint, &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-sentry-envelope")
+	req.Header.Set("X-Sentry-Auth", r.auth)
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("sentryx: send event: %w", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("sentryx: event %s rejected with status %d", ev.EventID, resp.StatusCode)
+	}
+	return nil
+}
+
+// event is the part of Sentry's event payload the reports fill
+type event struct {
+	EventID     string            `json:"event_id"`
+	Timestamp   string            `json:"timestamp"`
+	Platform    string            `json:"platform"`
+	Level       string            `json:"level"`
+	ServerName  string            `json:"server_name,omitempty"`
+	Environment string            `json:"environment,omitempty"`
+	Release     string            `json:"release,omitempty"`
+	Transaction string            `json:"transaction,omitempty"`
+	Exception   exceptions        `json:"exception"`
+	Request     *request          `json:"request,omitempty"`
+	User        *user             `json:"user,omitempty"`
+	Tags        map[string]string `json:"tags,omitempty"`
+	Contexts    map[string]any    `json:"contexts,omitempty"`
+}
+
+type exceptions struct {
+	Values []exception `json:"values"`
+}
+
+type exception struct {
+	Type       string      `json:"type"`
+	Value      string      `json:"value"`
+	Mechanism  mechanism   `json:"mechanism"`
+	Stacktrace *stacktrace `json:"stacktrace,omitempty"`
+}
+
+type mechanism struct {
+	Type    string `json:"type"`
+	Handled bool   `json:"handled"`
+}
+
+type request struct {
+	URL         string            `json:"url"`
+	Method      string            `json:"method"`
+	QueryString string            `json:"query_string,omitempty"`
+	Headers     map[string]string `json:"headers,omitempty"`
+}
+
+type user struct {
+	ID        string `json:"id,omitempty"`
+	IPAddress string `json:"ip_address,omitempty"`
+}
+
+// sensitiveHeaders are left out of events
+var sensitiveHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+	"X-Api-Key":           true,
+}
+
+// event converts report into a Sentry event
+func (r *Reporter) event(report fluxo.ErrorReport) *event {
+	id := make([]byte, 16)
+	_, _ = rand.Read(id)
+	ev := &event{
+		EventID:     hex.EncodeToString(id),
+		Timestamp:   time.Now().UTC().Format(time.RFC3339Nano),
+		Platform:    "go",
+		Level:       "error",
+		ServerName:  r.serverName,
+		Environment: r.environment,
+		Release:     r.release,
+		Tags:        map[string]string{"status": fmt.Sprint(report.Status)},
+	}
+
+	exc := exception{Type: fmt.Sprintf("%T", report.Err), Value: report.Err.Error(), Mechanism: mechanism{Type: "fluxo", Handled: true}}
+	if report.Panicked() {
+		ev.Level = "fatal"
+		exc.Type, exc.Mechanism.Handled = "panic", false
+	}
+	if frames := parseStack(report.Stack); len(frames) > 0 {
+		exc.Stacktrace = &stacktrace{Frames: frames}
+	}
+	ev.Exception.Values = []exception{exc}
+
+	if req := report.Request; req != nil {
+		scheme := "http"
+		if req.TLS != nil {
+			scheme = "https"
+		}
+		ev.Request = &request{
+			URL:         scheme + "://" + req.Host + req.URL.Path,
+			Method:      req.Method,
+			QueryString: req.URL.RawQuery,
+			Headers:     map[string]string{},
+		}
+		for name, values := range req.Header {
+			if !sensitiveHeaders[name] {
+				ev.Request.Headers[name] = strings.Join(values, ", ")
+			}
+		}
+		if report.Route != "" {
+			ev.Transaction = req.Method + " " + report.Route
+			ev.Tags["route"] = report.Route
+		}
+	}
+	if report.User != "" || report.ClientIP != "" {
+		ev.User = &user{ID: report.User, IPAddress: report.ClientIP}
+	}
+	if report.RequestID != "" {
+		ev.Tags["request_id"] = report.RequestID
+	}
+	if report.TraceID != "" {
+		ev.Contexts = map[string]any{"trace": map[string]string{"trace_id": report.TraceID}}
+	}
+	return ev
+}
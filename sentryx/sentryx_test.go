@@ -0,0 +1,131 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package sentryx
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/leviantech/fluxo"
+)
+
+func TestNew_DSN(t *testing.T) {
+	r, err := New("https://abc@o1.ingest.sentry.io/sub/42")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.endpoint != "https://o1.ingest.sentry.io/sub/api/42/envelope/" || !strings.Contains(r.auth, "sentry_key=abc") {
+		t.Errorf("endpoint %s, auth %s", r.endpoint, r.auth)
+	}
+	for _, dsn := range []string{"", "https://o1.ingest.sentry.io/42", "https://abc@o1.ingest.sentry.io/", "ftp://abc@host/1"} {
+		if _, err := New(dsn); err == nil {
+			t.Errorf("%q accepted", dsn)
+		}
+	}
+}
+
+func TestReporter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var (
+		mu     sync.Mutex
+		auth   string
+		events []map[string]any
+	)
+	sentry := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lines := bufio.NewScanner(r.Body)
+		var n int
+		for lines.Scan() {
+			if n++; n == 3 {
+				var ev map[string]any
+				if err := json.Unmarshal(lines.Bytes(), &ev); err != nil {
+					t.Error(err)
+				}
+				mu.Lock()
+				auth, events = r.Header.Get("X-Sentry-Auth"), append(events, ev)
+				mu.Unlock()
+			}
+		}
+	}))
+	defer sentry.Close()
+
+	reporter, err := New(strings.Replace(sentry.URL, "://", "://key@", 1)+"/7", WithEnvironment("test"), WithRelease("v1.2.0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	app := fluxo.New().WithRecovery().WithErrorReporter(reporter)
+	app.POST("/todos/:id", fluxo.Handle(func(ctx *fluxo.Context, _ struct{}) (string, error) {
+		panic(errors.New("boom"))
+	}))
+
+	req := httptest.NewRequest("POST", "/todos/7?debug=1", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	app.ServeHTTP(httptest.NewRecorder(), req)
+	reporter.Wait()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(events) != 1 || !strings.Contains(auth, "sentry_key=key") {
+		t.Fatalf("events = %v, auth %q", events, auth)
+	}
+	raw, _ := json.Marshal(events[0])
+	var ev event
+	if err := json.Unmarshal(raw, &ev); err != nil {
+		t.Fatal(err)
+	}
+	exc := ev.Exception.Values[0]
+	if ev.Level != "fatal" || exc.Type != "panic" || exc.Value != "panic: boom" || exc.Mechanism.Handled || exc.Stacktrace == nil {
+		t.Errorf("exception = %+v", exc)
+	}
+	if ev.Transaction != "POST /todos/:id" || ev.Environment != "test" || ev.Release != "v1.2.0" || ev.Tags["status"] != "500" {
+		t.Errorf("event = %+v", ev)
+	}
+	if ev.Request == nil || ev.Request.QueryString != "debug=1" || ev.Request.Headers["Authorization"] != "" {
+		t.Errorf("request = %+v", ev.Request)
+	}
+	if trace, _ := ev.Contexts["trace"].(map[string]any); trace["trace_id"] != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("contexts = %v", ev.Contexts)
+	}
+}
+
+func TestReporter_Failure(t *testing.T) {
+	sentry := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer sentry.Close()
+
+	var failures []error
+	reporter, _ := New(strings.Replace(sentry.URL, "://", "://key@", 1)+"/7", WithFailureHandler(func(err error) {
+		failures = append(failures, err)
+	}))
+	reporter.Report(t.Context(), fluxo.ErrorReport{Err: errors.New("db down"), Status: 500})
+	reporter.Wait()
+	if len(failures) != 1 || !strings.Contains(failures[0].Error(), "429") {
+		t.Errorf("failures = %v", failures)
+	}
+}
+
+func TestParseStack(t *testing.T) {
+	frames := parseStack(debug.Stack())
+	if len(frames) < 2 {
+		t.Fatalf("frames = %+v", frames)
+	}
+	last := frames[len(frames)-1]
+	if last.Module != "runtime/debug" || last.Function != "Stack" || last.InApp {
+		t.Errorf("innermost frame = %+v", last)
+	}
+	caller := frames[len(frames)-2]
+	if caller.Module != "github.com/leviantech/fluxo/sentryx" || caller.Function != "TestParseStack" || caller.Filename != "sentryx_test.go" || caller.Lineno == 0 {
+		t.Errorf("caller frame = %+v", caller)
+	}
+}
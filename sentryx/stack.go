@@ -0,0 +1,86 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package sentryx
+
+import (
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type stacktrace struct {
+	Frames []frame `json:"frames"`
+}
+
+type frame struct {
+	Function string `json:"function"`
+	Module   string `json:"module,omitempty"`
+	Filename string `json:"filename"`
+	AbsPath  string `json:"abs_path"`
+	Lineno   int    `json:"lineno"`
+	InApp    bool   `json:"in_app"`
+}
+
+// parseStack converts a stack formatted by runtime/debug.Stack into frames, outermost call first
+// as Sentry wants them. Frames of the runtime, the standard library, gin and fluxo aren't in app.
+func parseStack(stack []byte) []frame {
+	lines := strings.Split(strings.TrimSpace(string(stack)), "\n")
+	var frames []frame
+	for i := 1; i < len(lines); i++ {
+		// A call is followed by its tab-indented location; anything else is a note
+		if i+1 >= len(lines) || strings.HasPrefix(lines[i], "\t") || !strings.HasPrefix(lines[i+1], "\t") {
+			continue
+		}
+		call, loc := lines[i], strings.TrimSpace(lines[i+1])
+		i++
+		call = strings.TrimPrefix(call, "created by ")
+		if j := strings.LastIndex(call, "("); j > 0 && strings.HasSuffix(call, ")") {
+			call = call[:j]
+		}
+		call, _, _ = strings.Cut(call, " in goroutine ")
+		loc, _, _ = strings.Cut(loc, " +")
+		file, lineno := loc, 0
+		if j := strings.LastIndex(loc, ":"); j > 0 {
+			file = loc[:j]
+			lineno, _ = strconv.Atoi(loc[j+1:])
+		}
+
+		module, function := splitFunction(call)
+		frames = append(frames, frame{
+			Function: function,
+			Module:   module,
+			Filename: path.Base(file),
+			AbsPath:  file,
+			Lineno:   lineno,
+			InApp:    inApp(module),
+		})
+	}
+	slices.Reverse(frames)
+	return frames
+}
+
+// splitFunction splits github.com/a/b.(*T).M into the package github.com/a/b and (*T).M
+func splitFunction(name string) (module, function string) {
+	slash := strings.LastIndex(name, "/")
+	dot := strings.Index(name[slash+1:], ".")
+	if dot < 0 {
+		return "", name
+	}
+	dot += slash + 1
+	return name[:dot], name[dot+1:]
+}
+
+// inApp reports whether frames of module are the app's own code
+func inApp(module string) bool {
+	first, _, _ := strings.Cut(module, "/")
+	if !strings.Contains(first, ".") {
+		return module == "main" // the standard library and the runtime
+	}
+	for _, lib := range []string{"github.com/gin-gonic/", "github.com/leviantech/fluxo"} {
+		if strings.HasPrefix(module, lib) {
+			return false
+		}
+	}
+	return true
+}
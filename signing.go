@@ -0,0 +1,221 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Headers of signed requests
+const (
+	SignatureKeyIDHeader = "X-Signature-Key-Id"
+	SignatureDateHeader  = "X-Signature-Date" // http.TimeFormat, like the Date header
+	SignatureHeader      = "X-Signature"      // sha256=hex(HMAC(secret, canonical request))
+)
+
+// SecretLookup returns the shared secret of a key ID; a nil secret means the key is unknown
+type SecretLookup func(ctx context.Context, keyID string) ([]byte, error)
+
+// StaticSecrets looks secrets up in a map of key IDs to secrets
+func StaticSecrets(secrets map[string]string) SecretLookup {
+	return func(_ context.Context, keyID string) ([]byte, error) {
+		if secret, ok := secrets[keyID]; ok {
+			return []byte(secret), nil
+		}
+		return nil, nil
+	}
+}
+
+// SignatureKeyID holds the key ID a request was signed with, once RequireSignature verified it
+var SignatureKeyID = NewKey[string]("fluxo.signature_key_id")
+
+// SignatureOption configures RequireSignature
+type SignatureOption func(*signatureVerifier)
+
+// WithSignatureSkew sets how far the signature date may be from the server's clock, 5 minutes by default
+func WithSignatureSkew(skew time.Duration) SignatureOption {
+	return func(v *signatureVerifier) {
+		v.skew = skew
+	}
+}
+
+// WithSignatureMaxBody caps the size of signed request bodies, 10 MiB by default; larger ones get
+// 413. The body is read before the handler runs to check its hash, so keep it within what a
+// partner may send.
+func WithSignatureMaxBody(n int64) SignatureOption {
+	return func(v *signatureVerifier) {
+		v.maxBody = n
+	}
+}
+
+type signatureVerifier struct {
+	lookup  SecretLookup
+	skew    time.Duration
+	maxBody int64
+}
+
+// computeSignature returns the signature header value of a request: the HMAC of its method, path
+// with query, signature date and hex SHA-256 of the body, one per line
+func computeSignature(secret []byte, method, uri, date string, bodyHash []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(strings.Join([]string{strings.ToUpper(method), uri, date, hex.EncodeToString(bodyHash)}, "\n")))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// RequireSignature returns middleware accepting only requests signed with a shared secret, for
+// partners who can't use OAuth. Clients sign with SignRequest or SigningTransport:
+//
+//	partners := app.TypedGroup("/partners", fluxo.RequireSignature(fluxo.StaticSecrets(map[string]string{
+//		"acme": os.Getenv("ACME_SECRET"),
+//	})))
+//
+// Missing, stale or wrong signatures get 401; the key ID is then available from SignatureKeyID.
+func RequireSignature(lookup SecretLookup, opts ...SignatureOption) gin.HandlerFunc {
+	v := &signatureVerifier{lookup: lookup, skew: 5 * time.Minute, maxBody: 10 << 20}
+	for _, opt := range opts {
+		opt(v)
+	}
+	handler := v.verify
+	registerOperationDoc(handler, documentSignature)
+	return handler
+}
+
+func (v *signatureVerifier) verify(ctx *gin.Context) {
+	reject := func(msg string) {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": msg})
+	}
+	keyID, date, signature := ctx.GetHeader(SignatureKeyIDHeader), ctx.GetHeader(SignatureDateHeader), ctx.GetHeader(SignatureHeader)
+	if keyID == "" || date == "" || signature == "" {
+		reject(fmt.Sprintf("The request must be signed with %s, %s and %s headers", SignatureKeyIDHeader, SignatureDateHeader, SignatureHeader))
+		return
+	}
+	signed, err := http.ParseTime(date)
+	if err != nil {
+		reject(SignatureDateHeader + " is not a valid HTTP date")
+		return
+	}
+	if skew := time.Since(signed); skew > v.skew || skew < -v.skew {
+		reject("The request signature has expired")
+		return
+	}
+	secret, err := v.lookup(ctx.Request.Context(), keyID)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Looking up the signing key failed"})
+		return
+	}
+
+	if secret == nil {
+		// Unknown keys are rejected before the body is read, after an HMAC like that of wrong
+		// signatures
+		_ = hmac.Equal([]byte(computeSignature(nil, ctx.Request.Method, ctx.Request.URL.RequestURI(), date, nil)), []byte(signature))
+		reject("The request signature is invalid")
+		return
+	}
+
+	// The body is spooled so that the handler can still bind it
+	hash := sha256.New()
+	if ctx.Request.Body != nil {
+		tooLarge := func() {
+			ctx.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("A signed body holds at most %d bytes", v.maxBody)})
+		}
+		if ctx.Request.ContentLength > v.maxBody {
+			tooLarge()
+			return
+		}
+		body, err := spoolBody(requestTempDir(ctx), http.MaxBytesReader(ctx.Writer, ctx.Request.Body, v.maxBody), hash)
+		if err != nil {
+			var maxErr *http.MaxBytesError
+			if errors.As(err, &maxErr) {
+				tooLarge()
+				return
+			}
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Reading body failed: %v", err)})
+			return
+		}
+		defer body.Close()
+		ctx.Request.Body = body
+	}
+
+	want := computeSignature(secret, ctx.Request.Method, ctx.Request.URL.RequestURI(), date, hash.Sum(nil))
+	if !hmac.Equal([]byte(want), []byte(signature)) {
+		reject("The request signature is invalid")
+		return
+	}
+	SignatureKeyID.Set(ctx, keyID)
+	ctx.Next()
+}
+
+func documentSignature(sg *SwaggerGenerator, op *Operation) {
+	op.Parameters = append(op.Parameters,
+		Parameter{Name: SignatureKeyIDHeader, In: "header", Required: true, Description: "ID of the key the request is signed with", Schema: Schema{Type: "string"}},
+		Parameter{Name: SignatureDateHeader, In: "header", Required: true, Description: "Date of the signature, e.g. Mon, 02 Jan 2006 15:04:05 GMT", Schema: Schema{Type: "string"}},
+		Parameter{Name: SignatureHeader, In: "header", Required: true, Description: "sha256=<hex HMAC-SHA256 of the method, path and query, date and hex SHA-256 of the body, joined by newlines>", Schema: Schema{Type: "string"}},
+	)
+	if op.Responses == nil {
+		op.Responses = map[string]Response{}
+	}
+	op.Responses["401"] = Response{Description: "Missing, expired or invalid request signature"}
+	op.Responses["413"] = Response{Description: "Request body too large to verify"}
+}
+
+// SignRequest signs req with the secret of keyID, setting the signature headers. The body is
+// read and replaced, so that it can still be sent.
+func SignRequest(req *http.Request, keyID string, secret []byte) error {
+	if req.URL == nil {
+		return errors.New("fluxo: signing a request without a URL")
+	}
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return fmt.Errorf("fluxo: reading the body to sign: %w", err)
+		}
+		_ = req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		req.GetBody = func() (io.ReadCloser, error) { return io.NopCloser(bytes.NewReader(body)), nil }
+	}
+	date := time.Now().UTC().Format(http.TimeFormat)
+	sum := sha256.Sum256(body)
+	req.Header.Set(SignatureKeyIDHeader, keyID)
+	req.Header.Set(SignatureDateHeader, date)
+	req.Header.Set(SignatureHeader, computeSignature(secret, req.Method, req.URL.RequestURI(), date, sum[:]))
+	return nil
+}
+
+// SigningTransport returns a RoundTripper signing every request with the secret of keyID before
+// sending it with base (http.DefaultTransport when nil):
+//
+//	client := &http.Client{Transport: fluxo.SigningTransport(nil, "acme", secret)}
+func SigningTransport(base http.RoundTripper, keyID string, secret []byte) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return signingTransport{base: base, keyID: keyID, secret: secret}
+}
+
+type signingTransport struct {
+	base   http.RoundTripper
+	keyID  string
+	secret []byte
+}
+
+func (t signingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// A RoundTripper must not modify the request it is given
+	req = req.Clone(req.Context())
+	if err := SignRequest(req, t.keyID, t.secret); err != nil {
+		return nil, err
+	}
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,206 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type signedOrder struct {
+	Item string `json:"item" binding:"required"`
+}
+
+func newSignedApp(opts ...SignatureOption) *App {
+	gin.SetMode(gin.TestMode)
+	app := New()
+	app.POST("/partners/orders", RequireSignature(StaticSecrets(map[string]string{"acme": "s3cret"}), opts...),
+		Handle(func(ctx *Context, req signedOrder) (gin.H, error) {
+			keyID, _ := SignatureKeyID.Get(ctx)
+			return gin.H{"partner": keyID, "item": req.Item}, nil
+		}))
+	return app
+}
+
+func signedRequest(t *testing.T, keyID, secret, body string) *http.Request {
+	t.Helper()
+	req := httptest.NewRequest("POST", "/partners/orders?source=api", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if err := SignRequest(req, keyID, []byte(secret)); err != nil {
+		t.Fatal(err)
+	}
+	return req
+}
+
+func TestRequireSignature(t *testing.T) {
+	app := newSignedApp()
+
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, signedRequest(t, "acme", "s3cret", `{"item":"book"}`))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body)
+	}
+	if !strings.Contains(w.Body.String(), `"partner":"acme"`) || !strings.Contains(w.Body.String(), `"item":"book"`) {
+		t.Errorf("body = %s", w.Body)
+	}
+
+	tests := []struct {
+		name string
+		req  func() *http.Request
+	}{
+		{"unsigned", func() *http.Request {
+			return httptest.NewRequest("POST", "/partners/orders", strings.NewReader(`{"item":"book"}`))
+		}},
+		{"wrong secret", func() *http.Request { return signedRequest(t, "acme", "guess", `{"item":"book"}`) }},
+		{"unknown key", func() *http.Request { return signedRequest(t, "evil", "s3cret", `{"item":"book"}`) }},
+		{"tampered body", func() *http.Request {
+			req := signedRequest(t, "acme", "s3cret", `{"item":"book"}`)
+			req.Body = io.NopCloser(strings.NewReader(`{"item":"car"}`))
+			return req
+		}},
+		{"tampered query", func() *http.Request {
+			req := signedRequest(t, "acme", "s3cret", `{"item":"book"}`)
+			req.URL.RawQuery = "source=web"
+			return req
+		}},
+		{"stale", func() *http.Request {
+			req := signedRequest(t, "acme", "s3cret", `{"item":"book"}`)
+			req.Header.Set(SignatureDateHeader, time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat))
+			return req
+		}},
+		{"bad date", func() *http.Request {
+			req := signedRequest(t, "acme", "s3cret", `{"item":"book"}`)
+			req.Header.Set(SignatureDateHeader, "yesterday")
+			return req
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			app.ServeHTTP(w, tt.req())
+			if w.Code != http.StatusUnauthorized {
+				t.Errorf("status = %d, want 401: %s", w.Code, w.Body)
+			}
+		})
+	}
+}
+
+func TestRequireSignature_Skew(t *testing.T) {
+	app := newSignedApp(WithSignatureSkew(2 * time.Hour))
+	req := signedRequest(t, "acme", "s3cret", `{"item":"book"}`)
+	// Sign again with a date an hour ago, since the signature covers the date
+	sum := sha256.Sum256([]byte(`{"item":"book"}`))
+	date := time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat)
+	req.Header.Set(SignatureDateHeader, date)
+	req.Header.Set(SignatureHeader, computeSignature([]byte("s3cret"), "POST", "/partners/orders?source=api", date, sum[:]))
+	req.Body = io.NopCloser(strings.NewReader(`{"item":"book"}`))
+
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Errorf("status = %d, want 200 within the skew: %s", w.Code, w.Body)
+	}
+}
+
+// unreadBody fails the test when read
+type unreadBody struct{ t *testing.T }
+
+func (b unreadBody) Read([]byte) (int, error) {
+	b.t.Error("the body of a request with an unknown key was read")
+	return 0, io.EOF
+}
+
+func TestRequireSignature_UnknownKeyBodyUnread(t *testing.T) {
+	app := newSignedApp()
+	req := signedRequest(t, "evil", "s3cret", `{"item":"book"}`)
+	req.Body = io.NopCloser(unreadBody{t})
+
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, req)
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want 401", w.Code)
+	}
+}
+
+func TestRequireSignature_MaxBody(t *testing.T) {
+	app := newSignedApp(WithSignatureMaxBody(16))
+	body := `{"item":"a long book title"}`
+
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, signedRequest(t, "acme", "s3cret", body))
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("status = %d, want 413: %s", w.Code, w.Body)
+	}
+
+	// Without a Content-Length, the limit applies while reading
+	req := signedRequest(t, "acme", "s3cret", body)
+	req.ContentLength = -1
+	req.Body = io.NopCloser(strings.NewReader(body))
+	w = httptest.NewRecorder()
+	app.ServeHTTP(w, req)
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("status = %d, want 413 for a chunked body: %s", w.Code, w.Body)
+	}
+
+	w = httptest.NewRecorder()
+	app.ServeHTTP(w, signedRequest(t, "acme", "s3cret", `{"item":"pen"}`))
+	if w.Code != http.StatusOK {
+		t.Errorf("status = %d, want 200 within the limit: %s", w.Code, w.Body)
+	}
+}
+
+func TestRequireSignature_LookupError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := New()
+	app.POST("/partners/orders", RequireSignature(func(context.Context, string) ([]byte, error) {
+		return nil, errors.New("db down")
+	}), func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, signedRequest(t, "acme", "s3cret", `{}`))
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want 500", w.Code)
+	}
+}
+
+func TestSigningTransport(t *testing.T) {
+	server := httptest.NewServer(newSignedApp())
+	defer server.Close()
+
+	client := &http.Client{Transport: SigningTransport(nil, "acme", []byte("s3cret"))}
+	res, err := client.Post(server.URL+"/partners/orders?source=api", "application/json", strings.NewReader(`{"item":"pen"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	body, _ := io.ReadAll(res.Body)
+	if res.StatusCode != http.StatusOK || !strings.Contains(string(body), `"item":"pen"`) {
+		t.Errorf("status = %d, body = %s", res.StatusCode, body)
+	}
+}
+
+func TestRequireSignature_Swagger(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := New().WithSwagger("Partners", "1.0")
+	app.POST("/orders", RequireSignature(StaticSecrets(nil)), Handle(func(ctx *Context, req signedOrder) (gin.H, error) {
+		return nil, nil
+	}))
+	doc, err := app.swagger.SpecJSON(app.specHandlers())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{SignatureKeyIDHeader, SignatureDateHeader, `"401"`} {
+		if !strings.Contains(string(doc), want) {
+			t.Errorf("spec lacks %s", want)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"slices"
+	"sync"
+	"unsafe"
+
+	"github.com/gin-gonic/gin"
+)
+
+const skipKey = "fluxo.skip"
+
+// Unless runs mw except for requests matching skip:
+//
+//	app.Use(fluxo.Unless(rateLimit, fluxo.PathIs("/healthz", "/metrics")))
+func Unless(mw gin.HandlerFunc, skip func(ctx *gin.Context) bool) gin.HandlerFunc {
+	return recordWrapper(func(ctx *gin.Context) {
+		if skip(ctx) {
+			ctx.Next()
+			return
+		}
+		mw(ctx)
+	}, &wrapperInfo{inner: mw})
+}
+
+// PathIs matches requests to any of paths, given as registered (/users/:id) or as requested
+func PathIs(paths ...string) func(ctx *gin.Context) bool {
+	return func(ctx *gin.Context) bool {
+		return slices.Contains(paths, ctx.FullPath()) || slices.Contains(paths, ctx.Request.URL.Path)
+	}
+}
+
+// Skippable names mw so routes can opt out of it with Skip, even when it is installed on the
+// app or a group
+func Skippable(name string, mw gin.HandlerFunc) gin.HandlerFunc {
+	return recordWrapper(func(ctx *gin.Context) {
+		if skipped(ctx, name) {
+			ctx.Next()
+			return
+		}
+		mw(ctx)
+	}, &wrapperInfo{name: name, inner: mw})
+}
+
+// Skip is a route option exempting the route from the Skippable middleware with the given names.
+// Skipped typed middleware is left out of the route's documentation too:
+//
+//	app.Use(fluxo.Skippable("auth", fluxo.Middleware(requireAPIKey)))
+//	app.GET("/healthz", fluxo.Skip("auth"), fluxo.Handle(health))
+func Skip(names ...string) gin.HandlerFunc {
+	info := &wrapperInfo{skips: names}
+	return recordWrapper(info.option, info)
+}
+
+// wrapperInfo is what a wrapper from this file, Produces, View or an example option stands for,
+// recorded when it is made
+type wrapperInfo struct {
+	name    string
+	inner   gin.HandlerFunc
+	skips   []string
+	formats []Format // added by Produces
+	view    string   // selected by View
+
+	examples []routeExample // added by RequestExample and ResponseExample
+}
+
+// option is the handler of route options that only matter at registration; requests go through
+// untouched. As a method value, it is a new func for every option.
+func (w *wrapperInfo) option(*gin.Context) {}
+
+// wrappers holds the wrapperInfo of every wrapper made, by funcID
+var wrappers sync.Map
+
+// funcID identifies a func value by the closure it points to. Closures of one function literal
+// share their code, so the code pointer used for Handle can't tell wrappers apart, but every
+// wrapper made is a closure of its own.
+func funcID(h gin.HandlerFunc) unsafe.Pointer {
+	return *(*unsafe.Pointer)(unsafe.Pointer(&h))
+}
+
+// recordWrapper records what h stands for and returns it
+func recordWrapper(h gin.HandlerFunc, info *wrapperInfo) gin.HandlerFunc {
+	wrappers.Store(funcID(h), info)
+	return h
+}
+
+// wrapperOf returns what h stands for if it is one of the wrappers above, Produces, View or an example option
+func wrapperOf(h gin.HandlerFunc) (*wrapperInfo, bool) {
+	if h == nil {
+		return nil, false
+	}
+	v, ok := wrappers.Load(funcID(h))
+	if !ok {
+		return nil, false
+	}
+	return v.(*wrapperInfo), true
+}
+
+// unwrapMiddleware returns the handler inside Unless and Skippable wrappers and the names it goes by
+func unwrapMiddleware(h gin.HandlerFunc) (gin.HandlerFunc, []string) {
+	var names []string
+	for {
+		p, ok := wrapperOf(h)
+		if !ok || p.inner == nil {
+			return h, names
+		}
+		if p.name != "" {
+			names = append(names, p.name)
+		}
+		h = p.inner
+	}
+}
+
+// routeSkips returns the names skipped by Skip options among handlers
+func routeSkips(handlers []gin.HandlerFunc) []string {
+	var skips []string
+	for _, h := range handlers {
+		if p, ok := wrapperOf(h); ok {
+			skips = append(skips, p.skips...)
+		}
+	}
+	return skips
+}
+
+// applySkips tells Skippable middleware which names the matched route skips
+func (a *App) applySkips(ctx *gin.Context) {
+	if len(a.skips) > 0 {
+		if names, ok := a.skips[ctx.Request.Method+" "+ctx.FullPath()]; ok {
+			ctx.Set(skipKey, names)
+		}
+	}
+}
+
+func skipped(ctx *gin.Context, name string) bool {
+	v, _ := ctx.Get(skipKey)
+	names, _ := v.([]string)
+	return slices.Contains(names, name)
+}
+
+// skipsAny reports whether skips covers any of names
+func skipsAny(skips, names []string) bool {
+	for _, name := range names {
+		if slices.Contains(skips, name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,123 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type skipHealthRes struct {
+	Status string `json:"status"`
+}
+
+func skipHealth(ctx *Context, req struct{}) (skipHealthRes, error) {
+	return skipHealthRes{Status: "ok"}, nil
+}
+
+func skipCounter(n *int) gin.HandlerFunc {
+	return func(ctx *gin.Context) { *n++ }
+}
+
+func TestUnless(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	limited := 0
+	app := New()
+	app.Use(Unless(skipCounter(&limited), PathIs("/healthz", "/items/:id")))
+	app.GET("/healthz", Handle(skipHealth))
+	app.GET("/items/:id", Handle(skipHealth))
+	app.GET("/orders", Handle(skipHealth))
+
+	for _, path := range []string{"/healthz", "/items/1", "/orders"} {
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: status %d", path, w.Code)
+		}
+	}
+	if limited != 1 {
+		t.Fatalf("expected the middleware to run only for /orders, ran %d times", limited)
+	}
+}
+
+func TestSkipRouteOption(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := New().WithSwagger("Skip", "1.0")
+	limited := 0
+	app.UseTyped(Skippable("auth", Middleware(groupAuth)), Skippable("ratelimit", skipCounter(&limited)))
+	app.GET("/healthz", Skip("auth", "ratelimit"), Handle(skipHealth))
+	app.GET("/items/:id", Handle(groupGetItem))
+	api := app.TypedGroup("/api")
+	api.GET("/status", Skip("auth"), Handle(skipHealth))
+
+	serve := func(path string, key string) int {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if key != "" {
+			req.Header.Set("X-API-Key", key)
+		}
+		app.ServeHTTP(w, req)
+		return w.Code
+	}
+	if code := serve("/healthz", ""); code != http.StatusOK {
+		t.Fatalf("/healthz should skip auth, got %d", code)
+	}
+	if limited != 0 {
+		t.Fatalf("/healthz should skip the rate limit, ran %d times", limited)
+	}
+	if code := serve("/api/status", ""); code != http.StatusOK || limited != 1 {
+		t.Fatalf("/api/status should skip only auth, got %d with %d limits", code, limited)
+	}
+	if code := serve("/items/1", ""); code != http.StatusBadRequest {
+		t.Fatalf("/items/1 should require the key, got %d", code)
+	}
+	if code := serve("/items/1", "secret"); code != http.StatusOK {
+		t.Fatalf("/items/1 with the key should pass, got %d", code)
+	}
+
+	spec := groupSpec(t, app)
+	if groupHeaderParams(spec.Paths["/healthz"].GET)["X-API-Key"] {
+		t.Fatal("skipped middleware should not be documented")
+	}
+	if !groupHeaderParams(spec.Paths["/items/{id}"].GET)["X-API-Key"] {
+		t.Fatal("Skippable typed middleware should still be documented where it runs")
+	}
+}
+
+func TestUnwrapMiddleware(t *testing.T) {
+	mw := Middleware(groupAuth)
+	inner, names := unwrapMiddleware(Skippable("auth", Unless(mw, PathIs("/x"))))
+	if _, _, _, ok := lookupHandlerTypes(inner); !ok {
+		t.Fatal("expected the typed middleware inside the wrappers")
+	}
+	if len(names) != 1 || names[0] != "auth" {
+		t.Fatalf("unexpected names %v", names)
+	}
+	if _, ok := wrapperOf(mw); ok {
+		t.Fatal("plain handlers must not be taken for wrappers")
+	}
+}
+
+func TestWrapperOf_TellsWrappersApart(t *testing.T) {
+	mw := func(ctx *gin.Context) {}
+	auth, audit := Skippable("auth", mw), Skippable("audit", mw)
+	skipAuth, skipNone := Skip("auth"), Skip()
+	summary, full := View("summary"), View("full")
+
+	for h, check := range map[*gin.HandlerFunc]func(*wrapperInfo) bool{
+		&auth:     func(w *wrapperInfo) bool { return w.name == "auth" },
+		&audit:    func(w *wrapperInfo) bool { return w.name == "audit" },
+		&skipAuth: func(w *wrapperInfo) bool { return slices.Equal(w.skips, []string{"auth"}) },
+		&skipNone: func(w *wrapperInfo) bool { return len(w.skips) == 0 },
+		&summary:  func(w *wrapperInfo) bool { return w.view == "summary" },
+		&full:     func(w *wrapperInfo) bool { return w.view == "full" },
+	} {
+		if w, ok := wrapperOf(*h); !ok || !check(w) {
+			t.Errorf("unexpected wrapper %+v", w)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// UpdateSnapshotsEnv is the environment variable making SnapshotSpec rewrite its snapshots
+const UpdateSnapshotsEnv = "FLUXO_UPDATE_SNAPSHOTS"
+
+// SnapshotSpec compares the app's OpenAPI spec with the snapshot at path, failing t when they
+// differ. The spec is written indented with sorted keys, so that committing the snapshot turns
+// API changes into reviewable diffs:
+//
+//	func TestAPISpec(t *testing.T) {
+//		fluxo.SnapshotSpec(t, newApp(), "testdata/openapi.json")
+//	}
+//
+// A missing snapshot is written. Run the tests with FLUXO_UPDATE_SNAPSHOTS=1 to accept changes.
+func SnapshotSpec(t testing.TB, a *App, path string) {
+	t.Helper()
+	if a.swagger == nil {
+		t.Fatalf("fluxo: SnapshotSpec needs swagger, call WithSwagger first")
+	}
+	doc, err := a.swagger.SpecJSON(a.specHandlers())
+	if err != nil {
+		t.Fatalf("fluxo: building the spec: %v", err)
+	}
+	got, err := normalizeSpec(doc)
+	if err != nil {
+		t.Fatalf("fluxo: normalizing the spec: %v", err)
+	}
+
+	want, err := os.ReadFile(path)
+	if os.IsNotExist(err) || os.Getenv(UpdateSnapshotsEnv) != "" {
+		if !bytes.Equal(got, want) {
+			writeSnapshot(t, path, got)
+		}
+		return
+	}
+	if err != nil {
+		t.Fatalf("fluxo: reading the spec snapshot: %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("fluxo: the OpenAPI spec differs from %s, rerun with %s=1 to update it if the change is intended:\n%s",
+			path, UpdateSnapshotsEnv, snapshotDiff(string(want), string(got)))
+	}
+}
+
+// normalizeSpec indents doc with sorted object keys and a trailing newline
+func normalizeSpec(doc []byte) ([]byte, error) {
+	var v any
+	if err := json.Unmarshal(doc, &v); err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func writeSnapshot(t testing.TB, path string, data []byte) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("fluxo: writing the spec snapshot: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("fluxo: writing the spec snapshot: %v", err)
+	}
+	t.Logf("fluxo: wrote the spec snapshot %s", path)
+}
+
+// snapshotDiff shows the lines around the first difference between want and got
+func snapshotDiff(want, got string) string {
+	const around, limit = 3, 40
+	wantLines, gotLines := strings.Split(want, "\n"), strings.Split(got, "\n")
+	first := 0
+	for first < len(wantLines) && first < len(gotLines) && wantLines[first] == gotLines[first] {
+		first++
+	}
+	// Find where the two end the same, so that only the changed lines are shown
+	lastWant, lastGot := len(wantLines)-1, len(gotLines)-1
+	for lastWant >= first && lastGot >= first && wantLines[lastWant] == gotLines[lastGot] {
+		lastWant--
+		lastGot--
+	}
+
+	var b strings.Builder
+	start := max(first-around, 0)
+	fmt.Fprintf(&b, "@@ line %d @@\n", start+1)
+	for _, line := range wantLines[start:first] {
+		b.WriteString("  " + line + "\n")
+	}
+	changed := func(prefix string, lines []string) {
+		for i, line := range lines {
+			if i == limit {
+				fmt.Fprintf(&b, "%s ... %d more lines\n", prefix, len(lines)-limit)
+				return
+			}
+			b.WriteString(prefix + " " + line + "\n")
+		}
+	}
+	changed("-", wantLines[first:lastWant+1])
+	changed("+", gotLines[first:lastGot+1])
+	for _, line := range gotLines[lastGot+1 : min(lastGot+1+around, len(gotLines))] {
+		b.WriteString("  " + line + "\n")
+	}
+	return b.String()
+}
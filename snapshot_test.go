@@ -0,0 +1,63 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// recordingT records failures instead of failing the test
+type recordingT struct {
+	testing.TB
+	errors []string
+}
+
+func (t *recordingT) Helper()                         {}
+func (t *recordingT) Logf(format string, args ...any) {}
+func (t *recordingT) Errorf(format string, args ...any) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+func (t *recordingT) Fatalf(format string, args ...any) { t.Errorf(format, args...) }
+
+func snapshotApp(routes ...string) *App {
+	gin.SetMode(gin.TestMode)
+	app := New().WithSwagger("t", "v")
+	for _, path := range routes {
+		app.GET(path, Handle(func(ctx *Context, req struct{}) (conformItem, error) { return conformItem{}, nil }))
+	}
+	return app
+}
+
+func TestSnapshotSpec(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "testdata", "openapi.json")
+
+	SnapshotSpec(t, snapshotApp("/items"), path)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("expected the snapshot to be written: %v", err)
+	}
+	if !strings.Contains(string(data), "\n  \"components\": {") || !strings.HasSuffix(string(data), "}\n") {
+		t.Fatalf("expected an indented spec with sorted keys, got %s", data)
+	}
+
+	// An unchanged spec passes
+	SnapshotSpec(t, snapshotApp("/items"), path)
+
+	rt := &recordingT{}
+	SnapshotSpec(rt, snapshotApp("/items", "/products"), path)
+	if len(rt.errors) != 1 || !strings.Contains(rt.errors[0], `+     "/products": {`) || !strings.Contains(rt.errors[0], UpdateSnapshotsEnv+"=1") {
+		t.Fatalf("expected a diff of the new route, got %v", rt.errors)
+	}
+
+	t.Setenv(UpdateSnapshotsEnv, "1")
+	SnapshotSpec(t, snapshotApp("/items", "/products"), path)
+	if data, _ := os.ReadFile(path); !strings.Contains(string(data), `"/products"`) {
+		t.Fatalf("expected the snapshot to be updated, got %s", data)
+	}
+}
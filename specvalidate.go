@@ -0,0 +1,238 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// SpecValidator checks a generated OpenAPI document, e.g. by loading it with kin-openapi:
+//
+//	func(doc []byte) error {
+//		spec, err := openapi3.NewLoader().LoadFromData(doc)
+//		if err != nil {
+//			return err
+//		}
+//		return spec.Validate(context.Background())
+//	}
+type SpecValidator func(doc []byte) error
+
+// WithSpecValidation makes App.Start validate the spec before serving, see App.Validate.
+// The validators run after the built-in checks.
+func WithSpecValidation(validators ...SpecValidator) SwaggerOption {
+	return func(sg *SwaggerGenerator) {
+		sg.validateOnStart = true
+		sg.validators = append(sg.validators, validators...)
+	}
+}
+
+// Validate builds the OpenAPI spec and checks it is well formed: path templates match their
+// parameters, schemas have valid types and their references resolve, and so on. Each problem
+// is reported with the operation or component it was found in, followed by the errors of the
+// validators given to WithSpecValidation. Run it in a test to catch malformed specs in CI:
+//
+//	if err := app.Validate(); err != nil {
+//		t.Fatal(err)
+//	}
+//
+// Validate returns nil when swagger is not enabled.
+func (a *App) Validate() error {
+	if a.swagger == nil {
+		return nil
+	}
+	doc, err := a.swagger.SpecJSON(a.specHandlers())
+	if err != nil {
+		return fmt.Errorf("fluxo: building the spec: %w", err)
+	}
+	var spec OpenAPISpec
+	if err := json.Unmarshal(doc, &spec); err != nil {
+		return fmt.Errorf("fluxo: reading the spec: %w", err)
+	}
+
+	errs := checkSpec(&spec)
+	for _, validate := range a.swagger.validators {
+		if err := validate(doc); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("fluxo: invalid OpenAPI spec:\n%w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// validateSpecOnStart makes Start fail when the spec is invalid, if WithSpecValidation asked for it
+func (a *App) validateSpecOnStart() error {
+	if a.swagger == nil || !a.swagger.validateOnStart {
+		return nil
+	}
+	return a.Validate()
+}
+
+var (
+	openAPITypes       = []string{"string", "number", "integer", "boolean", "array", "object"}
+	parameterLocations = []string{"path", "query", "header", "cookie"}
+	componentNameRe    = regexp.MustCompile(`^[a-zA-Z0-9.\-_]+$`)
+	responseCodeRe     = regexp.MustCompile(`^([1-5][0-9]{2}|[1-5]XX|default)$`)
+	pathTemplateRe     = regexp.MustCompile(`\{([^{}/]*)\}`)
+)
+
+// checkSpec returns the problems found in spec, in a stable order
+func checkSpec(spec *OpenAPISpec) []error {
+	var errs []error
+	fail := func(where, format string, args ...any) {
+		errs = append(errs, fmt.Errorf("%s: %s", where, fmt.Sprintf(format, args...)))
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(spec.Components.Schemas)) {
+		where := "component " + name
+		if !componentNameRe.MatchString(name) {
+			fail(where, "name must only contain letters, digits, '.', '-' and '_'")
+		}
+		checkSchema(spec, spec.Components.Schemas[name], where, fail)
+	}
+	for _, path := range slices.Sorted(maps.Keys(spec.Paths)) {
+		if !strings.HasPrefix(path, "/") {
+			fail(path, "path must start with /")
+		}
+		checkPathItem(spec, path, spec.Paths[path], pathTemplateParams(path), fail)
+	}
+	for _, name := range slices.Sorted(maps.Keys(spec.Webhooks)) {
+		checkPathItem(spec, "webhook "+name, spec.Webhooks[name], nil, fail)
+	}
+	return errs
+}
+
+// pathTemplateParams returns the names templated in path, /users/{id} -> [id]
+func pathTemplateParams(path string) []string {
+	var names []string
+	for _, m := range pathTemplateRe.FindAllStringSubmatch(path, -1) {
+		names = append(names, m[1])
+	}
+	return names
+}
+
+func checkPathItem(spec *OpenAPISpec, path string, item PathItem, templated []string, fail func(where, format string, args ...any)) {
+	operations := []struct {
+		method string
+		op     *Operation
+	}{
+		{"GET", item.GET}, {"POST", item.POST}, {"PUT", item.PUT}, {"DELETE", item.DELETE},
+		{"PATCH", item.PATCH}, {"HEAD", item.HEAD}, {"OPTIONS", item.OPTIONS},
+	}
+	for _, o := range operations {
+		if o.op != nil {
+			checkOperation(spec, o.method+" "+path, o.op, templated, fail)
+		}
+	}
+}
+
+func checkOperation(spec *OpenAPISpec, where string, op *Operation, templated []string, fail func(where, format string, args ...any)) {
+	seen := make(map[string]bool)
+	for _, p := range op.Parameters {
+		if p.Name == "" {
+			fail(where, "a %s parameter has no name", p.In)
+			continue
+		}
+		if !slices.Contains(parameterLocations, p.In) {
+			fail(where, "parameter %q is in %q, expected one of %s", p.Name, p.In, strings.Join(parameterLocations, ", "))
+		}
+		key := p.In + ":" + p.Name
+		if p.In == "header" {
+			key = strings.ToLower(key)
+		}
+		if seen[key] {
+			fail(where, "%s parameter %q is declared twice", p.In, p.Name)
+		}
+		seen[key] = true
+		if p.In == "path" {
+			if !slices.Contains(templated, p.Name) {
+				fail(where, "path parameter %q does not appear in the path", p.Name)
+			} else if !p.Required {
+				fail(where, "path parameter %q must be required", p.Name)
+			}
+		}
+		checkSchema(spec, p.Schema, fmt.Sprintf("%s: parameter %q", where, p.Name), fail)
+	}
+	for _, name := range templated {
+		if !seen["path:"+name] {
+			fail(where, "path parameter %q is not declared", name)
+		}
+	}
+
+	if op.RequestBody != nil {
+		if len(op.RequestBody.Content) == 0 {
+			fail(where, "request body has no content")
+		}
+		for _, mediaType := range slices.Sorted(maps.Keys(op.RequestBody.Content)) {
+			checkSchema(spec, op.RequestBody.Content[mediaType].Schema, fmt.Sprintf("%s: request body %s", where, mediaType), fail)
+			checkExamples(spec, fmt.Sprintf("%s: request body %s", where, mediaType), mediaType, op.RequestBody.Content[mediaType], fail)
+		}
+	}
+
+	if len(op.Responses) == 0 {
+		fail(where, "operation has no responses")
+	}
+	for _, code := range slices.Sorted(maps.Keys(op.Responses)) {
+		if !responseCodeRe.MatchString(code) {
+			fail(where, "response %q is not a status code, a range like 4XX or default", code)
+		}
+		res := op.Responses[code]
+		if res.Description == "" {
+			fail(where, "response %s has no description", code)
+		}
+		for _, name := range slices.Sorted(maps.Keys(res.Headers)) {
+			checkSchema(spec, res.Headers[name].Schema, fmt.Sprintf("%s: response %s header %s", where, code, name), fail)
+		}
+		for _, mediaType := range slices.Sorted(maps.Keys(res.Content)) {
+			checkSchema(spec, res.Content[mediaType].Schema, fmt.Sprintf("%s: response %s %s", where, code, mediaType), fail)
+			checkExamples(spec, fmt.Sprintf("%s: response %s %s", where, code, mediaType), mediaType, res.Content[mediaType], fail)
+		}
+	}
+}
+
+// checkSchema checks s and the schemas nested in it
+func checkSchema(spec *OpenAPISpec, s Schema, where string, fail func(where, format string, args ...any)) {
+	if s.Ref != "" {
+		name, ok := strings.CutPrefix(s.Ref, "#/components/schemas/")
+		if _, exists := spec.Components.Schemas[name]; !ok || !exists {
+			fail(where, "$ref %q does not resolve to a component schema", s.Ref)
+		}
+		return
+	}
+	if s.Type != "" && !slices.Contains(openAPITypes, s.Type) {
+		fail(where, "type %q is not one of %s", s.Type, strings.Join(openAPITypes, ", "))
+	}
+	if s.Type == "array" && s.Items == nil {
+		fail(where, "array schema has no items")
+	}
+	for _, name := range s.Required {
+		if s.Type == "object" && s.AdditionalProperties == nil {
+			if _, ok := s.Properties[name]; !ok {
+				fail(where, "required property %q is not defined", name)
+			}
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(s.Properties)) {
+		checkSchema(spec, s.Properties[name], where+"."+name, fail)
+	}
+	if s.Items != nil {
+		checkSchema(spec, *s.Items, where+"[]", fail)
+	}
+	if s.AdditionalProperties != nil {
+		checkSchema(spec, *s.AdditionalProperties, where+"{}", fail)
+	}
+	for i, sub := range s.OneOf {
+		checkSchema(spec, sub, fmt.Sprintf("%s.oneOf[%d]", where, i), fail)
+	}
+	for i, sub := range s.AllOf {
+		checkSchema(spec, sub, fmt.Sprintf("%s.allOf[%d]", where, i), fail)
+	}
+}
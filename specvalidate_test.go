@@ -0,0 +1,88 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type specItemReq struct {
+	ID     int    `uri:"id"`
+	Tenant string `header:"X-Tenant"`
+	Name   string `json:"name" validate:"required"`
+}
+
+type specTree struct {
+	Name     string     `json:"name"`
+	Children []specTree `json:"children"`
+}
+
+func specApp() *App {
+	gin.SetMode(gin.TestMode)
+	app := New().WithSwagger("t", "v", WithGlobalHeader("X-Request-ID", false, Schema{Type: "string"}))
+	app.PUT("/items/:id", Handle(func(ctx *Context, req specItemReq) (specTree, error) { return specTree{}, nil }))
+	app.GET("/items", Produces(CSVFormat), Handle(func(ctx *Context, req enumReq) ([]specTree, error) { return nil, nil }))
+	return app
+}
+
+func TestValidate_GeneratedSpec(t *testing.T) {
+	if err := specApp().Validate(); err != nil {
+		t.Fatalf("expected the generated spec to be valid, got %v", err)
+	}
+	if err := New().Validate(); err != nil {
+		t.Fatalf("expected nothing to validate without swagger, got %v", err)
+	}
+}
+
+func TestValidate_Problems(t *testing.T) {
+	app := specApp()
+	app.OnSpec(func(spec *OpenAPISpec) {
+		op := spec.Paths["/items/{id}"].PUT
+		op.Parameters = append(op.Parameters, Parameter{Name: "slug", In: "path", Required: true, Schema: Schema{Type: "str"}})
+		op.Responses["ok"] = Response{Description: "OK"}
+		spec.Paths["/orphans/{id}"] = PathItem{GET: &Operation{Responses: map[string]Response{"200": {Description: "OK"}}}}
+		spec.Components.Schemas["Broken"] = Schema{Type: "array", Required: []string{"x"}, Properties: map[string]Schema{"y": {Ref: "#/components/schemas/Missing"}}}
+	})
+
+	err := app.Validate()
+	if err == nil {
+		t.Fatal("expected the spec to be invalid")
+	}
+	for _, want := range []string{
+		`component Broken: array schema has no items`,
+		`component Broken.y: $ref "#/components/schemas/Missing" does not resolve to a component schema`,
+		`GET /orphans/{id}: path parameter "id" is not declared`,
+		`PUT /items/{id}: path parameter "slug" does not appear in the path`,
+		`PUT /items/{id}: parameter "slug": type "str" is not one of`,
+		`PUT /items/{id}: response "ok" is not a status code`,
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in:\n%v", want, err)
+		}
+	}
+}
+
+func TestValidate_Validators(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	errCodegen := errors.New("codegen rejects the spec")
+	var got []byte
+	app := New().WithSwagger("t", "v", WithSpecValidation(func(doc []byte) error {
+		got = doc
+		return errCodegen
+	}))
+	app.GET("/ping", Handle(func(ctx *Context, req struct{}) (gin.H, error) { return nil, nil }))
+
+	if err := app.Validate(); !errors.Is(err, errCodegen) {
+		t.Fatalf("expected the validator's error, got %v", err)
+	}
+	if !strings.Contains(string(got), `"/ping"`) {
+		t.Errorf("expected the validator to get the spec, got %s", got)
+	}
+	if err := app.Start("127.0.0.1:0"); !errors.Is(err, errCodegen) {
+		t.Fatalf("expected Start to fail before serving, got %v", err)
+	}
+}
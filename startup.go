@@ -0,0 +1,73 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"log/slog"
+	"net"
+	"os"
+)
+
+// WithLogger sets the logger of the app, used for its startup report. Defaults to slog.Default().
+func WithLogger(logger *slog.Logger) AppOption {
+	return func(c *appConfig) {
+		c.logger = logger
+	}
+}
+
+// WithJSONLogs logs as JSON lines on stderr, for log collectors
+func WithJSONLogs() AppOption {
+	return WithLogger(slog.New(slog.NewJSONHandler(os.Stderr, nil)))
+}
+
+// WithQuietStart leaves out the startup report logged once the app listens
+func WithQuietStart() AppOption {
+	return func(c *appConfig) {
+		c.quietStart = true
+	}
+}
+
+// Logger returns the logger of the app, see WithLogger
+func (a *App) Logger() *slog.Logger {
+	return a.logger
+}
+
+// reportStartup logs the address the app listens on, its routes and where its docs are
+func (a *App) reportStartup(addr net.Addr, useTLS bool) {
+	if a.quietStart {
+		return
+	}
+	documented := 0
+	for _, r := range a.routes {
+		if r.Typed {
+			documented++
+		}
+	}
+	base := baseURL(addr, useTLS)
+	attrs := []any{
+		"addr", addr.String(),
+		"url", base,
+		"routes", len(a.router.Routes()),
+		"documented", documented,
+	}
+	if a.docsPath != "" {
+		attrs = append(attrs, "docs", base+a.docsPath)
+	}
+	a.logger.Info("fluxo: listening", attrs...)
+}
+
+// baseURL returns the URL the app is reachable at locally when listening on addr
+func baseURL(addr net.Addr, useTLS bool) string {
+	scheme := "http"
+	if useTLS {
+		scheme = "https"
+	}
+	host, port, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return scheme + "://" + addr.String()
+	}
+	if ip := net.ParseIP(host); ip == nil || ip.IsUnspecified() {
+		host = "localhost"
+	}
+	return scheme + "://" + net.JoinHostPort(host, port)
+}
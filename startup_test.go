@@ -0,0 +1,98 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// startAndStop starts app on a random local port, waits for it to serve and stops it
+func startAndStop(t *testing.T, app *App) {
+	t.Helper()
+	done := make(chan error, 1)
+	go func() { done <- app.Start("127.0.0.1:0") }()
+	time.Sleep(100 * time.Millisecond)
+	if err := app.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestStartupReport(t *testing.T) {
+	var logs bytes.Buffer
+	app := New(WithMode(gin.TestMode), WithLogger(slog.New(slog.NewJSONHandler(&logs, nil)))).WithSwagger("API", "1.0")
+	app.GET("/todos", Handle(func(ctx *Context, _ struct{}) ([]string, error) {
+		return nil, nil
+	}))
+	startAndStop(t, app)
+
+	var report map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(logs.String()), "\n") {
+		var entry map[string]any
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("log line %q: %v", line, err)
+		}
+		if entry["msg"] == "fluxo: listening" {
+			report = entry
+		}
+	}
+	url, _ := report["url"].(string)
+	if !strings.HasPrefix(url, "http://127.0.0.1:") || report["docs"] != url+"/docs" {
+		t.Errorf("report = %v", report)
+	}
+	if report["documented"] != 1.0 || report["routes"].(float64) < 2 {
+		t.Errorf("route counts = %v, %v", report["routes"], report["documented"])
+	}
+}
+
+func TestStartupReport_DefaultAddr(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, port, _ := net.SplitHostPort(ln.Addr().String())
+	ln.Close()
+	t.Setenv("PORT", port)
+
+	var logs bytes.Buffer
+	app := New(WithMode(gin.TestMode), WithLogger(slog.New(slog.NewTextHandler(&logs, nil))))
+	done := make(chan error, 1)
+	go func() { done <- app.Start("") }()
+	time.Sleep(100 * time.Millisecond)
+	if err := app.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(logs.String(), "url=http://localhost:"+port) {
+		t.Errorf("expected the report to show $PORT, got %q", logs.String())
+	}
+}
+
+func TestStartupReport_Quiet(t *testing.T) {
+	var logs bytes.Buffer
+	app := New(WithMode(gin.TestMode), WithQuietStart(), WithLogger(slog.New(slog.NewTextHandler(&logs, nil))))
+	startAndStop(t, app)
+	if logs.Len() != 0 {
+		t.Errorf("quiet start logged %q", logs.String())
+	}
+}
+
+func TestBaseURL(t *testing.T) {
+	addr := &net.TCPAddr{IP: net.IPv6unspecified, Port: 8080}
+	if got := baseURL(addr, true); got != "https://localhost:8080" {
+		t.Errorf("baseURL = %q", got)
+	}
+}
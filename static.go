@@ -0,0 +1,223 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"bytes"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// StaticOption configures a mount of static files, see App.Static
+type StaticOption func(*staticConfig)
+
+type staticConfig struct {
+	maxAge        time.Duration
+	fingerprinted *regexp.Regexp
+	encodings     []string // precompressed variants looked for, preferred first
+	index         string
+}
+
+// defaultFingerprint matches file names carrying a content hash, like app.3f2a9c1b.js,
+// app-3F2A9C1B.css or chunk.3f2a9c1b4d5e.min.js
+var defaultFingerprint = regexp.MustCompile(`[.-][0-9a-fA-F]{8,}(\.min)?\.[a-zA-Z0-9]+$`)
+
+// WithStaticMaxAge sets how long browsers may use files that aren't fingerprinted before
+// asking again, an hour by default. Zero makes them revalidate every time.
+func WithStaticMaxAge(d time.Duration) StaticOption {
+	return func(c *staticConfig) {
+		c.maxAge = d
+	}
+}
+
+// WithFingerprintPattern sets which file names carry a content hash and are cached as
+// immutable for a year. By default names like app.3f2a9c1b.js are; nil turns it off.
+func WithFingerprintPattern(re *regexp.Regexp) StaticOption {
+	return func(c *staticConfig) {
+		c.fingerprinted = re
+	}
+}
+
+// WithPrecompressed sets the precompressed variants served, "br" and "gzip" by default, looked
+// up next to each file as .br and .gz. None turns it off.
+func WithPrecompressed(encodings ...string) StaticOption {
+	return func(c *staticConfig) {
+		c.encodings = encodings
+	}
+}
+
+// WithStaticIndex sets the file served for directories, index.html by default
+func WithStaticIndex(name string) StaticOption {
+	return func(c *staticConfig) {
+		c.index = name
+	}
+}
+
+// staticExtensions are the file extensions of precompressed variants by encoding
+var staticExtensions = map[string]string{"br": ".br", "gzip": ".gz", "zstd": ".zst"}
+
+// Static serves the files of fsys, such as an embed.FS of a built frontend, under prefix for
+// GET and HEAD requests. A file compressed at build time next to the original, like app.js.br
+// or app.js.gz, is served instead when the Accept-Encoding header allows it. Fingerprinted files
+// are cached by browsers for good, and others for an hour:
+//
+//	//go:embed dist
+//	var dist embed.FS
+//
+//	assets, _ := fs.Sub(dist, "dist")
+//	app.Static("/assets", assets, fluxo.WithStaticMaxAge(5*time.Minute))
+//
+// The routes are plain gin handlers, left out of the spec.
+func (a *App) Static(prefix string, fsys fs.FS, opts ...StaticOption) {
+	cfg := staticConfig{
+		maxAge:        time.Hour,
+		fingerprinted: defaultFingerprint,
+		encodings:     []string{"br", "gzip"},
+		index:         "index.html",
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	for _, enc := range cfg.encodings {
+		if _, ok := staticExtensions[enc]; !ok {
+			panic("fluxo: unknown precompressed encoding " + enc)
+		}
+	}
+	prefix = "/" + strings.Trim(prefix, "/")
+	route := strings.TrimSuffix(prefix, "/") + "/*filepath"
+	handler := staticFiles(fsys, cfg)
+	a.GET(route, handler)
+	a.HEAD(route, handler)
+}
+
+func staticFiles(fsys fs.FS, cfg staticConfig) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		name := strings.TrimPrefix(path.Clean("/"+ctx.Param("filepath")), "/")
+		if name == "" {
+			name = "."
+		}
+		info, err := fs.Stat(fsys, name)
+		if err == nil && info.IsDir() && cfg.index != "" {
+			name = path.Join(name, cfg.index)
+			info, err = fs.Stat(fsys, name)
+		}
+		if err != nil || info.IsDir() {
+			ctx.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "Not Found"})
+			return
+		}
+
+		h := ctx.Writer.Header()
+		if cfg.fingerprinted != nil && cfg.fingerprinted.MatchString(path.Base(name)) {
+			h.Set("Cache-Control", "public, max-age=31536000, immutable")
+		} else if cfg.maxAge > 0 {
+			h.Set("Cache-Control", "public, max-age="+strconv.Itoa(int(cfg.maxAge.Seconds())))
+		} else {
+			h.Set("Cache-Control", "no-cache")
+		}
+		if contentType := mime.TypeByExtension(path.Ext(name)); contentType != "" {
+			h.Set("Content-Type", contentType)
+		}
+
+		served := name
+		if len(cfg.encodings) > 0 {
+			h.Add("Vary", "Accept-Encoding")
+			if enc, variant, ok := precompressed(fsys, name, ctx.GetHeader("Accept-Encoding"), cfg.encodings); ok {
+				h.Set("Content-Encoding", enc)
+				served = variant
+			}
+		}
+		f, err := fsys.Open(served)
+		if err != nil {
+			h.Del("Content-Encoding")
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Failed to read file"})
+			return
+		}
+		defer f.Close()
+		content, err := seekable(f)
+		if err != nil {
+			h.Del("Content-Encoding")
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Failed to read file"})
+			return
+		}
+		if served != name {
+			// ServeContent leaves out the length of encoded content, and ranges of the variant
+			// aren't worth serving
+			if variant, err := f.Stat(); err == nil {
+				h.Set("Content-Length", strconv.FormatInt(variant.Size(), 10))
+			}
+			ctx.Request.Header.Del("Range")
+		}
+		// The name is the original's, so ServeContent never sniffs compressed bytes
+		http.ServeContent(ctx.Writer, ctx.Request, name, info.ModTime(), content)
+	}
+}
+
+// precompressed returns the first of encodings the client accepts with a variant of name in fsys
+func precompressed(fsys fs.FS, name, acceptEncoding string, encodings []string) (string, string, bool) {
+	accepted := acceptedEncodings(acceptEncoding)
+	for _, enc := range encodings {
+		if !accepted[enc] {
+			continue
+		}
+		variant := name + staticExtensions[enc]
+		if info, err := fs.Stat(fsys, variant); err == nil && !info.IsDir() {
+			return enc, variant, true
+		}
+	}
+	return "", "", false
+}
+
+// acceptedEncodings returns the codings an Accept-Encoding header allows, leaving out those
+// with q=0
+func acceptedEncodings(header string) map[string]bool {
+	accepted := map[string]bool{}
+	wildcard := false
+	for _, part := range strings.Split(header, ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		coding = strings.ToLower(strings.TrimSpace(coding))
+		if coding == "" {
+			continue
+		}
+		ok := true
+		if q, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			if v, err := strconv.ParseFloat(q, 64); err == nil && v == 0 {
+				ok = false
+			}
+		}
+		if coding == "*" {
+			wildcard = ok
+			continue
+		}
+		accepted[coding] = ok
+	}
+	if wildcard {
+		for enc := range staticExtensions {
+			if _, listed := accepted[enc]; !listed {
+				accepted[enc] = true
+			}
+		}
+	}
+	return accepted
+}
+
+// seekable returns f for http.ServeContent, which needs to seek, reading it whole when its file
+// system doesn't support seeking
+func seekable(f fs.File) (io.ReadSeeker, error) {
+	if rs, ok := f.(io.ReadSeeker); ok {
+		return rs, nil
+	}
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewReader(data), nil
+}
@@ -0,0 +1,108 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+var staticFS = fstest.MapFS{
+	"index.html":          {Data: []byte("<h1>home</h1>")},
+	"app.3f2a9c1b.js":     {Data: []byte("console.log(1)")},
+	"app.3f2a9c1b.js.br":  {Data: []byte("br-bytes")},
+	"app.3f2a9c1b.js.gz":  {Data: []byte("gz-bytes")},
+	"css/site.css":        {Data: []byte("body{}")},
+	"css/site.css.gz":     {Data: []byte("gz-css")},
+	"docs/readme.txt":     {Data: []byte("read me")},
+	"docs/readme.txt.zst": {Data: []byte("zst")},
+}
+
+func staticGet(app *App, method, path, acceptEncoding string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	if acceptEncoding != "" {
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+	}
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, req)
+	return w
+}
+
+func TestStatic(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := New()
+	app.Static("/assets", staticFS)
+
+	for _, tc := range []struct {
+		path, acceptEncoding, body, encoding string
+	}{
+		{"/assets/app.3f2a9c1b.js", "gzip, br", "br-bytes", "br"},
+		{"/assets/app.3f2a9c1b.js", "gzip", "gz-bytes", "gzip"},
+		{"/assets/app.3f2a9c1b.js", "br;q=0, gzip", "gz-bytes", "gzip"},
+		{"/assets/app.3f2a9c1b.js", "*", "br-bytes", "br"},
+		{"/assets/app.3f2a9c1b.js", "", "console.log(1)", ""},
+		{"/assets/css/site.css", "br", "body{}", ""},
+		{"/assets/docs/readme.txt", "zstd", "read me", ""}, // zstd isn't served by default
+		{"/assets/", "", "<h1>home</h1>", ""},
+	} {
+		w := staticGet(app, http.MethodGet, tc.path, tc.acceptEncoding)
+		if w.Code != http.StatusOK || w.Body.String() != tc.body || w.Header().Get("Content-Encoding") != tc.encoding {
+			t.Errorf("%s %q: got %d %q encoded %q", tc.path, tc.acceptEncoding, w.Code, w.Body, w.Header().Get("Content-Encoding"))
+		}
+		if w.Header().Get("Vary") != "Accept-Encoding" {
+			t.Errorf("%s: expected Vary: Accept-Encoding, got %v", tc.path, w.Header())
+		}
+	}
+
+	w := staticGet(app, http.MethodGet, "/assets/app.3f2a9c1b.js", "br")
+	if got := w.Header().Get("Content-Type"); got != "text/javascript; charset=utf-8" {
+		t.Errorf("expected the original's content type, got %q", got)
+	}
+	if got := w.Header().Get("Cache-Control"); got != "public, max-age=31536000, immutable" {
+		t.Errorf("expected fingerprinted files immutable, got %q", got)
+	}
+	if got := staticGet(app, http.MethodGet, "/assets/css/site.css", "").Header().Get("Cache-Control"); got != "public, max-age=3600" {
+		t.Errorf("expected other files cached for an hour, got %q", got)
+	}
+
+	w = staticGet(app, http.MethodHead, "/assets/css/site.css", "gzip")
+	if w.Code != http.StatusOK || w.Body.Len() != 0 || w.Header().Get("Content-Length") != "6" {
+		t.Errorf("unexpected HEAD response %d %v %q", w.Code, w.Header(), w.Body)
+	}
+	for _, path := range []string{"/assets/missing.js", "/assets/../static.go", "/assets/css"} {
+		if w := staticGet(app, http.MethodGet, path, ""); w.Code != http.StatusNotFound {
+			t.Errorf("%s: expected 404, got %d", path, w.Code)
+		}
+	}
+}
+
+func TestStatic_Options(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := New()
+	app.Static("/a", staticFS, WithStaticMaxAge(0), WithFingerprintPattern(nil), WithPrecompressed("zstd"))
+	app.Static("/b", staticFS, WithPrecompressed(), WithStaticMaxAge(5*time.Minute))
+
+	w := staticGet(app, http.MethodGet, "/a/app.3f2a9c1b.js", "gzip, br")
+	if w.Body.String() != "console.log(1)" || w.Header().Get("Cache-Control") != "no-cache" {
+		t.Errorf("unexpected response %v %q", w.Header(), w.Body)
+	}
+	if w := staticGet(app, http.MethodGet, "/a/docs/readme.txt", "zstd"); w.Body.String() != "zst" {
+		t.Errorf("expected the zstd variant, got %q", w.Body)
+	}
+	w = staticGet(app, http.MethodGet, "/b/css/site.css", "gzip")
+	if w.Body.String() != "body{}" || w.Header().Get("Vary") != "" || w.Header().Get("Cache-Control") != "public, max-age=300" {
+		t.Errorf("unexpected response %v %q", w.Header(), w.Body)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic for an unknown encoding")
+		}
+	}()
+	app.Static("/c", staticFS, WithPrecompressed("deflate"))
+}
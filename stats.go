@@ -0,0 +1,275 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LatencyBuckets are the upper bounds of the per-route latency histogram
+var LatencyBuckets = []time.Duration{
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+// RouteStats is a snapshot of the requests served by one route
+type RouteStats struct {
+	Method       string
+	Path         string
+	Typed        bool // the route is a documented fluxo handler
+	Count        uint64
+	ClientErrors uint64 // 4xx responses
+	ServerErrors uint64 // 5xx responses
+	Total        time.Duration
+	Max          time.Duration
+	// Buckets[i] counts requests at most LatencyBuckets[i]; the last one counts slower requests
+	Buckets []uint64
+	// SpecMismatches counts sampled responses not matching the spec, see ValidateResponses
+	SpecMismatches uint64
+}
+
+// Mean returns the average latency
+func (s RouteStats) Mean() time.Duration {
+	if s.Count == 0 {
+		return 0
+	}
+	return s.Total / time.Duration(s.Count)
+}
+
+// ErrorRate returns the share of 5xx responses
+func (s RouteStats) ErrorRate() float64 {
+	if s.Count == 0 {
+		return 0
+	}
+	return float64(s.ServerErrors) / float64(s.Count)
+}
+
+// Quantile estimates the q-quantile (0..1) of the latency from the histogram,
+// interpolating within the bucket it falls in
+func (s RouteStats) Quantile(q float64) time.Duration {
+	if s.Count == 0 {
+		return 0
+	}
+	rank := q * float64(s.Count)
+	var seen uint64
+	lower := time.Duration(0)
+	for i, n := range s.Buckets {
+		if n > 0 && float64(seen+n) >= rank {
+			if i == len(LatencyBuckets) {
+				return s.Max
+			}
+			upper := LatencyBuckets[i]
+			if s.Max < upper {
+				upper = s.Max
+			}
+			within := (rank - float64(seen)) / float64(n)
+			return lower + time.Duration(within*float64(upper-lower))
+		}
+		seen += n
+		if i < len(LatencyBuckets) {
+			lower = LatencyBuckets[i]
+		}
+	}
+	return s.Max
+}
+
+// MarshalJSON renders latencies in milliseconds
+func (s RouteStats) MarshalJSON() ([]byte, error) {
+	ms := func(d time.Duration) float64 { return float64(d) / float64(time.Millisecond) }
+	buckets := make([]gin.H, len(s.Buckets))
+	for i, n := range s.Buckets {
+		le := "+Inf"
+		if i < len(LatencyBuckets) {
+			le = LatencyBuckets[i].String()
+		}
+		buckets[i] = gin.H{"le": le, "count": n}
+	}
+	return json.Marshal(gin.H{
+		"method":          s.Method,
+		"path":            s.Path,
+		"typed":           s.Typed,
+		"count":           s.Count,
+		"client_errors":   s.ClientErrors,
+		"server_errors":   s.ServerErrors,
+		"spec_mismatches": s.SpecMismatches,
+		"error_rate":      s.ErrorRate(),
+		"mean_ms":         ms(s.Mean()),
+		"p50_ms":          ms(s.Quantile(0.5)),
+		"p95_ms":          ms(s.Quantile(0.95)),
+		"p99_ms":          ms(s.Quantile(0.99)),
+		"max_ms":          ms(s.Max),
+		"buckets":         buckets,
+	})
+}
+
+// routeCounter accumulates the stats of one route without locking
+type routeCounter struct {
+	count        atomic.Uint64
+	clientErrors atomic.Uint64
+	serverErrors atomic.Uint64
+	mismatches   atomic.Uint64
+	total        atomic.Int64
+	max          atomic.Int64
+	buckets      []atomic.Uint64
+}
+
+func (c *routeCounter) observe(status int, d time.Duration) {
+	c.count.Add(1)
+	switch {
+	case status >= 500:
+		c.serverErrors.Add(1)
+	case status >= 400:
+		c.clientErrors.Add(1)
+	}
+	c.total.Add(int64(d))
+	for {
+		old := c.max.Load()
+		if int64(d) <= old || c.max.CompareAndSwap(old, int64(d)) {
+			break
+		}
+	}
+	i := sort.Search(len(LatencyBuckets), func(i int) bool { return d <= LatencyBuckets[i] })
+	c.buckets[i].Add(1)
+}
+
+// routeStats collects the stats of every route an app serves
+type routeStats struct {
+	routes sync.Map                       // "METHOD path" -> *routeCounter
+	recent atomic.Pointer[recentRequests] // the latest requests, kept once EnableAdmin is called
+}
+
+// record is the middleware timing each matched route
+func (s *routeStats) record(ctx *gin.Context) {
+	start := time.Now()
+	ctx.Next()
+	route, elapsed := ctx.FullPath(), time.Since(start)
+	if recent := s.recent.Load(); recent != nil {
+		recent.add(AdminRequest{
+			Time:      start,
+			Method:    ctx.Request.Method,
+			Path:      ctx.Request.URL.Path,
+			Route:     route,
+			Status:    ctx.Writer.Status(),
+			Duration:  elapsed,
+			ClientIP:  (&Context{Context: ctx}).RealIP(),
+			RequestID: requestIDOf(ctx),
+		})
+	}
+	if route == "" {
+		return
+	}
+	s.counter(ctx.Request.Method+" "+route).observe(ctx.Writer.Status(), elapsed)
+}
+
+// counter returns the counter of key, "METHOD path"
+func (s *routeStats) counter(key string) *routeCounter {
+	c, ok := s.routes.Load(key)
+	if !ok {
+		c, _ = s.routes.LoadOrStore(key, &routeCounter{buckets: make([]atomic.Uint64, len(LatencyBuckets)+1)})
+	}
+	return c.(*routeCounter)
+}
+
+// mismatch counts a response of key not matching the spec
+func (s *routeStats) mismatch(key string) {
+	s.counter(key).mismatches.Add(1)
+}
+
+func (s *routeStats) snapshot() []RouteStats {
+	var out []RouteStats
+	s.routes.Range(func(k, v any) bool {
+		method, path, _ := strings.Cut(k.(string), " ")
+		c := v.(*routeCounter)
+		rs := RouteStats{
+			Method:         method,
+			Path:           path,
+			Count:          c.count.Load(),
+			ClientErrors:   c.clientErrors.Load(),
+			ServerErrors:   c.serverErrors.Load(),
+			SpecMismatches: c.mismatches.Load(),
+			Total:          time.Duration(c.total.Load()),
+			Max:            time.Duration(c.max.Load()),
+			Buckets:        make([]uint64, len(c.buckets)),
+		}
+		for i := range c.buckets {
+			rs.Buckets[i] = c.buckets[i].Load()
+		}
+		out = append(out, rs)
+		return true
+	})
+	return out
+}
+
+// Stats returns the request count, error counts and latency histogram of every route served so
+// far, including routes of mounted sub-apps, sorted by path and method
+func (a *App) Stats() []RouteStats {
+	var out []RouteStats
+	for _, rs := range a.stats.snapshot() {
+		if a.mountRoute(rs.Path) {
+			continue
+		}
+		_, rs.Typed = a.handlers[rs.Method+":"+rs.Path]
+		out = append(out, rs)
+	}
+	for _, m := range a.mounts {
+		for _, rs := range m.app.Stats() {
+			rs.Path = m.prefix + rs.Path
+			out = append(out, rs)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Path != out[j].Path {
+			return out[i].Path < out[j].Path
+		}
+		return out[i].Method < out[j].Method
+	})
+	return out
+}
+
+// mountRoute reports whether path is the catch-all route of a mounted sub-app, whose own stats are used instead
+func (a *App) mountRoute(path string) bool {
+	for _, m := range a.mounts {
+		if path == m.prefix || path == m.prefix+"/*fluxo_mount_path" {
+			return true
+		}
+	}
+	return false
+}
+
+// EnableStats serves Stats as JSON at path, e.g. /debug/stats. Pass typed=true as a query
+// parameter to list only fluxo handlers.
+func (a *App) EnableStats(path string) {
+	a.GET(path, func(ctx *gin.Context) {
+		stats := a.Stats()
+		if ctx.Query("typed") == "true" {
+			typed := stats[:0]
+			for _, rs := range stats {
+				if rs.Typed {
+					typed = append(typed, rs)
+				}
+			}
+			stats = typed
+		}
+		if stats == nil {
+			stats = []RouteStats{}
+		}
+		ctx.JSON(http.StatusOK, gin.H{"routes": stats})
+	})
+}
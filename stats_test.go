@@ -0,0 +1,148 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type statsReq struct {
+	ID string `uri:"id"`
+}
+
+type statsRes struct {
+	ID string `json:"id"`
+}
+
+func statsGet(ctx *Context, req statsReq) (statsRes, error) {
+	switch req.ID {
+	case "missing":
+		return statsRes{}, NotFound("no such item")
+	case "broken":
+		return statsRes{}, errors.New("boom")
+	}
+	return statsRes{ID: req.ID}, nil
+}
+
+func statsServe(app *App, method, path string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+	return w
+}
+
+func TestStatsCountsPerRoute(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := New()
+	app.GET("/items/:id", Handle(statsGet))
+	app.GET("/health", func(ctx *gin.Context) { ctx.Status(http.StatusNoContent) })
+
+	for _, id := range []string{"1", "2", "missing", "broken"} {
+		statsServe(app, http.MethodGet, "/items/"+id)
+	}
+	statsServe(app, http.MethodGet, "/health")
+	statsServe(app, http.MethodGet, "/nowhere")
+
+	stats := app.Stats()
+	if len(stats) != 2 {
+		t.Fatalf("expected 2 routes, got %+v", stats)
+	}
+	health, items := stats[0], stats[1]
+	if health.Path != "/health" || health.Typed || health.Count != 1 {
+		t.Fatalf("unexpected health stats %+v", health)
+	}
+	if items.Path != "/items/:id" || items.Method != http.MethodGet || !items.Typed {
+		t.Fatalf("unexpected route %+v", items)
+	}
+	if items.Count != 4 || items.ClientErrors != 1 || items.ServerErrors != 1 || items.ErrorRate() != 0.25 {
+		t.Fatalf("unexpected counts %+v", items)
+	}
+	var bucketed uint64
+	for _, n := range items.Buckets {
+		bucketed += n
+	}
+	if bucketed != 4 || items.Max < items.Mean() || items.Quantile(0.99) > items.Max {
+		t.Fatalf("inconsistent latency %+v", items)
+	}
+}
+
+func TestStatsQuantile(t *testing.T) {
+	s := RouteStats{Count: 10, Max: 40 * time.Millisecond, Buckets: make([]uint64, len(LatencyBuckets)+1)}
+	s.Buckets[0] = 5 // <= 5ms
+	s.Buckets[3] = 5 // 25ms..50ms, capped by Max at 40ms
+	if got := s.Quantile(0.5); got != 5*time.Millisecond {
+		t.Fatalf("p50 = %v", got)
+	}
+	if got := s.Quantile(1); got != 40*time.Millisecond {
+		t.Fatalf("p100 = %v", got)
+	}
+	if got := s.Quantile(0.8); got <= 25*time.Millisecond || got >= 40*time.Millisecond {
+		t.Fatalf("p80 = %v", got)
+	}
+	if (RouteStats{}).Quantile(0.5) != 0 {
+		t.Fatal("empty stats should report zero")
+	}
+}
+
+func TestStatsMountedApp(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	sub := New()
+	sub.GET("/items/:id", Handle(statsGet))
+	app := New()
+	app.MountApp("/v2", sub)
+
+	statsServe(app, http.MethodGet, "/v2/items/1")
+	stats := app.Stats()
+	if len(stats) != 1 || stats[0].Path != "/v2/items/:id" || !stats[0].Typed || stats[0].Count != 1 {
+		t.Fatalf("expected the sub-app route under its prefix, got %+v", stats)
+	}
+}
+
+func TestStatsEndpoint(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := New()
+	app.GET("/items/:id", Handle(statsGet))
+	app.GET("/health", func(ctx *gin.Context) { ctx.Status(http.StatusNoContent) })
+	app.EnableStats("/debug/stats")
+
+	statsServe(app, http.MethodGet, "/items/broken")
+	statsServe(app, http.MethodGet, "/health")
+
+	w := statsServe(app, http.MethodGet, "/debug/stats?typed=true")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status %d", w.Code)
+	}
+	var body struct {
+		Routes []struct {
+			Method       string  `json:"method"`
+			Path         string  `json:"path"`
+			Count        uint64  `json:"count"`
+			ServerErrors uint64  `json:"server_errors"`
+			ErrorRate    float64 `json:"error_rate"`
+			P95          float64 `json:"p95_ms"`
+			Buckets      []struct {
+				Le    string `json:"le"`
+				Count uint64 `json:"count"`
+			} `json:"buckets"`
+		} `json:"routes"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+	if len(body.Routes) != 1 {
+		t.Fatalf("expected only the typed route, got %s", w.Body.String())
+	}
+	r := body.Routes[0]
+	if r.Path != "/items/:id" || r.Count != 1 || r.ServerErrors != 1 || r.ErrorRate != 1 {
+		t.Fatalf("unexpected route %+v", r)
+	}
+	if len(r.Buckets) != len(LatencyBuckets)+1 || r.Buckets[len(r.Buckets)-1].Le != "+Inf" {
+		t.Fatalf("unexpected buckets %+v", r.Buckets)
+	}
+}
@@ -0,0 +1,382 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// Storage is where streamed uploads are written
+type Storage interface {
+	// Put stores the content of r under key; on error nothing may be left under key
+	Put(ctx context.Context, key string, r io.Reader) error
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// AppendStorage is a Storage whose objects can be extended, as needed by resumable uploads
+type AppendStorage interface {
+	Storage
+	// Append adds r to the end of key, creating it if needed, and returns the number of bytes written
+	Append(ctx context.Context, key string, r io.Reader) (int64, error)
+}
+
+// UploadedFile is a multipart file streamed to storage before the handler runs.
+// Request fields of type UploadedFile or []UploadedFile are filled from the parts named by their form tag:
+//
+//	type AvatarReq struct {
+//	    Avatar fluxo.UploadedFile `form:"avatar" validate:"required,maxsize=5MB,mime=image/png image/jpeg"`
+//	}
+//
+// maxsize and mime are enforced while streaming, so rejected files are never stored.
+type UploadedFile struct {
+	Field       string `json:"field"`
+	Filename    string `json:"filename"`
+	ContentType string `json:"contentType"`
+	Key         string `json:"key"`
+	Size        int64  `json:"size"`
+	Checksum    string `json:"checksum"` // hex SHA-256 of the content
+}
+
+// ErrNoUploadStorage is returned when a request with UploadedFile fields is bound without Uploads middleware
+var ErrNoUploadStorage = errors.New("no upload storage configured, add fluxo.Uploads middleware")
+
+var errFileTooLarge = errors.New("file too large")
+
+const uploadsKey = "fluxo.uploads"
+
+// maxUploadFieldSize limits non-file parts of a streamed multipart body; larger ones get 413
+const maxUploadFieldSize = 1 << 20
+
+type uploadConfig struct {
+	storage Storage
+	key     func(filename string) string
+	scanner FileScanner // see WithUploadScanner
+}
+
+// UploadOption configures Uploads
+type UploadOption func(*uploadConfig)
+
+// WithUploadKey sets how storage keys are derived from client file names; the default is a random name keeping the extension
+func WithUploadKey(fn func(filename string) string) UploadOption {
+	return func(c *uploadConfig) {
+		c.key = fn
+	}
+}
+
+// Uploads returns middleware streaming UploadedFile fields of later handlers to storage
+func Uploads(storage Storage, opts ...UploadOption) gin.HandlerFunc {
+	cfg := &uploadConfig{
+		storage: storage,
+		key: func(filename string) string {
+			return strings.ToLower(rand.Text() + filepath.Ext(filename))
+		},
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return func(ctx *gin.Context) {
+		ctx.Set(uploadsKey, cfg)
+		ctx.Next()
+	}
+}
+
+var (
+	uploadedFileType      = reflect.TypeOf(UploadedFile{})
+	uploadedFileSliceType = reflect.TypeOf([]UploadedFile{})
+)
+
+// isUploadField reports whether t is a file field, bound from multipart/form-data
+func isUploadField(t reflect.Type) bool {
+	if t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return isFileHeader(t) || t == uploadedFileType
+}
+
+// uploadFields maps the form names of UploadedFile fields in t to their field index
+func uploadFields(t reflect.Type) map[string]reflect.StructField {
+	if t == nil {
+		return nil
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	fields := map[string]reflect.StructField{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if embedded, ok := embeddedStruct(field); ok {
+			for name, f := range uploadFields(embedded) {
+				f.Index = append([]int{i}, f.Index...)
+				fields[name] = f
+			}
+			continue
+		}
+		name := strings.Split(field.Tag.Get("form"), ",")[0]
+		if name == "" || name == "-" || (field.Type != uploadedFileType && field.Type != uploadedFileSliceType) {
+			continue
+		}
+		fields[name] = field
+	}
+	return fields
+}
+
+// bindUploads streams the multipart body into storage, filling UploadedFile fields and form fields of obj
+func bindUploads(ctx *gin.Context, obj any, fields map[string]reflect.StructField) error {
+	cfg, ok := ctx.Value(uploadsKey).(*uploadConfig)
+	if !ok {
+		return ErrNoUploadStorage
+	}
+	reader, err := ctx.Request.MultipartReader()
+	if err != nil {
+		return err
+	}
+
+	target := reflect.ValueOf(obj).Elem()
+	if target.Kind() == reflect.Ptr {
+		if target.IsNil() {
+			target.Set(reflect.New(target.Type().Elem()))
+		}
+		target = target.Elem()
+	}
+
+	values := url.Values{}
+	var stored []UploadedFile
+	fail := func(err error) error {
+		discardUploads(ctx, cfg.storage, stored)
+		return err
+	}
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fail(err)
+		}
+
+		name := part.FormName()
+		field, isFile := fields[name]
+		if part.FileName() == "" || !isFile {
+			if part.FileName() == "" {
+				value, err := io.ReadAll(io.LimitReader(part, maxUploadFieldSize+1))
+				if err != nil {
+					return fail(err)
+				}
+				if len(value) > maxUploadFieldSize {
+					return fail(NewHTTPError(http.StatusRequestEntityTooLarge, fmt.Sprintf("Form field %q exceeds %d bytes", name, maxUploadFieldSize)))
+				}
+				values.Add(name, string(value))
+			}
+			_ = part.Close()
+			continue
+		}
+
+		file, err := storeUpload(ctx, cfg, field, name, part.FileName(), part.Header.Get("Content-Type"), part)
+		_ = part.Close()
+		if err != nil {
+			return fail(err)
+		}
+		stored = append(stored, file)
+		if err := scanStored(ctx, cfg, file); err != nil {
+			return fail(err)
+		}
+
+		dst := target.FieldByIndex(field.Index)
+		if dst.Kind() == reflect.Slice {
+			dst.Set(reflect.Append(dst, reflect.ValueOf(file)))
+		} else {
+			dst.Set(reflect.ValueOf(file))
+		}
+	}
+	ctx.Set(uploadsKey+".stored", stored)
+
+	if err := binding.MapFormWithTag(obj, values, "form"); err != nil {
+		return fail(err)
+	}
+	return nil
+}
+
+// storeUpload streams one file part to storage, enforcing the field's maxsize and mime rules
+func storeUpload(ctx *gin.Context, cfg *uploadConfig, field reflect.StructField, name, filename, declared string, r io.Reader) (UploadedFile, error) {
+	rules := field.Tag.Get("validate")
+	br := bufio.NewReaderSize(r, 512)
+	head, _ := br.Peek(512)
+
+	contentType, _, _ := mime.ParseMediaType(http.DetectContentType(head))
+	if contentType == "application/octet-stream" {
+		contentType, _, _ = mime.ParseMediaType(declared)
+	}
+	if types, ok := validationRule(rules, "mime"); ok && !mimeAllowed(contentType, strings.Fields(types)) {
+		return UploadedFile{}, fmt.Errorf("%s must be one of the file types %s", field.Name, types)
+	}
+
+	limit := int64(-1)
+	if size, ok := validationRule(rules, "maxsize"); ok {
+		n, err := parseFileSize(size)
+		if err != nil {
+			return UploadedFile{}, err
+		}
+		limit = n
+	}
+
+	hash := sha256.New()
+	counter := &limitedCounter{r: io.TeeReader(br, hash), limit: limit}
+	key := cfg.key(filename)
+	if err := cfg.storage.Put(ctx.Request.Context(), key, counter); err != nil {
+		if errors.Is(err, errFileTooLarge) {
+			return UploadedFile{}, fmt.Errorf("%s must be at most %s per file", field.Name, rulesParam(rules, "maxsize"))
+		}
+		return UploadedFile{}, err
+	}
+
+	return UploadedFile{
+		Field:       name,
+		Filename:    filepath.Base(filename),
+		ContentType: contentType,
+		Key:         key,
+		Size:        counter.n,
+		Checksum:    hex.EncodeToString(hash.Sum(nil)),
+	}, nil
+}
+
+func rulesParam(rules, rule string) string {
+	param, _ := validationRule(rules, rule)
+	return param
+}
+
+// limitedCounter counts bytes read, failing once more than limit were read (a negative limit is unbounded)
+type limitedCounter struct {
+	r     io.Reader
+	n     int64
+	limit int64
+}
+
+func (c *limitedCounter) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	if c.limit >= 0 && c.n > c.limit {
+		return n, errFileTooLarge
+	}
+	return n, err
+}
+
+// discardUploads deletes files stored for a request that will not reach its handler
+func discardUploads(ctx *gin.Context, storage Storage, files []UploadedFile) {
+	for _, f := range files {
+		_ = storage.Delete(context.WithoutCancel(ctx.Request.Context()), f.Key)
+	}
+}
+
+// discardRequestUploads deletes the files bound for the current request, if any
+func discardRequestUploads(ctx *gin.Context) {
+	cfg, ok := ctx.Value(uploadsKey).(*uploadConfig)
+	files, stored := ctx.Value(uploadsKey + ".stored").([]UploadedFile)
+	if ok && stored {
+		discardUploads(ctx, cfg.storage, files)
+	}
+}
+
+// LocalStorage stores uploads as files under a directory
+type LocalStorage struct {
+	dir string
+}
+
+// NewLocalStorage creates a LocalStorage rooted at dir
+func NewLocalStorage(dir string) *LocalStorage {
+	return &LocalStorage{dir: dir}
+}
+
+func (s *LocalStorage) path(key string) (string, error) {
+	if !filepath.IsLocal(filepath.FromSlash(key)) {
+		return "", fmt.Errorf("invalid storage key %q", key)
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(key)), nil
+}
+
+// Put writes r to a temporary file and moves it into place once complete
+func (s *LocalStorage) Put(ctx context.Context, key string, r io.Reader) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Append writes r to the end of the file for key; bytes written before an error are kept
+func (s *LocalStorage) Append(ctx context.Context, key string, r io.Reader) (int64, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return 0, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return 0, err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return 0, err
+	}
+	n, err := io.Copy(f, r)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return n, err
+}
+
+func (s *LocalStorage) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	return os.Open(path)
+}
+
+func (s *LocalStorage) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
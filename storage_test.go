@@ -0,0 +1,259 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type streamReq struct {
+	Title  string         `form:"title" validate:"required"`
+	Avatar UploadedFile   `form:"avatar" validate:"required,mime=image/png"`
+	Photos []UploadedFile `form:"photos" validate:"maxfiles=2,maxsize=1KB"`
+}
+
+type streamRes struct {
+	Title  string         `json:"title"`
+	Avatar UploadedFile   `json:"avatar"`
+	Photos []UploadedFile `json:"photos"`
+}
+
+func newStreamApp(t *testing.T) (*App, *LocalStorage, string) {
+	gin.SetMode(gin.TestMode)
+	dir := t.TempDir()
+	storage := NewLocalStorage(dir)
+	app := New().WithSwagger("Uploads", "1.0.0")
+	app.POST("/profile", Uploads(storage), Handle(func(ctx *Context, req streamReq) (streamRes, error) {
+		return streamRes(req), nil
+	}))
+	return app, storage, dir
+}
+
+type multipartPart struct {
+	name  string
+	value string
+	file  testFile
+}
+
+func buildMultipart(t *testing.T, parts []multipartPart) (io.Reader, string) {
+	t.Helper()
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	for _, p := range parts {
+		if p.file.name == "" {
+			_ = w.WriteField(p.name, p.value)
+			continue
+		}
+		h := textproto.MIMEHeader{}
+		h.Set("Content-Disposition", `form-data; name="`+p.name+`"; filename="`+p.file.name+`"`)
+		h.Set("Content-Type", p.file.contentType)
+		if p.file.digest != "" {
+			h.Set(HeaderContentMD5, p.file.digest)
+		}
+		part, err := w.CreatePart(h)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _ = part.Write(p.file.data)
+	}
+	_ = w.Close()
+	return &body, w.FormDataContentType()
+}
+
+func streamRequest(t *testing.T, title string, avatar testFile, photos ...testFile) *http.Request {
+	t.Helper()
+	var parts []multipartPart
+	if title != "" {
+		parts = append(parts, multipartPart{name: "title", value: title})
+	}
+	parts = append(parts, multipartPart{name: "avatar", file: avatar})
+	for _, p := range photos {
+		parts = append(parts, multipartPart{name: "photos", file: p})
+	}
+	body, ct := buildMultipart(t, parts)
+	req := httptest.NewRequest(http.MethodPost, "/profile", body)
+	req.Header.Set("Content-Type", ct)
+	return req
+}
+
+func TestUploads_StreamsToStorage(t *testing.T) {
+	app, storage, _ := newStreamApp(t)
+	png := testFile{name: "me.PNG", contentType: "image/png", data: pngHeader}
+
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, streamRequest(t, "hello", png, png))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status=%d body=%s", w.Code, w.Body.String())
+	}
+	var got streamRes
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256(pngHeader)
+	if got.Title != "hello" || got.Avatar.Size != int64(len(pngHeader)) || got.Avatar.Checksum != hex.EncodeToString(sum[:]) ||
+		got.Avatar.ContentType != "image/png" || got.Avatar.Filename != "me.PNG" || !strings.HasSuffix(got.Avatar.Key, ".png") {
+		t.Fatalf("unexpected result %+v", got)
+	}
+	if len(got.Photos) != 1 {
+		t.Fatalf("photos: %+v", got.Photos)
+	}
+
+	rc, err := storage.Open(context.Background(), got.Avatar.Key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rc.Close()
+	data, _ := io.ReadAll(rc)
+	if string(data) != string(pngHeader) {
+		t.Fatalf("stored %q", data)
+	}
+}
+
+func TestUploads_RejectsWithoutStoring(t *testing.T) {
+	app, _, dir := newStreamApp(t)
+	png := testFile{name: "a.png", contentType: "image/png", data: pngHeader}
+	tests := []struct {
+		name string
+		req  *http.Request
+		msg  string
+	}{
+		{"wrong type", streamRequest(t, "x", testFile{name: "a.png", contentType: "image/png", data: []byte("<html></html>")}), "file types"},
+		{"too large", streamRequest(t, "x", png, testFile{name: "b.png", contentType: "image/png", data: make([]byte, 2048)}), "at most 1KB"},
+		{"validation after storing", streamRequest(t, "", png), "Title is required"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			app.ServeHTTP(w, tt.req)
+			if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), tt.msg) {
+				t.Fatalf("status=%d body=%s", w.Code, w.Body.String())
+			}
+			entries, _ := os.ReadDir(dir)
+			if len(entries) != 0 {
+				t.Fatalf("expected no stored files, found %d", len(entries))
+			}
+		})
+	}
+}
+
+func TestUploads_DiscardedWhenBindingFails(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	dir := t.TempDir()
+	app := New()
+	app.POST("/avatars/:id", Uploads(NewLocalStorage(dir)), Handle(func(ctx *Context, req struct {
+		ID     int          `uri:"id"`
+		Resize int          `form:"resize"`
+		Retry  int          `header:"X-Retry"`
+		Avatar UploadedFile `form:"avatar"`
+	}) (UploadedFile, error) {
+		return req.Avatar, nil
+	}))
+	png := testFile{name: "a.png", contentType: "image/png", data: pngHeader}
+	tests := []struct {
+		name, target, header, msg string
+	}{
+		{"query", "/avatars/1?resize=big", "", "Query binding failed"},
+		{"path", "/avatars/me", "", "Path binding failed"},
+		{"header", "/avatars/1", "soon", "Header binding failed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, ct := buildMultipart(t, []multipartPart{{name: "avatar", file: png}})
+			req := httptest.NewRequest(http.MethodPost, tt.target, body)
+			req.Header.Set("Content-Type", ct)
+			if tt.header != "" {
+				req.Header.Set("X-Retry", tt.header)
+			}
+			w := httptest.NewRecorder()
+			app.ServeHTTP(w, req)
+			if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), tt.msg) {
+				t.Fatalf("status=%d body=%s", w.Code, w.Body.String())
+			}
+			if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+				t.Fatalf("expected no stored files, found %d", len(entries))
+			}
+		})
+	}
+}
+
+func TestUploads_RejectsOversizedField(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	dir := t.TempDir()
+	app := New()
+	app.POST("/avatars", Uploads(NewLocalStorage(dir)), Handle(func(ctx *Context, req struct {
+		Caption string       `form:"caption"`
+		Avatar  UploadedFile `form:"avatar"`
+	}) (string, error) {
+		return req.Caption, nil
+	}))
+
+	png := testFile{name: "a.png", contentType: "image/png", data: pngHeader}
+	body, ct := buildMultipart(t, []multipartPart{
+		{name: "avatar", file: png},
+		{name: "caption", value: strings.Repeat("x", maxUploadFieldSize+1)},
+	})
+	req := httptest.NewRequest(http.MethodPost, "/avatars", body)
+	req.Header.Set("Content-Type", ct)
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, req)
+	if w.Code != http.StatusRequestEntityTooLarge || !strings.Contains(w.Body.String(), `caption`) {
+		t.Fatalf("status=%d body=%.200s", w.Code, w.Body.String())
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Fatalf("expected no stored files, found %d", len(entries))
+	}
+}
+
+func TestUploads_RequiresStorage(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := New()
+	app.POST("/profile", Handle(func(ctx *Context, req streamReq) (streamReq, error) {
+		return req, nil
+	}))
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, streamRequest(t, "x", testFile{name: "a.png", contentType: "image/png", data: pngHeader}))
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("status=%d body=%s", w.Code, w.Body.String())
+	}
+}
+
+func TestUploads_Swagger(t *testing.T) {
+	app, _, _ := newStreamApp(t)
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	var spec OpenAPISpec
+	if err := json.Unmarshal(w.Body.Bytes(), &spec); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := spec.Paths["/profile"].POST.RequestBody.Content["multipart/form-data"]; !ok {
+		t.Fatalf("missing multipart body")
+	}
+	req := spec.Components.Schemas["streamReq"]
+	if req.Properties["avatar"].Format != "binary" || req.Properties["photos"].Items.Format != "binary" {
+		t.Fatalf("request schema: %+v", req.Properties)
+	}
+	if spec.Components.Schemas["UploadedFile"].Properties["checksum"].Type != "string" {
+		t.Fatalf("UploadedFile should be documented as an object in responses")
+	}
+}
+
+func TestLocalStorage_RejectsEscapingKeys(t *testing.T) {
+	storage := NewLocalStorage(t.TempDir())
+	if err := storage.Put(context.Background(), "../evil", strings.NewReader("x")); err == nil {
+		t.Fatal("expected error")
+	}
+}
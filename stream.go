@@ -0,0 +1,205 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// StreamErrorTrailer is the HTTP trailer naming the error that cut a Stream short. The status
+// is sent before the first element, so a failing stream can't change it; the array is left
+// unterminated too, so that clients can't take the elements received for the whole result.
+const StreamErrorTrailer = "X-Stream-Error"
+
+// A Stream flushes every defaultStreamFlush elements or streamFlushInterval, whichever comes first
+const (
+	defaultStreamFlush  = 100
+	streamFlushInterval = time.Second
+	streamBufferSize    = 32 << 10
+)
+
+// Stream is a typed handler response writing a JSON array one element at a time, as its
+// sequence yields them, so that exports of millions of rows never sit in memory. It is
+// documented as an array of T. Build one with StreamOf, StreamValues or StreamChan:
+//
+//	app.GET("/orders/export", fluxo.Handle(func(ctx *fluxo.Context, req ExportReq) (fluxo.Stream[Order], error) {
+//		rows, err := db.QueryContext(ctx, "SELECT ...")
+//		if err != nil {
+//			return fluxo.Stream[Order]{}, err
+//		}
+//		return fluxo.StreamOf(scanOrders(rows)), nil // an iter.Seq2[Order, error] closing rows when done
+//	}))
+//
+// The response is flushed every 100 elements (see FlushEvery) and at least every second. When
+// the client goes away, the sequence is stopped. An error yielded before the first element is
+// written like a handler's error; after it, see StreamErrorTrailer. When the client prefers
+// NDJSON under Produces(fluxo.NDJSONFormat), elements are written as lines instead. Envelopes,
+// sparse fieldsets and other formats don't apply to streams.
+type Stream[T any] struct {
+	seq        iter.Seq2[T, error]
+	flushEvery int
+}
+
+// StreamOf streams the elements of seq until it yields an error
+func StreamOf[T any](seq iter.Seq2[T, error]) Stream[T] {
+	return Stream[T]{seq: seq}
+}
+
+// StreamValues streams the elements of seq
+func StreamValues[T any](seq iter.Seq[T]) Stream[T] {
+	return StreamOf(func(yield func(T, error) bool) {
+		for v := range seq {
+			if !yield(v, nil) {
+				return
+			}
+		}
+	})
+}
+
+// StreamChan streams the values received from ch until it is closed. If the client goes away,
+// ch is no longer read, so its sender should give up once the request's context is done.
+func StreamChan[T any](ch <-chan T) Stream[T] {
+	return StreamOf(func(yield func(T, error) bool) {
+		for v := range ch {
+			if !yield(v, nil) {
+				return
+			}
+		}
+	})
+}
+
+// FlushEvery flushes the response to the client every n elements
+func (s Stream[T]) FlushEvery(n int) Stream[T] {
+	s.flushEvery = max(n, 1)
+	return s
+}
+
+// streamer lets the spec document a Stream as an array of its elements
+type streamer interface {
+	streamElem() reflect.Type
+}
+
+var streamerType = reflect.TypeOf((*streamer)(nil)).Elem()
+
+func (Stream[T]) streamElem() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// render writes the elements of the stream as they come
+func (s Stream[T]) render(ctx *gin.Context) {
+	marshal := json.Marshal
+	if codec, ok := requestCodec(ctx); ok {
+		marshal = codec.Marshal
+	}
+	ndjson := false
+	if f, ok := negotiateFormat(ctx); ok && f.MediaType == NDJSONFormat.MediaType {
+		ndjson = true
+	}
+	flushEvery := s.flushEvery
+	if flushEvery == 0 {
+		flushEvery = defaultStreamFlush
+	}
+
+	var w *bufio.Writer
+	start := func() {
+		contentType := "application/json; charset=utf-8"
+		if ndjson {
+			contentType = NDJSONFormat.MediaType
+		}
+		ctx.Header("Content-Type", contentType)
+		ctx.Header("Trailer", StreamErrorTrailer)
+		ctx.Header("X-Content-Type-Options", "nosniff")
+		ctx.Status(http.StatusOK)
+		w = bufio.NewWriterSize(ctx.Writer, streamBufferSize)
+		if !ndjson {
+			w.WriteByte('[')
+		}
+	}
+	flush := func() error {
+		if err := w.Flush(); err != nil {
+			return err
+		}
+		ctx.Writer.Flush()
+		return nil
+	}
+
+	done := ctx.Request.Context().Done()
+	n, flushed := 0, time.Now()
+	if s.seq != nil {
+		for v, err := range s.seq {
+			if err == nil {
+				var data []byte
+				if data, err = marshal(v); err != nil {
+					err = fmt.Errorf("encoding element %d: %w", n, err)
+				} else {
+					if w == nil {
+						start()
+					} else if !ndjson {
+						w.WriteByte(',')
+					}
+					w.Write(data)
+					if ndjson {
+						w.WriteByte('\n')
+					}
+				}
+			}
+			if err != nil {
+				s.fail(ctx, w, err)
+				return
+			}
+
+			n++
+			if n%flushEvery == 0 || time.Since(flushed) >= streamFlushInterval {
+				if flush() != nil {
+					return // the client went away
+				}
+				flushed = time.Now()
+			}
+			select {
+			case <-done:
+				return // stopping the sequence, like a failed flush
+			default:
+			}
+		}
+	}
+
+	if w == nil {
+		start()
+	}
+	if !ndjson {
+		w.WriteByte(']')
+	}
+	_ = flush()
+}
+
+// fail ends a stream on err: with an error response when nothing was written yet, otherwise
+// with the StreamErrorTrailer, after reporting err to the app's error hooks. Errors of a
+// sequence stopped by the client going away are dropped.
+func (s Stream[T]) fail(ctx *gin.Context, w *bufio.Writer, err error) {
+	if clientGone(ctx) {
+		if w == nil {
+			dropResponse(ctx)
+		}
+		return
+	}
+	if w == nil {
+		writeError(ctx, err)
+		return
+	}
+	_ = w.Flush()
+	ctx.Error(err)
+	if a, ok := appFrom(ctx); ok {
+		a.reportError(ctx, err)
+		a.Logger().Error("fluxo: stream failed", "path", ctx.Request.URL.Path, "error", err)
+	}
+	ctx.Writer.Header().Set(StreamErrorTrailer, strings.Join(strings.Fields(err.Error()), " "))
+}
@@ -0,0 +1,173 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type streamRowsReq struct {
+	N    int `form:"n"`
+	Fail int `form:"fail"` // index of the element failing, 0 for none
+}
+
+type streamRow struct {
+	ID int `json:"id"`
+}
+
+func streamRows(ctx *Context, req streamRowsReq) (Stream[streamRow], error) {
+	return StreamOf(func(yield func(streamRow, error) bool) {
+		for i := 1; i <= req.N; i++ {
+			if i == req.Fail {
+				yield(streamRow{}, NotFound("row gone"))
+				return
+			}
+			if !yield(streamRow{ID: i}, nil) {
+				return
+			}
+		}
+	}).FlushEvery(2), nil
+}
+
+func streamApp() *App {
+	gin.SetMode(gin.TestMode)
+	app := New()
+	app.GET("/rows", Produces(NDJSONFormat), Handle(streamRows))
+	return app
+}
+
+func TestStream_WritesArray(t *testing.T) {
+	app := streamApp()
+	for n, want := range map[int]string{0: `[]`, 1: `[{"id":1}]`, 3: `[{"id":1},{"id":2},{"id":3}]`} {
+		w := get(app, "/rows?n="+strconv.Itoa(n))
+		if w.Code != http.StatusOK || w.Body.String() != want {
+			t.Errorf("n=%d: %d %s, want %s", n, w.Code, w.Body, want)
+		}
+		if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "application/json") {
+			t.Errorf("n=%d: Content-Type %q", n, ct)
+		}
+		if !w.Flushed {
+			t.Errorf("n=%d: response not flushed", n)
+		}
+	}
+}
+
+func TestStream_NDJSON(t *testing.T) {
+	app := streamApp()
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/rows?n=2", nil)
+	req.Header.Set("Accept", "application/x-ndjson")
+	app.ServeHTTP(w, req)
+	if w.Body.String() != "{\"id\":1}\n{\"id\":2}\n" || w.Header().Get("Content-Type") != "application/x-ndjson" {
+		t.Errorf("unexpected NDJSON stream %q (%s)", w.Body, w.Header().Get("Content-Type"))
+	}
+}
+
+func TestStream_ErrorBeforeFirstElement(t *testing.T) {
+	app := streamApp()
+	w := get(app, "/rows?n=3&fail=1")
+	if w.Code != http.StatusNotFound || !strings.Contains(w.Body.String(), "row gone") {
+		t.Errorf("expected the error response, got %d %s", w.Code, w.Body)
+	}
+}
+
+func TestStream_ErrorMidStream(t *testing.T) {
+	app := streamApp()
+	var hooked error
+	app.OnError(func(_ *Context, err error) { hooked = err })
+
+	w := get(app, "/rows?n=5&fail=3")
+	if w.Code != http.StatusOK || w.Body.String() != `[{"id":1},{"id":2}` {
+		t.Fatalf("expected an unterminated array, got %d %s", w.Code, w.Body)
+	}
+	if got := w.Result().Trailer.Get(StreamErrorTrailer); !strings.Contains(got, "row gone") {
+		t.Errorf("trailer = %q", got)
+	}
+	if hooked == nil {
+		t.Error("the error hooks weren't called")
+	}
+}
+
+func TestStream_StopsWhenClientLeaves(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := New()
+	ctx, cancel := context.WithCancel(context.Background())
+	produced := 0
+	app.GET("/forever", Handle(func(*Context, struct{}) (Stream[int], error) {
+		return StreamOf(func(yield func(int, error) bool) {
+			for i := 0; ; i++ {
+				produced++
+				if i == 3 {
+					cancel()
+				}
+				if !yield(i, nil) {
+					return
+				}
+			}
+		}), nil
+	}))
+
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/forever", nil).WithContext(ctx))
+	if produced != 4 {
+		t.Errorf("produced %d elements, want the sequence stopped after 4", produced)
+	}
+}
+
+func TestStream_Channel(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := New()
+	app.GET("/names", Handle(func(*Context, struct{}) (Stream[string], error) {
+		ch := make(chan string, 2)
+		ch <- "ada"
+		ch <- "grace"
+		close(ch)
+		return StreamChan(ch), nil
+	}))
+	app.GET("/values", Handle(func(*Context, struct{}) (Stream[string], error) {
+		return StreamValues(slices.Values([]string{"x"})), nil
+	}))
+	if w := get(app, "/names"); w.Body.String() != `["ada","grace"]` {
+		t.Errorf("unexpected channel stream %s", w.Body)
+	}
+	if w := get(app, "/values"); w.Body.String() != `["x"]` {
+		t.Errorf("unexpected values stream %s", w.Body)
+	}
+}
+
+func TestStream_EncodingError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := New()
+	app.GET("/bad", Handle(func(*Context, struct{}) (Stream[any], error) {
+		return StreamValues(slices.Values([]any{func() {}})), nil
+	}))
+	w := get(app, "/bad")
+	if w.Code != http.StatusInternalServerError || !strings.Contains(w.Body.String(), "encoding element 0") {
+		t.Errorf("unexpected response %d %s", w.Code, w.Body)
+	}
+}
+
+func TestStream_DocumentedAsArray(t *testing.T) {
+	app := streamApp()
+	app.WithSwagger("Streams", "1.0")
+	spec, err := app.swagger.parsedSpec(app.specHandlers())
+	if err != nil {
+		t.Fatal(err)
+	}
+	res := spec.Paths["/rows"].GET.Responses["200"]
+	schema := res.Content["application/json"].Schema
+	if schema.Type != "array" || schema.Items == nil || (schema.Items.Ref == "" && schema.Items.Properties["id"].Type == "") {
+		t.Errorf("expected an array of rows, got %+v", schema)
+	}
+	if _, ok := res.Content["application/x-ndjson"]; !ok {
+		t.Errorf("NDJSON not documented: %+v", res.Content)
+	}
+}
@@ -5,18 +5,38 @@ package fluxo
 import (
 	"encoding/json"
 	"fmt"
-	"net/http"
+	"io/fs"
+	"maps"
 	"reflect"
+	"slices"
+	"strconv"
 	"strings"
-
-	"github.com/gin-gonic/gin"
+	"sync"
+	"unicode"
+	"unicode/utf8"
 )
 
 type OpenAPISpec struct {
 	OpenAPI    string              `json:"openapi"`
 	Info       OpenAPIInfo         `json:"info"`
+	Servers    []Server            `json:"servers,omitempty"`
 	Paths      map[string]PathItem `json:"paths"`
 	Components Components          `json:"components"`
+	Webhooks   map[string]PathItem `json:"x-webhooks,omitempty"` // OpenAPI 3.0 has no webhooks object yet
+	Tags       []Tag               `json:"tags,omitempty"`
+}
+
+// Server is a base URL the API is served at, absolute or relative to the spec's own URL
+type Server struct {
+	URL         string `json:"url" config:"url"`
+	Description string `json:"description,omitempty" config:"description"`
+}
+
+// Tag describes a tag of the spec's operations; Swagger UI groups operations by tag in the
+// order tags are listed
+type Tag struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
 }
 
 type OpenAPIInfo struct {
@@ -26,16 +46,21 @@ type OpenAPIInfo struct {
 }
 
 type PathItem struct {
-	POST   *Operation `json:"post,omitempty"`
-	GET    *Operation `json:"get,omitempty"`
-	PUT    *Operation `json:"put,omitempty"`
-	DELETE *Operation `json:"delete,omitempty"`
-	PATCH  *Operation `json:"patch,omitempty"`
+	POST    *Operation `json:"post,omitempty"`
+	GET     *Operation `json:"get,omitempty"`
+	PUT     *Operation `json:"put,omitempty"`
+	DELETE  *Operation `json:"delete,omitempty"`
+	PATCH   *Operation `json:"patch,omitempty"`
+	HEAD    *Operation `json:"head,omitempty"`
+	OPTIONS *Operation `json:"options,omitempty"`
 }
 
 type Operation struct {
+	Tags        []string            `json:"tags,omitempty"`
 	Summary     string              `json:"summary,omitempty"`
 	Description string              `json:"description,omitempty"`
+	OperationID string              `json:"operationId,omitempty"`
+	Deprecated  bool                `json:"deprecated,omitempty"`
 	Parameters  []Parameter         `json:"parameters,omitempty"`
 	RequestBody *RequestBody        `json:"requestBody,omitempty"`
 	Responses   map[string]Response `json:"responses"`
@@ -49,14 +74,34 @@ type RequestBody struct {
 
 type Response struct {
 	Description string               `json:"description"`
+	Headers     map[string]Header    `json:"headers,omitempty"`
 	Content     map[string]MediaType `json:"content,omitempty"`
 }
 
+type Header struct {
+	Description string `json:"description,omitempty"`
+	Schema      Schema `json:"schema"`
+}
+
 type MediaType struct {
-	Schema Schema `json:"schema"`
+	Schema   Schema              `json:"schema"`
+	Examples map[string]Example  `json:"examples,omitempty"`
+	Encoding map[string]Encoding `json:"encoding,omitempty"`
+}
+
+// Example is a named example payload of a media type, see RequestExample
+type Example struct {
+	Summary string `json:"summary,omitempty"`
+	Value   any    `json:"value"`
+}
+
+// Encoding describes a multipart property, such as the content types accepted for a file
+type Encoding struct {
+	ContentType string `json:"contentType,omitempty"`
 }
 
 type Schema struct {
+	Ref         string            `json:"$ref,omitempty"`
 	Type        string            `json:"type,omitempty"`
 	Properties  map[string]Schema `json:"properties,omitempty"`
 	Required    []string          `json:"required,omitempty"`
@@ -64,6 +109,17 @@ type Schema struct {
 	Format      string            `json:"format,omitempty"`
 	Description string            `json:"description,omitempty"`
 	Example     interface{}       `json:"example,omitempty"`
+	Enum        []interface{}     `json:"enum,omitempty"`
+	OneOf       []Schema          `json:"oneOf,omitempty"`
+	AllOf       []Schema          `json:"allOf,omitempty"`
+	Nullable    bool              `json:"nullable,omitempty"`
+	MaxItems    *int              `json:"maxItems,omitempty"`
+	MaxSize     int64             `json:"x-max-size,omitempty"` // maximum upload size in bytes
+	Scopes      []string          `json:"x-scopes,omitempty"`   // scopes a response field is returned to, see ResolveScopes
+	Views       []string          `json:"x-views,omitempty"`    // views a response field is part of, see View
+	PII         bool              `json:"x-pii,omitempty"`      // the field holds personal data, tagged pii:"true"
+
+	AdditionalProperties *Schema `json:"additionalProperties,omitempty"` // value schema of maps
 }
 
 type Components struct {
@@ -76,11 +132,36 @@ type Parameter struct {
 	Description string `json:"description,omitempty"`
 	Required    bool   `json:"required"`
 	Schema      Schema `json:"schema"`
+	Style       string `json:"style,omitempty"` // deepObject for nested query parameters
+	Explode     bool   `json:"explode,omitempty"`
 }
 
 type SwaggerGenerator struct {
 	spec      OpenAPISpec
 	pageTitle string
+	uiCDN     bool                   // load the UI from the CDN instead of embedded assets
+	uiFS      fs.FS                  // custom UI assets, overrides the embedded copy
+	uiConfig  map[string]interface{} // extra SwaggerUIBundle settings
+	uiCSS     string
+	uiLogo    string
+	webhooks  []*Webhooks
+	specHooks []func(*OpenAPISpec) // run on every rebuild, see App.OnSpec
+	namer     SchemaNamer
+	globals   []Parameter // documented on every operation
+	tags      []Tag       // declared with WithSwaggerTag, listed first
+
+	generateExamples bool // see WithGeneratedExamples
+	headOperations   bool // see WithHeadOperations
+
+	validateOnStart bool            // see WithSpecValidation
+	validators      []SpecValidator // run by App.Validate after the built-in checks
+
+	schemaNames map[reflect.Type]string // component name of each struct type
+	schemaTypes map[string]reflect.Type // struct type of each component name
+
+	mu     sync.Mutex
+	cached []byte       // serialized spec, nil until built or after Invalidate
+	parsed *OpenAPISpec // cached spec decoded, see parsedSpec
 }
 
 type SwaggerOption func(*SwaggerGenerator)
@@ -97,6 +178,58 @@ func WithSwaggerPageTitle(title string) SwaggerOption {
 	}
 }
 
+// SchemaNamer names the component schema of a struct type. Returning "" keeps the default name.
+type SchemaNamer func(t reflect.Type) string
+
+// WithSchemaNamer names component schemas with fn, e.g. to prefix them with their package.
+// Names that clash with another type's get a number appended.
+func WithSchemaNamer(fn SchemaNamer) SwaggerOption {
+	return func(sg *SwaggerGenerator) {
+		sg.namer = fn
+	}
+}
+
+// WithSwaggerTag describes tag name. Declared tags are listed in the order they were declared,
+// followed by the other tags of the operations in alphabetical order.
+func WithSwaggerTag(name, description string) SwaggerOption {
+	return func(sg *SwaggerGenerator) {
+		sg.tags = append(sg.tags, Tag{Name: name, Description: description})
+	}
+}
+
+// WithServer lists url in the spec's servers, which Swagger UI sends its requests to. Without
+// servers, clients use the host the spec was fetched from.
+//
+//	app.WithSwagger("API", "1.0",
+//		fluxo.WithServer("https://api.example.com", "Production"),
+//		fluxo.WithServer("https://staging-api.example.com", "Staging"),
+//	)
+func WithServer(url, description string) SwaggerOption {
+	return func(sg *SwaggerGenerator) {
+		sg.spec.Servers = append(sg.spec.Servers, Server{URL: url, Description: description})
+	}
+}
+
+// WithGlobalParameter documents p on every operation, e.g. a header that middleware requires.
+// Operations whose request types declare a parameter of the same name and location keep theirs.
+func WithGlobalParameter(p Parameter) SwaggerOption {
+	return func(sg *SwaggerGenerator) {
+		sg.globals = append(sg.globals, p)
+	}
+}
+
+// WithGlobalHeader documents the header name on every operation
+//
+//	app.WithSwagger("API", "1.0", fluxo.WithGlobalHeader("X-Tenant-ID", true, fluxo.Schema{Type: "string"}))
+func WithGlobalHeader(name string, required bool, schema Schema) SwaggerOption {
+	return WithGlobalParameter(Parameter{Name: name, In: "header", Required: required, Schema: schema})
+}
+
+// WithGlobalQuery documents the query parameter name on every operation
+func WithGlobalQuery(name string, required bool, schema Schema) SwaggerOption {
+	return WithGlobalParameter(Parameter{Name: name, In: "query", Required: required, Schema: schema})
+}
+
 func NewSwaggerGenerator(title, version string, opts ...SwaggerOption) *SwaggerGenerator {
 	sg := &SwaggerGenerator{
 		spec: OpenAPISpec{
@@ -124,18 +257,120 @@ func NewSwaggerGenerator(title, version string, opts ...SwaggerOption) *SwaggerG
 
 // Generate returns the OpenAPI spec as a map (for JSON serialization)
 func (sg *SwaggerGenerator) Generate(handlers map[string]handlerInfo) map[string]interface{} {
-	// Process all handlers to build the spec
-	for _, info := range handlers {
-		sg.AddEndpoint(info.method, info.path, info.reqTypes, info.resType, info.contentType)
-	}
-
-	// Convert to map for JSON serialization
 	result := make(map[string]interface{})
-	data, _ := json.Marshal(sg.spec)
+	data, err := sg.SpecJSON(handlers)
+	if err != nil {
+		return result
+	}
 	json.Unmarshal(data, &result)
 	return result
 }
 
+// SpecJSON returns the serialized spec for the given handlers. The spec is built
+// and marshaled once, then served from cache until Invalidate is called.
+func (sg *SwaggerGenerator) SpecJSON(handlers map[string]handlerInfo) ([]byte, error) {
+	sg.mu.Lock()
+	defer sg.mu.Unlock()
+	return sg.build(handlers)
+}
+
+// parsedSpec returns the spec as served, decoded once per rebuild. It must not be modified.
+func (sg *SwaggerGenerator) parsedSpec(handlers map[string]handlerInfo) (*OpenAPISpec, error) {
+	sg.mu.Lock()
+	defer sg.mu.Unlock()
+	data, err := sg.build(handlers)
+	if err != nil {
+		return nil, err
+	}
+	if sg.parsed == nil {
+		var spec OpenAPISpec
+		if err := json.Unmarshal(data, &spec); err != nil {
+			return nil, err
+		}
+		sg.parsed = &spec
+	}
+	return sg.parsed, nil
+}
+
+// build returns the cached spec, rebuilding it if needed; sg.mu must be held
+func (sg *SwaggerGenerator) build(handlers map[string]handlerInfo) ([]byte, error) {
+	if sg.cached != nil {
+		return sg.cached, nil
+	}
+	sg.parsed = nil
+
+	// Schemas are derived from the handlers, so start from a clean set on every rebuild
+	sg.spec.Components.Schemas = make(map[string]Schema)
+	sg.schemaNames, sg.schemaTypes = nil, nil
+	// Routes are visited in a fixed order, so clashing names are resolved the same way every time
+	for _, key := range slices.Sorted(maps.Keys(handlers)) {
+		info := handlers[key]
+		sg.AddEndpoint(info.method, info.path, info.reqTypes, info.resType, info.contentType)
+		if op := sg.operation(info.method, info.path); op != nil {
+			for _, doc := range info.docs {
+				doc(sg, op)
+			}
+			if len(op.Tags) == 0 && info.tag != "" {
+				op.Tags = []string{info.tag}
+			}
+		}
+	}
+	if sg.headOperations {
+		for _, info := range handlers {
+			if info.autoHead {
+				sg.documentHead(info.path)
+			}
+		}
+	}
+	sg.spec.Tags = sg.listTags()
+	for _, w := range sg.webhooks {
+		for _, ev := range w.Events() {
+			sg.AddWebhook(ev.Name, ev.Description, ev.PayloadType)
+		}
+	}
+	for _, fn := range sg.specHooks {
+		fn(&sg.spec)
+	}
+
+	data, err := json.Marshal(sg.spec)
+	if err != nil {
+		return nil, err
+	}
+	sg.cached = data
+	return data, nil
+}
+
+// listTags returns the declared tags followed by the other tags used by operations, sorted
+func (sg *SwaggerGenerator) listTags() []Tag {
+	tags := append([]Tag(nil), sg.tags...)
+	var used []string
+	for _, item := range sg.spec.Paths {
+		for _, op := range []*Operation{item.GET, item.POST, item.PUT, item.DELETE, item.PATCH, item.HEAD, item.OPTIONS} {
+			if op == nil {
+				continue
+			}
+			for _, tag := range op.Tags {
+				declared := slices.ContainsFunc(tags, func(t Tag) bool { return t.Name == tag })
+				if !declared && !slices.Contains(used, tag) {
+					used = append(used, tag)
+				}
+			}
+		}
+	}
+	slices.Sort(used)
+	for _, tag := range used {
+		tags = append(tags, Tag{Name: tag})
+	}
+	return tags
+}
+
+// Invalidate drops the cached spec so that the next request rebuilds it.
+func (sg *SwaggerGenerator) Invalidate() {
+	sg.mu.Lock()
+	sg.cached = nil
+	sg.mu.Unlock()
+}
+
 // detectSwaggerContentTypes analyzes struct tags to determine appropriate content types for swagger
 func (sg *SwaggerGenerator) detectSwaggerContentTypes(requestType reflect.Type) []string {
 	if requestType == nil || requestType.Kind() != reflect.Struct {
@@ -159,8 +394,7 @@ func (sg *SwaggerGenerator) detectSwaggerContentTypes(requestType reflect.Type)
 		}
 
 		// Check for file upload fields
-		if field.Type.String() == "*multipart.FileHeader" ||
-			field.Type.String() == "[]*multipart.FileHeader" {
+		if isUploadField(field.Type) {
 			hasFile = true
 		}
 	}
@@ -202,6 +436,12 @@ func (sg *SwaggerGenerator) generateParameters(requestType reflect.Type, path st
 	for i := 0; i < requestType.NumField(); i++ {
 		field := requestType.Field(i)
 
+		// Embedded structs (e.g. PageRequest) contribute their own parameters
+		if embedded, ok := embeddedStruct(field); ok {
+			parameters = append(parameters, sg.generateParameters(embedded, path)...)
+			continue
+		}
+
 		// Check for path parameters (uri tags in gin)
 		if uriTag := field.Tag.Get("uri"); uriTag != "" && uriTag != "-" {
 			paramName := strings.Split(uriTag, ",")[0]
@@ -215,6 +455,12 @@ func (sg *SwaggerGenerator) generateParameters(requestType reflect.Type, path st
 				Required: true, // Path parameters are always required
 				Schema:   sg.generateSchema(field.Type),
 			}
+			if wildcardParam(path, paramName) {
+				param.Description = "Rest of the path, slashes included"
+			}
+			applyOneOf(&param.Schema, field.Type, fieldRules(field))
+			param.Schema.PII = isPII(field)
+			sg.generateExample(&param.Schema, paramName, fieldRules(field))
 
 			parameters = append(parameters, param)
 			continue
@@ -235,11 +481,14 @@ func (sg *SwaggerGenerator) generateParameters(requestType reflect.Type, path st
 			}
 
 			// Check if field is required based on validation tags
-			if validateTag := field.Tag.Get("validate"); validateTag != "" {
+			if validateTag := fieldRules(field); validateTag != "" {
 				if strings.Contains(validateTag, "required") {
 					param.Required = true
 				}
+				applyOneOf(&param.Schema, field.Type, validateTag)
 			}
+			param.Schema.PII = isPII(field)
+			sg.generateExample(&param.Schema, paramName, fieldRules(field))
 
 			parameters = append(parameters, param)
 			continue
@@ -263,13 +512,23 @@ func (sg *SwaggerGenerator) generateParameters(requestType reflect.Type, path st
 				Required: false, // Query params are typically optional
 				Schema:   sg.generateSchema(field.Type),
 			}
+			// Structs and maps are sent as filter[status]=done, or filter.status=done
+			if nestedQueryType(field.Type) {
+				param.Style, param.Explode = "deepObject", true
+			}
+			if aliases := tagList(field, "alias"); len(aliases) > 0 {
+				param.Description = "Also accepted as " + strings.Join(aliases, ", ")
+			}
 
 			// Check if field is required based on validation tags
-			if validateTag := field.Tag.Get("validate"); validateTag != "" {
+			if validateTag := fieldRules(field); validateTag != "" {
 				if strings.Contains(validateTag, "required") {
 					param.Required = true
 				}
+				applyOneOf(&param.Schema, field.Type, validateTag)
 			}
+			param.Schema.PII = isPII(field)
+			sg.generateExample(&param.Schema, paramName, fieldRules(field))
 
 			parameters = append(parameters, param)
 		}
@@ -278,19 +537,54 @@ func (sg *SwaggerGenerator) generateParameters(requestType reflect.Type, path st
 	return parameters
 }
 
-// extractPathParameters extracts parameter names from path like /users/:id -> [id]
+// nestedQueryType reports whether a query field of type t is bound from nested keys
+func nestedQueryType(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if reflect.PointerTo(t).Implements(bindUnmarshalerType) {
+		return false
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		return t != timeType && t != fileHeaderType
+	case reflect.Map:
+		return t.Key().Kind() == reflect.String && scalarKind(t.Elem().Kind())
+	}
+	return false
+}
+
+// extractPathParameters extracts parameter names from path like /users/:id -> [id], catch-all
+// ones included (/files/*filepath -> [filepath])
 func extractPathParameters(path string) []string {
 	var params []string
 	parts := strings.Split(path, "/")
 	for _, part := range parts {
-		if strings.HasPrefix(part, ":") {
-			paramName := strings.TrimPrefix(part, ":")
-			params = append(params, paramName)
+		if strings.HasPrefix(part, ":") || strings.HasPrefix(part, "*") {
+			params = append(params, part[1:])
 		}
 	}
 	return params
 }
 
+// openAPIPath turns a route path into an OpenAPI path template: /users/:id -> /users/{id} and
+// /files/*filepath -> /files/{filepath}
+func openAPIPath(path string) string {
+	parts := strings.Split(path, "/")
+	for i, part := range parts {
+		if strings.HasPrefix(part, ":") || strings.HasPrefix(part, "*") {
+			parts[i] = "{" + part[1:] + "}"
+		}
+	}
+	return strings.Join(parts, "/")
+}
+
+// wildcardParam reports whether name is the catch-all parameter of path, whose value spans the
+// rest of the path
+func wildcardParam(path, name string) bool {
+	return strings.HasSuffix(path, "/*"+name)
+}
+
 // contains checks if a string slice contains a specific string
 func contains(slice []string, item string) bool {
 	for _, s := range slice {
@@ -306,14 +600,6 @@ func (sg *SwaggerGenerator) AddEndpoint(method, path string, requestTypes []refl
 	operation := &Operation{
 		Summary: fmt.Sprintf("%s %s", method, path),
 		Responses: map[string]Response{
-			"200": {
-				Description: "Success",
-				Content: map[string]MediaType{
-					"application/json": {
-						Schema: sg.generateSchema(responseType),
-					},
-				},
-			},
 			"400": {
 				Description: "Bad Request",
 				Content: map[string]MediaType{
@@ -331,6 +617,8 @@ func (sg *SwaggerGenerator) AddEndpoint(method, path string, requestTypes []refl
 		},
 	}
 
+	operation.Responses[successStatus(responseType)] = sg.successResponse(responseType)
+
 	if len(requestTypes) > 0 {
 		// All methods can have parameters (path or query)
 		for _, rt := range requestTypes {
@@ -347,13 +635,26 @@ func (sg *SwaggerGenerator) AddEndpoint(method, path string, requestTypes []refl
 
 			// Merge content types and schemas from all request types
 			for _, rt := range requestTypes {
+				// Patch requests accept merge patches and JSON Patch documents for the target type
+				if target, ok := patchTargetOf(rt); ok {
+					targetSchema := sg.generateSchema(target)
+					operation.RequestBody.Content[MIMEMergePatch] = MediaType{Schema: targetSchema}
+					operation.RequestBody.Content[MIMEJSONPatch] = MediaType{Schema: jsonPatchSchema}
+					operation.RequestBody.Content["application/json"] = MediaType{Schema: targetSchema}
+					continue
+				}
+
 				cts := sg.detectSwaggerContentTypes(rt)
 				schema := sg.generateSchema(rt)
 
 				for _, ct := range cts {
 					existing, exists := operation.RequestBody.Content[ct]
 					if !exists {
-						operation.RequestBody.Content[ct] = MediaType{Schema: schema}
+						media := MediaType{Schema: schema}
+						if ct == "multipart/form-data" {
+							media.Encoding = fileEncodings(rt)
+						}
+						operation.RequestBody.Content[ct] = media
 					} else {
 						// Merge schemas if they are objects
 						if existing.Schema.Type == "object" && schema.Type == "object" {
@@ -372,7 +673,30 @@ func (sg *SwaggerGenerator) AddEndpoint(method, path string, requestTypes []refl
 		}
 	}
 
-	pathItem, exists := sg.spec.Paths[path]
+	// GET responses can be narrowed with a sparse fieldset
+	if method == "GET" && FieldsQueryParam != "" && acceptsFieldset(responseType) && !hasParameter(operation.Parameters, FieldsQueryParam, "query") {
+		operation.Parameters = append(operation.Parameters, Parameter{
+			Name:        FieldsQueryParam,
+			In:          "query",
+			Description: "Comma separated list of fields to include in the response, e.g. id,owner.name",
+			Schema:      Schema{Type: "string"},
+		})
+	}
+
+	// and those of types with view fields can select a view
+	if method == "GET" && ViewQueryParam != "" && !hasParameter(operation.Parameters, ViewQueryParam, "query") {
+		if views := taggedNodeFor(responseType, "view").values(); len(views) > 0 {
+			operation.Parameters = append(operation.Parameters, viewParameter(views))
+		}
+	}
+
+	for _, p := range sg.globals {
+		if !hasParameter(operation.Parameters, p.Name, p.In) {
+			operation.Parameters = append(operation.Parameters, p)
+		}
+	}
+
+	pathItem, exists := sg.spec.Paths[openAPIPath(path)]
 	if !exists {
 		pathItem = PathItem{}
 	}
@@ -388,9 +712,181 @@ func (sg *SwaggerGenerator) AddEndpoint(method, path string, requestTypes []refl
 		pathItem.DELETE = operation
 	case "PATCH":
 		pathItem.PATCH = operation
+	case "HEAD":
+		pathItem.HEAD = operation
+	case "OPTIONS":
+		pathItem.OPTIONS = operation
+	}
+
+	sg.spec.Paths[openAPIPath(path)] = pathItem
+}
+
+// successStatus returns the documented success code, honouring response types that implement
+// StatusCoder. A Redirection may use any 3xx code.
+func successStatus(t reflect.Type) string {
+	if t == redirectionType {
+		return "3XX"
+	}
+	if t != nil && t.Implements(statusCoderType) {
+		if sc, ok := reflect.Zero(t).Interface().(StatusCoder); ok {
+			return strconv.Itoa(sc.StatusCode())
+		}
 	}
+	return "200"
+}
 
-	sg.spec.Paths[path] = pathItem
+// successResponse documents the success body and any headers declared by the response type
+func (sg *SwaggerGenerator) successResponse(t reflect.Type) Response {
+	if t == redirectionType {
+		return Response{Description: "Redirect", Headers: Redirection{}.responseHeaders()}
+	}
+	schema := Schema{}
+	if t != nil && t.Implements(streamerType) {
+		items := sg.generateSchema(reflect.Zero(t).Interface().(streamer).streamElem())
+		schema = Schema{Type: "array", Items: &items}
+	} else {
+		schema = sg.generateSchema(t)
+	}
+	res := Response{
+		Description: "Success",
+		Content: map[string]MediaType{
+			"application/json": {
+				Schema: schema,
+			},
+		},
+	}
+	if t != nil && t.Implements(headerDocType) {
+		if hd, ok := reflect.Zero(t).Interface().(headerDoc); ok {
+			res.Headers = hd.responseHeaders()
+		}
+	}
+	if t != nil && t.Implements(versionedType) {
+		if res.Headers == nil {
+			res.Headers = map[string]Header{}
+		}
+		res.Headers["ETag"] = Header{Description: "Version of the resource, to send in If-Match when updating it", Schema: Schema{Type: "string"}}
+	}
+	if localizedNodeFor(t) != nil {
+		if res.Headers == nil {
+			res.Headers = map[string]Header{}
+		}
+		res.Headers["Content-Language"] = Header{Description: "Language of the localized fields, negotiated with Accept-Language", Schema: Schema{Type: "string"}}
+	}
+	return res
+}
+
+// acceptsFieldset reports whether responses of type t are JSON objects or arrays that can be filtered
+func acceptsFieldset(t reflect.Type) bool {
+	if t == nil || t == redirectionType || t.Implements(streamerType) {
+		return false
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Struct, reflect.Map, reflect.Slice, reflect.Array:
+		return true
+	}
+	return false
+}
+
+// hasParameter reports whether params already declares name in the given location
+func hasParameter(params []Parameter, name, in string) bool {
+	for _, p := range params {
+		// Header names are case-insensitive
+		if p.In == in && (p.Name == name || in == "header" && strings.EqualFold(p.Name, name)) {
+			return true
+		}
+	}
+	return false
+}
+
+// AddWebhookSource documents the events registered on w each time the spec is built
+func (sg *SwaggerGenerator) AddWebhookSource(w *Webhooks) {
+	sg.mu.Lock()
+	sg.webhooks = append(sg.webhooks, w)
+	sg.cached = nil
+	sg.mu.Unlock()
+}
+
+// AddWebhook documents an outgoing webhook event delivered as a WebhookEnvelope around payloadType
+func (sg *SwaggerGenerator) AddWebhook(name, description string, payloadType reflect.Type) {
+	if sg.spec.Webhooks == nil {
+		sg.spec.Webhooks = make(map[string]PathItem)
+	}
+
+	envelope := Schema{
+		Type:     "object",
+		Required: []string{"id", "event", "created_at", "data"},
+		Properties: map[string]Schema{
+			"id":         {Type: "string"},
+			"event":      {Type: "string", Example: name},
+			"created_at": {Type: "string", Format: "date-time"},
+			"data":       sg.generateSchema(payloadType),
+		},
+	}
+	signature := Parameter{
+		Name:        WebhookSignatureHeader,
+		In:          "header",
+		Description: "sha256=<hex HMAC-SHA256 of \"<timestamp>.<body>\" keyed by the subscription secret>",
+		Required:    true,
+		Schema:      Schema{Type: "string"},
+	}
+	timestamp := Parameter{Name: WebhookTimestampHeader, In: "header", Required: true, Schema: Schema{Type: "string"}}
+
+	sg.spec.Webhooks[name] = PathItem{
+		POST: &Operation{
+			Summary:     name,
+			Description: description,
+			Parameters:  []Parameter{signature, timestamp},
+			RequestBody: &RequestBody{
+				Content:  map[string]MediaType{"application/json": {Schema: envelope}},
+				Required: true,
+			},
+			Responses: map[string]Response{
+				"2XX": {Description: "Delivery acknowledged"},
+				"5XX": {Description: "Delivery is retried with exponential backoff"},
+			},
+		},
+	}
+}
+
+// operation returns the documented operation for method and path, or nil
+func (sg *SwaggerGenerator) operation(method, path string) *Operation {
+	return sg.spec.Paths[openAPIPath(path)].operation(method)
+}
+
+// operationMethod returns the method op is documented under, or "" when it isn't in the spec
+func (sg *SwaggerGenerator) operationMethod(op *Operation) string {
+	for _, item := range sg.spec.Paths {
+		for _, method := range []string{"GET", "POST", "PUT", "DELETE", "PATCH", "HEAD", "OPTIONS"} {
+			if item.operation(method) == op {
+				return method
+			}
+		}
+	}
+	return ""
+}
+
+// operation returns the item's operation for method, or nil
+func (item PathItem) operation(method string) *Operation {
+	switch method {
+	case "POST":
+		return item.POST
+	case "GET":
+		return item.GET
+	case "PUT":
+		return item.PUT
+	case "DELETE":
+		return item.DELETE
+	case "PATCH":
+		return item.PATCH
+	case "HEAD":
+		return item.HEAD
+	case "OPTIONS":
+		return item.OPTIONS
+	}
+	return nil
 }
 
 func (sg *SwaggerGenerator) generateSchema(t reflect.Type) Schema {
@@ -401,14 +897,51 @@ func (sg *SwaggerGenerator) generateSchema(t reflect.Type) Schema {
 		t = t.Elem()
 	}
 
+	if schema, ok := providedSchema(t); ok {
+		return schema
+	}
+
+	if schema, ok := wellKnownSchema(t); ok {
+		return schema
+	}
+
 	if isFileHeader(t) {
 		return Schema{Type: "string", Format: "binary"}
 	}
 
+	// Localized[T] is documented as the T responses hold
+	if elem, ok := localizedElem(t); ok {
+		schema := sg.generateSchema(elem)
+		if schema.Ref != "" {
+			schema = Schema{AllOf: []Schema{schema}}
+		}
+		schema.Description = "In the language negotiated with Accept-Language, see Content-Language"
+		return schema
+	}
+
+	// Optional[T] is documented as a nullable T
+	if elem, ok := optionalElem(t); ok {
+		schema := sg.generateSchema(elem)
+		// Siblings of a $ref are ignored, so the reference is wrapped
+		if schema.Ref != "" {
+			schema = Schema{AllOf: []Schema{schema}}
+		}
+		schema.Nullable = true
+		return schema
+	}
+
+	// Enumer types list their values
+	if values, ok := enumValues(t); ok {
+		schema := sg.generateSchema(reflect.TypeOf(plainEnumValue(reflect.Zero(t))))
+		schema.Enum = values
+		return schema
+	}
+
 	switch t.Kind() {
 	case reflect.String:
 		return Schema{Type: "string"}
-	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
 		return Schema{Type: "integer", Format: "int64"}
 	case reflect.Float32, reflect.Float64:
 		return Schema{Type: "number", Format: "double"}
@@ -426,38 +959,264 @@ func (sg *SwaggerGenerator) generateSchema(t reflect.Type) Schema {
 		}
 		itemSchema := sg.generateSchema(it)
 		return Schema{Type: "array", Items: &itemSchema}
+	case reflect.Map:
+		// JSON objects only have string keys, other key types are encoded as text
+		valueSchema := sg.generateSchema(t.Elem())
+		return Schema{Type: "object", AdditionalProperties: &valueSchema}
+	case reflect.Interface:
+		return freeFormSchema()
 	default:
 		return Schema{Type: "object"}
 	}
 }
 
+var rawMessageType = reflect.TypeOf(json.RawMessage{})
+
+// wellKnownSchema documents types whose JSON encoding differs from their Go shape
+func wellKnownSchema(t reflect.Type) (Schema, bool) {
+	switch {
+	case t == timeType:
+		return Schema{Type: "string", Format: "date-time"}, true
+	case t == rawMessageType:
+		return freeFormSchema(), true
+	// uuid.UUID of github.com/google/uuid, gofrs/uuid and the like encode as text
+	case t.Name() == "UUID" && t.Kind() == reflect.Array && t.Len() == 16 && t.Elem().Kind() == reflect.Uint8:
+		return Schema{Type: "string", Format: "uuid"}, true
+	// Byte slices are encoded as base64
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8:
+		return Schema{Type: "string", Format: "byte"}, true
+	}
+	return Schema{}, false
+}
+
+// freeFormSchema accepts any JSON object
+func freeFormSchema() Schema {
+	return Schema{Type: "object", AdditionalProperties: &Schema{}}
+}
+
+// SchemaProvider is implemented by types that document their own schema, like json.Marshaler
+// lets them encode themselves. Use it for types that encode differently from their Go shape,
+// such as decimals and UUIDs sent as strings, or unions:
+//
+//	func (Decimal) OpenAPISchema() fluxo.Schema {
+//	    return fluxo.Schema{Type: "string", Format: "decimal", Example: "12.50"}
+//	}
+//
+// The method may have a value or a pointer receiver and is called on the zero value.
+type SchemaProvider interface {
+	OpenAPISchema() Schema
+}
+
+var schemaProviderType = reflect.TypeOf((*SchemaProvider)(nil)).Elem()
+
+// providedSchema returns the schema of t when it implements SchemaProvider
+func providedSchema(t reflect.Type) (Schema, bool) {
+	switch {
+	case t.Kind() != reflect.Interface && t.Implements(schemaProviderType):
+		return reflect.Zero(t).Interface().(SchemaProvider).OpenAPISchema(), true
+	case reflect.PointerTo(t).Implements(schemaProviderType):
+		return reflect.New(t).Interface().(SchemaProvider).OpenAPISchema(), true
+	}
+	return Schema{}, false
+}
+
 func isFileHeader(t reflect.Type) bool {
 	return t.PkgPath() == "mime/multipart" && t.Name() == "FileHeader"
 }
 
+// schemaName returns the component name for t, turning generic instantiations such as
+// Page[github.com/acme/shop.Product] into PageOfProduct
+func schemaName(t reflect.Type) string {
+	return typeNameFromString(t.Name(), false)
+}
+
+// componentName returns the unique component name of struct type t, "" for anonymous structs.
+// A name already taken by another type is qualified with t's package (billing.Invoice, then
+// v2.billing.Invoice), and numbered when that isn't enough.
+func (sg *SwaggerGenerator) componentName(t reflect.Type) string {
+	if name, ok := sg.schemaNames[t]; ok {
+		return name
+	}
+	if sg.schemaNames == nil {
+		sg.schemaNames = map[reflect.Type]string{}
+		sg.schemaTypes = map[string]reflect.Type{}
+	}
+
+	base := ""
+	if sg.namer != nil {
+		base = sanitizeSchemaName(sg.namer(t))
+	}
+	candidates := []string{base}
+	if base == "" {
+		if base = sanitizeSchemaName(schemaName(t)); base == "" {
+			return ""
+		}
+		candidates = []string{base}
+		if pkg := t.PkgPath(); pkg != "" {
+			segments := strings.Split(sanitizeSchemaName(strings.ReplaceAll(pkg, "/", ".")), ".")
+			for i := len(segments) - 1; i >= 0; i-- {
+				candidates = append(candidates, strings.Join(segments[i:], ".")+"."+base)
+			}
+		}
+	}
+	name := ""
+	for _, candidate := range candidates {
+		if _, taken := sg.schemaTypes[candidate]; !taken {
+			name = candidate
+			break
+		}
+	}
+	for n := 2; name == ""; n++ {
+		if _, taken := sg.schemaTypes[base+strconv.Itoa(n)]; !taken {
+			name = base + strconv.Itoa(n)
+		}
+	}
+	sg.schemaNames[t] = name
+	sg.schemaTypes[name] = t
+	return name
+}
+
+// sanitizeSchemaName replaces the characters OpenAPI doesn't allow in component names
+func sanitizeSchemaName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r < utf8.RuneSelf && (r == '.' || r == '-' || r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+// typeNameFromString builds a schema name from a reflect type name; type arguments are capitalized
+func typeNameFromString(name string, capitalize bool) string {
+	switch {
+	case strings.HasPrefix(name, "*"):
+		return typeNameFromString(name[1:], capitalize)
+	case strings.HasPrefix(name, "[]"):
+		return "ListOf" + typeNameFromString(name[2:], true)
+	case strings.HasPrefix(name, "map["):
+		if _, value, ok := splitBracket(name[3:]); ok {
+			return "MapOf" + typeNameFromString(value, true)
+		}
+	}
+
+	open := strings.Index(name, "[")
+	base := name
+	if open >= 0 {
+		base = name[:open]
+	}
+	if dot := strings.LastIndex(base, "."); dot >= 0 {
+		base = base[dot+1:]
+	}
+	// Function-local types are numbered in type arguments, as in Page[acme.Product·1]
+	if local := strings.Index(base, "·"); local >= 0 {
+		base = base[:local]
+	}
+	if capitalize && base != "" {
+		base = strings.ToUpper(base[:1]) + base[1:]
+	}
+	if open < 0 || !strings.HasSuffix(name, "]") {
+		return base
+	}
+
+	args := splitTypeArgs(name[open+1 : len(name)-1])
+	for i, arg := range args {
+		args[i] = typeNameFromString(arg, true)
+	}
+	return base + "Of" + strings.Join(args, "And")
+}
+
+// splitBracket splits "[K]V" into K and V
+func splitBracket(s string) (string, string, bool) {
+	depth := 0
+	for i, r := range s {
+		switch r {
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				return s[1:i], s[i+1:], true
+			}
+		}
+	}
+	return "", "", false
+}
+
+// splitTypeArgs splits a generic argument list on top-level commas
+func splitTypeArgs(s string) []string {
+	var args []string
+	depth, start := 0, 0
+	for i, r := range s {
+		switch r {
+		case '[':
+			depth++
+		case ']':
+			depth--
+		case ',':
+			if depth == 0 {
+				args = append(args, strings.TrimSpace(s[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	return append(args, strings.TrimSpace(s[start:]))
+}
+
 func (sg *SwaggerGenerator) generateStructSchema(t reflect.Type) Schema {
-	schemaName := t.Name()
+	schemaName := sg.componentName(t)
+	// Anonymous structs have no component to refer to, nor a name to refer to themselves by
 	if schemaName == "" {
-		schemaName = "Anonymous"
+		return sg.objectSchema(t)
 	}
 
-	// Check if we already have this schema
+	// Types seen before refer to their component, so do recursive types still being generated
 	if _, ok := sg.spec.Components.Schemas[schemaName]; ok {
-		return Schema{Description: "Reference to " + schemaName} // Should ideally use $ref
+		return schemaRef(schemaName)
 	}
 
-	// Set a placeholder to prevent infinite recursion
-	sg.spec.Components.Schemas[schemaName] = Schema{Type: "object", Description: "Circular reference"}
+	// Types built with cmd/fluxogen carry their schema
+	if schema, ok := generatedSchema(t); ok {
+		sg.spec.Components.Schemas[schemaName] = schema
+		return schema
+	}
+
+	// Claim the name first, so references back to the type stop at a $ref
+	sg.spec.Components.Schemas[schemaName] = Schema{Type: "object"}
+
+	schema := sg.objectSchema(t)
 
+	// Store the schema for reuse
+	sg.spec.Components.Schemas[schemaName] = schema
+
+	return schema
+}
+
+// objectSchema returns the schema listing the fields of struct t
+func (sg *SwaggerGenerator) objectSchema(t reflect.Type) Schema {
 	schema := Schema{
 		Type:       "object",
 		Properties: make(map[string]Schema),
 		Required:   []string{},
 	}
+	sg.addStructProperties(&schema, t)
+	return schema
+}
 
+// schemaRef returns a reference to the component schema called name
+func schemaRef(name string) Schema {
+	return Schema{Ref: "#/components/schemas/" + name}
+}
+
+// addStructProperties adds the fields of t to schema, flattening embedded structs
+func (sg *SwaggerGenerator) addStructProperties(schema *Schema, t reflect.Type) {
 	for i := 0; i < t.NumField(); i++ {
 		field := t.Field(i)
 
+		if embedded, ok := embeddedStruct(field); ok {
+			sg.addStructProperties(schema, embedded)
+			continue
+		}
+
 		// Try to get field name from json tag first, then form tag
 		fieldName := ""
 		jsonTag := field.Tag.Get("json")
@@ -474,27 +1233,54 @@ func (sg *SwaggerGenerator) generateStructSchema(t reflect.Type) Schema {
 		}
 
 		fieldSchema := sg.generateSchema(field.Type)
+		// Streamed uploads are sent as files
+		switch field.Type {
+		case uploadedFileType:
+			fieldSchema = Schema{Type: "string", Format: "binary"}
+		case uploadedFileSliceType:
+			fieldSchema = Schema{Type: "array", Items: &Schema{Type: "string", Format: "binary"}}
+		}
 
 		// Add validation info
-		if validateTag := field.Tag.Get("validate"); validateTag != "" {
+		if validateTag := fieldRules(field); validateTag != "" {
 			fieldSchema.Description = "Validation: " + validateTag
 
 			// Parse basic validation rules
 			if strings.Contains(validateTag, "email") {
 				fieldSchema.Format = "email"
 			}
-			if strings.Contains(validateTag, "required") {
+			applyFileConstraints(&fieldSchema, validateTag)
+			applyOneOf(&fieldSchema, field.Type, validateTag)
+			// Optional fields may always be omitted, their rules only apply to present values
+			if _, optional := optionalElem(field.Type); strings.Contains(validateTag, "required") && !optional {
 				schema.Required = append(schema.Required, fieldName)
 			}
 		}
 
+		fieldSchema.PII = isPII(field)
+		sg.generateExample(&fieldSchema, fieldName, fieldRules(field))
+
+		if scopes := tagList(field, "scope"); len(scopes) > 0 {
+			fieldSchema = documentScopes(fieldSchema, scopes)
+		}
+		if views := tagList(field, "view"); len(views) > 0 {
+			fieldSchema = documentViews(fieldSchema, views)
+		}
+
 		schema.Properties[fieldName] = fieldSchema
 	}
+}
 
-	// Store the schema for reuse
-	sg.spec.Components.Schemas[schemaName] = schema
-
-	return schema
+// embeddedStruct reports whether field is an untagged embedded struct whose fields are promoted
+func embeddedStruct(field reflect.StructField) (reflect.Type, bool) {
+	if !field.Anonymous || field.Tag.Get("json") != "" || field.Tag.Get("form") != "" {
+		return nil, false
+	}
+	t := field.Type
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t, t.Kind() == reflect.Struct
 }
 
 func (sg *SwaggerGenerator) GetSpec() OpenAPISpec {
@@ -504,53 +1290,3 @@ func (sg *SwaggerGenerator) GetSpec() OpenAPISpec {
 func (sg *SwaggerGenerator) GetJSON() ([]byte, error) {
 	return json.MarshalIndent(sg.spec, "", "  ")
 }
-
-// serveSwaggerUI serves the Swagger UI using gin
-func (sg *SwaggerGenerator) UIHandler() gin.HandlerFunc {
-	return func(ctx *gin.Context) {
-		ctx.Header("Content-Type", "text/html")
-		title := sg.pageTitle
-		if title == "" {
-			title = sg.spec.Info.Title
-		}
-		ctx.String(http.StatusOK, fmt.Sprintf(swaggerUITemplate, title, "/openapi.json"))
-	}
-}
-
-const swaggerUITemplate = `
-<!DOCTYPE html>
-<html lang="en">
-<head>
-    <meta charset="UTF-8">
-    <title>%s</title>
-    <link rel="stylesheet" href="https://cdn.jsdelivr.net/npm/swagger-ui-dist@5.9.0/swagger-ui.css">
-    <style>
-        html { box-sizing: border-box; overflow: -moz-scrollbars-vertical; overflow-y: scroll; }
-        *, *:before, *:after { box-sizing: inherit; }
-        body { margin: 0; background: #fafafa; }
-    </style>
-</head>
-<body>
-    <div id="swagger-ui"></div>
-    <script src="https://cdn.jsdelivr.net/npm/swagger-ui-dist@5.9.0/swagger-ui-bundle.js"></script>
-    <script src="https://cdn.jsdelivr.net/npm/swagger-ui-dist@5.9.0/swagger-ui-standalone-preset.js"></script>
-    <script>
-        window.onload = function() {
-            window.ui = SwaggerUIBundle({
-                url: "%s",
-                dom_id: '#swagger-ui',
-                deepLinking: true,
-                presets: [
-                    SwaggerUIBundle.presets.apis,
-                    SwaggerUIStandalonePreset
-                ],
-                plugins: [
-                    SwaggerUIBundle.plugins.DownloadUrl
-                ],
-                layout: "StandaloneLayout"
-            });
-        };
-    </script>
-</body>
-</html>
-`
@@ -1,12 +1,16 @@
 package fluxo
 
 import (
+    "bytes"
     "encoding/json"
     "net/http"
     "net/http/httptest"
+    "maps"
     "reflect"
+    "slices"
     "strings"
     "testing"
+    "time"
     mimeMultipart "mime/multipart"
 
     "github.com/gin-gonic/gin"
@@ -201,7 +205,7 @@ func TestSwagger_Schema_EdgeCases(t *testing.T) {
 		}
 		sg.AddEndpoint("POST", "/test/:id", []reflect.Type{reflect.TypeOf(ComplexReq{})}, nil, "application/json")
 		spec := sg.GetSpec()
-		if _, ok := spec.Paths["/test/:id"]; !ok {
+		if _, ok := spec.Paths["/test/{id}"]; !ok {
 			t.Error("expected /test/:id in spec")
 		}
 	})
@@ -285,3 +289,342 @@ func TestSwagger_Schema_EdgeCases(t *testing.T) {
 		}
 	})
 }
+
+func TestSwagger_SpecCache(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := New().WithSwagger("Test", "1.0.0")
+	app.GET("/a", Handle(func(ctx *Context, req struct{}) (gin.H, error) { return gin.H{}, nil }))
+
+	fetch := func() map[string]interface{} {
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("status=%d", w.Code)
+		}
+		var m map[string]interface{}
+		if err := json.Unmarshal(w.Body.Bytes(), &m); err != nil {
+			t.Fatalf("invalid json: %v", err)
+		}
+		return m["paths"].(map[string]interface{})
+	}
+
+	fetch()
+	first := app.swagger.cached
+	fetch()
+	if &first[0] != &app.swagger.cached[0] {
+		t.Fatalf("expected cached spec to be reused")
+	}
+
+	app.GET("/b", Handle(func(ctx *Context, req struct{}) (gin.H, error) { return gin.H{}, nil }))
+	if app.swagger.cached != nil {
+		t.Fatalf("expected cache to be invalidated on route registration")
+	}
+	if _, ok := fetch()["/b"]; !ok {
+		t.Fatalf("expected /b in rebuilt spec")
+	}
+}
+
+type providedDecimal struct{ units int64 }
+
+func (providedDecimal) OpenAPISchema() Schema {
+	return Schema{Type: "string", Format: "decimal", Example: "12.50"}
+}
+
+type providedUUID [16]byte
+
+func (*providedUUID) OpenAPISchema() Schema { return Schema{Type: "string", Format: "uuid"} }
+
+type providedShape struct{}
+
+func (providedShape) OpenAPISchema() Schema {
+	return Schema{OneOf: []Schema{
+		{Type: "object", Properties: map[string]Schema{"radius": {Type: "number"}}},
+		{Type: "object", Properties: map[string]Schema{"side": {Type: "number"}}},
+	}}
+}
+
+func TestSwagger_SchemaProvider(t *testing.T) {
+	sg := NewSwaggerGenerator("t", "v")
+	type Order struct {
+		Total  providedDecimal           `json:"total"`
+		ID     providedUUID              `json:"id"`
+		Ref    *providedUUID             `json:"ref"`
+		Shapes []providedShape           `json:"shapes"`
+		Tip    Optional[providedDecimal] `json:"tip"`
+	}
+	props := sg.generateSchema(reflect.TypeOf(Order{})).Properties
+
+	if got := props["total"]; got.Type != "string" || got.Format != "decimal" || got.Example != "12.50" {
+		t.Errorf("expected the value receiver's schema, got %+v", got)
+	}
+	if props["id"].Format != "uuid" || props["ref"].Format != "uuid" {
+		t.Errorf("expected the pointer receiver's schema, got %+v and %+v", props["id"], props["ref"])
+	}
+	if items := props["shapes"].Items; items == nil || len(items.OneOf) != 2 {
+		t.Errorf("expected a union of two shapes, got %+v", props["shapes"])
+	}
+	if got := props["tip"]; got.Format != "decimal" || !got.Nullable {
+		t.Errorf("expected an Optional to keep the provided schema, got %+v", got)
+	}
+	if _, ok := sg.spec.Components.Schemas["providedDecimal"]; ok {
+		t.Error("expected provided schemas to be used as is, not as components")
+	}
+
+	type Query struct {
+		ID providedUUID `form:"id"`
+	}
+	params := sg.generateParameters(reflect.TypeOf(Query{}), "/orders")
+	if len(params) != 1 || params[0].Schema.Format != "uuid" {
+		t.Fatalf("expected the parameter to use the provided schema, got %+v", params)
+	}
+}
+
+type refCategory struct {
+	Name     string        `json:"name"`
+	Children []refCategory `json:"children"`
+	Parent   *refCategory  `json:"parent"`
+}
+
+type refAuthor struct {
+	Name  string    `json:"name"`
+	Books []refBook `json:"books"`
+}
+
+type refBook struct {
+	Title  string             `json:"title"`
+	Author refAuthor          `json:"author"`
+	Sequel Optional[*refBook] `json:"sequel"`
+	Meta   struct {
+		ISBN string `json:"isbn"`
+	} `json:"meta"`
+}
+
+func TestSwagger_RecursiveSchemas(t *testing.T) {
+	t.Run("tree", func(t *testing.T) {
+		sg := NewSwaggerGenerator("t", "v")
+		schema := sg.generateSchema(reflect.TypeOf(refCategory{}))
+		ref := "#/components/schemas/refCategory"
+		if items := schema.Properties["children"].Items; items == nil || items.Ref != ref {
+			t.Fatalf("expected children to refer back to the category, got %+v", schema.Properties["children"])
+		}
+		if schema.Properties["parent"].Ref != ref {
+			t.Fatalf("expected parent to refer back to the category, got %+v", schema.Properties["parent"])
+		}
+		if component := sg.spec.Components.Schemas["refCategory"]; component.Properties["name"].Type != "string" {
+			t.Fatalf("expected the full component, got %+v", component)
+		}
+		// Later uses refer to the component too
+		if again := sg.generateSchema(reflect.TypeOf(refCategory{})); again.Ref != ref {
+			t.Fatalf("expected a reference, got %+v", again)
+		}
+	})
+
+	t.Run("mutual", func(t *testing.T) {
+		sg := NewSwaggerGenerator("t", "v")
+		schema := sg.generateSchema(reflect.TypeOf(refAuthor{}))
+		book := schema.Properties["books"].Items
+		if book == nil || book.Properties["title"].Type != "string" {
+			t.Fatalf("expected the book inline the first time, got %+v", book)
+		}
+		if book.Properties["author"].Ref != "#/components/schemas/refAuthor" {
+			t.Fatalf("expected the author to be referenced, got %+v", book.Properties["author"])
+		}
+		sequel := book.Properties["sequel"]
+		if !sequel.Nullable || len(sequel.AllOf) != 1 || sequel.AllOf[0].Ref != "#/components/schemas/refBook" {
+			t.Fatalf("expected a nullable reference to the book, got %+v", sequel)
+		}
+		if book.Properties["meta"].Properties["isbn"].Type != "string" {
+			t.Fatalf("expected anonymous structs inline, got %+v", book.Properties["meta"])
+		}
+
+		data, err := json.Marshal(sg.spec.Components.Schemas)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, want := range []string{`"refAuthor"`, `"refBook"`, `"$ref":"#/components/schemas/refAuthor"`} {
+			if !strings.Contains(string(data), want) {
+				t.Errorf("expected %s in the components, got %s", want, data)
+			}
+		}
+	})
+}
+
+type namedPair[A, B any] struct {
+	First  A `json:"first"`
+	Second B `json:"second"`
+}
+
+func TestSwagger_ComponentNames(t *testing.T) {
+	sg := NewSwaggerGenerator("t", "v")
+	type Reader struct {
+		Name string `json:"name"`
+	}
+	names := map[reflect.Type]string{}
+	for _, rt := range []reflect.Type{
+		reflect.TypeOf(Reader{}),
+		reflect.TypeOf(bytes.Reader{}),
+		reflect.TypeOf(strings.Reader{}),
+		reflect.TypeOf(namedPair[int, string]{}),
+		reflect.TypeOf(namedPair[int, *Reader]{}),
+	} {
+		names[rt] = sg.componentName(rt)
+	}
+
+	want := map[reflect.Type]string{
+		reflect.TypeOf(Reader{}):                  "Reader",
+		reflect.TypeOf(bytes.Reader{}):            "bytes.Reader",
+		reflect.TypeOf(strings.Reader{}):          "strings.Reader",
+		reflect.TypeOf(namedPair[int, string]{}):  "namedPairOfIntAndString",
+		reflect.TypeOf(namedPair[int, *Reader]{}): "namedPairOfIntAndReader",
+	}
+	for rt, name := range want {
+		if names[rt] != name {
+			t.Errorf("%v: expected %q, got %q", rt, name, names[rt])
+		}
+	}
+	if again := sg.componentName(reflect.TypeOf(bytes.Reader{})); again != "bytes.Reader" {
+		t.Errorf("expected a type to keep its name, got %q", again)
+	}
+
+	sg = NewSwaggerGenerator("t", "v", WithSchemaNamer(func(t reflect.Type) string {
+		if t.PkgPath() == "bytes" {
+			return ""
+		}
+		return "api/" + t.Name()
+	}))
+	for _, tt := range []struct {
+		rt   reflect.Type
+		name string
+	}{
+		{reflect.TypeOf(Reader{}), "api_Reader"},
+		{reflect.TypeOf(strings.Reader{}), "api_Reader2"},
+		{reflect.TypeOf(bytes.Reader{}), "Reader"},
+	} {
+		if got := sg.componentName(tt.rt); got != tt.name {
+			t.Errorf("%v: expected %q from the namer, got %q", tt.rt, tt.name, got)
+		}
+	}
+}
+
+type UUID [16]byte
+
+func TestSwagger_WellKnownTypes(t *testing.T) {
+	sg := NewSwaggerGenerator("t", "v")
+	type Event struct {
+		At      time.Time         `json:"at"`
+		Until   *time.Time        `json:"until"`
+		ID      UUID              `json:"id"`
+		Payload json.RawMessage   `json:"payload"`
+		Extra   any               `json:"extra"`
+		Labels  map[string]string `json:"labels"`
+		Counts  map[string][]int  `json:"counts"`
+		Blob    []byte            `json:"blob"`
+	}
+	props := sg.generateSchema(reflect.TypeOf(Event{})).Properties
+
+	for name, want := range map[string]Schema{
+		"at":      {Type: "string", Format: "date-time"},
+		"until":   {Type: "string", Format: "date-time"},
+		"id":      {Type: "string", Format: "uuid"},
+		"payload": {Type: "object", AdditionalProperties: &Schema{}},
+		"extra":   {Type: "object", AdditionalProperties: &Schema{}},
+		"labels":  {Type: "object", AdditionalProperties: &Schema{Type: "string"}},
+		"counts":  {Type: "object", AdditionalProperties: &Schema{Type: "array", Items: &Schema{Type: "integer", Format: "int64"}}},
+		"blob":    {Type: "string", Format: "byte"},
+	} {
+		if !reflect.DeepEqual(props[name], want) {
+			t.Errorf("%s: expected %+v, got %+v", name, want, props[name])
+		}
+	}
+	if _, ok := sg.spec.Components.Schemas["Time"]; ok {
+		t.Error("expected time.Time not to become a component")
+	}
+
+	data, _ := json.Marshal(props["payload"])
+	if string(data) != `{"type":"object","additionalProperties":{}}` {
+		t.Errorf("expected a free-form object, got %s", data)
+	}
+
+	type Query struct {
+		Since time.Time `form:"since"`
+	}
+	params := sg.generateParameters(reflect.TypeOf(Query{}), "/events")
+	if len(params) != 1 || params[0].Schema.Format != "date-time" {
+		t.Fatalf("expected a date-time parameter, got %+v", params)
+	}
+}
+
+func TestSwagger_GlobalParameters(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := New().WithSwagger("t", "v",
+		WithGlobalHeader("X-Tenant-ID", true, Schema{Type: "string", Format: "uuid"}),
+		WithGlobalQuery("locale", false, Schema{Type: "string"}),
+	)
+	type TenantReq struct {
+		Tenant string `header:"x-tenant-id"`
+	}
+	app.GET("/todos", Handle(func(ctx *Context, req struct{}) (gin.H, error) { return gin.H{}, nil }))
+	app.POST("/todos", Handle(func(ctx *Context, req TenantReq) (gin.H, error) { return gin.H{}, nil }))
+
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	var spec OpenAPISpec
+	if err := json.Unmarshal(w.Body.Bytes(), &spec); err != nil {
+		t.Fatal(err)
+	}
+
+	get := spec.Paths["/todos"].GET.Parameters
+	if !hasParameter(get, "X-Tenant-ID", "header") || !hasParameter(get, "locale", "query") {
+		t.Fatalf("expected the global parameters, got %+v", get)
+	}
+	for _, p := range get {
+		if p.Name == "X-Tenant-ID" && (!p.Required || p.Schema.Format != "uuid") {
+			t.Errorf("expected the global header as given, got %+v", p)
+		}
+	}
+
+	post := spec.Paths["/todos"].POST.Parameters
+	headers := 0
+	for _, p := range post {
+		if p.In == "header" {
+			headers++
+		}
+	}
+	if headers != 1 || !hasParameter(post, "x-tenant-id", "header") {
+		t.Fatalf("expected the operation's own header to win, got %+v", post)
+	}
+}
+
+func TestSwagger_WildcardPath(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	type FileReq struct {
+		Bucket string `uri:"bucket"`
+		Path   string `uri:"filepath"`
+	}
+	app := New().WithSwagger("Files", "1.0")
+	app.GET("/buckets/:bucket/files/*filepath", Handle(func(ctx *Context, req FileReq) (FileReq, error) {
+		return req, nil
+	}))
+
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/buckets/media/files/docs/2025/report.pdf", nil))
+	var got FileReq
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Bucket != "media" || got.Path != "/docs/2025/report.pdf" {
+		t.Errorf("bound %+v", got)
+	}
+
+	spec, err := app.swagger.parsedSpec(app.specHandlers())
+	if err != nil {
+		t.Fatal(err)
+	}
+	op := spec.Paths["/buckets/{bucket}/files/{filepath}"].GET
+	if op == nil || !hasParameter(op.Parameters, "filepath", "path") {
+		t.Fatalf("expected the catch-all parameter templated, got paths %v", slices.Collect(maps.Keys(spec.Paths)))
+	}
+	if err := app.Validate(); err != nil {
+		t.Errorf("Validate: %v", err)
+	}
+}
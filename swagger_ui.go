@@ -0,0 +1,266 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const swaggerUICDN = "https://cdn.jsdelivr.net/npm/swagger-ui-dist@5.18.2"
+
+//go:embed swaggerui
+var swaggerUIEmbedded embed.FS
+
+// WithSwaggerUICDN loads the Swagger UI from the jsDelivr CDN instead of the embedded assets
+func WithSwaggerUICDN() SwaggerOption {
+	return func(sg *SwaggerGenerator) {
+		sg.uiCDN = true
+	}
+}
+
+// WithSwaggerUIAssets serves the Swagger UI from fsys, which must hold the swagger-ui-dist files at its root
+func WithSwaggerUIAssets(fsys fs.FS) SwaggerOption {
+	return func(sg *SwaggerGenerator) {
+		sg.uiFS = fsys
+	}
+}
+
+// WithSwaggerUIConfig sets an arbitrary SwaggerUIBundle configuration key, e.g. "filter" or "syntaxHighlight"
+func WithSwaggerUIConfig(key string, value interface{}) SwaggerOption {
+	return func(sg *SwaggerGenerator) {
+		if sg.uiConfig == nil {
+			sg.uiConfig = make(map[string]interface{})
+		}
+		sg.uiConfig[key] = value
+	}
+}
+
+// WithSwaggerUIDocExpansion controls the default expansion of operations: "list", "full" or "none"
+func WithSwaggerUIDocExpansion(mode string) SwaggerOption {
+	return WithSwaggerUIConfig("docExpansion", mode)
+}
+
+// WithSwaggerUITryItOut enables "Try it out" on every operation by default
+func WithSwaggerUITryItOut(enabled bool) SwaggerOption {
+	return WithSwaggerUIConfig("tryItOutEnabled", enabled)
+}
+
+// WithSwaggerUIPersistAuthorization keeps entered credentials across page reloads
+func WithSwaggerUIPersistAuthorization(enabled bool) SwaggerOption {
+	return WithSwaggerUIConfig("persistAuthorization", enabled)
+}
+
+// WithSwaggerUIModelsExpandDepth sets how deep the models section is expanded; -1 hides it
+func WithSwaggerUIModelsExpandDepth(depth int) SwaggerOption {
+	return WithSwaggerUIConfig("defaultModelsExpandDepth", depth)
+}
+
+// WithSwaggerUIOAuth2RedirectURL sets the OAuth2 redirect URL used by the authorize dialog
+func WithSwaggerUIOAuth2RedirectURL(url string) SwaggerOption {
+	return WithSwaggerUIConfig("oauth2RedirectUrl", url)
+}
+
+// WithSwaggerUICustomCSS appends custom CSS to the docs page
+func WithSwaggerUICustomCSS(css string) SwaggerOption {
+	return func(sg *SwaggerGenerator) {
+		sg.uiCSS = css
+	}
+}
+
+// WithSwaggerUILogo replaces the Swagger logo in the top bar with the image at url
+func WithSwaggerUILogo(url string) SwaggerOption {
+	return func(sg *SwaggerGenerator) {
+		sg.uiLogo = url
+	}
+}
+
+// swaggerUIFiles are the swagger-ui-dist files served from the embedded swaggerui directory
+var swaggerUIFiles = []string{"swagger-ui.css", "swagger-ui-bundle.js", "swagger-ui-standalone-preset.js", "oauth2-redirect.html"}
+
+// errSwaggerUIMissing is why the docs page can't load the UI when the assets weren't vendored
+var errSwaggerUIMissing = errors.New("the Swagger UI assets are not embedded in this build of fluxo; " +
+	"serve swagger-ui-dist with WithSwaggerUIAssets, or load it from jsDelivr with WithSwaggerUICDN")
+
+// swaggerUIDist keeps the embedded directory to the swagger-ui-dist files, leaving out its README
+type swaggerUIDist struct {
+	fs.FS
+}
+
+func (d swaggerUIDist) Open(name string) (fs.File, error) {
+	if !slices.Contains(swaggerUIFiles, name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	return d.FS.Open(name)
+}
+
+// uiAssets returns the filesystem to serve the UI from, nil when it is loaded from the CDN. It
+// fails when the assets are missing: the CDN is only used when asked for.
+func (sg *SwaggerGenerator) uiAssets() (fs.FS, error) {
+	if sg.uiCDN {
+		return nil, nil
+	}
+	if sg.uiFS != nil {
+		if _, err := fs.Stat(sg.uiFS, "swagger-ui-bundle.js"); err != nil {
+			return nil, fmt.Errorf("the Swagger UI assets given to WithSwaggerUIAssets lack swagger-ui-bundle.js: %w", err)
+		}
+		return sg.uiFS, nil
+	}
+	sub, err := fs.Sub(swaggerUIEmbedded, "swaggerui")
+	if err != nil {
+		return nil, err
+	}
+	fsys := swaggerUIDist{sub}
+	if _, err := fs.Stat(fsys, "swagger-ui-bundle.js"); err != nil {
+		return nil, errSwaggerUIMissing
+	}
+	return fsys, nil
+}
+
+// warnMissingUI logs why the docs page can't load the UI, if it can't
+func (sg *SwaggerGenerator) warnMissingUI(logger *slog.Logger) {
+	if _, err := sg.uiAssets(); err != nil {
+		logger.Warn("fluxo: the docs page can't load the Swagger UI", "error", err)
+	}
+}
+
+// swaggerUIPage holds the values rendered into swaggerUITemplate
+type swaggerUIPage struct {
+	Title     string
+	AssetBase string
+	SpecURL   string
+	Config    template.JS
+	CSS       template.CSS
+	Missing   string // why the UI can't load, shown on the page
+}
+
+// UIHandler serves the Swagger UI page using gin
+func (sg *SwaggerGenerator) UIHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		title := sg.pageTitle
+		if title == "" {
+			title = sg.spec.Info.Title
+		}
+
+		assetBase, missing := swaggerUICDN, ""
+		if _, err := sg.uiAssets(); err != nil {
+			missing = err.Error()
+		}
+		if !sg.uiCDN {
+			// FullPath is empty when the handler is called outside a router
+			docsPath := ctx.FullPath()
+			if docsPath == "" && ctx.Request != nil {
+				docsPath = ctx.Request.URL.Path
+			}
+			assetBase = strings.TrimSuffix(docsPath, "/") + "/assets"
+		}
+
+		config := []byte("{}")
+		if len(sg.uiConfig) > 0 {
+			var err error
+			if config, err = json.Marshal(sg.uiConfig); err != nil {
+				ctx.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Invalid Swagger UI config: %v", err)})
+				return
+			}
+		}
+
+		css := sg.uiCSS
+		if sg.uiLogo != "" {
+			css += fmt.Sprintf("\n.swagger-ui .topbar .topbar-wrapper .link { content: url(%q); height: 40px; }", sg.uiLogo)
+		}
+
+		ctx.Header("Content-Type", "text/html")
+		ctx.Status(http.StatusOK)
+		err := swaggerUITemplate.Execute(ctx.Writer, swaggerUIPage{
+			Title:     title,
+			AssetBase: assetBase,
+			SpecURL:   "/openapi.json",
+			Config:    template.JS(config),
+			CSS:       template.CSS(css),
+			Missing:   missing,
+		})
+		if err != nil {
+			ctx.Error(err)
+		}
+	}
+}
+
+// specHandler serves the cached spec built from the routes returned by handlers
+func (sg *SwaggerGenerator) specHandler(handlers func() map[string]handlerInfo) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// The spec is built once and cached until new routes are registered
+		data, err := sg.SpecJSON(handlers())
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Spec generation failed: %v", err)})
+			return
+		}
+		c.Data(http.StatusOK, "application/json; charset=utf-8", data)
+	}
+}
+
+// AssetsHandler serves the Swagger UI static files; the route must declare a *filepath parameter
+func (sg *SwaggerGenerator) AssetsHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		fsys, err := sg.uiAssets()
+		if err != nil {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if fsys == nil {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+		ctx.Header("Cache-Control", "public, max-age=86400")
+		ctx.FileFromFS(ctx.Param("filepath"), http.FS(fsys))
+	}
+}
+
+var swaggerUITemplate = template.Must(template.New("swagger-ui").Parse(`
+<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <title>{{.Title}}</title>
+    <link rel="stylesheet" href="{{.AssetBase}}/swagger-ui.css">
+    <style>
+        html { box-sizing: border-box; overflow: -moz-scrollbars-vertical; overflow-y: scroll; }
+        *, *:before, *:after { box-sizing: inherit; }
+        body { margin: 0; background: #fafafa; }
+        {{.CSS}}
+    </style>
+</head>
+<body>
+    {{if .Missing}}<p style="margin: 0; padding: 12px 20px; background: #f93e3e; color: #fff; font-family: sans-serif;">{{.Missing}}</p>{{end}}
+    <div id="swagger-ui"></div>
+    <script src="{{.AssetBase}}/swagger-ui-bundle.js"></script>
+    <script src="{{.AssetBase}}/swagger-ui-standalone-preset.js"></script>
+    <script>
+        window.onload = function() {
+            window.ui = SwaggerUIBundle(Object.assign({
+                url: {{.SpecURL}},
+                dom_id: '#swagger-ui',
+                deepLinking: true,
+                presets: [
+                    SwaggerUIBundle.presets.apis,
+                    SwaggerUIStandalonePreset
+                ],
+                plugins: [
+                    SwaggerUIBundle.plugins.DownloadUrl
+                ],
+                layout: "StandaloneLayout"
+            }, {{.Config}}));
+        };
+    </script>
+</body>
+</html>
+`))
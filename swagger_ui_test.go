@@ -0,0 +1,160 @@
+package fluxo
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestSwaggerUI_CustomAssets(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	assets := fstest.MapFS{
+		"swagger-ui-bundle.js": {Data: []byte("// bundle")},
+		"swagger-ui.css":       {Data: []byte("/* css */")},
+	}
+	app := New().WithSwagger("Test", "1.0.0", WithSwaggerUIAssets(assets))
+
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs", nil))
+	if !strings.Contains(w.Body.String(), `src="/docs/assets/swagger-ui-bundle.js"`) {
+		t.Fatalf("expected local asset references, got %s", w.Body.String())
+	}
+	if strings.Contains(w.Body.String(), swaggerUICDN) {
+		t.Fatalf("expected no CDN references")
+	}
+
+	w = httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs/assets/swagger-ui-bundle.js", nil))
+	if w.Code != http.StatusOK || w.Body.String() != "// bundle" {
+		t.Fatalf("status=%d body=%q", w.Code, w.Body.String())
+	}
+}
+
+func TestSwaggerUI_EmbeddedAssets(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var logs bytes.Buffer
+	app := New(WithLogger(slog.New(slog.NewTextHandler(&logs, nil)))).WithSwagger("Test", "1.0.0")
+	if logs.Len() != 0 {
+		t.Errorf("expected no warning, got %q", logs.String())
+	}
+
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs", nil))
+	body := w.Body.String()
+	if strings.Contains(body, swaggerUICDN) || strings.Contains(body, "not embedded") || !strings.Contains(body, `src="/docs/assets/swagger-ui-bundle.js"`) {
+		t.Fatalf("expected the page to load the embedded UI, got %s", body)
+	}
+
+	for file, want := range map[string]string{
+		"swagger-ui.css":                  "text/css",
+		"swagger-ui-bundle.js":            "javascript",
+		"swagger-ui-standalone-preset.js": "javascript",
+		"oauth2-redirect.html":            "text/html",
+	} {
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs/assets/"+file, nil))
+		if w.Code != http.StatusOK || w.Body.Len() == 0 || !strings.Contains(w.Header().Get("Content-Type"), want) {
+			t.Errorf("%s: status=%d type=%q size=%d", file, w.Code, w.Header().Get("Content-Type"), w.Body.Len())
+		}
+	}
+}
+
+func TestSwaggerUI_MissingAssets(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var logs bytes.Buffer
+	app := New(WithLogger(slog.New(slog.NewTextHandler(&logs, nil)))).
+		WithSwagger("Test", "1.0.0", WithSwaggerUIAssets(fstest.MapFS{"swagger-ui.css": {Data: []byte("/* css */")}}))
+	if !strings.Contains(logs.String(), "swagger-ui-bundle.js") {
+		t.Errorf("expected a warning, got %q", logs.String())
+	}
+
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs", nil))
+	body := w.Body.String()
+	if strings.Contains(body, swaggerUICDN) || !strings.Contains(body, "lack swagger-ui-bundle.js") {
+		t.Fatalf("expected the page to say the assets are missing rather than load them from the CDN, got %s", body)
+	}
+
+	w = httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs/assets/swagger-ui-bundle.js", nil))
+	if w.Code != http.StatusNotFound || !strings.Contains(w.Body.String(), "WithSwaggerUIAssets") {
+		t.Fatalf("status=%d body=%s", w.Code, w.Body.String())
+	}
+}
+
+func TestSwaggerUI_EmbeddedServesOnlyDist(t *testing.T) {
+	fsys := swaggerUIDist{fstest.MapFS{
+		"README.md":            {Data: []byte("# docs")},
+		"swagger-ui-bundle.js": {Data: []byte("// bundle")},
+	}}
+	if _, err := fs.Stat(fsys, "README.md"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("README.md is served: %v", err)
+	}
+	if data, err := fs.ReadFile(fsys, "swagger-ui-bundle.js"); err != nil || string(data) != "// bundle" {
+		t.Errorf("bundle = %q, %v", data, err)
+	}
+
+	gin.SetMode(gin.TestMode)
+	app := New().WithSwagger("Test", "1.0.0")
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs/assets/README.md", nil))
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for the README, got %d", w.Code)
+	}
+}
+
+func TestSwaggerUI_CDN(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	assets := fstest.MapFS{"swagger-ui-bundle.js": {Data: []byte("// bundle")}}
+	app := New().WithSwagger("Test", "1.0.0", WithSwaggerUIAssets(assets), WithSwaggerUICDN())
+
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs", nil))
+	if !strings.Contains(w.Body.String(), swaggerUICDN+"/swagger-ui-bundle.js") {
+		t.Fatalf("expected CDN asset references")
+	}
+
+	w = httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs/assets/swagger-ui-bundle.js", nil))
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for assets in CDN mode, got %d", w.Code)
+	}
+}
+
+func TestSwaggerUI_ConfigOptions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := New().WithSwagger("Test", "1.0.0",
+		WithSwaggerUIDocExpansion("none"),
+		WithSwaggerUITryItOut(true),
+		WithSwaggerUIPersistAuthorization(true),
+		WithSwaggerUIModelsExpandDepth(-1),
+		WithSwaggerUIOAuth2RedirectURL("https://example.com/docs/oauth2-redirect.html"),
+		WithSwaggerUICustomCSS(".topbar { background: #000; }"),
+		WithSwaggerUILogo("/static/logo.png"),
+	)
+
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs", nil))
+	body := w.Body.String()
+	for _, want := range []string{
+		`"docExpansion":"none"`,
+		`"tryItOutEnabled":true`,
+		`"persistAuthorization":true`,
+		`"defaultModelsExpandDepth":-1`,
+		`"oauth2RedirectUrl":"https://example.com/docs/oauth2-redirect.html"`,
+		`.topbar { background: #000; }`,
+		`/static/logo.png`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected %s in page", want)
+		}
+	}
+}
@@ -0,0 +1,237 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultTasksPath is where task status is served when no path is given to App.Tasks
+const DefaultTasksPath = "/tasks"
+
+type TaskStatus string
+
+const (
+	TaskPending   TaskStatus = "pending"
+	TaskRunning   TaskStatus = "running"
+	TaskSucceeded TaskStatus = "succeeded"
+	TaskFailed    TaskStatus = "failed"
+)
+
+// Task is the pollable state of an asynchronous operation
+type Task struct {
+	ID        string      `json:"id"`
+	Status    TaskStatus  `json:"status"`
+	Result    interface{} `json:"result,omitempty"`
+	Error     string      `json:"error,omitempty"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+}
+
+// Done reports whether the task has finished, successfully or not
+func (t Task) Done() bool {
+	return t.Status == TaskSucceeded || t.Status == TaskFailed
+}
+
+// ErrTaskNotFound is returned by a TaskStore when no task has the requested ID
+var ErrTaskNotFound = errors.New("task not found")
+
+// TaskStore persists task state; implement it to share tasks across instances (e.g. Redis or SQL)
+type TaskStore interface {
+	Save(ctx context.Context, task Task) error
+	Get(ctx context.Context, id string) (Task, error)
+}
+
+// MemoryTaskStore keeps tasks in process memory
+type MemoryTaskStore struct {
+	mu    sync.RWMutex
+	tasks map[string]Task
+}
+
+func NewMemoryTaskStore() *MemoryTaskStore {
+	return &MemoryTaskStore{tasks: make(map[string]Task)}
+}
+
+func (s *MemoryTaskStore) Save(_ context.Context, task Task) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tasks[task.ID] = task
+	return nil
+}
+
+func (s *MemoryTaskStore) Get(_ context.Context, id string) (Task, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	task, ok := s.tasks[id]
+	if !ok {
+		return Task{}, ErrTaskNotFound
+	}
+	return task, nil
+}
+
+// TaskFunc performs the work of an asynchronous task and returns its result
+type TaskFunc func(ctx context.Context) (interface{}, error)
+
+// Tasks runs asynchronous operations and records their progress in a TaskStore
+type Tasks struct {
+	store TaskStore
+	path  string
+	wg    sync.WaitGroup
+}
+
+// NewTasks creates a task runner backed by store (an in-memory store when nil)
+func NewTasks(store TaskStore) *Tasks {
+	if store == nil {
+		store = NewMemoryTaskStore()
+	}
+	return &Tasks{store: store, path: DefaultTasksPath}
+}
+
+// Store returns the underlying TaskStore
+func (t *Tasks) Store() TaskStore {
+	return t.store
+}
+
+// Run records a pending task and executes fn in the background. The task keeps the
+// values of ctx but is not cancelled when the request that started it completes. Handlers
+// may pass their *Context: gin reuses it for other requests, so the task gets a copy.
+func (t *Tasks) Run(ctx context.Context, fn TaskFunc) (string, error) {
+	id, err := newTaskID()
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now().UTC()
+	task := Task{ID: id, Status: TaskPending, CreatedAt: now, UpdatedAt: now}
+	if err := t.store.Save(ctx, task); err != nil {
+		return "", err
+	}
+
+	bg := detached(ctx)
+	t.wg.Add(1)
+	go func() {
+		defer t.wg.Done()
+		t.execute(bg, task, fn)
+	}()
+	return id, nil
+}
+
+// detached returns ctx without its cancellation. A request's gin context is copied, so that
+// the task keeps its values once gin reuses it for another request.
+func detached(ctx context.Context) context.Context {
+	switch c := ctx.(type) {
+	case *gin.Context:
+		ctx = requestContext{Context: c.Request.Context(), gin: c.Copy()}
+	case *Context:
+		ctx = requestContext{Context: c.Request.Context(), gin: c.Copy()}
+	case requestContext:
+		ctx = requestContext{Context: c.Context, gin: c.gin.Copy()}
+	}
+	return context.WithoutCancel(ctx)
+}
+
+func (t *Tasks) execute(ctx context.Context, task Task, fn TaskFunc) {
+	task.Status = TaskRunning
+	task.UpdatedAt = time.Now().UTC()
+	_ = t.store.Save(ctx, task)
+
+	defer func() {
+		if r := recover(); r != nil {
+			task.Status = TaskFailed
+			task.Error = fmt.Sprintf("task panicked: %v", r)
+			task.UpdatedAt = time.Now().UTC()
+			_ = t.store.Save(ctx, task)
+		}
+	}()
+
+	result, err := fn(ctx)
+	task.UpdatedAt = time.Now().UTC()
+	if err != nil {
+		task.Status = TaskFailed
+		task.Error = err.Error()
+	} else {
+		task.Status = TaskSucceeded
+		task.Result = result
+	}
+	_ = t.store.Save(ctx, task)
+}
+
+// Wait blocks until every task started by Run has finished, e.g. during graceful shutdown
+func (t *Tasks) Wait() {
+	t.wg.Wait()
+}
+
+type taskStatusReq struct {
+	ID string `uri:"id" validate:"required"`
+}
+
+func (t *Tasks) status(ctx *Context, req taskStatusReq) (Task, error) {
+	task, err := t.store.Get(ctx, req.ID)
+	if errors.Is(err, ErrTaskNotFound) {
+		return Task{}, NotFound(fmt.Sprintf("task %s not found", req.ID))
+	}
+	if err != nil {
+		return Task{}, err
+	}
+	return task, nil
+}
+
+// Tasks registers GET <path>/:id for polling tasks (path defaults to DefaultTasksPath)
+func (a *App) Tasks(tasks *Tasks, path ...string) {
+	if len(path) > 0 && path[0] != "" {
+		tasks.path = "/" + strings.Trim(path[0], "/")
+	}
+	a.GET(tasks.path+"/:id", Handle(tasks.status))
+}
+
+// Async returns an Accepted response whose Location points at this runner's status endpoint
+func (t *Tasks) Async(taskID string) Accepted {
+	return Accepted{TaskID: taskID, Location: t.path + "/" + taskID}
+}
+
+// Accepted is returned by handlers that start an asynchronous task. It is rendered
+// with status 202 and a Location header pointing at the task status endpoint:
+//
+//	id, err := tasks.Run(ctx, func(ctx context.Context) (any, error) { return buildReport(ctx) })
+//	return tasks.Async(id), err
+type Accepted struct {
+	TaskID   string `json:"task_id"`
+	Location string `json:"location"`
+}
+
+// Async returns an Accepted response for a task served under DefaultTasksPath
+func Async(taskID string) Accepted {
+	return Accepted{TaskID: taskID, Location: DefaultTasksPath + "/" + taskID}
+}
+
+func (a Accepted) StatusCode() int {
+	return http.StatusAccepted
+}
+
+func (a Accepted) SetHeaders(ctx *Context) {
+	ctx.Header("Location", a.Location)
+}
+
+func (a Accepted) responseHeaders() map[string]Header {
+	return map[string]Header{
+		"Location": {Description: "URL to poll for the task status", Schema: Schema{Type: "string"}},
+	}
+}
+
+func newTaskID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,167 @@
+package fluxo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type tkReportReq struct {
+	Name string `json:"name" validate:"required"`
+}
+
+func TestTasks_AcceptedAndPolling(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := New().WithSwagger("Test", "1.0.0")
+	tasks := NewTasks(nil)
+	app.Tasks(tasks, "/jobs")
+
+	release := make(chan struct{})
+	app.POST("/reports", Handle(func(ctx *Context, req tkReportReq) (Accepted, error) {
+		id, err := tasks.Run(ctx, func(ctx context.Context) (interface{}, error) {
+			<-release
+			if req.Name == "fail" {
+				return nil, errors.New("boom")
+			}
+			return gin.H{"rows": 3}, nil
+		})
+		return tasks.Async(id), err
+	}))
+
+	start := func(name string) string {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, "/reports", strings.NewReader(`{"name":"`+name+`"}`))
+		r.Header.Set("Content-Type", "application/json")
+		app.ServeHTTP(w, r)
+		if w.Code != http.StatusAccepted {
+			t.Fatalf("status=%d body=%s", w.Code, w.Body.String())
+		}
+		var acc Accepted
+		_ = json.Unmarshal(w.Body.Bytes(), &acc)
+		if w.Header().Get("Location") != "/jobs/"+acc.TaskID || acc.Location != "/jobs/"+acc.TaskID {
+			t.Fatalf("unexpected location %q / %+v", w.Header().Get("Location"), acc)
+		}
+		return acc.Location
+	}
+	poll := func(location string) Task {
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, location, nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("status=%d", w.Code)
+		}
+		var task Task
+		_ = json.Unmarshal(w.Body.Bytes(), &task)
+		return task
+	}
+
+	ok := start("ok")
+	failed := start("fail")
+	if task := poll(ok); task.Done() {
+		t.Fatalf("expected task to be in progress, got %s", task.Status)
+	}
+	close(release)
+	tasks.Wait()
+
+	if task := poll(ok); task.Status != TaskSucceeded || task.Result.(map[string]interface{})["rows"] != float64(3) {
+		t.Fatalf("unexpected task %+v", task)
+	}
+	if task := poll(failed); task.Status != TaskFailed || task.Error != "boom" {
+		t.Fatalf("unexpected task %+v", task)
+	}
+
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/jobs/missing", nil))
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", w.Code)
+	}
+
+	spec := app.swagger.Generate(app.handlers)
+	paths := spec["paths"].(map[string]interface{})
+	post := paths["/reports"].(map[string]interface{})["post"].(map[string]interface{})
+	accepted, exists := post["responses"].(map[string]interface{})["202"].(map[string]interface{})
+	if !exists {
+		t.Fatalf("expected 202 response, got %v", post["responses"])
+	}
+	if _, ok := accepted["headers"].(map[string]interface{})["Location"]; !ok {
+		t.Fatalf("expected Location header to be documented")
+	}
+	if _, ok := paths["/jobs/{id}"]; !ok {
+		t.Fatalf("expected task status endpoint in spec")
+	}
+}
+
+func TestTasks_Panic(t *testing.T) {
+	tasks := NewTasks(NewMemoryTaskStore())
+	id, err := tasks.Run(context.Background(), func(ctx context.Context) (interface{}, error) {
+		panic("oops")
+	})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	tasks.Wait()
+	task, _ := tasks.Store().Get(context.Background(), id)
+	if task.Status != TaskFailed {
+		t.Fatalf("expected failed task, got %+v", task)
+	}
+	if acc := Async(id); acc.Location != DefaultTasksPath+"/"+id {
+		t.Fatalf("unexpected default location %s", acc.Location)
+	}
+}
+
+func TestTasks_RunOutlivesRequest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := New()
+	tasks := NewTasks(nil)
+	user := NewKey[string]("tasks.user")
+	app.Use(func(ctx *gin.Context) {
+		user.Set(ctx, ctx.GetHeader("X-User"))
+	})
+
+	release := make(chan struct{})
+	seen := make(chan string, 5)
+	work := func(ctx context.Context) (interface{}, error) {
+		<-release
+		seen <- user.MustGet(ctx)
+		return nil, nil
+	}
+	app.POST("/typed", Handle(func(ctx *Context, _ struct{}) (Accepted, error) {
+		id, err := tasks.Run(ctx, work)
+		return tasks.Async(id), err
+	}))
+	app.POST("/plain", HandleCtx(func(ctx context.Context, _ struct{}) (Accepted, error) {
+		id, err := tasks.Run(ctx, work)
+		return tasks.Async(id), err
+	}))
+
+	for _, path := range []string{"/typed", "/plain"} {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, path, nil)
+		r.Header.Set("X-User", "alice")
+		app.ServeHTTP(w, r)
+		if w.Code != http.StatusAccepted {
+			t.Fatalf("%s: status=%d body=%s", path, w.Code, w.Body.String())
+		}
+	}
+	// gin hands the pooled contexts to other requests meanwhile
+	for range 3 {
+		r := httptest.NewRequest(http.MethodPost, "/typed", nil)
+		r.Header.Set("X-User", "mallory")
+		app.ServeHTTP(httptest.NewRecorder(), r)
+	}
+	close(release)
+	tasks.Wait()
+	close(seen)
+	names := map[string]int{}
+	for name := range seen {
+		names[name]++
+	}
+	if names["alice"] != 2 || names["mallory"] != 3 {
+		t.Fatalf("expected the tasks to keep the values of their own requests, got %v", names)
+	}
+}
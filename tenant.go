@@ -0,0 +1,239 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Tenant is the tenant a request is served for, see Tenancy
+type Tenant struct {
+	ID   string
+	Name string
+	// RateLimit overrides the requests per second of WithTenantRateLimit for this tenant, and
+	// Burst its burst; zero keeps the defaults
+	RateLimit float64
+	Burst     int
+	Data      any // anything the lookup wants handlers to have
+}
+
+// TenantKey holds the tenant of the request, see CurrentTenant
+var TenantKey = NewKey[Tenant]("fluxo.tenant")
+
+// CurrentTenant returns the tenant Tenancy resolved for the request
+func CurrentTenant(ctx context.Context) (Tenant, bool) {
+	return TenantKey.Get(ctx)
+}
+
+// ErrTenantNotFound is returned by tenant lookups for unknown tenants, which get 404
+var ErrTenantNotFound = errors.New("fluxo: tenant not found")
+
+// TenantResolver finds the tenant ID of a request
+type TenantResolver struct {
+	resolve func(ctx *gin.Context) string
+	doc     func(op *Operation)
+}
+
+// TenantFromHeader reads the tenant ID from a header, documented on every operation
+func TenantFromHeader(name string) TenantResolver {
+	return TenantResolver{
+		resolve: func(ctx *gin.Context) string { return strings.TrimSpace(ctx.GetHeader(name)) },
+		doc: func(op *Operation) {
+			op.Parameters = append(op.Parameters, Parameter{Name: name, In: "header", Description: "ID of the tenant", Schema: Schema{Type: "string"}})
+		},
+	}
+}
+
+// TenantFromSubdomain reads the tenant ID from the subdomain of domain in the Host header:
+// with "example.com", acme.example.com is tenant acme
+func TenantFromSubdomain(domain string) TenantResolver {
+	suffix := "." + strings.ToLower(strings.Trim(domain, "."))
+	return TenantResolver{
+		resolve: func(ctx *gin.Context) string {
+			host := strings.ToLower(ctx.Request.Host)
+			if h, _, err := net.SplitHostPort(host); err == nil {
+				host = h
+			}
+			sub, ok := strings.CutSuffix(host, suffix)
+			if !ok || sub == "" || strings.Contains(sub, ".") {
+				return ""
+			}
+			return sub
+		},
+		doc: func(op *Operation) {
+			op.Description = strings.TrimSpace(op.Description + "\n\nThe tenant is the subdomain of " + strings.TrimPrefix(suffix, ".") + ".")
+		},
+	}
+}
+
+// TenantFromClaim reads the tenant ID from a claim of the bearer JWT. The token is not verified
+// here: use it after the middleware authenticating the token.
+func TenantFromClaim(claim string) TenantResolver {
+	return TenantResolver{
+		resolve: func(ctx *gin.Context) string {
+			token, ok := strings.CutPrefix(ctx.GetHeader("Authorization"), "Bearer ")
+			if !ok {
+				return ""
+			}
+			parts := strings.Split(strings.TrimSpace(token), ".")
+			if len(parts) != 3 {
+				return ""
+			}
+			payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+			if err != nil {
+				return ""
+			}
+			var claims map[string]any
+			if json.Unmarshal(payload, &claims) != nil {
+				return ""
+			}
+			switch v := claims[claim].(type) {
+			case string:
+				return v
+			case float64:
+				return strconv.FormatFloat(v, 'f', -1, 64)
+			}
+			return ""
+		},
+		doc: func(op *Operation) {
+			op.Description = strings.TrimSpace(op.Description + "\n\nThe tenant is the " + claim + " claim of the bearer token.")
+		},
+	}
+}
+
+// TenancyOption configures Tenancy
+type TenancyOption func(*tenancy)
+
+// WithTenantLookup loads the tenant of a resolved ID, returning ErrTenantNotFound for unknown ones.
+// Without it any ID is accepted as Tenant{ID: id}.
+func WithTenantLookup(lookup func(ctx context.Context, id string) (Tenant, error)) TenancyOption {
+	return func(t *tenancy) {
+		t.lookup = lookup
+	}
+}
+
+// WithTenantOptional lets requests without a tenant through instead of answering 400
+func WithTenantOptional() TenancyOption {
+	return func(t *tenancy) {
+		t.optional = true
+	}
+}
+
+// WithTenantRateLimit limits each tenant to rate requests per second with bursts of burst
+// requests, answering 429 beyond it. Tenant.RateLimit and Tenant.Burst override it per tenant.
+func WithTenantRateLimit(rate float64, burst int) TenancyOption {
+	return func(t *tenancy) {
+		t.rate, t.burst = rate, burst
+	}
+}
+
+type tenancy struct {
+	resolvers []TenantResolver
+	lookup    func(ctx context.Context, id string) (Tenant, error)
+	optional  bool
+	rate      float64
+	burst     int
+	buckets   rateBuckets
+}
+
+// Tenancy returns middleware placing the tenant of each request into its context, trying the
+// resolvers in order:
+//
+//	app.UseTyped(fluxo.Tenancy(
+//		[]fluxo.TenantResolver{fluxo.TenantFromSubdomain("example.com"), fluxo.TenantFromHeader("X-Tenant-ID")},
+//		fluxo.WithTenantLookup(tenants.Find),
+//		fluxo.WithTenantRateLimit(50, 100),
+//	))
+//
+// Requests without a tenant get 400 and unknown tenants 404. Used with UseTyped or on a Group,
+// the tenant header is documented on every operation.
+func Tenancy(resolvers []TenantResolver, opts ...TenancyOption) gin.HandlerFunc {
+	t := &tenancy{resolvers: resolvers}
+	for _, opt := range opts {
+		opt(t)
+	}
+	handler := t.handle
+	registerOperationDoc(handler, t.document)
+	return handler
+}
+
+func (t *tenancy) handle(ctx *gin.Context) {
+	id := ""
+	for _, r := range t.resolvers {
+		if id = r.resolve(ctx); id != "" {
+			break
+		}
+	}
+	if id == "" {
+		if !t.optional {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "The request names no tenant"})
+		}
+		return
+	}
+
+	tenant := Tenant{ID: id}
+	if t.lookup != nil {
+		var err error
+		tenant, err = t.lookup(ctx.Request.Context(), id)
+		if errors.Is(err, ErrTenantNotFound) {
+			ctx.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("Unknown tenant %q", id)})
+			return
+		}
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Loading the tenant failed"})
+			return
+		}
+		if tenant.ID == "" {
+			tenant.ID = id
+		}
+	}
+
+	if wait, ok := t.allow(tenant); !ok {
+		ctx.Header("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+		ctx.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "Too many requests for tenant " + tenant.ID})
+		return
+	}
+	TenantKey.Set(ctx, tenant)
+}
+
+func (t *tenancy) document(_ *SwaggerGenerator, op *Operation) {
+	for _, r := range t.resolvers {
+		r.doc(op)
+	}
+	if op.Responses == nil {
+		op.Responses = map[string]Response{}
+	}
+	if !t.optional {
+		op.Responses["400"] = Response{Description: "No tenant given"}
+	}
+	if t.lookup != nil {
+		op.Responses["404"] = Response{Description: "Unknown tenant"}
+	}
+	if t.rate > 0 {
+		op.Responses["429"] = Response{Description: "The tenant's rate limit is exceeded"}
+	}
+}
+
+// allow takes a token from the tenant's bucket, or returns how long until one is available
+func (t *tenancy) allow(tenant Tenant) (time.Duration, bool) {
+	rate, burst := t.rate, t.burst
+	if tenant.RateLimit > 0 {
+		rate = tenant.RateLimit
+	}
+	if tenant.Burst > 0 {
+		burst = tenant.Burst
+	}
+	return t.buckets.allow(tenant.ID, rate, burst)
+}
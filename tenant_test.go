@@ -0,0 +1,139 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func tenantApp(resolvers []TenantResolver, opts ...TenancyOption) *App {
+	gin.SetMode(gin.TestMode)
+	app := New().WithSwagger("Tenants", "1.0")
+	app.UseTyped(Tenancy(resolvers, opts...))
+	app.GET("/projects", HandleCtx(func(ctx context.Context, _ struct{}) (gin.H, error) {
+		tenant, _ := CurrentTenant(ctx)
+		return gin.H{"tenant": tenant.ID, "name": tenant.Name}, nil
+	}))
+	return app
+}
+
+func serveTenant(app *App, host string, header http.Header) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("GET", "/projects", nil)
+	req.Host = host
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, req)
+	return w
+}
+
+func testJWT(payload string) string {
+	enc := base64.RawURLEncoding
+	return enc.EncodeToString([]byte(`{"alg":"HS256"}`)) + "." + enc.EncodeToString([]byte(payload)) + ".sig"
+}
+
+func TestTenancy_Resolvers(t *testing.T) {
+	app := tenantApp([]TenantResolver{
+		TenantFromSubdomain("example.com"),
+		TenantFromHeader("X-Tenant-ID"),
+		TenantFromClaim("org"),
+	})
+
+	tests := []struct {
+		name   string
+		host   string
+		header http.Header
+		want   string
+	}{
+		{"subdomain", "acme.example.com:8080", nil, `"tenant":"acme"`},
+		{"header", "api.other.org", http.Header{"X-Tenant-Id": {"globex"}}, `"tenant":"globex"`},
+		{"subdomain first", "acme.example.com", http.Header{"X-Tenant-Id": {"globex"}}, `"tenant":"acme"`},
+		{"claim", "example.com", http.Header{"Authorization": {"Bearer " + testJWT(`{"sub":"u1","org":"initech"}`)}}, `"tenant":"initech"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serveTenant(app, tt.host, tt.header)
+			if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), tt.want) {
+				t.Errorf("status = %d, body = %s, want %s", w.Code, w.Body, tt.want)
+			}
+		})
+	}
+
+	if w := serveTenant(app, "a.b.example.com", nil); w.Code != http.StatusBadRequest {
+		t.Errorf("nested subdomain: status = %d, want 400", w.Code)
+	}
+}
+
+func TestTenancy_Optional(t *testing.T) {
+	app := tenantApp([]TenantResolver{TenantFromHeader("X-Tenant-ID")}, WithTenantOptional())
+	if w := serveTenant(app, "", nil); w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"tenant":""`) {
+		t.Errorf("status = %d, body = %s", w.Code, w.Body)
+	}
+}
+
+func TestTenancy_Lookup(t *testing.T) {
+	app := tenantApp([]TenantResolver{TenantFromHeader("X-Tenant-ID")}, WithTenantLookup(func(_ context.Context, id string) (Tenant, error) {
+		if id != "acme" {
+			return Tenant{}, ErrTenantNotFound
+		}
+		return Tenant{ID: id, Name: "Acme Corp"}, nil
+	}))
+
+	w := serveTenant(app, "", http.Header{"X-Tenant-Id": {"acme"}})
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"name":"Acme Corp"`) {
+		t.Errorf("status = %d, body = %s", w.Code, w.Body)
+	}
+	if w := serveTenant(app, "", http.Header{"X-Tenant-Id": {"umbrella"}}); w.Code != http.StatusNotFound {
+		t.Errorf("unknown tenant: status = %d, want 404", w.Code)
+	}
+}
+
+func TestTenancy_RateLimit(t *testing.T) {
+	app := tenantApp([]TenantResolver{TenantFromHeader("X-Tenant-ID")},
+		WithTenantLookup(func(_ context.Context, id string) (Tenant, error) {
+			if id == "premium" {
+				return Tenant{ID: id, Burst: 5}, nil
+			}
+			return Tenant{ID: id}, nil
+		}),
+		WithTenantRateLimit(0.001, 2),
+	)
+
+	count := func(tenant string) (ok int, last *httptest.ResponseRecorder) {
+		for i := 0; i < 6; i++ {
+			last = serveTenant(app, "", http.Header{"X-Tenant-Id": {tenant}})
+			if last.Code == http.StatusOK {
+				ok++
+			}
+		}
+		return ok, last
+	}
+	if ok, last := count("acme"); ok != 2 || last.Code != http.StatusTooManyRequests || last.Header().Get("Retry-After") == "" {
+		t.Errorf("acme: %d requests allowed, last status %d", ok, last.Code)
+	}
+	// Each tenant has its own bucket, and premium a bigger burst
+	if ok, _ := count("premium"); ok != 5 {
+		t.Errorf("premium: %d requests allowed, want 5", ok)
+	}
+}
+
+func TestTenancy_Swagger(t *testing.T) {
+	app := tenantApp([]TenantResolver{TenantFromHeader("X-Tenant-ID")}, WithTenantRateLimit(10, 10))
+	doc, err := app.swagger.SpecJSON(app.specHandlers())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`"name":"X-Tenant-ID"`, `"429"`, `"400"`} {
+		if !strings.Contains(string(doc), want) {
+			t.Errorf("spec lacks %s: %s", want, doc)
+		}
+	}
+}
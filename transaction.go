@@ -0,0 +1,162 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Transactor begins, commits and rolls back transactions of type Tx
+type Transactor[Tx any] interface {
+	Begin(ctx context.Context) (Tx, error)
+	Commit(tx Tx) error
+	Rollback(tx Tx) error
+}
+
+// TxOption configures the transactions opened per request
+type TxOption func(*sql.TxOptions)
+
+// WithIsolation sets the isolation level of the transactions
+func WithIsolation(level sql.IsolationLevel) TxOption {
+	return func(o *sql.TxOptions) { o.Isolation = level }
+}
+
+// WithReadOnlyTx opens read-only transactions
+func WithReadOnlyTx() TxOption {
+	return func(o *sql.TxOptions) { o.ReadOnly = true }
+}
+
+type txKey[Tx any] struct{}
+
+// Transaction returns middleware running the rest of the request in a transaction from t. The
+// response is held back until the transaction ends: it is committed when the handlers finish
+// with a 2xx status, and rolled back on any other status or a panic. If the commit fails the
+// client gets the error instead of the held back response.
+func Transaction[Tx any](t Transactor[Tx]) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		tx, err := t.Begin(ctx.Request.Context())
+		if err != nil {
+			writeError(ctx, fmt.Errorf("begin transaction: %w", err))
+			ctx.Abort()
+			return
+		}
+		ctx.Set(txKey[Tx]{}, tx)
+
+		w := &txWriter{ResponseWriter: ctx.Writer, status: http.StatusOK}
+		ctx.Writer = w
+		done := false
+		defer func() {
+			if done {
+				return
+			}
+			// A panic is on its way up: undo the work and drop the held back response
+			ctx.Writer = w.ResponseWriter
+			_ = t.Rollback(tx)
+		}()
+
+		ctx.Next()
+
+		ctx.Writer = w.ResponseWriter
+		done = true
+		if w.status < 200 || w.status >= 300 {
+			_ = t.Rollback(tx)
+			w.flush()
+			return
+		}
+		if err := t.Commit(tx); err != nil {
+			writeError(ctx, fmt.Errorf("commit transaction: %w", err))
+			return
+		}
+		w.flush()
+	}
+}
+
+// TxFrom returns the transaction of type Tx opened for the request by Transaction
+func TxFrom[Tx any](ctx *Context) (Tx, bool) {
+	v, _ := ctx.Get(txKey[Tx]{})
+	tx, ok := v.(Tx)
+	return tx, ok
+}
+
+// Transactional returns middleware running each request in a database/sql transaction, see Transaction.
+// Handlers get it with SQLTx:
+//
+//	api := app.TypedGroup("/api", fluxo.Transactional(db))
+//	tx := fluxo.SQLTx(ctx)
+func Transactional(db *sql.DB, opts ...TxOption) gin.HandlerFunc {
+	o := &sql.TxOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return Transaction[*sql.Tx](sqlTransactor{db: db, opts: o})
+}
+
+// SQLTx returns the transaction opened by Transactional, or nil outside of one
+func SQLTx(ctx *Context) *sql.Tx {
+	tx, _ := TxFrom[*sql.Tx](ctx)
+	return tx
+}
+
+type sqlTransactor struct {
+	db   *sql.DB
+	opts *sql.TxOptions
+}
+
+func (s sqlTransactor) Begin(ctx context.Context) (*sql.Tx, error) { return s.db.BeginTx(ctx, s.opts) }
+func (s sqlTransactor) Commit(tx *sql.Tx) error                    { return tx.Commit() }
+func (s sqlTransactor) Rollback(tx *sql.Tx) error                  { return tx.Rollback() }
+
+// txWriter holds the response back until the transaction is over
+type txWriter struct {
+	gin.ResponseWriter
+	status  int
+	written bool
+	body    bytes.Buffer
+}
+
+func (w *txWriter) WriteHeader(code int) {
+	if code > 0 {
+		w.status = code
+	}
+}
+
+func (w *txWriter) WriteHeaderNow() { w.written = true }
+
+func (w *txWriter) Write(data []byte) (int, error) {
+	w.written = true
+	return w.body.Write(data)
+}
+
+func (w *txWriter) WriteString(s string) (int, error) {
+	w.written = true
+	return w.body.WriteString(s)
+}
+
+func (w *txWriter) Status() int { return w.status }
+
+func (w *txWriter) Size() int {
+	if !w.written {
+		return -1
+	}
+	return w.body.Len()
+}
+
+func (w *txWriter) Written() bool { return w.written }
+
+// Flush is a no-op, streaming would send the response before the commit
+func (w *txWriter) Flush() {}
+
+func (w *txWriter) flush() {
+	w.ResponseWriter.WriteHeader(w.status)
+	if w.body.Len() > 0 {
+		_, _ = w.ResponseWriter.Write(w.body.Bytes())
+	} else if w.written {
+		w.ResponseWriter.WriteHeaderNow()
+	}
+}
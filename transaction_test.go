@@ -0,0 +1,123 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+type txNoteReq struct {
+	Text string `json:"text" validate:"required"`
+}
+
+type txNoteRes struct {
+	Text string `json:"text"`
+}
+
+func txOpenDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+	if _, err := db.Exec(`CREATE TABLE notes (text TEXT NOT NULL)`); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func txCount(t *testing.T, db *sql.DB) int {
+	t.Helper()
+	var n int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM notes`).Scan(&n); err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+func txCreateNote(ctx *Context, req txNoteReq) (txNoteRes, error) {
+	tx := SQLTx(ctx)
+	if tx == nil {
+		return txNoteRes{}, errors.New("no transaction")
+	}
+	if _, err := tx.ExecContext(ctx, `INSERT INTO notes (text) VALUES (?)`, req.Text); err != nil {
+		return txNoteRes{}, err
+	}
+	switch req.Text {
+	case "reject":
+		return txNoteRes{}, Conflict("rejected after insert")
+	case "panic":
+		panic("boom")
+	}
+	return txNoteRes{Text: req.Text}, nil
+}
+
+func txPost(app *App, text string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/notes", strings.NewReader(`{"text":"`+text+`"}`))
+	req.Header.Set("Content-Type", "application/json")
+	app.ServeHTTP(w, req)
+	return w
+}
+
+func TestTransactionalCommitsAndRollsBack(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := txOpenDB(t)
+	app := New()
+	app.Use(gin.Recovery())
+	app.POST("/notes", Transactional(db), Handle(txCreateNote))
+
+	if w := txPost(app, "kept"); w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"text":"kept"`) {
+		t.Fatalf("status %d: %s", w.Code, w.Body.String())
+	}
+	if w := txPost(app, "reject"); w.Code != http.StatusConflict || !strings.Contains(w.Body.String(), "rejected") {
+		t.Fatalf("expected the handler's error, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := txPost(app, "panic"); w.Code != http.StatusInternalServerError {
+		t.Fatalf("expected recovery to answer 500, got %d", w.Code)
+	}
+	if n := txCount(t, db); n != 1 {
+		t.Fatalf("expected only the committed note, got %d rows", n)
+	}
+}
+
+// failingCommit wraps the sql transactor with a commit that always fails
+type failingCommit struct{ sqlTransactor }
+
+func (f failingCommit) Commit(tx *sql.Tx) error {
+	_ = tx.Rollback()
+	return errors.New("serialization failure")
+}
+
+func TestTransactionCommitFailureReplacesResponse(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := txOpenDB(t)
+	app := New()
+	app.POST("/notes", Transaction[*sql.Tx](failingCommit{sqlTransactor{db: db}}), Handle(txCreateNote))
+
+	w := txPost(app, "lost")
+	if w.Code != http.StatusInternalServerError || !strings.Contains(w.Body.String(), "serialization failure") || strings.Contains(w.Body.String(), `"text"`) {
+		t.Fatalf("expected the commit error instead of the response, got %d: %s", w.Code, w.Body.String())
+	}
+	if n := txCount(t, db); n != 0 {
+		t.Fatalf("expected no rows, got %d", n)
+	}
+}
+
+func TestTxFromOutsideTransaction(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	if SQLTx(&Context{Context: c}) != nil {
+		t.Fatal("expected no transaction")
+	}
+}
@@ -0,0 +1,204 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goccy/go-yaml"
+)
+
+// TranslationOption configures LoadTranslations
+type TranslationOption func(*translationSource)
+
+// ReloadEvery re-reads changed, added and removed files on the next lookup after interval has
+// passed since the last check. Meant for development with os.DirFS; embedded files never change.
+// Files that fail to load on reload keep their previous messages.
+func ReloadEvery(interval time.Duration) TranslationOption {
+	return func(s *translationSource) {
+		s.interval = interval
+	}
+}
+
+// LoadTranslations loads the messages of the files matching pattern in fsys into the default
+// validator. Each file holds one language, named after the file (locales/id.yaml is "id"), and
+// maps validation tags to messages with {field} and {param} placeholders, and the keys of Errorf
+// to messages with {0}, {1}, ... placeholders. Nested keys are joined with dots:
+//
+//	required: "{field} wajib diisi"
+//	min: "{field} minimal {param} karakter"
+//	todo:
+//	  not_found: "todo {0} tidak ditemukan"
+//
+// YAML (.yaml, .yml) and JSON (.json) files are supported. Messages replace those registered
+// earlier for the same language and key.
+//
+//	//go:embed locales
+//	var locales embed.FS
+//
+//	fluxo.LoadTranslations(locales, "locales/*.yaml")
+func LoadTranslations(fsys fs.FS, pattern string, opts ...TranslationOption) error {
+	return defaultValidator.LoadTranslations(fsys, pattern, opts...)
+}
+
+// LoadTranslations loads translation files into this validator, see the package-level LoadTranslations
+func (v *Validator) LoadTranslations(fsys fs.FS, pattern string, opts ...TranslationOption) error {
+	s := &translationSource{fsys: fsys, pattern: pattern, files: map[string]translationFile{}}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if err := s.load(v, false); err != nil {
+		return err
+	}
+	s.checked = time.Now()
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.sources = append(v.sources, s)
+	return nil
+}
+
+// reloadTranslations reloads the sources that are due for a check
+func (v *Validator) reloadTranslations() {
+	v.mu.RLock()
+	sources := v.sources
+	v.mu.RUnlock()
+
+	for _, s := range sources {
+		if s.interval <= 0 {
+			continue
+		}
+		s.mu.Lock()
+		if time.Since(s.checked) >= s.interval {
+			s.checked = time.Now()
+			_ = s.load(v, true)
+		}
+		s.mu.Unlock()
+	}
+}
+
+// translationSource is a set of translation files loaded into a validator
+type translationSource struct {
+	fsys     fs.FS
+	pattern  string
+	interval time.Duration
+
+	mu      sync.Mutex
+	checked time.Time
+	files   map[string]translationFile // path -> what was loaded from it
+}
+
+type translationFile struct {
+	modTime time.Time
+	lang    string
+	tags    []string
+}
+
+// load reads the files matching the pattern into v. On reload, unchanged files are skipped,
+// messages of removed files are dropped and files that fail to load are kept as they were.
+func (s *translationSource) load(v *Validator, reload bool) error {
+	paths, err := fs.Glob(s.fsys, s.pattern)
+	if err != nil {
+		return fmt.Errorf("translations %q: %w", s.pattern, err)
+	}
+
+	seen := make(map[string]bool, len(paths))
+	for _, p := range paths {
+		seen[p] = true
+		info, err := fs.Stat(s.fsys, p)
+		if err != nil {
+			if reload {
+				continue
+			}
+			return fmt.Errorf("translations %s: %w", p, err)
+		}
+		prev, loaded := s.files[p]
+		if loaded && info.ModTime().Equal(prev.modTime) {
+			continue
+		}
+		lang, messages, err := readTranslationFile(s.fsys, p)
+		if err != nil {
+			if reload {
+				continue
+			}
+			return err
+		}
+		if loaded {
+			v.removeTranslations(prev.lang, prev.tags)
+		}
+		file := translationFile{modTime: info.ModTime(), lang: lang}
+		for tag, message := range messages {
+			v.setTranslation(lang, tag, translation{message: message, named: true})
+			file.tags = append(file.tags, tag)
+		}
+		s.files[p] = file
+	}
+
+	for p, file := range s.files {
+		if !seen[p] {
+			v.removeTranslations(file.lang, file.tags)
+			delete(s.files, p)
+		}
+	}
+	return nil
+}
+
+// readTranslationFile returns the language and messages of a translation file
+func readTranslationFile(fsys fs.FS, p string) (string, map[string]string, error) {
+	data, err := fs.ReadFile(fsys, p)
+	if err != nil {
+		return "", nil, fmt.Errorf("translations %s: %w", p, err)
+	}
+	ext := path.Ext(p)
+	var doc map[string]any
+	switch strings.ToLower(ext) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &doc)
+	case ".json":
+		err = json.Unmarshal(data, &doc)
+	default:
+		return "", nil, fmt.Errorf("translations %s: unsupported file type %q", p, ext)
+	}
+	if err != nil {
+		return "", nil, fmt.Errorf("translations %s: %w", p, err)
+	}
+	messages := map[string]string{}
+	if err := flattenMessages(messages, "", doc); err != nil {
+		return "", nil, fmt.Errorf("translations %s: %w", p, err)
+	}
+	return strings.TrimSuffix(path.Base(p), ext), messages, nil
+}
+
+// flattenMessages adds the messages of doc to messages, joining nested keys with dots
+func flattenMessages(messages map[string]string, prefix string, doc map[string]any) error {
+	for key, value := range doc {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		switch value := value.(type) {
+		case string:
+			messages[key] = value
+		case map[string]any:
+			if err := flattenMessages(messages, key, value); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("message %s is a %T, not a string", key, value)
+		}
+	}
+	return nil
+}
+
+func (v *Validator) removeTranslations(lang string, tags []string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	for _, tag := range tags {
+		delete(v.translations[lang], tag)
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func TestLoadTranslations(t *testing.T) {
+	fsys := fstest.MapFS{
+		"locales/id.yaml":   {Data: []byte("required: \"{field} wajib diisi\"\nmin: \"{field} minimal {param} karakter\"\n")},
+		"locales/fr.json":   {Data: []byte(`{"required": "{field} est obligatoire"}`)},
+		"locales/notes.txt": {Data: []byte("ignored by the pattern")},
+	}
+	v := NewValidator()
+	v.RegisterTranslation("id", "email", "%s harus email")
+	if err := v.LoadTranslations(fsys, "locales/*.yaml"); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.LoadTranslations(fsys, "locales/*.json"); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct{ lang, tag, param, want string }{
+		{"id", "required", "", "Name wajib diisi"},
+		{"id", "min", "3", "Name minimal 3 karakter"},
+		{"id", "email", "", "Name harus email"},
+		{"fr", "required", "", "Name est obligatoire"},
+		{"fr", "min", "3", "Name must be at least 3 characters"},
+	}
+	for _, tt := range tests {
+		if got := v.message(tt.lang, "Name", tt.tag, tt.param); got != tt.want {
+			t.Errorf("%s %s: expected %q, got %q", tt.lang, tt.tag, tt.want, got)
+		}
+	}
+}
+
+func TestLoadTranslations_Errors(t *testing.T) {
+	v := NewValidator()
+	if err := v.LoadTranslations(fstest.MapFS{"l/en.yaml": {Data: []byte("required: [")}}, "l/*"); err == nil || !strings.Contains(err.Error(), "l/en.yaml") {
+		t.Fatalf("expected the broken file to be named, got %v", err)
+	}
+	if err := v.LoadTranslations(fstest.MapFS{"l/en.toml": {Data: []byte("")}}, "l/*"); err == nil || !strings.Contains(err.Error(), "unsupported") {
+		t.Fatalf("expected an unsupported file type, got %v", err)
+	}
+	if err := v.LoadTranslations(fstest.MapFS{"l/en.json": {Data: []byte(`{"todo": {"count": 1}}`)}}, "l/*"); err == nil || !strings.Contains(err.Error(), "todo.count") {
+		t.Fatalf("expected a non-string message to be named, got %v", err)
+	}
+	if err := v.LoadTranslations(fstest.MapFS{}, "["); err == nil {
+		t.Fatal("expected a bad pattern to fail")
+	}
+}
+
+func TestLoadTranslations_Reload(t *testing.T) {
+	start := time.Now()
+	fsys := fstest.MapFS{
+		"l/id.yaml": {Data: []byte(`required: "{field} wajib diisi"`), ModTime: start},
+		"l/de.yaml": {Data: []byte(`required: "{field} ist erforderlich"`), ModTime: start},
+	}
+	v := NewValidator()
+	if err := v.LoadTranslations(fsys, "l/*.yaml", ReloadEvery(time.Nanosecond)); err != nil {
+		t.Fatal(err)
+	}
+
+	fsys["l/id.yaml"] = &fstest.MapFile{Data: []byte(`min: "{field} minimal {param}"`), ModTime: start.Add(time.Second)}
+	delete(fsys, "l/de.yaml")
+	fsys["l/fr.yaml"] = &fstest.MapFile{Data: []byte(`required: "{field} est obligatoire"`), ModTime: start}
+	time.Sleep(time.Millisecond)
+
+	if got := v.message("id", "Name", "min", "3"); got != "Name minimal 3" {
+		t.Fatalf("expected the changed file to be reloaded, got %q", got)
+	}
+	if got := v.message("id", "Name", "required", ""); got != "Name is required" {
+		t.Fatalf("expected a message removed from the file to be dropped, got %q", got)
+	}
+	if got := v.message("de", "Name", "required", ""); got != "Name is required" {
+		t.Fatalf("expected the removed file to be dropped, got %q", got)
+	}
+	if got := v.message("fr", "Name", "required", ""); got != "Name est obligatoire" {
+		t.Fatalf("expected the new file to be loaded, got %q", got)
+	}
+
+	// A broken edit keeps the last good messages
+	fsys["l/fr.yaml"] = &fstest.MapFile{Data: []byte("required: ["), ModTime: start.Add(time.Second)}
+	time.Sleep(time.Millisecond)
+	if got := v.message("fr", "Name", "required", ""); got != "Name est obligatoire" {
+		t.Fatalf("expected the previous messages to be kept, got %q", got)
+	}
+}
+
+func TestLoadTranslations_NoReloadByDefault(t *testing.T) {
+	fsys := fstest.MapFS{"l/id.yaml": {Data: []byte(`required: "{field} wajib diisi"`)}}
+	v := NewValidator()
+	if err := v.LoadTranslations(fsys, "l/*.yaml"); err != nil {
+		t.Fatal(err)
+	}
+	fsys["l/id.yaml"] = &fstest.MapFile{Data: []byte(`required: "{field} harus diisi"`), ModTime: time.Now()}
+	if got := v.message("id", "Name", "required", ""); got != "Name wajib diisi" {
+		t.Fatalf("expected the files to be read once, got %q", got)
+	}
+}
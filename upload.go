@@ -0,0 +1,215 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// File upload rules for *multipart.FileHeader and UploadedFile fields and slices of them:
+//
+//	Photos []*multipart.FileHeader `form:"photos" validate:"required,maxfiles=10,maxsize=5MB,mime=image/png image/jpeg"`
+//
+// maxsize and mime apply to every file, maxfiles limits how many were sent.
+func registerFileRules(v *validator.Validate) {
+	_ = v.RegisterValidation("maxsize", validateFileSize)
+	_ = v.RegisterValidation("mime", validateFileMIME)
+	_ = v.RegisterValidation("maxfiles", validateFileCount)
+}
+
+// fileMeta is what the upload rules check of a file
+type fileMeta struct {
+	size      int64
+	mediaType func() string
+}
+
+// uploadedFiles returns the files held by a file field, reporting whether it is one
+func uploadedFiles(v reflect.Value) ([]fileMeta, bool) {
+	header := func(f *multipart.FileHeader) fileMeta {
+		return fileMeta{size: f.Size, mediaType: func() string { return fileMIME(f) }}
+	}
+	stored := func(f UploadedFile) fileMeta {
+		return fileMeta{size: f.Size, mediaType: func() string { return f.ContentType }}
+	}
+
+	var files []fileMeta
+	switch value := v.Interface().(type) {
+	case *multipart.FileHeader:
+		if value != nil {
+			files = append(files, header(value))
+		}
+	case []*multipart.FileHeader:
+		for _, f := range value {
+			if f != nil {
+				files = append(files, header(f))
+			}
+		}
+	case UploadedFile:
+		if value != (UploadedFile{}) {
+			files = append(files, stored(value))
+		}
+	case []UploadedFile:
+		for _, f := range value {
+			files = append(files, stored(f))
+		}
+	default:
+		return nil, false
+	}
+	return files, true
+}
+
+func validateFileSize(fl validator.FieldLevel) bool {
+	files, ok := uploadedFiles(fl.Field())
+	limit, err := parseFileSize(fl.Param())
+	if !ok || err != nil {
+		return false
+	}
+	for _, f := range files {
+		if f.size > limit {
+			return false
+		}
+	}
+	return true
+}
+
+func validateFileMIME(fl validator.FieldLevel) bool {
+	files, ok := uploadedFiles(fl.Field())
+	if !ok {
+		return false
+	}
+	allowed := strings.Fields(fl.Param())
+	for _, f := range files {
+		if !mimeAllowed(f.mediaType(), allowed) {
+			return false
+		}
+	}
+	return true
+}
+
+func validateFileCount(fl validator.FieldLevel) bool {
+	files, ok := uploadedFiles(fl.Field())
+	limit, err := strconv.Atoi(fl.Param())
+	return ok && err == nil && len(files) <= limit
+}
+
+// fileMIME sniffs the content of f, falling back to the declared type when sniffing is inconclusive
+func fileMIME(f *multipart.FileHeader) string {
+	sniffed := "application/octet-stream"
+	if file, err := f.Open(); err == nil {
+		buf := make([]byte, 512)
+		n, _ := file.Read(buf)
+		_ = file.Close()
+		sniffed = http.DetectContentType(buf[:n])
+	}
+	if mt, _, err := mime.ParseMediaType(sniffed); err == nil && mt != "application/octet-stream" {
+		return mt
+	}
+	mt, _, _ := mime.ParseMediaType(f.Header.Get("Content-Type"))
+	return mt
+}
+
+// mimeAllowed matches mediaType against allowed types, which may end in a /* wildcard
+func mimeAllowed(mediaType string, allowed []string) bool {
+	for _, a := range allowed {
+		if strings.EqualFold(a, mediaType) {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(a, "/*"); ok && strings.HasPrefix(mediaType, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// parseFileSize parses sizes such as 512, 100KB, 5MB or 1GB (binary multiples)
+func parseFileSize(s string) (int64, error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	multiplier := int64(1)
+	for _, unit := range []struct {
+		suffix string
+		size   int64
+	}{{"KB", 1 << 10}, {"MB", 1 << 20}, {"GB", 1 << 30}, {"B", 1}} {
+		if n, ok := strings.CutSuffix(s, unit.suffix); ok {
+			s, multiplier = n, unit.size
+			break
+		}
+	}
+	n, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return n * multiplier, nil
+}
+
+// validationRule returns the parameter of rule in a validate tag
+func validationRule(tag, rule string) (string, bool) {
+	for _, part := range strings.Split(tag, ",") {
+		if param, ok := strings.CutPrefix(part, rule+"="); ok {
+			return param, true
+		}
+	}
+	return "", false
+}
+
+// applyFileConstraints documents upload rules of a file field on its schema
+func applyFileConstraints(schema *Schema, validateTag string) {
+	file := schema
+	if schema.Type == "array" && schema.Items != nil {
+		file = schema.Items
+	}
+	if file.Format != "binary" {
+		return
+	}
+	if file != schema {
+		if n, ok := validationRule(validateTag, "maxfiles"); ok {
+			if limit, err := strconv.Atoi(n); err == nil {
+				schema.MaxItems = &limit
+			}
+		}
+	}
+	if size, ok := validationRule(validateTag, "maxsize"); ok {
+		if limit, err := parseFileSize(size); err == nil {
+			file.MaxSize = limit
+		}
+	}
+}
+
+// fileEncodings returns the multipart encoding of file fields in t restricted by a mime rule
+func fileEncodings(t reflect.Type) map[string]Encoding {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	var encodings map[string]Encoding
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if embedded, ok := embeddedStruct(field); ok {
+			for name, enc := range fileEncodings(embedded) {
+				if encodings == nil {
+					encodings = make(map[string]Encoding)
+				}
+				encodings[name] = enc
+			}
+			continue
+		}
+		name := strings.Split(field.Tag.Get("form"), ",")[0]
+		types, ok := validationRule(field.Tag.Get("validate"), "mime")
+		if name == "" || name == "-" || !ok {
+			continue
+		}
+		if encodings == nil {
+			encodings = make(map[string]Encoding)
+		}
+		encodings[name] = Encoding{ContentType: strings.Join(strings.Fields(types), ", ")}
+	}
+	return encodings
+}
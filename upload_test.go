@@ -0,0 +1,124 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type uploadReq struct {
+	Photos []*multipart.FileHeader `form:"photos" validate:"required,maxfiles=2,maxsize=1KB,mime=image/png image/jpeg"`
+}
+
+type uploadRes struct {
+	Count int `json:"count"`
+}
+
+var pngHeader = []byte("\x89PNG\r\n\x1a\n")
+
+type testFile struct {
+	name        string
+	contentType string
+	data        []byte
+	digest      string // Content-MD5 of the part, if set
+}
+
+func multipartRequest(t *testing.T, field string, files ...testFile) *http.Request {
+	t.Helper()
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	for _, f := range files {
+		h := textproto.MIMEHeader{}
+		h.Set("Content-Disposition", `form-data; name="`+field+`"; filename="`+f.name+`"`)
+		h.Set("Content-Type", f.contentType)
+		part, err := w.CreatePart(h)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _ = part.Write(f.data)
+	}
+	_ = w.Close()
+	req := httptest.NewRequest(http.MethodPost, "/upload", &body)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	return req
+}
+
+func newUploadApp() *App {
+	gin.SetMode(gin.TestMode)
+	app := New().WithSwagger("Uploads", "1.0.0")
+	app.POST("/upload", Handle(func(ctx *Context, req uploadReq) (uploadRes, error) {
+		return uploadRes{Count: len(req.Photos)}, nil
+	}))
+	return app
+}
+
+func TestUpload_Validation(t *testing.T) {
+	app := newUploadApp()
+	png := testFile{name: "a.png", contentType: "image/png", data: pngHeader}
+
+	tests := []struct {
+		name   string
+		files  []testFile
+		status int
+		msg    string
+	}{
+		{"valid", []testFile{png, png}, http.StatusOK, ""},
+		{"too many files", []testFile{png, png, png}, http.StatusBadRequest, "at most 2 files"},
+		{"too large", []testFile{{name: "b.png", contentType: "image/png", data: append(pngHeader, make([]byte, 2048)...)}}, http.StatusBadRequest, "at most 1KB"},
+		{"spoofed type", []testFile{{name: "c.png", contentType: "image/png", data: []byte("<html></html>")}}, http.StatusBadRequest, "file types"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			app.ServeHTTP(w, multipartRequest(t, "photos", tt.files...))
+			if w.Code != tt.status {
+				t.Fatalf("status=%d body=%s", w.Code, w.Body.String())
+			}
+			if tt.msg != "" && !strings.Contains(w.Body.String(), tt.msg) {
+				t.Fatalf("expected %q in %s", tt.msg, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestUpload_Swagger(t *testing.T) {
+	app := newUploadApp()
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+
+	var spec OpenAPISpec
+	if err := json.Unmarshal(w.Body.Bytes(), &spec); err != nil {
+		t.Fatal(err)
+	}
+	media := spec.Paths["/upload"].POST.RequestBody.Content["multipart/form-data"]
+	photos := media.Schema.Properties["photos"]
+	if photos.MaxItems == nil || *photos.MaxItems != 2 {
+		t.Fatalf("maxItems: %+v", photos.MaxItems)
+	}
+	if photos.Items == nil || photos.Items.MaxSize != 1024 {
+		t.Fatalf("items: %+v", photos.Items)
+	}
+	if media.Encoding["photos"].ContentType != "image/png, image/jpeg" {
+		t.Fatalf("encoding: %+v", media.Encoding)
+	}
+}
+
+func TestParseFileSize(t *testing.T) {
+	for in, want := range map[string]int64{"512": 512, "100KB": 100 << 10, "5MB": 5 << 20, "1gb": 1 << 30, "10B": 10} {
+		if got, err := parseFileSize(in); err != nil || got != want {
+			t.Errorf("%s: got %d err=%v", in, got, err)
+		}
+	}
+	if _, err := parseFileSize("lots"); err == nil {
+		t.Error("expected error")
+	}
+}
@@ -0,0 +1,274 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Quota headers set on metered responses
+const (
+	QuotaLimitHeader     = "X-Quota-Limit"
+	QuotaRemainingHeader = "X-Quota-Remaining"
+	QuotaResetHeader     = "X-Quota-Reset" // seconds until the period ends
+)
+
+// Usage is what a client used in a period
+type Usage struct {
+	Requests int64 `json:"requests"`
+	Bytes    int64 `json:"bytes"` // of request and response bodies
+}
+
+// UsageRecord is the usage of a client in one period
+type UsageRecord struct {
+	Key    string    `json:"key"`
+	Period time.Time `json:"period"` // when the period starts
+	Usage
+}
+
+// UsageQuery selects usage records. Zero fields select everything.
+type UsageQuery struct {
+	Key  string    `form:"key"`
+	From time.Time `form:"from"` // periods starting at or after From
+	To   time.Time `form:"to"`   // periods starting before To
+}
+
+// matches reports whether q selects the record of key in period
+func (q UsageQuery) matches(key string, period time.Time) bool {
+	return (q.Key == "" || q.Key == key) && (q.From.IsZero() || !period.Before(q.From)) && (q.To.IsZero() || period.Before(q.To))
+}
+
+// UsageStore keeps the usage counters of a UsageMeter. MemoryUsageStore keeps them in the
+// process; a store shared by every instance, e.g. on Redis or SQL, enforces quotas across them.
+type UsageStore interface {
+	// Add adds delta to the usage of key in the period starting at period, returning the total
+	Add(ctx context.Context, key string, period time.Time, delta Usage) (Usage, error)
+	// Query returns the records q selects, sorted by period and key
+	Query(ctx context.Context, q UsageQuery) ([]UsageRecord, error)
+}
+
+type usageSlot struct {
+	key    string
+	period int64
+}
+
+// MemoryUsageStore is an in-process UsageStore
+type MemoryUsageStore struct {
+	mu    sync.Mutex
+	usage map[usageSlot]Usage
+}
+
+// NewMemoryUsageStore returns an empty in-process store
+func NewMemoryUsageStore() *MemoryUsageStore {
+	return &MemoryUsageStore{usage: make(map[usageSlot]Usage)}
+}
+
+func (s *MemoryUsageStore) Add(_ context.Context, key string, period time.Time, delta Usage) (Usage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	slot := usageSlot{key: key, period: period.Unix()}
+	u := s.usage[slot]
+	u.Requests += delta.Requests
+	u.Bytes += delta.Bytes
+	s.usage[slot] = u
+	return u, nil
+}
+
+func (s *MemoryUsageStore) Query(_ context.Context, q UsageQuery) ([]UsageRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	records := []UsageRecord{}
+	for slot, u := range s.usage {
+		period := time.Unix(slot.period, 0).UTC()
+		if q.matches(slot.key, period) {
+			records = append(records, UsageRecord{Key: slot.key, Period: period, Usage: u})
+		}
+	}
+	sortUsage(records)
+	return records, nil
+}
+
+// Prune drops the records of periods starting before t
+func (s *MemoryUsageStore) Prune(t time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for slot := range s.usage {
+		if slot.period < t.Unix() {
+			delete(s.usage, slot)
+		}
+	}
+}
+
+func sortUsage(records []UsageRecord) {
+	sort.Slice(records, func(i, j int) bool {
+		if !records[i].Period.Equal(records[j].Period) {
+			return records[i].Period.Before(records[j].Period)
+		}
+		return records[i].Key < records[j].Key
+	})
+}
+
+// Quota limits what a client may use in a period; zero fields are unlimited
+type Quota struct {
+	Requests int64
+	Bytes    int64
+}
+
+// UsageMeter counts the requests and bytes of each client per period and enforces quotas
+type UsageMeter struct {
+	key    func(ctx *Context) string
+	store  UsageStore
+	period func(t time.Time) (start, end time.Time)
+	quota  func(ctx context.Context, key string) (Quota, error)
+}
+
+// UsageOption configures a UsageMeter
+type UsageOption func(*UsageMeter)
+
+// WithUsageStore keeps the counters in store (default: a MemoryUsageStore)
+func WithUsageStore(store UsageStore) UsageOption {
+	return func(m *UsageMeter) { m.store = store }
+}
+
+// WithUsagePeriod counts usage in periods of d, aligned to the Unix epoch (default: calendar months, UTC)
+func WithUsagePeriod(d time.Duration) UsageOption {
+	if d <= 0 {
+		panic("fluxo: WithUsagePeriod needs a positive period")
+	}
+	return func(m *UsageMeter) {
+		m.period = func(t time.Time) (time.Time, time.Time) {
+			start := t.UTC().Truncate(d)
+			return start, start.Add(d)
+		}
+	}
+}
+
+// WithQuota gives every client quota
+func WithQuota(quota Quota) UsageOption {
+	return func(m *UsageMeter) {
+		m.quota = func(context.Context, string) (Quota, error) { return quota, nil }
+	}
+}
+
+// WithQuotaLookup looks up the quota of each client, e.g. from its plan
+func WithQuotaLookup(lookup func(ctx context.Context, key string) (Quota, error)) UsageOption {
+	return func(m *UsageMeter) { m.quota = lookup }
+}
+
+// UsageByHeader keys usage by a request header, such as an API key
+func UsageByHeader(name string) func(ctx *Context) string {
+	return func(ctx *Context) string { return ctx.GetHeader(name) }
+}
+
+// NewUsageMeter returns a meter keying requests with key; requests with an empty key aren't metered
+//
+//	meter := fluxo.NewUsageMeter(fluxo.UsageByHeader("X-API-Key"), fluxo.WithQuota(fluxo.Quota{Requests: 10000}))
+//	api := app.TypedGroup("/api", meter.Middleware())
+//	admin.GET("/usage", meter.QueryHandler())
+func NewUsageMeter(key func(ctx *Context) string, opts ...UsageOption) *UsageMeter {
+	m := &UsageMeter{key: key, store: NewMemoryUsageStore(), period: monthOf}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// monthOf returns the calendar month of t, in UTC
+func monthOf(t time.Time) (time.Time, time.Time) {
+	t = t.UTC()
+	start := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return start, start.AddDate(0, 1, 0)
+}
+
+// Middleware returns middleware counting the requests of each client and their request and
+// response bodies. Once a client's quota is used up, its requests get 429 with a Retry-After
+// header until the period ends; they are counted too. Responses carry the X-Quota headers of
+// the request quota. When the store fails, requests are let through and the error is logged.
+func (m *UsageMeter) Middleware() gin.HandlerFunc {
+	handler := func(ctx *gin.Context) {
+		c := &Context{Context: ctx}
+		key := m.key(c)
+		if key == "" {
+			return
+		}
+		start, end := m.period(time.Now())
+		quota := Quota{}
+		if m.quota != nil {
+			q, err := m.quota(ctx, key)
+			if err != nil {
+				m.fail(ctx, err)
+				return
+			}
+			quota = q
+		}
+
+		used, err := m.store.Add(ctx, key, start, Usage{Requests: 1, Bytes: max(ctx.Request.ContentLength, 0)})
+		if err != nil {
+			m.fail(ctx, err)
+			return
+		}
+		reset := strconv.Itoa(int(math.Ceil(time.Until(end).Seconds())))
+		if quota.Requests > 0 {
+			ctx.Header(QuotaLimitHeader, strconv.FormatInt(quota.Requests, 10))
+			ctx.Header(QuotaRemainingHeader, strconv.FormatInt(max(quota.Requests-used.Requests, 0), 10))
+			ctx.Header(QuotaResetHeader, reset)
+		}
+		if (quota.Requests > 0 && used.Requests > quota.Requests) || (quota.Bytes > 0 && used.Bytes > quota.Bytes) {
+			ctx.Header("Retry-After", reset)
+			ctx.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "Usage quota exceeded"})
+			return
+		}
+
+		ctx.Next()
+		if size := ctx.Writer.Size(); size > 0 {
+			if _, err := m.store.Add(context.WithoutCancel(ctx), key, start, Usage{Bytes: int64(size)}); err != nil {
+				m.fail(ctx, err)
+			}
+		}
+	}
+	registerOperationDoc(handler, func(_ *SwaggerGenerator, op *Operation) {
+		if op.Responses == nil {
+			op.Responses = map[string]Response{}
+		}
+		op.Responses["429"] = Response{Description: "The client's usage quota is exceeded"}
+	})
+	return handler
+}
+
+// fail logs a store or lookup error of the meter
+func (m *UsageMeter) fail(ctx *gin.Context, err error) {
+	if a, ok := appFrom(ctx); ok {
+		a.Logger().Error("fluxo: usage metering failed", "path", ctx.Request.URL.Path, "error", err)
+	}
+}
+
+// Query returns the usage records q selects, e.g. for billing or a usage dashboard
+func (m *UsageMeter) Query(ctx context.Context, q UsageQuery) ([]UsageRecord, error) {
+	return m.store.Query(ctx, q)
+}
+
+// Usage returns what key has used in the current period
+func (m *UsageMeter) Usage(ctx context.Context, key string) (Usage, error) {
+	start, end := m.period(time.Now())
+	records, err := m.store.Query(ctx, UsageQuery{Key: key, From: start, To: end})
+	if err != nil || len(records) == 0 {
+		return Usage{}, err
+	}
+	return records[0].Usage, nil
+}
+
+// QueryHandler returns a handler serving Query, filtered by the key, from and to (RFC 3339)
+// query parameters. Mount it where only operators can reach it.
+func (m *UsageMeter) QueryHandler() gin.HandlerFunc {
+	return Handle(func(ctx *Context, q UsageQuery) ([]UsageRecord, error) {
+		return m.Query(ctx, q)
+	})
+}
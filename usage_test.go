@@ -0,0 +1,148 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func usageApp(meter *UsageMeter) *App {
+	app := New(WithMode(gin.TestMode), WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil)))).WithSwagger("API", "1.0")
+	api := app.TypedGroup("/api", meter.Middleware())
+	api.GET("/todos", Handle(func(ctx *Context, _ struct{}) ([]string, error) {
+		return []string{"ship"}, nil
+	}))
+	app.GET("/admin/usage", meter.QueryHandler())
+	return app
+}
+
+func usageGet(app *App, path, key string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("GET", path, nil)
+	if key != "" {
+		req.Header.Set("X-API-Key", key)
+	}
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, req)
+	return w
+}
+
+func TestUsageMeter(t *testing.T) {
+	meter := NewUsageMeter(UsageByHeader("X-API-Key"), WithQuotaLookup(func(ctx context.Context, key string) (Quota, error) {
+		if key == "pro" {
+			return Quota{}, nil
+		}
+		return Quota{Requests: 2}, nil
+	}))
+	app := usageApp(meter)
+
+	for i, want := range []int{200, 200, 429} {
+		w := usageGet(app, "/api/todos", "free")
+		if w.Code != want {
+			t.Fatalf("request %d = %d", i, w.Code)
+		}
+		if remaining := w.Header().Get(QuotaRemainingHeader); remaining != []string{"1", "0", "0"}[i] {
+			t.Errorf("request %d: remaining = %q", i, remaining)
+		}
+		if w.Code == http.StatusTooManyRequests && w.Header().Get("Retry-After") == "" {
+			t.Error("no Retry-After")
+		}
+	}
+	for range 3 {
+		if w := usageGet(app, "/api/todos", "pro"); w.Code != http.StatusOK || w.Header().Get(QuotaLimitHeader) != "" {
+			t.Errorf("unlimited client = %d, headers %v", w.Code, w.Header())
+		}
+	}
+	if w := usageGet(app, "/api/todos", ""); w.Code != http.StatusOK {
+		t.Errorf("anonymous = %d", w.Code)
+	}
+
+	used, err := meter.Usage(context.Background(), "pro")
+	if err != nil || used.Requests != 3 || used.Bytes != 3*int64(len(`["ship"]`)) {
+		t.Errorf("usage = %+v, %v", used, err)
+	}
+
+	w := usageGet(app, "/admin/usage?key=free", "")
+	var records []UsageRecord
+	if err := json.Unmarshal(w.Body.Bytes(), &records); err != nil || len(records) != 1 || records[0].Requests != 3 {
+		t.Errorf("query = %d %s", w.Code, w.Body)
+	}
+	if w := usageGet(app, "/admin/usage", ""); !strings.Contains(w.Body.String(), `"key":"free"`) || !strings.Contains(w.Body.String(), `"key":"pro"`) {
+		t.Errorf("all keys = %s", w.Body)
+	}
+
+	spec, err := app.swagger.parsedSpec(app.specHandlers())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := spec.Paths["/api/todos"].GET.Responses["429"]; !ok {
+		t.Error("429 not documented")
+	}
+}
+
+func TestUsageMeter_Bytes(t *testing.T) {
+	meter := NewUsageMeter(UsageByHeader("X-API-Key"), WithQuota(Quota{Bytes: 10}))
+	app := usageApp(meter)
+	codes := []int{}
+	for range 3 {
+		codes = append(codes, usageGet(app, "/api/todos", "k").Code)
+	}
+	// 8 bytes per response: the second request starts under the quota and ends over it
+	if codes[0] != 200 || codes[1] != 200 || codes[2] != 429 {
+		t.Errorf("codes = %v", codes)
+	}
+}
+
+func TestUsageMeter_StoreFailure(t *testing.T) {
+	meter := NewUsageMeter(UsageByHeader("X-API-Key"), WithUsageStore(failingUsageStore{}), WithQuota(Quota{Requests: 1}))
+	if w := usageGet(usageApp(meter), "/api/todos", "k"); w.Code != http.StatusOK {
+		t.Errorf("status = %d", w.Code)
+	}
+}
+
+type failingUsageStore struct{}
+
+func (failingUsageStore) Add(context.Context, string, time.Time, Usage) (Usage, error) {
+	return Usage{}, errors.New("store down")
+}
+
+func (failingUsageStore) Query(context.Context, UsageQuery) ([]UsageRecord, error) {
+	return nil, errors.New("store down")
+}
+
+func TestMemoryUsageStore(t *testing.T) {
+	s := NewMemoryUsageStore()
+	ctx := context.Background()
+	jan := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	feb := jan.AddDate(0, 1, 0)
+	s.Add(ctx, "b", jan, Usage{Requests: 1})
+	s.Add(ctx, "a", feb, Usage{Requests: 2})
+	s.Add(ctx, "a", jan, Usage{Requests: 3, Bytes: 10})
+
+	records, _ := s.Query(ctx, UsageQuery{})
+	if len(records) != 3 || records[0].Key != "a" || !records[0].Period.Equal(jan) || records[2].Key != "a" {
+		t.Errorf("records = %+v", records)
+	}
+	if records, _ := s.Query(ctx, UsageQuery{Key: "a", From: feb}); len(records) != 1 || records[0].Requests != 2 {
+		t.Errorf("filtered = %+v", records)
+	}
+	s.Prune(feb)
+	if records, _ := s.Query(ctx, UsageQuery{}); len(records) != 1 {
+		t.Errorf("pruned = %+v", records)
+	}
+
+	start, end := monthOf(time.Date(2025, 12, 31, 23, 0, 0, 0, time.FixedZone("x", -3600)))
+	if !start.Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)) || !end.Equal(time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("month = %v - %v", start, end)
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// The endpoints below are plain gin handlers, so they are served but left out of the spec
+
+// WellKnown serves v at /.well-known/<name>, e.g. security.txt or
+// apple-app-site-association. Strings and byte slices are served as plain text, other values as
+// JSON encoded once, here.
+func (a *App) WellKnown(name string, v any) {
+	name = strings.Trim(name, "/")
+	if name == "" {
+		panic("fluxo: a well-known document needs a name")
+	}
+	contentType, body := "application/json", []byte(nil)
+	switch doc := v.(type) {
+	case string:
+		contentType, body = "text/plain; charset=utf-8", []byte(doc)
+	case []byte:
+		contentType, body = "text/plain; charset=utf-8", doc
+	default:
+		var err error
+		if body, err = json.Marshal(v); err != nil {
+			panic(fmt.Sprintf("fluxo: encoding the well-known document %s: %v", name, err))
+		}
+	}
+	a.GET("/.well-known/"+name, staticDocument(contentType, body, ""))
+}
+
+// Robots serves rules at /robots.txt, e.g. "User-agent: *\nDisallow: /"
+func (a *App) Robots(rules string) {
+	a.GET("/robots.txt", staticDocument("text/plain; charset=utf-8", []byte(rules), ""))
+}
+
+// Favicon serves icon at /favicon.ico, letting browsers cache it for a day. Its content type is
+// sniffed, so PNG and SVG icons work too.
+func (a *App) Favicon(icon []byte) {
+	contentType := http.DetectContentType(icon)
+	if strings.HasPrefix(contentType, "text/") && strings.Contains(string(icon), "<svg") {
+		contentType = "image/svg+xml"
+	}
+	a.GET("/favicon.ico", staticDocument(contentType, icon, "public, max-age=86400"))
+}
+
+func staticDocument(contentType string, body []byte, cacheControl string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if cacheControl != "" {
+			ctx.Header("Cache-Control", cacheControl)
+		}
+		ctx.Data(http.StatusOK, contentType, body)
+	}
+}
+
+// BuildInfo describes the running binary, see CurrentBuildInfo
+type BuildInfo struct {
+	Module    string `json:"module,omitempty"`
+	Version   string `json:"version,omitempty"` // of the main module, (devel) for local builds
+	GoVersion string `json:"go_version"`
+	Revision  string `json:"revision,omitempty"` // VCS commit the binary was built from
+	Time      string `json:"time,omitempty"`     // of the commit, RFC 3339
+	Modified  bool   `json:"modified,omitempty"` // the working tree had uncommitted changes
+}
+
+// CurrentBuildInfo returns the build information embedded in the binary by the go command
+func CurrentBuildInfo() BuildInfo {
+	return buildInfo()
+}
+
+var buildInfo = sync.OnceValue(func() BuildInfo {
+	info := BuildInfo{GoVersion: runtime.Version()}
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+	info.Module, info.Version = bi.Main.Path, bi.Main.Version
+	for _, s := range bi.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			info.Revision = s.Value
+		case "vcs.time":
+			info.Time = s.Value
+		case "vcs.modified":
+			info.Modified = s.Value == "true"
+		}
+	}
+	return info
+})
+
+// EnableVersion serves CurrentBuildInfo as JSON at path, e.g. /version. To document it, serve
+// it with a typed handler instead:
+//
+//	app.GET("/version", fluxo.Handle(func(*fluxo.Context, struct{}) (fluxo.BuildInfo, error) {
+//		return fluxo.CurrentBuildInfo(), nil
+//	}))
+func (a *App) EnableVersion(path string) {
+	a.GET(path, func(ctx *gin.Context) {
+		ctx.JSON(http.StatusOK, CurrentBuildInfo())
+	})
+}
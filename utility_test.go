@@ -0,0 +1,73 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"encoding/json"
+	"net/http"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func utilityApp() *App {
+	gin.SetMode(gin.TestMode)
+	app := New().WithSwagger("Utility", "1.0")
+	app.WellKnown("security.txt", "Contact: mailto:security@example.com\n")
+	app.WellKnown("/assetlinks.json/", []map[string]any{{"relation": []string{"delegate_permission/common.handle_all_urls"}}})
+	app.Robots("User-agent: *\nDisallow: /admin\n")
+	app.Favicon([]byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"))
+	app.EnableVersion("/version")
+	app.GET("/todos", Handle(func(ctx *Context, _ struct{}) ([]string, error) { return nil, nil }))
+	return app
+}
+
+func TestUtilityEndpoints(t *testing.T) {
+	app := utilityApp()
+
+	w := get(app, "/.well-known/security.txt")
+	if w.Code != http.StatusOK || !strings.HasPrefix(w.Header().Get("Content-Type"), "text/plain") || !strings.Contains(w.Body.String(), "security@example.com") {
+		t.Errorf("security.txt = %d %q %s", w.Code, w.Header().Get("Content-Type"), w.Body)
+	}
+	w = get(app, "/.well-known/assetlinks.json")
+	if w.Header().Get("Content-Type") != "application/json" || !strings.Contains(w.Body.String(), "delegate_permission") {
+		t.Errorf("assetlinks.json = %q %s", w.Header().Get("Content-Type"), w.Body)
+	}
+	if w := get(app, "/robots.txt"); !strings.Contains(w.Body.String(), "Disallow: /admin") {
+		t.Errorf("robots.txt = %s", w.Body)
+	}
+	w = get(app, "/favicon.ico")
+	if w.Header().Get("Content-Type") != "image/png" || w.Header().Get("Cache-Control") == "" {
+		t.Errorf("favicon = %q, Cache-Control %q", w.Header().Get("Content-Type"), w.Header().Get("Cache-Control"))
+	}
+
+	var info BuildInfo
+	if err := json.Unmarshal(get(app, "/version").Body.Bytes(), &info); err != nil {
+		t.Fatal(err)
+	}
+	if info.GoVersion != runtime.Version() {
+		t.Errorf("version = %+v", info)
+	}
+}
+
+func TestUtilityEndpoints_NotDocumented(t *testing.T) {
+	app := utilityApp()
+	spec, err := app.swagger.parsedSpec(app.specHandlers())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(spec.Paths) != 1 {
+		t.Errorf("paths = %v, want only /todos", spec.Paths)
+	}
+}
+
+func TestWellKnown_EmptyName(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("empty name accepted")
+		}
+	}()
+	New().WellKnown("/", "x")
+}
@@ -4,53 +4,164 @@
 package fluxo
 
 import (
+	"context"
 	"fmt"
+	"reflect"
+	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 
 	"github.com/gin-gonic/gin"
+	ut "github.com/go-playground/universal-translator"
 	"github.com/go-playground/validator/v10"
 )
 
+// Validator holds validation rules and translated messages. Apps share the default validator,
+// which the package-level RegisterTranslation and RegisterRule configure, unless WithValidator
+// gives them another; apps given the same validator share its rules and translations.
+type Validator struct {
+	validate     *validator.Validate
+	binding      *validator.Validate // checks `binding` rules once UseBindingTags turns it on
+	bindingTags  atomic.Bool
+	mu           sync.RWMutex
+	translations map[string]map[string]translation
+	sources      []*translationSource       // files loaded with LoadTranslations
+	locales      map[string][]ut.Translator // built-in messages added with UseLocales, per engine
+}
+
+// translation is a registered message: a fmt format when registered with RegisterTranslation,
+// or a template with {field}, {param} and {0}, {1}, ... placeholders when loaded from a file
+type translation struct {
+	message string
+	named   bool
+}
+
+// format formats the message of a failed rule; param is empty for rules without one
+func (t translation) format(field, param string) string {
+	if t.named {
+		return strings.NewReplacer("{field}", field, "{param}", param).Replace(t.message)
+	}
+	if param != "" {
+		return fmt.Sprintf(t.message, field, param)
+	}
+	return fmt.Sprintf(t.message, field)
+}
+
+// formatArgs formats a message looked up by key, e.g. for Errorf
+func (t translation) formatArgs(args ...any) string {
+	if !t.named {
+		return fmt.Sprintf(t.message, args...)
+	}
+	pairs := make([]string, 0, 2*len(args))
+	for i, arg := range args {
+		pairs = append(pairs, "{"+strconv.Itoa(i)+"}", fmt.Sprint(arg))
+	}
+	return strings.NewReplacer(pairs...).Replace(t.message)
+}
+
 var (
-	validate            = validator.New()
-	translationRegistry = map[string]map[string]string{}
-	mu                  sync.RWMutex
+	defaultValidator = NewValidator()
+
+	validatorsMu sync.Mutex
+	validators   []*Validator   // every validator, so each learns the Optional types handlers use
+	optionals    []reflect.Type // the Optional types handlers use
 )
 
+// NewValidator returns a validator with the built-in rules and, unless WithLocales adds some,
+// no translations
+func NewValidator(opts ...ValidatorOption) *Validator {
+	v := &Validator{
+		validate:     validator.New(),
+		binding:      newBindingEngine(),
+		translations: map[string]map[string]translation{},
+		locales:      map[string][]ut.Translator{},
+	}
+	registerFileRules(v.validate)
+	registerFileRules(v.binding)
+
+	validatorsMu.Lock()
+	validators = append(validators, v)
+	for _, t := range optionals {
+		v.registerOptional(t)
+	}
+	validatorsMu.Unlock()
+
+	for _, opt := range opts {
+		opt(v)
+	}
+	return v
+}
+
+// Engine returns the underlying go-playground validator, e.g. to register plain rules or aliases.
+// It checks `validate` tags; `binding` tags, see UseBindingTags, only know fluxo's rules.
+func (v *Validator) Engine() *validator.Validate {
+	return v.validate
+}
+
 // RegisterTranslation registers a translated message for a validation tag.
 // Example: fluxo.RegisterTranslation("jp", "required", "%s は必須です")
 func RegisterTranslation(lang, tag, message string) {
-	mu.Lock()
-	defer mu.Unlock()
+	defaultValidator.RegisterTranslation(lang, tag, message)
+}
 
-	if _, ok := translationRegistry[lang]; !ok {
-		translationRegistry[lang] = map[string]string{}
+// RegisterTranslation registers a translated message for a validation tag of this validator
+func (v *Validator) RegisterTranslation(lang, tag, message string) {
+	v.setTranslation(lang, tag, translation{message: message})
+}
+
+func (v *Validator) setTranslation(lang, tag string, t translation) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if _, ok := v.translations[lang]; !ok {
+		v.translations[lang] = map[string]translation{}
 	}
 
-	translationRegistry[lang][tag] = message
+	v.translations[lang][tag] = t
 }
 
 // translate returns a translated message if found.
-func translate(lang, tag string, args ...any) (string, bool) {
-	mu.RLock()
-	defer mu.RUnlock()
+func (v *Validator) translate(lang, tag, field, param string) (string, bool) {
+	t, ok := v.lookup(lang, tag)
+	if !ok {
+		return "", false
+	}
+	return t.format(field, param), true
+}
 
-	if tbl, ok := translationRegistry[lang]; ok {
-		if msg, ok := tbl[tag]; ok {
-			return fmt.Sprintf(msg, args...), true
-		}
+// lookup returns the message registered for key in lang
+func (v *Validator) lookup(lang, key string) (translation, bool) {
+	v.reloadTranslations()
+
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	t, ok := v.translations[lang][key]
+	return t, ok
+}
+
+// registerOptional teaches the validator to look inside Optional type t
+func (v *Validator) registerOptional(t reflect.Type) {
+	for _, engine := range v.engines() {
+		engine.RegisterCustomTypeFunc(func(v reflect.Value) interface{} {
+			return v.Interface().(optionalValue).validationValue()
+		}, reflect.Zero(t).Interface())
 	}
+}
 
-	return "", false
+// registerOptionalType teaches every validator, current and future, to look inside Optional type t
+func registerOptionalType(t reflect.Type) {
+	validatorsMu.Lock()
+	defer validatorsMu.Unlock()
+	optionals = append(optionals, t)
+	for _, v := range validators {
+		v.registerOptional(t)
+	}
 }
 
 // defaultValidationMessage replicates your original English fallback text.
-func defaultValidationMessage(e validator.FieldError) string {
-	field := e.Field()
-	tag := e.Tag()
-	param := e.Param()
-
+func defaultValidationMessage(field, tag, param string) string {
 	switch tag {
 	case "required":
 		return fmt.Sprintf("%s is required", field)
@@ -68,50 +179,120 @@ func defaultValidationMessage(e validator.FieldError) string {
 		return fmt.Sprintf("%s must contain only letters", field)
 	case "alphanum":
 		return fmt.Sprintf("%s must contain only letters and numbers", field)
+	case "maxsize":
+		return fmt.Sprintf("%s must be at most %s per file", field, param)
+	case "mime":
+		return fmt.Sprintf("%s must be one of the file types %s", field, param)
+	case "maxfiles":
+		return fmt.Sprintf("%s must have at most %s files", field, param)
+	case "enum":
+		return fmt.Sprintf("%s must be one of %s", field, param)
 	default:
 		return fmt.Sprintf("%s failed validation for %s", field, tag)
 	}
 }
 
-// formatValidationError uses translation if available, fallback otherwise.
-func formatValidationError(e validator.FieldError, lang string) string {
-	field := e.Field()
-	tag := e.Tag()
-	param := e.Param()
-
-	if param != "" {
-		if msg, ok := translate(lang, tag, field, param); ok {
-			return msg
+// formatValidationError uses translation if available, then the built-in messages of the
+// language, fallback otherwise.
+func (v *Validator) formatValidationError(e validator.FieldError, lang string) string {
+	if msg, ok := v.translate(lang, e.Tag(), e.Field(), e.Param()); ok {
+		return msg
+	}
+	if translators, ok := v.locale(lang); ok {
+		// Tags without a built-in message, and translators of another engine, give back the raw
+		// validator error
+		for _, trans := range translators {
+			if msg := e.Translate(trans); msg != e.Error() {
+				return msg
+			}
 		}
-	} else {
-		if msg, ok := translate(lang, tag, field); ok {
+	}
+	return defaultValidationMessage(e.Field(), e.Tag(), e.Param())
+}
+
+// message formats a failed rule of field, translated to lang when possible
+func (v *Validator) message(lang, field, tag, param string) string {
+	if msg, ok := v.translate(lang, tag, field, param); ok {
+		return msg
+	}
+	// Only messages that don't depend on the field's kind can be used without a validator error
+	if translators, ok := v.locale(lang); ok {
+		if msg, err := translators[0].T(tag, field, param); err == nil {
 			return msg
 		}
 	}
 
-	return defaultValidationMessage(e)
+	return defaultValidationMessage(field, tag, param)
 }
 
-// validateStruct validates a struct using ctx to determine language.
-func validateStruct(ctx *gin.Context, s interface{}) error {
-	lang := ctx.GetHeader("Accept-Language")
-	if lang == "" {
-		lang = "en"
+// requestValidator returns the validator of the app serving the request
+func requestValidator(ctx *gin.Context) *Validator {
+	if a, ok := appFrom(ctx); ok && a.validator != nil {
+		return a.validator
 	}
+	return defaultValidator
+}
 
-	if err := validate.Struct(s); err != nil {
-		validationErrors, ok := err.(validator.ValidationErrors)
-		if !ok {
-			return fmt.Errorf("validation failed: %v", err)
-		}
+// validateStruct validates a struct using ctx to determine the validator and language and to run
+// RegisterRule rules.
+func validateStruct(ctx *gin.Context, s interface{}) error {
+	return requestValidator(ctx).check(requestContext{Context: ctx.Request.Context(), gin: ctx}, requestLang(ctx), s)
+}
 
-		var messages []string
-		for _, e := range validationErrors {
-			messages = append(messages, formatValidationError(e, lang))
+// check validates a struct, passing ctx to RegisterRule rules and formatting messages in lang.
+// Enumer fields must hold one of their values. An error of a rule is returned as a *ruleError,
+// see ruleCause.
+func (v *Validator) check(ctx context.Context, lang string, s interface{}) error {
+	var messages []string
+	if g, ok := lookupGenerated(reflect.TypeOf(s)); ok && g.Validate != nil {
+		if err := v.generatedValidation(lang, g.Validate(s)); err != nil {
+			return err
+		}
+	} else if msgs, err := v.run(v.validate, ctx, lang, s); err != nil {
+		return err
+	} else {
+		messages = msgs
+	}
+	if v.bindingTags.Load() {
+		msgs, err := v.run(v.binding, ctx, lang, s)
+		if err != nil {
+			return err
 		}
+		messages = append(messages, msgs...)
+	}
+	return validationFailed(append(messages, v.enumMessages(lang, s)...))
+}
 
-		return fmt.Errorf("validation failed: %s", strings.Join(messages, "; "))
+// run checks s with one of the validator's engines, returning the messages of the failed rules
+func (v *Validator) run(engine *validator.Validate, ctx context.Context, lang string, s interface{}) ([]string, error) {
+	state := &ruleState{}
+	err := engine.StructCtx(context.WithValue(ctx, ruleStateKey{}, state), s)
+	if state.err != nil {
+		return nil, &ruleError{err: state.err}
+	}
+	if err == nil {
+		return nil, nil
+	}
+	validationErrors, ok := err.(validator.ValidationErrors)
+	if !ok {
+		return nil, fmt.Errorf("validation failed: %v", err)
 	}
 
-	return nil
+	var messages []string
+	for _, e := range validationErrors {
+		// Rules on an Optional only apply when it holds a value
+		if e.Kind() == reflect.Invalid && isUnsetOptional(reflect.ValueOf(s), e.StructNamespace()) {
+			continue
+		}
+		messages = append(messages, v.formatValidationError(e, lang))
+	}
+	return messages, nil
+}
+
+// validationFailed returns the error reporting messages, or nil when there are none
+func validationFailed(messages []string) error {
+	if len(messages) == 0 {
+		return nil
+	}
+	return fmt.Errorf("validation failed: %s", strings.Join(messages, "; "))
 }
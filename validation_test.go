@@ -1,6 +1,9 @@
 package fluxo
 
 import (
+	"context"
+	"mime/multipart"
+	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
@@ -111,3 +114,75 @@ func TestValidation_EdgeCases(t *testing.T) {
 		}
 	})
 }
+
+type perAppReq struct {
+	Code  string           `json:"code" validate:"required,tenant_code"`
+	Title Optional[string] `json:"title" validate:"min=3"`
+}
+
+func perAppServe(app *App, body string) string {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/codes", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept-Language", "id")
+	app.ServeHTTP(w, req)
+	return w.Body.String()
+}
+
+func TestValidator_PerApp(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	prefixRule := func(prefix string) RuleFunc {
+		return func(ctx context.Context, v any, _ string) (bool, error) {
+			return strings.HasPrefix(v.(string), prefix), nil
+		}
+	}
+	a, b := NewValidator(), NewValidator()
+	a.RegisterRule("tenant_code", prefixRule("A-"))
+	a.RegisterTranslation("id", "required", "%s wajib diisi")
+	b.RegisterRule("tenant_code", prefixRule("B-"))
+
+	handler := Handle(func(ctx *Context, req perAppReq) (gin.H, error) { return gin.H{"ok": true}, nil })
+	appA, appA2, appB := New(WithValidator(a)), New(WithValidator(a)), New(WithValidator(b))
+	for _, app := range []*App{appA, appA2, appB} {
+		app.POST("/codes", handler)
+	}
+
+	if got := perAppServe(appA, `{"code":"A-1"}`); got != `{"ok":true}` {
+		t.Fatalf("expected app A's rule to pass, got %s", got)
+	}
+	if got := perAppServe(appB, `{"code":"A-1"}`); !strings.Contains(got, "tenant_code") {
+		t.Fatalf("expected app B's rule to fail, got %s", got)
+	}
+	if got := perAppServe(appA2, `{"code":"B-1"}`); !strings.Contains(got, "tenant_code") {
+		t.Fatalf("expected the shared validator's rule, got %s", got)
+	}
+	if got := perAppServe(appA2, `{}`); !strings.Contains(got, "Code wajib diisi") {
+		t.Fatalf("expected the shared translation, got %s", got)
+	}
+	if got := perAppServe(appB, `{}`); !strings.Contains(got, "Code is required") {
+		t.Fatalf("expected no translation in app B, got %s", got)
+	}
+	if got := perAppServe(appB, `{"code":"B-1","title":"ab"}`); !strings.Contains(got, "Title must be at least 3") {
+		t.Fatalf("expected Optional fields to be validated, got %s", got)
+	}
+	if got := perAppServe(New(), `{"code":"A-1"}`); strings.Contains(got, "ok") {
+		t.Fatalf("expected the default validator to know neither rule, got %s", got)
+	}
+	if New().Validator() != defaultValidator || appA.Validator() != a {
+		t.Fatal("expected apps to expose their validator")
+	}
+}
+
+func TestNewValidator_BuiltinRules(t *testing.T) {
+	type files struct {
+		Photos []*multipart.FileHeader `validate:"maxfiles=1"`
+	}
+	v := NewValidator()
+	if err := v.check(context.Background(), "en", &files{Photos: []*multipart.FileHeader{{}}}); err != nil {
+		t.Fatalf("expected one file to pass, got %v", err)
+	}
+	err := v.check(context.Background(), "en", &files{Photos: []*multipart.FileHeader{{}, {}}})
+	if err == nil || !strings.Contains(err.Error(), "at most 1 files") {
+		t.Fatalf("expected the file rules to be registered, got %v", err)
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ViewQueryParam is the query parameter selecting the view of a response, e.g. ?view=summary.
+// Set it to "" to only select views with the View route option.
+var ViewQueryParam = "view"
+
+const viewKey = "fluxo.view"
+
+// View is a route option selecting the view its responses are written in unless the request
+// asks for another one. A view leaves out the fields whose `view` tag doesn't name it, so list
+// and detail endpoints can share a struct:
+//
+//	type Article struct {
+//		ID    int    `json:"id"`
+//		Title string `json:"title"`
+//		Body  string `json:"body" view:"full"`
+//	}
+//
+//	app.GET("/articles", fluxo.View("summary"), fluxo.Handle(listArticles))
+//	app.GET("/articles/:id", fluxo.Handle(getArticle))
+//
+// Untagged fields are part of every view, and all fields are returned without a view.
+func View(name string) gin.HandlerFunc {
+	return recordWrapper(func(ctx *gin.Context) {
+		ctx.Set(viewKey, name)
+	}, &wrapperInfo{view: name})
+}
+
+// requestView returns the view the request asked for, or else the one of its route
+func requestView(ctx *gin.Context) (string, bool) {
+	if ViewQueryParam != "" {
+		if view := ctx.Query(ViewQueryParam); view != "" {
+			return view, true
+		}
+	}
+	view := ctx.GetString(viewKey)
+	return view, view != ""
+}
+
+// routeView returns the view selected by a View option among handlers
+func routeView(handlers []gin.HandlerFunc) string {
+	view := ""
+	for _, h := range handlers {
+		if p, ok := wrapperOf(h); ok && p.view != "" {
+			view = p.view
+		}
+	}
+	return view
+}
+
+// viewParameter documents the query parameter selecting one of views
+func viewParameter(views []string) Parameter {
+	enum := make([]interface{}, len(views))
+	for i, v := range views {
+		enum[i] = v
+	}
+	return Parameter{
+		Name:        ViewQueryParam,
+		In:          "query",
+		Description: "View of the response, leaving out the fields of other views. All fields are returned without one.",
+		Schema:      Schema{Type: "string", Enum: enum},
+	}
+}
+
+// documentDefaultView notes the view a route's responses are written in by default
+func documentDefaultView(op *Operation, view string) {
+	for i, p := range op.Parameters {
+		if p.Name == ViewQueryParam && p.In == "query" {
+			op.Parameters[i].Description = "View of the response, leaving out the fields of other views. Defaults to " + view + "."
+		}
+	}
+}
+
+// documentViews marks a property that is only part of some views in the spec
+func documentViews(schema Schema, views []string) Schema {
+	if schema.Ref != "" {
+		// Siblings of $ref are ignored, so the reference is wrapped
+		schema = Schema{AllOf: []Schema{schema}}
+	}
+	schema.Views = views
+	note := "Only part of the view " + strings.Join(views, " or ") + "."
+	schema.Description = strings.TrimSpace(note + " " + schema.Description)
+	return schema
+}
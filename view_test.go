@@ -0,0 +1,132 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type viewAuthor struct {
+	Name string `json:"name"`
+	Bio  string `json:"bio" view:"full"`
+}
+
+type viewArticle struct {
+	ID      int        `json:"id"`
+	Title   string     `json:"title"`
+	Summary string     `json:"summary" view:"summary"`
+	Body    string     `json:"body" view:"full"`
+	Author  viewAuthor `json:"author"`
+	Secret  string     `json:"secret,omitempty" view:"full" scope:"admin"`
+}
+
+func viewApp() *App {
+	gin.SetMode(gin.TestMode)
+	article := viewArticle{
+		ID: 1, Title: "Views", Summary: "Short", Body: "Long",
+		Author: viewAuthor{Name: "Ann", Bio: "Writer"}, Secret: "draft",
+	}
+	app := New().WithSwagger("Articles", "1.0")
+	app.GET("/articles", View("summary"), Handle(func(ctx *Context, _ struct{}) ([]viewArticle, error) {
+		return []viewArticle{article}, nil
+	}))
+	app.GET("/articles/:id", Handle(func(ctx *Context, _ struct {
+		ID int `uri:"id"`
+	}) (viewArticle, error) {
+		return article, nil
+	}))
+	return app
+}
+
+func getJSON(app *App, path string) any {
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+	var body any
+	_ = json.Unmarshal(w.Body.Bytes(), &body)
+	return body
+}
+
+func TestView(t *testing.T) {
+	app := viewApp()
+
+	list := getJSON(app, "/articles").([]any)[0].(map[string]any)
+	if list["summary"] != "Short" || list["title"] != "Views" {
+		t.Errorf("summary view misses fields: %v", list)
+	}
+	if _, ok := list["body"]; ok {
+		t.Errorf("summary view has the body: %v", list)
+	}
+	if _, ok := list["author"].(map[string]any)["bio"]; ok {
+		t.Errorf("summary view has author.bio: %v", list)
+	}
+
+	full := getJSON(app, "/articles?view=full").([]any)[0].(map[string]any)
+	if full["body"] != "Long" || full["author"].(map[string]any)["bio"] != "Writer" {
+		t.Errorf("full view misses fields: %v", full)
+	}
+	if _, ok := full["summary"]; ok {
+		t.Errorf("full view has the summary: %v", full)
+	}
+	if _, ok := full["secret"]; ok {
+		t.Errorf("full view ignores scopes: %v", full)
+	}
+
+	detail := getJSON(app, "/articles/1").(map[string]any)
+	if detail["summary"] != "Short" || detail["body"] != "Long" {
+		t.Errorf("without a view some fields are missing: %v", detail)
+	}
+	if _, ok := detail["secret"]; ok {
+		t.Errorf("scoped field returned without its scope: %v", detail)
+	}
+}
+
+func TestView_QueryParamDisabled(t *testing.T) {
+	defer func(param string) { ViewQueryParam = param }(ViewQueryParam)
+	ViewQueryParam = ""
+	app := viewApp()
+	list := getJSON(app, "/articles?view=full").([]any)[0].(map[string]any)
+	if _, ok := list["body"]; ok {
+		t.Errorf("query parameter overrides the route's view: %v", list)
+	}
+}
+
+func TestView_Swagger(t *testing.T) {
+	app := viewApp()
+	spec, err := app.swagger.parsedSpec(app.specHandlers())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for path, wantDefault := range map[string]bool{"/articles": true, "/articles/{id}": false} {
+		op := spec.Paths[path].GET
+		if op == nil {
+			t.Fatalf("%s: missing operation", path)
+		}
+		var param *Parameter
+		for i, p := range op.Parameters {
+			if p.Name == "view" && p.In == "query" {
+				param = &op.Parameters[i]
+			}
+		}
+		if param == nil || len(param.Schema.Enum) != 2 || param.Schema.Enum[0] != "full" {
+			t.Fatalf("%s: view parameter = %+v", path, param)
+		}
+		if got := strings.Contains(param.Description, "Defaults to summary"); got != wantDefault {
+			t.Errorf("%s: view parameter description = %q", path, param.Description)
+		}
+	}
+	props := spec.Components.Schemas["viewArticle"].Properties
+	if got := props["body"].Views; len(got) != 1 || got[0] != "full" {
+		t.Errorf("body views = %v", got)
+	}
+	if len(props["title"].Views) != 0 {
+		t.Errorf("title views = %v", props["title"].Views)
+	}
+	if err := app.Validate(); err != nil {
+		t.Errorf("Validate: %v", err)
+	}
+}
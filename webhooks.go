@@ -0,0 +1,317 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Headers sent with every webhook delivery
+const (
+	WebhookEventHeader     = "X-Webhook-Event"
+	WebhookIDHeader        = "X-Webhook-Id"
+	WebhookTimestampHeader = "X-Webhook-Timestamp"
+	WebhookSignatureHeader = "X-Webhook-Signature"
+)
+
+// Subscription is a receiver registered for one or more events
+type Subscription struct {
+	ID     string   `json:"id"`
+	URL    string   `json:"url"`
+	Secret string   `json:"-"`
+	Events []string `json:"events"` // empty means every event
+}
+
+func (s Subscription) wants(event string) bool {
+	return len(s.Events) == 0 || contains(s.Events, event)
+}
+
+// SubscriptionStore persists webhook subscriptions
+type SubscriptionStore interface {
+	Add(ctx context.Context, sub Subscription) error
+	Remove(ctx context.Context, id string) error
+	ForEvent(ctx context.Context, event string) ([]Subscription, error)
+}
+
+// MemorySubscriptionStore keeps subscriptions in process memory
+type MemorySubscriptionStore struct {
+	mu   sync.RWMutex
+	subs map[string]Subscription
+}
+
+func NewMemorySubscriptionStore() *MemorySubscriptionStore {
+	return &MemorySubscriptionStore{subs: make(map[string]Subscription)}
+}
+
+func (s *MemorySubscriptionStore) Add(_ context.Context, sub Subscription) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.subs[sub.ID] = sub
+	return nil
+}
+
+func (s *MemorySubscriptionStore) Remove(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.subs, id)
+	return nil
+}
+
+func (s *MemorySubscriptionStore) ForEvent(_ context.Context, event string) ([]Subscription, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var subs []Subscription
+	for _, sub := range s.subs {
+		if sub.wants(event) {
+			subs = append(subs, sub)
+		}
+	}
+	return subs, nil
+}
+
+// WebhookEvent describes an event type and the payload sent with it
+type WebhookEvent struct {
+	Name        string
+	Description string
+	PayloadType reflect.Type
+}
+
+// WebhookEnvelope is the JSON body of every delivery
+type WebhookEnvelope[T any] struct {
+	ID        string    `json:"id"`
+	Event     string    `json:"event"`
+	CreatedAt time.Time `json:"created_at"`
+	Data      T         `json:"data"`
+}
+
+// Webhooks signs and delivers events to subscribers, retrying failed deliveries with exponential backoff
+type Webhooks struct {
+	store       SubscriptionStore
+	client      *http.Client
+	maxAttempts int
+	backoff     time.Duration
+	onFailure   func(sub Subscription, event string, err error)
+
+	mu     sync.RWMutex
+	events map[string]WebhookEvent
+	wg     sync.WaitGroup
+}
+
+type WebhookOption func(*Webhooks)
+
+// WithWebhookClient sets the HTTP client used for deliveries
+func WithWebhookClient(client *http.Client) WebhookOption {
+	return func(w *Webhooks) {
+		w.client = client
+	}
+}
+
+// WithWebhookRetries sets the number of delivery attempts and the initial backoff between them
+func WithWebhookRetries(maxAttempts int, backoff time.Duration) WebhookOption {
+	return func(w *Webhooks) {
+		w.maxAttempts = maxAttempts
+		w.backoff = backoff
+	}
+}
+
+// WithWebhookFailureHandler is called when a delivery fails after all attempts
+func WithWebhookFailureHandler(fn func(sub Subscription, event string, err error)) WebhookOption {
+	return func(w *Webhooks) {
+		w.onFailure = fn
+	}
+}
+
+// NewWebhooks creates a dispatcher backed by store (an in-memory store when nil)
+func NewWebhooks(store SubscriptionStore, opts ...WebhookOption) *Webhooks {
+	if store == nil {
+		store = NewMemorySubscriptionStore()
+	}
+	w := &Webhooks{
+		store:       store,
+		client:      &http.Client{Timeout: 10 * time.Second},
+		maxAttempts: 5,
+		backoff:     time.Second,
+		events:      make(map[string]WebhookEvent),
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(w)
+		}
+	}
+	return w
+}
+
+// RegisterEvent declares an event and its payload type so it can be dispatched and documented
+func RegisterEvent[T any](w *Webhooks, name, description string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.events[name] = WebhookEvent{
+		Name:        name,
+		Description: description,
+		PayloadType: reflect.TypeOf((*T)(nil)).Elem(),
+	}
+}
+
+// Events returns the registered events sorted by name
+func (w *Webhooks) Events() []WebhookEvent {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	events := make([]WebhookEvent, 0, len(w.events))
+	for _, ev := range w.events {
+		events = append(events, ev)
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].Name < events[j].Name })
+	return events
+}
+
+// Store returns the underlying SubscriptionStore
+func (w *Webhooks) Store() SubscriptionStore {
+	return w.store
+}
+
+// Dispatch delivers payload to every subscriber of event in the background.
+// The payload must match the type the event was registered with.
+func (w *Webhooks) Dispatch(ctx context.Context, event string, payload interface{}) error {
+	w.mu.RLock()
+	ev, ok := w.events[event]
+	w.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("webhook event %q is not registered", event)
+	}
+	if pt := reflect.TypeOf(payload); pt != ev.PayloadType {
+		return fmt.Errorf("webhook event %q expects %v payload, got %v", event, ev.PayloadType, pt)
+	}
+
+	subs, err := w.store.ForEvent(ctx, event)
+	if err != nil {
+		return err
+	}
+	if len(subs) == 0 {
+		return nil
+	}
+
+	id, err := newTaskID()
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(WebhookEnvelope[interface{}]{
+		ID:        id,
+		Event:     event,
+		CreatedAt: time.Now().UTC(),
+		Data:      payload,
+	})
+	if err != nil {
+		return err
+	}
+
+	bg := context.WithoutCancel(ctx)
+	for _, sub := range subs {
+		w.wg.Add(1)
+		go func(sub Subscription) {
+			defer w.wg.Done()
+			if err := w.deliver(bg, sub, event, id, body); err != nil && w.onFailure != nil {
+				w.onFailure(sub, event, err)
+			}
+		}(sub)
+	}
+	return nil
+}
+
+// Wait blocks until all in-flight deliveries have finished
+func (w *Webhooks) Wait() {
+	w.wg.Wait()
+}
+
+// deliver posts body to sub, retrying network errors, 429 and 5xx responses
+func (w *Webhooks) deliver(ctx context.Context, sub Subscription, event, id string, body []byte) error {
+	attempts := w.maxAttempts
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 {
+			delay := w.backoff << (attempt - 1)
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(delay):
+			}
+		}
+
+		retry, err := w.send(ctx, sub, event, id, body)
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+		if !retry {
+			break
+		}
+	}
+	return lastErr
+}
+
+func (w *Webhooks) send(ctx context.Context, sub Subscription, event, id string, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookEventHeader, event)
+	req.Header.Set(WebhookIDHeader, id)
+	req.Header.Set(WebhookTimestampHeader, timestamp)
+	if sub.Secret != "" {
+		req.Header.Set(WebhookSignatureHeader, SignWebhook(sub.Secret, timestamp, body))
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return false, nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return true, fmt.Errorf("webhook delivery to %s failed with status %d", sub.URL, resp.StatusCode)
+	default:
+		return false, fmt.Errorf("webhook delivery to %s rejected with status %d", sub.URL, resp.StatusCode)
+	}
+}
+
+// SignWebhook returns the signature header value for a delivery: sha256=hex(HMAC(secret, timestamp + "." + body))
+func SignWebhook(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyWebhook checks a delivery signature in constant time; receivers should also reject stale timestamps
+func VerifyWebhook(secret, timestamp string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(SignWebhook(secret, timestamp, body)), []byte(signature))
+}
+
+// Webhooks documents the events of w in the OpenAPI spec
+func (a *App) Webhooks(w *Webhooks) {
+	if a.swagger == nil {
+		return
+	}
+	a.swagger.AddWebhookSource(w)
+}
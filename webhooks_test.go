@@ -0,0 +1,109 @@
+package fluxo
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type whTodoCreated struct {
+	ID    int    `json:"id"`
+	Title string `json:"title"`
+}
+
+func TestWebhooks_DeliverSignedWithRetries(t *testing.T) {
+	var calls int32
+	var verified atomic.Bool
+	var envelope WebhookEnvelope[whTodoCreated]
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		verified.Store(VerifyWebhook("s3cret", r.Header.Get(WebhookTimestampHeader), body, r.Header.Get(WebhookSignatureHeader)))
+		_ = json.Unmarshal(body, &envelope)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	hooks := NewWebhooks(nil, WithWebhookRetries(3, time.Millisecond))
+	RegisterEvent[whTodoCreated](hooks, "todo.created", "Sent when a todo is created")
+	_ = hooks.Store().Add(context.Background(), Subscription{ID: "1", URL: receiver.URL, Secret: "s3cret", Events: []string{"todo.created"}})
+
+	if err := hooks.Dispatch(context.Background(), "todo.created", whTodoCreated{ID: 1, Title: "t"}); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	hooks.Wait()
+
+	if calls != 3 {
+		t.Fatalf("expected 3 attempts, got %d", calls)
+	}
+	if !verified.Load() {
+		t.Fatalf("expected a valid signature")
+	}
+	if envelope.Event != "todo.created" || envelope.Data.Title != "t" || envelope.ID == "" {
+		t.Fatalf("unexpected envelope %+v", envelope)
+	}
+}
+
+func TestWebhooks_RejectedNotRetried(t *testing.T) {
+	var calls int32
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusGone)
+	}))
+	defer receiver.Close()
+
+	var failed atomic.Bool
+	hooks := NewWebhooks(nil, WithWebhookRetries(5, time.Millisecond), WithWebhookFailureHandler(func(sub Subscription, event string, err error) {
+		failed.Store(true)
+	}))
+	RegisterEvent[whTodoCreated](hooks, "todo.created", "")
+	_ = hooks.Store().Add(context.Background(), Subscription{ID: "1", URL: receiver.URL})
+
+	if err := hooks.Dispatch(context.Background(), "todo.created", whTodoCreated{}); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	hooks.Wait()
+	if calls != 1 || !failed.Load() {
+		t.Fatalf("expected a single failed attempt, got %d", calls)
+	}
+
+	if err := hooks.Dispatch(context.Background(), "todo.deleted", whTodoCreated{}); err == nil {
+		t.Fatalf("expected error for unregistered event")
+	}
+	if err := hooks.Dispatch(context.Background(), "todo.created", "wrong"); err == nil {
+		t.Fatalf("expected error for mismatched payload")
+	}
+}
+
+func TestWebhooks_Spec(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := New().WithSwagger("Test", "1.0.0")
+	hooks := NewWebhooks(nil)
+	RegisterEvent[whTodoCreated](hooks, "todo.created", "Sent when a todo is created")
+	app.Webhooks(hooks)
+
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	var spec map[string]interface{}
+	_ = json.Unmarshal(w.Body.Bytes(), &spec)
+	webhooks, ok := spec["x-webhooks"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected x-webhooks section")
+	}
+	post := webhooks["todo.created"].(map[string]interface{})["post"].(map[string]interface{})
+	schema := post["requestBody"].(map[string]interface{})["content"].(map[string]interface{})["application/json"].(map[string]interface{})["schema"].(map[string]interface{})
+	data := schema["properties"].(map[string]interface{})["data"].(map[string]interface{})
+	if _, ok := data["properties"].(map[string]interface{})["title"]; !ok {
+		t.Fatalf("expected payload schema, got %v", data)
+	}
+}
@@ -0,0 +1,356 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// Job is a unit of background work waiting in a JobQueue
+type Job struct {
+	ID         string          `json:"id"`
+	Type       string          `json:"type"`
+	Payload    json.RawMessage `json:"payload"`
+	Attempts   int             `json:"attempts"`
+	EnqueuedAt time.Time       `json:"enqueued_at"`
+}
+
+var (
+	// ErrQueueClosed is returned by a closed JobQueue: by Push at once, by Pop once no jobs are left for this process
+	ErrQueueClosed = errors.New("job queue closed")
+	// ErrNoWorkers is returned by Context.Enqueue when the app has no workers
+	ErrNoWorkers = errors.New("no workers configured, call App.Workers or App.WithWorkers")
+)
+
+// JobQueue holds jobs until a worker takes them; implement it to share work across instances
+type JobQueue interface {
+	Push(ctx context.Context, job Job) error
+	// Pop blocks until a job is available, ctx is done or the queue is closed
+	Pop(ctx context.Context) (Job, error)
+	// Close stops Push; Pop returns the jobs this process still has to run, then ErrQueueClosed
+	Close() error
+}
+
+// MemoryJobQueue keeps jobs in process memory; jobs still queued at shutdown are drained
+type MemoryJobQueue struct {
+	mu     sync.Mutex
+	jobs   []Job
+	ready  chan struct{} // signalled when jobs are pushed or the queue closes
+	closed bool
+}
+
+func NewMemoryJobQueue() *MemoryJobQueue {
+	return &MemoryJobQueue{ready: make(chan struct{}, 1)}
+}
+
+func (q *MemoryJobQueue) Push(_ context.Context, job Job) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return ErrQueueClosed
+	}
+	q.jobs = append(q.jobs, job)
+	q.signal()
+	return nil
+}
+
+func (q *MemoryJobQueue) Pop(ctx context.Context) (Job, error) {
+	for {
+		q.mu.Lock()
+		if len(q.jobs) > 0 {
+			job := q.jobs[0]
+			q.jobs = q.jobs[1:]
+			// Let another waiting worker look at the rest
+			if len(q.jobs) > 0 || q.closed {
+				q.signal()
+			}
+			q.mu.Unlock()
+			return job, nil
+		}
+		if q.closed {
+			q.signal()
+			q.mu.Unlock()
+			return Job{}, ErrQueueClosed
+		}
+		q.mu.Unlock()
+
+		select {
+		case <-q.ready:
+		case <-ctx.Done():
+			return Job{}, ctx.Err()
+		}
+	}
+}
+
+func (q *MemoryJobQueue) Close() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.closed = true
+	q.signal()
+	return nil
+}
+
+// Len returns the number of queued jobs
+func (q *MemoryJobQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.jobs)
+}
+
+func (q *MemoryJobQueue) signal() {
+	select {
+	case q.ready <- struct{}{}:
+	default:
+	}
+}
+
+// JobHandler runs one job
+type JobHandler func(ctx context.Context, job Job) error
+
+// Workers runs registered job handlers for the jobs of a JobQueue
+type Workers struct {
+	queue       JobQueue
+	concurrency int
+	maxAttempts int
+	backoff     time.Duration
+	onFailure   func(job Job, err error)
+
+	mu       sync.RWMutex
+	handlers map[string]JobHandler
+	started  bool
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+}
+
+// WorkerOption configures Workers
+type WorkerOption func(*Workers)
+
+// WithConcurrency sets how many jobs run at once (default GOMAXPROCS)
+func WithConcurrency(n int) WorkerOption {
+	return func(w *Workers) {
+		if n > 0 {
+			w.concurrency = n
+		}
+	}
+}
+
+// WithJobRetries sets how many times a failing job is attempted and the initial
+// backoff between attempts, doubled after each failure
+func WithJobRetries(maxAttempts int, backoff time.Duration) WorkerOption {
+	return func(w *Workers) {
+		if maxAttempts > 0 {
+			w.maxAttempts = maxAttempts
+		}
+		w.backoff = backoff
+	}
+}
+
+// WithJobFailureHandler is called when a job fails for the last time or has no handler
+func WithJobFailureHandler(fn func(job Job, err error)) WorkerOption {
+	return func(w *Workers) {
+		w.onFailure = fn
+	}
+}
+
+// NewWorkers creates workers for queue (an in-memory queue when nil); call Start to run them
+func NewWorkers(queue JobQueue, opts ...WorkerOption) *Workers {
+	if queue == nil {
+		queue = NewMemoryJobQueue()
+	}
+	w := &Workers{
+		queue:       queue,
+		concurrency: runtime.GOMAXPROCS(0),
+		maxAttempts: 1,
+		handlers:    make(map[string]JobHandler),
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	return w
+}
+
+// Handle registers the handler of jobType
+func (w *Workers) Handle(jobType string, h JobHandler) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.handlers[jobType] = h
+}
+
+// RegisterJob registers a handler receiving the decoded payload of jobType
+//
+//	fluxo.RegisterJob(workers, "email.welcome", func(ctx context.Context, m WelcomeEmail) error {
+//		return mailer.Send(ctx, m.To, "Welcome!")
+//	})
+func RegisterJob[T any](w *Workers, jobType string, fn func(ctx context.Context, payload T) error) {
+	w.Handle(jobType, func(ctx context.Context, job Job) error {
+		var payload T
+		if err := json.Unmarshal(job.Payload, &payload); err != nil {
+			return fmt.Errorf("decode %s payload: %w", jobType, err)
+		}
+		return fn(ctx, payload)
+	})
+}
+
+// Queue returns the underlying JobQueue
+func (w *Workers) Queue() JobQueue {
+	return w.queue
+}
+
+// Enqueue queues a job of jobType with payload encoded as JSON, returning its ID
+func (w *Workers) Enqueue(ctx context.Context, jobType string, payload any) (string, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("encode %s payload: %w", jobType, err)
+	}
+	id, err := newTaskID()
+	if err != nil {
+		return "", err
+	}
+	job := Job{ID: id, Type: jobType, Payload: data, EnqueuedAt: time.Now().UTC()}
+	if err := w.queue.Push(ctx, job); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// Start launches the worker goroutines; calling it again does nothing
+func (w *Workers) Start() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.started {
+		return
+	}
+	w.started = true
+	ctx, cancel := context.WithCancel(context.Background())
+	w.cancel = cancel
+	for i := 0; i < w.concurrency; i++ {
+		w.wg.Add(1)
+		go w.loop(ctx)
+	}
+}
+
+// Shutdown closes the queue and waits for the workers to finish the jobs they still have.
+// When ctx ends first, running jobs are cancelled and ctx's error is returned.
+func (w *Workers) Shutdown(ctx context.Context) error {
+	if err := w.queue.Close(); err != nil {
+		return err
+	}
+	done := make(chan struct{})
+	go func() {
+		w.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		w.mu.RLock()
+		if w.cancel != nil {
+			w.cancel()
+		}
+		w.mu.RUnlock()
+		<-done
+		return ctx.Err()
+	}
+}
+
+func (w *Workers) loop(ctx context.Context) {
+	defer w.wg.Done()
+	for {
+		job, err := w.queue.Pop(ctx)
+		if err != nil {
+			if errors.Is(err, ErrQueueClosed) || ctx.Err() != nil {
+				return
+			}
+			// A queue outage should not spin the worker
+			select {
+			case <-time.After(time.Second):
+			case <-ctx.Done():
+				return
+			}
+			continue
+		}
+		w.run(ctx, job)
+	}
+}
+
+func (w *Workers) run(ctx context.Context, job Job) {
+	w.mu.RLock()
+	h, ok := w.handlers[job.Type]
+	w.mu.RUnlock()
+	if !ok {
+		w.fail(job, fmt.Errorf("no handler for job type %q", job.Type))
+		return
+	}
+
+	backoff := w.backoff
+	for {
+		job.Attempts++
+		err := w.attempt(ctx, h, job)
+		if err == nil {
+			return
+		}
+		if job.Attempts >= w.maxAttempts || ctx.Err() != nil {
+			w.fail(job, err)
+			return
+		}
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			w.fail(job, err)
+			return
+		}
+		backoff *= 2
+	}
+}
+
+func (w *Workers) attempt(ctx context.Context, h JobHandler, job Job) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+	return h(ctx, job)
+}
+
+func (w *Workers) fail(job Job, err error) {
+	if w.onFailure != nil {
+		w.onFailure(job, err)
+	}
+}
+
+// Workers returns the app's workers, creating and starting them with an in-memory queue on
+// first use. They are drained by Shutdown.
+func (a *App) Workers() *Workers {
+	if a.workers == nil {
+		a.WithWorkers(NewWorkers(nil))
+	}
+	return a.workers
+}
+
+// WithWorkers makes the app enqueue to w, starting it and draining it on Shutdown
+func (a *App) WithWorkers(w *Workers) *App {
+	a.workers = w
+	w.Start()
+	a.OnShutdown(w.Shutdown)
+	return a
+}
+
+// Enqueue queues a background job on the app's workers, see Workers.Enqueue
+func (c *Context) Enqueue(jobType string, payload any) (string, error) {
+	app, ok := appFrom(c.Context)
+	// A mounted sub-app without workers uses those of the app it is mounted in
+	for ok && app.workers == nil && len(app.parents) > 0 {
+		app = app.parents[0]
+	}
+	if !ok || app.workers == nil {
+		return "", ErrNoWorkers
+	}
+	return app.workers.Enqueue(c.Request.Context(), jobType, payload)
+}
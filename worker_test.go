@@ -0,0 +1,156 @@
+// Copyright 2025 M Reyhan Fahlevi
+// Licensed under the MIT License. See LICENSE for details.
+package fluxo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type welcomeEmail struct {
+	To string `json:"to"`
+}
+
+type signupReq struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type signupRes struct {
+	JobID string `json:"job_id"`
+}
+
+func TestEnqueueFromHandlerAndDrain(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := New()
+	release := make(chan struct{})
+	var mu sync.Mutex
+	var sent []string
+	RegisterJob(app.Workers(), "email.welcome", func(ctx context.Context, m welcomeEmail) error {
+		<-release
+		mu.Lock()
+		defer mu.Unlock()
+		sent = append(sent, m.To)
+		return nil
+	})
+	app.POST("/signup", Handle(func(ctx *Context, req signupReq) (signupRes, error) {
+		id, err := ctx.Enqueue("email.welcome", welcomeEmail{To: req.Email})
+		return signupRes{JobID: id}, err
+	}))
+
+	for _, email := range []string{"a@example.com", "b@example.com", "c@example.com"} {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/signup", strings.NewReader(`{"email":"`+email+`"}`))
+		req.Header.Set("Content-Type", "application/json")
+		app.ServeHTTP(w, req)
+		if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "job_id") {
+			t.Fatalf("status %d: %s", w.Code, w.Body.String())
+		}
+	}
+
+	// Shutdown waits for queued and running jobs
+	close(release)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := app.Shutdown(ctx); err != nil {
+		t.Fatal(err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(sent) != 3 {
+		t.Fatalf("expected every queued job to run before shutdown returned, got %v", sent)
+	}
+	if _, err := app.Workers().Enqueue(context.Background(), "email.welcome", welcomeEmail{}); !errors.Is(err, ErrQueueClosed) {
+		t.Fatalf("expected ErrQueueClosed after shutdown, got %v", err)
+	}
+}
+
+func TestEnqueueWithoutWorkers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := New()
+	app.POST("/signup", Handle(func(ctx *Context, req signupReq) (signupRes, error) {
+		id, err := ctx.Enqueue("email.welcome", welcomeEmail{To: req.Email})
+		if errors.Is(err, ErrNoWorkers) {
+			return signupRes{}, InternalServerError("no workers")
+		}
+		return signupRes{JobID: id}, err
+	}))
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/signup", strings.NewReader(`{"email":"a@example.com"}`))
+	req.Header.Set("Content-Type", "application/json")
+	app.ServeHTTP(w, req)
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("expected 500 without workers, got %d", w.Code)
+	}
+}
+
+func TestWorkersRetryAndFailure(t *testing.T) {
+	var attempts atomic.Int32
+	failed := make(chan Job, 2)
+	w := NewWorkers(nil,
+		WithConcurrency(1),
+		WithJobRetries(3, time.Millisecond),
+		WithJobFailureHandler(func(job Job, err error) { failed <- job }),
+	)
+	w.Handle("flaky", func(ctx context.Context, job Job) error {
+		if attempts.Add(1) < 3 {
+			return errors.New("try again")
+		}
+		return nil
+	})
+	w.Handle("broken", func(ctx context.Context, job Job) error { panic("boom") })
+	w.Start()
+
+	ctx := context.Background()
+	for _, jobType := range []string{"flaky", "broken", "unknown"} {
+		if _, err := w.Enqueue(ctx, jobType, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Shutdown(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if attempts.Load() != 3 {
+		t.Fatalf("expected the flaky job to succeed on its third attempt, got %d attempts", attempts.Load())
+	}
+	close(failed)
+	var types []string
+	for job := range failed {
+		types = append(types, job.Type)
+		if job.Type == "broken" && job.Attempts != 3 {
+			t.Fatalf("expected 3 attempts for the broken job, got %d", job.Attempts)
+		}
+	}
+	if len(types) != 2 || types[0] != "broken" || types[1] != "unknown" {
+		t.Fatalf("unexpected failures %v", types)
+	}
+}
+
+func TestWorkersShutdownDeadlineCancelsJobs(t *testing.T) {
+	w := NewWorkers(nil, WithConcurrency(1))
+	started := make(chan struct{})
+	w.Handle("slow", func(ctx context.Context, job Job) error {
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	w.Start()
+	if _, err := w.Enqueue(context.Background(), "slow", nil); err != nil {
+		t.Fatal(err)
+	}
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := w.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the deadline error, got %v", err)
+	}
+}